@@ -0,0 +1,310 @@
+package dd
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Defaults of AdaptiveConfig.
+const (
+	defaultAdaptiveInterval     = time.Second
+	defaultAdaptiveRecoverAfter = 3
+	defaultAdaptiveMaxFactor    = 64
+)
+
+// AdaptiveConfig makes the logger sample more aggressively while its
+// writers are slow or backed up, and relax again once they recover.
+// Static sampling settings are tuned for normal traffic and are wrong
+// during an incident; the adaptive controller reacts to what the log
+// pipeline can actually absorb.
+//
+// Every Interval the controller measures the mean write latency of each
+// writer (the slowest one counts) and the total queue depth of writers
+// implementing QueuedWriter. While either is above its limit it moves one
+// step up a ladder: keep 1 in 2 entries, 1 in 4, ... up to 1 in MaxFactor,
+// then drop whole levels up to MaxLevel. Once both are at most half their
+// limits for RecoverAfter intervals in a row it moves one step back down.
+// Each change is logged as a WARN entry and counted in AdaptiveStats.
+//
+// ERROR and FATAL entries are never dropped by the controller. It works on
+// top of Sampling and RateLimit, which still apply.
+//
+// Example:
+//
+//	cfg.Adaptive = &dd.AdaptiveConfig{
+//	    MaxLatency:    5 * time.Millisecond,
+//	    MaxQueueDepth: 5000,
+//	    MaxLevel:      dd.LevelWarn,
+//	}
+type AdaptiveConfig struct {
+	// Interval is how often the controller measures the writers.
+	// Zero uses 1s.
+	Interval time.Duration
+
+	// MaxLatency is the mean write latency above which the writers are
+	// under pressure. Zero ignores latency.
+	MaxLatency time.Duration
+
+	// MaxQueueDepth is the total QueuedWriter queue depth above which the
+	// writers are under pressure. Zero ignores queue depth.
+	MaxQueueDepth int
+
+	// RecoverAfter is the number of calm intervals in a row before the
+	// controller relaxes one step. Zero uses 3.
+	RecoverAfter int
+
+	// MaxFactor is the most aggressive sampling: keep 1 in MaxFactor
+	// entries. Zero uses 64; 1 disables sampling steps.
+	MaxFactor int
+
+	// MaxLevel is the highest minimum level the controller may impose
+	// after reaching MaxFactor, at most LevelWarn. LevelDebug (the zero
+	// value) never drops whole levels.
+	MaxLevel LogLevel
+}
+
+// Clone returns a copy of the config.
+func (c *AdaptiveConfig) Clone() *AdaptiveConfig {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	return &clone
+}
+
+// validate checks the limits and MaxLevel.
+func (c *AdaptiveConfig) validate() error {
+	if c.Interval < 0 || c.MaxLatency < 0 || c.MaxQueueDepth < 0 || c.RecoverAfter < 0 || c.MaxFactor < 0 {
+		return fmt.Errorf("%w: Adaptive settings must not be negative", ErrConfigValidation)
+	}
+	if c.MaxLatency == 0 && c.MaxQueueDepth == 0 {
+		return fmt.Errorf("%w: Adaptive needs MaxLatency or MaxQueueDepth", ErrConfigValidation)
+	}
+	if c.MaxLevel < LevelDebug || c.MaxLevel > LevelWarn {
+		return fmt.Errorf("%w: Adaptive MaxLevel %s must be between DEBUG and WARN", ErrInvalidLevel, c.MaxLevel)
+	}
+	return nil
+}
+
+// withDefaults returns a copy of c with zero values replaced by defaults.
+func (c *AdaptiveConfig) withDefaults() AdaptiveConfig {
+	config := *c
+	if config.Interval == 0 {
+		config.Interval = defaultAdaptiveInterval
+	}
+	if config.RecoverAfter == 0 {
+		config.RecoverAfter = defaultAdaptiveRecoverAfter
+	}
+	if config.MaxFactor == 0 {
+		config.MaxFactor = defaultAdaptiveMaxFactor
+	}
+	return config
+}
+
+// QueuedWriter is implemented by writers that buffer entries for a
+// background sender, such as LokiWriter. AdaptiveConfig.MaxQueueDepth
+// watches the sum of their queue depths.
+type QueuedWriter interface {
+	// QueueDepth returns the number of entries waiting to be sent.
+	QueueDepth() int
+}
+
+// AdaptiveStats reports the state of the adaptive controller.
+type AdaptiveStats struct {
+	Factor      int      // Current sampling: keep 1 in Factor entries
+	MinLevel    LogLevel // Current minimum level imposed by the controller
+	Escalations int64    // Steps up since the logger was created
+	Relaxations int64    // Steps down since the logger was created
+	Dropped     int64    // Entries dropped by the controller
+
+	Latency    time.Duration // Mean write latency of the slowest writer in the last interval
+	QueueDepth int           // Total QueuedWriter depth at the last measurement
+}
+
+// adaptiveMode is one step of the controller's ladder.
+type adaptiveMode struct {
+	factor   int
+	minLevel LogLevel
+}
+
+// adaptiveState is the runtime state of an AdaptiveConfig.
+type adaptiveState struct {
+	config AdaptiveConfig
+	modes  []adaptiveMode // modes[0] keeps everything
+
+	mode    atomic.Int32
+	counter atomic.Uint64
+
+	dropped     atomic.Int64
+	escalations atomic.Int64
+	relaxations atomic.Int64
+	latency     atomic.Int64
+	queueDepth  atomic.Int64
+
+	// Only used by the controller goroutine.
+	calm int
+	last map[*writerStats]latencyMark
+}
+
+// latencyMark is a writer's counters at the previous measurement.
+type latencyMark struct {
+	writes  int64
+	latency int64
+}
+
+func newAdaptiveState(config *AdaptiveConfig) *adaptiveState {
+	state := &adaptiveState{
+		config: config.withDefaults(),
+		modes:  []adaptiveMode{{factor: 1, minLevel: LevelDebug}},
+		last:   make(map[*writerStats]latencyMark),
+	}
+	for factor := 2; ; factor *= 2 {
+		factor = min(factor, state.config.MaxFactor)
+		if factor <= 1 {
+			break
+		}
+		state.modes = append(state.modes, adaptiveMode{factor: factor, minLevel: LevelDebug})
+		if factor == state.config.MaxFactor {
+			break
+		}
+	}
+	top := state.modes[len(state.modes)-1]
+	for level := LevelInfo; level <= state.config.MaxLevel; level++ {
+		state.modes = append(state.modes, adaptiveMode{factor: top.factor, minLevel: level})
+	}
+	return state
+}
+
+// allow reports whether the current mode keeps an entry at level,
+// counting it if not.
+func (a *adaptiveState) allow(level LogLevel) bool {
+	if level >= LevelError {
+		return true
+	}
+	mode := a.modes[a.mode.Load()]
+	if level < mode.minLevel || (mode.factor > 1 && a.counter.Add(1)%uint64(mode.factor) != 0) {
+		a.dropped.Add(1)
+		return false
+	}
+	return true
+}
+
+// step moves the controller according to one measurement and returns the
+// change of mode index: 1, -1 or 0.
+func (a *adaptiveState) step(latency time.Duration, queueDepth int) int {
+	a.latency.Store(int64(latency))
+	a.queueDepth.Store(int64(queueDepth))
+
+	maxLatency, maxDepth := a.config.MaxLatency, a.config.MaxQueueDepth
+	pressure := (maxLatency > 0 && latency > maxLatency) || (maxDepth > 0 && queueDepth > maxDepth)
+	calm := (maxLatency == 0 || latency <= maxLatency/2) && (maxDepth == 0 || queueDepth <= maxDepth/2)
+
+	mode := int(a.mode.Load())
+	switch {
+	case pressure:
+		a.calm = 0
+		if mode < len(a.modes)-1 {
+			a.mode.Store(int32(mode + 1))
+			a.escalations.Add(1)
+			return 1
+		}
+	case calm:
+		a.calm++
+		if mode > 0 && a.calm >= a.config.RecoverAfter {
+			a.calm = 0
+			a.mode.Store(int32(mode - 1))
+			a.relaxations.Add(1)
+			return -1
+		}
+	default:
+		a.calm = 0
+	}
+	return 0
+}
+
+// allowAdaptive reports whether the adaptive controller keeps an entry at
+// level.
+func (l *Logger) allowAdaptive(level LogLevel) bool {
+	return l.adaptive == nil || l.adaptive.allow(level)
+}
+
+// runAdaptive evaluates the controller every Interval until the logger
+// closes.
+func (l *Logger) runAdaptive() {
+	ticker := time.NewTicker(l.adaptive.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case <-ticker.C:
+			l.evaluateAdaptive()
+		}
+	}
+}
+
+// evaluateAdaptive measures the writers, steps the controller and logs a
+// change of mode.
+func (l *Logger) evaluateAdaptive() {
+	a := l.adaptive
+	var latency time.Duration
+	queueDepth := 0
+	if set := l.writerStats.Load(); set != nil {
+		seen := make(map[*writerStats]latencyMark, len(set.stats))
+		for _, s := range set.stats {
+			mark := latencyMark{writes: s.writes.Load(), latency: s.latencyTotal.Load()}
+			seen[s] = mark
+			prev := a.last[s]
+			if writes, total := mark.writes-prev.writes, mark.latency-prev.latency; writes > 0 && total >= 0 {
+				latency = max(latency, time.Duration(total/writes))
+			}
+			if qw, ok := unwrapMiddleware(s.writer).(QueuedWriter); ok {
+				queueDepth += qw.QueueDepth()
+			}
+		}
+		a.last = seen
+	}
+
+	change := a.step(latency, queueDepth)
+	if change == 0 || l.closed.Load() || !l.IsLevelEnabled(LevelWarn) {
+		return
+	}
+	mode := a.modes[a.mode.Load()]
+	msg := "adaptive sampling escalated"
+	if change < 0 {
+		msg = "adaptive sampling relaxed"
+	}
+	l.logCore(LevelWarn, logEntry{msg: msg, fields: []Field{
+		Int("sample_factor", mode.factor),
+		String("min_level", mode.minLevel.String()),
+		Duration("write_latency", latency),
+		Int("queue_depth", queueDepth),
+	}})
+}
+
+// AdaptiveStats returns the current mode and counters of the adaptive
+// controller, or zero stats if Config.Adaptive is not set.
+//
+// Example:
+//
+//	if s := logger.AdaptiveStats(); s.Factor > 1 {
+//	    metrics.Gauge("log.adaptive_factor", float64(s.Factor))
+//	}
+func (l *Logger) AdaptiveStats() AdaptiveStats {
+	if l == nil || l.adaptive == nil {
+		return AdaptiveStats{}
+	}
+	a := l.adaptive
+	mode := a.modes[a.mode.Load()]
+	return AdaptiveStats{
+		Factor:      mode.factor,
+		MinLevel:    mode.minLevel,
+		Escalations: a.escalations.Load(),
+		Relaxations: a.relaxations.Load(),
+		Dropped:     a.dropped.Load(),
+		Latency:     time.Duration(a.latency.Load()),
+		QueueDepth:  int(a.queueDepth.Load()),
+	}
+}
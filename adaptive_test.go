@@ -0,0 +1,146 @@
+package dd
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// delayWriter delays every write by a settable duration.
+type delayWriter struct {
+	mu    sync.Mutex
+	delay time.Duration
+	buf   strings.Builder
+}
+
+func (w *delayWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	time.Sleep(w.delay)
+	return w.buf.Write(p)
+}
+
+func (w *delayWriter) setDelay(d time.Duration) {
+	w.mu.Lock()
+	w.delay = d
+	w.mu.Unlock()
+}
+
+func (w *delayWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestAdaptiveEscalatesAndRelaxes(t *testing.T) {
+	w := &delayWriter{delay: 2 * time.Millisecond}
+	cfg := DefaultConfig()
+	cfg.Output = w
+	cfg.Adaptive = &AdaptiveConfig{
+		Interval:     time.Hour, // evaluated by hand
+		MaxLatency:   time.Millisecond,
+		RecoverAfter: 2,
+		MaxFactor:    4,
+	}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Info("slow write")
+	logger.evaluateAdaptive()
+	if s := logger.AdaptiveStats(); s.Factor != 2 || s.Escalations != 1 || s.Latency < time.Millisecond {
+		t.Fatalf("after one slow interval: %+v", s)
+	}
+	if !strings.Contains(w.String(), "adaptive sampling escalated") || !strings.Contains(w.String(), "sample_factor=2") {
+		t.Errorf("no escalation event: %q", w.String())
+	}
+
+	w.setDelay(0)
+	for i := 0; i < 10; i++ {
+		logger.Info("sampled")
+		logger.Error("kept")
+	}
+	if n := strings.Count(w.String(), "kept"); n != 10 {
+		t.Errorf("%d ERROR entries written, want 10", n)
+	}
+	if n := strings.Count(w.String(), "sampled"); n != 5 {
+		t.Errorf("%d INFO entries written at factor 2, want 5", n)
+	}
+
+	logger.evaluateAdaptive() // calm 1
+	if s := logger.AdaptiveStats(); s.Factor != 2 {
+		t.Fatalf("relaxed before RecoverAfter: %+v", s)
+	}
+	// The escalation entry was written slowly, so the first interval may
+	// not count as calm; intervals without writes are.
+	for i := 0; i < 3 && logger.AdaptiveStats().Relaxations == 0; i++ {
+		logger.evaluateAdaptive()
+	}
+	s := logger.AdaptiveStats()
+	if s.Factor != 1 || s.Relaxations != 1 || s.Dropped != 5 {
+		t.Errorf("after recovery: %+v", s)
+	}
+	if !strings.Contains(w.String(), "adaptive sampling relaxed") {
+		t.Errorf("no relax event: %q", w.String())
+	}
+}
+
+func TestAdaptiveLadderAndQueueDepth(t *testing.T) {
+	state := newAdaptiveState(&AdaptiveConfig{MaxQueueDepth: 100, MaxFactor: 3, MaxLevel: LevelWarn})
+	want := []adaptiveMode{{1, LevelDebug}, {2, LevelDebug}, {3, LevelDebug}, {3, LevelInfo}, {3, LevelWarn}}
+	if len(state.modes) != len(want) {
+		t.Fatalf("modes = %v", state.modes)
+	}
+	for i := range want {
+		if state.modes[i] != want[i] {
+			t.Errorf("modes[%d] = %v, want %v", i, state.modes[i], want[i])
+		}
+	}
+
+	for i := 0; i < 10; i++ {
+		state.step(0, 500)
+	}
+	if got := state.mode.Load(); int(got) != len(want)-1 {
+		t.Fatalf("mode = %d after sustained pressure", got)
+	}
+	if state.allow(LevelInfo) || !state.allow(LevelError) {
+		t.Error("top mode should drop INFO and keep ERROR")
+	}
+	if state.step(0, 80) != 0 || state.calm != 0 {
+		t.Error("depth between half and the limit should hold the mode")
+	}
+	for i := 0; i < defaultAdaptiveRecoverAfter; i++ {
+		state.step(0, 10)
+	}
+	if got := state.mode.Load(); int(got) != len(want)-2 {
+		t.Errorf("mode = %d after recovery, want %d", got, len(want)-2)
+	}
+}
+
+func TestAdaptiveConfigValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config AdaptiveConfig
+		want   error
+	}{
+		{"no signal", AdaptiveConfig{}, ErrConfigValidation},
+		{"negative", AdaptiveConfig{MaxLatency: -1}, ErrConfigValidation},
+		{"level too high", AdaptiveConfig{MaxQueueDepth: 10, MaxLevel: LevelError}, ErrInvalidLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Adaptive = &tt.config
+			if _, err := New(cfg); !errors.Is(err, tt.want) {
+				t.Errorf("New = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if got := (&Logger{}).AdaptiveStats(); got != (AdaptiveStats{}) {
+		t.Errorf("disabled stats = %+v", got)
+	}
+}
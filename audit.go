@@ -317,7 +317,7 @@ func (al *AuditLogger) writeEvent(event AuditEvent) {
 	if al.config.JSONFormat {
 		data, err := json.Marshal(event)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "dd: failed to marshal audit event: %v\n", err)
+			reportInternal(nil, ComponentAudit, err, "failed to marshal audit event: %v", err)
 			return
 		}
 		output = string(data)
@@ -0,0 +1,186 @@
+package dd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// backupTimeLayout is how BackupTime prints in templates.
+const backupTimeLayout = "20060102T150405"
+
+// maxBackupNameAttempts bounds the search for an unused backup name.
+const maxBackupNameAttempts = 1000
+
+// BackupTime is the rotation time passed to backup naming. It prints as
+// 20060102T150405 so templates can use {{.Time}} directly; the embedded
+// time.Time allows {{.Time.Format "2006-01-02"}}.
+type BackupTime struct {
+	time.Time
+}
+
+// String formats the time as 20060102T150405.
+func (t BackupTime) String() string {
+	return t.Format(backupTimeLayout)
+}
+
+// BackupNameInfo describes a rotation for BackupNameFunc and
+// BackupNameTemplate.
+type BackupNameInfo struct {
+	Path string     // Active log file path
+	Dir  string     // Directory of the log file
+	Base string     // File name without extension, e.g. "app"
+	Ext  string     // Extension including the dot, e.g. ".log"
+	Time BackupTime // Rotation time
+	Seq  int        // Sequence number, starting at 1
+}
+
+// initBackupNaming configures custom backup naming from config.
+func (fw *FileWriter) initBackupNaming(config FileWriterConfig) error {
+	switch {
+	case config.BackupNameFunc != nil:
+		fw.backupName = config.BackupNameFunc
+		fw.backupGlob = config.BackupGlob
+	case config.BackupNameTemplate != "":
+		tmpl, err := template.New("backup").Option("missingkey=error").Parse(config.BackupNameTemplate)
+		if err != nil {
+			return fmt.Errorf("%w: BackupNameTemplate: %w", ErrConfigValidation, err)
+		}
+		fw.backupName = func(info BackupNameInfo) string {
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, info); err != nil {
+				return ""
+			}
+			return buf.String()
+		}
+		// Retention must only match this log's backups, so the names must
+		// carry the log file name rather than just the time or sequence.
+		base := filepath.Base(fw.path)
+		ext := filepath.Ext(base)
+		fields := map[string]string{".Base": strings.TrimSuffix(base, ext), ".Ext": ext}
+		anchor := fields[".Base"]
+		if anchor == "" {
+			anchor = base
+		}
+		if !internal.TemplateHasLiteral(config.BackupNameTemplate, fields, anchor) {
+			return fmt.Errorf("%w: BackupNameTemplate %q must include the log file name (e.g. {{.Base}}) so retention cannot match other files", ErrConfigValidation, config.BackupNameTemplate)
+		}
+		fw.backupGlob = internal.TemplateToGlob(config.BackupNameTemplate, fields)
+	default:
+		return nil
+	}
+
+	if fw.backupGlob != "" {
+		if _, err := filepath.Match(fw.backupGlob, ""); err != nil {
+			return fmt.Errorf("%w: BackupGlob: %w", ErrConfigValidation, err)
+		}
+		fw.backupSeq = len(fw.listBackups())
+	}
+	return nil
+}
+
+// nextBackupPath returns the path the active file is renamed to on rotation.
+// Caller must hold fw.mu.
+func (fw *FileWriter) nextBackupPath(now time.Time) string {
+	if fw.backupName != nil {
+		if path, ok := fw.customBackupPath(now); ok {
+			return path
+		}
+	}
+	// Count backups in any form, including ones waiting to be compressed
+	nextIndex := internal.FindNextBackupIndex(fw.path, false)
+	return internal.GetBackupPath(fw.path, nextIndex, false)
+}
+
+// customBackupPath asks the naming function for an unused name in the log
+// directory, bumping Seq on collisions. Invalid names fall back to the
+// default scheme with a diagnostic.
+func (fw *FileWriter) customBackupPath(now time.Time) (string, bool) {
+	dir := filepath.Dir(fw.path)
+	base := filepath.Base(fw.path)
+	ext := filepath.Ext(base)
+	info := BackupNameInfo{
+		Path: fw.path,
+		Dir:  dir,
+		Base: strings.TrimSuffix(base, ext),
+		Ext:  ext,
+		Time: BackupTime{now},
+	}
+
+	for range maxBackupNameAttempts {
+		fw.backupSeq++
+		info.Seq = fw.backupSeq
+
+		name := fw.backupName(info)
+		if name == "" || name != filepath.Base(name) || name == base || name == "." || name == ".." {
+			reportInternal(nil, ComponentWriter, nil, "invalid backup name %q for %s, using default naming", name, fw.path)
+			return "", false
+		}
+
+		path := filepath.Join(dir, name)
+		if !backupExists(path) {
+			return path, true
+		}
+	}
+
+	reportInternal(nil, ComponentWriter, nil, "no unused backup name for %s, using default naming", fw.path)
+	return "", false
+}
+
+// listBackups returns existing backups, oldest first.
+func (fw *FileWriter) listBackups() []string {
+	if fw.backupName == nil {
+		return internal.ListBackups(fw.path)
+	}
+	if fw.backupGlob == "" {
+		return nil
+	}
+	return internal.ListBackupsGlob(filepath.Dir(fw.path), fw.backupGlob, filepath.Base(fw.path), compressedExtensions()...)
+}
+
+// applyRetention enforces MaxBackups after a rotation.
+func (fw *FileWriter) applyRetention() {
+	if fw.backupName == nil {
+		internal.RotateBackups(fw.path, fw.maxBackups, false)
+		return
+	}
+	if fw.backupGlob != "" {
+		// Best-effort, like the default scheme
+		_ = internal.CleanupBackupsGlob(filepath.Dir(fw.path), fw.backupGlob, filepath.Base(fw.path), fw.maxBackups, 0, compressedExtensions()...)
+	}
+}
+
+// cleanupOldBackups enforces MaxAge.
+func (fw *FileWriter) cleanupOldBackups() error {
+	if fw.backupName == nil {
+		return internal.CleanupOldFiles(fw.path, fw.maxAge)
+	}
+	if fw.backupGlob == "" {
+		return nil
+	}
+	return internal.CleanupBackupsGlob(filepath.Dir(fw.path), fw.backupGlob, filepath.Base(fw.path), 0, fw.maxAge, compressedExtensions()...)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// backupExists reports whether path exists, plain or compressed.
+func backupExists(path string) bool {
+	if fileExists(path) {
+		return true
+	}
+	for _, ext := range compressedExtensions() {
+		if fileExists(path + ext) {
+			return true
+		}
+	}
+	return false
+}
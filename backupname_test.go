@@ -0,0 +1,189 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// forceRotation writes enough data to a 1MB FileWriter to rotate it once.
+func forceRotation(t *testing.T, fw *FileWriter) {
+	t.Helper()
+	chunk := bytes.Repeat([]byte("x"), 600*1024)
+	for range 2 {
+		if _, err := fw.Write(chunk); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+}
+
+func TestFileWriterBackupNameTemplate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	var mu sync.Mutex
+	var rotated []string
+	fw, err := NewFileWriter(path, FileWriterConfig{
+		MaxSizeMB:          1,
+		MaxBackups:         5,
+		BackupNameTemplate: "{{.Base}}-{{.Time}}-{{.Seq}}{{.Ext}}",
+		OnRotate: func(backupPath string) {
+			mu.Lock()
+			rotated = append(rotated, backupPath)
+			mu.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFileWriter() error = %v", err)
+	}
+
+	forceRotation(t, fw)
+	if err := fw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(rotated) != 1 {
+		t.Fatalf("OnRotate called %d times, want 1", len(rotated))
+	}
+	name := filepath.Base(rotated[0])
+	if !regexp.MustCompile(`^app-\d{8}T\d{6}-1\.log$`).MatchString(name) {
+		t.Errorf("unexpected backup name %q", name)
+	}
+	if _, err := os.Stat(rotated[0]); err != nil {
+		t.Errorf("backup file missing: %v", err)
+	}
+}
+
+func TestFileWriterBackupNameFuncCompressed(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	done := make(chan string, 1)
+	fw, err := NewFileWriter(path, FileWriterConfig{
+		MaxSizeMB: 1,
+		Compress:  true,
+		BackupNameFunc: func(info BackupNameInfo) string {
+			return "rotated-" + info.Time.Format("2006") + info.Ext
+		},
+		OnRotate: func(backupPath string) { done <- backupPath },
+	})
+	if err != nil {
+		t.Fatalf("NewFileWriter() error = %v", err)
+	}
+	defer fw.Close()
+
+	forceRotation(t, fw)
+
+	select {
+	case got := <-done:
+		want := filepath.Join(dir, "rotated-"+time.Now().Format("2006")+".log.gz")
+		if got != want {
+			t.Errorf("OnRotate path = %q, want %q", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnRotate was not called")
+	}
+}
+
+func TestFileWriterBackupNameRetention(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	var rotations atomic.Int32
+	fw, err := NewFileWriter(path, FileWriterConfig{
+		MaxSizeMB:          1,
+		MaxBackups:         1,
+		BackupNameTemplate: "app-{{.Seq}}.log",
+		OnRotate:           func(string) { rotations.Add(1) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fw.Close()
+
+	for range 3 {
+		forceRotation(t, fw)
+		time.Sleep(10 * time.Millisecond) // distinct mod times
+	}
+
+	backups := fw.listBackups()
+	if len(backups) != 1 {
+		t.Fatalf("expected 1 retained backup, got %v", backups)
+	}
+	want := fmt.Sprintf("app-%d.log", rotations.Load())
+	if filepath.Base(backups[0]) != want {
+		t.Errorf("expected newest backup to be retained, got %v", backups)
+	}
+}
+
+func TestFileWriterBackupNameRetentionKeepsOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	others := []string{"config.yaml", "db.sqlite", "other.1.log"}
+	for _, name := range others {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("keep"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fw, err := NewFileWriter(path, FileWriterConfig{
+		MaxSizeMB:          1,
+		MaxBackups:         1,
+		BackupNameTemplate: "{{.Base}}.{{.Seq}}{{.Ext}}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fw.Close()
+
+	for range 3 {
+		forceRotation(t, fw)
+		time.Sleep(10 * time.Millisecond) // distinct mod times
+	}
+
+	for _, name := range others {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("retention removed %s: %v", name, err)
+		}
+	}
+	if backups := fw.listBackups(); len(backups) != 1 || !regexp.MustCompile(`^app\.\d+\.log$`).MatchString(filepath.Base(backups[0])) {
+		t.Errorf("backups = %v, want one app.N.log", backups)
+	}
+}
+
+func TestFileWriterBackupNameTemplateInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if _, err := NewFileWriter(path, FileWriterConfig{BackupNameTemplate: "{{.Time}}.{{.Seq}}{{.Ext}}"}); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("template without the log name: err = %v", err)
+	}
+	if _, err := NewFileWriter(path, FileWriterConfig{BackupNameTemplate: "{{.Seq"}); err == nil {
+		t.Error("expected error for invalid template")
+	}
+}
+
+func TestTemplateBackupPathFallback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	fw, err := NewFileWriter(path, FileWriterConfig{
+		BackupNameFunc: func(BackupNameInfo) string { return "../escape.log" },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fw.Close()
+
+	fw.mu.Lock()
+	got := fw.nextBackupPath(time.Now())
+	fw.mu.Unlock()
+	if filepath.Base(got) != "app_log_1.log" {
+		t.Errorf("expected default naming fallback, got %q", got)
+	}
+}
@@ -489,6 +489,29 @@ func BenchmarkSecureFilter(b *testing.B) {
 	}
 }
 
+// BenchmarkFilterLiteralPrefilter measures the literal pre-filter on an
+// uncached message that only a few patterns can match.
+func BenchmarkFilterLiteralPrefilter(b *testing.B) {
+	message := "GET /api/v1/orders?page=2 status=200 duration=35ms user_agent=Mozilla/5.0 " +
+		"handled by worker 7 after retry, password=secret123 redacted upstream"
+
+	b.Run("Prefilter", func(b *testing.B) {
+		filter := NewSensitiveDataFilter()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = filter.Filter(message)
+		}
+	})
+	b.Run("FullScan", func(b *testing.B) {
+		filter := NewSensitiveDataFilter()
+		filter.literalsPtr.Store(nil)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = filter.Filter(message)
+		}
+	})
+}
+
 // ============================================================================
 // MESSAGE SIZE BENCHMARKS
 // ============================================================================
@@ -0,0 +1,322 @@
+// Package benchmarks runs standardized logging workloads against dd
+// configurations and reports structured results, so applications can
+// compare configurations and catch performance regressions in their own CI
+// without copying dd's benchmark files.
+//
+// Example:
+//
+//	results, err := benchmarks.RunSuite(benchmarks.Suite{
+//	    Configs:   benchmarks.StandardConfigs(),
+//	    Workloads: benchmarks.StandardWorkloads(),
+//	})
+//	if err != nil { ... }
+//	if regs := benchmarks.Compare(baseline, results, 0.15); len(regs) > 0 {
+//	    for _, r := range regs {
+//	        fmt.Println(r)
+//	    }
+//	    os.Exit(1)
+//	}
+package benchmarks
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cybergodev/dd"
+)
+
+// defaultDuration is how long each workload runs when Suite.Duration is
+// not set.
+const defaultDuration = time.Second
+
+// Workload describes the entries a benchmark logs.
+type Workload struct {
+	Name        string
+	MessageSize int  // message length in bytes
+	Fields      int  // number of fields per entry
+	Concurrency int  // goroutines logging at once (default: 1)
+	Sensitive   bool // put a credential in the message so filters have work to do
+}
+
+// Config is a named logger configuration under test. New returns a fresh
+// Config for each workload; its outputs are replaced with io.Discard.
+type Config struct {
+	Name string
+	New  func() *dd.Config
+}
+
+// Suite is a set of configurations and the workloads to run each of them
+// with.
+type Suite struct {
+	Configs   []Config
+	Workloads []Workload
+	// Duration is the minimum time each configuration and workload pair
+	// runs for (default: 1s).
+	Duration time.Duration
+}
+
+// Result is the measurement of one configuration and workload pair. It is
+// JSON-tagged so results can be stored as a baseline for Compare.
+type Result struct {
+	Config      string  `json:"config"`
+	Workload    string  `json:"workload"`
+	Ops         int     `json:"ops"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	AllocsPerOp float64 `json:"allocs_per_op"`
+	BytesPerOp  float64 `json:"bytes_per_op"`
+}
+
+// String formats r like a go test benchmark line.
+func (r Result) String() string {
+	return fmt.Sprintf("%s/%s\t%d\t%.1f ns/op\t%.0f B/op\t%.1f allocs/op",
+		r.Config, r.Workload, r.Ops, r.NsPerOp, r.BytesPerOp, r.AllocsPerOp)
+}
+
+// StandardWorkloads returns workloads covering small and large messages,
+// field counts from none to many, parallel logging and filter load.
+func StandardWorkloads() []Workload {
+	return []Workload{
+		{Name: "small", MessageSize: 32},
+		{Name: "large", MessageSize: 1024},
+		{Name: "fields-5", MessageSize: 32, Fields: 5},
+		{Name: "fields-20", MessageSize: 32, Fields: 20},
+		{Name: "parallel-8", MessageSize: 64, Fields: 5, Concurrency: 8},
+		{Name: "sensitive", MessageSize: 128, Fields: 5, Sensitive: true},
+	}
+}
+
+// StandardConfigs returns text and JSON configurations without filtering,
+// with the basic filter (the default) and with the full filter.
+func StandardConfigs() []Config {
+	withSecurity := func(newConfig func() *dd.Config, security func() *dd.SecurityConfig) func() *dd.Config {
+		return func() *dd.Config {
+			cfg := newConfig()
+			cfg.Security = security()
+			return cfg
+		}
+	}
+	noFilter := func() *dd.SecurityConfig {
+		return dd.SecurityConfigForLevel(dd.SecurityLevelDevelopment)
+	}
+	jsonConfig := func() *dd.Config {
+		cfg := dd.JSONConfig()
+		cfg.Level = dd.LevelInfo
+		return cfg
+	}
+	return []Config{
+		{Name: "text", New: withSecurity(dd.DefaultConfig, noFilter)},
+		{Name: "text-basic-filter", New: dd.DefaultConfig},
+		{Name: "text-full-filter", New: withSecurity(dd.DefaultConfig, dd.DefaultSecureConfig)},
+		{Name: "json", New: withSecurity(jsonConfig, noFilter)},
+		{Name: "json-basic-filter", New: jsonConfig},
+		{Name: "json-full-filter", New: withSecurity(jsonConfig, dd.DefaultSecureConfig)},
+	}
+}
+
+// Message returns a message of size bytes. With sensitive set it contains
+// a password assignment that the built-in filters redact.
+func Message(size int, sensitive bool) string {
+	const words = "request handled for user account with status ok "
+	var b strings.Builder
+	b.Grow(size)
+	if sensitive {
+		b.WriteString("login password=hunter2 ")
+	}
+	for b.Len() < size {
+		b.WriteString(words[:min(len(words), size-b.Len())])
+	}
+	return b.String()
+}
+
+// Fields returns n fields of mixed types.
+func Fields(n int) []dd.Field {
+	fields := make([]dd.Field, n)
+	for i := range fields {
+		key := fmt.Sprintf("field_%d", i)
+		switch i % 4 {
+		case 0:
+			fields[i] = dd.String(key, "value")
+		case 1:
+			fields[i] = dd.Int(key, i)
+		case 2:
+			fields[i] = dd.Bool(key, true)
+		default:
+			fields[i] = dd.Duration(key, time.Duration(i)*time.Millisecond)
+		}
+	}
+	return fields
+}
+
+// RunSuite runs every workload against every configuration and returns
+// the results in that order.
+func RunSuite(suite Suite) ([]Result, error) {
+	if len(suite.Configs) == 0 || len(suite.Workloads) == 0 {
+		return nil, errors.New("benchmarks: suite needs at least one config and one workload")
+	}
+	duration := suite.Duration
+	if duration <= 0 {
+		duration = defaultDuration
+	}
+
+	results := make([]Result, 0, len(suite.Configs)*len(suite.Workloads))
+	for _, c := range suite.Configs {
+		for _, w := range suite.Workloads {
+			result, err := Run(c, w, duration)
+			if err != nil {
+				return results, err
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+// Run measures one workload against one configuration for at least
+// duration.
+func Run(c Config, w Workload, duration time.Duration) (Result, error) {
+	if c.New == nil {
+		return Result{}, fmt.Errorf("benchmarks: config %q has no New function", c.Name)
+	}
+	cfg := c.New()
+	cfg.Output = io.Discard
+	cfg.Outputs = nil
+	cfg.File = nil
+	cfg.LevelOutputs = nil
+	logger, err := dd.New(cfg)
+	if err != nil {
+		return Result{}, fmt.Errorf("benchmarks: config %q: %w", c.Name, err)
+	}
+	defer logger.Close()
+
+	msg := Message(w.MessageSize, w.Sensitive)
+	fields := Fields(w.Fields)
+	workers := max(w.Concurrency, 1)
+	logN := func(n int) {
+		var wg sync.WaitGroup
+		for i := range workers {
+			count := n / workers
+			if i < n%workers {
+				count++
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range count {
+					logger.InfoWith(msg, fields...)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	logN(1) // warm up pools and caches
+	n := 1
+	for {
+		result := measure(n, logN)
+		if result.elapsed >= duration || n >= 1e9 {
+			return Result{
+				Config:      c.Name,
+				Workload:    w.Name,
+				Ops:         n,
+				NsPerOp:     float64(result.elapsed.Nanoseconds()) / float64(n),
+				AllocsPerOp: float64(result.allocs) / float64(n),
+				BytesPerOp:  float64(result.bytes) / float64(n),
+			}, nil
+		}
+		// Grow towards the target duration, as the testing package does
+		next := n * 2
+		if result.elapsed > 0 {
+			next = int(float64(n) * 1.2 * float64(duration) / float64(result.elapsed))
+		}
+		n = min(max(next, n+1), 100*n)
+	}
+}
+
+type measurement struct {
+	elapsed time.Duration
+	allocs  uint64
+	bytes   uint64
+}
+
+// measure runs fn(n) and returns its duration and allocations.
+func measure(n int, fn func(int)) measurement {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	fn(n)
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	return measurement{
+		elapsed: elapsed,
+		allocs:  after.Mallocs - before.Mallocs,
+		bytes:   after.TotalAlloc - before.TotalAlloc,
+	}
+}
+
+// Regression is a result that got slower or allocates more than its
+// baseline allows.
+type Regression struct {
+	Baseline Result
+	Current  Result
+	Metric   string  // "ns/op", "allocs/op" or "B/op"
+	Change   float64 // relative change, e.g. 0.25 for 25% worse
+}
+
+// String describes the regression.
+func (r Regression) String() string {
+	return fmt.Sprintf("%s/%s: %s %+.1f%%", r.Current.Config, r.Current.Workload, r.Metric, r.Change*100)
+}
+
+// Compare returns the results in current that are worse than the result
+// for the same configuration and workload in baseline by more than
+// tolerance (0.1 allows 10%). Time, allocations and bytes are checked
+// separately; pairs missing from either side are ignored.
+func Compare(baseline, current []Result, tolerance float64) []Regression {
+	base := make(map[[2]string]Result, len(baseline))
+	for _, r := range baseline {
+		base[[2]string{r.Config, r.Workload}] = r
+	}
+
+	var regressions []Regression
+	for _, cur := range current {
+		old, ok := base[[2]string{cur.Config, cur.Workload}]
+		if !ok {
+			continue
+		}
+		for _, m := range []struct {
+			name     string
+			old, cur float64
+		}{
+			{"ns/op", old.NsPerOp, cur.NsPerOp},
+			{"allocs/op", old.AllocsPerOp, cur.AllocsPerOp},
+			{"B/op", old.BytesPerOp, cur.BytesPerOp},
+		} {
+			if change, worse := relativeChange(m.old, m.cur); worse && change > tolerance {
+				regressions = append(regressions, Regression{Baseline: old, Current: cur, Metric: m.name, Change: change})
+			}
+		}
+	}
+	return regressions
+}
+
+// relativeChange returns (cur-old)/old and whether cur is worse. A rise
+// from zero counts as an infinite change.
+func relativeChange(old, cur float64) (float64, bool) {
+	if cur <= old {
+		return 0, false
+	}
+	if old == 0 {
+		if cur < 0.5 {
+			return 0, false // less than one allocation per two ops is noise
+		}
+		return math.Inf(1), true
+	}
+	return (cur - old) / old, true
+}
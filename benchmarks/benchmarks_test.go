@@ -0,0 +1,82 @@
+package benchmarks
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunSuite(t *testing.T) {
+	workloads := StandardWorkloads()
+	suite := Suite{
+		Configs:   StandardConfigs()[:2],
+		Workloads: []Workload{workloads[0], workloads[4]},
+		Duration:  10 * time.Millisecond,
+	}
+	results, err := RunSuite(suite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+	if results[0].Config != "text" || results[0].Workload != "small" || results[3].Workload != "parallel-8" {
+		t.Errorf("results out of order: %v", results)
+	}
+	for _, r := range results {
+		if r.Ops < 1 || r.NsPerOp <= 0 {
+			t.Errorf("implausible result: %v", r)
+		}
+	}
+	if !strings.Contains(results[0].String(), "text/small\t") {
+		t.Errorf("String() = %q", results[0].String())
+	}
+
+	if _, err := RunSuite(Suite{}); err == nil {
+		t.Error("empty suite should fail")
+	}
+	if _, err := Run(Config{Name: "nil"}, workloads[0], time.Millisecond); err == nil {
+		t.Error("config without New should fail")
+	}
+}
+
+func TestMessageAndFields(t *testing.T) {
+	for _, size := range []int{0, 10, 100, 1000} {
+		if got := len(Message(size, false)); got != size {
+			t.Errorf("Message(%d) has %d bytes", size, got)
+		}
+	}
+	if !strings.Contains(Message(64, true), "password=") {
+		t.Error("sensitive message should contain a credential")
+	}
+	if len(Fields(7)) != 7 {
+		t.Error("Fields(7) should return 7 fields")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := []Result{
+		{Config: "text", Workload: "small", NsPerOp: 100, AllocsPerOp: 1, BytesPerOp: 64},
+		{Config: "json", Workload: "small", NsPerOp: 200, AllocsPerOp: 0, BytesPerOp: 0},
+	}
+	current := []Result{
+		{Config: "text", Workload: "small", NsPerOp: 105, AllocsPerOp: 2, BytesPerOp: 64},
+		{Config: "json", Workload: "small", NsPerOp: 150, AllocsPerOp: 1, BytesPerOp: 0},
+		{Config: "json", Workload: "large", NsPerOp: 999},
+	}
+
+	regs := Compare(baseline, current, 0.1)
+	if len(regs) != 2 {
+		t.Fatalf("expected 2 regressions, got %v", regs)
+	}
+	if regs[0].Metric != "allocs/op" || regs[0].Change != 1 || regs[0].Current.Config != "text" {
+		t.Errorf("unexpected first regression: %+v", regs[0])
+	}
+	if regs[1].Metric != "allocs/op" || !math.IsInf(regs[1].Change, 1) {
+		t.Errorf("allocations rising from zero: %+v", regs[1])
+	}
+	if regs[0].String() != "text/small: allocs/op +100.0%" {
+		t.Errorf("String() = %q", regs[0].String())
+	}
+}
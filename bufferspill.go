@@ -0,0 +1,155 @@
+package dd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// BufferedWriterConfig configures a BufferedWriter.
+type BufferedWriterConfig struct {
+	// BufferSize is the buffer size in bytes (default and minimum: 1KB,
+	// maximum: 10MB).
+	BufferSize int
+
+	// FlushInterval bounds how long an entry can wait in the buffer: a
+	// burst of entries is written with one Write once half the buffer is
+	// used or FlushInterval has passed, whichever is first. Zero uses
+	// 100ms.
+	FlushInterval time.Duration
+
+	// SpillPath, when set, names an append-only file that mirrors the
+	// unflushed part of the buffer. Each Write is also appended to it and
+	// the file is emptied whenever the buffer is flushed, so entries that
+	// were buffered when the process crashed survive there. The next
+	// BufferedWriter created with the same SpillPath writes them to its
+	// writer first; RecoverSpill does the same by hand.
+	//
+	// Writes go to the operating system without fsync: the spill file
+	// survives a process crash, not a power loss. Each Write costs an
+	// extra system call.
+	SpillPath string
+}
+
+// RecoverSpill writes the entries left in the spill file at path by a
+// crashed BufferedWriter to w and removes the file. It returns the number
+// of bytes recovered; a missing file recovers nothing. If writing to w
+// fails the file is kept.
+func RecoverSpill(path string, w io.Writer) (int64, error) {
+	if w == nil {
+		return 0, ErrNilWriter
+	}
+	securePath, err := internal.ValidateAndSecurePath(path, maxPathLength, ErrEmptyFilePath, ErrNullByte, ErrPathTooLong, ErrPathTraversal, ErrInvalidPath)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.Open(securePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("open spill file: %w", err)
+	}
+	n, err := io.Copy(w, f)
+	f.Close()
+	if err != nil {
+		return n, fmt.Errorf("recover spill file: %w", err)
+	}
+	if err := os.Remove(securePath); err != nil {
+		return n, fmt.Errorf("remove spill file: %w", err)
+	}
+	return n, nil
+}
+
+// openSpill recovers the spill file at path into w and opens it afresh.
+func openSpill(path string, w io.Writer) (*os.File, error) {
+	securePath, err := internal.ValidateAndSecurePath(path, maxPathLength, ErrEmptyFilePath, ErrNullByte, ErrPathTooLong, ErrPathTraversal, ErrInvalidPath)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := RecoverSpill(securePath, w); err != nil {
+		return nil, err
+	} else if n > 0 {
+		reportInternal(nil, ComponentWriter, nil, "recovered %d bytes from spill file %s", n, securePath)
+	}
+	if err := os.MkdirAll(filepath.Dir(securePath), dirPermissions); err != nil {
+		return nil, fmt.Errorf("create spill directory: %w", err)
+	}
+	f, _, err := internal.OpenFile(securePath)
+	if err != nil {
+		return nil, fmt.Errorf("spill file: %w", err)
+	}
+	return f, nil
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	*c.n += int64(n)
+	return n, err
+}
+
+// appendSpill mirrors p to the spill file. Called with bw.mu held.
+func (bw *BufferedWriter) appendSpill(p []byte) {
+	if bw.spill == nil {
+		return
+	}
+	if _, err := bw.spill.Write(p); err != nil {
+		bw.dropSpill(err)
+	}
+}
+
+// syncSpill trims the spill file to the data still buffered after a write
+// of p, or a flush if p is nil, that began with flushed bytes passed on.
+// Called with bw.mu held, only after the buffer operations succeeded.
+func (bw *BufferedWriter) syncSpill(p []byte, flushed int64) {
+	if bw.spill == nil {
+		return
+	}
+	buffered := bw.buffer.Buffered()
+	var err error
+	switch {
+	case buffered == 0:
+		err = bw.spill.Truncate(0)
+	case bw.flushed != flushed && buffered <= len(p):
+		// The buffer filled up during the write; what remains is the tail of p
+		if err = bw.spill.Truncate(0); err == nil {
+			_, err = bw.spill.Write(p[len(p)-buffered:])
+		}
+	}
+	if err != nil {
+		bw.dropSpill(err)
+	}
+}
+
+// dropSpill stops mirroring after a spill file error. The file is kept:
+// it still holds at least the entries not yet flushed.
+func (bw *BufferedWriter) dropSpill(err error) {
+	reportInternal(nil, ComponentWriter, err, "spill file %s disabled: %v", bw.spill.Name(), err)
+	bw.spill.Close()
+	bw.spill = nil
+}
+
+// closeSpill closes the spill file and, if everything was flushed,
+// removes it.
+func (bw *BufferedWriter) closeSpill(flushed bool) error {
+	name := bw.spill.Name()
+	err := bw.spill.Close()
+	bw.spill = nil
+	if err == nil && flushed {
+		err = os.Remove(name)
+	}
+	return err
+}
@@ -0,0 +1,152 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newSpillWriter returns a BufferedWriter whose timed flush waits an hour.
+func newSpillWriter(t *testing.T, w *bytes.Buffer, path string, size int) *BufferedWriter {
+	t.Helper()
+	bw, err := NewBufferedWriterWithConfig(w, BufferedWriterConfig{BufferSize: size, SpillPath: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bw.mu.Lock()
+	bw.lastFlush = time.Now().Add(time.Hour)
+	bw.mu.Unlock()
+	return bw
+}
+
+// crash stops bw without flushing, as if the process died.
+func crash(bw *BufferedWriter) {
+	bw.closed.Store(true)
+	bw.cancel()
+	bw.wg.Wait()
+	if bw.spill != nil {
+		bw.spill.Close()
+	}
+}
+
+func readSpill(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestBufferedWriterSpillSurvivesCrash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spill", "app.spill")
+	var out bytes.Buffer
+	bw := newSpillWriter(t, &out, path, 64*1024)
+
+	bw.Write([]byte("one\n"))
+	bw.Write([]byte("two\n"))
+	if out.Len() != 0 {
+		t.Fatalf("nothing should be flushed yet: %q", out.String())
+	}
+	if got := readSpill(t, path); got != "one\ntwo\n" {
+		t.Fatalf("spill file = %q", got)
+	}
+	crash(bw)
+
+	var next bytes.Buffer
+	bw2 := newSpillWriter(t, &next, path, 64*1024)
+	defer bw2.Close()
+	if next.String() != "one\ntwo\n" {
+		t.Errorf("new writer should recover the spilled entries, got %q", next.String())
+	}
+	if got := readSpill(t, path); got != "" {
+		t.Errorf("spill file should start empty, got %q", got)
+	}
+}
+
+func TestBufferedWriterSpillTrimmedOnFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.spill")
+	var out bytes.Buffer
+	bw := newSpillWriter(t, &out, path, 1024)
+
+	bw.Write([]byte(strings.Repeat("a", 300)))
+	tail := strings.Repeat("b", 900)
+	bw.Write([]byte(tail)) // fills the buffer: 1024 bytes flushed, 176 kept
+
+	if out.Len() != 1024 {
+		t.Fatalf("expected one full buffer flushed, got %d bytes", out.Len())
+	}
+	if got := readSpill(t, path); got != tail[len(tail)-176:] {
+		t.Errorf("spill file should hold the 176 buffered bytes, got %d", len(got))
+	}
+
+	if err := bw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := readSpill(t, path); got != "" {
+		t.Errorf("flush should empty the spill file, got %q", got)
+	}
+
+	bw.Write([]byte("last\n"))
+	if err := bw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(out.String(), "last\n") {
+		t.Error("Close should flush")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Close should remove the spill file: %v", err)
+	}
+}
+
+func TestRecoverSpill(t *testing.T) {
+	dir := t.TempDir()
+	var out bytes.Buffer
+
+	if n, err := RecoverSpill(filepath.Join(dir, "missing.spill"), &out); n != 0 || err != nil {
+		t.Errorf("missing file: n=%d err=%v", n, err)
+	}
+
+	path := filepath.Join(dir, "app.spill")
+	if err := os.WriteFile(path, []byte("lost\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	n, err := RecoverSpill(path, &out)
+	if err != nil || n != 5 || out.String() != "lost\n" {
+		t.Errorf("RecoverSpill = %d, %v; out %q", n, err, out.String())
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("recovered file should be removed: %v", err)
+	}
+
+	if _, err := RecoverSpill(path, nil); err != ErrNilWriter {
+		t.Errorf("nil writer: %v", err)
+	}
+}
+
+func TestBufferedWriterFlushInterval(t *testing.T) {
+	var out syncBuffer
+	bw, err := NewBufferedWriterWithConfig(&out, BufferedWriterConfig{BufferSize: 64 * 1024, FlushInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bw.Close()
+	bw.Write([]byte("one\n"))
+	bw.Write([]byte("two\n"))
+
+	deadline := time.Now().Add(2 * time.Second)
+	for out.String() != "one\ntwo\n" && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if out.String() != "one\ntwo\n" {
+		t.Errorf("not flushed after FlushInterval: %q", out.String())
+	}
+
+	if _, err := NewBufferedWriterWithConfig(&out, BufferedWriterConfig{FlushInterval: -1}); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("negative FlushInterval: %v", err)
+	}
+}
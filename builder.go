@@ -3,6 +3,10 @@ package dd
 import (
 	"fmt"
 	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/cybergodev/dd/internal"
 )
@@ -13,19 +17,50 @@ type internalConfig struct {
 	level             LogLevel
 	format            LogFormat
 	timeFormat        string
+	timeLocation      *time.Location
+	timePrecision     TimePrecision
+	timeEpoch         bool
 	includeTime       bool
 	includeLevel      bool
 	fullPath          bool
 	dynamicCaller     bool
+	goroutineID       bool
 	writers           []io.Writer
+	writerMiddleware  []WriterMiddleware
 	json              *JSONOptions
+	text              *TextOptions
+	console           *internal.ConsoleOptions
 	securityConfig    *SecurityConfig
 	fieldValidation   *FieldValidationConfig
+	schema            *LogSchema
 	fatalHandler      FatalHandler
+	fatalPolicy       FatalPolicy
+	fatalTimeout      time.Duration
+	fatalStackDump    bool
+	crashDumpPath     string
 	writeErrorHandler WriteErrorHandler
+	internalHandler   InternalErrorHandler
+	shutdownHooks     []ShutdownHook
 	contextExtractors []ContextExtractor
 	hooks             *HookRegistry
 	sampling          *SamplingConfig
+	rateLimit         *RateLimitConfig
+	adaptive          *AdaptiveConfig
+	events            *EventsConfig
+	maxMemoryBytes    int64
+	memoryPolicy      MemoryPolicy
+	runtimeStats      *RuntimeStatsConfig
+	quarantine        *QuarantineConfig
+	extractorGuard    *ExtractorGuardConfig
+	valueEncoders     []ValueEncoder
+	fingerprint       bool
+	fingerprintFunc   FingerprintFunc
+	contextPolicy     *ContextPolicy
+	fieldConflicts    FieldConflictPolicy
+	globalFields      []Field
+	buildInfo         BuildInfoMode
+	ingestTime        bool
+	clock             Clock
 }
 
 // build creates a new Logger from the configuration.
@@ -35,22 +70,64 @@ func (c *Config) build() (*Logger, error) {
 		return nil, err
 	}
 
+	level, err := c.resolveLevel()
+	if err != nil {
+		return nil, err
+	}
+
+	crashDumpPath, err := c.crashDumpPath()
+	if err != nil {
+		return nil, err
+	}
+
 	// Build internal config
 	loggerConfig := &internalConfig{
-		level:             c.Level,
+		level:             level,
 		format:            c.Format,
 		timeFormat:        c.TimeFormat,
+		timeLocation:      c.TimeLocation,
+		timePrecision:     c.TimePrecision,
+		timeEpoch:         c.TimeEpoch,
 		includeTime:       c.IncludeTime,
 		includeLevel:      c.IncludeLevel,
 		fullPath:          c.FullPath,
 		dynamicCaller:     c.DynamicCaller,
+		goroutineID:       c.IncludeGoroutineID,
+		text:              c.Text,
 		securityConfig:    c.Security,
 		fieldValidation:   c.FieldValidation,
+		schema:            c.Schema.Clone(),
 		fatalHandler:      c.FatalHandler,
+		fatalPolicy:       c.FatalPolicy,
+		fatalTimeout:      c.FatalTimeout,
+		fatalStackDump:    c.FatalStackDump,
+		crashDumpPath:     crashDumpPath,
 		writeErrorHandler: c.WriteErrorHandler,
+		internalHandler:   c.InternalErrorHandler,
+		shutdownHooks:     slices.Clone(c.ShutdownHooks),
 		contextExtractors: c.ContextExtractors,
 		hooks:             c.Hooks,
 		sampling:          c.Sampling,
+		rateLimit:         c.RateLimit,
+		adaptive:          c.Adaptive.Clone(),
+		events:            c.Events.Clone(),
+		maxMemoryBytes:    c.MaxMemoryBytes,
+		memoryPolicy:      c.MemoryPolicy,
+		runtimeStats:      c.RuntimeStats.Clone(),
+		quarantine:        c.Quarantine,
+		extractorGuard:    c.ExtractorGuard,
+		valueEncoders:     slices.Clone(c.ValueEncoders),
+		fingerprint:       c.Fingerprint,
+		fingerprintFunc:   c.FingerprintFunc,
+		contextPolicy:     c.ContextPolicy,
+		fieldConflicts:    c.FieldConflicts,
+		globalFields:      c.GlobalFields,
+		buildInfo:         c.IncludeBuildInfo,
+		ingestTime:        c.IngestTime,
+		clock:             c.Clock,
+	}
+	if c.IncludeBuildInfo == BuildInfoEntries {
+		loggerConfig.globalFields = append(BuildInfoFields(), c.GlobalFields...)
 	}
 
 	// Handle JSON options
@@ -88,52 +165,167 @@ func (c *Config) build() (*Logger, error) {
 		writers = append(writers, fileWriter)
 	}
 
+	// Create writers from registered factories
+	for _, sink := range c.Sinks {
+		w, err := NewSinkWriter(sink.Type, sink.Params)
+		if err != nil {
+			return nil, err
+		}
+		writers = append(writers, w)
+	}
+
 	// Default to stdout if no writers configured
 	if len(writers) == 0 {
 		writers = []io.Writer{defaultOutput}
 	}
 
+	if len(c.LevelOutputs) > 0 {
+		writers = routeLevelOutputs(writers, c.LevelOutputs)
+	}
+
 	loggerConfig.writers = writers
+	loggerConfig.writerMiddleware = slices.Clone(c.WriterMiddleware)
+	if c.Format == FormatConsole {
+		loggerConfig.console = c.consoleOptions(writers)
+	}
 
 	return newFromInternalConfig(loggerConfig)
 }
 
+// resolveLevel returns Level, overridden by the LevelEnv variable when set.
+func (c *Config) resolveLevel() (LogLevel, error) {
+	if c.LevelEnv == "" {
+		return c.Level, nil
+	}
+	value := os.Getenv(c.LevelEnv)
+	if value == "" {
+		return c.Level, nil
+	}
+	level, err := ParseLevel(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", c.LevelEnv, err)
+	}
+	return level, nil
+}
+
 // createFileWriter creates a FileWriter from FileConfig.
 func (c *Config) createFileWriter() (*FileWriter, error) {
 	if c.File == nil || c.File.Path == "" {
 		return nil, nil
 	}
+	return NewFileWriter(c.File.Path, c.fileWriterConfig())
+}
 
-	config := FileWriterConfig{
+// fileWriterConfig returns the FileWriterConfig for FileConfig.
+func (c *Config) fileWriterConfig() FileWriterConfig {
+	return FileWriterConfig{
 		MaxSizeMB:  c.File.MaxSizeMB,
 		MaxBackups: c.File.MaxBackups,
 		MaxAge:     c.File.MaxAge,
 		Compress:   c.File.Compress,
-	}
 
-	return NewFileWriter(c.File.Path, config)
+		CompressionAlgorithm: c.File.CompressionAlgorithm,
+		CompressionLevel:     c.File.CompressionLevel,
+		OnCompressError:      c.File.OnCompressError,
+
+		MinFreeBytes:          c.File.MinFreeBytes,
+		MinFreeDiskPercent:    c.File.MinFreeDiskPercent,
+		MaxTotalSizeMB:        c.File.MaxTotalSizeMB,
+		PruneBackupsOnLowDisk: c.File.PruneBackupsOnLowDisk,
+		OnDiskPressure:        c.File.OnDiskPressure,
+
+		BackupNameTemplate: c.File.BackupNameTemplate,
+		BackupNameFunc:     c.File.BackupNameFunc,
+		BackupGlob:         c.File.BackupGlob,
+		OnRotate:           c.File.OnRotate,
+
+		Clock: c.Clock,
+	}
 }
 
-// validate validates the configuration.
+// validate validates the configuration, returning the first problem that
+// makes New fail.
 func (c *Config) validate() error {
 	if c == nil {
 		return ErrNilConfig
 	}
+	if issues := c.configIssues(); len(issues) > 0 {
+		return issues[0].Err
+	}
+	return nil
+}
+
+// configIssues returns the problems that make New fail, in the order they
+// are checked. Validate adds file system checks and warnings on top.
+func (c *Config) configIssues() []ValidationIssue {
+	var issues []ValidationIssue
+	add := func(field, code string, err error) {
+		issues = append(issues, newValidationIssue(field, code, SeverityError, err))
+	}
 
 	// Validate log level
 	if c.Level < LevelDebug || c.Level > LevelFatal {
-		return fmt.Errorf("%w: %d (valid range: %d-%d)", ErrInvalidLevel, c.Level, LevelDebug, LevelFatal)
+		add("Level", ErrCodeInvalidLevel, fmt.Errorf("%w: %d (valid range: %d-%d)", ErrInvalidLevel, c.Level, LevelDebug, LevelFatal))
 	}
 
 	// Validate format
-	if c.Format != FormatText && c.Format != FormatJSON {
-		return fmt.Errorf("%w: %d (valid: %d=Text, %d=JSON)", ErrInvalidFormat, c.Format, FormatText, FormatJSON)
+	if c.Format != FormatText && c.Format != FormatJSON && c.Format != FormatConsole && formatEncoder(c.Format) == nil {
+		add("Format", ErrCodeInvalidFormat, fmt.Errorf("%w: %d (valid: %d=Text, %d=JSON, %d=Console)", ErrInvalidFormat, c.Format, FormatText, FormatJSON, FormatConsole))
+	}
+	if c.Console != nil && (c.Console.Color < ColorAuto || c.Console.Color > ColorNever) {
+		add("Console.Color", ErrCodeConfigValidation, fmt.Errorf("%w: invalid console color mode %d", ErrConfigValidation, c.Console.Color))
 	}
 
 	// Validate time format
 	if c.IncludeTime && c.TimeFormat != "" {
 		if err := internal.ValidateTimeFormat(c.TimeFormat); err != nil {
-			return err
+			add("TimeFormat", ErrCodeConfigValidation, err)
+		}
+	}
+	if c.FatalPolicy < FatalPolicyExit || c.FatalPolicy > FatalPolicyPanic {
+		add("FatalPolicy", ErrCodeConfigValidation, fmt.Errorf("%w: invalid FatalPolicy %d", ErrConfigValidation, c.FatalPolicy))
+	}
+	if c.FatalTimeout < 0 {
+		add("FatalTimeout", ErrCodeConfigValidation, fmt.Errorf("%w: FatalTimeout cannot be negative", ErrConfigValidation))
+	}
+	if c.CrashDumpPath != "" {
+		if _, err := c.crashDumpPath(); err != nil {
+			add("CrashDumpPath", "", fmt.Errorf("%w: CrashDumpPath: %w", ErrConfigValidation, err))
+		}
+	}
+	if !c.TimePrecision.IsValid() {
+		add("TimePrecision", ErrCodeConfigValidation, fmt.Errorf("%w: invalid TimePrecision %d", ErrConfigValidation, c.TimePrecision))
+	}
+	for i, mw := range c.WriterMiddleware {
+		if mw == nil {
+			add(fmt.Sprintf("WriterMiddleware[%d]", i), ErrCodeConfigValidation, fmt.Errorf("%w: writer middleware at WriterMiddleware[%d] is nil", ErrConfigValidation, i))
+		}
+	}
+	for i, enc := range c.ValueEncoders {
+		if enc == nil {
+			add(fmt.Sprintf("ValueEncoders[%d]", i), ErrCodeConfigValidation, fmt.Errorf("%w: value encoder at ValueEncoders[%d] is nil", ErrConfigValidation, i))
+		}
+	}
+	if c.Text != nil && c.Text.FloatPrecision < 0 {
+		add("Text.FloatPrecision", ErrCodeConfigValidation, fmt.Errorf("%w: FloatPrecision cannot be negative", ErrConfigValidation))
+	}
+	if c.Text != nil && strings.ContainsAny(c.Text.ContinuationPrefix, "\n\r") {
+		add("Text.ContinuationPrefix", ErrCodeConfigValidation, fmt.Errorf("%w: ContinuationPrefix cannot contain line breaks", ErrConfigValidation))
+	}
+	if c.Security != nil {
+		if c.Security.MaxEntrySize < 0 {
+			add("Security.MaxEntrySize", ErrCodeConfigValidation, fmt.Errorf("%w: MaxEntrySize cannot be negative", ErrConfigValidation))
+		}
+		for level, size := range c.Security.MaxEntrySizeByLevel {
+			if !level.IsValid() || size < 0 {
+				add("Security.MaxEntrySizeByLevel", ErrCodeConfigValidation, fmt.Errorf("%w: invalid MaxEntrySizeByLevel entry %s: %d", ErrConfigValidation, level, size))
+			}
+		}
+		if !c.Security.ControlChars.IsValid() {
+			add("Security.ControlChars", ErrCodeConfigValidation, fmt.Errorf("%w: invalid ControlChars policy %d", ErrConfigValidation, c.Security.ControlChars))
+		}
+		if !c.Security.FilterScope.IsValid() {
+			add("Security.FilterScope", ErrCodeConfigValidation, fmt.Errorf("%w: invalid FilterScope %d", ErrConfigValidation, c.Security.FilterScope))
 		}
 	}
 
@@ -143,21 +335,119 @@ func (c *Config) validate() error {
 		writerCount++
 	}
 	writerCount += len(c.Outputs)
+	for _, levelWriters := range c.LevelOutputs {
+		writerCount += len(levelWriters)
+	}
 	if c.File != nil && c.File.Path != "" {
 		writerCount++
 	}
+	writerCount += len(c.Sinks)
 
 	// Validate writer count
 	if writerCount > maxWriterCount {
-		return fmt.Errorf("%w: %d writers configured, maximum is %d", ErrMaxWritersExceeded, writerCount, maxWriterCount)
+		add("Outputs", ErrCodeMaxWritersExceeded, fmt.Errorf("%w: %d writers configured, maximum is %d", ErrMaxWritersExceeded, writerCount, maxWriterCount))
 	}
 
 	// Check for nil writers in Outputs slice
 	for i, w := range c.Outputs {
 		if w == nil {
-			return fmt.Errorf("writer at Outputs[%d] is nil", i)
+			add(fmt.Sprintf("Outputs[%d]", i), ErrCodeNilWriter, fmt.Errorf("writer at Outputs[%d] is nil", i))
 		}
 	}
 
-	return nil
+	for i, sink := range c.Sinks {
+		if sink.Type == "" {
+			add(fmt.Sprintf("Sinks[%d].Type", i), ErrCodeConfigValidation, fmt.Errorf("%w: sink at Sinks[%d] has no type", ErrConfigValidation, i))
+		}
+	}
+
+	// Validate level routes
+	for _, level := range sortedLevelKeys(c.LevelOutputs) {
+		if !level.IsValid() {
+			add("LevelOutputs", ErrCodeInvalidLevel, fmt.Errorf("%w: LevelOutputs key %d", ErrInvalidLevel, level))
+			continue
+		}
+		for i, w := range c.LevelOutputs[level] {
+			if w == nil {
+				add(fmt.Sprintf("LevelOutputs[%s][%d]", level, i), ErrCodeNilWriter, fmt.Errorf("writer at LevelOutputs[%s][%d] is nil", level, i))
+			}
+		}
+	}
+
+	if c.RateLimit != nil {
+		if err := c.RateLimit.validate(); err != nil {
+			add("RateLimit", "", err)
+		}
+	}
+	if c.RuntimeStats != nil {
+		if err := c.RuntimeStats.validate(); err != nil {
+			add("RuntimeStats", "", err)
+		}
+	}
+	if c.Events != nil {
+		if err := c.Events.validate(); err != nil {
+			add("Events", "", err)
+		}
+	}
+	if c.Adaptive != nil {
+		if err := c.Adaptive.validate(); err != nil {
+			add("Adaptive", "", err)
+		}
+	}
+	if c.MaxMemoryBytes < 0 {
+		add("MaxMemoryBytes", ErrCodeConfigValidation, fmt.Errorf("%w: MaxMemoryBytes %d is negative", ErrConfigValidation, c.MaxMemoryBytes))
+	}
+	if !c.MemoryPolicy.isValid() {
+		add("MemoryPolicy", ErrCodeConfigValidation, fmt.Errorf("%w: unknown MemoryPolicy %d", ErrConfigValidation, c.MemoryPolicy))
+	}
+	if c.Quarantine != nil {
+		if err := c.Quarantine.validate(); err != nil {
+			add("Quarantine", "", err)
+		}
+	}
+	if c.ExtractorGuard != nil {
+		if err := c.ExtractorGuard.validate(); err != nil {
+			add("ExtractorGuard", "", err)
+		}
+	}
+
+	if c.Sampling != nil {
+		if err := c.Sampling.validate(); err != nil {
+			add("Sampling", "", err)
+		}
+	}
+
+	if fv := c.FieldValidation; fv != nil && (fv.StrictAction < InvalidFieldKeep || fv.StrictAction > InvalidFieldRename) {
+		add("FieldValidation.StrictAction", ErrCodeConfigValidation, fmt.Errorf("%w: invalid StrictAction %d", ErrConfigValidation, fv.StrictAction))
+	}
+
+	if c.Schema != nil {
+		if err := c.Schema.validate(); err != nil {
+			add("Schema", "", err)
+		}
+	}
+
+	if !c.FieldConflicts.isValid() {
+		add("FieldConflicts", ErrCodeConfigValidation, fmt.Errorf("%w: unknown FieldConflicts policy %d", ErrConfigValidation, c.FieldConflicts))
+	}
+
+	for i, hook := range c.ShutdownHooks {
+		if hook.Run == nil {
+			add(fmt.Sprintf("ShutdownHooks[%d]", i), ErrCodeNilHook, fmt.Errorf("%w: ShutdownHooks[%d] %q", ErrNilHook, i, hook.Name))
+		} else if hook.Timeout < 0 {
+			add(fmt.Sprintf("ShutdownHooks[%d]", i), ErrCodeConfigValidation, fmt.Errorf("%w: ShutdownHooks[%d] %q has a negative timeout", ErrConfigValidation, i, hook.Name))
+		}
+	}
+
+	if !c.IncludeBuildInfo.isValid() {
+		add("IncludeBuildInfo", ErrCodeConfigValidation, fmt.Errorf("%w: unknown IncludeBuildInfo mode %d", ErrConfigValidation, c.IncludeBuildInfo))
+	}
+
+	for i, field := range c.GlobalFields {
+		if field.Key == "" {
+			add(fmt.Sprintf("GlobalFields[%d]", i), ErrCodeConfigValidation, fmt.Errorf("%w: GlobalFields[%d] has an empty key", ErrConfigValidation, i))
+		}
+	}
+
+	return issues
 }
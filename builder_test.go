@@ -72,8 +72,8 @@ func TestConfigDevelopment(t *testing.T) {
 	if cfg.Level != LevelDebug {
 		t.Errorf("Expected LevelDebug, got %v", cfg.Level)
 	}
-	if cfg.Format != FormatText {
-		t.Errorf("Expected FormatText, got %v", cfg.Format)
+	if cfg.Format != FormatConsole {
+		t.Errorf("Expected FormatConsole, got %v", cfg.Format)
 	}
 	if !cfg.DynamicCaller {
 		t.Error("Expected DynamicCaller to be true")
@@ -91,6 +91,60 @@ func TestConfigJSON(t *testing.T) {
 	}
 }
 
+func TestConfigECS(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := ECSConfig()
+	cfg.Output = &buf
+	cfg.DynamicCaller = false
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.WarnCtx(WithTraceID(context.Background(), "abc"), "disk low", Int("free", 5))
+
+	out := buf.String()
+	if !strings.HasPrefix(out, `{"@timestamp":"`) {
+		t.Errorf("expected @timestamp first, got %s", out)
+	}
+	want := `"log.level":"warn","message":"disk low","ecs.version":"` + ECSVersion + `","trace.id":"abc","free":5}`
+	if !strings.Contains(out, want) {
+		t.Errorf("output %s\nmissing %s", out, want)
+	}
+}
+
+func TestConfigGCP(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "my-project")
+
+	var buf bytes.Buffer
+	cfg := GCPConfig()
+	cfg.Output = &buf
+	cfg.DynamicCaller = false
+	cfg.IncludeTime = false
+	cfg.FatalHandler = func() {}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Warn("careful")
+	logger.FatalCtx(WithTraceID(context.Background(), "abc"), "down")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", buf.String())
+	}
+	want := `{"severity":"CRITICAL","message":"down","logging.googleapis.com/trace":"projects/my-project/traces/abc"}`
+	if lines[0] != `{"severity":"WARNING","message":"careful"}` {
+		t.Errorf("unexpected warn line %s", lines[0])
+	}
+	if lines[1] != want {
+		t.Errorf("got  %s\nwant %s", lines[1], want)
+	}
+}
+
 func TestConfigFileOutput(t *testing.T) {
 	t.Run("File config sets file path", func(t *testing.T) {
 		cfg := DefaultConfig()
@@ -191,6 +245,24 @@ func TestBuilderConfigClone(t *testing.T) {
 		}
 	})
 
+	t.Run("clone preserves disk guard settings", func(t *testing.T) {
+		original := DefaultConfig()
+		original.File = &FileConfig{
+			MinFreeDiskPercent: 5,
+			MaxTotalSizeMB:     200,
+			OnDiskPressure:     func(DiskPressureEvent) {},
+		}
+
+		cloned := original.Clone()
+
+		if cloned.File.MinFreeDiskPercent != 5 || cloned.File.MaxTotalSizeMB != 200 {
+			t.Errorf("Clone disk guard thresholds mismatch: %+v", cloned.File)
+		}
+		if cloned.File.OnDiskPressure == nil {
+			t.Error("Clone should keep OnDiskPressure")
+		}
+	})
+
 	t.Run("clone is independent", func(t *testing.T) {
 		original := DefaultConfig()
 		original.Level = LevelDebug
@@ -513,3 +585,49 @@ func TestNewMultipleConfigs(t *testing.T) {
 		defer logger.Close()
 	})
 }
+
+func TestConfigLevelEnv(t *testing.T) {
+	t.Run("env overrides level", func(t *testing.T) {
+		t.Setenv("DD_TEST_LEVEL", "debug")
+		cfg := DefaultConfig()
+		cfg.LevelEnv = "DD_TEST_LEVEL"
+		cfg.Output = io.Discard
+		logger, err := New(cfg)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		defer logger.Close()
+		if logger.GetLevel() != LevelDebug {
+			t.Errorf("level = %v, want DEBUG", logger.GetLevel())
+		}
+	})
+
+	t.Run("unset env keeps level", func(t *testing.T) {
+		t.Setenv("DD_TEST_LEVEL", "")
+		cfg := DefaultConfig()
+		cfg.Level = LevelWarn
+		cfg.LevelEnv = "DD_TEST_LEVEL"
+		cfg.Output = io.Discard
+		logger, err := New(cfg)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		defer logger.Close()
+		if logger.GetLevel() != LevelWarn {
+			t.Errorf("level = %v, want WARN", logger.GetLevel())
+		}
+	})
+
+	t.Run("invalid env value", func(t *testing.T) {
+		t.Setenv("DD_TEST_LEVEL", "loud")
+		cfg := DefaultConfig()
+		cfg.LevelEnv = "DD_TEST_LEVEL"
+		_, err := New(cfg)
+		if !errors.Is(err, ErrInvalidLevel) {
+			t.Fatalf("New() error = %v, want ErrInvalidLevel", err)
+		}
+		if !strings.Contains(err.Error(), "DD_TEST_LEVEL") {
+			t.Errorf("error should name the variable: %v", err)
+		}
+	})
+}
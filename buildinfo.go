@@ -0,0 +1,96 @@
+package dd
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+// Keys of the fields returned by BuildInfoFields.
+const (
+	BuildModuleKey   = "build.module"   // main module path
+	BuildVersionKey  = "build.version"  // main module version, e.g. v1.4.2
+	BuildRevisionKey = "build.revision" // VCS revision (commit hash)
+	BuildTimeKey     = "build.time"     // VCS commit time (RFC 3339)
+	BuildModifiedKey = "build.modified" // true if built from a modified tree
+	BuildGoKey       = "build.go"       // Go toolchain version
+)
+
+// BuildInfoMode selects where Config.IncludeBuildInfo puts the fields of
+// BuildInfoFields.
+type BuildInfoMode int
+
+const (
+	// BuildInfoNone omits build information (default).
+	BuildInfoNone BuildInfoMode = iota
+	// BuildInfoEntries adds the fields to every entry, like GlobalFields.
+	BuildInfoEntries
+	// BuildInfoBanner writes one INFO entry with the fields when the
+	// logger is created, regardless of the logger level.
+	BuildInfoBanner
+)
+
+// buildInfoMessage is the message of the BuildInfoBanner entry.
+const buildInfoMessage = "build info"
+
+// readBuildInfo reads the build information once per process.
+var readBuildInfo = sync.OnceValue(func() []Field {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	fields := make([]Field, 0, 6)
+	if info.Main.Path != "" {
+		fields = append(fields, String(BuildModuleKey, info.Main.Path))
+	}
+	if info.Main.Version != "" {
+		fields = append(fields, String(BuildVersionKey, info.Main.Version))
+	}
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			fields = append(fields, String(BuildRevisionKey, s.Value))
+		case "vcs.time":
+			fields = append(fields, String(BuildTimeKey, s.Value))
+		case "vcs.modified":
+			if s.Value == "true" {
+				fields = append(fields, Bool(BuildModifiedKey, true))
+			}
+		}
+	}
+	if info.GoVersion != "" {
+		fields = append(fields, String(BuildGoKey, info.GoVersion))
+	}
+	return fields
+})
+
+// BuildInfoFields returns the module path and version, VCS revision,
+// commit time and modified flag, and Go version of the running binary
+// from runtime/debug.ReadBuildInfo, omitting those that are unknown. VCS
+// fields are only present in binaries built with VCS stamping (the
+// default for go build in a repository). The result is computed once.
+//
+// Example:
+//
+//	cfg := dd.DefaultConfig()
+//	cfg.GlobalFields = append(dd.MetadataFields("checkout", "", "production"), dd.BuildInfoFields()...)
+func BuildInfoFields() []Field {
+	fields := readBuildInfo()
+	return append([]Field(nil), fields...)
+}
+
+// isValid reports whether m is a known mode.
+func (m BuildInfoMode) isValid() bool {
+	return m >= BuildInfoNone && m <= BuildInfoBanner
+}
+
+// logBuildInfo writes the BuildInfoBanner entry.
+func (l *Logger) logBuildInfo() {
+	fields := BuildInfoFields()
+	if len(fields) == 0 {
+		return
+	}
+	l.logCore(LevelInfo, logEntry{
+		msg:    buildInfoMessage,
+		fields: l.processFields(fields),
+	})
+}
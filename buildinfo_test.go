@@ -0,0 +1,85 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestBuildInfoFields(t *testing.T) {
+	fields := BuildInfoFields()
+	var goVersion any
+	for _, f := range fields {
+		if f.Key == BuildGoKey {
+			goVersion = f.Value
+		}
+	}
+	if goVersion != runtime.Version() {
+		t.Errorf("%s = %v, want %s (fields %v)", BuildGoKey, goVersion, runtime.Version(), fields)
+	}
+
+	fields[0].Value = "changed"
+	if BuildInfoFields()[0].Value == "changed" {
+		t.Error("BuildInfoFields returns a shared slice")
+	}
+}
+
+func TestIncludeBuildInfoEntries(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.IncludeBuildInfo = BuildInfoEntries
+	cfg.GlobalFields = []Field{String("service", "api")}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	if buf.Len() != 0 {
+		t.Errorf("unexpected banner: %q", buf.String())
+	}
+	logger.Info("first")
+	logger.Info("second")
+	if n := strings.Count(buf.String(), BuildGoKey+"="); n != 2 {
+		t.Errorf("%s in %d entries, want 2: %q", BuildGoKey, n, buf.String())
+	}
+	if !strings.Contains(buf.String(), "service=api") {
+		t.Errorf("GlobalFields dropped: %q", buf.String())
+	}
+	if len(cfg.GlobalFields) != 1 {
+		t.Errorf("GlobalFields modified: %v", cfg.GlobalFields)
+	}
+}
+
+func TestIncludeBuildInfoBanner(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Level = LevelError
+	cfg.IncludeBuildInfo = BuildInfoBanner
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	if out := buf.String(); strings.Count(out, "\n") != 1 || !strings.Contains(out, buildInfoMessage) || !strings.Contains(out, BuildGoKey+"=") {
+		t.Errorf("banner = %q", out)
+	}
+	buf.Reset()
+	logger.Error("failed")
+	if strings.Contains(buf.String(), BuildGoKey) {
+		t.Errorf("entry carries build info: %q", buf.String())
+	}
+}
+
+func TestIncludeBuildInfoValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.IncludeBuildInfo = BuildInfoMode(5)
+	if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("err = %v", err)
+	}
+}
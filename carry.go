@@ -0,0 +1,77 @@
+package dd
+
+import (
+	"context"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// contextEntryKey stores the LoggerEntry of CarryTo.
+type contextEntryKey struct{}
+
+// CarryTo returns a copy of ctx that carries the entry, so a goroutine or
+// worker handed ctx keeps the entry's correlation fields. The entry is
+// retrieved with EntryFromContext; its fields are also added to the
+// context fields (see ContextWithFields), so the *Ctx methods of any
+// logger include them.
+//
+// Example:
+//
+//	entry := logger.WithFields(dd.String("request_id", id))
+//	jobs <- job{ctx: entry.CarryTo(ctx)}
+//
+//	// in the worker
+//	dd.EntryFromContext(j.ctx).Info("processing") // request_id=...
+func (e *LoggerEntry) CarryTo(ctx context.Context) context.Context {
+	if e == nil {
+		return ctx
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = ContextWithFields(ctx, e.fields...)
+	return context.WithValue(ctx, contextEntryKey{}, e)
+}
+
+// EntryFromContext returns the entry stored in ctx by CarryTo. Without
+// one it returns an entry of FromContext(ctx) with the context fields, so
+// it can be used wherever a context is handed over.
+func EntryFromContext(ctx context.Context) *LoggerEntry {
+	if ctx != nil {
+		if entry, ok := ctx.Value(contextEntryKey{}).(*LoggerEntry); ok && entry != nil {
+			return entry
+		}
+	}
+	return FromContext(ctx).WithFields(FieldsFromContext(ctx)...)
+}
+
+// Go runs fn(ctx) in a new goroutine that inherits the calling goroutine's
+// GoroutineFields, which a plain go statement loses. Pass a ctx from
+// CarryTo or ContextWithFields to carry entry and context fields as well.
+//
+// Example:
+//
+//	defer dd.GoroutineFields(dd.String("job", id))()
+//	dd.Go(entry.CarryTo(ctx), func(ctx context.Context) {
+//	    dd.EntryFromContext(ctx).Info("uploading") // job and entry fields
+//	})
+func Go(ctx context.Context, fn func(ctx context.Context)) {
+	if fn == nil {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var inherited []Field
+	if goroutineFieldCount.Load() > 0 {
+		if fields, ok := goroutineFields.Load(internal.GoroutineID()); ok {
+			inherited = fields.([]Field)
+		}
+	}
+	go func() {
+		if len(inherited) > 0 {
+			defer GoroutineFields(inherited...)()
+		}
+		fn(ctx)
+	}()
+}
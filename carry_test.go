@@ -0,0 +1,72 @@
+package dd
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCarryToAcrossGoroutines(t *testing.T) {
+	buf := &syncBuffer{}
+	logger, _ := newTestLogger(t, func(cfg *Config) { cfg.Output = buf })
+	entry := logger.WithFields(String("request_id", "r-1"))
+	ctx := entry.CarryTo(context.Background())
+
+	jobs := make(chan context.Context)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for jobCtx := range jobs {
+			EntryFromContext(jobCtx).Info("from entry")
+			logger.InfoCtx(jobCtx, "from ctx")
+		}
+	}()
+	jobs <- ctx
+	close(jobs)
+	wg.Wait()
+
+	if n := strings.Count(buf.String(), "request_id=r-1"); n != 2 {
+		t.Errorf("request_id in %d entries, want 2: %q", n, buf.String())
+	}
+	if EntryFromContext(ctx) != entry {
+		t.Error("EntryFromContext did not return the carried entry")
+	}
+}
+
+func TestEntryFromContextWithoutEntry(t *testing.T) {
+	buf := &syncBuffer{}
+	logger, _ := newTestLogger(t, func(cfg *Config) { cfg.Output = buf })
+	ctx := NewContext(ContextWithFields(context.Background(), String("tenant", "acme")), logger)
+
+	EntryFromContext(ctx).Info("fallback")
+	if !strings.Contains(buf.String(), "tenant=acme") {
+		t.Errorf("output = %q", buf.String())
+	}
+	if EntryFromContext(nil) == nil {
+		t.Error("EntryFromContext(nil) = nil")
+	}
+	var nilEntry *LoggerEntry
+	if got := nilEntry.CarryTo(ctx); got != ctx {
+		t.Error("nil entry changed the context")
+	}
+}
+
+func TestGoInheritsGoroutineFields(t *testing.T) {
+	buf := &syncBuffer{}
+	logger, _ := newTestLogger(t, func(cfg *Config) { cfg.Output = buf })
+	restore := GoroutineFields(String("job", "export"))
+	defer restore()
+
+	done := make(chan struct{})
+	Go(logger.WithFields(String("user", "u-7")).CarryTo(context.Background()), func(ctx context.Context) {
+		defer close(done)
+		EntryFromContext(ctx).Info("worker")
+	})
+	<-done
+
+	if out := buf.String(); !strings.Contains(out, "job=export") || !strings.Contains(out, "user=u-7") {
+		t.Errorf("output = %q", out)
+	}
+}
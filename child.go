@@ -0,0 +1,179 @@
+package dd
+
+import (
+	"fmt"
+	"sync"
+)
+
+// childSet holds the children of a logger or child entry in creation order.
+type childSet struct {
+	mu       sync.Mutex
+	children []*LoggerEntry
+}
+
+// get returns the child with path, creating it with create on first use.
+func (s *childSet) get(path string, create func() *LoggerEntry) *LoggerEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, child := range s.children {
+		if child.name == path {
+			return child
+		}
+	}
+	child := create()
+	s.children = append(s.children, child)
+	return child
+}
+
+// list returns a copy of the children.
+func (s *childSet) list() []*LoggerEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.children) == 0 {
+		return nil
+	}
+	return append([]*LoggerEntry(nil), s.children...)
+}
+
+// childNode is the part of a child entry shared by its WithFields copies.
+type childNode struct {
+	children childSet
+}
+
+// Child returns the child logger called name. A child carries a
+// LoggerNameKey field and writes through the parent's writers, hooks and
+// security settings. Its level and sampling follow the parent at the time
+// of each entry, so SetLevel and SetSampling on the parent reach every
+// child that has not overridden them with LoggerEntry.SetLevel or
+// LoggerEntry.SetSampling.
+//
+// Calling Child again with the same name returns the same child, so it can
+// be used to look up a component's logger. Children are kept for the
+// lifetime of the logger: use them for long-lived components such as
+// plugins, and WithFields or Tenant for per-request loggers. Unlike Named
+// loggers, children are not affected by SetLevels rules.
+//
+// Example:
+//
+//	plugins := logger.Child("plugins")
+//	auth := plugins.Child("auth") // "plugins.auth"
+//	_ = plugins.SetLevel(dd.LevelWarn) // auth inherits WARN
+//	_ = auth.SetLevel(dd.LevelDebug)   // auth alone logs DEBUG
+//	_ = logger.SetLevel(dd.LevelError) // plugins keeps its own WARN
+func (l *Logger) Child(name string) *LoggerEntry {
+	if l == nil {
+		return nilLogger().nopEntry
+	}
+	if l.nopEntry != nil {
+		return l.nopEntry
+	}
+	return l.children.get(name, func() *LoggerEntry {
+		return l.newChild(nil, name, nil)
+	})
+}
+
+// GetChildren returns the children created with Child, in creation order.
+func (l *Logger) GetChildren() []*LoggerEntry {
+	if l == nil {
+		return nil
+	}
+	return l.children.list()
+}
+
+// Child returns the child of e called name (see Logger.Child). The path of
+// the child is "<parent>.<name>" and it keeps e's fields from the time it
+// was first created. An entry not created by Child, such as one returned by
+// WithFields, Named or Tenant, becomes the parent only for inheritance:
+// its children are listed by Logger.GetChildren.
+func (e *LoggerEntry) Child(name string) *LoggerEntry {
+	if e.logger.nopEntry != nil {
+		return e
+	}
+	path := name
+	if e.name != "" {
+		path = e.name + "." + name
+	}
+	set := &e.logger.children
+	if e.child != nil {
+		set = &e.child.children
+	}
+	return set.get(path, func() *LoggerEntry {
+		return e.logger.newChild(e, path, e.tenant)
+	})
+}
+
+// newChild creates a child entry below parent (nil for the logger).
+func (l *Logger) newChild(parent *LoggerEntry, path string, parentState *tenantState) *LoggerEntry {
+	var fields []Field
+	if parent != nil {
+		fields = parent.fields
+	}
+	entry := newLoggerEntry(l, mergeFieldSlices(fields, []Field{{Key: LoggerNameKey, Value: path}}))
+	entry.name = path
+	entry.child = &childNode{}
+	entry.tenant = &tenantState{parent: parentState}
+	entry.tenant.level.Store(noTenantLevel)
+	if parent != nil {
+		entry.security = parent.security
+	}
+	return entry
+}
+
+// GetChildren returns the children of e created with Child, in creation
+// order. It returns nil for entries not created by Child.
+func (e *LoggerEntry) GetChildren() []*LoggerEntry {
+	if e.child == nil {
+		return nil
+	}
+	return e.child.children.list()
+}
+
+// SetLevel sets the minimum level of a child entry and of its descendants
+// that have no level of their own, overriding the parent's level.
+// It returns ErrNotChildLogger for entries not created by Child.
+func (e *LoggerEntry) SetLevel(level LogLevel) error {
+	if e.child == nil {
+		return ErrNotChildLogger
+	}
+	if level < LevelDebug || level > LevelFatal {
+		return fmt.Errorf("%w: %d (valid range: %d-%d)", ErrInvalidLevel, level, LevelDebug, LevelFatal)
+	}
+	e.tenant.level.Store(int32(level))
+	return nil
+}
+
+// ResetLevel removes the level set with SetLevel, so the child follows its
+// parent's level again.
+func (e *LoggerEntry) ResetLevel() {
+	if e.child != nil {
+		e.tenant.level.Store(noTenantLevel)
+	}
+}
+
+// GetLevel returns the level in effect for the entry: its own level, the
+// nearest ancestor's, or the logger's.
+func (e *LoggerEntry) GetLevel() LogLevel {
+	if e.tenant == nil {
+		return e.logger.GetLevel()
+	}
+	if level, ok := e.tenant.ownLevel(); ok {
+		return level
+	}
+	return e.logger.GetLevel()
+}
+
+// SetSampling sets the sampling of a child entry and of its descendants
+// without sampling of their own, with counters separate from the parent's.
+// Pass nil to follow the parent's sampling again. It returns
+// ErrNotChildLogger for entries not created by Child.
+func (e *LoggerEntry) SetSampling(config *SamplingConfig) error {
+	if e.child == nil {
+		return ErrNotChildLogger
+	}
+	if config == nil {
+		e.tenant.sampling.Store(nil)
+		return nil
+	}
+	e.tenant.sampling.Store(newSamplingState(config, e.logger.clock))
+	return nil
+}
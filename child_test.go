@@ -0,0 +1,101 @@
+package dd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestChildInheritsParentLevel(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	plugins := logger.Child("plugins")
+	auth := plugins.Child("auth")
+	if auth.Name() != "plugins.auth" {
+		t.Errorf("Name = %q", auth.Name())
+	}
+
+	auth.Debug("hidden")
+	_ = logger.SetLevel(LevelDebug)
+	auth.Debug("follows root")
+	if got := buf.String(); strings.Contains(got, "hidden") || !strings.Contains(got, "follows root") {
+		t.Fatalf("root level not inherited: %q", got)
+	}
+
+	_ = plugins.SetLevel(LevelWarn)
+	auth.Info("dropped by plugins level")
+	if strings.Contains(buf.String(), "dropped by plugins level") {
+		t.Error("parent child level not inherited")
+	}
+	if auth.GetLevel() != LevelWarn {
+		t.Errorf("GetLevel = %s, want WARN", auth.GetLevel())
+	}
+
+	_ = auth.SetLevel(LevelDebug)
+	_ = logger.SetLevel(LevelError)
+	auth.Debug("own level")
+	plugins.Warn("plugins keeps warn")
+	if got := buf.String(); !strings.Contains(got, "own level") || !strings.Contains(got, "plugins keeps warn") {
+		t.Errorf("overrides not kept: %q", got)
+	}
+
+	auth.ResetLevel()
+	plugins.ResetLevel()
+	if auth.GetLevel() != LevelError {
+		t.Errorf("GetLevel after reset = %s, want ERROR", auth.GetLevel())
+	}
+}
+
+func TestChildFieldsAndIdentity(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	first := logger.Child("db")
+	if logger.Child("db") != first {
+		t.Error("Child returned a new entry for an existing name")
+	}
+	first.WithFields(String("table", "users")).Info("query")
+	if got := buf.String(); !strings.Contains(got, "logger=db") || !strings.Contains(got, "table=users") {
+		t.Errorf("output = %q", got)
+	}
+
+	pool := first.WithField("shard", 1).Child("pool")
+	children := logger.GetChildren()
+	if len(children) != 1 || children[0] != first {
+		t.Errorf("logger children = %v", children)
+	}
+	if got := first.GetChildren(); len(got) != 1 || got[0] != pool {
+		t.Errorf("db children = %v", got)
+	}
+}
+
+func TestChildSampling(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+	_ = logger.SetLevel(LevelDebug)
+
+	parent := logger.Child("parent")
+	child := parent.Child("child")
+	if err := parent.SetSampling(&SamplingConfig{Enabled: true, Thereafter: 0}); err != nil {
+		t.Fatal(err)
+	}
+	child.Info("sampled away")
+	logger.Info("root unaffected")
+	if got := buf.String(); strings.Contains(got, "sampled away") || !strings.Contains(got, "root unaffected") {
+		t.Errorf("output = %q", got)
+	}
+
+	_ = parent.SetSampling(nil)
+	child.Info("kept again")
+	if !strings.Contains(buf.String(), "kept again") {
+		t.Error("SetSampling(nil) did not restore inheritance")
+	}
+}
+
+func TestSetLevelOnNonChildEntry(t *testing.T) {
+	logger, _ := newTestLogger(t, nil)
+	if err := logger.WithField("k", "v").SetLevel(LevelDebug); !errors.Is(err, ErrNotChildLogger) {
+		t.Errorf("SetLevel error = %v", err)
+	}
+	if err := logger.Child("c").SetLevel(LogLevel(42)); !errors.Is(err, ErrInvalidLevel) {
+		t.Errorf("SetLevel(42) error = %v", err)
+	}
+}
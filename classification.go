@@ -0,0 +1,133 @@
+package dd
+
+import (
+	"fmt"
+	"io"
+)
+
+// ConfidentialityLevel classifies the sensitivity of a single log entry.
+// Writers implementing RecordWriter see it as Record.Classification and can
+// route or drop entries according to data-handling policy.
+type ConfidentialityLevel int8
+
+const (
+	// ConfidentialityUnclassified is the default for entries without a
+	// Classification field.
+	ConfidentialityUnclassified ConfidentialityLevel = iota
+	ConfidentialityPublic
+	ConfidentialityInternal
+	ConfidentialityConfidential
+	ConfidentialityRestricted
+)
+
+// classificationKey is the field key used by Classification.
+const classificationKey = "classification"
+
+var confidentialityNames = [...]string{
+	ConfidentialityUnclassified: "unclassified",
+	ConfidentialityPublic:       "public",
+	ConfidentialityInternal:     "internal",
+	ConfidentialityConfidential: "confidential",
+	ConfidentialityRestricted:   "restricted",
+}
+
+// String returns the lower-case name of the level.
+func (c ConfidentialityLevel) String() string {
+	if c >= 0 && int(c) < len(confidentialityNames) {
+		return confidentialityNames[c]
+	}
+	return "unknown"
+}
+
+// MarshalText implements encoding.TextMarshaler so JSON output uses the name.
+func (c ConfidentialityLevel) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *ConfidentialityLevel) UnmarshalText(text []byte) error {
+	for i, name := range confidentialityNames {
+		if name == string(text) {
+			*c = ConfidentialityLevel(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown confidentiality level %q", text)
+}
+
+// Classification marks an entry with a confidentiality level.
+// The field is rendered as classification=<name> and carried to writers
+// as Record.Classification.
+//
+// Example:
+//
+//	logger.InfoWith("patient record accessed",
+//	    dd.Classification(dd.ConfidentialityRestricted),
+//	    dd.String("patient_id", id),
+//	)
+func Classification(level ConfidentialityLevel) Field {
+	return Field{Key: classificationKey, Value: level}
+}
+
+// classificationOf returns the classification carried by fields. When several
+// Classification fields are present, the most restrictive one wins.
+func classificationOf(fields []Field) ConfidentialityLevel {
+	result := ConfidentialityUnclassified
+	for _, f := range fields {
+		if f.Key != classificationKey {
+			continue
+		}
+		if c, ok := f.Value.(ConfidentialityLevel); ok && c > result {
+			result = c
+		}
+	}
+	return result
+}
+
+// ClassificationFilterWriter passes only entries classified at or below a
+// maximum level to the wrapped writer. Use it to keep restricted data away
+// from sinks that are not approved for it.
+//
+// Example:
+//
+//	console, _ := dd.NewClassificationFilterWriter(os.Stdout, dd.ConfidentialityInternal)
+//	cfg.Outputs = []io.Writer{console, encryptedFile} // restricted entries only reach encryptedFile
+type ClassificationFilterWriter struct {
+	writer io.Writer
+	max    ConfidentialityLevel
+}
+
+// NewClassificationFilterWriter wraps w so it only receives entries whose
+// classification is at most maxLevel. Unclassified entries always pass.
+func NewClassificationFilterWriter(w io.Writer, maxLevel ConfidentialityLevel) (*ClassificationFilterWriter, error) {
+	if w == nil {
+		return nil, ErrNilWriter
+	}
+	return &ClassificationFilterWriter{writer: w, max: maxLevel}, nil
+}
+
+// Write writes p to the wrapped writer unconditionally.
+func (cw *ClassificationFilterWriter) Write(p []byte) (int, error) {
+	return cw.writer.Write(p)
+}
+
+// WriteRecord implements RecordWriter.
+func (cw *ClassificationFilterWriter) WriteRecord(rec *Record, p []byte) (int, error) {
+	if rec.Classification > cw.max {
+		return len(p), nil
+	}
+	return forwardRecord(cw.writer, rec, p)
+}
+
+// Flush flushes the wrapped writer if it implements Flusher.
+func (cw *ClassificationFilterWriter) Flush() error {
+	if f, ok := cw.writer.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Close closes the wrapped writer.
+func (cw *ClassificationFilterWriter) Close() error {
+	return closeWriter(cw.writer)
+}
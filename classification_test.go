@@ -0,0 +1,97 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+type recordingWriter struct {
+	records []Record
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+func (w *recordingWriter) WriteRecord(rec *Record, p []byte) (int, error) {
+	w.records = append(w.records, *rec)
+	return len(p), nil
+}
+
+func TestClassificationPropagatesToRecordWriter(t *testing.T) {
+	rw := &recordingWriter{}
+	logger, err := ToWriter(rw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.InfoWith("plain")
+	logger.InfoWith("secret", Classification(ConfidentialityRestricted), String("k", "v"))
+	logger.WithFields(Classification(ConfidentialityInternal)).Warn("entry")
+
+	if len(rw.records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(rw.records))
+	}
+	want := []ConfidentialityLevel{ConfidentialityUnclassified, ConfidentialityRestricted, ConfidentialityInternal}
+	for i, rec := range rw.records {
+		if rec.Classification != want[i] {
+			t.Errorf("record %d classification = %v, want %v", i, rec.Classification, want[i])
+		}
+	}
+	if rw.records[1].Message != "secret" || rw.records[1].Level != LevelInfo {
+		t.Errorf("unexpected record: %+v", rw.records[1])
+	}
+	if rw.records[2].Level != LevelWarn {
+		t.Errorf("record level = %v, want WARN", rw.records[2].Level)
+	}
+}
+
+func TestClassificationFilterWriter(t *testing.T) {
+	if _, err := NewClassificationFilterWriter(nil, ConfidentialityPublic); err == nil {
+		t.Error("expected error for nil writer")
+	}
+
+	var console, secure bytes.Buffer
+	filtered, err := NewClassificationFilterWriter(&console, ConfidentialityInternal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Outputs = []io.Writer{filtered, &secure}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.InfoWith("public event", Classification(ConfidentialityPublic))
+	logger.InfoWith("restricted event", Classification(ConfidentialityRestricted))
+
+	if !strings.Contains(console.String(), "public event") || strings.Contains(console.String(), "restricted event") {
+		t.Errorf("console output = %q", console.String())
+	}
+	if !strings.Contains(secure.String(), "restricted event") || !strings.Contains(secure.String(), "classification=restricted") {
+		t.Errorf("secure output = %q", secure.String())
+	}
+}
+
+func TestConfidentialityLevelText(t *testing.T) {
+	data, err := json.Marshal(map[string]any{"c": ConfidentialityConfidential})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"c":"confidential"}` {
+		t.Errorf("json = %s", data)
+	}
+
+	var c ConfidentialityLevel
+	if err := c.UnmarshalText([]byte("restricted")); err != nil || c != ConfidentialityRestricted {
+		t.Errorf("UnmarshalText() = %v, %v", c, err)
+	}
+	if err := c.UnmarshalText([]byte("bogus")); err == nil {
+		t.Error("expected error for unknown level")
+	}
+}
@@ -0,0 +1,130 @@
+package dd
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Clock supplies the current time for entry timestamps, Record.Time, hook
+// timestamps, sampling tick resets and backup file names. Implementations
+// must be safe for concurrent use.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the default Clock backed by time.Now.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// SystemClock returns the Clock backed by time.Now that loggers use by default.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// ManualClock is a Clock that only moves when told to, for tests that
+// check timestamps, sampling ticks or rotation names.
+//
+// Example:
+//
+//	clock := dd.NewManualClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+//	cfg := dd.DefaultConfig()
+//	cfg.Clock = clock
+//	logger, _ := dd.New(cfg)
+//	logger.Info("frozen") // [2024-01-02T03:04:05Z ...
+//	clock.Advance(time.Minute)
+type ManualClock struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+// NewManualClock returns a ManualClock set to t.
+func NewManualClock(t time.Time) *ManualClock {
+	return &ManualClock{now: t}
+}
+
+// Now returns the clock's current time.
+func (c *ManualClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.now
+}
+
+// Set moves the clock to t.
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	c.now = t
+	c.mu.Unlock()
+}
+
+// Advance moves the clock forward by d.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+// defaultCoarseResolution is the CoarseClock update interval used when the
+// requested resolution is not positive.
+const defaultCoarseResolution = time.Millisecond
+
+// CoarseClock is a Clock that reads a time refreshed by a background
+// goroutine every resolution. Now is a single atomic load, which is cheaper
+// than time.Now under very high logging rates, at the cost of timestamps
+// that lag by up to one resolution. The cached time keeps its monotonic
+// reading, so durations between readings never go backwards.
+//
+// Call Stop when the clock is no longer needed.
+type CoarseClock struct {
+	now  atomic.Pointer[time.Time]
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// NewCoarseClock starts a CoarseClock refreshed every resolution
+// (default 1ms).
+func NewCoarseClock(resolution time.Duration) *CoarseClock {
+	if resolution <= 0 {
+		resolution = defaultCoarseResolution
+	}
+	c := &CoarseClock{stop: make(chan struct{}), done: make(chan struct{})}
+	now := time.Now()
+	c.now.Store(&now)
+
+	go func() {
+		defer close(c.done)
+		ticker := time.NewTicker(resolution)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.stop:
+				return
+			case t := <-ticker.C:
+				c.now.Store(&t)
+			}
+		}
+	}()
+	return c
+}
+
+// Now returns the most recently cached time.
+func (c *CoarseClock) Now() time.Time {
+	return *c.now.Load()
+}
+
+// Stop stops the refresh goroutine and waits for it to exit. Now keeps
+// returning the last cached time. Stop is safe to call more than once.
+func (c *CoarseClock) Stop() {
+	c.once.Do(func() { close(c.stop) })
+	<-c.done
+}
+
+// clockOrSystem returns c, or the system clock if c is nil.
+func clockOrSystem(c Clock) Clock {
+	if c == nil {
+		return systemClock{}
+	}
+	return c
+}
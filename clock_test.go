@@ -0,0 +1,160 @@
+package dd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestManualClockTimestamps(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Clock = clock
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Info("first")
+	clock.Advance(time.Hour)
+	logger.Info("second")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], "2024-01-02T03:04:05Z") {
+		t.Errorf("unexpected timestamp: %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "2024-01-02T04:04:05Z") {
+		t.Errorf("unexpected timestamp after Advance: %q", lines[1])
+	}
+}
+
+func TestManualClockRecordAndHooks(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rw := &recordCapture{}
+	cfg := DefaultConfig()
+	cfg.Output = rw
+	cfg.Clock = NewManualClock(start)
+	cfg.Hooks = NewHookRegistry()
+	var hookTime time.Time
+	cfg.Hooks.Add(HookBeforeLog, func(_ context.Context, hc *HookContext) error {
+		hookTime = hc.Timestamp
+		return nil
+	})
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Info("msg")
+	if !hookTime.Equal(start) {
+		t.Errorf("hook timestamp = %v, want %v", hookTime, start)
+	}
+	if rw.last == nil || !rw.last.Equal(start) {
+		t.Errorf("record time = %v, want %v", rw.last, start)
+	}
+}
+
+type recordCapture struct {
+	last *time.Time
+}
+
+func (r *recordCapture) Write(p []byte) (int, error) { return len(p), nil }
+
+func (r *recordCapture) WriteRecord(rec *Record, p []byte) (int, error) {
+	t := rec.Time
+	r.last = &t
+	return len(p), nil
+}
+
+func TestManualClockSamplingTick(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Clock = clock
+	cfg.Sampling = &SamplingConfig{Enabled: true, Initial: 1, Thereafter: 0, Tick: time.Second}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Info("a")
+	logger.Info("b") // dropped: same tick
+	clock.Advance(time.Second)
+	logger.Info("c") // new tick
+
+	out := buf.String()
+	if !strings.Contains(out, " a") || strings.Contains(out, " b") || !strings.Contains(out, " c") {
+		t.Errorf("unexpected sampled output %q", out)
+	}
+}
+
+func TestManualClockRotationName(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	fw, err := NewFileWriter(path, FileWriterConfig{
+		MaxSizeMB:          1,
+		MaxBackups:         5,
+		BackupNameTemplate: "app-{{.Time}}.log",
+		Clock:              NewManualClock(time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunk := bytes.Repeat([]byte("x"), 700*1024)
+	for i := 0; i < 2; i++ {
+		if _, err := fw.Write(chunk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := filepath.Join(dir, "app-"+"20240506T070809"+".log")
+	if _, err := os.Stat(want); err != nil {
+		entries, _ := os.ReadDir(dir)
+		t.Errorf("expected backup %s: %v (dir: %v)", filepath.Base(want), err, entries)
+	}
+}
+
+func TestCoarseClock(t *testing.T) {
+	clock := NewCoarseClock(time.Millisecond)
+	defer clock.Stop()
+
+	first := clock.Now()
+	if time.Since(first) > time.Second {
+		t.Fatalf("coarse clock too far behind: %v", first)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if !clock.Now().After(first) {
+		t.Error("coarse clock should advance")
+	}
+
+	clock.Stop()
+	clock.Stop() // idempotent
+	stopped := clock.Now()
+	time.Sleep(10 * time.Millisecond)
+	if !clock.Now().Equal(stopped) {
+		t.Error("stopped clock should not advance")
+	}
+}
+
+func TestSystemClock(t *testing.T) {
+	if d := time.Since(SystemClock().Now()); d < 0 || d > time.Second {
+		t.Errorf("system clock off by %v", d)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cybergodev/dd"
+)
+
+// filter is one field expression.
+type filter struct {
+	key   string
+	op    string
+	value string
+	num   float64 // value as a number, for comparisons
+}
+
+// filterFlags collects repeated -f flags.
+type filterFlags []filter
+
+func (f *filterFlags) String() string {
+	parts := make([]string, len(*f))
+	for i, flt := range *f {
+		parts[i] = flt.key + flt.op + flt.value
+	}
+	return strings.Join(parts, ",")
+}
+
+func (f *filterFlags) Set(expr string) error {
+	flt, err := parseFilter(expr)
+	if err != nil {
+		return err
+	}
+	*f = append(*f, flt)
+	return nil
+}
+
+// operators in matching order: two-character operators first.
+var operators = []string{"!=", ">=", "<=", "=", "~", ">", "<"}
+
+// parseFilter parses "key<op>value".
+func parseFilter(expr string) (filter, error) {
+	idx, op := -1, ""
+	for _, candidate := range operators {
+		if i := strings.Index(expr, candidate); i > 0 && (idx < 0 || i < idx) {
+			idx, op = i, candidate
+		}
+	}
+	if idx < 0 {
+		return filter{}, fmt.Errorf("invalid filter %q: want key=value, key!=value, key~value or a comparison", expr)
+	}
+
+	flt := filter{key: strings.TrimSpace(expr[:idx]), op: op, value: expr[idx+len(op):]}
+	switch op {
+	case ">", ">=", "<", "<=":
+		n, err := strconv.ParseFloat(flt.value, 64)
+		if err != nil {
+			return filter{}, fmt.Errorf("invalid filter %q: %s needs a number", expr, op)
+		}
+		flt.num = n
+	}
+	return flt, nil
+}
+
+// matchAll reports whether rec satisfies every filter.
+func matchAll(filters []filter, rec *dd.Record) bool {
+	for _, f := range filters {
+		if !f.match(rec) {
+			return false
+		}
+	}
+	return true
+}
+
+// match reports whether rec satisfies f. A missing field only matches !=.
+func (f filter) match(rec *dd.Record) bool {
+	value, ok := lookup(rec, f.key)
+	if !ok {
+		return f.op == "!="
+	}
+
+	switch f.op {
+	case "=":
+		return value == f.value || f.key == "level" && strings.EqualFold(value, f.value)
+	case "!=":
+		return value != f.value && !(f.key == "level" && strings.EqualFold(value, f.value))
+	case "~":
+		return strings.Contains(value, f.value)
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false
+	}
+	switch f.op {
+	case ">":
+		return n > f.num
+	case ">=":
+		return n >= f.num
+	case "<":
+		return n < f.num
+	default:
+		return n <= f.num
+	}
+}
+
+// lookup returns the value of key as a string. The last field with the
+// key wins, like in the logger.
+func lookup(rec *dd.Record, key string) (string, bool) {
+	for i := len(rec.Fields) - 1; i >= 0; i-- {
+		if rec.Fields[i].Key == key {
+			return fmt.Sprint(rec.Fields[i].Value), true
+		}
+	}
+	switch key {
+	case "message", "msg":
+		return rec.Message, true
+	case "level":
+		return rec.Level.String(), true
+	case "caller":
+		return rec.Caller, rec.Caller != ""
+	}
+	return "", false
+}
@@ -0,0 +1,217 @@
+// Command ddlog reads dd log files, filters entries and prints them in a
+// chosen format.
+//
+// Usage:
+//
+//	ddlog [flags] [file ...]
+//
+// Without files, ddlog reads standard input. Files ending in ".gz" are
+// decompressed. Text and JSON entries may be mixed.
+//
+// Examples:
+//
+//	ddlog -l error app.log                   # ERROR and FATAL entries
+//	ddlog -f user_id=123 -f 'status>=500' app.log
+//	ddlog -F -o console logs/app.log          # follow, pretty-printed
+//	ddlog -o json app.log.1.gz > app.jsonl    # convert text to JSON
+//
+// Field expressions are key=value, key!=value, key~substring and the
+// numeric comparisons key>n, key>=n, key<n and key<=n. The keys message,
+// level and caller match the entry itself. All expressions must match.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/cybergodev/dd"
+)
+
+// followInterval is how often a followed file is polled for new data.
+const followInterval = 250 * time.Millisecond
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// errUsage marks flag errors, which the flag package prints itself.
+var errUsage = errors.New("usage")
+
+// options are the parsed command line flags.
+type options struct {
+	level      dd.LogLevel
+	filters    []filter
+	format     string
+	color      bool
+	follow     bool
+	timeFormat string
+	files      []string
+}
+
+// run executes the command and returns the exit code.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	opts, err := parseArgs(args, stdout, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if errors.Is(err, errUsage) {
+		return 2 // the flag package has reported it
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "ddlog: %v\n", err)
+		return 2
+	}
+
+	out := newRenderer(stdout, opts.format, opts.color)
+	cfg := dd.ReaderConfig{TimeFormat: opts.timeFormat}
+
+	if len(opts.files) == 0 {
+		if err := process(dd.NewReader(stdin, cfg), opts, out); err != nil {
+			fmt.Fprintf(stderr, "ddlog: stdin: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	status := 0
+	for _, path := range opts.files {
+		if err := processFile(ctx, path, cfg, opts, out); err != nil {
+			fmt.Fprintf(stderr, "ddlog: %s: %v\n", path, err)
+			status = 1
+		}
+	}
+	return status
+}
+
+// parseArgs parses the command line.
+func parseArgs(args []string, stdout, stderr io.Writer) (*options, error) {
+	fs := flag.NewFlagSet("ddlog", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	var (
+		level   = fs.String("l", "", "minimum `level`: debug, info, warn, error or fatal")
+		format  = fs.String("o", "text", "output `format`: text, json or console")
+		color   = fs.String("color", "auto", "colorize console output: auto, always or never")
+		follow  = fs.Bool("F", false, "follow the file as it grows (like tail -f)")
+		timeFmt = fs.String("time-format", "", "timestamp `layout` of the input (default: dd defaults)")
+		filters filterFlags
+	)
+	fs.Var(&filters, "f", "field `expression` (repeatable): key=v, key!=v, key~v, key>n, key>=n, key<n, key<=n")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: ddlog [flags] [file ...]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	opts := &options{
+		level:      dd.LevelDebug,
+		filters:    filters,
+		format:     strings.ToLower(*format),
+		follow:     *follow,
+		timeFormat: *timeFmt,
+		files:      fs.Args(),
+	}
+	if *level != "" {
+		l, err := dd.ParseLevel(*level)
+		if err != nil {
+			return nil, err
+		}
+		opts.level = l
+	}
+	switch opts.format {
+	case "text", "json", "console":
+	default:
+		return nil, fmt.Errorf("unknown output format %q", *format)
+	}
+	switch strings.ToLower(*color) {
+	case "always":
+		opts.color = true
+	case "never":
+	case "auto":
+		opts.color = opts.format == "console" && isTerminal(stdout) && os.Getenv("NO_COLOR") == ""
+	default:
+		return nil, fmt.Errorf("unknown color mode %q", *color)
+	}
+	if opts.follow && len(opts.files) != 1 {
+		return nil, errors.New("-F needs exactly one file")
+	}
+	return opts, nil
+}
+
+// processFile reads one file, following it if requested.
+func processFile(ctx context.Context, path string, cfg dd.ReaderConfig, opts *options, out *renderer) error {
+	if !opts.follow {
+		r, err := dd.OpenLogFile(path, cfg)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		return process(r, opts, out)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return process(dd.NewReader(&followReader{ctx: ctx, r: f}, cfg), opts, out)
+}
+
+// process prints every matching record of r.
+func process(r *dd.Reader, opts *options, out *renderer) error {
+	for r.Next() {
+		rec := r.Record()
+		if rec.Level < opts.level || !matchAll(opts.filters, rec) {
+			continue
+		}
+		if err := out.render(rec); err != nil {
+			return err
+		}
+	}
+	return r.Err()
+}
+
+// followReader blocks at the end of the file until more data is written
+// or ctx is done.
+type followReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (f *followReader) Read(p []byte) (int, error) {
+	for {
+		n, err := f.r.Read(p)
+		if n > 0 || !errors.Is(err, io.EOF) {
+			return n, err
+		}
+		select {
+		case <-f.ctx.Done():
+			return 0, io.EOF
+		case <-time.After(followInterval):
+		}
+	}
+}
+
+// isTerminal reports whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const sampleLog = `[2024-01-02T03:04:05Z   INFO] api.go:10 request user_id=123 status=200
+[2024-01-02T03:04:06Z  ERROR] api.go:20 request failed user_id=123 status=503 error=timeout
+{"timestamp":"2024-01-02T03:04:07Z","level":"WARN","message":"slow","fields":{"user_id":456,"took":"2.5s"}}
+`
+
+func runDDLog(t *testing.T, stdin string, args ...string) (string, string, int) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), stderr.String(), code
+}
+
+func TestRunFilters(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"level", []string{"-l", "warn"}, []string{"request failed", "slow"}},
+		{"field equals", []string{"-f", "user_id=123"}, []string{"request user_id", "request failed"}},
+		{"numeric", []string{"-f", "status>=500"}, []string{"request failed"}},
+		{"combined", []string{"-l", "error", "-f", "user_id=456"}, nil},
+		{"contains", []string{"-f", "message~slo"}, []string{"slow"}},
+		{"level key", []string{"-f", "level=warn"}, []string{"slow"}},
+		{"missing field", []string{"-f", "status!=200"}, []string{"request failed", "slow"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, errOut, code := runDDLog(t, sampleLog, tt.args...)
+			if code != 0 {
+				t.Fatalf("exit %d: %s", code, errOut)
+			}
+			lines := strings.Split(strings.TrimSpace(out), "\n")
+			if out == "" {
+				lines = nil
+			}
+			if len(lines) != len(tt.want) {
+				t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(tt.want), out)
+			}
+			for i, w := range tt.want {
+				if !strings.Contains(lines[i], w) {
+					t.Errorf("line %d = %q, want it to contain %q", i, lines[i], w)
+				}
+			}
+		})
+	}
+}
+
+func TestRunFormats(t *testing.T) {
+	out, _, _ := runDDLog(t, sampleLog, "-o", "json", "-l", "error")
+	want := `{"timestamp":"2024-01-02T03:04:06Z","level":"ERROR","caller":"api.go:20","message":"request failed","fields":{"user_id":123,"status":503,"error":"timeout"}}`
+	if strings.TrimSpace(out) != want {
+		t.Errorf("json output:\n%s\nwant:\n%s", out, want)
+	}
+
+	out, _, _ = runDDLog(t, sampleLog, "-o", "text", "-f", "user_id=456")
+	if strings.TrimSpace(out) != `[2024-01-02T03:04:07Z  WARN] slow user_id=456 took=2.5s` {
+		t.Errorf("text output: %q", out)
+	}
+
+	out, _, _ = runDDLog(t, sampleLog, "-o", "console", "-color", "always", "-f", "user_id=456")
+	if !strings.Contains(out, "\x1b[33mWARN \x1b[0m slow\n") || !strings.Contains(out, "took\x1b[0m:    2.5s") {
+		t.Errorf("console output: %q", out)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"-l", "loud"},
+		{"-o", "yaml"},
+		{"-f", "novalue"},
+		{"-f", "status>abc"},
+		{"-F"},
+		{"-unknown"},
+	} {
+		if _, errOut, code := runDDLog(t, "", args...); code != 2 || errOut == "" {
+			t.Errorf("%v: exit %d, stderr %q", args, code, errOut)
+		}
+	}
+
+	if _, errOut, code := runDDLog(t, "", filepath.Join(t.TempDir(), "missing.log")); code != 1 || !strings.Contains(errOut, "missing.log") {
+		t.Errorf("missing file: exit %d, stderr %q", code, errOut)
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestRunFollow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("[2024-01-02T03:04:05Z   INFO] first\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var stdout, stderr syncBuffer
+	done := make(chan int)
+	go func() {
+		done <- run(ctx, []string{"-F", path}, nil, &stdout, &stderr)
+	}()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("[2024-01-02T03:04:06Z   INFO] second\n[2024-01-02T03:04:07Z   INFO] third\n")
+	f.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(stdout.String(), "second") && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	cancel()
+	if code := <-done; code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "first") || !strings.Contains(out, "second") || !strings.Contains(out, "third") {
+		t.Errorf("followed output: %q", out)
+	}
+}
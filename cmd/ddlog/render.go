@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cybergodev/dd"
+	"github.com/cybergodev/dd/internal"
+)
+
+// ANSI colors of the console output, indexed by level.
+var levelColors = [...]string{
+	dd.LevelDebug: "\x1b[90m",
+	dd.LevelInfo:  "\x1b[32m",
+	dd.LevelWarn:  "\x1b[33m",
+	dd.LevelError: "\x1b[31m",
+	dd.LevelFatal: "\x1b[1;35m",
+}
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiDim   = "\x1b[2m"
+	ansiCyan  = "\x1b[36m"
+)
+
+// renderer writes records in one output format.
+type renderer struct {
+	w      *bufio.Writer
+	format string
+	color  bool
+	buf    bytes.Buffer
+}
+
+func newRenderer(w io.Writer, format string, color bool) *renderer {
+	return &renderer{w: bufio.NewWriter(w), format: format, color: color}
+}
+
+// render writes rec and flushes, so followed output appears immediately.
+func (r *renderer) render(rec *dd.Record) error {
+	r.buf.Reset()
+	switch r.format {
+	case "json":
+		if err := r.renderJSON(rec); err != nil {
+			return err
+		}
+	case "console":
+		r.renderConsole(rec)
+	default:
+		r.renderText(rec)
+	}
+	r.buf.WriteByte('\n')
+	if _, err := r.w.Write(r.buf.Bytes()); err != nil {
+		return err
+	}
+	return r.w.Flush()
+}
+
+// renderText writes dd's text layout: [time LEVEL] caller message k=v.
+func (r *renderer) renderText(rec *dd.Record) {
+	r.buf.WriteByte('[')
+	if !rec.Time.IsZero() {
+		r.buf.WriteString(rec.Time.Format(dd.DefaultTimeFormat))
+		r.buf.WriteByte(' ')
+	}
+	fmt.Fprintf(&r.buf, "%5s]", rec.Level)
+	if rec.Caller != "" {
+		r.buf.WriteByte(' ')
+		r.buf.WriteString(rec.Caller)
+	}
+	r.buf.WriteByte(' ')
+	r.buf.WriteString(rec.Message)
+	if fields := internal.FormatFields(rec.Fields); fields != "" {
+		r.buf.WriteByte(' ')
+		r.buf.WriteString(fields)
+	}
+}
+
+// renderJSON writes dd's default JSON layout with fields in input order.
+func (r *renderer) renderJSON(rec *dd.Record) error {
+	r.buf.WriteByte('{')
+	if !rec.Time.IsZero() {
+		r.writeJSONString("timestamp", rec.Time.Format(time.RFC3339Nano), true)
+	}
+	r.writeJSONString("level", rec.Level.String(), rec.Time.IsZero())
+	if rec.Caller != "" {
+		r.writeJSONString("caller", rec.Caller, false)
+	}
+	r.writeJSONString("message", rec.Message, false)
+
+	if len(rec.Fields) > 0 {
+		r.buf.WriteString(`,"fields":{`)
+		for i, f := range rec.Fields {
+			if err := r.writeJSONValueMember(f.Key, f.Value, i == 0); err != nil {
+				return err
+			}
+		}
+		r.buf.WriteByte('}')
+	}
+	r.buf.WriteByte('}')
+	return nil
+}
+
+// writeJSONString writes a string member, which cannot fail to encode.
+func (r *renderer) writeJSONString(key, value string, first bool) {
+	_ = r.writeJSONValueMember(key, value, first)
+}
+
+// writeJSONValueMember writes "key":value, preceded by a comma unless first.
+func (r *renderer) writeJSONValueMember(key string, value any, first bool) error {
+	if !first {
+		r.buf.WriteByte(',')
+	}
+	k, _ := json.Marshal(key)
+	v, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("field %q: %w", key, err)
+	}
+	r.buf.Write(k)
+	r.buf.WriteByte(':')
+	r.buf.Write(v)
+	return nil
+}
+
+// renderConsole writes the layout of dd.FormatConsole: aligned columns
+// and one indented line per field.
+func (r *renderer) renderConsole(rec *dd.Record) {
+	if !rec.Time.IsZero() {
+		r.colored(ansiDim, rec.Time.Format("15:04:05.000"))
+		r.buf.WriteByte(' ')
+	}
+	color := ""
+	if int(rec.Level) < len(levelColors) {
+		color = levelColors[rec.Level]
+	}
+	r.colored(color, fmt.Sprintf("%-5s", rec.Level))
+	r.buf.WriteByte(' ')
+	if rec.Caller != "" {
+		r.colored(ansiDim, fmt.Sprintf("%-24s", rec.Caller))
+		r.buf.WriteByte(' ')
+	}
+	r.buf.WriteString(rec.Message)
+
+	width := 0
+	for _, f := range rec.Fields {
+		width = max(width, len(f.Key))
+	}
+	for _, f := range rec.Fields {
+		r.buf.WriteString("\n    ")
+		r.colored(ansiCyan, f.Key)
+		r.buf.WriteByte(':')
+		r.buf.WriteString(strings.Repeat(" ", width-len(f.Key)+1))
+		r.writeConsoleValue(f.Value)
+	}
+}
+
+// writeConsoleValue writes a value; multi-line strings continue on
+// indented lines.
+func (r *renderer) writeConsoleValue(v any) {
+	s, ok := v.(string)
+	if !ok {
+		r.buf.WriteString(strings.TrimPrefix(internal.FormatFields([]dd.Field{{Key: "v", Value: v}}), "v="))
+		return
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		s = internal.HumanizeDuration(d)
+	}
+	first, rest, _ := strings.Cut(s, "\n")
+	r.buf.WriteString(first)
+	for _, line := range strings.Split(rest, "\n") {
+		if strings.TrimSpace(line) == "" || line == "Stack:" {
+			continue
+		}
+		r.buf.WriteString("\n        ")
+		r.buf.WriteString(strings.TrimLeft(line, " \t"))
+	}
+}
+
+func (r *renderer) colored(code, s string) {
+	if !r.color || code == "" {
+		r.buf.WriteString(s)
+		return
+	}
+	r.buf.WriteString(code)
+	r.buf.WriteString(s)
+	r.buf.WriteString(ansiReset)
+}
@@ -0,0 +1,283 @@
+package dd
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// CompressionAlgorithm selects how FileWriter compresses rotated backups.
+type CompressionAlgorithm string
+
+const (
+	// CompressionNone keeps backups uncompressed, overriding Compress.
+	CompressionNone CompressionAlgorithm = "none"
+	// CompressionGzip writes ".gz" backups. It is built in and used when
+	// Compress is set without an algorithm.
+	CompressionGzip CompressionAlgorithm = "gzip"
+	// CompressionZstd writes ".zst" backups. It needs the
+	// github.com/cybergodev/dd/zstd module, which registers it:
+	//
+	//	import _ "github.com/cybergodev/dd/zstd"
+	CompressionZstd CompressionAlgorithm = "zstd"
+)
+
+// Compressor implements a CompressionAlgorithm. Level is the
+// CompressionLevel from the writer config; 0 asks for the algorithm's
+// default. NewWriter should reject levels it does not support, since it is
+// also called to validate the config.
+type Compressor struct {
+	Extension string // appended to compressed backups, e.g. ".zst"
+	NewWriter func(w io.Writer, level int) (io.WriteCloser, error)
+	NewReader func(r io.Reader) (io.ReadCloser, error)
+}
+
+var (
+	compressorsMu sync.RWMutex
+	compressors   = map[CompressionAlgorithm]Compressor{
+		CompressionGzip: {
+			Extension: ".gz",
+			NewWriter: func(w io.Writer, level int) (io.WriteCloser, error) {
+				if level == 0 {
+					level = gzip.DefaultCompression
+				}
+				return gzip.NewWriterLevel(w, level)
+			},
+			NewReader: func(r io.Reader) (io.ReadCloser, error) {
+				return gzip.NewReader(r)
+			},
+		},
+	}
+)
+
+// RegisterCompressor makes a compression algorithm available to
+// FileWriterConfig.CompressionAlgorithm and OpenLogFile. Registering an
+// algorithm again replaces it; CompressionNone cannot be registered.
+func RegisterCompressor(alg CompressionAlgorithm, c Compressor) error {
+	if alg == "" || alg == CompressionNone {
+		return fmt.Errorf("%w: invalid compression algorithm %q", ErrConfigValidation, alg)
+	}
+	if c.NewWriter == nil || c.NewReader == nil {
+		return fmt.Errorf("%w: compressor %q needs NewWriter and NewReader", ErrConfigValidation, alg)
+	}
+	if len(c.Extension) < 2 || c.Extension[0] != '.' {
+		return fmt.Errorf("%w: compressor %q has invalid extension %q", ErrConfigValidation, alg, c.Extension)
+	}
+
+	compressorsMu.Lock()
+	defer compressorsMu.Unlock()
+	compressors[alg] = c
+	return nil
+}
+
+// lookupCompressor returns the registered compressor for alg.
+func lookupCompressor(alg CompressionAlgorithm) (Compressor, bool) {
+	compressorsMu.RLock()
+	defer compressorsMu.RUnlock()
+	c, ok := compressors[alg]
+	return c, ok
+}
+
+// compressorForFile returns the registered compressor whose extension ends
+// path.
+func compressorForFile(path string) (Compressor, bool) {
+	compressorsMu.RLock()
+	defer compressorsMu.RUnlock()
+	for _, c := range compressors {
+		if strings.HasSuffix(path, c.Extension) {
+			return c, true
+		}
+	}
+	return Compressor{}, false
+}
+
+// compressedExtensions returns the extensions of all registered
+// compressors, sorted.
+func compressedExtensions() []string {
+	compressorsMu.RLock()
+	defer compressorsMu.RUnlock()
+	exts := make([]string, 0, len(compressors))
+	for _, c := range compressors {
+		exts = append(exts, c.Extension)
+	}
+	sort.Strings(exts)
+	return exts
+}
+
+// CompressionStats reports the progress of a FileWriter's compression
+// worker.
+type CompressionStats struct {
+	Algorithm CompressionAlgorithm // empty when compression is disabled
+	Pending   int                  // backups waiting to be compressed
+	Active    bool                 // a backup is being compressed now
+	Completed int64                // backups compressed
+	Failed    int64                // backups left uncompressed after an error
+	Skipped   int64                // backups left uncompressed because the queue was full
+	BytesIn   int64                // uncompressed bytes of completed backups
+	BytesOut  int64                // compressed bytes of completed backups
+}
+
+// compression is the compression state of a FileWriter.
+type compression struct {
+	algorithm CompressionAlgorithm
+	codec     internal.Codec
+	onError   func(path string, err error)
+	jobs      chan string
+
+	active    atomic.Bool
+	completed atomic.Int64
+	failed    atomic.Int64
+	skipped   atomic.Int64
+	bytesIn   atomic.Int64
+	bytesOut  atomic.Int64
+}
+
+// newCompression resolves the compression settings of config, returning
+// nil when backups stay uncompressed.
+func newCompression(config FileWriterConfig) (*compression, error) {
+	alg := config.CompressionAlgorithm
+	if alg == "" {
+		if !config.Compress {
+			return nil, nil
+		}
+		alg = CompressionGzip
+	}
+	if alg == CompressionNone {
+		return nil, nil
+	}
+
+	c, ok := lookupCompressor(alg)
+	if !ok {
+		return nil, fmt.Errorf("%w: compression algorithm %q is not registered", ErrConfigValidation, alg)
+	}
+	level := config.CompressionLevel
+	probe, err := c.NewWriter(io.Discard, level)
+	if err != nil {
+		return nil, fmt.Errorf("%w: compression level %d for %s: %w", ErrConfigValidation, level, alg, err)
+	}
+	probe.Close()
+
+	return &compression{
+		algorithm: alg,
+		codec: internal.Codec{
+			Ext: c.Extension,
+			NewWriter: func(w io.Writer) (io.WriteCloser, error) {
+				return c.NewWriter(w, level)
+			},
+			NewReader: c.NewReader,
+		},
+		onError: config.OnCompressError,
+		jobs:    make(chan string, compressionQueueSize),
+	}, nil
+}
+
+// CompressionStats returns the progress of backup compression. It is the
+// zero value when compression is disabled.
+func (fw *FileWriter) CompressionStats() CompressionStats {
+	c := fw.compression
+	if c == nil {
+		return CompressionStats{}
+	}
+	return CompressionStats{
+		Algorithm: c.algorithm,
+		Pending:   len(c.jobs),
+		Active:    c.active.Load(),
+		Completed: c.completed.Load(),
+		Failed:    c.failed.Load(),
+		Skipped:   c.skipped.Load(),
+		BytesIn:   c.bytesIn.Load(),
+		BytesOut:  c.bytesOut.Load(),
+	}
+}
+
+// queueCompression hands a rotated backup to the compression worker. If
+// the queue is full the backup stays uncompressed. Caller must hold fw.mu.
+func (fw *FileWriter) queueCompression(path string) {
+	c := fw.compression
+	select {
+	case c.jobs <- path:
+		return
+	default:
+	}
+
+	c.skipped.Add(1)
+	err := fmt.Errorf("%w: %d backups pending", ErrCompressionBacklog, compressionQueueSize)
+	fw.wg.Add(1)
+	go func() {
+		defer fw.wg.Done()
+		fw.compressFailed(path, err)
+		if fw.onRotate != nil {
+			fw.callOnRotate(path)
+		}
+	}()
+}
+
+// compressionWorker compresses queued backups one at a time, then reports
+// them to OnRotate. After Close it finishes the backups already queued.
+func (fw *FileWriter) compressionWorker() {
+	defer fw.wg.Done()
+	c := fw.compression
+	for {
+		select {
+		case path := <-c.jobs:
+			fw.finishRotation(path)
+		case <-fw.ctx.Done():
+			for {
+				select {
+				case path := <-c.jobs:
+					fw.finishRotation(path)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// finishRotation compresses the backup, then reports it to OnRotate.
+func (fw *FileWriter) finishRotation(path string) {
+	c := fw.compression
+	c.active.Store(true)
+	in, out, err := internal.CompressFileWith(path, c.codec)
+	c.active.Store(false)
+
+	if errors.Is(err, os.ErrNotExist) {
+		// Removed by retention before its turn
+		return
+	}
+	if err != nil {
+		c.failed.Add(1)
+		fw.compressFailed(path, err)
+	} else {
+		c.completed.Add(1)
+		c.bytesIn.Add(in)
+		c.bytesOut.Add(out)
+		path += c.codec.Ext
+	}
+	if fw.onRotate != nil {
+		fw.callOnRotate(path)
+	}
+}
+
+// compressFailed reports a backup left uncompressed to OnCompressError, or
+// to stderr without one.
+func (fw *FileWriter) compressFailed(path string, err error) {
+	if fw.compression.onError == nil {
+		reportInternal(nil, ComponentWriter, err, "compress backup %s: %v", path, err)
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			reportInternal(nil, ComponentWriter, nil, "OnCompressError callback panic for %s: %v", path, r)
+		}
+	}()
+	fw.compression.onError(path, err)
+}
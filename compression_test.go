@@ -0,0 +1,203 @@
+package dd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// nopWriteCloser adds a no-op Close to an io.Writer.
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestFileWriterCompressionGzipLevel(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	fw, err := NewFileWriter(path, FileWriterConfig{
+		MaxSizeMB:            1,
+		CompressionAlgorithm: CompressionGzip,
+		CompressionLevel:     9,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	forceRotation(t, fw)
+	if err := fw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "app_log_1.log.gz")); err != nil {
+		t.Errorf("gzip backup missing: %v", err)
+	}
+	stats := fw.CompressionStats()
+	if stats.Algorithm != CompressionGzip || stats.Completed != 1 || stats.Pending != 0 || stats.Active {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if stats.BytesIn != 600*1024 || stats.BytesOut <= 0 || stats.BytesOut >= stats.BytesIn {
+		t.Errorf("unexpected byte counts: in=%d out=%d", stats.BytesIn, stats.BytesOut)
+	}
+}
+
+func TestFileWriterCompressionNone(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	fw, err := NewFileWriter(path, FileWriterConfig{
+		MaxSizeMB:            1,
+		Compress:             true,
+		CompressionAlgorithm: CompressionNone,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	forceRotation(t, fw)
+	fw.Close()
+
+	if _, err := os.Stat(filepath.Join(dir, "app_log_1.log")); err != nil {
+		t.Errorf("CompressionNone should keep the backup plain: %v", err)
+	}
+	if stats := fw.CompressionStats(); stats != (CompressionStats{}) {
+		t.Errorf("stats should be empty without compression: %+v", stats)
+	}
+}
+
+func TestFileWriterCompressionInvalidConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	configs := []FileWriterConfig{
+		{CompressionAlgorithm: "brotli"},
+		{CompressionAlgorithm: CompressionGzip, CompressionLevel: 42},
+		{Compress: true, CompressionLevel: -5},
+	}
+	for _, cfg := range configs {
+		if _, err := NewFileWriter(path, cfg); !errors.Is(err, ErrConfigValidation) {
+			t.Errorf("%+v: expected ErrConfigValidation, got %v", cfg, err)
+		}
+	}
+}
+
+func TestRegisterCompressor(t *testing.T) {
+	identity := Compressor{
+		Extension: ".ident",
+		NewWriter: func(w io.Writer, level int) (io.WriteCloser, error) { return nopWriteCloser{w}, nil },
+		NewReader: func(r io.Reader) (io.ReadCloser, error) { return io.NopCloser(r), nil },
+	}
+
+	invalid := map[CompressionAlgorithm]Compressor{
+		"":              identity,
+		CompressionNone: identity,
+		"test-noext":    {NewWriter: identity.NewWriter, NewReader: identity.NewReader},
+		"test-nofuncs":  {Extension: ".x"},
+	}
+	for alg, c := range invalid {
+		if err := RegisterCompressor(alg, c); !errors.Is(err, ErrConfigValidation) {
+			t.Errorf("RegisterCompressor(%q) = %v, want ErrConfigValidation", alg, err)
+		}
+	}
+
+	if err := RegisterCompressor("test-identity", identity); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "app_log_1.log.ident")
+	if err := os.WriteFile(path, []byte("[2024-01-02T03:04:05Z   INFO] kept\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	fw, err := NewFileWriter(filepath.Join(t.TempDir(), "app.log"), FileWriterConfig{CompressionAlgorithm: "test-identity"})
+	if err != nil {
+		t.Fatalf("registered algorithm should be accepted: %v", err)
+	}
+	fw.Close()
+
+	r, err := OpenLogFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if !r.Next() || r.Record().Message != "kept" {
+		t.Errorf("OpenLogFile should read registered extensions: %v", r.Err())
+	}
+}
+
+func TestFileWriterCompressionBacklog(t *testing.T) {
+	release := make(chan struct{})
+	blocking := Compressor{
+		Extension: ".blk",
+		NewWriter: func(w io.Writer, level int) (io.WriteCloser, error) {
+			if w != io.Discard {
+				<-release
+			}
+			return nopWriteCloser{w}, nil
+		},
+		NewReader: func(r io.Reader) (io.ReadCloser, error) { return io.NopCloser(r), nil },
+	}
+	if err := RegisterCompressor("test-blocking", blocking); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	var mu sync.Mutex
+	var failed []string
+	fw, err := NewFileWriter(filepath.Join(dir, "app.log"), FileWriterConfig{
+		CompressionAlgorithm: "test-blocking",
+		OnCompressError: func(path string, err error) {
+			if errors.Is(err, ErrCompressionBacklog) {
+				mu.Lock()
+				failed = append(failed, path)
+				mu.Unlock()
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backup := func(i int) string {
+		p := filepath.Join(dir, fmt.Sprintf("backup-%d.log", i))
+		if err := os.WriteFile(p, []byte("x\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	fw.mu.Lock()
+	fw.queueCompression(backup(0))
+	fw.mu.Unlock()
+	deadline := time.Now().Add(2 * time.Second)
+	for !fw.CompressionStats().Active && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	fw.mu.Lock()
+	for i := 1; i <= compressionQueueSize+1; i++ {
+		fw.queueCompression(backup(i))
+	}
+	fw.mu.Unlock()
+
+	stats := fw.CompressionStats()
+	if !stats.Active || stats.Pending != compressionQueueSize || stats.Skipped != 1 {
+		t.Errorf("unexpected stats while blocked: %+v", stats)
+	}
+
+	close(release)
+	fw.Close()
+
+	stats = fw.CompressionStats()
+	if stats.Completed != compressionQueueSize+1 || stats.Pending != 0 {
+		t.Errorf("Close should finish queued backups: %+v", stats)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(failed) != 1 || !strings.HasSuffix(failed[0], fmt.Sprintf("backup-%d.log", compressionQueueSize+1)) {
+		t.Fatalf("OnCompressError should report the skipped backup, got %v", failed)
+	}
+	if _, err := os.Stat(failed[0]); err != nil {
+		t.Errorf("skipped backup should stay uncompressed: %v", err)
+	}
+}
@@ -1,7 +1,12 @@
 package dd
 
 import (
+	"context"
+	"fmt"
 	"io"
+	"maps"
+	"os"
+	"slices"
 	"time"
 
 	"github.com/cybergodev/dd/internal"
@@ -14,6 +19,24 @@ type FileConfig struct {
 	MaxBackups int           // Max number of old log files to retain (default: 10)
 	MaxAge     time.Duration // Max duration to retain old log files (default: 30 days)
 	Compress   bool          // Enable gzip compression for rotated files (default: false)
+
+	// Compression of rotated files (see FileWriterConfig.CompressionAlgorithm)
+	CompressionAlgorithm CompressionAlgorithm         // gzip, zstd or none (default: gzip when Compress is set)
+	CompressionLevel     int                          // Algorithm-specific level (0 = default)
+	OnCompressError      func(path string, err error) // Called when a backup stays uncompressed
+
+	// Disk space guard (see FileWriterConfig.MinFreeBytes)
+	MinFreeBytes          int64                         // Enter degraded mode below this many free bytes (0 = disabled)
+	MinFreeDiskPercent    float64                       // Enter emergency mode below this free percentage (0 = disabled)
+	MaxTotalSizeMB        int                           // Cap on active file plus backups (0 = disabled)
+	PruneBackupsOnLowDisk bool                          // Delete oldest backups while free space is low
+	OnDiskPressure        func(event DiskPressureEvent) // Called on degraded mode changes and pruning
+
+	// Backup naming and rotation callback (see FileWriterConfig)
+	BackupNameTemplate string                           // e.g. "app-{{.Time}}-{{.Seq}}.log"
+	BackupNameFunc     func(info BackupNameInfo) string // Takes precedence over the template
+	BackupGlob         string                           // Retention pattern for BackupNameFunc
+	OnRotate           func(backupPath string)          // Called after each rotation
 }
 
 // Config provides a struct-based configuration API for creating loggers.
@@ -29,6 +52,10 @@ type Config struct {
 	// Log level
 	Level LogLevel
 
+	// LevelEnv names an environment variable that overrides Level when set
+	// and non-empty (e.g. "LOG_LEVEL"). Invalid values make New() fail.
+	LevelEnv string
+
 	// Output format
 	Format LogFormat
 
@@ -37,32 +64,169 @@ type Config struct {
 	IncludeTime  bool
 	IncludeLevel bool
 
+	// TimeLocation converts timestamps to a time zone, e.g. time.UTC
+	// (nil keeps the local zone).
+	TimeLocation *time.Location
+
+	// TimePrecision replaces the fractional seconds of TimeFormat with
+	// 0, 3, 6 or 9 digits. With TimeEpoch it sets the epoch unit.
+	TimePrecision TimePrecision
+
+	// TimeEpoch writes timestamps as Unix epoch numbers (seconds, or the
+	// unit of TimePrecision) instead of formatted strings.
+	TimeEpoch bool
+
 	// Caller information
 	DynamicCaller bool
 	FullPath      bool
 
+	// IncludeGoroutineID adds the ID of the logging goroutine to every
+	// entry as a "goroutine" field. Off by default: finding the ID costs
+	// about 1µs per entry.
+	IncludeGoroutineID bool
+
 	// Output targets
 	Output  io.Writer   // Single output writer
 	Outputs []io.Writer // Multiple output writers
 	File    *FileConfig // File output configuration
 
+	// LevelOutputs sends entries of a level to its own writers instead of
+	// Output, Outputs and File. Levels without an entry keep using those.
+	// See RouteLevel.
+	LevelOutputs map[LogLevel][]io.Writer
+
+	// WriterMiddleware wraps every writer, including those added later
+	// with AddWriter, with byte-level transforms. The first middleware
+	// sees each entry first. See WriterMiddleware.
+	WriterMiddleware []WriterMiddleware
+
+	// Sinks are writers created by name from factories registered with
+	// RegisterWriterFactory, for configuration loaded at runtime.
+	Sinks []SinkConfig
+
 	// JSON configuration
 	JSON *JSONOptions
 
+	// Text configuration (nil uses defaults)
+	Text *TextOptions
+
+	// Console configuration for FormatConsole (nil uses defaults)
+	Console *ConsoleOptions
+
 	// Security configuration
 	Security *SecurityConfig
 
 	// Field validation configuration
 	FieldValidation *FieldValidationConfig
 
+	// Schema checks field types and required fields at log time (nil
+	// disables it). See LogSchema.
+	Schema *LogSchema
+
 	// Lifecycle handlers
 	FatalHandler      FatalHandler
 	WriteErrorHandler WriteErrorHandler
 
+	// ShutdownHooks are registered with OnShutdown, in order, when the
+	// logger is created.
+	ShutdownHooks []ShutdownHook
+
+	// InternalErrorHandler receives the logger's own warnings and errors
+	// (field validation, hook and fatal timeouts, writer quarantine) in
+	// place of stderr. See SetInternalErrorHandler for writer events.
+	InternalErrorHandler InternalErrorHandler
+
+	// FatalPolicy selects whether Fatal exits through FatalHandler or
+	// panics so deferred functions run (see FatalPolicyPanic).
+	FatalPolicy FatalPolicy
+
+	// FatalTimeout bounds the OnFatal hooks and the final close on Fatal
+	// (default 5s).
+	FatalTimeout time.Duration
+
+	// FatalStackDump adds the stacks of all goroutines to FATAL entries as
+	// a "goroutines" field.
+	FatalStackDump bool
+
+	// CrashDumpPath, when set, appends each FATAL entry with the stacks of
+	// all goroutines to this file before the program exits.
+	CrashDumpPath string
+
 	// Extensibility
 	ContextExtractors []ContextExtractor
 	Hooks             *HookRegistry
 	Sampling          *SamplingConfig
+
+	// RateLimit drops entries above a per-second rate (nil disables it).
+	RateLimit *RateLimitConfig
+
+	// Adaptive samples more aggressively while writers are slow or
+	// backed up (nil disables it). See AdaptiveConfig.
+	Adaptive *AdaptiveConfig
+
+	// Events configures the schemas, sampling and outputs of the
+	// structured events written by Logger.Emit (nil: no rules).
+	Events *EventsConfig
+
+	// MaxMemoryBytes caps the memory held by the logger: filter caches,
+	// entries being written and writers implementing MemoryUser, such as
+	// ring buffers and the queues of SocketWriter and LokiWriter. Entries
+	// that would exceed it are handled by MemoryPolicy; FATAL entries are
+	// always written. Zero is unlimited. See Logger.MemoryStats.
+	MaxMemoryBytes int64
+
+	// MemoryPolicy selects what happens at MaxMemoryBytes (default:
+	// MemoryDrop).
+	MemoryPolicy MemoryPolicy
+
+	// RuntimeStats adds Go runtime metrics to entries at or above a level
+	// and optionally logs them on a timer (nil disables it).
+	RuntimeStats *RuntimeStatsConfig
+
+	// Quarantine stops writing to a writer after repeated failures and
+	// retries it with backoff (nil disables it).
+	Quarantine *QuarantineConfig
+
+	// ExtractorGuard bounds context extractor calls and disables
+	// extractors that keep failing (nil only recovers panics).
+	ExtractorGuard *ExtractorGuardConfig
+
+	// ContextPolicy controls how *Ctx methods handle canceled contexts and
+	// missing request IDs.
+	ContextPolicy *ContextPolicy
+
+	// FieldConflicts resolves repeated field keys (default: last wins).
+	FieldConflicts FieldConflictPolicy
+
+	// GlobalFields are added to every entry, e.g. from MetadataFields.
+	// They are rendered once at startup and are not filtered; fields
+	// logged with an entry override them.
+	GlobalFields []Field
+
+	// IncludeBuildInfo adds the fields of BuildInfoFields to every entry
+	// or to a single entry written at startup (default: BuildInfoNone).
+	IncludeBuildInfo BuildInfoMode
+
+	// IngestTime adds an IngestTimeKey field with the clock time to
+	// entries logged with an explicit timestamp (LogAt, Event.At).
+	IngestTime bool
+
+	// Clock supplies timestamps, sampling ticks and backup file names
+	// (nil uses the system clock). See ManualClock and CoarseClock.
+	Clock Clock
+
+	// ValueEncoders render field values of types without native support,
+	// e.g. decimal.Decimal or uuid.UUID, in place of reflection. See
+	// ValueEncoder and EncodeType.
+	ValueEncoders []ValueEncoder
+
+	// Fingerprint adds a FingerprintKey field to ERROR and FATAL entries
+	// so aggregators can group them by cause rather than raw message.
+	Fingerprint bool
+
+	// FingerprintFunc computes fingerprints in place of DefaultFingerprint.
+	// Setting it enables Fingerprint.
+	FingerprintFunc FingerprintFunc
 }
 
 // DefaultConfig creates a new Config with default settings.
@@ -104,7 +268,7 @@ func defaultConfig() *Config {
 func DevelopmentConfig() *Config {
 	return &Config{
 		Level:         LevelDebug,
-		Format:        FormatText,
+		Format:        FormatConsole,
 		TimeFormat:    devTimeFormat,
 		IncludeTime:   true,
 		IncludeLevel:  true,
@@ -143,13 +307,121 @@ func JSONConfig() *Config {
 	}
 }
 
+// ECSVersion is the Elastic Common Schema version reported by ECSConfig.
+const ECSVersion = "8.11.0"
+
+// ECSConfig creates a Config emitting JSON that follows Elastic Common Schema
+// logging conventions: "@timestamp", lowercase "log.level", "message",
+// "ecs.version", and fields at the top level. Context methods (InfoCtx etc.)
+// add "trace.id", "span.id" and "http.request.id".
+//
+// Example:
+//
+//	logger, _ := dd.New(dd.ECSConfig())
+//	logger.InfoCtx(dd.WithTraceID(ctx, traceID), "order placed")
+func ECSConfig() *Config {
+	cfg := JSONConfig()
+	cfg.Level = LevelInfo
+	cfg.TimeFormat = "2006-01-02T15:04:05.000Z07:00"
+	cfg.JSON = &internal.JSONOptions{
+		Indent: defaultJSONIndent,
+		FieldNames: &internal.JSONFieldNames{
+			Timestamp: "@timestamp",
+			Level:     "log.level",
+			Caller:    "log.origin.file.name",
+			Message:   "message",
+			Fields:    "labels",
+		},
+		FieldOrder:    JSONOrderInsertion,
+		FlattenFields: true,
+		LevelNames: map[LogLevel]string{
+			LevelDebug: "debug",
+			LevelInfo:  "info",
+			LevelWarn:  "warn",
+			LevelError: "error",
+			LevelFatal: "fatal",
+		},
+		StaticFields: []Field{{Key: "ecs.version", Value: ECSVersion}},
+	}
+	cfg.ContextExtractors = []ContextExtractor{
+		contextStringExtractor("trace.id", GetTraceID),
+		contextStringExtractor("span.id", GetSpanID),
+		contextStringExtractor("http.request.id", GetRequestID),
+	}
+	return cfg
+}
+
+// GCPConfig creates a Config emitting JSON for Google Cloud Logging:
+// "severity" with Cloud Logging severity names (WARNING, CRITICAL for
+// FATAL), "timestamp", "message", and fields at the top level. Context
+// methods add "logging.googleapis.com/trace" and ".../spanId"; when the
+// GOOGLE_CLOUD_PROJECT environment variable is set, the trace is written as
+// "projects/<project>/traces/<id>" so Cloud Logging can correlate it.
+//
+// Example:
+//
+//	logger, _ := dd.New(dd.GCPConfig())
+//	logger.ErrorCtx(dd.WithTraceID(ctx, traceID), "payment failed")
+func GCPConfig() *Config {
+	cfg := JSONConfig()
+	cfg.Level = LevelInfo
+	cfg.TimeFormat = time.RFC3339Nano
+	cfg.JSON = &internal.JSONOptions{
+		Indent: defaultJSONIndent,
+		FieldNames: &internal.JSONFieldNames{
+			Timestamp: "timestamp",
+			Level:     "severity",
+			Caller:    "caller",
+			Message:   "message",
+			Fields:    "fields",
+		},
+		FieldOrder:    JSONOrderInsertion,
+		FlattenFields: true,
+		LevelNames: map[LogLevel]string{
+			LevelDebug: "DEBUG",
+			LevelInfo:  "INFO",
+			LevelWarn:  "WARNING",
+			LevelError: "ERROR",
+			LevelFatal: "CRITICAL",
+		},
+	}
+
+	project := os.Getenv("GOOGLE_CLOUD_PROJECT")
+	cfg.ContextExtractors = []ContextExtractor{
+		contextStringExtractor("logging.googleapis.com/trace", func(ctx context.Context) string {
+			traceID := GetTraceID(ctx)
+			if traceID == "" || project == "" {
+				return traceID
+			}
+			return "projects/" + project + "/traces/" + traceID
+		}),
+		contextStringExtractor("logging.googleapis.com/spanId", GetSpanID),
+		contextStringExtractor("request_id", GetRequestID),
+	}
+	return cfg
+}
+
+// contextStringExtractor returns an extractor adding key when get returns a
+// non-empty value.
+func contextStringExtractor(key string, get func(context.Context) string) ContextExtractor {
+	return func(ctx context.Context) []Field {
+		if value := get(ctx); value != "" {
+			return []Field{{Key: key, Value: value}}
+		}
+		return nil
+	}
+}
+
 // Clone creates a copy of the configuration.
 //
 // Clone behavior:
 //   - Deep copy: File, JSON, Sampling, Security, Hooks configs
-//   - Shallow copy: Output, Outputs, FatalHandler, WriteErrorHandler, FieldValidation
+//   - Shallow copy: Output, Outputs, LevelOutputs, FatalHandler, WriteErrorHandler, FieldValidation, Clock, TimeLocation
 //     (io.Writer instances and function pointers are shared)
 //   - ContextExtractors slice is copied but extractor instances are shared
+//   - GlobalFields slice is copied but field values are shared
+//   - ValueEncoders slice is copied but encoder instances are shared
+//   - WriterMiddleware slice is copied but middleware functions are shared
 //
 // The shallow copy behavior for io.Writer is intentional since writers are
 // typically shared resources that should not be duplicated.
@@ -167,19 +439,36 @@ func (c *Config) Clone() *Config {
 		return nil
 	}
 	clone := &Config{
-		Level:             c.Level,
-		Format:            c.Format,
-		TimeFormat:        c.TimeFormat,
-		IncludeTime:       c.IncludeTime,
-		IncludeLevel:      c.IncludeLevel,
-		FullPath:          c.FullPath,
-		DynamicCaller:     c.DynamicCaller,
-		Output:            c.Output,
-		Security:          c.Security,
-		FieldValidation:   c.FieldValidation,
-		FatalHandler:      c.FatalHandler,
-		WriteErrorHandler: c.WriteErrorHandler,
-		Sampling:          c.Sampling,
+		Level:                c.Level,
+		LevelEnv:             c.LevelEnv,
+		Format:               c.Format,
+		TimeFormat:           c.TimeFormat,
+		TimeLocation:         c.TimeLocation,
+		TimePrecision:        c.TimePrecision,
+		TimeEpoch:            c.TimeEpoch,
+		IncludeTime:          c.IncludeTime,
+		IncludeLevel:         c.IncludeLevel,
+		FullPath:             c.FullPath,
+		DynamicCaller:        c.DynamicCaller,
+		IncludeGoroutineID:   c.IncludeGoroutineID,
+		Output:               c.Output,
+		Security:             c.Security,
+		FieldValidation:      c.FieldValidation,
+		FatalHandler:         c.FatalHandler,
+		FatalPolicy:          c.FatalPolicy,
+		FatalTimeout:         c.FatalTimeout,
+		FatalStackDump:       c.FatalStackDump,
+		CrashDumpPath:        c.CrashDumpPath,
+		WriteErrorHandler:    c.WriteErrorHandler,
+		InternalErrorHandler: c.InternalErrorHandler,
+		FieldConflicts:       c.FieldConflicts,
+		IngestTime:           c.IngestTime,
+		IncludeBuildInfo:     c.IncludeBuildInfo,
+		MaxMemoryBytes:       c.MaxMemoryBytes,
+		MemoryPolicy:         c.MemoryPolicy,
+		Clock:                c.Clock,
+		Fingerprint:          c.Fingerprint,
+		FingerprintFunc:      c.FingerprintFunc,
 	}
 
 	// Copy Outputs slice
@@ -188,6 +477,14 @@ func (c *Config) Clone() *Config {
 		copy(clone.Outputs, c.Outputs)
 	}
 
+	// Copy Sinks
+	if c.Sinks != nil {
+		clone.Sinks = make([]SinkConfig, len(c.Sinks))
+		for i, sink := range c.Sinks {
+			clone.Sinks[i] = SinkConfig{Type: sink.Type, Params: maps.Clone(sink.Params)}
+		}
+	}
+
 	// Copy File config
 	if c.File != nil {
 		clone.File = &FileConfig{
@@ -196,15 +493,30 @@ func (c *Config) Clone() *Config {
 			MaxBackups: c.File.MaxBackups,
 			MaxAge:     c.File.MaxAge,
 			Compress:   c.File.Compress,
+
+			CompressionAlgorithm: c.File.CompressionAlgorithm,
+			CompressionLevel:     c.File.CompressionLevel,
+			OnCompressError:      c.File.OnCompressError,
+
+			MinFreeBytes:          c.File.MinFreeBytes,
+			MinFreeDiskPercent:    c.File.MinFreeDiskPercent,
+			MaxTotalSizeMB:        c.File.MaxTotalSizeMB,
+			PruneBackupsOnLowDisk: c.File.PruneBackupsOnLowDisk,
+			OnDiskPressure:        c.File.OnDiskPressure,
+
+			BackupNameTemplate: c.File.BackupNameTemplate,
+			BackupNameFunc:     c.File.BackupNameFunc,
+			BackupGlob:         c.File.BackupGlob,
+			OnRotate:           c.File.OnRotate,
 		}
 	}
 
 	// Copy JSON options
 	if c.JSON != nil {
-		clone.JSON = &internal.JSONOptions{
-			PrettyPrint: c.JSON.PrettyPrint,
-			Indent:      c.JSON.Indent,
-		}
+		jsonOpts := *c.JSON
+		jsonOpts.LevelNames = maps.Clone(c.JSON.LevelNames)
+		jsonOpts.StaticFields = slices.Clone(c.JSON.StaticFields)
+		clone.JSON = &jsonOpts
 		if c.JSON.FieldNames != nil {
 			clone.JSON.FieldNames = &internal.JSONFieldNames{
 				Timestamp: c.JSON.FieldNames.Timestamp,
@@ -216,6 +528,26 @@ func (c *Config) Clone() *Config {
 		}
 	}
 
+	// Copy Text options
+	if c.Text != nil {
+		text := *c.Text
+		clone.Text = &text
+	}
+
+	// Copy Console options
+	if c.Console != nil {
+		console := *c.Console
+		clone.Console = &console
+	}
+
+	// Copy LevelOutputs
+	if c.LevelOutputs != nil {
+		clone.LevelOutputs = make(map[LogLevel][]io.Writer, len(c.LevelOutputs))
+		for level, writers := range c.LevelOutputs {
+			clone.LevelOutputs[level] = append([]io.Writer(nil), writers...)
+		}
+	}
+
 	// Copy ContextExtractors
 	if c.ContextExtractors != nil {
 		clone.ContextExtractors = make([]ContextExtractor, len(c.ContextExtractors))
@@ -233,18 +565,64 @@ func (c *Config) Clone() *Config {
 	}
 
 	// Copy Sampling config
-	if c.Sampling != nil {
-		clone.Sampling = &SamplingConfig{
-			Enabled:    c.Sampling.Enabled,
-			Initial:    c.Sampling.Initial,
-			Thereafter: c.Sampling.Thereafter,
-			Tick:       c.Sampling.Tick,
-		}
+	clone.Sampling = c.Sampling.Clone()
+
+	// Copy RateLimit config
+	if c.RateLimit != nil {
+		clone.RateLimit = c.RateLimit.Clone()
+	}
+	clone.RuntimeStats = c.RuntimeStats.Clone()
+	clone.Schema = c.Schema.Clone()
+	clone.Adaptive = c.Adaptive.Clone()
+	clone.Events = c.Events.Clone()
+	if c.Quarantine != nil {
+		quarantine := *c.Quarantine
+		clone.Quarantine = &quarantine
+	}
+	if c.ExtractorGuard != nil {
+		guard := *c.ExtractorGuard
+		clone.ExtractorGuard = &guard
 	}
 
+	// Copy ContextPolicy
+	if c.ContextPolicy != nil {
+		policy := *c.ContextPolicy
+		clone.ContextPolicy = &policy
+	}
+
+	// Copy GlobalFields
+	if c.GlobalFields != nil {
+		clone.GlobalFields = make([]Field, len(c.GlobalFields))
+		copy(clone.GlobalFields, c.GlobalFields)
+	}
+	clone.ValueEncoders = slices.Clone(c.ValueEncoders)
+	clone.ShutdownHooks = slices.Clone(c.ShutdownHooks)
+	clone.WriterMiddleware = slices.Clone(c.WriterMiddleware)
+
 	return clone
 }
 
+// RouteLevel sends entries at level and above to writers instead of the
+// regular outputs (Output, Outputs and File), which keep the lower levels.
+// Writers add to any already routed for those levels. It returns c for
+// chaining.
+//
+// Example:
+//
+//	cfg := dd.DefaultConfig()
+//	cfg.Output = os.Stdout // DEBUG-WARN
+//	cfg.RouteLevel(dd.LevelError, os.Stderr, errorFile) // ERROR and FATAL
+//	logger, _ := dd.New(cfg)
+func (c *Config) RouteLevel(level LogLevel, writers ...io.Writer) *Config {
+	if c.LevelOutputs == nil {
+		c.LevelOutputs = make(map[LogLevel][]io.Writer)
+	}
+	for l := max(level, LevelDebug); l <= LevelFatal; l++ {
+		c.LevelOutputs[l] = append(c.LevelOutputs[l], writers...)
+	}
+	return c
+}
+
 // ============================================================================
 // JSON Options
 // ============================================================================
@@ -255,6 +633,45 @@ type JSONOptions = internal.JSONOptions
 // JSONFieldNames configures custom field names for JSON output.
 type JSONFieldNames = internal.JSONFieldNames
 
+// JSONFieldOrder controls the order of keys in JSON output.
+type JSONFieldOrder = internal.JSONFieldOrder
+
+const (
+	// JSONOrderAny leaves key order unspecified. This is the default.
+	JSONOrderAny = internal.JSONOrderAny
+	// JSONOrderInsertion writes timestamp, level, caller and message first,
+	// then fields in the order they were logged.
+	JSONOrderInsertion = internal.JSONOrderInsertion
+	// JSONOrderSorted writes the standard keys first, then fields sorted by key.
+	JSONOrderSorted = internal.JSONOrderSorted
+)
+
+// JSONInts selects how 64-bit integers are written in JSON output.
+type JSONInts = internal.JSONInts
+
+const (
+	// JSONIntsNumber writes integers as numbers. This is the default.
+	JSONIntsNumber = internal.JSONIntsNumber
+	// JSONIntsUnsafeAsString writes integers beyond ±(2^53-1) as strings so
+	// JavaScript consumers do not lose precision.
+	JSONIntsUnsafeAsString = internal.JSONIntsUnsafeAsString
+	// JSONIntsAsString writes every 64-bit integer as a string.
+	JSONIntsAsString = internal.JSONIntsAsString
+)
+
+// JSONNonFinite selects how NaN and ±Inf floats are written in JSON output.
+type JSONNonFinite = internal.JSONNonFinite
+
+const (
+	// JSONNonFiniteError replaces the entry with an "error" entry. This is
+	// the default.
+	JSONNonFiniteError = internal.JSONNonFiniteError
+	// JSONNonFiniteNull writes null.
+	JSONNonFiniteNull = internal.JSONNonFiniteNull
+	// JSONNonFiniteString writes "NaN", "+Inf" or "-Inf".
+	JSONNonFiniteString = internal.JSONNonFiniteString
+)
+
 // DefaultJSONOptions returns default JSON options.
 func DefaultJSONOptions() *JSONOptions {
 	return &JSONOptions{
@@ -264,6 +681,83 @@ func DefaultJSONOptions() *JSONOptions {
 	}
 }
 
+// ============================================================================
+// Text Options
+// ============================================================================
+
+// TextOptions configures text output format.
+type TextOptions = internal.TextOptions
+
+// StackTraceMode controls how stack trace fields (ErrWithStack, or fields
+// named "stack"/"stacktrace"/"stack_trace") are rendered in text output.
+type StackTraceMode = internal.StackTraceMode
+
+const (
+	// StackTraceIndent renders the stack as an indented block after the
+	// log line. This is the default.
+	StackTraceIndent = internal.StackTraceIndent
+	// StackTraceFold keeps the first line and replaces the frames with a count.
+	StackTraceFold = internal.StackTraceFold
+	// StackTraceInline writes the raw multi-line value inline.
+	StackTraceInline = internal.StackTraceInline
+)
+
+// ============================================================================
+// Console Options
+// ============================================================================
+
+// ConsoleOptions configures FormatConsole output.
+type ConsoleOptions struct {
+	// Color selects when levels and field keys are colored.
+	Color ColorMode
+}
+
+// ColorMode controls ANSI colors in console output.
+type ColorMode int8
+
+const (
+	// ColorAuto colors output when every writer is a terminal and the
+	// NO_COLOR environment variable is unset. This is the default.
+	ColorAuto ColorMode = iota
+	// ColorAlways always colors output.
+	ColorAlways
+	// ColorNever never colors output.
+	ColorNever
+)
+
+// consoleOptions resolves the console options for writers.
+func (c *Config) consoleOptions(writers []io.Writer) *internal.ConsoleOptions {
+	mode := ColorAuto
+	if c.Console != nil {
+		mode = c.Console.Color
+	}
+	switch mode {
+	case ColorAlways:
+		return &internal.ConsoleOptions{Color: true}
+	case ColorNever:
+		return &internal.ConsoleOptions{}
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return &internal.ConsoleOptions{}
+	}
+	for _, w := range writers {
+		if !isTerminal(w) {
+			return &internal.ConsoleOptions{}
+		}
+	}
+	return &internal.ConsoleOptions{Color: true}
+}
+
+// isTerminal reports whether w is a file attached to a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
 // ============================================================================
 // Sampling Configuration
 // ============================================================================
@@ -282,4 +776,191 @@ type SamplingConfig struct {
 	// Tick is the time interval after which counters are reset.
 	// This allows sampling to restart periodically for burst handling.
 	Tick time.Duration
+
+	// Levels overrides the settings above for individual levels, each
+	// with its own counter. Levels without an entry share one counter.
+	//
+	//	Levels: map[dd.LogLevel]dd.LevelSampling{
+	//	    dd.LevelDebug: {Thereafter: 100},
+	//	    dd.LevelError: {KeepAll: true},
+	//	    dd.LevelFatal: {KeepAll: true},
+	//	}
+	Levels map[LogLevel]LevelSampling
+
+	// Deterministic keeps or drops entries by a hash of the trace ID in
+	// their context (see WithTraceID) instead of a counter, so every entry
+	// of a trace shares one decision. Services sampling with the same Rate
+	// and Seed keep the same traces. Entries without a trace ID are
+	// sampled by counter.
+	Deterministic bool
+
+	// Rate is the fraction of traces kept in deterministic mode, in [0, 1].
+	// Zero derives it from Thereafter (1/Thereafter).
+	Rate float64
+
+	// Seed changes which traces are kept at a given Rate.
+	Seed uint64
+
+	// Annotate adds SampledKey (true) and SampleDropRatioKey fields to
+	// kept entries so dashboards can reconstruct the rate before
+	// sampling. Drop counts are always available from
+	// Logger.SamplingStats.
+	Annotate bool
+}
+
+// LevelSampling is the sampling of a single level in SamplingConfig.
+type LevelSampling struct {
+	Initial    int
+	Thereafter int
+	Rate       float64 // Deterministic mode; zero derives it from Thereafter
+	KeepAll    bool    // Never drop entries of the level
+}
+
+// Clone returns a deep copy of the config.
+func (c *SamplingConfig) Clone() *SamplingConfig {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	if c.Levels != nil {
+		clone.Levels = make(map[LogLevel]LevelSampling, len(c.Levels))
+		for level, sampling := range c.Levels {
+			clone.Levels[level] = sampling
+		}
+	}
+	return &clone
+}
+
+// validate checks rates and level keys.
+func (c *SamplingConfig) validate() error {
+	if c.Rate < 0 || c.Rate > 1 {
+		return fmt.Errorf("%w: Sampling Rate %v is outside [0, 1]", ErrConfigValidation, c.Rate)
+	}
+	for level, sampling := range c.Levels {
+		if !level.IsValid() {
+			return fmt.Errorf("%w: Sampling Levels key %d", ErrInvalidLevel, level)
+		}
+		if sampling.Rate < 0 || sampling.Rate > 1 {
+			return fmt.Errorf("%w: Sampling Levels[%s] Rate %v is outside [0, 1]", ErrConfigValidation, level, sampling.Rate)
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// Rate Limit Configuration
+// ============================================================================
+
+// RateLimitConfig limits how many entries per second the logger writes.
+// Entries over the limit are dropped and counted (see Logger.RateLimitStats).
+// Unlike sampling, which thins out a steady stream, rate limiting caps
+// bursts and lets everything through below the limit.
+//
+// FATAL entries are never rate limited.
+//
+// Example:
+//
+//	cfg.RateLimit = &dd.RateLimitConfig{
+//	    PerSecond: 1000,
+//	    Burst:     200,
+//	    PerLevel: map[dd.LogLevel]dd.LevelRateLimit{
+//	        dd.LevelDebug: {PerSecond: 100},
+//	        dd.LevelError: {}, // no limit for errors
+//	    },
+//	    SummaryInterval: time.Minute,
+//	}
+type RateLimitConfig struct {
+	// PerSecond is the number of entries allowed per second across all
+	// levels without their own PerLevel entry. Zero means no limit.
+	PerSecond int
+	// Burst is the number of extra entries allowed within a second once
+	// PerSecond is used up.
+	Burst int
+	// PerLevel gives a level its own limit instead of the shared one.
+	// A zero LevelRateLimit exempts the level from rate limiting.
+	PerLevel map[LogLevel]LevelRateLimit
+	// SummaryInterval, if positive, makes the logger write a WARN entry
+	// with the number of dropped entries at this interval whenever any
+	// were dropped since the previous summary.
+	SummaryInterval time.Duration
+}
+
+// LevelRateLimit is the rate limit for a single level in RateLimitConfig.
+type LevelRateLimit struct {
+	PerSecond int // Zero means no limit
+	Burst     int
+}
+
+// Clone returns a deep copy of the config.
+func (c *RateLimitConfig) Clone() *RateLimitConfig {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	if c.PerLevel != nil {
+		clone.PerLevel = make(map[LogLevel]LevelRateLimit, len(c.PerLevel))
+		for level, limit := range c.PerLevel {
+			clone.PerLevel[level] = limit
+		}
+	}
+	return &clone
+}
+
+// validate checks the config for negative values and invalid levels.
+func (c *RateLimitConfig) validate() error {
+	if c.PerSecond < 0 || c.Burst < 0 {
+		return fmt.Errorf("%w: RateLimit PerSecond and Burst must not be negative", ErrConfigValidation)
+	}
+	if c.SummaryInterval < 0 {
+		return fmt.Errorf("%w: RateLimit SummaryInterval must not be negative", ErrConfigValidation)
+	}
+	for level, limit := range c.PerLevel {
+		if !level.IsValid() {
+			return fmt.Errorf("%w: RateLimit PerLevel key %d", ErrInvalidLevel, level)
+		}
+		if limit.PerSecond < 0 || limit.Burst < 0 {
+			return fmt.Errorf("%w: RateLimit PerLevel[%s] must not be negative", ErrConfigValidation, level)
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// Writer Quarantine Configuration
+// ============================================================================
+
+// QuarantineConfig takes failing writers out of service so a dead sink
+// (a hung NFS mount, an unreachable collector) does not slow down every
+// log call. After MaxFailures consecutive failed writes within Window, the
+// writer is quarantined: entries for it are skipped and counted in its
+// WriterStats. After InitialBackoff one entry is written to it as a probe;
+// if that succeeds the writer is restored, otherwise the backoff doubles
+// up to MaxBackoff. HookOnWriterQuarantined and HookOnWriterRestored
+// report the transitions.
+//
+// Example:
+//
+//	cfg.Quarantine = &dd.QuarantineConfig{MaxFailures: 3, Window: 10 * time.Second}
+type QuarantineConfig struct {
+	// MaxFailures is the number of consecutive failed writes that
+	// quarantines a writer (default 5).
+	MaxFailures int
+	// Window bounds a run of failures: a failure more than Window after
+	// the first of the run starts a new run (default 1 minute).
+	Window time.Duration
+	// InitialBackoff is the time before the first probe (default 1s).
+	InitialBackoff time.Duration
+	// MaxBackoff caps the time between probes (default 5 minutes).
+	MaxBackoff time.Duration
+}
+
+// validate checks the config for negative values.
+func (c *QuarantineConfig) validate() error {
+	if c.MaxFailures < 0 || c.Window < 0 || c.InitialBackoff < 0 || c.MaxBackoff < 0 {
+		return fmt.Errorf("%w: Quarantine values must not be negative", ErrConfigValidation)
+	}
+	if c.MaxBackoff > 0 && c.InitialBackoff > c.MaxBackoff {
+		return fmt.Errorf("%w: Quarantine InitialBackoff exceeds MaxBackoff", ErrConfigValidation)
+	}
+	return nil
 }
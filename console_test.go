@@ -0,0 +1,106 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConsoleFormatFieldsOnSeparateLines(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.Format = FormatConsole
+		cfg.DynamicCaller = false
+	})
+
+	logger.InfoWith("request handled",
+		String("method", "GET"),
+		Duration("duration", 1234567*time.Microsecond),
+		String("path", "/api/users"),
+	)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected message and 3 field lines, got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], " INFO  request handled") {
+		t.Errorf("unexpected head line: %q", lines[0])
+	}
+	want := []string{
+		"    method:   GET",
+		"    duration: 1.2s",
+		"    path:     /api/users",
+	}
+	for i, w := range want {
+		if lines[i+1] != w {
+			t.Errorf("field line %d = %q, want %q", i, lines[i+1], w)
+		}
+	}
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("buffer output should not be colored: %q", buf.String())
+	}
+}
+
+func TestConsoleFormatStackTrace(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.Format = FormatConsole
+		cfg.DynamicCaller = false
+	})
+
+	logger.ErrorWith("failed", ErrWithStack(errors.New("boom")))
+
+	out := buf.String()
+	if !strings.Contains(out, "\n    error: boom\n        ") {
+		t.Errorf("stack frames should be indented under the error: %q", out)
+	}
+	if strings.Contains(out, "\n        Stack:") {
+		t.Errorf("stack marker should be dropped: %q", out)
+	}
+}
+
+func TestConsoleFormatColor(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.Format = FormatConsole
+		cfg.DynamicCaller = false
+		cfg.Console = &ConsoleOptions{Color: ColorAlways}
+	})
+	logger.WarnWith("careful", Int("n", 1))
+
+	out := buf.String()
+	if !strings.Contains(out, "\x1b[33mWARN \x1b[0m") {
+		t.Errorf("level should be colored: %q", out)
+	}
+	if !strings.Contains(out, "\x1b[36mn\x1b[0m: 1") {
+		t.Errorf("field key should be colored: %q", out)
+	}
+}
+
+func TestConsoleColorAuto(t *testing.T) {
+	cfg := DevelopmentConfig()
+	if opts := cfg.consoleOptions([]io.Writer{&bytes.Buffer{}}); opts.Color {
+		t.Error("a buffer is not a terminal")
+	}
+	cfg.Console = &ConsoleOptions{Color: ColorNever}
+	if opts := cfg.consoleOptions(nil); opts.Color {
+		t.Error("ColorNever should disable color")
+	}
+}
+
+func TestConsoleFormatValidation(t *testing.T) {
+	cfg := DevelopmentConfig()
+	cfg.Console = &ConsoleOptions{Color: ColorMode(9)}
+	if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("expected ErrConfigValidation, got %v", err)
+	}
+
+	t.Setenv(EnvFormat, "console")
+	cfg, err := configFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Format != FormatConsole || FormatConsole.String() != "console" {
+		t.Errorf("DD_FORMAT=console not applied: %v", cfg.Format)
+	}
+}
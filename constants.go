@@ -12,6 +12,24 @@ type LogFormat = internal.LogFormat
 const (
 	FormatText LogFormat = internal.LogFormatText
 	FormatJSON LogFormat = internal.LogFormatJSON
+	// FormatConsole is a human-oriented layout for local development:
+	// aligned columns, level colors and one indented line per field.
+	// See ConsoleOptions.
+	FormatConsole LogFormat = internal.LogFormatConsole
+)
+
+// TimePrecision selects the sub-second digits of timestamps, or the unit
+// of epoch timestamps (see Config.TimeEpoch).
+type TimePrecision = internal.TimePrecision
+
+const (
+	// TimePrecisionDefault keeps TimeFormat unchanged; epoch timestamps
+	// are in seconds.
+	TimePrecisionDefault TimePrecision = internal.TimePrecisionDefault
+	TimePrecisionSecond  TimePrecision = internal.TimePrecisionSecond
+	TimePrecisionMilli   TimePrecision = internal.TimePrecisionMilli
+	TimePrecisionMicro   TimePrecision = internal.TimePrecisionMicro
+	TimePrecisionNano    TimePrecision = internal.TimePrecisionNano
 )
 
 const (
@@ -78,6 +96,31 @@ const (
 	// maxFileSizeMB limits the maximum size of a single log file to 10GB.
 	// Files larger than this will trigger rotation.
 	maxFileSizeMB = 10240
+
+	// diskSpaceCheckInterval limits how often FileWriter queries free disk
+	// space when the disk guard is enabled. statfs is cheap but not free.
+	diskSpaceCheckInterval = 5 * time.Second
+
+	// degradedMinLevel is the lowest level still written while a FileWriter
+	// is in low-disk degraded mode.
+	degradedMinLevel = LevelWarn
+
+	// emergencyMinLevel replaces degradedMinLevel while a FileWriter is
+	// below MinFreeDiskPercent or above MaxTotalSizeMB.
+	emergencyMinLevel = LevelError
+
+	// compressionQueueSize bounds the backups waiting for a FileWriter's
+	// compression worker. Backups rotated while it is full stay
+	// uncompressed.
+	compressionQueueSize = 16
+)
+
+// Writer quarantine defaults (see QuarantineConfig).
+const (
+	defaultQuarantineFailures   = 5
+	defaultQuarantineWindow     = time.Minute
+	defaultQuarantineBackoff    = time.Second
+	defaultQuarantineMaxBackoff = 5 * time.Minute
 )
 
 const (
@@ -137,10 +180,22 @@ const (
 	// the underlying writer is blocked or unresponsive.
 	defaultFatalFlushTimeout = 5 * time.Second
 
+	// defaultHookDrainTimeout bounds how long Close waits for queued async
+	// hooks to finish.
+	defaultHookDrainTimeout = 5 * time.Second
+
+	// defaultAsyncHookQueueSize is the async hook queue capacity when
+	// AsyncHookConfig.QueueSize is zero.
+	defaultAsyncHookQueueSize = 1024
+
 	// defaultLoggerCloseDelay is the delay before closing an old logger
 	// when SetDefault() is called with a new logger. This allows in-flight
 	// log operations to complete before the old logger is closed.
 	defaultLoggerCloseDelay = 100 * time.Millisecond
+
+	// defaultProgressInterval is the reporting interval of a Progress when
+	// ProgressConfig.Interval is zero.
+	defaultProgressInterval = 10 * time.Second
 )
 
 const (
@@ -3,9 +3,9 @@ package dd
 import (
 	"context"
 	"fmt"
-	"os"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // ============================================================================
@@ -75,15 +75,162 @@ func WithRequestID(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, ContextKeyRequestID, requestID)
 }
 
+// contextLoggerKey and contextFieldsKey are unexported so only this package
+// can store loggers and fields in a context.
+type (
+	contextLoggerKey struct{}
+	contextFieldsKey struct{}
+	contextLevelKey  struct{}
+)
+
+// NewContext returns a copy of ctx that carries logger.
+// Retrieve it with FromContext.
+//
+// Example:
+//
+//	ctx = dd.NewContext(ctx, logger)
+//	dd.FromContext(ctx).InfoCtx(ctx, "handled")
+func NewContext(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, contextLoggerKey{}, logger)
+}
+
+// FromContext returns the logger stored in ctx by NewContext,
+// or Default() if there is none.
+func FromContext(ctx context.Context) *Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(contextLoggerKey{}).(*Logger); ok && logger != nil {
+			return logger
+		}
+	}
+	return Default()
+}
+
+// ContextWithFields returns a copy of ctx with fields added to any fields
+// already stored in it. Later fields override earlier ones with the same key.
+// The accumulated fields are emitted by the *Ctx logging methods.
+//
+// Example:
+//
+//	ctx = dd.ContextWithFields(ctx, dd.String("user_id", id))
+//	logger.InfoCtx(ctx, "order placed", dd.Int("items", n)) // includes user_id
+func ContextWithFields(ctx context.Context, fields ...Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	var merged []Field
+	if existing := FieldsFromContext(ctx); len(existing) > 0 {
+		merged = mergeFieldSlices(existing, fields)
+	} else {
+		// Copy so later changes to the caller's slice do not leak in
+		merged = append([]Field(nil), fields...)
+	}
+	return context.WithValue(ctx, contextFieldsKey{}, merged)
+}
+
+// FieldsFromContext returns the fields stored in ctx by ContextWithFields.
+// The returned slice must not be modified.
+func FieldsFromContext(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(contextFieldsKey{}).([]Field)
+	return fields
+}
+
+// WithLevelOverride returns a copy of ctx in which level replaces the
+// logger's level, its LevelResolver and any tenant level for the *Ctx
+// logging methods. Use it to debug a single request while the rest of
+// the service stays at its configured level. An invalid level leaves ctx
+// unchanged.
+//
+// Example:
+//
+//	if r.Header.Get("X-Debug") == "1" {
+//	    ctx = dd.WithLevelOverride(ctx, dd.LevelDebug)
+//	}
+//	logger.DebugCtx(ctx, "cache lookup", dd.String("key", key)) // logged for this request only
+func WithLevelOverride(ctx context.Context, level LogLevel) context.Context {
+	if !level.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, contextLevelKey{}, level)
+}
+
+// LevelOverride returns the level stored in ctx by WithLevelOverride and
+// whether there is one.
+func LevelOverride(ctx context.Context) (LogLevel, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	level, ok := ctx.Value(contextLevelKey{}).(LogLevel)
+	return level, ok
+}
+
+// Package-level context-aware logging functions. They log through the logger
+// stored in ctx (see NewContext), falling back to Default().
+
+func DebugCtx(ctx context.Context, msg string, fields ...Field) {
+	FromContext(ctx).LogCtx(ctx, LevelDebug, msg, fields...)
+}
+func InfoCtx(ctx context.Context, msg string, fields ...Field) {
+	FromContext(ctx).LogCtx(ctx, LevelInfo, msg, fields...)
+}
+func WarnCtx(ctx context.Context, msg string, fields ...Field) {
+	FromContext(ctx).LogCtx(ctx, LevelWarn, msg, fields...)
+}
+func ErrorCtx(ctx context.Context, msg string, fields ...Field) {
+	FromContext(ctx).LogCtx(ctx, LevelError, msg, fields...)
+}
+
+// FatalCtx logs at FATAL level and terminates the program via os.Exit(1).
+func FatalCtx(ctx context.Context, msg string, fields ...Field) {
+	FromContext(ctx).LogCtx(ctx, LevelFatal, msg, fields...)
+}
+
 // getContextString retrieves a string value from context by key.
 // This is an internal helper to reduce code duplication in getter functions.
 func getContextString(ctx context.Context, key ContextKey) string {
-	if v := ctx.Value(key); v != nil {
-		if s, ok := v.(string); ok {
-			return s
-		}
+	s, _ := ContextValue(ctx, key)
+	return s
+}
+
+// ContextValue returns the string stored in ctx under key and whether
+// there is one. Other libraries use it to read the values dd extracts,
+// including keys added with RegisterContextKey.
+func ContextValue(ctx context.Context, key ContextKey) (string, bool) {
+	if ctx == nil {
+		return "", false
 	}
-	return ""
+	s, ok := ctx.Value(key).(string)
+	return s, ok
+}
+
+// WithContextValue returns a copy of ctx with value stored under key, for
+// keys added with RegisterContextKey.
+//
+// Example:
+//
+//	ctx = dd.WithContextValue(ctx, TenantKey, "acme")
+func WithContextValue(ctx context.Context, key ContextKey, value string) context.Context {
+	return context.WithValue(ctx, key, value)
+}
+
+// LookupTraceID returns the trace ID stored by WithTraceID and whether
+// there is one.
+func LookupTraceID(ctx context.Context) (string, bool) {
+	return ContextValue(ctx, ContextKeyTraceID)
+}
+
+// LookupSpanID returns the span ID stored by WithSpanID and whether there
+// is one.
+func LookupSpanID(ctx context.Context) (string, bool) {
+	return ContextValue(ctx, ContextKeySpanID)
+}
+
+// LookupRequestID returns the request ID stored by WithRequestID and
+// whether there is one.
+func LookupRequestID(ctx context.Context) (string, bool) {
+	return ContextValue(ctx, ContextKeyRequestID)
 }
 
 // GetTraceID retrieves the trace ID from the context.
@@ -152,14 +299,21 @@ type ContextExtractor func(ctx context.Context) []Field
 // It is thread-safe and supports dynamic addition of extractors.
 // Uses atomic.Pointer for lock-free reads.
 type ContextExtractorRegistry struct {
-	extractorsPtr atomic.Pointer[[]ContextExtractor]
+	extractorsPtr atomic.Pointer[[]registeredExtractor]
 	mu            sync.Mutex // protects modification operations
 }
 
+// registeredExtractor pairs an extractor with its failure counters, which
+// are shared by clones of the registry.
+type registeredExtractor struct {
+	fn    ContextExtractor
+	state *extractorState
+}
+
 // NewContextExtractorRegistry creates a new empty extractor registry.
 func NewContextExtractorRegistry() *ContextExtractorRegistry {
 	r := &ContextExtractorRegistry{}
-	emptySlice := make([]ContextExtractor, 0)
+	emptySlice := make([]registeredExtractor, 0)
 	r.extractorsPtr.Store(&emptySlice)
 	return r
 }
@@ -179,9 +333,9 @@ func (r *ContextExtractorRegistry) Add(extractor ContextExtractor) {
 	current := *currentPtr
 
 	// Create new slice with the extractor added
-	newExtractors := make([]ContextExtractor, len(current)+1)
+	newExtractors := make([]registeredExtractor, len(current)+1)
 	copy(newExtractors, current)
-	newExtractors[len(current)] = extractor
+	newExtractors[len(current)] = registeredExtractor{fn: extractor, state: &extractorState{}}
 
 	// Atomically swap the pointer
 	r.extractorsPtr.Store(&newExtractors)
@@ -193,8 +347,15 @@ func (r *ContextExtractorRegistry) Add(extractor ContextExtractor) {
 //
 // Panic Recovery: If an extractor panics, the panic is recovered, logged to stderr,
 // and the extractor is skipped. This ensures that a misbehaving extractor cannot
-// crash the application.
+// crash the application. Extractors disabled by a logger's ExtractorGuard are
+// skipped.
 func (r *ContextExtractorRegistry) Extract(ctx context.Context) []Field {
+	return r.extract(ctx, nil, nil)
+}
+
+// extract implements Extract. guard (nil for none) bounds each call and
+// disables failing extractors, reporting them to onDisabled.
+func (r *ContextExtractorRegistry) extract(ctx context.Context, guard *ExtractorGuardConfig, onDisabled func(index int, failures int64, err error)) []Field {
 	if r == nil {
 		return nil
 	}
@@ -210,11 +371,16 @@ func (r *ContextExtractorRegistry) Extract(ctx context.Context) []Field {
 		return nil
 	}
 
-	// Pre-allocate result slice with estimated capacity
 	var fields []Field
-	for _, extractor := range extractors {
-		// Execute extractor with panic recovery
-		extracted := r.executeExtractorWithRecovery(ctx, extractor)
+	for i, extractor := range extractors {
+		if extractor.state.disabled.Load() {
+			continue
+		}
+		extracted, err := extractor.state.run(ctx, extractor.fn, guard)
+		if err != nil {
+			extractor.state.fail(i, err, guard, onDisabled)
+			continue
+		}
 		if len(extracted) > 0 {
 			fields = append(fields, extracted...)
 		}
@@ -223,22 +389,9 @@ func (r *ContextExtractorRegistry) Extract(ctx context.Context) []Field {
 	return fields
 }
 
-// executeExtractorWithRecovery executes an extractor with panic recovery.
-// If the extractor panics, the panic is recovered, logged to stderr, and nil is returned.
-func (r *ContextExtractorRegistry) executeExtractorWithRecovery(ctx context.Context, extractor ContextExtractor) (fields []Field) {
-	defer func() {
-		if rec := recover(); rec != nil {
-			// Log panic to stderr
-			fmt.Fprintf(os.Stderr, "dd: context extractor panic: %v\n", rec)
-			fields = nil
-		}
-	}()
-
-	return extractor(ctx)
-}
-
 // Clone creates a copy of the registry with the same extractors.
-// The extractors themselves are shared (functions are not copied).
+// The extractors themselves are shared (functions are not copied), and so
+// are their ExtractorStats.
 func (r *ContextExtractorRegistry) Clone() *ContextExtractorRegistry {
 	if r == nil {
 		return nil
@@ -251,7 +404,7 @@ func (r *ContextExtractorRegistry) Clone() *ContextExtractorRegistry {
 	extractors := *extractorsPtr
 
 	clone := &ContextExtractorRegistry{}
-	clonedSlice := make([]ContextExtractor, len(extractors))
+	clonedSlice := make([]registeredExtractor, len(extractors))
 	copy(clonedSlice, extractors)
 	clone.extractorsPtr.Store(&clonedSlice)
 
@@ -275,10 +428,23 @@ func (r *ContextExtractorRegistry) Clear() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	emptySlice := make([]ContextExtractor, 0)
+	emptySlice := make([]registeredExtractor, 0)
 	r.extractorsPtr.Store(&emptySlice)
 }
 
+// extractors returns a copy of the registered extractor functions.
+func (r *ContextExtractorRegistry) extractors() []ContextExtractor {
+	extractorsPtr := r.extractorsPtr.Load()
+	if extractorsPtr == nil {
+		return nil
+	}
+	extractors := make([]ContextExtractor, len(*extractorsPtr))
+	for i, e := range *extractorsPtr {
+		extractors[i] = e.fn
+	}
+	return extractors
+}
+
 // Singleton default registry
 var (
 	defaultRegistry     *ContextExtractorRegistry
@@ -286,7 +452,8 @@ var (
 )
 
 // DefaultContextExtractorRegistry returns a singleton registry with the default extractors.
-// The default extractors extract trace_id, span_id, and request_id from context values.
+// The default extractors extract trace_id, span_id, and request_id from context values,
+// plus the keys added with RegisterContextKey.
 // This function is thread-safe and uses sync.Once for initialization.
 func DefaultContextExtractorRegistry() *ContextExtractorRegistry {
 	defaultRegistryOnce.Do(func() {
@@ -325,6 +492,43 @@ func createDefaultExtractor(key ContextKey, fieldName string) ContextExtractor {
 	}
 }
 
+var (
+	contextKeysMu sync.Mutex
+	contextKeys   = map[ContextKey]bool{
+		ContextKeyTraceID:   true,
+		ContextKeySpanID:    true,
+		ContextKeyRequestID: true,
+	}
+)
+
+// RegisterContextKey adds a well-known context key that the default
+// extractors log as a field called name, and returns the key to store
+// values with (see WithContextValue and ContextValue). Registering a name
+// again, including a built-in one, returns the same key. Loggers with
+// their own ContextExtractors do not use the default extractors.
+//
+// Example:
+//
+//	var TenantKey, _ = dd.RegisterContextKey("tenant_id")
+//
+//	ctx = dd.WithContextValue(ctx, TenantKey, "acme")
+//	logger.InfoCtx(ctx, "order placed") // includes tenant_id=acme
+func RegisterContextKey(name string) (ContextKey, error) {
+	if name == "" {
+		return "", fmt.Errorf("%w: context key name cannot be empty", ErrConfigValidation)
+	}
+	key := ContextKey(name)
+
+	contextKeysMu.Lock()
+	defer contextKeysMu.Unlock()
+	if contextKeys[key] {
+		return key, nil
+	}
+	contextKeys[key] = true
+	DefaultContextExtractorRegistry().Add(createDefaultExtractor(key, name))
+	return key, nil
+}
+
 // Default extractors created using the factory function.
 // These extract trace_id, span_id, and request_id from context values.
 var (
@@ -347,3 +551,95 @@ func stringValue(v any) string {
 		return fmt.Sprintf("%v", v)
 	}
 }
+
+// ============================================================================
+// Context Policy
+// ============================================================================
+
+// Field keys written by ContextStatusExtractor.
+const (
+	ContextErrKey      = "ctx_err"
+	ContextDeadlineKey = "ctx_deadline"
+)
+
+// RequestIDMissingKey is the field added by ContextPolicy.MarkMissingRequestID.
+const RequestIDMissingKey = "request_id_missing"
+
+// ContextPolicy controls how the *Ctx logging methods react to the
+// state of their context. The zero value changes nothing.
+//
+// Example:
+//
+//	cfg.ContextPolicy = &dd.ContextPolicy{Annotate: true, SkipCanceled: true}
+type ContextPolicy struct {
+	// Annotate adds the fields from ContextStatusExtractor to every entry
+	// logged with a context, in addition to the configured extractors.
+	Annotate bool
+
+	// SkipCanceled drops entries whose context is already canceled or past
+	// its deadline, before any formatting work is done. This saves work
+	// when abandoned requests keep logging. FATAL entries are never skipped.
+	SkipCanceled bool
+
+	// MarkMissingRequestID adds RequestIDMissingKey=true to entries whose
+	// context yields no request_id, so call sites that skip EnsureRequestID
+	// or RequestIDMiddleware can be found. No ID is invented: a fresh ID per
+	// entry would correlate nothing.
+	MarkMissingRequestID bool
+}
+
+// ContextStatusExtractor is a ContextExtractor that reports the state of
+// ctx: ContextErrKey holds ctx.Err() once the context is done, and
+// ContextDeadlineKey the time remaining until its deadline, if it has one.
+// It returns nil for a live context without a deadline.
+func ContextStatusExtractor(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	var fields []Field
+	if err := ctx.Err(); err != nil {
+		fields = append(fields, String(ContextErrKey, err.Error()))
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		fields = append(fields, Duration(ContextDeadlineKey, time.Until(deadline)))
+	}
+	return fields
+}
+
+// SetContextPolicy sets the context policy at runtime (thread-safe).
+// Pass nil to restore the default behavior.
+func (l *Logger) SetContextPolicy(policy *ContextPolicy) {
+	if l == nil {
+		return
+	}
+	if policy == nil {
+		l.contextPolicy.Store(nil)
+		return
+	}
+	p := *policy
+	l.contextPolicy.Store(&p)
+}
+
+// GetContextPolicy returns a copy of the current context policy, or nil if
+// none is set.
+func (l *Logger) GetContextPolicy() *ContextPolicy {
+	if l == nil {
+		return nil
+	}
+	policy := l.contextPolicy.Load()
+	if policy == nil {
+		return nil
+	}
+	p := *policy
+	return &p
+}
+
+// skipForContext reports whether the context policy drops an entry at
+// level because ctx is already done.
+func (l *Logger) skipForContext(ctx context.Context, level LogLevel) bool {
+	if ctx == nil || level >= LevelFatal {
+		return false
+	}
+	policy := l.contextPolicy.Load()
+	return policy != nil && policy.SkipCanceled && ctx.Err() != nil
+}
@@ -0,0 +1,96 @@
+package dd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContextPolicyAnnotate(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) { cfg.ContextPolicy = &ContextPolicy{Annotate: true} })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	logger.InfoCtx(ctx, "live")
+	cancel()
+	logger.WithField("k", "v").WarnCtx(ctx, "canceled")
+	logger.InfoCtx(context.Background(), "plain")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], ContextDeadlineKey+"=") || strings.Contains(lines[0], ContextErrKey) {
+		t.Errorf("live context should report only the deadline: %q", lines[0])
+	}
+	if !strings.Contains(lines[1], ContextErrKey+`="context canceled"`) {
+		t.Errorf("canceled context should report ctx.Err(): %q", lines[1])
+	}
+	if strings.Contains(lines[2], "ctx_") {
+		t.Errorf("background context should add no status fields: %q", lines[2])
+	}
+}
+
+func TestContextPolicySkipCanceled(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.FatalHandler = func() {}
+		cfg.ContextPolicy = &ContextPolicy{SkipCanceled: true}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	logger.ErrorCtx(ctx, "skipped")
+	logger.WithField("k", "v").InfoCtx(ctx, "skipped entry")
+	logger.Tenant("acme").InfoCtx(ctx, "skipped tenant")
+	logger.Info("no context")
+	logger.FatalCtx(ctx, "fatal kept")
+
+	out := buf.String()
+	if strings.Contains(out, "skipped") {
+		t.Errorf("entries with a canceled context should be skipped: %q", out)
+	}
+	if !strings.Contains(out, "no context") || !strings.Contains(out, "fatal kept") {
+		t.Errorf("other entries should be kept: %q", out)
+	}
+}
+
+func TestContextPolicyDefaultAndRuntime(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	logger.InfoCtx(ctx, "first")
+	if !strings.Contains(buf.String(), "first") || strings.Contains(buf.String(), ContextErrKey) {
+		t.Errorf("without a policy entries are logged unchanged: %q", buf.String())
+	}
+
+	logger.SetContextPolicy(&ContextPolicy{SkipCanceled: true})
+	logger.InfoCtx(ctx, "second")
+	if strings.Contains(buf.String(), "second") {
+		t.Error("SetContextPolicy should take effect immediately")
+	}
+	if p := logger.GetContextPolicy(); p == nil || !p.SkipCanceled {
+		t.Errorf("GetContextPolicy = %+v", p)
+	}
+
+	logger.SetContextPolicy(nil)
+	if logger.GetContextPolicy() != nil {
+		t.Error("policy should be cleared")
+	}
+}
+
+func TestContextStatusExtractor(t *testing.T) {
+	if fields := ContextStatusExtractor(context.Background()); fields != nil {
+		t.Errorf("expected no fields, got %v", fields)
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	fields := ContextStatusExtractor(ctx)
+	if len(fields) != 2 || fields[0].Key != ContextErrKey || fields[1].Key != ContextDeadlineKey {
+		t.Fatalf("unexpected fields %v", fields)
+	}
+	if fields[1].Value.(time.Duration) >= 0 {
+		t.Errorf("expired deadline should be negative, got %v", fields[1].Value)
+	}
+}
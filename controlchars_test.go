@@ -0,0 +1,73 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestControlCharPolicy(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Security = &SecurityConfig{ControlChars: ControlCharReplace}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Info("bell\x07 line1\nline2")
+	out := buf.String()
+	if !strings.Contains(out, `bell? line1\nline2`) || strings.Count(out, "\n") != 1 {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestKeepNewlinesJSON(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := JSONConfig()
+	cfg.Output = &buf
+	cfg.Security = &SecurityConfig{KeepNewlines: true}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Error("panic: boom\n\tmain.go:12\x00")
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Fatalf("entry should stay on one line: %q", buf.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["message"] != "panic: boom\n\tmain.go:12" {
+		t.Errorf("message = %q", entry["message"])
+	}
+}
+
+func TestKeepNewlinesIgnoredForText(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Security = &SecurityConfig{KeepNewlines: true}
+	logger, _ := New(cfg)
+	defer logger.Close()
+
+	logger.Info("a\nERROR fake")
+	if strings.Count(buf.String(), "\n") != 1 || !strings.Contains(buf.String(), `a\nERROR fake`) {
+		t.Errorf("text output should escape newlines: %q", buf.String())
+	}
+}
+
+func TestControlCharPolicyValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Security = &SecurityConfig{ControlChars: 42}
+	if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("expected ErrConfigValidation, got %v", err)
+	}
+}
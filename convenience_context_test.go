@@ -3,6 +3,7 @@ package dd
 import (
 	"bytes"
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -110,6 +111,64 @@ func TestContextKeys_WithLogger(t *testing.T) {
 	}
 }
 
+func TestLookupContextIDs(t *testing.T) {
+	ctx := WithRequestID(WithTraceID(context.Background(), "trace-abc"), "")
+	if id, ok := LookupTraceID(ctx); !ok || id != "trace-abc" {
+		t.Errorf("LookupTraceID = %q, %v", id, ok)
+	}
+	if _, ok := LookupSpanID(ctx); ok {
+		t.Error("LookupSpanID found a span ID that was not set")
+	}
+	if id, ok := LookupRequestID(ctx); !ok || id != "" {
+		t.Errorf("LookupRequestID = %q, %v (empty IDs are still present)", id, ok)
+	}
+	if _, ok := LookupTraceID(nil); ok {
+		t.Error("LookupTraceID(nil) should report false")
+	}
+}
+
+func TestRegisterContextKey(t *testing.T) {
+	// Registration is global: restore the default extractors afterwards
+	registry := DefaultContextExtractorRegistry()
+	prev := registry.extractorsPtr.Load()
+	t.Cleanup(func() {
+		registry.extractorsPtr.Store(prev)
+		contextKeysMu.Lock()
+		delete(contextKeys, "test_tenant_id")
+		contextKeysMu.Unlock()
+	})
+
+	key, err := RegisterContextKey("test_tenant_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := RegisterContextKey("test_tenant_id"); again != key {
+		t.Errorf("second registration returned %q", again)
+	}
+	if builtin, _ := RegisterContextKey("trace_id"); builtin != ContextKeyTraceID {
+		t.Errorf("built-in name returned %q", builtin)
+	}
+	if _, err := RegisterContextKey(""); err == nil {
+		t.Error("empty name should be rejected")
+	}
+
+	var buf bytes.Buffer
+	logger, err := New(&Config{Level: LevelInfo, Format: FormatText, Output: &buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	ctx := WithContextValue(context.Background(), key, "acme")
+	logger.InfoCtx(ctx, "order placed")
+	if got := buf.String(); strings.Count(got, "test_tenant_id=acme") != 1 {
+		t.Errorf("output = %q", got)
+	}
+	if v, ok := ContextValue(ctx, key); !ok || v != "acme" {
+		t.Errorf("ContextValue = %q, %v", v, ok)
+	}
+}
+
 // ============================================================================
 // CONVENIENCE CONSTRUCTOR TESTS
 // ============================================================================
@@ -531,3 +590,86 @@ func TestLoggerEntry_LogWithLevel(t *testing.T) {
 		t.Errorf("Entry.LogWith should contain message, got: %s", output)
 	}
 }
+
+func TestNewContextFromContext(t *testing.T) {
+	logger, _ := ToWriter(io.Discard)
+	defer logger.Close()
+
+	ctx := NewContext(context.Background(), logger)
+	if FromContext(ctx) != logger {
+		t.Error("FromContext() did not return the stored logger")
+	}
+	if FromContext(context.Background()) != Default() {
+		t.Error("FromContext() should fall back to Default()")
+	}
+}
+
+func TestContextWithFields(t *testing.T) {
+	ctx := ContextWithFields(context.Background(), String("user", "alice"), Int("attempt", 1))
+	ctx = ContextWithFields(ctx, Int("attempt", 2))
+
+	fields := FieldsFromContext(ctx)
+	if len(fields) != 2 {
+		t.Fatalf("expected 2 fields, got %v", fields)
+	}
+	if fields[0].Key != "user" || fields[1].Value != 2 {
+		t.Errorf("later fields should override earlier ones: %v", fields)
+	}
+
+	if got := ContextWithFields(ctx); got != ctx {
+		t.Error("ContextWithFields with no fields should return ctx unchanged")
+	}
+}
+
+func TestLoggerCtxMethods(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := ToWriter(&buf)
+	defer logger.Close()
+
+	ctx := WithTraceID(context.Background(), "trace-1")
+	ctx = ContextWithFields(ctx, String("tenant", "acme"), String("user", "alice"))
+
+	logger.InfoCtx(ctx, "handled", String("user", "bob"))
+	out := buf.String()
+	for _, want := range []string{"trace_id=trace-1", "tenant=acme", "user=bob"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q: %s", want, out)
+		}
+	}
+	if strings.Contains(out, "user=alice") {
+		t.Errorf("explicit field should override context field: %s", out)
+	}
+
+	buf.Reset()
+	logger.WithField("component", "db").WarnCtx(ctx, "slow")
+	if !strings.Contains(buf.String(), "component=db") || !strings.Contains(buf.String(), "tenant=acme") {
+		t.Errorf("entry output missing fields: %s", buf.String())
+	}
+
+	buf.Reset()
+	InfoCtx(NewContext(ctx, logger), "via package")
+	if !strings.Contains(buf.String(), "via package") || !strings.Contains(buf.String(), "tenant=acme") {
+		t.Errorf("package-level InfoCtx did not use context logger: %s", buf.String())
+	}
+}
+
+func TestLoggerCtxUsesLevelResolver(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := ToWriter(&buf)
+	defer logger.Close()
+
+	type debugKey struct{}
+	logger.SetLevelResolver(func(ctx context.Context) LogLevel {
+		if ctx.Value(debugKey{}) != nil {
+			return LevelDebug
+		}
+		return LevelInfo
+	})
+
+	logger.DebugCtx(context.Background(), "hidden")
+	logger.DebugCtx(context.WithValue(context.Background(), debugKey{}, true), "shown")
+
+	if strings.Contains(buf.String(), "hidden") || !strings.Contains(buf.String(), "shown") {
+		t.Errorf("level resolver should receive ctx: %s", buf.String())
+	}
+}
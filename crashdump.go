@@ -0,0 +1,102 @@
+package dd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// GoroutinesKey is the field key of the goroutine dump added to FATAL
+// entries when Config.FatalStackDump is set.
+const GoroutinesKey = "goroutines"
+
+const (
+	// initialStackDumpSize is the first buffer size tried for a goroutine dump.
+	initialStackDumpSize = 64 * 1024
+
+	// maxStackDumpSize caps goroutine dumps; larger dumps are cut off.
+	maxStackDumpSize = 16 * 1024 * 1024
+)
+
+// crashDumpPath returns the validated CrashDumpPath, or "" if unset.
+func (c *Config) crashDumpPath() (string, error) {
+	if c.CrashDumpPath == "" {
+		return "", nil
+	}
+	return internal.ValidateAndSecurePath(c.CrashDumpPath, maxPathLength, ErrEmptyFilePath, ErrNullByte, ErrPathTooLong, ErrPathTraversal, ErrInvalidPath)
+}
+
+// goroutineDump returns the stacks of all goroutines, as printed by an
+// unrecovered panic, up to maxStackDumpSize bytes.
+func goroutineDump() string {
+	buf := make([]byte, initialStackDumpSize)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) || len(buf) >= maxStackDumpSize {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// addFatalDump captures the goroutine dump for a FATAL entry when a crash
+// dump is configured, and adds it as a field if FatalStackDump is set.
+func (l *Logger) addFatalDump(entry *logEntry) {
+	if !l.fatalStackDump && l.crashDumpPath == "" {
+		return
+	}
+	entry.fatalDump = goroutineDump()
+	if l.fatalStackDump {
+		fields := make([]Field, len(entry.fields), len(entry.fields)+1)
+		copy(fields, entry.fields)
+		entry.fields = append(fields, String(GoroutinesKey, entry.fatalDump))
+	}
+}
+
+// writeCrashDump appends the FATAL entry and the goroutine dump to
+// Config.CrashDumpPath. Failures are reported on stderr; the program is
+// exiting anyway.
+func (l *Logger) writeCrashDump(entry *logEntry) {
+	if l.crashDumpPath == "" || entry.fatalDump == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(l.crashDumpPath), dirPermissions); err != nil {
+		l.internalError(ComponentFatal, err, "crash dump: %v", err)
+		return
+	}
+	file, _, err := internal.OpenFile(l.crashDumpPath)
+	if err != nil {
+		l.internalError(ComponentFatal, err, "crash dump: %v", err)
+		return
+	}
+	defer file.Close()
+
+	var b strings.Builder
+	b.Grow(len(entry.fatalDump) + 256)
+	fmt.Fprintf(&b, "=== dd crash dump ===\n")
+	fmt.Fprintf(&b, "time: %s\n", l.clock.Now().Format(time.RFC3339Nano))
+	fmt.Fprintf(&b, "pid: %d\n", os.Getpid())
+	fmt.Fprintf(&b, "goroutines: %d\n", runtime.NumGoroutine())
+	fmt.Fprintf(&b, "message: %s\n", entry.msg)
+	for _, f := range entry.fields {
+		if f.Key != GoroutinesKey {
+			fmt.Fprintf(&b, "field %s: %v\n", f.Key, f.Value)
+		}
+	}
+	b.WriteByte('\n')
+	b.WriteString(entry.fatalDump)
+	b.WriteString("\n\n")
+
+	if _, err := file.WriteString(b.String()); err != nil {
+		l.internalError(ComponentFatal, err, "crash dump: %v", err)
+		return
+	}
+	if err := file.Sync(); err != nil {
+		l.internalError(ComponentFatal, err, "crash dump: %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFatalStackDumpField(t *testing.T) {
+	var exits atomic.Int32
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.FatalHandler = func() { exits.Add(1) }
+		cfg.FatalStackDump = true
+	})
+
+	logger.FatalWith("out of memory", String("component", "cache"))
+
+	out := buf.String()
+	if exits.Load() != 1 {
+		t.Error("FatalHandler should be called")
+	}
+	if !strings.Contains(out, `goroutines="goroutine `) || !strings.Contains(out, "TestFatalStackDumpField") {
+		t.Errorf("goroutine dump missing: %q", out)
+	}
+	if !strings.Contains(out, "\n    ") {
+		t.Errorf("dump should render as an indented block in text output: %q", out)
+	}
+}
+
+func TestCrashDumpPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crash", "dump.log")
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.FatalHandler = func() {}
+		cfg.CrashDumpPath = path
+	})
+
+	logger.FatalWith("disk failed", String("device", "sda"))
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dump := string(data)
+	for _, want := range []string{"=== dd crash dump ===", "message: disk failed", "field device: sda", "goroutine ", "TestCrashDumpPath"} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("crash dump missing %q", want)
+		}
+	}
+	if strings.Contains(buf.String(), "goroutines=") {
+		t.Error("CrashDumpPath alone should not add the dump to the entry")
+	}
+}
+
+func TestNoDumpByDefault(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) { cfg.FatalHandler = func() {} })
+	logger.Fatal("plain")
+	if strings.Contains(buf.String(), "goroutines") {
+		t.Errorf("dump should be opt-in: %q", buf.String())
+	}
+}
+
+func TestCrashDumpPathValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Output = &bytes.Buffer{}
+	cfg.CrashDumpPath = "../../etc/crash.log"
+	if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) || !errors.Is(err, ErrPathTraversal) {
+		t.Errorf("expected ErrConfigValidation wrapping ErrPathTraversal, got %v", err)
+	}
+}
+
+func TestGoroutineDump(t *testing.T) {
+	dump := goroutineDump()
+	if !strings.HasPrefix(dump, "goroutine ") || !strings.Contains(dump, "TestGoroutineDump") {
+		t.Errorf("unexpected dump: %.200q", dump)
+	}
+}
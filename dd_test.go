@@ -9,8 +9,10 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -567,6 +569,39 @@ func TestMultiWriterManagement(t *testing.T) {
 	mw.Close()
 }
 
+func TestMultiWriterAddWriterLevel(t *testing.T) {
+	var all, errs bytes.Buffer
+	mw := NewMultiWriter(&all)
+	if err := mw.AddWriterLevel(&errs, LevelError); err != nil {
+		t.Fatalf("AddWriterLevel failed: %v", err)
+	}
+	if err := mw.AddWriterLevel(&errs, 99); !errors.Is(err, ErrInvalidLevel) {
+		t.Errorf("AddWriterLevel(99) error = %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.IncludeTime = false
+	cfg.DynamicCaller = false
+	cfg.Output = mw
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("routine")
+	logger.Error("broken")
+
+	if got := all.String(); !strings.Contains(got, "routine") || !strings.Contains(got, "broken") {
+		t.Errorf("unleveled writer = %q", got)
+	}
+	if got := errs.String(); strings.Contains(got, "routine") || !strings.Contains(got, "broken") {
+		t.Errorf("ERROR writer = %q", got)
+	}
+
+	if err := mw.RemoveWriter(&errs); err != nil {
+		t.Errorf("RemoveWriter of leveled writer failed: %v", err)
+	}
+}
+
 func TestMultiWriterClose(t *testing.T) {
 	tmpFile := filepath.Join(t.TempDir(), "test.log")
 	fw, _ := NewFileWriter(tmpFile)
@@ -887,6 +922,170 @@ func TestLoggerClose(t *testing.T) {
 	stdoutLogger.Close() // Should not panic or exit
 }
 
+// shutdownWriter records Flush/Close order and can fail or block.
+type shutdownWriter struct {
+	mu         sync.Mutex
+	calls      []string
+	flushErr   error
+	closeErr   error
+	block      chan struct{} // blocks Close
+	blockFlush chan struct{}
+}
+
+func (w *shutdownWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+func (w *shutdownWriter) record(call string) {
+	w.mu.Lock()
+	w.calls = append(w.calls, call)
+	w.mu.Unlock()
+}
+
+func (w *shutdownWriter) called(call string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return slices.Contains(w.calls, call)
+}
+
+func (w *shutdownWriter) Flush() error {
+	if w.blockFlush != nil {
+		<-w.blockFlush
+	}
+	w.record("flush")
+	return w.flushErr
+}
+
+func (w *shutdownWriter) Close() error {
+	if w.block != nil {
+		<-w.block
+	}
+	w.record("close")
+	return w.closeErr
+}
+
+func TestLoggerShutdown(t *testing.T) {
+	t.Run("FlushesThenCloses", func(t *testing.T) {
+		var buf bytes.Buffer
+		bw, err := NewBufferedWriter(&buf, 4096)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sw := &shutdownWriter{}
+		cfg := DefaultConfig()
+		cfg.Outputs = []io.Writer{bw, sw}
+		logger, _ := New(cfg)
+
+		logger.Info("buffered entry")
+		if err := logger.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Shutdown() error = %v", err)
+		}
+		if !strings.Contains(buf.String(), "buffered entry") {
+			t.Error("buffered output should be flushed on shutdown")
+		}
+		if got := strings.Join(sw.calls, ","); got != "flush,close" {
+			t.Errorf("calls = %s, want flush,close", got)
+		}
+		if !logger.IsClosed() {
+			t.Error("logger should be closed")
+		}
+		if err := logger.Shutdown(context.Background()); err != nil {
+			t.Errorf("second Shutdown() should be a no-op, got %v", err)
+		}
+	})
+
+	t.Run("AggregatesErrors", func(t *testing.T) {
+		flushErr := errors.New("flush failed")
+		closeErr := errors.New("close failed")
+		w1 := &shutdownWriter{flushErr: flushErr}
+		w2 := &shutdownWriter{closeErr: closeErr}
+		cfg := DefaultConfig()
+		cfg.Outputs = []io.Writer{w1, w2}
+		logger, _ := New(cfg)
+
+		err := logger.Shutdown(context.Background())
+		if !errors.Is(err, flushErr) || !errors.Is(err, closeErr) {
+			t.Errorf("expected both errors, got %v", err)
+		}
+	})
+
+	t.Run("RespectsDeadline", func(t *testing.T) {
+		block := make(chan struct{})
+		w := &shutdownWriter{block: block, flushErr: errors.New("flush failed")}
+		defer func() {
+			// Let the abandoned close finish so it does not outlive the test
+			close(block)
+			for !w.called("close") {
+				time.Sleep(time.Millisecond)
+			}
+		}()
+		cfg := DefaultConfig()
+		cfg.Output = w
+		logger, _ := New(cfg)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err := logger.Shutdown(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+		if err == nil || !strings.Contains(err.Error(), "flush failed") {
+			t.Errorf("errors collected before the deadline should be kept, got %v", err)
+		}
+		if time.Since(start) > time.Second {
+			t.Error("Shutdown should return at the deadline")
+		}
+	})
+
+	t.Run("ClosesAllWritersAfterDeadline", func(t *testing.T) {
+		block := make(chan struct{})
+		w1 := &shutdownWriter{blockFlush: block}
+		w2 := &shutdownWriter{}
+		cfg := DefaultConfig()
+		cfg.Outputs = []io.Writer{w1, w2}
+		logger, _ := New(cfg)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := logger.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+		close(block)
+		deadline := time.Now().Add(5 * time.Second)
+		for !w1.called("close") || !w2.called("close") {
+			if time.Now().After(deadline) {
+				t.Fatalf("writers not closed after the deadline: %v, %v", w1.calls, w2.calls)
+			}
+			time.Sleep(time.Millisecond)
+		}
+		if !w2.called("flush") {
+			t.Error("second writer was not flushed")
+		}
+	})
+
+	t.Run("DrainsAsyncHooks", func(t *testing.T) {
+		var ran atomic.Bool
+		cfg := DefaultConfig()
+		cfg.Output = io.Discard
+		logger, _ := New(cfg)
+		_, err := logger.AddHookWithOptions(HookAfterLog, func(ctx context.Context, hc *HookContext) error {
+			time.Sleep(20 * time.Millisecond)
+			ran.Store(true)
+			return nil
+		}, HookOptions{Async: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		logger.Info("entry")
+		if err := logger.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Shutdown() error = %v", err)
+		}
+		if !ran.Load() {
+			t.Error("async hooks should complete before Shutdown returns")
+		}
+	})
+}
+
 // ============================================================================
 // FIELD CONSTRUCTORS TESTS
 // ============================================================================
@@ -917,6 +1116,17 @@ func TestFieldConstructors(t *testing.T) {
 		{"Time", Time("k", time.Now()), "k"},
 		// Special types
 		{"Any", Any("k", nil), "k"},
+		{"TimeLayout", TimeLayout("k", time.Now(), time.Kitchen), "k"},
+		{"Bytes", Bytes("k", []byte{1}), "k"},
+		{"BytesHex", BytesHex("k", []byte{1}), "k"},
+		{"Stringer", Stringer("k", time.Second), "k"},
+		{"Strings", Strings("k", []string{"a"}), "k"},
+		{"Ints", Ints("k", []int{1}), "k"},
+		{"Int64s", Int64s("k", []int64{1}), "k"},
+		{"Uint64s", Uint64s("k", []uint64{1}), "k"},
+		{"Float64s", Float64s("k", []float64{1}), "k"},
+		{"Bools", Bools("k", []bool{true}), "k"},
+		{"Durations", Durations("k", []time.Duration{time.Second}), "k"},
 		{"Err", Err(nil), "error"},
 		{"ErrWithValue", Err(errors.New("test error")), "error"},
 	}
@@ -984,6 +1194,59 @@ func TestFieldConstructorsWithLogging(t *testing.T) {
 	}
 }
 
+// countingStringer records how often String is called.
+type countingStringer struct{ calls *int }
+
+func (s countingStringer) String() string {
+	*s.calls++
+	return "token=abc123 shown"
+}
+
+func TestExtendedFieldValues(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Format = FormatJSON
+	cfg.Level = LevelInfo
+	logger, _ := New(cfg)
+
+	calls := 0
+	logger.DebugWith("skipped", Stringer("lazy", countingStringer{&calls}))
+	if calls != 0 {
+		t.Errorf("String called %d times for a disabled level, want 0", calls)
+	}
+
+	ts := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	logger.InfoWith("values",
+		Stringer("lazy", countingStringer{&calls}),
+		Bytes("b64", []byte("hi")),
+		BytesHex("hex", []byte{0xde, 0xad}),
+		TimeLayout("at", ts, time.Kitchen),
+		Strings("tags", []string{"a", "b"}),
+		Ints("ints", []int{1, 2}),
+		Durations("waits", []time.Duration{time.Second}),
+	)
+	out := buf.String()
+	for _, want := range []string{
+		`"b64":"aGk="`,
+		`"hex":"dead"`,
+		`"at":"10:30AM"`,
+		`"tags":["a","b"]`,
+		`"ints":[1,2]`,
+		`"waits":["1s"]`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s: %s", want, out)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("String called %d times, want 1", calls)
+	}
+	if strings.Contains(out, "abc123") {
+		t.Errorf("Stringer output should be filtered: %s", out)
+	}
+}
+
 // ============================================================================
 // FORMAT TESTS
 // ============================================================================
@@ -1449,6 +1712,135 @@ func TestDefaultLoggerCompareAndSwap(t *testing.T) {
 	}
 }
 
+// resetDefaultState clears the global default logger state for a test and
+// restores it afterwards.
+func resetDefaultState(t *testing.T) {
+	t.Helper()
+	oldLogger := defaultLogger.Swap(nil)
+	oldHooks := defaultInitHooks.Swap(nil)
+	defaultOnce = sync.Once{}
+	t.Cleanup(func() {
+		defaultLogger.Store(oldLogger)
+		defaultInitHooks.Store(oldHooks)
+		defaultOnce = sync.Once{}
+		defaultInitErr.Store(errNoInit)
+		defaultUsedFallback.Store(false)
+	})
+}
+
+func TestSwapDefault(t *testing.T) {
+	resetDefaultState(t)
+
+	var buf1, buf2 bytes.Buffer
+	logger1, _ := ToWriter(&buf1)
+	logger2, _ := ToWriter(&buf2)
+	defer logger1.Close()
+	defer logger2.Close()
+
+	if old := SwapDefault(logger1); old != nil {
+		t.Errorf("expected no previous default, got %p", old)
+	}
+	if old := SwapDefault(logger2); old != logger1 {
+		t.Error("SwapDefault should return the previous logger")
+	}
+	if SwapDefault(nil) != nil || Default() != logger2 {
+		t.Error("SwapDefault(nil) should be ignored")
+	}
+
+	time.Sleep(2 * defaultLoggerCloseDelay)
+	if logger1.IsClosed() {
+		t.Error("SwapDefault must not close the previous logger")
+	}
+}
+
+func TestOnDefaultInit(t *testing.T) {
+	resetDefaultState(t)
+
+	var installed []*Logger
+	OnDefaultInit(func(l *Logger) { installed = append(installed, l) })
+	OnDefaultInit(func(*Logger) { panic("ignored") })
+	OnDefaultInit(nil)
+
+	lazy := Default()
+	defer lazy.Close()
+
+	logger, _ := ToWriter(&bytes.Buffer{})
+	defer logger.Close()
+	SetDefault(logger)
+
+	if len(installed) != 2 || installed[0] != lazy || installed[1] != logger {
+		t.Fatalf("expected callbacks for lazy and explicit installs, got %v", installed)
+	}
+}
+
+func TestDefaultExplicitWinsOverLazyInit(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		func() {
+			resetDefaultState(t)
+
+			explicit, _ := ToWriter(&bytes.Buffer{})
+			defer explicit.Close()
+
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_ = Default()
+			}()
+			go func() {
+				defer wg.Done()
+				SetDefault(explicit)
+			}()
+			wg.Wait()
+
+			if Default() != explicit {
+				t.Fatal("explicitly installed logger must win over lazy initialization")
+			}
+		}()
+	}
+}
+
+func TestReplaceDefaultFromEnv(t *testing.T) {
+	resetDefaultState(t)
+
+	t.Setenv(EnvLevel, "warn")
+	t.Setenv(EnvFormat, "JSON")
+	t.Setenv(EnvOutput, "stderr")
+	if err := ReplaceDefaultFromEnv(); err != nil {
+		t.Fatalf("ReplaceDefaultFromEnv: %v", err)
+	}
+	logger := Default()
+	defer logger.Close()
+	if logger.GetLevel() != LevelWarn {
+		t.Errorf("level = %v, want WARN", logger.GetLevel())
+	}
+
+	cfg, err := configFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Format != FormatJSON || cfg.Output != os.Stderr {
+		t.Errorf("unexpected config: format=%v output=%v", cfg.Format, cfg.Output)
+	}
+
+	t.Setenv(EnvOutput, "logs/app.log")
+	if cfg, _ := configFromEnv(); cfg.File == nil || cfg.File.Path != "logs/app.log" {
+		t.Errorf("file output not configured: %+v", cfg.File)
+	}
+
+	for env, value := range map[string]string{EnvLevel: "verbose", EnvFormat: "yaml"} {
+		t.Run(env, func(t *testing.T) {
+			t.Setenv(env, value)
+			if err := ReplaceDefaultFromEnv(); !errors.Is(err, ErrConfigValidation) {
+				t.Errorf("expected ErrConfigValidation, got %v", err)
+			}
+			if Default() != logger {
+				t.Error("a failed replacement must keep the current default")
+			}
+		})
+	}
+}
+
 // ============================================================================
 // CONFIG BUILD TESTS
 // ============================================================================
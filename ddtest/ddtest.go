@@ -0,0 +1,231 @@
+// Package ddtest provides test helpers for code that logs with dd.
+//
+// NewTestLogger returns a logger whose entries are captured as structured
+// records rather than formatted text, so tests can assert on levels,
+// messages and fields without depending on timestamps, callers or layout.
+//
+// Example:
+//
+//	logger, logs := ddtest.NewTestLogger()
+//	svc := NewService(logger)
+//	svc.Charge(42)
+//
+//	if logs.FilterMessage("charge declined").FilterField(dd.Int("amount", 42)).Len() != 1 {
+//	    t.Error("expected one declined charge")
+//	}
+package ddtest
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergodev/dd"
+	"github.com/cybergodev/dd/internal"
+)
+
+// LoggedEntry is a captured log entry.
+type LoggedEntry struct {
+	Time           time.Time
+	Level          dd.LogLevel
+	Message        string
+	Fields         []dd.Field
+	Classification dd.ConfidentialityLevel
+}
+
+// Field returns the value of the last field with key and whether it exists.
+func (e LoggedEntry) Field(key string) (any, bool) {
+	for i := len(e.Fields) - 1; i >= 0; i-- {
+		if e.Fields[i].Key == key {
+			return e.Fields[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// FieldMap returns the entry's fields as a map. Later duplicates win.
+func (e LoggedEntry) FieldMap() map[string]any {
+	m := make(map[string]any, len(e.Fields))
+	for _, f := range e.Fields {
+		m[f.Key] = f.Value
+	}
+	return m
+}
+
+// Observed is a dd writer that records entries for inspection.
+// Filter methods return a new Observed holding a snapshot of the matching
+// entries, so queries can be chained. It is safe for concurrent use.
+type Observed struct {
+	mu      sync.Mutex
+	entries []LoggedEntry
+}
+
+// NewObserved returns an empty Observed. Use it directly as a logger
+// output (it implements dd.RecordWriter) when NewTestLogger's
+// configuration does not fit.
+func NewObserved() *Observed {
+	return &Observed{}
+}
+
+// NewTestLogger returns a logger at DEBUG level that writes only to the
+// returned Observed. Pass a config to change other settings; its outputs
+// are replaced. Unless the config sets one, the fatal handler does not exit
+// the process, so Fatal can be asserted on.
+func NewTestLogger(cfgs ...*dd.Config) (*dd.Logger, *Observed) {
+	var cfg *dd.Config
+	if len(cfgs) > 0 && cfgs[0] != nil {
+		cfg = cfgs[0].Clone()
+	} else {
+		cfg = dd.DefaultConfig()
+		cfg.Level = dd.LevelDebug
+		cfg.FatalHandler = func() {}
+	}
+	if cfg.FatalHandler == nil {
+		cfg.FatalHandler = func() {}
+	}
+
+	obs := NewObserved()
+	cfg.Output = obs
+	cfg.Outputs = nil
+	cfg.File = nil
+
+	logger, err := dd.New(cfg)
+	if err != nil {
+		panic("ddtest: invalid config: " + err.Error())
+	}
+	return logger, obs
+}
+
+// Write implements io.Writer. Entries without metadata are recorded with
+// the formatted text as the message.
+func (o *Observed) Write(p []byte) (int, error) {
+	o.add(LoggedEntry{Time: time.Now(), Message: strings.TrimRight(string(p), "\n")})
+	return len(p), nil
+}
+
+// WriteRecord implements dd.RecordWriter.
+func (o *Observed) WriteRecord(rec *dd.Record, p []byte) (int, error) {
+	o.add(LoggedEntry{
+		Time:           rec.Time,
+		Level:          rec.Level,
+		Message:        rec.Message,
+		Fields:         append([]dd.Field(nil), rec.Fields...),
+		Classification: rec.Classification,
+	})
+	return len(p), nil
+}
+
+func (o *Observed) add(e LoggedEntry) {
+	o.mu.Lock()
+	o.entries = append(o.entries, e)
+	o.mu.Unlock()
+}
+
+// Len returns the number of recorded entries.
+func (o *Observed) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.entries)
+}
+
+// All returns a copy of the recorded entries in order.
+func (o *Observed) All() []LoggedEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]LoggedEntry(nil), o.entries...)
+}
+
+// TakeAll returns the recorded entries and clears them.
+func (o *Observed) TakeAll() []LoggedEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	entries := o.entries
+	o.entries = nil
+	return entries
+}
+
+// Messages returns the messages of the recorded entries in order.
+func (o *Observed) Messages() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	msgs := make([]string, len(o.entries))
+	for i, e := range o.entries {
+		msgs[i] = e.Message
+	}
+	return msgs
+}
+
+// Filter returns the entries for which keep returns true.
+func (o *Observed) Filter(keep func(LoggedEntry) bool) *Observed {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	out := &Observed{}
+	for _, e := range o.entries {
+		if keep(e) {
+			out.entries = append(out.entries, e)
+		}
+	}
+	return out
+}
+
+// FilterMessage returns the entries whose message equals msg.
+func (o *Observed) FilterMessage(msg string) *Observed {
+	return o.Filter(func(e LoggedEntry) bool { return e.Message == msg })
+}
+
+// FilterMessageSnippet returns the entries whose message contains snippet.
+func (o *Observed) FilterMessageSnippet(snippet string) *Observed {
+	return o.Filter(func(e LoggedEntry) bool { return strings.Contains(e.Message, snippet) })
+}
+
+// FilterLevel returns the entries logged at exactly level.
+func (o *Observed) FilterLevel(level dd.LogLevel) *Observed {
+	return o.Filter(func(e LoggedEntry) bool { return e.Level == level })
+}
+
+// FilterField returns the entries that have a field with the same key and
+// an equal value. Values are compared with reflect.DeepEqual, so the field
+// type matters: dd.Int("n", 1) does not match an int64 value.
+func (o *Observed) FilterField(field dd.Field) *Observed {
+	return o.Filter(func(e LoggedEntry) bool {
+		for _, f := range e.Fields {
+			if f.Key == field.Key && reflect.DeepEqual(f.Value, field.Value) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// FilterFieldKey returns the entries that have a field with key.
+func (o *Observed) FilterFieldKey(key string) *Observed {
+	return o.Filter(func(e LoggedEntry) bool {
+		_, ok := e.Field(key)
+		return ok
+	})
+}
+
+// EscapingCorpus returns strings that have broken log encoders: quotes,
+// backslashes and line breaks, control characters, invalid and truncated
+// UTF-8 and JSON fragments. Use it to seed fuzz tests of code that logs
+// user input or of custom formatters and writers.
+func EscapingCorpus() []string {
+	return internal.EscapingCorpus()
+}
+
+// AddEscapingCorpus adds every string of EscapingCorpus to the seed corpus
+// of f, for fuzz targets taking a single string.
+//
+// Example:
+//
+//	func FuzzAuditLog(f *testing.F) {
+//	    ddtest.AddEscapingCorpus(f)
+//	    f.Fuzz(func(t *testing.T, input string) { ... })
+//	}
+func AddEscapingCorpus(f *testing.F) {
+	for _, s := range EscapingCorpus() {
+		f.Add(s)
+	}
+}
@@ -0,0 +1,215 @@
+package ddtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergodev/dd"
+)
+
+func TestNewTestLogger(t *testing.T) {
+	logger, logs := NewTestLogger()
+	defer logger.Close()
+
+	logger.Debug("starting")
+	logger.InfoWith("charged", dd.String("user", "alice"), dd.Int("amount", 42))
+	logger.WithFields(dd.String("component", "billing")).Warn("slow response")
+	logger.ErrorWith("charge declined", dd.Int("amount", 42), dd.Err(errors.New("card expired")))
+
+	if logs.Len() != 4 {
+		t.Fatalf("expected 4 entries, got %d", logs.Len())
+	}
+
+	want := []string{"starting", "charged", "slow response", "charge declined"}
+	for i, msg := range logs.Messages() {
+		if msg != want[i] {
+			t.Errorf("message %d = %q, want %q", i, msg, want[i])
+		}
+	}
+
+	entries := logs.All()
+	if entries[0].Level != dd.LevelDebug || entries[3].Level != dd.LevelError {
+		t.Errorf("unexpected levels: %v, %v", entries[0].Level, entries[3].Level)
+	}
+	if v, ok := entries[1].Field("user"); !ok || v != "alice" {
+		t.Errorf("user field = %v, %v", v, ok)
+	}
+	if entries[2].FieldMap()["component"] != "billing" {
+		t.Errorf("WithFields field missing: %v", entries[2].Fields)
+	}
+	if entries[1].Time.IsZero() {
+		t.Error("entry time should be set")
+	}
+}
+
+func TestObservedFilters(t *testing.T) {
+	logger, logs := NewTestLogger()
+	defer logger.Close()
+
+	logger.InfoWith("charged", dd.Int("amount", 42))
+	logger.InfoWith("charged", dd.Int("amount", 7))
+	logger.ErrorWith("charge declined", dd.Int("amount", 42))
+	logger.Info("done")
+
+	if n := logs.FilterMessage("charged").Len(); n != 2 {
+		t.Errorf("FilterMessage: got %d, want 2", n)
+	}
+	if n := logs.FilterMessageSnippet("charge").Len(); n != 3 {
+		t.Errorf("FilterMessageSnippet: got %d, want 3", n)
+	}
+	if n := logs.FilterField(dd.Int("amount", 42)).Len(); n != 2 {
+		t.Errorf("FilterField: got %d, want 2", n)
+	}
+	if n := logs.FilterField(dd.Int64("amount", 42)).Len(); n != 0 {
+		t.Errorf("FilterField should compare types, got %d", n)
+	}
+	if n := logs.FilterFieldKey("amount").Len(); n != 3 {
+		t.Errorf("FilterFieldKey: got %d, want 3", n)
+	}
+	if n := logs.FilterLevel(dd.LevelError).Len(); n != 1 {
+		t.Errorf("FilterLevel: got %d, want 1", n)
+	}
+	if n := logs.FilterMessage("charged").FilterField(dd.Int("amount", 7)).Len(); n != 1 {
+		t.Errorf("chained filters: got %d, want 1", n)
+	}
+
+	taken := logs.TakeAll()
+	if len(taken) != 4 || logs.Len() != 0 {
+		t.Errorf("TakeAll: took %d, %d left", len(taken), logs.Len())
+	}
+}
+
+type traceKey struct{}
+
+func TestNewTestLoggerWithConfig(t *testing.T) {
+	cfg := dd.DefaultConfig()
+	cfg.Level = dd.LevelWarn
+	cfg.ContextExtractors = []dd.ContextExtractor{
+		func(ctx context.Context) []dd.Field {
+			if id, ok := ctx.Value(traceKey{}).(string); ok {
+				return []dd.Field{dd.String("trace_id", id)}
+			}
+			return nil
+		},
+	}
+	logger, logs := NewTestLogger(cfg)
+	defer logger.Close()
+
+	ctx := context.WithValue(context.Background(), traceKey{}, "abc")
+	logger.InfoCtx(ctx, "dropped")
+	logger.WarnCtx(ctx, "kept")
+
+	if logs.Len() != 1 {
+		t.Fatalf("expected 1 entry at WARN, got %d", logs.Len())
+	}
+	if logs.FilterField(dd.String("trace_id", "abc")).Len() != 1 {
+		t.Errorf("context field missing: %+v", logs.All())
+	}
+}
+
+func TestNewTestLoggerFatalDoesNotExit(t *testing.T) {
+	logger, logs := NewTestLogger()
+	logger.Fatal("boom")
+
+	if logs.FilterLevel(dd.LevelFatal).Len() != 1 {
+		t.Errorf("expected a fatal entry, got %+v", logs.All())
+	}
+}
+
+func TestObservedRedaction(t *testing.T) {
+	logger, logs := NewTestLogger()
+	defer logger.Close()
+
+	logger.InfoWith("login", dd.String("password", "hunter2"))
+
+	if v, _ := logs.All()[0].Field("password"); v == "hunter2" {
+		t.Error("observed fields should be filtered like real output")
+	}
+}
+
+func TestObservedRedactionOutputScope(t *testing.T) {
+	cfg := dd.DefaultConfig()
+	cfg.Security.FilterScope = dd.FilterScopeOutput
+	logger, logs := NewTestLogger(cfg)
+	defer logger.Close()
+
+	var hookFields []dd.Field
+	logger.AddHook(dd.HookBeforeLog, func(_ context.Context, hc *dd.HookContext) error {
+		hookFields = hc.Fields
+		return nil
+	})
+
+	logger.InfoWith("card 4111111111111111 used", dd.String("note", "card 4111111111111111"))
+
+	entry := logs.All()[0]
+	if strings.Contains(entry.Message, "4111111111111111") {
+		t.Errorf("observed message not filtered: %q", entry.Message)
+	}
+	if v, _ := entry.Field("note"); strings.Contains(fmt.Sprint(v), "4111111111111111") {
+		t.Errorf("observed field not filtered: %v", v)
+	}
+	if len(hookFields) != 1 || strings.Contains(fmt.Sprint(hookFields[0].Value), "4111111111111111") {
+		t.Errorf("hook fields not filtered: %v", hookFields)
+	}
+}
+
+func TestObservedExplicitTime(t *testing.T) {
+	logger, logs := NewTestLogger()
+	defer logger.Close()
+
+	at := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	logger.LogAt(at, dd.LevelInfo, "replayed")
+	logger.Info("live")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries", len(entries))
+	}
+	if !entries[0].Time.Equal(at) {
+		t.Errorf("LogAt entry time = %v, want %v", entries[0].Time, at)
+	}
+	if time.Since(entries[1].Time) > time.Minute {
+		t.Errorf("live entry time = %v", entries[1].Time)
+	}
+}
+
+func TestObservedConcurrent(t *testing.T) {
+	logger, logs := NewTestLogger()
+	defer logger.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				logger.Info("concurrent")
+				_ = logs.Len()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if logs.Len() != 400 {
+		t.Errorf("expected 400 entries, got %d", logs.Len())
+	}
+}
+
+func FuzzEscapingCorpus(f *testing.F) {
+	AddEscapingCorpus(f)
+	logger, logs := NewTestLogger()
+	f.Cleanup(func() { logger.Close() })
+
+	f.Fuzz(func(t *testing.T, input string) {
+		logs.TakeAll()
+		logger.InfoWith("input", dd.String("value", input))
+		if logs.Len() != 1 {
+			t.Fatalf("%d entries recorded", logs.Len())
+		}
+	})
+}
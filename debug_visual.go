@@ -14,10 +14,15 @@ package dd
 //    - Output directly to stdout WITHOUT sensitive data filtering
 //    - SECURITY WARNING: Never use with passwords, tokens, or sensitive data
 //    - For quick debugging only, not for production use
+//
+// 3. Redacting output functions (SecureJSON, SecureText):
+//    - Like JSON and Text, but run the sensitive data filter recursively
+//      over the dumped values first
 
 import (
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/cybergodev/dd/internal"
 )
@@ -65,6 +70,38 @@ func Textf(format string, args ...any) {
 	fmt.Fprintln(os.Stdout, formatted)
 }
 
+// SecureJSON is like JSON but redacts the data with the default logger's
+// sensitive data filter first (see Logger.SecureJSON).
+func SecureJSON(data ...any) {
+	internal.OutputJSON(os.Stdout, internal.GetCaller(debugVisualizationDepth, false), Default().redactDebugData(data)...)
+}
+
+// SecureText is like Text but redacts the data with the default logger's
+// sensitive data filter first (see Logger.SecureText).
+func SecureText(data ...any) {
+	internal.OutputTextData(os.Stdout, Default().redactDebugData(data)...)
+}
+
+// debugFilter is used by SecureJSON and SecureText when the logger has no
+// sensitive data filter.
+var debugFilter = sync.OnceValue(NewSensitiveDataFilter)
+
+// redactDebugData returns data with sensitive values redacted by the
+// logger's filter, or the default filter if the logger has none. Nested
+// maps, slices and structs are filtered by key and value like fields; a
+// disabled filter leaves data unchanged.
+func (l *Logger) redactDebugData(data []any) []any {
+	filter := l.sensitiveFilter(nil)
+	if filter == nil {
+		filter = debugFilter()
+	}
+	redacted := make([]any, len(data))
+	for i, item := range data {
+		redacted[i] = filter.FilterValueRecursive("", item)
+	}
+	return redacted
+}
+
 // Exit outputs data as pretty-printed JSON to stdout and exits with code 0.
 func Exit(data ...any) {
 	internal.OutputText(os.Stdout, internal.GetCaller(debugVisualizationDepth, false), data...)
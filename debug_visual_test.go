@@ -0,0 +1,90 @@
+package dd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// captureStdout returns what fn writes to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Defaults replaced by earlier tests are closed in the background and
+	// read os.Stdout; let them finish before swapping it.
+	replacedDefaults.Wait()
+	old := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = old }()
+	fn()
+	w.Close()
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	return buf.String()
+}
+
+type debugLogin struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Note     string `json:"note"`
+	Tags     map[string]string
+}
+
+func TestSecureDebugOutput(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Output = io.Discard
+	cfg.Security = DefaultSecurityConfig()
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	login := debugLogin{
+		User:     "alice",
+		Password: "hunter2",
+		Note:     "card 4532015112830366",
+		Tags:     map[string]string{"api_key": "k-123", "env": "prod"},
+	}
+	for name, dump := range map[string]func(...any){"SecureText": logger.SecureText, "SecureJSON": logger.SecureJSON} {
+		out := captureStdout(t, func() { dump(login, "token=abcd1234efgh5678") })
+		for _, leaked := range []string{"hunter2", "4532015112830366", "k-123", "abcd1234efgh5678"} {
+			if strings.Contains(out, leaked) {
+				t.Errorf("%s leaked %q: %s", name, leaked, out)
+			}
+		}
+		if !strings.Contains(out, "alice") || !strings.Contains(out, "prod") {
+			t.Errorf("%s dropped safe values: %s", name, out)
+		}
+	}
+	if login.Password != "hunter2" || login.Tags["api_key"] != "k-123" {
+		t.Error("SecureText modified the caller's data")
+	}
+
+	out := captureStdout(t, func() { logger.Text(login) })
+	if !strings.Contains(out, "hunter2") {
+		t.Errorf("Text should stay unfiltered: %s", out)
+	}
+}
+
+func TestSecureDebugWithoutFilter(t *testing.T) {
+	logger, err := New(&Config{Output: io.Discard, Format: FormatText, Level: LevelInfo})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	out := captureStdout(t, func() { logger.SecureJSON(map[string]any{"password": "hunter2"}) })
+	if strings.Contains(out, "hunter2") || !strings.Contains(out, "[REDACTED]") {
+		t.Errorf("SecureJSON without a filter = %s", out)
+	}
+	out = captureStdout(t, func() { SecureText(map[string]any{"secret": "s3cr3t"}) })
+	if strings.Contains(out, "s3cr3t") {
+		t.Errorf("package SecureText = %s", out)
+	}
+}
@@ -0,0 +1,116 @@
+package dd
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Components of an InternalEvent.
+const (
+	ComponentLogger          = "logger"           // default logger setup and Close
+	ComponentFieldValidation = "field_validation" // FieldValidationConfig warnings
+	ComponentFieldConflict   = "field_conflict"   // FieldConflictError duplicates
+	ComponentFatal           = "fatal"            // OnFatal hooks and the final close
+	ComponentHooks           = "hooks"            // hook errors and panics
+	ComponentExtractor       = "extractor"        // context extractor panics and timeouts
+	ComponentEncoder         = "encoder"          // value encoders and fingerprints
+	ComponentFilter          = "filter"           // sensitive data filter timeouts
+	ComponentWriter          = "writer"           // writers, quarantine, rotation, sinks
+	ComponentAudit           = "audit"            // audit logger
+	ComponentSchema          = "schema"           // LogSchema violations
+	ComponentMemory          = "memory"           // MaxMemoryBytes drops
+)
+
+// InternalEvent is a warning or error raised by dd itself rather than by
+// the application, such as a failing hook, a writer that cannot be
+// reopened or a logger that could not be created.
+type InternalEvent struct {
+	Time      time.Time
+	Component string // one of the Component constants
+	Message   string // description, without the "dd: " prefix
+	Err       error  // underlying error, or nil
+}
+
+// String returns the event as dd writes it to stderr.
+func (ev InternalEvent) String() string {
+	return "dd: " + ev.Message
+}
+
+// InternalErrorHandler receives dd's internal events in place of stderr.
+// It is called synchronously from logging, writer and close paths, so it
+// must be fast and must not log through the logger that raised the event.
+type InternalErrorHandler func(ev InternalEvent)
+
+// internalErrorHandler is the handler set with SetInternalErrorHandler.
+var internalErrorHandler atomic.Pointer[InternalErrorHandler]
+
+// SetInternalErrorHandler routes the internal events of every logger and
+// writer to h instead of stderr; nil restores stderr. Config.InternalErrorHandler
+// takes precedence for the events of a logger; events of writers, filters,
+// hook registries and the default logger setup only reach this handler.
+//
+// Some events are only delivered to handlers and never printed, such as
+// sensitive data filter timeouts, which are otherwise counted in
+// FilterStats.
+//
+// Example:
+//
+//	dd.SetInternalErrorHandler(func(ev dd.InternalEvent) {
+//	    metrics.Inc("dd_internal_" + ev.Component)
+//	    diagLog.Printf("%s: %s", ev.Component, ev.Message)
+//	})
+func SetInternalErrorHandler(h InternalErrorHandler) {
+	if h == nil {
+		internalErrorHandler.Store(nil)
+		return
+	}
+	internalErrorHandler.Store(&h)
+}
+
+// reportInternal delivers an internal event to h, the package handler or
+// stderr, in that order of preference.
+func reportInternal(h InternalErrorHandler, component string, err error, format string, args ...any) {
+	deliverInternal(h, true, component, err, format, args...)
+}
+
+// notifyInternal is like reportInternal but drops the event when no
+// handler is set.
+func notifyInternal(h InternalErrorHandler, component string, err error, format string, args ...any) {
+	deliverInternal(h, false, component, err, format, args...)
+}
+
+func deliverInternal(h InternalErrorHandler, print bool, component string, err error, format string, args ...any) {
+	if h == nil {
+		if p := internalErrorHandler.Load(); p != nil {
+			h = *p
+		}
+	}
+	if h == nil && !print {
+		return
+	}
+
+	ev := InternalEvent{
+		Time:      time.Now(),
+		Component: component,
+		Message:   fmt.Sprintf(format, args...),
+		Err:       err,
+	}
+	if h == nil {
+		fmt.Fprintln(os.Stderr, ev.String())
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "dd: InternalErrorHandler panic: %v\n%s\n", r, ev)
+		}
+	}()
+	h(ev)
+}
+
+// internalError reports an internal event of the logger through
+// Config.InternalErrorHandler, falling back to reportInternal's defaults.
+func (l *Logger) internalError(component string, err error, format string, args ...any) {
+	reportInternal(l.internalHandler, component, err, format, args...)
+}
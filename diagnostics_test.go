@@ -0,0 +1,76 @@
+package dd
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestConfigInternalErrorHandler(t *testing.T) {
+	var mu sync.Mutex
+	var events []InternalEvent
+	cfg := DefaultConfig()
+	cfg.Output = io.Discard
+	cfg.FieldValidation = &FieldValidationConfig{Mode: FieldValidationWarn, Convention: NamingConventionSnakeCase}
+	cfg.InternalErrorHandler = func(ev InternalEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, ev)
+	}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.InfoWith("bad key", String("userName", "alice"))
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 1 {
+		t.Fatalf("got %d events: %+v", len(events), events)
+	}
+	ev := events[0]
+	if ev.Component != ComponentFieldValidation || ev.Err == nil || ev.Time.IsZero() {
+		t.Errorf("event = %+v", ev)
+	}
+	if ev.String() != "dd: "+ev.Message {
+		t.Errorf("String = %q", ev.String())
+	}
+}
+
+func TestSetInternalErrorHandler(t *testing.T) {
+	var got []InternalEvent
+	SetInternalErrorHandler(func(ev InternalEvent) { got = append(got, ev) })
+	t.Cleanup(func() { SetInternalErrorHandler(nil) })
+
+	cfg := DefaultConfig()
+	cfg.Output = io.Discard
+	cfg.Hooks = NewHookRegistry()
+	cfg.Hooks.Add(HookBeforeLog, func(context.Context, *HookContext) error { panic("hook bug") })
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("entry")
+	logger.Close()
+
+	if len(got) == 0 || got[0].Component != ComponentHooks || got[0].Err == nil {
+		t.Errorf("events = %+v", got)
+	}
+
+	// A panicking handler does not break logging.
+	SetInternalErrorHandler(func(InternalEvent) { panic("handler bug") })
+	reportInternal(nil, ComponentWriter, nil, "test event")
+}
+
+func TestNotifyInternalWithoutHandler(t *testing.T) {
+	called := false
+	notifyInternal(func(InternalEvent) { called = true }, ComponentFilter, nil, "timeout")
+	if !called {
+		t.Error("handler not called")
+	}
+	// Without a handler the event is dropped rather than printed.
+	notifyInternal(nil, ComponentFilter, nil, "timeout")
+}
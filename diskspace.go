@@ -0,0 +1,211 @@
+package dd
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// DiskPressureReason identifies which FileWriter disk threshold was crossed.
+type DiskPressureReason int
+
+const (
+	// DiskPressureNone means no threshold is crossed; reported on recovery.
+	DiskPressureNone DiskPressureReason = iota
+	// DiskPressureFreeBytes means free space is below MinFreeBytes.
+	DiskPressureFreeBytes
+	// DiskPressureFreePercent means free space is below MinFreeDiskPercent.
+	DiskPressureFreePercent
+	// DiskPressureTotalSize means the log files exceed MaxTotalSizeMB.
+	DiskPressureTotalSize
+)
+
+// String returns the string representation of the reason.
+func (r DiskPressureReason) String() string {
+	switch r {
+	case DiskPressureNone:
+		return "none"
+	case DiskPressureFreeBytes:
+		return "free_bytes"
+	case DiskPressureFreePercent:
+		return "free_percent"
+	case DiskPressureTotalSize:
+		return "total_size"
+	default:
+		return "unknown"
+	}
+}
+
+// DiskPressureEvent describes a disk guard action taken by a FileWriter.
+type DiskPressureEvent struct {
+	Path       string             // Active log file path
+	Reason     DiskPressureReason // Threshold that was crossed when the check ran
+	FreeBytes  int64              // Free space after any pruning
+	TotalBytes int64              // Filesystem size
+	LogBytes   int64              // Active file plus backups (only measured with MaxTotalSizeMB)
+	Pruned     []string           // Backups deleted by this check
+	Degraded   bool               // Whether the writer is now dropping entries below MinLevel
+	MinLevel   LogLevel           // Lowest level written while degraded: WARN, or ERROR in emergency mode
+}
+
+// diskUsage is a snapshot of the values compared against the thresholds.
+type diskUsage struct {
+	free     int64
+	total    int64
+	logBytes int64
+}
+
+// diskGuardEnabled reports whether any disk threshold is configured.
+func (fw *FileWriter) diskGuardEnabled() bool {
+	return fw.minFreeBytes > 0 || fw.minFreePercent > 0 || fw.maxTotalSize > 0
+}
+
+// pressureReason returns the first threshold crossed by usage.
+func (fw *FileWriter) pressureReason(usage diskUsage) DiskPressureReason {
+	switch {
+	case fw.belowMinFreeBytes(usage):
+		return DiskPressureFreeBytes
+	case fw.belowMinFreePercent(usage):
+		return DiskPressureFreePercent
+	case fw.overMaxTotalSize(usage):
+		return DiskPressureTotalSize
+	default:
+		return DiskPressureNone
+	}
+}
+
+// minLevelFor returns the lowest level written under usage: ERROR when
+// MinFreeDiskPercent or MaxTotalSizeMB is crossed (emergency mode), WARN
+// when only MinFreeBytes is.
+func (fw *FileWriter) minLevelFor(usage diskUsage) LogLevel {
+	switch {
+	case fw.belowMinFreePercent(usage) || fw.overMaxTotalSize(usage):
+		return emergencyMinLevel
+	case fw.belowMinFreeBytes(usage):
+		return degradedMinLevel
+	default:
+		return LevelDebug
+	}
+}
+
+func (fw *FileWriter) belowMinFreeBytes(usage diskUsage) bool {
+	return fw.minFreeBytes > 0 && usage.free < fw.minFreeBytes
+}
+
+func (fw *FileWriter) belowMinFreePercent(usage diskUsage) bool {
+	return fw.minFreePercent > 0 && usage.total > 0 &&
+		float64(usage.free)*100 < fw.minFreePercent*float64(usage.total)
+}
+
+func (fw *FileWriter) overMaxTotalSize(usage diskUsage) bool {
+	return fw.maxTotalSize > 0 && usage.logBytes > fw.maxTotalSize
+}
+
+// checkDiskSpace refreshes the degraded state at most once per
+// diskSpaceCheckInterval, pruning backups first where allowed.
+// Caller must hold fw.mu.
+func (fw *FileWriter) checkDiskSpace(now time.Time) {
+	last := fw.lastDiskCheck.Load()
+	if last != 0 && now.UnixNano()-last < int64(diskSpaceCheckInterval) {
+		return
+	}
+	fw.lastDiskCheck.Store(now.UnixNano())
+
+	var usage diskUsage
+	var err error
+	usage.free, usage.total, err = internal.DiskUsage(filepath.Dir(fw.path))
+	if err != nil {
+		// Unknown free space must not stop logging
+		return
+	}
+
+	var backups []string
+	var sizes map[string]int64
+	if fw.maxTotalSize > 0 {
+		backups = fw.listBackups()
+		sizes = make(map[string]int64, len(backups))
+		usage.logBytes = fw.currentSize.Load()
+		for _, backup := range backups {
+			if info, err := os.Stat(backup); err == nil {
+				sizes[backup] = info.Size()
+				usage.logBytes += info.Size()
+			}
+		}
+	}
+
+	trigger := fw.pressureReason(usage)
+	var pruned []string
+	if trigger != DiskPressureNone {
+		if backups == nil {
+			backups = fw.listBackups()
+		}
+		pruned = fw.pruneBackups(backups, sizes, &usage)
+	}
+
+	reason := fw.pressureReason(usage)
+	low := reason != DiskPressureNone
+	minLevel := fw.minLevelFor(usage)
+	changed := fw.degraded.Swap(low) != low
+	if LogLevel(fw.minLevel.Swap(int32(minLevel))) != minLevel && low {
+		changed = true
+	}
+	if changed && low {
+		reportInternal(nil, ComponentWriter, nil, "disk pressure (%s) for %s (%d bytes free): dropping entries below %s",
+			reason, fw.path, usage.free, minLevel)
+	} else if changed {
+		reportInternal(nil, ComponentWriter, nil, "disk space recovered for %s (%d bytes free): resuming normal logging",
+			fw.path, usage.free)
+	}
+
+	if fw.onDiskPressure != nil && (changed || len(pruned) > 0) {
+		fw.wg.Add(1)
+		go fw.callOnDiskPressure(DiskPressureEvent{
+			Path:       fw.path,
+			Reason:     trigger,
+			FreeBytes:  usage.free,
+			TotalBytes: usage.total,
+			LogBytes:   usage.logBytes,
+			Pruned:     pruned,
+			Degraded:   low,
+			MinLevel:   minLevel,
+		})
+	}
+}
+
+// pruneBackups removes the oldest backups while a threshold is crossed and
+// pruning is allowed for it: always for MaxTotalSizeMB, and for free space
+// only with PruneBackupsOnLowDisk. usage is updated in place; the removed
+// paths are returned.
+func (fw *FileWriter) pruneBackups(backups []string, sizes map[string]int64, usage *diskUsage) []string {
+	var pruned []string
+	for _, backup := range backups {
+		if fw.pressureReason(*usage) == DiskPressureNone {
+			break
+		}
+		if !fw.overMaxTotalSize(*usage) && !fw.pruneOnLowDisk {
+			break
+		}
+		if err := os.Remove(backup); err != nil {
+			continue
+		}
+		pruned = append(pruned, backup)
+		usage.logBytes -= sizes[backup]
+		if free, total, err := internal.DiskUsage(filepath.Dir(fw.path)); err == nil {
+			usage.free, usage.total = free, total
+		}
+	}
+	return pruned
+}
+
+// callOnDiskPressure runs the OnDiskPressure callback, recovering from panics.
+func (fw *FileWriter) callOnDiskPressure(event DiskPressureEvent) {
+	defer fw.wg.Done()
+	defer func() {
+		if r := recover(); r != nil {
+			reportInternal(nil, ComponentWriter, nil, "OnDiskPressure callback panic for %s: %v", event.Path, r)
+		}
+	}()
+	fw.onDiskPressure(event)
+}
@@ -0,0 +1,234 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileWriterDiskSpaceGuard(t *testing.T) {
+	t.Run("degraded mode drops low levels", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.log")
+		fw, err := NewFileWriter(path, FileWriterConfig{MinFreeBytes: math.MaxInt64})
+		if err != nil {
+			t.Fatalf("NewFileWriter() error = %v", err)
+		}
+		defer fw.Close()
+
+		if !fw.IsDegraded() {
+			t.Fatal("expected writer to be degraded")
+		}
+
+		if n, err := fw.WriteLevel(LevelInfo, []byte("info\n")); err != nil || n != 5 {
+			t.Errorf("WriteLevel(Info) = %d, %v", n, err)
+		}
+		if _, err := fw.WriteLevel(LevelWarn, []byte("warn\n")); err != nil {
+			t.Errorf("WriteLevel(Warn) error = %v", err)
+		}
+		if _, err := fw.WriteLevel(LevelError, []byte("error\n")); err != nil {
+			t.Errorf("WriteLevel(Error) error = %v", err)
+		}
+		if _, err := fw.Write([]byte("plain\n")); err != nil {
+			t.Errorf("Write() error = %v", err)
+		}
+
+		data, _ := os.ReadFile(path)
+		if strings.Contains(string(data), "info") {
+			t.Error("info entry should have been dropped")
+		}
+		if !strings.Contains(string(data), "warn") || !strings.Contains(string(data), "error") || !strings.Contains(string(data), "plain") {
+			t.Errorf("expected warn, error and plain entries, got %q", data)
+		}
+		if got := fw.DroppedWrites(); got != 1 {
+			t.Errorf("DroppedWrites() = %d, want 1", got)
+		}
+	})
+
+	t.Run("guard disabled", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.log")
+		fw, err := NewFileWriter(path, FileWriterConfig{})
+		if err != nil {
+			t.Fatalf("NewFileWriter() error = %v", err)
+		}
+		defer fw.Close()
+
+		if _, err := fw.WriteLevel(LevelDebug, []byte("debug\n")); err != nil {
+			t.Fatal(err)
+		}
+		if fw.IsDegraded() || fw.DroppedWrites() != 0 {
+			t.Error("writer should not be degraded when MinFreeBytes is 0")
+		}
+	})
+
+	t.Run("prune backups", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "app.log")
+		backup := filepath.Join(dir, "app_log_1.log")
+		if err := os.WriteFile(backup, []byte("old"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		fw, err := NewFileWriter(path, FileWriterConfig{
+			MinFreeBytes:          math.MaxInt64,
+			PruneBackupsOnLowDisk: true,
+		})
+		if err != nil {
+			t.Fatalf("NewFileWriter() error = %v", err)
+		}
+		defer fw.Close()
+
+		if _, err := os.Stat(backup); !os.IsNotExist(err) {
+			t.Error("expected oldest backup to be pruned")
+		}
+	})
+
+	t.Run("invalid thresholds rejected", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.log")
+		for _, cfg := range []FileWriterConfig{
+			{MinFreeBytes: -1},
+			{MinFreeDiskPercent: -1},
+			{MinFreeDiskPercent: 100},
+			{MaxTotalSizeMB: -1},
+		} {
+			if _, err := NewFileWriter(path, cfg); !errors.Is(err, ErrConfigValidation) {
+				t.Errorf("NewFileWriter(%+v) error = %v, want ErrConfigValidation", cfg, err)
+			}
+		}
+	})
+}
+
+func TestFileWriterDiskPressure(t *testing.T) {
+	t.Run("free percent degrades and notifies", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.log")
+		events := make(chan DiskPressureEvent, 4)
+		fw, err := NewFileWriter(path, FileWriterConfig{
+			MinFreeDiskPercent: 99.999,
+			OnDiskPressure:     func(e DiskPressureEvent) { events <- e },
+		})
+		if err != nil {
+			t.Fatalf("NewFileWriter() error = %v", err)
+		}
+		defer fw.Close()
+
+		if !fw.IsDegraded() {
+			t.Skip("filesystem has more than 99.999% free space")
+		}
+		if _, err := fw.WriteLevel(LevelWarn, []byte("warn\n")); err != nil {
+			t.Fatal(err)
+		}
+		if got := fw.DroppedWrites(); got != 1 {
+			t.Errorf("DroppedWrites() = %d, want 1", got)
+		}
+
+		select {
+		case e := <-events:
+			if e.Reason != DiskPressureFreePercent || !e.Degraded || e.MinLevel != LevelError || e.Path != path {
+				t.Errorf("unexpected event %+v", e)
+			}
+			if e.TotalBytes <= 0 {
+				t.Errorf("TotalBytes = %d, want > 0", e.TotalBytes)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("OnDiskPressure was not called")
+		}
+	})
+
+	t.Run("max total size prunes oldest backups", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "app.log")
+		chunk := bytes.Repeat([]byte("x"), 600*1024)
+		for i := 1; i <= 3; i++ {
+			backup := filepath.Join(dir, fmt.Sprintf("app_log_%d.log", i))
+			if err := os.WriteFile(backup, chunk, 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		events := make(chan DiskPressureEvent, 4)
+		fw, err := NewFileWriter(path, FileWriterConfig{
+			MaxTotalSizeMB: 1,
+			OnDiskPressure: func(e DiskPressureEvent) { events <- e },
+		})
+		if err != nil {
+			t.Fatalf("NewFileWriter() error = %v", err)
+		}
+		defer fw.Close()
+
+		if fw.IsDegraded() {
+			t.Error("pruning should have relieved the pressure")
+		}
+		for i, wantExists := range []bool{false, false, true} {
+			backup := filepath.Join(dir, fmt.Sprintf("app_log_%d.log", i+1))
+			if _, err := os.Stat(backup); (err == nil) != wantExists {
+				t.Errorf("%s exists = %v, want %v", backup, err == nil, wantExists)
+			}
+		}
+
+		select {
+		case e := <-events:
+			if e.Reason != DiskPressureTotalSize || e.Degraded || len(e.Pruned) != 2 {
+				t.Errorf("unexpected event %+v", e)
+			}
+			if e.LogBytes != int64(len(chunk)) {
+				t.Errorf("LogBytes = %d, want %d", e.LogBytes, len(chunk))
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("OnDiskPressure was not called")
+		}
+	})
+}
+
+func TestLoggerUsesLevelWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	cfg := DefaultConfig()
+	cfg.Level = LevelDebug
+	cfg.File = &FileConfig{Path: path, MinFreeBytes: math.MaxInt64}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	logger.Debug("debug entry")
+	logger.Info("info entry")
+	logger.Warn("warn entry")
+	logger.Error("error entry")
+	logger.Close()
+
+	data, _ := os.ReadFile(path)
+	out := string(data)
+	for _, dropped := range []string{"debug entry", "info entry"} {
+		if strings.Contains(out, dropped) {
+			t.Errorf("%q should be dropped, got %q", dropped, out)
+		}
+	}
+	for _, kept := range []string{"warn entry", "error entry"} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("%q missing, got %q", kept, out)
+		}
+	}
+}
+
+func TestFileWriterDegradedMinLevel(t *testing.T) {
+	fw := &FileWriter{minFreeBytes: 200, minFreePercent: 10, maxTotalSize: 1000}
+	tests := []struct {
+		usage diskUsage
+		want  LogLevel
+	}{
+		{diskUsage{free: 500, total: 1000, logBytes: 10}, LevelDebug},
+		{diskUsage{free: 150, total: 1000, logBytes: 10}, LevelWarn},
+		{diskUsage{free: 50, total: 1000, logBytes: 10}, LevelError},
+		{diskUsage{free: 500, total: 1000, logBytes: 2000}, LevelError},
+		{diskUsage{free: 150, total: 1500, logBytes: 10}, LevelWarn},
+	}
+	for _, tt := range tests {
+		if got := fw.minLevelFor(tt.usage); got != tt.want {
+			t.Errorf("minLevelFor(%+v) = %v, want %v", tt.usage, got, tt.want)
+		}
+	}
+}
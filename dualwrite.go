@@ -0,0 +1,75 @@
+package dd
+
+import "sync/atomic"
+
+// DualWrite returns a logger that writes each entry twice, once through
+// primary and once through secondary, for migrating consumers from one
+// format or destination to another (a legacy text file and a new JSON
+// pipeline, say) without a cut-over.
+//
+// Unlike Tee, the level, sampling and rate limit decision is made once,
+// by primary, so both copies contain the same entries; the secondary's
+// level, sampling and rate limit are not used. Each logger applies its own
+// security config to the unfiltered entry, then its own hooks, schema,
+// format and writers. DualWriteStats counts the entries each side wrote
+// to confirm parity. A writer shared by both receives each entry once. A
+// nil logger is ignored.
+//
+// Example:
+//
+//	legacy, _ := dd.New(legacyTextConfig) // logs/app.log, as today
+//	next, _ := dd.New(dd.JSONConfig())    // new collector pipeline
+//	logger := dd.DualWrite(legacy, next)
+//	defer logger.Close()
+func DualWrite(primary, secondary *Logger) *Logger {
+	l := Tee(primary, secondary).(*Logger)
+	l.tee.dual = &dualWriteCounters{}
+	return l
+}
+
+// DualWriteStats reports the entries of a DualWrite logger. In a healthy
+// migration Primary and Secondary both equal Entries and Mismatched is 0.
+type DualWriteStats struct {
+	Entries    int64 // Entries passed to both loggers
+	Primary    int64 // Entries the primary logger wrote
+	Secondary  int64 // Entries the secondary logger wrote
+	Mismatched int64 // Entries written by only one of them
+}
+
+// dualWriteCounters holds the counts behind DualWriteStats.
+type dualWriteCounters struct {
+	entries    atomic.Int64
+	primary    atomic.Int64
+	secondary  atomic.Int64
+	mismatched atomic.Int64
+}
+
+// record counts an entry and whether each logger wrote it. An entry
+// dropped by a hook, the schema or an empty writer set is not written.
+func (c *dualWriteCounters) record(wrote [2]bool) {
+	c.entries.Add(1)
+	if wrote[0] {
+		c.primary.Add(1)
+	}
+	if wrote[1] {
+		c.secondary.Add(1)
+	}
+	if wrote[0] != wrote[1] {
+		c.mismatched.Add(1)
+	}
+}
+
+// DualWriteStats returns the parity counters of a logger created with
+// DualWrite, or zero stats for other loggers.
+func (l *Logger) DualWriteStats() DualWriteStats {
+	if l == nil || l.tee == nil || l.tee.dual == nil {
+		return DualWriteStats{}
+	}
+	c := l.tee.dual
+	return DualWriteStats{
+		Entries:    c.entries.Load(),
+		Primary:    c.primary.Load(),
+		Secondary:  c.secondary.Load(),
+		Mismatched: c.mismatched.Load(),
+	}
+}
@@ -0,0 +1,82 @@
+package dd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDualWriteFormatsAndSecurity(t *testing.T) {
+	primary, legacy := newTestLogger(t, func(cfg *Config) { cfg.Security = &SecurityConfig{} })
+	secondary, next := newTestLogger(t, func(cfg *Config) {
+		cfg.Format = FormatJSON
+		cfg.Level = LevelError // ignored: primary decides
+	})
+	logger := DualWrite(primary, secondary)
+
+	logger.InfoWith("login", String("password", "hunter2"), Int("user", 7))
+
+	if !strings.Contains(legacy.String(), "login") || !strings.Contains(legacy.String(), "hunter2") {
+		t.Errorf("legacy output = %q", legacy.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(next.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", next.String(), err)
+	}
+	fields, _ := entry["fields"].(map[string]any)
+	if entry["message"] != "login" || fields["password"] != "[REDACTED]" || fields["user"] != float64(7) {
+		t.Errorf("JSON entry = %v", entry)
+	}
+	if got := logger.DualWriteStats(); got != (DualWriteStats{Entries: 1, Primary: 1, Secondary: 1}) {
+		t.Errorf("stats = %+v", got)
+	}
+}
+
+func TestDualWriteSamplesOnce(t *testing.T) {
+	primary, a := newTestLogger(t, func(cfg *Config) {
+		cfg.Sampling = &SamplingConfig{Enabled: true, Initial: 1, Thereafter: 3}
+	})
+	secondary, b := newTestLogger(t, func(cfg *Config) {
+		cfg.Sampling = &SamplingConfig{Enabled: true, Initial: 1, Thereafter: 2}
+	})
+	logger := DualWrite(primary, secondary)
+
+	for i := 0; i < 10; i++ {
+		logger.Info("tick")
+	}
+	if a.String() != b.String() {
+		t.Errorf("copies diverged:\n%s\n%s", a.String(), b.String())
+	}
+	stats := logger.DualWriteStats()
+	if stats.Entries == 10 || stats.Entries != stats.Primary || stats.Entries != stats.Secondary {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestDualWriteMismatch(t *testing.T) {
+	primary, _ := newTestLogger(t, nil)
+	secondary, _ := newTestLogger(t, func(cfg *Config) {
+		cfg.Format = FormatJSON
+		cfg.Hooks = NewHooksFromConfig(HooksConfig{
+			BeforeLog: []Hook{func(_ context.Context, hc *HookContext) error {
+				if hc.Message == "skip" {
+					return errors.New("rejected")
+				}
+				return nil
+			}},
+		})
+	})
+	logger := DualWrite(primary, secondary)
+
+	logger.Info("keep")
+	logger.Info("skip")
+
+	if got := logger.DualWriteStats(); got != (DualWriteStats{Entries: 2, Primary: 2, Secondary: 1, Mismatched: 1}) {
+		t.Errorf("stats = %+v", got)
+	}
+	if got := (&Logger{}).DualWriteStats(); got != (DualWriteStats{}) {
+		t.Errorf("plain logger stats = %+v", got)
+	}
+}
@@ -0,0 +1,288 @@
+package dd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// EventKey is the key of the field holding the event name of entries
+// written by Emit.
+const EventKey = "event"
+
+// EventsConfig configures the structured events written by Logger.Emit.
+// Events are records for analytics rather than free-text logs: they have
+// an event name and fields but no message, and they bypass the logger's
+// level, Sampling, RateLimit, Adaptive and Schema settings, which are
+// tuned for logs. Their own rules are set here.
+//
+// Example:
+//
+//	cfg.Events = &dd.EventsConfig{
+//	    Schemas: map[string]*dd.EventSchema{
+//	        "signup": {
+//	            Fields:   map[string]dd.SchemaType{"user_id": dd.SchemaString, "plan": dd.SchemaString},
+//	            Required: []string{"user_id"},
+//	        },
+//	    },
+//	    SampleRates: map[string]float64{"page_view": 0.1},
+//	    Outputs:     []io.Writer{eventsFile},
+//	}
+type EventsConfig struct {
+	// Schemas maps event names to their schema. An event that does not
+	// conform is dropped and counted in EventStats.Invalid.
+	Schemas map[string]*EventSchema
+
+	// RequireSchema drops events whose name has no schema.
+	RequireSchema bool
+
+	// SampleRates maps event names to the fraction of events kept, in
+	// [0, 1]. Sampling is deterministic: with 0.1 every tenth event is
+	// kept. Events not listed are all kept.
+	SampleRates map[string]float64
+
+	// Outputs, when set, receive the events instead of the logger's
+	// writers. The logger does not close them.
+	Outputs []io.Writer
+}
+
+// EventSchema is the schema of one event. It is stricter than LogSchema:
+// every field the event carries must be listed in Fields.
+type EventSchema struct {
+	// Fields maps the allowed field keys to their types.
+	Fields map[string]SchemaType
+
+	// Required lists the fields every event must carry.
+	Required []string
+}
+
+// Check returns the violations of an event with fields, or nil if it
+// conforms.
+func (s *EventSchema) Check(fields []Field) []string {
+	if s == nil {
+		return nil
+	}
+	var violations []string
+	for _, key := range s.Required {
+		if !slices.ContainsFunc(fields, func(f Field) bool { return f.Key == key }) {
+			violations = append(violations, fmt.Sprintf("missing required field %q", key))
+		}
+	}
+	for _, field := range fields {
+		want, ok := s.Fields[field.Key]
+		switch {
+		case !ok:
+			violations = append(violations, fmt.Sprintf("unknown field %q", field.Key))
+		case field.Value != nil && !schemaTypeMatches(want, field.Value):
+			violations = append(violations, fmt.Sprintf("field %q is %T, want %s", field.Key, field.Value, want))
+		}
+	}
+	return violations
+}
+
+// Clone returns a deep copy of the config. Outputs are shared.
+func (c *EventsConfig) Clone() *EventsConfig {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	if c.Schemas != nil {
+		clone.Schemas = make(map[string]*EventSchema, len(c.Schemas))
+		for name, schema := range c.Schemas {
+			if schema == nil {
+				clone.Schemas[name] = nil
+				continue
+			}
+			clone.Schemas[name] = &EventSchema{
+				Fields:   cloneSchemaFields(schema.Fields),
+				Required: slices.Clone(schema.Required),
+			}
+		}
+	}
+	if c.SampleRates != nil {
+		clone.SampleRates = make(map[string]float64, len(c.SampleRates))
+		for name, rate := range c.SampleRates {
+			clone.SampleRates[name] = rate
+		}
+	}
+	clone.Outputs = slices.Clone(c.Outputs)
+	return &clone
+}
+
+func cloneSchemaFields(fields map[string]SchemaType) map[string]SchemaType {
+	if fields == nil {
+		return nil
+	}
+	clone := make(map[string]SchemaType, len(fields))
+	for key, t := range fields {
+		clone[key] = t
+	}
+	return clone
+}
+
+// validate checks the schemas, sample rates and outputs.
+func (c *EventsConfig) validate() error {
+	for name, schema := range c.Schemas {
+		if name == "" || schema == nil {
+			return fmt.Errorf("%w: Events.Schemas needs a name and a schema for every entry", ErrConfigValidation)
+		}
+		for key, t := range schema.Fields {
+			if !t.isValid() {
+				return fmt.Errorf("%w: event %q field %q has unknown type %q", ErrConfigValidation, name, key, t)
+			}
+		}
+		for _, key := range schema.Required {
+			if _, ok := schema.Fields[key]; !ok {
+				return fmt.Errorf("%w: event %q requires field %q missing from Fields", ErrConfigValidation, name, key)
+			}
+		}
+	}
+	for name, rate := range c.SampleRates {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("%w: Events.SampleRates[%q] %v is outside [0, 1]", ErrConfigValidation, name, rate)
+		}
+	}
+	for _, w := range c.Outputs {
+		if w == nil {
+			return fmt.Errorf("%w: Events.Outputs", ErrNilWriter)
+		}
+	}
+	return nil
+}
+
+// EventStats reports the events passed to Emit since the logger was
+// created.
+type EventStats struct {
+	Emitted int64 // Events written
+	Sampled int64 // Events dropped by SampleRates
+	Invalid int64 // Events dropped for an empty name or a schema violation
+}
+
+// eventsState is the runtime state of an EventsConfig.
+type eventsState struct {
+	config   *EventsConfig
+	counters sync.Map // event name -> *atomic.Uint64, for SampleRates
+
+	emitted atomic.Int64
+	sampled atomic.Int64
+	invalid atomic.Int64
+}
+
+// newEventsState returns the state for config; nil means no rules.
+func newEventsState(config *EventsConfig) *eventsState {
+	if config == nil {
+		config = &EventsConfig{}
+	}
+	return &eventsState{config: config}
+}
+
+// keep reports whether SampleRates keeps the next event called name.
+func (s *eventsState) keep(name string) bool {
+	rate, ok := s.config.SampleRates[name]
+	if !ok || rate >= 1 {
+		return true
+	}
+	v, _ := s.counters.LoadOrStore(name, new(atomic.Uint64))
+	n := v.(*atomic.Uint64).Add(1)
+	// Keep the events at which the kept count n*rate reaches a new integer
+	return uint64(float64(n)*rate) > uint64(float64(n-1)*rate)
+}
+
+// Emit writes a structured event: an entry with an EventKey field holding
+// name, the given fields and no message, at INFO. Events are written
+// whatever the logger's level and are checked, sampled and routed by
+// Config.Events rather than by the settings for logs. Security filtering,
+// global fields and hooks apply as for logs.
+//
+// Example:
+//
+//	logger.Emit("signup", dd.String("user_id", id), dd.String("plan", "pro"))
+//	// {"level":"INFO","fields":{"event":"signup","user_id":"u-1","plan":"pro"},...}
+func (l *Logger) Emit(name string, fields ...Field) {
+	l.emit(nil, name, fields)
+}
+
+// EmitCtx is like Emit but adds the fields of ctx (see ContextWithFields
+// and the context extractors). Only the fields passed to EmitCtx are
+// checked against the event schema.
+func (l *Logger) EmitCtx(ctx context.Context, name string, fields ...Field) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	l.emit(ctx, name, fields)
+}
+
+func (l *Logger) emit(ctx context.Context, name string, fields []Field) {
+	if l == nil || l.nopEntry != nil || l.closed.Load() {
+		return
+	}
+	if ctx != nil && l.skipForContext(ctx, LevelInfo) {
+		return
+	}
+	events := l.events
+	if name == "" {
+		events.invalid.Add(1)
+		l.internalError(ComponentSchema, nil, "event without a name dropped")
+		return
+	}
+	if !events.keep(name) {
+		events.sampled.Add(1)
+		return
+	}
+
+	schema, ok := events.config.Schemas[name]
+	if !ok && events.config.RequireSchema {
+		events.invalid.Add(1)
+		l.internalError(ComponentSchema, nil, "event %q without a schema dropped", name)
+		return
+	}
+	if violations := schema.Check(fields); len(violations) > 0 {
+		events.invalid.Add(1)
+		l.internalError(ComponentSchema, nil, "event %q dropped: %s", name, strings.Join(violations, "; "))
+		return
+	}
+	events.emitted.Add(1)
+
+	all := make([]Field, 0, len(fields)+1)
+	all = append(all, String(EventKey, name))
+	all = append(all, fields...)
+	if ctx != nil {
+		all = mergeFieldSlices(l.contextFields(ctx), all)
+	}
+
+	var originalFields []Field
+	if l.hooks.Load() != nil {
+		originalFields = slices.Clone(all)
+	}
+	l.logCore(LevelInfo, logEntry{
+		ctx:            ctx,
+		fields:         l.processFields(all),
+		originalFields: originalFields,
+		event:          true,
+	})
+}
+
+// writeEvent writes an event entry to Config.Events.Outputs.
+func (l *Logger) writeEvent(w *entryWriter) {
+	for _, writer := range l.events.config.Outputs {
+		if _, err := w.writeTo(writer); err != nil {
+			l.handleWriteError(writer, err)
+		}
+	}
+}
+
+// EventStats returns the number of events written and dropped by Emit.
+func (l *Logger) EventStats() EventStats {
+	if l == nil || l.events == nil {
+		return EventStats{}
+	}
+	return EventStats{
+		Emitted: l.events.emitted.Load(),
+		Sampled: l.events.sampled.Load(),
+		Invalid: l.events.invalid.Load(),
+	}
+}
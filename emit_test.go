@@ -0,0 +1,164 @@
+package dd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestEmitBypassesLogSettings(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Level = LevelError
+	cfg.Sampling = &SamplingConfig{Enabled: true, Initial: 0, Thereafter: 0}
+	cfg.Schema = &LogSchema{Required: map[LogLevel][]string{LevelInfo: {"request_id"}}, Enforcement: SchemaEnforceDrop}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Info("hidden")
+	logger.EmitCtx(ContextWithFields(context.Background(), String("trace", "t-1")), "signup", String("user_id", "u-1"))
+
+	out := buf.String()
+	if strings.Contains(out, "hidden") {
+		t.Errorf("log entry written: %q", out)
+	}
+	for _, want := range []string{"event=signup", "user_id=u-1", "trace=t-1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+	if got := logger.EventStats(); got != (EventStats{Emitted: 1}) {
+		t.Errorf("stats = %+v", got)
+	}
+}
+
+func TestEmitSchemasSamplingAndOutputs(t *testing.T) {
+	var logs, events bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &logs
+	cfg.Security = DefaultSecurityConfig()
+	cfg.InternalErrorHandler = func(InternalEvent) {}
+	cfg.Events = &EventsConfig{
+		Schemas: map[string]*EventSchema{
+			"signup": {
+				Fields:   map[string]SchemaType{"user_id": SchemaString, "age": SchemaInt, "password": SchemaString},
+				Required: []string{"user_id"},
+			},
+		},
+		SampleRates: map[string]float64{"page_view": 0.25},
+		Outputs:     []io.Writer{&events},
+	}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Info("a log")
+	logger.Emit("signup", String("user_id", "u-1"), Int("age", 30), String("password", "hunter2"))
+	logger.Emit("signup", Int("age", 30))                                    // missing user_id
+	logger.Emit("signup", String("user_id", "u-2"), String("age", "thirty")) // wrong type
+	logger.Emit("signup", String("user_id", "u-3"), String("plan", "pro"))   // unknown field
+	logger.Emit("")
+	for i := 0; i < 8; i++ {
+		logger.Emit("page_view", Int("n", i))
+	}
+
+	if strings.Contains(logs.String(), "event=") || !strings.Contains(logs.String(), "a log") {
+		t.Errorf("log output = %q", logs.String())
+	}
+	out := events.String()
+	if !strings.Contains(out, "user_id=u-1") || strings.Contains(out, "hunter2") {
+		t.Errorf("valid event missing or unfiltered: %q", out)
+	}
+	for _, rejected := range []string{"u-2", "u-3"} {
+		if strings.Contains(out, rejected) {
+			t.Errorf("invalid event %s written: %q", rejected, out)
+		}
+	}
+	if n := strings.Count(out, "event=page_view"); n != 2 {
+		t.Errorf("%d page_view events kept, want 2", n)
+	}
+	if got := logger.EventStats(); got != (EventStats{Emitted: 3, Sampled: 6, Invalid: 4}) {
+		t.Errorf("stats = %+v", got)
+	}
+}
+
+func TestEmitRequireSchemaAndValidation(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.InternalErrorHandler = func(InternalEvent) {}
+	cfg.Events = &EventsConfig{RequireSchema: true}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Emit("unknown")
+	logger.Close()
+	if buf.Len() != 0 || logger.EventStats().Invalid != 1 {
+		t.Errorf("event without schema: output %q, stats %+v", buf.String(), logger.EventStats())
+	}
+
+	for name, events := range map[string]*EventsConfig{
+		"rate":     {SampleRates: map[string]float64{"x": 2}},
+		"type":     {Schemas: map[string]*EventSchema{"x": {Fields: map[string]SchemaType{"a": "uuid"}}}},
+		"required": {Schemas: map[string]*EventSchema{"x": {Required: []string{"a"}}}},
+		"nil":      {Schemas: map[string]*EventSchema{"x": nil}},
+	} {
+		cfg := DefaultConfig()
+		cfg.Events = events
+		if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+			t.Errorf("%s: New = %v", name, err)
+		}
+	}
+	if got := Nop().EventStats(); got != (EventStats{}) {
+		t.Errorf("Nop stats = %+v", got)
+	}
+	Nop().Emit("ignored")
+}
+
+func TestEmitOmitsMessage(t *testing.T) {
+	for name, newConfig := range map[string]func() *Config{
+		"json": JSONConfig,
+		"ecs":  ECSConfig,
+		"gcp":  GCPConfig,
+	} {
+		var buf bytes.Buffer
+		cfg := newConfig()
+		cfg.Output = &buf
+		logger, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		logger.Emit("signup", String("user_id", "u-1"))
+		logger.Info("")
+		logger.Close()
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("%s: output = %q", name, buf.String())
+		}
+		var event, log map[string]any
+		if err := json.Unmarshal([]byte(lines[0]), &event); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err := json.Unmarshal([]byte(lines[1]), &log); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if _, ok := event["message"]; ok || !strings.Contains(lines[0], `"event":"signup"`) {
+			t.Errorf("%s: event = %s", name, lines[0])
+		}
+		if _, ok := log["message"]; !ok {
+			t.Errorf("%s: log entry lost its message key: %s", name, lines[1])
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package dd
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// RedactionEntropy marks a token redacted by the entropy detector in
+// SecurityConfig.AuditRedactions events.
+const RedactionEntropy = "entropy"
+
+const (
+	defaultEntropyMinLength = 20
+	defaultEntropyThreshold = 0.85
+	maxEntropyMinLength     = 4096
+)
+
+// EntropyConfig configures the entropy detector of a SensitiveDataFilter.
+// The detector splits filtered text into tokens of base64 and hex
+// characters (A-Z a-z 0-9 + / = _ -) and redacts tokens that are long and
+// random enough to be a secret, such as bearer tokens in formats the
+// pattern packs do not know.
+type EntropyConfig struct {
+	// MinLength is the shortest token checked. Zero uses 20.
+	MinLength int
+
+	// Threshold is the Shannon entropy above which a token is redacted,
+	// as a fraction (0-1] of the highest entropy a token of its length and
+	// alphabet (hex or base64) can have. Zero uses 0.85: random tokens
+	// score about 0.9, identifiers, paths and UUIDs 0.8 or less.
+	Threshold float64
+
+	// Allowlist holds tokens that are never redacted, such as known
+	// build IDs.
+	Allowlist []string
+
+	// AllowPatterns keeps tokens matching any of the patterns, such as
+	// commit hashes (`^[0-9a-f]{40}$`) or trace IDs.
+	AllowPatterns []*regexp.Regexp
+}
+
+// entropyDetector is an immutable EntropyConfig with defaults applied.
+type entropyDetector struct {
+	minLength     int
+	threshold     float64
+	allowlist     map[string]bool
+	allowPatterns []*regexp.Regexp
+}
+
+// newEntropyDetector validates cfg and applies its defaults.
+func newEntropyDetector(cfg EntropyConfig) (*entropyDetector, error) {
+	if cfg.MinLength < 0 || cfg.MinLength > maxEntropyMinLength {
+		return nil, fmt.Errorf("%w: EntropyConfig.MinLength must be between 0 and %d", ErrConfigValidation, maxEntropyMinLength)
+	}
+	if !(cfg.Threshold >= 0 && cfg.Threshold <= 1) {
+		return nil, fmt.Errorf("%w: EntropyConfig.Threshold must be between 0 and 1", ErrConfigValidation)
+	}
+	if slices.Contains(cfg.AllowPatterns, nil) {
+		return nil, fmt.Errorf("%w: EntropyConfig.AllowPatterns contains a nil pattern", ErrConfigValidation)
+	}
+
+	d := &entropyDetector{
+		minLength:     cfg.MinLength,
+		threshold:     cfg.Threshold,
+		allowPatterns: slices.Clone(cfg.AllowPatterns),
+	}
+	if d.minLength == 0 {
+		d.minLength = defaultEntropyMinLength
+	}
+	if d.threshold == 0 {
+		d.threshold = defaultEntropyThreshold
+	}
+	if len(cfg.Allowlist) > 0 {
+		d.allowlist = make(map[string]bool, len(cfg.Allowlist))
+		for _, token := range cfg.Allowlist {
+			d.allowlist[token] = true
+		}
+	}
+	return d, nil
+}
+
+// EnableEntropyDetection makes the filter also redact high-entropy tokens
+// not matched by its patterns, replacing any previous entropy settings.
+// Entropy detection is off by default.
+//
+// Example:
+//
+//	filter := dd.NewSensitiveDataFilter()
+//	err := filter.EnableEntropyDetection(dd.EntropyConfig{
+//	    AllowPatterns: []*regexp.Regexp{regexp.MustCompile(`^[0-9a-f]{40}$`)},
+//	})
+func (f *SensitiveDataFilter) EnableEntropyDetection(cfg EntropyConfig) error {
+	if f == nil {
+		return ErrNilFilter
+	}
+	d, err := newEntropyDetector(cfg)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entropy.Store(d)
+	f.publishPatterns()
+	return nil
+}
+
+// DisableEntropyDetection turns off entropy detection.
+func (f *SensitiveDataFilter) DisableEntropyDetection() {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entropy.Store(nil)
+	f.publishPatterns()
+}
+
+// EntropyDetectionEnabled reports whether entropy detection is on.
+func (f *SensitiveDataFilter) EntropyDetectionEnabled() bool {
+	return f != nil && f.entropy.Load() != nil
+}
+
+// redactHighEntropy applies the entropy detector d (nil when disabled) to
+// s, counting the tokens it redacts.
+func (f *SensitiveDataFilter) redactHighEntropy(d *entropyDetector, s string) string {
+	result, n := d.redactHighEntropy(s)
+	if n > 0 {
+		f.totalEntropy.Add(int64(n))
+	}
+	return result
+}
+
+// redactHighEntropy replaces the high-entropy tokens of s with
+// [REDACTED] and returns the number replaced.
+func (d *entropyDetector) redactHighEntropy(s string) (string, int) {
+	if d == nil || len(s) < d.minLength {
+		return s, 0
+	}
+	var b strings.Builder
+	count, last := 0, 0
+	for start := 0; start < len(s); {
+		if !isEntropyTokenChar(s[start]) {
+			start++
+			continue
+		}
+		end := start + 1
+		for end < len(s) && isEntropyTokenChar(s[end]) {
+			end++
+		}
+		if d.isSecret(s[start:end]) {
+			if count == 0 {
+				b.Grow(len(s))
+			}
+			b.WriteString(s[last:start])
+			b.WriteString("[REDACTED]")
+			last = end
+			count++
+		}
+		start = end
+	}
+	if count == 0 {
+		return s, 0
+	}
+	b.WriteString(s[last:])
+	return b.String(), count
+}
+
+// isSecret reports whether token is long and random enough to be a secret
+// and is not allowed.
+func (d *entropyDetector) isSecret(token string) bool {
+	// Trim base64 padding and separators so "----" or "====" runs do not
+	// count towards the length.
+	core := strings.Trim(token, "=-_")
+	if len(core) < d.minLength {
+		return false
+	}
+	alphabet := 64
+	if isHexToken(core) {
+		alphabet = 16
+	}
+	if shannonEntropy(core) <= d.threshold*math.Log2(float64(min(len(core), alphabet))) {
+		return false
+	}
+	if d.allowlist[token] || d.allowlist[core] {
+		return false
+	}
+	for _, re := range d.allowPatterns {
+		if re.MatchString(core) {
+			return false
+		}
+	}
+	return true
+}
+
+// isEntropyTokenChar reports whether c belongs to the base64 (standard or
+// URL-safe) alphabet.
+func isEntropyTokenChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '+' || c == '/' || c == '=' || c == '_' || c == '-'
+}
+
+// isHexToken reports whether s consists of hex digits only.
+func isHexToken(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// shannonEntropy returns the Shannon entropy of the bytes of s in bits per
+// byte.
+func shannonEntropy(s string) float64 {
+	var counts [256]int
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+	n := float64(len(s))
+	var h float64
+	for _, c := range counts {
+		if c == 0 {
+			continue
+		}
+		p := float64(c) / n
+		h -= p * math.Log2(p)
+	}
+	return h
+}
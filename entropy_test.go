@@ -0,0 +1,124 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const (
+	testEntropyToken = "Zq8vN2xKp4RtW9mYb3LcHs7DfGj5QeUa"
+	testEntropySHA   = "9f2c4e7a1b3d5f608e2a4c6b8d0f1e3a5c7b9d2e"
+)
+
+func TestEntropyDetectionRedactsRandomTokens(t *testing.T) {
+	filter := NewEmptySensitiveDataFilter()
+	if filter.EntropyDetectionEnabled() {
+		t.Fatal("entropy detection enabled by default")
+	}
+	if got := filter.Filter("auth " + testEntropyToken); got != "auth "+testEntropyToken {
+		t.Fatalf("disabled detector changed input: %q", got)
+	}
+
+	if err := filter.EnableEntropyDetection(EntropyConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	got := filter.Filter("upstream replied with X-Session " + testEntropyToken + " for AbstractSingletonProxyFactoryBean")
+	want := "upstream replied with X-Session [REDACTED] for AbstractSingletonProxyFactoryBean"
+	if got != want {
+		t.Errorf("Filter = %q, want %q", got, want)
+	}
+
+	for _, safe := range []string{
+		"/usr/local/lib/python3/site-packages",
+		"550e8400-e29b-41d4-a716-446655440000",
+		"internal_server_error_while_processing",
+		"github.com/cybergodev/dd/internal/formatting",
+		"short Zq8vN2xKp4Rt",
+	} {
+		if got := filter.Filter(safe); got != safe {
+			t.Errorf("Filter(%q) = %q", safe, got)
+		}
+	}
+	if n := filter.GetFilterStats().EntropyRedactions; n != 1 {
+		t.Errorf("EntropyRedactions = %d, want 1", n)
+	}
+
+	filter.DisableEntropyDetection()
+	if got := filter.Filter(testEntropyToken); got != testEntropyToken {
+		t.Errorf("after disable: %q", got)
+	}
+}
+
+func TestEntropyDetectionAllowlist(t *testing.T) {
+	filter := NewSensitiveDataFilter()
+	err := filter.EnableEntropyDetection(EntropyConfig{
+		Allowlist:     []string{testEntropyToken},
+		AllowPatterns: []*regexp.Regexp{regexp.MustCompile(`^[0-9a-f]{40}$`)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := "deploy " + testEntropySHA + " build " + testEntropyToken
+	if got := filter.Filter(input); got != input {
+		t.Errorf("Filter = %q", got)
+	}
+	if got := filter.Filter("other " + strings.ToLower(testEntropyToken)); !strings.Contains(got, "[REDACTED]") {
+		t.Errorf("unlisted token kept: %q", got)
+	}
+
+	clone := filter.Clone()
+	if !clone.EntropyDetectionEnabled() {
+		t.Error("Clone dropped entropy detection")
+	}
+}
+
+func TestEntropyDetectionThreshold(t *testing.T) {
+	strict := NewEmptySensitiveDataFilter()
+	if err := strict.EnableEntropyDetection(EntropyConfig{Threshold: 0.99}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strict.Filter(testEntropySHA); got != testEntropySHA {
+		t.Errorf("Threshold 0.99 redacted %q", got)
+	}
+
+	loose := NewEmptySensitiveDataFilter()
+	if err := loose.EnableEntropyDetection(EntropyConfig{Threshold: 0.7, MinLength: 30}); err != nil {
+		t.Fatal(err)
+	}
+	if got := loose.Filter("AbstractSingletonProxyFactoryBean"); got != "[REDACTED]" {
+		t.Errorf("Threshold 0.7 kept %q", got)
+	}
+
+	for _, cfg := range []EntropyConfig{{Threshold: 1.5}, {Threshold: -1}, {MinLength: -1}, {AllowPatterns: []*regexp.Regexp{nil}}} {
+		if err := loose.EnableEntropyDetection(cfg); !errors.Is(err, ErrConfigValidation) {
+			t.Errorf("EnableEntropyDetection(%+v) = %v", cfg, err)
+		}
+	}
+}
+
+func TestEntropyDetectionInLogger(t *testing.T) {
+	filter := NewSensitiveDataFilter()
+	if err := filter.EnableEntropyDetection(EntropyConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Security = &SecurityConfig{SensitiveFilter: filter}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.InfoWith("callback", String("header", testEntropyToken))
+	if out := buf.String(); strings.Contains(out, testEntropyToken) || !strings.Contains(out, "[REDACTED]") {
+		t.Errorf("output = %q", out)
+	}
+	if got := filter.matchingPacks("header " + testEntropyToken); len(got) != 1 || got[0] != RedactionEntropy {
+		t.Errorf("matchingPacks = %v", got)
+	}
+}
@@ -1,20 +1,28 @@
 package dd
 
 import (
+	"context"
 	"fmt"
+
+	"github.com/cybergodev/dd/internal"
 )
 
 // LoggerEntry represents a logger with pre-set fields.
 // Fields are inherited and merged with additional fields passed to logging methods.
 // LoggerEntry is immutable - each WithFields call returns a new entry.
 type LoggerEntry struct {
-	logger *Logger
-	fields []Field
+	logger   *Logger
+	fields   []Field
+	tenant   *tenantState   // set for entries returned by Logger.Tenant or Named
+	security *entrySecurity // set by WithSecurity
+	name     string         // set by Named and Child
+	child    *childNode     // set by Child
 }
 
 // newLoggerEntry creates a new LoggerEntry with the given logger and fields.
 func newLoggerEntry(logger *Logger, fields []Field) *LoggerEntry {
-	// Copy fields to ensure immutability
+	// Copy fields to ensure immutability; repeated keys keep the last value
+	fields = internal.DedupFields(fields, false, nil)
 	copiedFields := make([]Field, len(fields))
 	copy(copiedFields, fields)
 	return &LoggerEntry{
@@ -125,16 +133,22 @@ func mergeFieldSlicesLarge(existingFields, newFields []Field) []Field {
 //	entry2 := entry.WithFields(dd.String("version", "1.0"))
 //	entry2.Info("request received") // Contains both service and version fields
 func (e *LoggerEntry) WithFields(fields ...Field) *LoggerEntry {
-	if len(fields) == 0 {
+	if len(fields) == 0 || e.logger.nopEntry != nil {
 		return e
 	}
 
-	// Fast path: no existing fields
+	var entry *LoggerEntry
 	if len(e.fields) == 0 {
-		return newLoggerEntry(e.logger, fields)
+		// Fast path: no existing fields
+		entry = newLoggerEntry(e.logger, fields)
+	} else {
+		entry = newLoggerEntry(e.logger, mergeFieldSlices(e.fields, fields))
 	}
-
-	return newLoggerEntry(e.logger, mergeFieldSlices(e.fields, fields))
+	entry.tenant = e.tenant
+	entry.security = e.security
+	entry.name = e.name
+	entry.child = e.child
+	return entry
 }
 
 // WithField returns a new LoggerEntry with a single additional field.
@@ -156,10 +170,16 @@ func (e *LoggerEntry) mergeFields(fields []Field) []Field {
 // logWithDepth logs a message at the specified level with the entry's fields,
 // using an increased caller depth to correctly report the caller location.
 // This is the internal implementation that handles the extra stack frames from LoggerEntry.
-func (e *LoggerEntry) logWithDepth(level LogLevel, msg string, fields []Field) {
-	if !e.logger.shouldLog(level) {
+// ctx is nil for methods without a context; template marks msg as a
+// message template (see LogT).
+func (e *LoggerEntry) logWithDepth(ctx context.Context, level LogLevel, msg string, fields []Field, template bool) {
+	if !e.shouldLog(ctx, level) {
 		return
 	}
+	if ctx != nil {
+		// Entry and call fields override context fields
+		fields = mergeFieldSlices(e.logger.contextFields(ctx), fields)
+	}
 
 	// Copy original fields if hooks are registered
 	var originalFields []Field
@@ -168,30 +188,58 @@ func (e *LoggerEntry) logWithDepth(level LogLevel, msg string, fields []Field) {
 		copy(originalFields, fields)
 	}
 
-	msg = e.logger.applyMessageSecurity(msg)
-	processedFields := e.logger.processFields(fields)
+	filter := e.logger.sensitiveFilter(e.security)
+	msg = e.logger.applyMessageSecurityWith(msg, filter)
+	processedFields := e.logger.processFieldsWith(fields, filter)
 
 	e.logger.logCoreWithDepth(level, logEntry{
+		ctx:            ctx,
 		msg:            msg,
 		fields:         processedFields,
 		originalFields: originalFields,
+		template:       template,
+		security:       e.security,
+		tenant:         e.tenant,
 	}, entryCallerDepth)
 }
 
+// shouldLog applies the tenant overrides, if any, on top of the logger checks.
+func (e *LoggerEntry) shouldLog(ctx context.Context, level LogLevel) bool {
+	if e.tenant == nil {
+		return e.logger.shouldLogCtx(ctx, level)
+	}
+	return e.logger.shouldLogTenant(ctx, level, e.tenant)
+}
+
 // Log logs a message at the specified level with the entry's fields.
 func (e *LoggerEntry) Log(level LogLevel, args ...any) {
-	e.logWithDepth(level, e.logger.formatter.FormatArgsToString(args...), e.fields)
+	if e.logger.nopEntry != nil {
+		return
+	}
+	e.logWithDepth(nil, level, e.logger.formatter.FormatArgsToString(args...), e.fields, false)
 }
 
 // Logf logs a formatted message at the specified level with the entry's fields.
 func (e *LoggerEntry) Logf(level LogLevel, format string, args ...any) {
+	if e.logger.nopEntry != nil {
+		return
+	}
 	msg := fmt.Sprintf(format, args...)
-	e.logWithDepth(level, msg, e.fields)
+	e.logWithDepth(nil, level, msg, e.fields, false)
 }
 
 // LogWith logs a structured message with the entry's fields plus additional fields.
 func (e *LoggerEntry) LogWith(level LogLevel, msg string, fields ...Field) {
-	e.logWithDepth(level, msg, e.mergeFields(fields))
+	if e.logger.nopEntry != nil {
+		return
+	}
+	e.logWithDepth(nil, level, msg, e.mergeFields(fields), false)
+}
+
+// LogCtx logs a structured message with context fields, the entry's fields
+// and additional fields. See Logger.LogCtx.
+func (e *LoggerEntry) LogCtx(ctx context.Context, level LogLevel, msg string, fields ...Field) {
+	e.logWithDepth(ctx, level, msg, e.mergeFields(fields), false)
 }
 
 // Convenience methods for each log level
@@ -214,6 +262,25 @@ func (e *LoggerEntry) WarnWith(msg string, fields ...Field)  { e.LogWith(LevelWa
 func (e *LoggerEntry) ErrorWith(msg string, fields ...Field) { e.LogWith(LevelError, msg, fields...) }
 func (e *LoggerEntry) FatalWith(msg string, fields ...Field) { e.LogWith(LevelFatal, msg, fields...) }
 
+func (e *LoggerEntry) DebugCtx(ctx context.Context, msg string, fields ...Field) {
+	e.LogCtx(ctx, LevelDebug, msg, fields...)
+}
+func (e *LoggerEntry) InfoCtx(ctx context.Context, msg string, fields ...Field) {
+	e.LogCtx(ctx, LevelInfo, msg, fields...)
+}
+func (e *LoggerEntry) WarnCtx(ctx context.Context, msg string, fields ...Field) {
+	e.LogCtx(ctx, LevelWarn, msg, fields...)
+}
+func (e *LoggerEntry) ErrorCtx(ctx context.Context, msg string, fields ...Field) {
+	e.LogCtx(ctx, LevelError, msg, fields...)
+}
+
+// FatalCtx logs at FATAL level with context and entry fields and terminates
+// the program via os.Exit(1).
+func (e *LoggerEntry) FatalCtx(ctx context.Context, msg string, fields ...Field) {
+	e.LogCtx(ctx, LevelFatal, msg, fields...)
+}
+
 // Print methods - output via logger's writers with caller info and entry's fields.
 // These methods use LevelInfo for filtering and apply sensitive data filtering.
 
@@ -246,6 +313,12 @@ func (e *LoggerEntry) Printf(format string, args ...any) {
 //	entry.Info("request received") // Contains service and version fields
 //	entry.WithFields(dd.String("user", "john")).Info("user action") // Contains all three fields
 func (l *Logger) WithFields(fields ...Field) *LoggerEntry {
+	if l == nil {
+		return nilLogger().nopEntry
+	}
+	if l.nopEntry != nil {
+		return l.nopEntry
+	}
 	return newLoggerEntry(l, fields)
 }
 
@@ -256,5 +329,11 @@ func (l *Logger) WithFields(fields ...Field) *LoggerEntry {
 //
 //	entry := logger.WithField("request_id", "abc123")
 func (l *Logger) WithField(key string, value any) *LoggerEntry {
+	if l == nil {
+		return nilLogger().nopEntry
+	}
+	if l.nopEntry != nil {
+		return l.nopEntry
+	}
 	return newLoggerEntry(l, []Field{{Key: key, Value: value}})
 }
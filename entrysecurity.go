@@ -0,0 +1,97 @@
+package dd
+
+// entrySecurity overrides the sensitive data filter for a LoggerEntry.
+type entrySecurity struct {
+	level  SecurityLevel
+	filter *SensitiveDataFilter // nil for SecurityLevelDevelopment
+}
+
+// WithSecurity returns a LoggerEntry that filters messages and fields with
+// the sensitive data filter of the given security level instead of the
+// logger's, while sharing the logger's writers and other settings. This
+// lets one module (for example a payments package) log with
+// SecurityLevelParanoid while the rest of the application uses the
+// logger's SecurityConfig.
+//
+// Only the sensitive data filter is overridden; MaxMessageSize and
+// MaxFieldSizes still come from the logger's SecurityConfig. Filters are
+// built once per level and shared by all entries of the logger.
+//
+// Example:
+//
+//	payments := logger.WithSecurity(dd.SecurityLevelParanoid).WithField("module", "payments")
+//	payments.Info("charge accepted")
+func (l *Logger) WithSecurity(level SecurityLevel) *LoggerEntry {
+	if l == nil {
+		return nilLogger().nopEntry
+	}
+	if l.nopEntry != nil {
+		return l.nopEntry
+	}
+	entry := newLoggerEntry(l, nil)
+	entry.security = l.securityForLevel(level)
+	return entry
+}
+
+// WithSecurity returns a copy of the entry that uses the sensitive data
+// filter of the given security level. See Logger.WithSecurity.
+func (e *LoggerEntry) WithSecurity(level SecurityLevel) *LoggerEntry {
+	if e.logger.nopEntry != nil {
+		return e
+	}
+	entry := newLoggerEntry(e.logger, e.fields)
+	entry.tenant = e.tenant
+	entry.name = e.name
+	entry.child = e.child
+	entry.security = e.logger.securityForLevel(level)
+	return entry
+}
+
+// SecurityLevel returns the security level set with WithSecurity and true,
+// or false if the entry uses the logger's SecurityConfig.
+func (e *LoggerEntry) SecurityLevel() (SecurityLevel, bool) {
+	if e.security == nil {
+		return 0, false
+	}
+	return e.security.level, true
+}
+
+// securityForLevel returns the cached filter override for level, building
+// it on first use.
+func (l *Logger) securityForLevel(level SecurityLevel) *entrySecurity {
+	if cached, ok := l.securityFilters.Load(level); ok {
+		return cached.(*entrySecurity)
+	}
+	sec := &entrySecurity{
+		level:  level,
+		filter: SecurityConfigForLevel(level).SensitiveFilter,
+	}
+	actual, _ := l.securityFilters.LoadOrStore(level, sec)
+	return actual.(*entrySecurity)
+}
+
+// sensitiveFilter returns the filter of the override, or the logger's
+// filter if override is nil.
+func (l *Logger) sensitiveFilter(override *entrySecurity) *SensitiveDataFilter {
+	if override != nil {
+		return override.filter
+	}
+	if secConfig := l.getSecurityConfig(); secConfig != nil {
+		return secConfig.SensitiveFilter
+	}
+	return nil
+}
+
+// eachSensitiveFilter calls fn for the logger's filter and every filter
+// built for WithSecurity.
+func (l *Logger) eachSensitiveFilter(fn func(*SensitiveDataFilter)) {
+	if filter := l.sensitiveFilter(nil); filter != nil {
+		fn(filter)
+	}
+	l.securityFilters.Range(func(_, value any) bool {
+		if filter := value.(*entrySecurity).filter; filter != nil {
+			fn(filter)
+		}
+		return true
+	})
+}
@@ -0,0 +1,83 @@
+package dd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithSecurityOverridesFilter(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	logger.Info("contact user@example.com")
+	if !strings.Contains(buf.String(), "user@example.com") {
+		t.Fatalf("basic filter should keep emails: %q", buf.String())
+	}
+
+	buf.Reset()
+	payments := logger.WithSecurity(SecurityLevelParanoid)
+	payments.InfoWith("contact user@example.com", String("note", "reach admin@example.com"))
+	if strings.Contains(buf.String(), "@example.com") {
+		t.Errorf("paranoid entry should redact emails: %q", buf.String())
+	}
+
+	buf.Reset()
+	logger.Info("contact user@example.com")
+	if !strings.Contains(buf.String(), "user@example.com") {
+		t.Errorf("the logger should keep its own filter: %q", buf.String())
+	}
+}
+
+func TestWithSecurityDevelopmentDisablesFilter(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	logger.WithSecurity(SecurityLevelDevelopment).Info("password=hunter22")
+	if !strings.Contains(buf.String(), "hunter22") {
+		t.Errorf("development level should not filter: %q", buf.String())
+	}
+}
+
+func TestWithSecurityPropagation(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	entry := logger.WithField("module", "payments").WithSecurity(SecurityLevelParanoid)
+	child := entry.WithField("step", "charge")
+	if level, ok := child.SecurityLevel(); !ok || level != SecurityLevelParanoid {
+		t.Errorf("SecurityLevel = %v, %v", level, ok)
+	}
+	if _, ok := logger.WithField("k", "v").SecurityLevel(); ok {
+		t.Error("entries without an override should report none")
+	}
+
+	child.Info("contact user@example.com")
+	out := buf.String()
+	if strings.Contains(out, "user@example.com") || !strings.Contains(out, "module=payments") {
+		t.Errorf("override and fields should be inherited: %q", out)
+	}
+
+	tenant := logger.Tenant("acme").WithSecurity(SecurityLevelParanoid)
+	if tenant.tenant == nil {
+		t.Error("WithSecurity should keep the tenant")
+	}
+}
+
+func TestWithSecurityCachesFilters(t *testing.T) {
+	logger, _ := newTestLogger(t, nil)
+
+	a := logger.WithSecurity(SecurityLevelStrict)
+	b := logger.WithSecurity(SecurityLevelStrict)
+	if a.security != b.security {
+		t.Error("filters should be built once per level")
+	}
+	if !logger.WaitForFilterGoroutines(time.Second) {
+		t.Error("WaitForFilterGoroutines should cover override filters")
+	}
+}
+
+func TestWithSecurityNop(t *testing.T) {
+	logger := Nop()
+	if entry := logger.WithSecurity(SecurityLevelParanoid); entry == nil {
+		t.Fatal("expected an entry")
+	}
+	logger.WithSecurity(SecurityLevelParanoid).Info("ignored")
+}
@@ -0,0 +1,85 @@
+package dd
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMaxEntrySizeOmitsLargeValues(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.Format = FormatJSON
+		cfg.DynamicCaller = false
+		cfg.Security = &SecurityConfig{MaxEntrySize: 512}
+	})
+
+	ids := make([]int, 10000)
+	logger.InfoWith("batch done", Any("ids", ids), String("job", "sync"))
+
+	line := strings.TrimSuffix(buf.String(), "\n")
+	if len(line) > 512 {
+		t.Fatalf("entry is %d bytes, limit 512", len(line))
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		t.Fatalf("entry should stay valid JSON: %v\n%s", err, line)
+	}
+	fields := entry["fields"].(map[string]any)
+	if fields[EntryTruncatedKey] != true || fields[EntryOriginalSizeKey].(float64) <= 512 {
+		t.Errorf("missing truncation markers: %v", fields)
+	}
+	if !strings.Contains(fields["ids"].(string), "bytes omitted") || fields["job"] != "sync" || entry["message"] != "batch done" {
+		t.Errorf("only the large value should be replaced: %v", entry)
+	}
+}
+
+func TestMaxEntrySizeShortensStrings(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.Format = FormatJSON
+		cfg.DynamicCaller = false
+		cfg.Security = &SecurityConfig{MaxEntrySize: 300}
+	})
+
+	logger.InfoWith("upload", String("body", strings.Repeat("x", 5000)))
+	line := strings.TrimSuffix(buf.String(), "\n")
+	if len(line) > 300 || !strings.Contains(line, `xxx..."`) {
+		t.Errorf("body should be shortened to fit: %d bytes %s", len(line), line)
+	}
+}
+
+func TestMaxEntrySizeByLevel(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.Format = FormatJSON
+		cfg.DynamicCaller = false
+		cfg.Security = &SecurityConfig{
+			MaxEntrySize:        200,
+			MaxEntrySizeByLevel: map[LogLevel]int{LevelError: 0},
+		}
+	})
+
+	big := String("body", strings.Repeat("y", 1000))
+	logger.ErrorWith("kept", big)
+	if buf.Len() < 1000 || strings.Contains(buf.String(), EntryTruncatedKey) {
+		t.Errorf("error entries should not be limited: %d bytes", buf.Len())
+	}
+	buf.Reset()
+	logger.InfoWith("limited", big)
+	if len(strings.TrimSuffix(buf.String(), "\n")) > 200 {
+		t.Errorf("info entries should be limited: %d bytes", buf.Len())
+	}
+}
+
+func TestMaxEntrySizeValidation(t *testing.T) {
+	for _, sc := range []*SecurityConfig{
+		{MaxEntrySize: -1},
+		{MaxEntrySizeByLevel: map[LogLevel]int{LevelInfo: -5}},
+		{MaxEntrySizeByLevel: map[LogLevel]int{LogLevel(42): 100}},
+	} {
+		cfg := DefaultConfig()
+		cfg.Security = sc
+		if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+			t.Errorf("%+v: expected ErrConfigValidation, got %v", sc, err)
+		}
+	}
+}
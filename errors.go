@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+
+	"github.com/cybergodev/dd/internal"
 )
 
 // Error codes for structured error handling.
@@ -39,6 +41,7 @@ const (
 	ErrCodeWriterAdd          = "WRITER_ADD"
 	ErrCodeMultipleConfigs    = "MULTIPLE_CONFIGS"
 	ErrCodeNilMultiWriter     = "NIL_MULTIWRITER"
+	ErrCodeHookAborted        = "HOOK_ABORTED"
 )
 
 // LoggerError represents a structured error with additional context.
@@ -109,6 +112,7 @@ var errorCodeToSentinel = map[string]error{
 	ErrCodeWriterAdd:          ErrWriterAdd,
 	ErrCodeMultipleConfigs:    ErrMultipleConfigs,
 	ErrCodeNilMultiWriter:     ErrNilMultiWriter,
+	ErrCodeHookAborted:        ErrHookAborted,
 }
 
 // allErrorCodes contains all defined error codes for validation.
@@ -144,6 +148,7 @@ var allErrorCodes = []string{
 	ErrCodeWriterAdd,
 	ErrCodeMultipleConfigs,
 	ErrCodeNilMultiWriter,
+	ErrCodeHookAborted,
 }
 
 // validateErrorCodeMapping validates that all error codes have a corresponding
@@ -226,36 +231,47 @@ func (e *LoggerError) WithField(key string, value any) *LoggerError {
 // Sentinel errors for backward compatibility.
 // These can be used with errors.Is() for simple error matching.
 var (
-	ErrNilConfig          = errors.New("config cannot be nil")
-	ErrNilWriter          = errors.New("writer cannot be nil")
-	ErrNilFilter          = errors.New("filter cannot be nil")
-	ErrNilHook            = errors.New("hook cannot be nil")
-	ErrNilExtractor       = errors.New("context extractor cannot be nil")
-	ErrLoggerClosed       = errors.New("logger is closed")
-	ErrWriterNotFound     = errors.New("writer not found")
-	ErrInvalidLevel       = errors.New("invalid log level")
-	ErrInvalidFormat      = errors.New("invalid log format")
-	ErrMaxWritersExceeded = errors.New("maximum writer count exceeded")
-	ErrEmptyFilePath      = errors.New("file path cannot be empty")
-	ErrPathTooLong        = errors.New("file path too long")
-	ErrPathTraversal      = errors.New("path traversal detected")
-	ErrNullByte           = errors.New("null byte in input")
-	ErrInvalidPath        = errors.New("invalid file path")
-	ErrSymlinkNotAllowed  = errors.New("symlinks not allowed")
-	ErrHardlinkNotAllowed = errors.New("hardlinks not allowed")
-	ErrOverlongEncoding   = errors.New("UTF-8 overlong encoding detected")
-	ErrMaxSizeExceeded    = errors.New("maximum size exceeded")
-	ErrMaxBackupsExceeded = errors.New("maximum backup count exceeded")
-	ErrBufferSizeTooLarge = errors.New("buffer size too large")
-	ErrInvalidPattern     = errors.New("invalid regex pattern")
-	ErrEmptyPattern       = errors.New("pattern cannot be empty")
-	ErrPatternTooLong     = errors.New("pattern length exceeds maximum")
-	ErrReDoSPattern       = errors.New("pattern contains dangerous nested quantifiers that may cause ReDoS")
-	ErrPatternFailed      = errors.New("failed to add pattern")
-	ErrConfigValidation   = errors.New("configuration validation failed")
-	ErrWriterAdd          = errors.New("failed to add writer")
-	ErrMultipleConfigs    = errors.New("multiple configs provided, expected 0 or 1")
-	ErrNilMultiWriter     = errors.New("multiwriter is nil")
+	ErrNilConfig             = errors.New("config cannot be nil")
+	ErrNilWriter             = errors.New("writer cannot be nil")
+	ErrNilFilter             = errors.New("filter cannot be nil")
+	ErrNilHook               = errors.New("hook cannot be nil")
+	ErrNilExtractor          = errors.New("context extractor cannot be nil")
+	ErrLoggerClosed          = errors.New("logger is closed")
+	ErrWriterNotFound        = errors.New("writer not found")
+	ErrInvalidLevel          = internal.ErrInvalidLevel
+	ErrInvalidFormat         = internal.ErrInvalidFormat
+	ErrMaxWritersExceeded    = errors.New("maximum writer count exceeded")
+	ErrEmptyFilePath         = errors.New("file path cannot be empty")
+	ErrPathTooLong           = errors.New("file path too long")
+	ErrPathTraversal         = errors.New("path traversal detected")
+	ErrNullByte              = errors.New("null byte in input")
+	ErrInvalidPath           = errors.New("invalid file path")
+	ErrSymlinkNotAllowed     = errors.New("symlinks not allowed")
+	ErrHardlinkNotAllowed    = errors.New("hardlinks not allowed")
+	ErrOverlongEncoding      = errors.New("UTF-8 overlong encoding detected")
+	ErrMaxSizeExceeded       = errors.New("maximum size exceeded")
+	ErrMaxBackupsExceeded    = errors.New("maximum backup count exceeded")
+	ErrBufferSizeTooLarge    = errors.New("buffer size too large")
+	ErrInvalidPattern        = errors.New("invalid regex pattern")
+	ErrEmptyPattern          = errors.New("pattern cannot be empty")
+	ErrPatternTooLong        = errors.New("pattern length exceeds maximum")
+	ErrReDoSPattern          = errors.New("pattern contains dangerous nested quantifiers that may cause ReDoS")
+	ErrPatternFailed         = errors.New("failed to add pattern")
+	ErrConfigValidation      = errors.New("configuration validation failed")
+	ErrWriterAdd             = errors.New("failed to add writer")
+	ErrMultipleConfigs       = errors.New("multiple configs provided, expected 0 or 1")
+	ErrNilMultiWriter        = errors.New("multiwriter is nil")
+	ErrHookAborted           = errors.New("aborted by hook")
+	ErrCompressionBacklog    = errors.New("compression queue full")
+	ErrPresetNotFound        = errors.New("preset not found")
+	ErrWriteTimeout          = errors.New("write timed out")
+	ErrNotChildLogger        = errors.New("entry was not created by Child")
+	ErrWriterFactoryNotFound = errors.New("writer factory not found")
+	ErrNilLogger             = errors.New("logger is nil")
+	ErrExtractorPanic        = errors.New("context extractor panicked")
+	ErrExtractorTimeout      = errors.New("context extractor timed out")
+	ErrReservedFieldKey      = errors.New("reserved field key")
+	ErrShutdownHookTimeout   = errors.New("shutdown hook timed out")
 )
 
 // WriterError represents an error from a single writer in a MultiWriter.
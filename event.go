@@ -0,0 +1,213 @@
+package dd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// maxPooledEventFields keeps events with unusually many fields out of the
+// pool so one large event does not pin a large slice.
+const maxPooledEventFields = 64
+
+var eventPool = sync.Pool{
+	New: func() any {
+		return &Event{fields: make([]Field, 0, 8)}
+	},
+}
+
+// Event is a log entry built with chained calls and written by Msg, Msgf
+// or Send. Events come from a pool: after Msg, Msgf or Send the Event must
+// not be used again.
+//
+// Event methods are safe to call on a nil *Event, which is what
+// Logger.Event returns for disabled levels, so the whole chain costs almost
+// nothing when the level is off.
+//
+// Example:
+//
+//	logger.Event(dd.LevelInfo).
+//	    Str("user", "alice").
+//	    Int("status", 200).
+//	    Err(err).
+//	    Msg("request done")
+type Event struct {
+	logger *Logger
+	level  LogLevel
+	ctx    context.Context
+	fields []Field
+	time   time.Time // set by At
+}
+
+// Event starts a new entry at the given level. It returns nil if the level
+// is disabled.
+func (l *Logger) Event(level LogLevel) *Event {
+	if !l.shouldLog(level) {
+		return nil
+	}
+	return newEvent(l, nil, level)
+}
+
+// EventCtx is like Event but attaches ctx: context fields are added and
+// level and context policies use ctx.
+func (l *Logger) EventCtx(ctx context.Context, level LogLevel) *Event {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !l.shouldLogCtx(ctx, level) {
+		return nil
+	}
+	return newEvent(l, ctx, level)
+}
+
+func newEvent(l *Logger, ctx context.Context, level LogLevel) *Event {
+	e := eventPool.Get().(*Event)
+	e.logger = l
+	e.level = level
+	e.ctx = ctx
+	return e
+}
+
+// putEvent returns e to the pool.
+func putEvent(e *Event) {
+	if cap(e.fields) > maxPooledEventFields {
+		return
+	}
+	clear(e.fields)
+	e.fields = e.fields[:0]
+	e.logger = nil
+	e.ctx = nil
+	e.time = time.Time{}
+	eventPool.Put(e)
+}
+
+// Enabled reports whether the event will be written.
+func (e *Event) Enabled() bool {
+	return e != nil
+}
+
+// Str adds a string field.
+func (e *Event) Str(key, value string) *Event {
+	return e.add(String(key, value))
+}
+
+// Strs adds a string slice field.
+func (e *Event) Strs(key string, value []string) *Event {
+	return e.add(Strings(key, value))
+}
+
+// Int adds an int field.
+func (e *Event) Int(key string, value int) *Event {
+	return e.add(Int(key, value))
+}
+
+// Int64 adds an int64 field.
+func (e *Event) Int64(key string, value int64) *Event {
+	return e.add(Int64(key, value))
+}
+
+// Uint64 adds a uint64 field.
+func (e *Event) Uint64(key string, value uint64) *Event {
+	return e.add(Uint64(key, value))
+}
+
+// Float64 adds a float64 field.
+func (e *Event) Float64(key string, value float64) *Event {
+	return e.add(Float64(key, value))
+}
+
+// Bool adds a bool field.
+func (e *Event) Bool(key string, value bool) *Event {
+	return e.add(Bool(key, value))
+}
+
+// Dur adds a time.Duration field.
+func (e *Event) Dur(key string, value time.Duration) *Event {
+	return e.add(Duration(key, value))
+}
+
+// Time adds a time.Time field.
+func (e *Event) Time(key string, value time.Time) *Event {
+	return e.add(Time(key, value))
+}
+
+// Err adds an "error" field. A nil error adds nothing.
+func (e *Event) Err(err error) *Event {
+	if err == nil {
+		return e
+	}
+	return e.add(Err(err))
+}
+
+// Any adds a field with an arbitrary value.
+func (e *Event) Any(key string, value any) *Event {
+	return e.add(Any(key, value))
+}
+
+// Fields adds already constructed fields.
+func (e *Event) Fields(fields ...Field) *Event {
+	if e == nil {
+		return nil
+	}
+	e.fields = append(e.fields, fields...)
+	return e
+}
+
+func (e *Event) add(field Field) *Event {
+	if e == nil {
+		return nil
+	}
+	e.fields = append(e.fields, field)
+	return e
+}
+
+// Msg writes the event with the given message.
+func (e *Event) Msg(msg string) {
+	if e == nil {
+		return
+	}
+	e.write(msg)
+}
+
+// Msgf writes the event with a formatted message.
+func (e *Event) Msgf(format string, args ...any) {
+	if e == nil {
+		return
+	}
+	e.write(fmt.Sprintf(format, args...))
+}
+
+// Send writes the event with an empty message.
+func (e *Event) Send() {
+	if e == nil {
+		return
+	}
+	e.write("")
+}
+
+// write logs the event and returns it to the pool. It must be called
+// directly from Msg, Msgf or Send to keep the caller depth of Logger.Log.
+func (e *Event) write(msg string) {
+	l := e.logger
+	fields := e.fields
+	if e.ctx != nil {
+		// Event fields override context fields
+		fields = mergeFieldSlices(l.contextFields(e.ctx), fields)
+	}
+
+	var originalFields []Field
+	if l.hooks.Load() != nil && len(fields) > 0 {
+		originalFields = make([]Field, len(fields))
+		copy(originalFields, fields)
+	}
+
+	l.logCore(e.level, logEntry{
+		ctx:            e.ctx,
+		msg:            l.applyMessageSecurity(msg),
+		fields:         l.processFields(fields),
+		originalFields: originalFields,
+		time:           e.time,
+	})
+	putEvent(e)
+}
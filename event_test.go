@@ -0,0 +1,115 @@
+package dd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventBuilder(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	logger.Event(LevelWarn).
+		Str("user", "alice").
+		Int("status", 200).
+		Int64("bytes", 1024).
+		Bool("cached", true).
+		Dur("took", 35*time.Millisecond).
+		Err(errors.New("boom")).
+		Fields(String("extra", "x")).
+		Msg("request done")
+
+	out := buf.String()
+	for _, want := range []string{"WARN", "request done", "user=alice", "status=200", "bytes=1024", "cached=true", "took=35ms", "error=boom", "extra=x"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q: %q", want, out)
+		}
+	}
+}
+
+func TestEventMsgfAndSend(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	logger.Event(LevelInfo).Err(nil).Msgf("took %d ms", 12)
+	logger.Event(LevelInfo).Str("k", "v").Send()
+
+	out := buf.String()
+	if !strings.Contains(out, "took 12 ms") || strings.Contains(out, "error=") {
+		t.Errorf("unexpected Msgf output %q", out)
+	}
+	if !strings.Contains(out, "k=v") {
+		t.Errorf("Send should write the fields: %q", out)
+	}
+}
+
+func TestEventDisabledLevel(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	e := logger.Event(LevelDebug)
+	if e != nil || e.Enabled() {
+		t.Fatal("disabled levels should return a nil event")
+	}
+	e.Str("k", "v").Int("n", 1).Err(errors.New("x")).Msg("dropped")
+	if buf.Len() != 0 {
+		t.Errorf("nothing should be written: %q", buf.String())
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		logger.Event(LevelDebug).Str("k", "v").Int("n", 1).Msg("dropped")
+	})
+	if allocs != 0 {
+		t.Errorf("disabled events should not allocate, got %v", allocs)
+	}
+}
+
+func TestEventCtx(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+	ctx := ContextWithFields(context.Background(), String("request_id", "r-1"), String("user", "ctx"))
+
+	logger.EventCtx(ctx, LevelInfo).Str("user", "event").Msg("with context")
+
+	out := buf.String()
+	if !strings.Contains(out, "request_id=r-1") || !strings.Contains(out, "user=event") || strings.Contains(out, "user=ctx") {
+		t.Errorf("event fields should override context fields: %q", out)
+	}
+}
+
+func TestEventFilteringAndReuse(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Security = DefaultSecurityConfig()
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Event(LevelInfo).Str("password", "hunter22").Msg("login")
+	logger.Event(LevelInfo).Msg("second")
+
+	out := buf.String()
+	if strings.Contains(out, "hunter22") {
+		t.Errorf("event fields should be filtered: %q", out)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || strings.Contains(lines[1], "password") {
+		t.Errorf("pooled events should start empty: %q", out)
+	}
+}
+
+func BenchmarkEventBuilder(b *testing.B) {
+	cfg := DefaultConfig()
+	cfg.Output = io.Discard
+	logger, _ := New(cfg)
+	defer logger.Close()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Event(LevelInfo).Str("user", "alice").Int("status", 200).Msg("request done")
+	}
+}
@@ -0,0 +1,117 @@
+package dd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MatchReport describes what one filter pattern does to an input.
+type MatchReport struct {
+	Pattern string   // the pattern's regular expression
+	Pack    string   // the pattern pack, or "" for custom patterns
+	Offsets [][2]int // byte ranges [start, end) matched in the original input
+
+	// Redacted is the input after this pattern and every pattern before
+	// it were applied. The last report's Redacted is the filter output.
+	Redacted string
+}
+
+// Explain reports which active patterns match input, where they match, and
+// how each one changes the output, in the order Filter applies them.
+// Patterns that neither match the input nor change the output are left
+// out, so an empty result means Filter leaves input unchanged.
+//
+// Explain is meant for tuning patterns: it ignores whether the filter is
+// enabled, does not truncate long input or apply the filter timeout, and
+// does not update the filter statistics or cache.
+//
+// Example:
+//
+//	for _, m := range filter.Explain("password=hunter2 user=bob") {
+//	    fmt.Printf("%s (%s) at %v -> %s\n", m.Pattern, m.Pack, m.Offsets, m.Redacted)
+//	}
+func (f *SensitiveDataFilter) Explain(input string) []MatchReport {
+	if f == nil || input == "" {
+		return nil
+	}
+
+	f.mu.RLock()
+	sources := make([]packPattern, 0, len(f.sources))
+	for _, p := range f.sources {
+		if p.pack != "" && f.disabledPacks[p.pack] {
+			continue
+		}
+		sources = append(sources, p)
+	}
+	f.mu.RUnlock()
+
+	var reports []MatchReport
+	result := input
+	for _, p := range sources {
+		var offsets [][2]int
+		for _, loc := range p.re.FindAllStringIndex(input, -1) {
+			offsets = append(offsets, [2]int{loc[0], loc[1]})
+		}
+
+		before := result
+		if result != "" && result != "[REDACTED]" {
+			result = f.replaceWithPattern(result, p.re)
+		}
+		if offsets == nil && result == before {
+			continue
+		}
+		reports = append(reports, MatchReport{
+			Pattern:  p.re.String(),
+			Pack:     p.pack,
+			Offsets:  offsets,
+			Redacted: result,
+		})
+	}
+	return reports
+}
+
+// PatternCase is an input and the output a filter should produce for it.
+type PatternCase struct {
+	Input string
+	Want  string
+}
+
+// TestPatterns runs each case through filter.Explain and returns an error
+// describing every case whose output differs from Want, or nil if all pass.
+// It lets CI check custom patterns without running a logger:
+//
+//	func TestRedaction(t *testing.T) {
+//	    err := dd.TestPatterns(filter,
+//	        dd.PatternCase{Input: "token=abc123", Want: "token=[REDACTED]"},
+//	        dd.PatternCase{Input: "order=42", Want: "order=42"},
+//	    )
+//	    if err != nil {
+//	        t.Fatal(err)
+//	    }
+//	}
+func TestPatterns(filter *SensitiveDataFilter, cases ...PatternCase) error {
+	if filter == nil {
+		return ErrNilFilter
+	}
+
+	var errs []error
+	for _, c := range cases {
+		reports := filter.Explain(c.Input)
+		got := c.Input
+		if len(reports) > 0 {
+			got = reports[len(reports)-1].Redacted
+		}
+		if got == c.Want {
+			continue
+		}
+
+		matched := make([]string, len(reports))
+		for i, r := range reports {
+			matched[i] = r.Pattern
+		}
+		errs = append(errs, fmt.Errorf("input %q: got %q, want %q (matched: %s)",
+			c.Input, got, c.Want, strings.Join(matched, ", ")))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,81 @@
+package dd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilterExplain(t *testing.T) {
+	filter, err := NewCustomSensitiveDataFilter(`(?i)(token=)\S+`, `order-\d+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := "token=abc order-42 order-7"
+
+	reports := filter.Explain(input)
+	if len(reports) != 2 {
+		t.Fatalf("expected 2 reports, got %+v", reports)
+	}
+	if reports[0].Pattern != `(?i)(token=)\S+` || reports[0].Pack != "" ||
+		len(reports[0].Offsets) != 1 || reports[0].Offsets[0] != [2]int{0, 9} {
+		t.Errorf("unexpected first report: %+v", reports[0])
+	}
+	if reports[1].Offsets[0] != [2]int{10, 18} || reports[1].Offsets[1] != [2]int{19, 26} {
+		t.Errorf("unexpected offsets: %v", reports[1].Offsets)
+	}
+	want := filter.Filter(input)
+	if reports[1].Redacted != want || want != "token=[REDACTED] [REDACTED] [REDACTED]" {
+		t.Errorf("Redacted = %q, Filter = %q", reports[1].Redacted, want)
+	}
+
+	if got := filter.Explain("nothing here"); len(got) != 0 {
+		t.Errorf("no match should give no reports, got %+v", got)
+	}
+}
+
+func TestFilterExplainLeavesStatsAlone(t *testing.T) {
+	filter := NewSensitiveDataFilter()
+	filter.Disable()
+
+	reports := filter.Explain("password=hunter2")
+	if len(reports) == 0 || reports[0].Pack != PackCredentials {
+		t.Fatalf("disabled filter should still explain built-in patterns: %+v", reports)
+	}
+	if stats := filter.GetFilterStats(); stats.TotalFiltered != 0 || stats.TotalRedactions != 0 || stats.CacheMiss != 0 {
+		t.Errorf("Explain should not update stats: %+v", stats)
+	}
+
+	var nilFilter *SensitiveDataFilter
+	if nilFilter.Explain("password=x") != nil {
+		t.Error("nil filter should explain nothing")
+	}
+}
+
+func TestTestPatterns(t *testing.T) {
+	filter, _ := NewCustomSensitiveDataFilter(`(token=)\S+`)
+
+	err := TestPatterns(filter,
+		PatternCase{Input: "token=abc", Want: "token=[REDACTED]"},
+		PatternCase{Input: "order=42", Want: "order=42"},
+	)
+	if err != nil {
+		t.Errorf("passing cases: %v", err)
+	}
+
+	err = TestPatterns(filter,
+		PatternCase{Input: "token=abc", Want: "token=abc"},
+		PatternCase{Input: "secret=abc", Want: "secret=[REDACTED]"},
+	)
+	if err == nil {
+		t.Fatal("expected failures")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, `input "token=abc"`) || !strings.Contains(msg, `(token=)\S+`) ||
+		!strings.Contains(msg, `input "secret=abc"`) {
+		t.Errorf("error should describe both failures: %s", msg)
+	}
+
+	if err := TestPatterns(nil); err != ErrNilFilter {
+		t.Errorf("nil filter: %v", err)
+	}
+}
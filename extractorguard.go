@@ -0,0 +1,204 @@
+package dd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// defaultExtractorMaxFailures is the ExtractorGuardConfig.MaxFailures
+// default.
+const defaultExtractorMaxFailures = 5
+
+// ExtractorGuardConfig isolates context extractors so a buggy or slow one
+// cannot stall or break logging. Panics are recovered whether or not a
+// guard is configured; the guard adds a time limit per call and disables
+// an extractor after MaxFailures consecutive failures (panics or
+// timeouts). HookOnExtractorDisabled reports disabled extractors.
+//
+// Example:
+//
+//	cfg.ExtractorGuard = &dd.ExtractorGuardConfig{Timeout: 5 * time.Millisecond, MaxFailures: 3}
+type ExtractorGuardConfig struct {
+	// Timeout bounds each extractor call (0 for none). A call that does
+	// not return in time is abandoned and its fields are dropped; the
+	// extractor's context keeps the caller's values but is cancelled only
+	// at the deadline, so it can stop.
+	//
+	// A Timeout runs every extractor call of every *Ctx entry in its own
+	// goroutine with its own context timer, which costs a goroutine and a
+	// few allocations per extractor per entry. Leave it 0 unless an
+	// extractor can block.
+	Timeout time.Duration
+	// MaxFailures is the number of consecutive failures that disables an
+	// extractor (default 5).
+	MaxFailures int
+}
+
+// withDefaults returns c with zero values replaced by defaults.
+func (c ExtractorGuardConfig) withDefaults() ExtractorGuardConfig {
+	if c.MaxFailures == 0 {
+		c.MaxFailures = defaultExtractorMaxFailures
+	}
+	return c
+}
+
+// validate checks the config for negative values.
+func (c *ExtractorGuardConfig) validate() error {
+	if c.Timeout < 0 || c.MaxFailures < 0 {
+		return fmt.Errorf("%w: ExtractorGuard values must not be negative", ErrConfigValidation)
+	}
+	return nil
+}
+
+// ExtractorStats reports the calls and failures of a context extractor.
+type ExtractorStats struct {
+	Index     int   // Position of the extractor in GetContextExtractors
+	Calls     int64 // Calls made, including failed ones
+	Panics    int64 // Calls that panicked
+	Timeouts  int64 // Calls abandoned after ExtractorGuardConfig.Timeout
+	Disabled  bool  // Whether the extractor was disabled by ExtractorGuard
+	LastError error // Most recent failure, or nil
+}
+
+// extractorState counts the calls and failures of one extractor.
+type extractorState struct {
+	calls    atomic.Int64
+	panics   atomic.Int64
+	timeouts atomic.Int64
+	failures atomic.Int64 // consecutive
+	disabled atomic.Bool
+	lastErr  atomic.Pointer[error]
+}
+
+// run calls fn, recovering panics and applying the guard's timeout.
+func (s *extractorState) run(ctx context.Context, fn ContextExtractor, guard *ExtractorGuardConfig) ([]Field, error) {
+	s.calls.Add(1)
+	if guard == nil || guard.Timeout <= 0 {
+		return s.succeed(callExtractor(ctx, fn))
+	}
+
+	// The caller's cancellation must not abandon the call: entries of
+	// canceled requests still need their fields
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), guard.Timeout)
+	defer cancel()
+
+	type result struct {
+		fields []Field
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		fields, err := callExtractor(ctx, fn)
+		done <- result{fields, err}
+	}()
+
+	select {
+	case res := <-done:
+		// A result that raced the deadline is dropped like a late one
+		if ctx.Err() == nil {
+			return s.succeed(res.fields, res.err)
+		}
+	case <-ctx.Done():
+	}
+	return nil, fmt.Errorf("%w after %v", ErrExtractorTimeout, guard.Timeout)
+}
+
+// succeed resets the consecutive failure count after a successful call.
+func (s *extractorState) succeed(fields []Field, err error) ([]Field, error) {
+	if err == nil {
+		s.failures.Store(0)
+	}
+	return fields, err
+}
+
+// fail records a failed call and disables the extractor once it reaches
+// the guard's MaxFailures.
+func (s *extractorState) fail(index int, err error, guard *ExtractorGuardConfig, onDisabled func(index int, failures int64, err error)) {
+	if errors.Is(err, ErrExtractorTimeout) {
+		s.timeouts.Add(1)
+	} else {
+		s.panics.Add(1)
+	}
+	s.lastErr.Store(&err)
+
+	failures := s.failures.Add(1)
+	if guard == nil || failures < int64(guard.MaxFailures) || !s.disabled.CompareAndSwap(false, true) {
+		return
+	}
+	if onDisabled != nil {
+		onDisabled(index, failures, err)
+	} else {
+		reportInternal(nil, ComponentExtractor, err, "context extractor %d disabled after %d failures: %v", index, failures, err)
+	}
+}
+
+// stats returns the counters of the extractor at index.
+func (s *extractorState) stats(index int) ExtractorStats {
+	stats := ExtractorStats{
+		Index:    index,
+		Calls:    s.calls.Load(),
+		Panics:   s.panics.Load(),
+		Timeouts: s.timeouts.Load(),
+		Disabled: s.disabled.Load(),
+	}
+	if err := s.lastErr.Load(); err != nil {
+		stats.LastError = *err
+	}
+	return stats
+}
+
+// callExtractor calls fn, turning a panic into an ErrExtractorPanic error.
+func callExtractor(ctx context.Context, fn ContextExtractor) (fields []Field, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			reportInternal(nil, ComponentExtractor, nil, "context extractor panic: %v", rec)
+			fields, err = nil, fmt.Errorf("%w: %v", ErrExtractorPanic, rec)
+		}
+	}()
+	return fn(ctx), nil
+}
+
+// ContextExtractorStats returns the calls and failures of the logger's
+// context extractors, in the order of GetContextExtractors. It returns nil
+// when the logger uses the default extractors.
+//
+// Example:
+//
+//	for _, s := range logger.ContextExtractorStats() {
+//	    if s.Disabled {
+//	        alert("context extractor %d disabled: %v", s.Index, s.LastError)
+//	    }
+//	}
+func (l *Logger) ContextExtractorStats() []ExtractorStats {
+	if l == nil {
+		return nil
+	}
+	v := l.contextExtractors.Load()
+	if v == nil {
+		return nil
+	}
+	extractorsPtr := v.(*ContextExtractorRegistry).extractorsPtr.Load()
+	if extractorsPtr == nil || len(*extractorsPtr) == 0 {
+		return nil
+	}
+	stats := make([]ExtractorStats, len(*extractorsPtr))
+	for i, e := range *extractorsPtr {
+		stats[i] = e.state.stats(i)
+	}
+	return stats
+}
+
+// extractorDisabled reports a disabled extractor and triggers
+// HookOnExtractorDisabled.
+func (l *Logger) extractorDisabled(index int, failures int64, err error) {
+	l.internalError(ComponentExtractor, err, "context extractor %d disabled after %d failures: %v", index, failures, err)
+	_ = l.triggerHooks(l.ctx, &HookContext{
+		Event:     HookOnExtractorDisabled,
+		Error:     err,
+		Timestamp: l.clock.Now(),
+		Metadata:  map[string]any{"index": index},
+	})
+}
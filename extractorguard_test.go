@@ -0,0 +1,122 @@
+package dd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExtractorGuardDisablesPanickingExtractor(t *testing.T) {
+	var buf bytes.Buffer
+	var disabled atomic.Int32
+	var hookErr atomic.Value
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.ExtractorGuard = &ExtractorGuardConfig{MaxFailures: 2}
+	cfg.ContextExtractors = []ContextExtractor{
+		func(context.Context) []Field { panic("broken") },
+		func(context.Context) []Field { return []Field{String("ok", "yes")} },
+	}
+	cfg.Hooks = NewHooksFromConfig(HooksConfig{
+		OnExtractorDisabled: []Hook{func(_ context.Context, hc *HookContext) error {
+			disabled.Add(1)
+			hookErr.Store(hc.Error)
+			return nil
+		}},
+	})
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	for i := 0; i < 4; i++ {
+		logger.InfoCtx(context.Background(), "entry")
+	}
+
+	if n := strings.Count(buf.String(), "ok=yes"); n != 4 {
+		t.Errorf("healthy extractor ran %d times, want 4: %q", n, buf.String())
+	}
+	if disabled.Load() != 1 {
+		t.Errorf("OnExtractorDisabled called %d times, want 1", disabled.Load())
+	}
+	if err, _ := hookErr.Load().(error); !errors.Is(err, ErrExtractorPanic) {
+		t.Errorf("hook error = %v", err)
+	}
+
+	stats := logger.ContextExtractorStats()
+	if len(stats) != 2 {
+		t.Fatalf("got %d stats", len(stats))
+	}
+	if s := stats[0]; !s.Disabled || s.Panics != 2 || s.Calls != 2 || !errors.Is(s.LastError, ErrExtractorPanic) {
+		t.Errorf("broken extractor stats = %+v", s)
+	}
+	if s := stats[1]; s.Disabled || s.Calls != 4 || s.LastError != nil {
+		t.Errorf("healthy extractor stats = %+v", s)
+	}
+}
+
+func TestExtractorGuardTimeout(t *testing.T) {
+	var buf bytes.Buffer
+	release := make(chan struct{})
+	defer close(release)
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.ExtractorGuard = &ExtractorGuardConfig{Timeout: 10 * time.Millisecond}
+	cfg.ContextExtractors = []ContextExtractor{
+		func(ctx context.Context) []Field {
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+			return []Field{String("slow", "late")}
+		},
+	}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.InfoCtx(context.Background(), "entry")
+	if got := buf.String(); !strings.Contains(got, "entry") || strings.Contains(got, "slow") {
+		t.Errorf("output = %q", got)
+	}
+	s := logger.ContextExtractorStats()[0]
+	if s.Timeouts != 1 || s.Disabled || !errors.Is(s.LastError, ErrExtractorTimeout) {
+		t.Errorf("stats = %+v", s)
+	}
+}
+
+func TestExtractorGuardCanceledContext(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.ExtractorGuard = &ExtractorGuardConfig{Timeout: time.Minute}
+		cfg.ContextExtractors = []ContextExtractor{
+			func(ctx context.Context) []Field {
+				return []Field{String("request_id", GetRequestID(ctx))}
+			},
+		}
+	})
+
+	ctx, cancel := context.WithCancel(WithRequestID(context.Background(), "req-1"))
+	cancel()
+	logger.InfoCtx(ctx, "request canceled")
+	if got := buf.String(); !strings.Contains(got, "request_id=req-1") {
+		t.Errorf("output = %q", got)
+	}
+	if s := logger.ContextExtractorStats()[0]; s.Timeouts != 0 || s.LastError != nil {
+		t.Errorf("stats = %+v", s)
+	}
+}
+
+func TestExtractorGuardValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ExtractorGuard = &ExtractorGuardConfig{Timeout: -1}
+	if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("err = %v", err)
+	}
+}
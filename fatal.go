@@ -0,0 +1,163 @@
+package dd
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// FatalPolicy determines how Fatal ends the program once the entry is
+// written and the OnFatal hooks have run.
+type FatalPolicy int
+
+const (
+	// FatalPolicyExit closes the logger and calls the FatalHandler, which
+	// defaults to os.Exit(1) (default).
+	FatalPolicyExit FatalPolicy = iota
+
+	// FatalPolicyPanic flushes the writers and panics with a *FatalPanic,
+	// so deferred functions run (closing spans, flushing telemetry).
+	// Recover it with ExitOnFatal deferred at the top of main; an
+	// unrecovered FatalPanic crashes the program like any other panic.
+	FatalPolicyPanic
+)
+
+// String returns the name of the policy.
+func (p FatalPolicy) String() string {
+	switch p {
+	case FatalPolicyExit:
+		return "Exit"
+	case FatalPolicyPanic:
+		return "Panic"
+	default:
+		return "Unknown"
+	}
+}
+
+// FatalPanic is the panic value raised by FatalDefer and FatalPolicyPanic.
+type FatalPanic struct {
+	Message string
+	logger  *Logger
+}
+
+// Error implements error.
+func (p *FatalPanic) Error() string {
+	return "dd: fatal: " + p.Message
+}
+
+// FatalDefer logs a message at FATAL level like Fatal, then panics with a
+// *FatalPanic instead of exiting, whatever the FatalPolicy, so deferred
+// functions run. Pair it with ExitOnFatal in main.
+//
+// Example:
+//
+//	func main() {
+//	    defer dd.ExitOnFatal() // first defer: runs last
+//	    defer tracerProvider.Shutdown(context.Background())
+//	    ...
+//	    logger.FatalDefer("cannot start:", err)
+//	}
+func (l *Logger) FatalDefer(args ...any) { l.fatalDefer(args...) }
+
+// fatalDefer implements FatalDefer. It keeps the caller depth of Log.
+func (l *Logger) fatalDefer(args ...any) {
+	if !l.shouldLog(LevelFatal) {
+		return
+	}
+
+	msg := l.applyMessageSecurity(l.formatter.FormatArgsToString(args...))
+	l.logCore(LevelFatal, logEntry{msg: msg, fatalPanic: true})
+}
+
+// ExitOnFatal recovers a *FatalPanic raised by FatalDefer or
+// FatalPolicyPanic, closes the logger that raised it and calls its
+// FatalHandler (os.Exit(1) by default). Other panics are re-raised.
+// It must be deferred directly:
+//
+//	defer dd.ExitOnFatal()
+func ExitOnFatal() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	fp, ok := r.(*FatalPanic)
+	if !ok {
+		panic(r)
+	}
+	fp.logger.exitFatal()
+}
+
+// handleFatal writes the crash dump and runs the OnFatal hooks, then ends
+// the program according to the FatalPolicy, or by panicking for FatalDefer.
+func (l *Logger) handleFatal(entry *logEntry) {
+	l.writeCrashDump(entry)
+	l.runFatalHooks(entry.msg)
+
+	if entry.fatalPanic || l.fatalPolicy == FatalPolicyPanic {
+		_ = l.Flush()
+		panic(&FatalPanic{Message: entry.msg, logger: l})
+	}
+	l.exitFatal()
+}
+
+// runFatalHooks triggers the OnFatal hooks with a context that expires
+// after the fatal timeout. Hooks still running at the deadline are
+// abandoned so a stuck hook cannot block the exit.
+func (l *Logger) runFatalHooks(msg string) {
+	if l.hooks.Load() == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.fatalTimeoutOrDefault())
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		hookCtx := &HookContext{
+			Event:     HookOnFatal,
+			Level:     LevelFatal,
+			Message:   msg,
+			Timestamp: l.clock.Now(),
+		}
+		_ = l.triggerHooks(ctx, hookCtx)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		l.internalError(ComponentFatal, context.DeadlineExceeded, "OnFatal hooks timed out after %v", l.fatalTimeoutOrDefault())
+	}
+}
+
+// exitFatal closes the logger, waiting at most the fatal timeout, and
+// calls the FatalHandler.
+func (l *Logger) exitFatal() {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = l.Close()
+	}()
+
+	timeout := l.fatalTimeoutOrDefault()
+	select {
+	case <-done:
+		// Close completed successfully
+	case <-time.After(timeout):
+		l.internalError(ComponentFatal, context.DeadlineExceeded, "logger close timed out after %v", timeout)
+	}
+
+	if l.fatalHandler != nil {
+		l.fatalHandler()
+	} else {
+		os.Exit(1)
+	}
+}
+
+// fatalTimeoutOrDefault returns the configured fatal timeout or the default.
+func (l *Logger) fatalTimeoutOrDefault() time.Duration {
+	if l.fatalTimeout > 0 {
+		return l.fatalTimeout
+	}
+	return defaultFatalFlushTimeout
+}
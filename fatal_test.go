@@ -0,0 +1,141 @@
+package dd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFatalRunsOnFatalHooks(t *testing.T) {
+	var order []string
+	var exits atomic.Int32
+	logger, _ := newTestLogger(t, func(cfg *Config) {
+		cfg.FatalHandler = func() { exits.Add(1) }
+		cfg.Hooks = NewHooksFromConfig(HooksConfig{
+			OnFatal: []Hook{func(ctx context.Context, hc *HookContext) error {
+				if _, ok := ctx.Deadline(); !ok {
+					t.Error("OnFatal context should have a deadline")
+				}
+				order = append(order, "hook:"+hc.Message)
+				return nil
+			}},
+			OnClose: []Hook{func(context.Context, *HookContext) error {
+				order = append(order, "close")
+				return nil
+			}},
+		})
+	})
+
+	logger.Fatal("boom")
+
+	if exits.Load() != 1 {
+		t.Errorf("FatalHandler called %d times", exits.Load())
+	}
+	if strings.Join(order, ",") != "hook:boom,close" {
+		t.Errorf("unexpected order %v", order)
+	}
+}
+
+func TestFatalHookTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	var exits atomic.Int32
+	logger, _ := newTestLogger(t, func(cfg *Config) {
+		cfg.FatalHandler = func() { exits.Add(1) }
+		cfg.FatalTimeout = 50 * time.Millisecond
+		cfg.Hooks = NewHooksFromConfig(HooksConfig{
+			OnFatal: []Hook{func(context.Context, *HookContext) error {
+				<-release // ignores its context
+				return nil
+			}},
+		})
+	})
+
+	start := time.Now()
+	logger.Fatal("stuck")
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("stuck hook delayed exit by %v", elapsed)
+	}
+	if exits.Load() != 1 {
+		t.Error("FatalHandler should run after the hook timeout")
+	}
+}
+
+func TestFatalDefer(t *testing.T) {
+	var exits atomic.Int32
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.FatalHandler = func() { exits.Add(1) }
+	})
+
+	var deferRan bool
+	func() {
+		defer ExitOnFatal()
+		defer func() { deferRan = true }()
+		logger.FatalDefer("cannot start:", errors.New("no config"))
+		t.Error("FatalDefer should not return")
+	}()
+
+	if !deferRan {
+		t.Error("deferred functions should run")
+	}
+	if exits.Load() != 1 {
+		t.Errorf("ExitOnFatal should call the FatalHandler once, got %d", exits.Load())
+	}
+	if !logger.IsClosed() {
+		t.Error("ExitOnFatal should close the logger")
+	}
+	if !strings.Contains(buf.String(), "FATAL") || !strings.Contains(buf.String(), "fatal_test.go:") ||
+		!strings.Contains(buf.String(), "cannot start: no config") {
+		t.Errorf("fatal entry missing: %q", buf.String())
+	}
+}
+
+func TestFatalPolicyPanic(t *testing.T) {
+	var exits atomic.Int32
+	logger, _ := newTestLogger(t, func(cfg *Config) {
+		cfg.FatalHandler = func() { exits.Add(1) }
+		cfg.FatalPolicy = FatalPolicyPanic
+	})
+
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		logger.FatalWith("shutdown", String("reason", "test"))
+	}()
+
+	fp, ok := recovered.(*FatalPanic)
+	if !ok || fp.Message != "shutdown" {
+		t.Fatalf("expected *FatalPanic, got %#v", recovered)
+	}
+	if exits.Load() != 0 || logger.IsClosed() {
+		t.Error("FatalPolicyPanic should not exit or close before recovery")
+	}
+}
+
+func TestExitOnFatalRepanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "other" {
+			t.Errorf("expected other panics to propagate, got %v", r)
+		}
+	}()
+	func() {
+		defer ExitOnFatal()
+		panic("other")
+	}()
+}
+
+func TestFatalPolicyValidation(t *testing.T) {
+	for _, configure := range []func(*Config){
+		func(cfg *Config) { cfg.FatalPolicy = FatalPolicy(9) },
+		func(cfg *Config) { cfg.FatalTimeout = -time.Second },
+	} {
+		cfg := DefaultConfig()
+		configure(cfg)
+		if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+			t.Errorf("expected ErrConfigValidation, got %v", err)
+		}
+	}
+}
@@ -3,7 +3,10 @@ package dd
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"unicode"
 
 	"github.com/cybergodev/dd/internal"
@@ -21,10 +24,10 @@ const (
 	// the configured naming convention, but still accepts them.
 	FieldValidationWarn
 
-	// FieldValidationStrict rejects field keys that don't match the configured
-	// naming convention by returning an error from the logging method.
-	// Note: For performance reasons, validation errors are logged rather than
-	// returned from logging methods, as they don't return errors.
+	// FieldValidationStrict reports field keys that don't match the configured
+	// naming convention as errors and applies FieldValidationConfig.StrictAction
+	// to them. Logging methods do not return errors, so the entry is still
+	// written.
 	FieldValidationStrict
 )
 
@@ -52,6 +55,26 @@ const (
 	NamingConventionKebabCase
 )
 
+// InvalidFieldAction is what FieldValidationStrict does with a field whose
+// key fails validation.
+type InvalidFieldAction int
+
+const (
+	// InvalidFieldKeep reports the violation and logs the field unchanged
+	// (default).
+	InvalidFieldKeep InvalidFieldAction = iota
+
+	// InvalidFieldDrop reports the violation and removes the field.
+	InvalidFieldDrop
+
+	// InvalidFieldRename reports the violation and prefixes the key with
+	// RenamePrefix, so it cannot be mistaken for a valid or standard key.
+	InvalidFieldRename
+)
+
+// defaultInvalidFieldPrefix is the FieldValidationConfig.RenamePrefix default.
+const defaultInvalidFieldPrefix = "invalid_"
+
 // String returns the string representation of the validation mode.
 func (m FieldValidationMode) String() string {
 	switch m {
@@ -100,6 +123,33 @@ type FieldValidationConfig struct {
 	// Log4Shell detection, homograph attack detection, and overlong UTF-8 checks.
 	// Default: true when Mode is not FieldValidationNone
 	EnableSecurityValidation bool
+
+	// Pattern, when set, replaces Convention: keys must match it. Anchor
+	// it (^...$) to match whole keys. AllowCommonAbbreviations does not
+	// apply to it.
+	Pattern *regexp.Regexp
+
+	// Validator, when set, is called for keys that pass the other checks;
+	// a non-nil error is a violation.
+	Validator func(key string) error
+
+	// ReservedKeys are rejected with ErrReservedFieldKey, e.g. "timestamp",
+	// "level", "message" and "caller", so fields cannot collide with the
+	// standard keys of the output.
+	ReservedKeys []string
+
+	// OnViolation, when set, receives each violation in place of the
+	// internal diagnostics channel (see Config.InternalErrorHandler). It
+	// is called synchronously from the logging call.
+	OnViolation func(key string, err error)
+
+	// StrictAction is applied to offending fields in FieldValidationStrict
+	// mode (default InvalidFieldKeep).
+	StrictAction InvalidFieldAction
+
+	// RenamePrefix is prepended to offending keys by InvalidFieldRename.
+	// Empty uses "invalid_".
+	RenamePrefix string
 }
 
 // DefaultFieldValidationConfig returns the default field validation configuration
@@ -152,6 +202,29 @@ func (c *FieldValidationConfig) ValidateFieldKey(key string) error {
 		}
 	}
 
+	if slices.Contains(c.ReservedKeys, key) {
+		return fmt.Errorf("%w: %q", ErrReservedFieldKey, key)
+	}
+	if err := c.validateConvention(key); err != nil {
+		return err
+	}
+	if c.Validator != nil {
+		if err := c.Validator(key); err != nil {
+			return fmt.Errorf("field key %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// validateConvention checks key against Pattern or Convention.
+func (c *FieldValidationConfig) validateConvention(key string) error {
+	if c.Pattern != nil {
+		if !c.Pattern.MatchString(key) {
+			return fmt.Errorf("field key %q does not match pattern %s", key, c.Pattern)
+		}
+		return nil
+	}
+
 	// Skip naming convention check if Any convention is specified
 	if c.Convention == NamingConventionAny {
 		return nil
@@ -184,6 +257,68 @@ func (c *FieldValidationConfig) ValidateFieldKey(key string) error {
 	return nil
 }
 
+// strictAction returns the action for offending fields in the config's mode.
+func (c *FieldValidationConfig) strictAction() InvalidFieldAction {
+	if c.Mode != FieldValidationStrict {
+		return InvalidFieldKeep
+	}
+	return c.StrictAction
+}
+
+// renamePrefix returns RenamePrefix or its default.
+func (c *FieldValidationConfig) renamePrefix() string {
+	if c.RenamePrefix == "" {
+		return defaultInvalidFieldPrefix
+	}
+	return c.RenamePrefix
+}
+
+// FieldValidationStats reports the field keys that failed validation.
+type FieldValidationStats struct {
+	Violations int64 // Fields whose key failed validation
+	Dropped    int64 // Fields removed by InvalidFieldDrop
+	Renamed    int64 // Fields renamed by InvalidFieldRename
+}
+
+// fieldValidationCounters holds the counts behind FieldValidationStats.
+type fieldValidationCounters struct {
+	violations atomic.Int64
+	dropped    atomic.Int64
+	renamed    atomic.Int64
+}
+
+// FieldValidationStats returns the field validation violations since the
+// logger was created.
+func (l *Logger) FieldValidationStats() FieldValidationStats {
+	if l == nil {
+		return FieldValidationStats{}
+	}
+	return FieldValidationStats{
+		Violations: l.fieldStats.violations.Load(),
+		Dropped:    l.fieldStats.dropped.Load(),
+		Renamed:    l.fieldStats.renamed.Load(),
+	}
+}
+
+// reportFieldViolation passes a violation to OnViolation or the internal
+// diagnostics channel.
+func (l *Logger) reportFieldViolation(fv *FieldValidationConfig, key string, err error) {
+	if fv.OnViolation == nil {
+		kind := "warning"
+		if fv.Mode == FieldValidationStrict {
+			kind = "error"
+		}
+		l.internalError(ComponentFieldValidation, err, "field validation %s: %v", kind, err)
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			l.internalError(ComponentFieldValidation, err, "OnViolation callback panic for field %q: %v", key, r)
+		}
+	}()
+	fv.OnViolation(key, err)
+}
+
 // commonSuffixes contains suffixes that indicate a common abbreviation pattern.
 // Pre-computed to avoid allocation on every call to isCommonAbbreviation.
 var commonSuffixes = []string{"_id", "_url", "_uri", "_ip", "_api"}
@@ -0,0 +1,90 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestFieldValidationCustomRules(t *testing.T) {
+	fv := &FieldValidationConfig{
+		Mode:         FieldValidationWarn,
+		Pattern:      regexp.MustCompile(`^[a-z]+(\.[a-z]+)*$`),
+		ReservedKeys: []string{"level", "timestamp"},
+		Validator: func(key string) error {
+			if len(key) > 12 {
+				return errors.New("too long")
+			}
+			return nil
+		},
+	}
+	tests := []struct {
+		key     string
+		wantErr bool
+	}{
+		{"http.method", false},
+		{"user_id", true},
+		{"level", true},
+		{"verylongkeyname", true},
+	}
+	for _, tt := range tests {
+		if err := fv.ValidateFieldKey(tt.key); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateFieldKey(%q) = %v", tt.key, err)
+		}
+	}
+	if err := fv.ValidateFieldKey("timestamp"); !errors.Is(err, ErrReservedFieldKey) {
+		t.Errorf("reserved: err = %v", err)
+	}
+}
+
+func TestFieldValidationStrictActions(t *testing.T) {
+	for _, tt := range []struct {
+		action InvalidFieldAction
+		want   string
+		reject string
+	}{
+		{InvalidFieldKeep, "level=x", ""},
+		{InvalidFieldDrop, "", "level=x"},
+		{InvalidFieldRename, "invalid_level=x", ""},
+	} {
+		var buf bytes.Buffer
+		var violations []string
+		cfg := DefaultConfig()
+		cfg.Output = &buf
+		cfg.FieldValidation = &FieldValidationConfig{
+			Mode:         FieldValidationStrict,
+			ReservedKeys: []string{"level"},
+			StrictAction: tt.action,
+			OnViolation:  func(key string, err error) { violations = append(violations, key) },
+		}
+		logger, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		logger.InfoWith("entry", String("level", "x"), String("ok", "y"))
+		logger.Close()
+
+		out := buf.String()
+		if !strings.Contains(out, "ok=y") || (tt.want != "" && !strings.Contains(out, tt.want)) ||
+			(tt.reject != "" && strings.Contains(out, tt.reject)) {
+			t.Errorf("action %d: output = %q", tt.action, out)
+		}
+		if len(violations) != 1 || violations[0] != "level" {
+			t.Errorf("action %d: violations = %v", tt.action, violations)
+		}
+
+		stats := logger.FieldValidationStats()
+		want := FieldValidationStats{Violations: 1}
+		switch tt.action {
+		case InvalidFieldDrop:
+			want.Dropped = 1
+		case InvalidFieldRename:
+			want.Renamed = 1
+		}
+		if stats != want {
+			t.Errorf("action %d: stats = %+v, want %+v", tt.action, stats, want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package dd
+
+import (
+	"fmt"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// FieldConflictPolicy determines what happens when an entry ends up with
+// several fields with the same key, for example from WithFields, context
+// extractors, hooks and the call site. It is applied after hooks run and
+// before the entry is formatted, so text output, RecordWriters and
+// templates all see a single value per key.
+type FieldConflictPolicy int
+
+const (
+	// FieldConflictLastWins keeps the last field for each key (default).
+	// This matches WithFields, where later fields override earlier ones.
+	FieldConflictLastWins FieldConflictPolicy = iota
+
+	// FieldConflictFirstWins keeps the first field for each key.
+	FieldConflictFirstWins
+
+	// FieldConflictError keeps the last field like FieldConflictLastWins and
+	// reports each duplicate key on stderr, to help find the call sites.
+	FieldConflictError
+)
+
+// String returns the name of the policy.
+func (p FieldConflictPolicy) String() string {
+	switch p {
+	case FieldConflictLastWins:
+		return "LastWins"
+	case FieldConflictFirstWins:
+		return "FirstWins"
+	case FieldConflictError:
+		return "Error"
+	default:
+		return "Unknown"
+	}
+}
+
+// isValid reports whether p is a known policy.
+func (p FieldConflictPolicy) isValid() bool {
+	return p >= FieldConflictLastWins && p <= FieldConflictError
+}
+
+// SetFieldConflictPolicy sets how duplicate field keys are resolved (thread-safe).
+func (l *Logger) SetFieldConflictPolicy(policy FieldConflictPolicy) error {
+	if l == nil {
+		return ErrNilLogger
+	}
+	if !policy.isValid() {
+		return fmt.Errorf("%w: unknown FieldConflictPolicy %d", ErrConfigValidation, policy)
+	}
+	l.fieldConflicts.Store(int32(policy))
+	return nil
+}
+
+// GetFieldConflictPolicy returns the current field conflict policy.
+func (l *Logger) GetFieldConflictPolicy() FieldConflictPolicy {
+	if l == nil {
+		return 0
+	}
+	return FieldConflictPolicy(l.fieldConflicts.Load())
+}
+
+// resolveFieldConflicts applies the field conflict policy to fields.
+func (l *Logger) resolveFieldConflicts(fields []Field) []Field {
+	switch FieldConflictPolicy(l.fieldConflicts.Load()) {
+	case FieldConflictFirstWins:
+		return internal.DedupFields(fields, true, nil)
+	case FieldConflictError:
+		return internal.DedupFields(fields, false, l.reportDuplicateField)
+	default:
+		return internal.DedupFields(fields, false, nil)
+	}
+}
+
+func (l *Logger) reportDuplicateField(key string) {
+	l.internalError(ComponentFieldConflict, nil, "duplicate field key %q", key)
+}
@@ -0,0 +1,109 @@
+package dd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFieldConflictLastWinsByDefault(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+	ctx := ContextWithFields(context.Background(), String("user", "from-ctx"))
+
+	logger.WithFields(String("user", "a"), String("user", "b")).
+		InfoCtx(ctx, "msg", String("user", "call"), String("user", "final"))
+
+	if n := strings.Count(buf.String(), "user="); n != 1 || !strings.Contains(buf.String(), "user=final") {
+		t.Errorf("expected a single user=final field: %q", buf.String())
+	}
+}
+
+func TestFieldConflictFirstWins(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Format = FormatJSON
+	cfg.Output = &buf
+	cfg.FieldConflicts = FieldConflictFirstWins
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.InfoWith("msg", String("k", "first"), String("k", "second"))
+
+	if !strings.Contains(buf.String(), `"k":"first"`) || strings.Contains(buf.String(), "second") {
+		t.Errorf("expected only the first value: %s", buf.String())
+	}
+}
+
+func TestFieldConflictHookAddedDuplicate(t *testing.T) {
+	var buf bytes.Buffer
+	hooks := NewHookRegistry()
+	hooks.AddResultHook(HookBeforeLog, func(_ context.Context, hc *HookContext) (HookResult, error) {
+		hc.Fields = append(hc.Fields, String("k", "hook"))
+		return HookModify, nil
+	})
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Hooks = hooks
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.InfoWith("msg", String("k", "call"))
+
+	if !strings.Contains(buf.String(), "k=hook") || strings.Count(buf.String(), "k=") != 1 {
+		t.Errorf("duplicates added by hooks should be resolved: %q", buf.String())
+	}
+}
+
+func TestFieldConflictRecordWriter(t *testing.T) {
+	rw := &recordingWriter{}
+	cfg := DefaultConfig()
+	cfg.Output = rw
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.WithField("k", 1).InfoWith("msg", Int("n", 1), Int("n", 2))
+
+	if len(rw.records) != 1 || len(rw.records[0].Fields) != 2 {
+		t.Fatalf("expected one record with 2 fields, got %+v", rw.records)
+	}
+}
+
+func TestFieldConflictPolicyValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FieldConflicts = FieldConflictPolicy(99)
+	if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("expected ErrConfigValidation, got %v", err)
+	}
+
+	logger, _ := newTestLogger(t, nil)
+	if err := logger.SetFieldConflictPolicy(FieldConflictPolicy(-1)); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("expected ErrConfigValidation, got %v", err)
+	}
+	if err := logger.SetFieldConflictPolicy(FieldConflictError); err != nil {
+		t.Fatal(err)
+	}
+	if got := logger.GetFieldConflictPolicy(); got != FieldConflictError {
+		t.Errorf("GetFieldConflictPolicy = %v", got)
+	}
+}
+
+func TestWithFieldsDeduplicates(t *testing.T) {
+	logger, _ := newTestLogger(t, nil)
+
+	entry := logger.WithFields(String("a", "1"), String("a", "2")).WithFields(String("b", "3"), String("b", "4"))
+
+	if len(entry.fields) != 2 || entry.fields[0].Value != "2" || entry.fields[1].Value != "4" {
+		t.Errorf("entry fields should be deduplicated, got %v", entry.fields)
+	}
+}
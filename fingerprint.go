@@ -0,0 +1,120 @@
+package dd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// FingerprintKey is the field holding the grouping fingerprint of ERROR
+// and FATAL entries when Config.Fingerprint is set.
+const FingerprintKey = "fingerprint"
+
+// FingerprintInput is what a fingerprint is computed from.
+type FingerprintInput struct {
+	Level   LogLevel
+	Message string  // the message as logged
+	Fields  []Field // the entry's fields; do not modify
+
+	// Template is the message with its variable parts (numbers, IDs,
+	// quoted strings) replaced by "?". LogT entries use their template.
+	Template string
+	// ErrorType is the type of the innermost error of the first error
+	// field, e.g. "*fs.PathError", or "" if there is none.
+	ErrorType string
+	// Function is the fully qualified name of the function that logged
+	// the entry.
+	Function string
+}
+
+// FingerprintFunc computes the fingerprint of an entry. An empty result
+// leaves the entry without a fingerprint.
+type FingerprintFunc func(in FingerprintInput) string
+
+// DefaultFingerprint hashes the message template, error type and logging
+// function, so entries that differ only in IDs share a fingerprint. It
+// returns 16 hex characters.
+func DefaultFingerprint(in FingerprintInput) string {
+	h := sha256.New()
+	h.Write([]byte(in.Template))
+	h.Write([]byte{0})
+	h.Write([]byte(in.ErrorType))
+	h.Write([]byte{0})
+	h.Write([]byte(in.Function))
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// addFingerprint adds a FingerprintKey field to ERROR and FATAL entries
+// when fingerprints are enabled.
+func (l *Logger) addFingerprint(level LogLevel, entry *logEntry) {
+	if l.fingerprint == nil || level < LevelError {
+		return
+	}
+
+	template := entry.msg
+	if entry.format != "" {
+		template = entry.format
+	}
+	in := FingerprintInput{
+		Level:     level,
+		Message:   entry.msg,
+		Fields:    entry.fields,
+		Template:  internal.NormalizeMessage(template),
+		ErrorType: entry.errType,
+		Function:  internal.UserFunction(),
+	}
+	if in.ErrorType == "" {
+		in.ErrorType = fieldsErrorType(entry.fields)
+	}
+	if fp := l.computeFingerprint(in); fp != "" {
+		entry.fields = append(entry.fields[:len(entry.fields):len(entry.fields)], Field{Key: FingerprintKey, Value: fp})
+	}
+}
+
+// computeFingerprint calls the fingerprint function, recovering from a
+// panic in a custom one.
+func (l *Logger) computeFingerprint(in FingerprintInput) (fp string) {
+	defer func() {
+		if r := recover(); r != nil {
+			l.internalError(ComponentEncoder, nil, "FingerprintFunc panic: %v", r)
+			fp = ""
+		}
+	}()
+	return l.fingerprint(in)
+}
+
+// fieldsErrorType returns the type of the first error-valued field (see
+// innermostErrorType). Fields built with Err hold the message only.
+func fieldsErrorType(fields []Field) string {
+	for _, field := range fields {
+		if err, ok := field.Value.(error); ok && err != nil {
+			return innermostErrorType(err)
+		}
+	}
+	return ""
+}
+
+// argsErrorType returns the type of the first error in args.
+func argsErrorType(args []any) string {
+	for _, arg := range args {
+		if err, ok := arg.(error); ok && err != nil {
+			return innermostErrorType(err)
+		}
+	}
+	return ""
+}
+
+// innermostErrorType returns the type name of the error at the end of
+// err's Unwrap chain, e.g. "*fs.PathError".
+func innermostErrorType(err error) string {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return fmt.Sprintf("%T", err)
+		}
+		err = next
+	}
+}
@@ -0,0 +1,90 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func fingerprints(t *testing.T, buf *bytes.Buffer) []string {
+	t.Helper()
+	var fps []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry struct {
+			Fields map[string]any `json:"fields"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		fp, _ := entry.Fields[FingerprintKey].(string)
+		fps = append(fps, fp)
+	}
+	buf.Reset()
+	return fps
+}
+
+func TestFingerprint(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := JSONConfig()
+	cfg.Output = &buf
+	cfg.Fingerprint = true
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	for _, id := range []string{"42", "7f3c9a2e-1b4d"} {
+		logger.ErrorWith("order "+id+" failed", String("order", id))
+	}
+	logger.ErrorWith("payment declined")
+	logger.WarnWith("order 1 failed")
+	fps := fingerprints(t, &buf)
+	if len(fps[0]) != 16 || fps[0] != fps[1] {
+		t.Errorf("messages differing in IDs should share a fingerprint: %v", fps)
+	}
+	if fps[2] == "" || fps[2] == fps[0] {
+		t.Errorf("different messages should differ: %v", fps)
+	}
+	if fps[3] != "" {
+		t.Errorf("WARN entries should not get a fingerprint: %v", fps)
+	}
+
+	// Errorf uses its format string; error arguments add their type
+	logger.Errorf("open %s: %v", "a.txt", os.ErrNotExist)
+	logger.Errorf("open %s: %v", "b.txt", os.ErrNotExist)
+	logger.Error("open failed", fmt.Errorf("wrapped: %w", &os.PathError{Op: "open", Path: "x", Err: os.ErrNotExist}))
+	logger.Error("open failed", fmt.Errorf("wrapped: %w", os.ErrPermission))
+	fps = fingerprints(t, &buf)
+	if fps[0] != fps[1] {
+		t.Errorf("Errorf entries with the same format should match: %v", fps)
+	}
+	if fps[2] == fps[3] {
+		t.Errorf("different error types should differ: %v", fps)
+	}
+}
+
+func TestFingerprintFunc(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := JSONConfig()
+	cfg.Output = &buf
+	var got FingerprintInput
+	cfg.FingerprintFunc = func(in FingerprintInput) string {
+		got = in
+		return "custom"
+	}
+	logger, _ := New(cfg)
+	defer logger.Close()
+
+	logger.ErrorWith(`user "bob" has 3 items`, Any("err", &os.PathError{Err: os.ErrClosed}))
+	if fps := fingerprints(t, &buf); fps[0] != "custom" {
+		t.Errorf("fingerprint = %v", fps)
+	}
+	if got.Template != `user "?" has ? items` || got.ErrorType != "*errors.errorString" ||
+		!strings.HasSuffix(got.Function, "TestFingerprintFunc") || got.Level != LevelError {
+		t.Errorf("unexpected input: %+v", got)
+	}
+}
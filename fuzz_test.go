@@ -1,8 +1,11 @@
 package dd
 
 import (
+	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/cybergodev/dd/internal"
 )
@@ -177,3 +180,37 @@ func FuzzFilterFieldValue(f *testing.F) {
 		_ = result
 	})
 }
+
+// FuzzStrictJSON tests that JSONOptions.Strict writes valid JSON in valid
+// UTF-8 whatever the message, keys and values hold.
+func FuzzStrictJSON(f *testing.F) {
+	for _, s := range internal.EscapingCorpus() {
+		f.Add(s, s)
+	}
+
+	var buf bytes.Buffer
+	cfg := JSONConfig()
+	cfg.Output = &buf
+	cfg.JSON.Strict = true
+	logger, err := New(cfg)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Cleanup(func() { logger.Close() })
+
+	f.Fuzz(func(t *testing.T, text, key string) {
+		buf.Reset()
+		logger.InfoWith(text,
+			String(key, text),
+			Any("list", []string{key, text}),
+			Any("map", map[string]string{key: text}),
+		)
+		line := strings.TrimSuffix(buf.String(), "\n")
+		if strings.Contains(line, "\n") {
+			t.Fatalf("entry spans lines: %q", line)
+		}
+		if !json.Valid([]byte(line)) || !utf8.ValidString(line) {
+			t.Fatalf("invalid JSON entry: %q", line)
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package dd
+
+import (
+	"sync"
+	"time"
+)
+
+// gateSweepSize is the number of Every and EveryN keys above which a logger
+// drops expired ones before adding another one.
+const gateSweepSize = 4096
+
+// gateSweepInterval is the minimum time between two sweeps, so a logger
+// with many live keys does not scan them all for every new key.
+const gateSweepInterval = time.Minute
+
+// gateIdleTTL is how long an EveryN key may go unused before a sweep drops
+// it, restarting its count.
+const gateIdleTTL = 10 * time.Minute
+
+// nopGate is returned by Once, EveryN and Every for suppressed calls.
+var nopGate = Nop().nopEntry
+
+// logGates holds the per-key state of Once, EveryN and Every. Once keys
+// never expire, so they are kept apart from the keys a sweep may drop.
+type logGates struct {
+	mu        sync.Mutex
+	once      map[string]struct{}
+	keys      map[string]*gateState
+	lastSweep time.Time
+}
+
+type gateState struct {
+	count    uint64    // calls seen (EveryN)
+	next     time.Time // earliest next entry (Every)
+	lastUsed time.Time
+}
+
+// Once returns an entry that logs the first time Once is called with key
+// and a no-op entry on every later call, so a warning appears once for the
+// life of the logger. The call is counted whether or not the level of the
+// log method is enabled.
+//
+// Example:
+//
+//	logger.Once("legacy-api").Warn("the v1 API is deprecated")
+func (l *Logger) Once(key string) *LoggerEntry {
+	if l == nil {
+		return nopGate
+	}
+	l.gates.mu.Lock()
+	_, seen := l.gates.once[key]
+	if !seen {
+		if l.gates.once == nil {
+			l.gates.once = make(map[string]struct{})
+		}
+		l.gates.once[key] = struct{}{}
+	}
+	l.gates.mu.Unlock()
+	return l.gateEntry(!seen)
+}
+
+// EveryN returns an entry that logs on the first call with key and then on
+// every nth call; other calls get a no-op entry. n below 1 is treated
+// as 1. Keys unused for ten minutes may be forgotten, restarting the count.
+//
+// Example:
+//
+//	logger.EveryN("cache-miss", 1000).InfoWith("cache miss", dd.String("key", k))
+func (l *Logger) EveryN(key string, n int) *LoggerEntry {
+	if l == nil {
+		return nopGate
+	}
+	every := uint64(max(n, 1))
+	allowed := l.gate(key, func(s *gateState, _ time.Time) bool {
+		s.count++
+		return (s.count-1)%every == 0
+	})
+	return l.gateEntry(allowed)
+}
+
+// Every returns an entry that logs at most once per interval d for key;
+// calls within the interval get a no-op entry.
+//
+// Example:
+//
+//	logger.Every("queue-full", time.Minute).Warn("queue full, dropping jobs")
+func (l *Logger) Every(key string, d time.Duration) *LoggerEntry {
+	if l == nil {
+		return nopGate
+	}
+	allowed := l.gate(key, func(s *gateState, now time.Time) bool {
+		if now.Before(s.next) {
+			return false
+		}
+		s.next = now.Add(d)
+		return true
+	})
+	return l.gateEntry(allowed)
+}
+
+// gate runs allow on the state of key under the gate lock.
+func (l *Logger) gate(key string, allow func(s *gateState, now time.Time) bool) bool {
+	now := l.clock.Now()
+
+	l.gates.mu.Lock()
+	defer l.gates.mu.Unlock()
+
+	s := l.gates.keys[key]
+	if s == nil {
+		if l.gates.keys == nil {
+			l.gates.keys = make(map[string]*gateState)
+		} else if len(l.gates.keys) >= gateSweepSize && now.Sub(l.gates.lastSweep) >= gateSweepInterval {
+			l.gates.sweep(now)
+		}
+		s = &gateState{}
+		l.gates.keys[key] = s
+	}
+	s.lastUsed = now
+	return allow(s, now)
+}
+
+// sweep drops keys whose state no longer affects the next call: Every keys
+// past their interval and EveryN keys idle for gateIdleTTL.
+func (g *logGates) sweep(now time.Time) {
+	g.lastSweep = now
+	for key, s := range g.keys {
+		switch {
+		case !s.next.IsZero():
+			if !now.Before(s.next) {
+				delete(g.keys, key)
+			}
+		case now.Sub(s.lastUsed) >= gateIdleTTL:
+			delete(g.keys, key)
+		}
+	}
+}
+
+func (l *Logger) gateEntry(allowed bool) *LoggerEntry {
+	if !allowed {
+		return nopGate
+	}
+	return &LoggerEntry{logger: l}
+}
@@ -0,0 +1,113 @@
+package dd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOnce(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := New(&Config{Level: LevelInfo, Format: FormatText, Output: &buf})
+	defer logger.Close()
+
+	for i := 0; i < 3; i++ {
+		logger.Once("legacy").Warn("deprecated API used")
+		logger.Once("other").WarnWith("other warning", Int("i", i))
+	}
+	out := buf.String()
+	if strings.Count(out, "deprecated API used") != 1 || strings.Count(out, "other warning") != 1 {
+		t.Errorf("each key should log once: %q", out)
+	}
+	if !strings.Contains(out, "i=0") {
+		t.Errorf("the first call should log: %q", out)
+	}
+}
+
+func TestEveryN(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := New(&Config{Level: LevelInfo, Format: FormatText, Output: &buf})
+	defer logger.Close()
+
+	for i := 0; i < 10; i++ {
+		logger.EveryN("miss", 4).Infof("cache miss %d", i)
+	}
+	out := buf.String()
+	for _, want := range []string{"cache miss 0", "cache miss 4", "cache miss 8"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q: %q", want, out)
+		}
+	}
+	if strings.Count(out, "\n") != 3 {
+		t.Errorf("expected 3 entries, got %q", out)
+	}
+
+	buf.Reset()
+	logger.EveryN("zero", 0).Info("a")
+	logger.EveryN("zero", 0).Info("b")
+	if strings.Count(buf.String(), "\n") != 2 {
+		t.Errorf("n below 1 should log every call: %q", buf.String())
+	}
+}
+
+func TestEvery(t *testing.T) {
+	var buf bytes.Buffer
+	clock := NewManualClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	logger, _ := New(&Config{Level: LevelInfo, Format: FormatText, Output: &buf, Clock: clock})
+	defer logger.Close()
+
+	logger.Every("full", time.Minute).Warn("queue full 1")
+	clock.Advance(30 * time.Second)
+	logger.Every("full", time.Minute).Warn("queue full 2")
+	clock.Advance(30 * time.Second)
+	logger.Every("full", time.Minute).Warn("queue full 3")
+
+	out := buf.String()
+	if !strings.Contains(out, "queue full 1") || strings.Contains(out, "queue full 2") || !strings.Contains(out, "queue full 3") {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestGateSweep(t *testing.T) {
+	var buf bytes.Buffer
+	clock := NewManualClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	logger, _ := New(&Config{Level: LevelInfo, Format: FormatText, Output: &buf, Clock: clock})
+	defer logger.Close()
+
+	logger.Once("once").Warn("first")
+	for i := 0; i < gateSweepSize; i++ {
+		logger.Every(fmt.Sprint("every-", i), time.Second)
+	}
+	clock.Advance(time.Second)
+	logger.EveryN("new", 10)
+
+	countKeys := func() int {
+		logger.gates.mu.Lock()
+		defer logger.gates.mu.Unlock()
+		return len(logger.gates.keys)
+	}
+	if n := countKeys(); n != 1 {
+		t.Errorf("sweep should drop expired keys and add the new one, have %d keys", n)
+	}
+	logger.Once("once").Warn("again")
+	if strings.Contains(buf.String(), "again") {
+		t.Errorf("Once keys should survive a sweep: %q", buf.String())
+	}
+
+	// Within gateSweepInterval of the last sweep, new keys skip the scan
+	for i := 0; i < gateSweepSize; i++ {
+		logger.Every(fmt.Sprint("every-", i), time.Second)
+	}
+	clock.Advance(time.Second)
+	logger.EveryN("newer", 10)
+	if n := countKeys(); n != gateSweepSize+2 {
+		t.Errorf("sweep should wait for gateSweepInterval, have %d keys", n)
+	}
+	clock.Advance(gateSweepInterval)
+	logger.EveryN("newest", 10)
+	if n := countKeys(); n != 3 {
+		t.Errorf("sweep should run after gateSweepInterval, have %d keys", n)
+	}
+}
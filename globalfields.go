@@ -0,0 +1,44 @@
+package dd
+
+import (
+	"os"
+)
+
+// Environment variables read by MetadataFields for Kubernetes metadata,
+// usually set through the downward API.
+const (
+	envPodName      = "POD_NAME"
+	envPodNamespace = "POD_NAMESPACE"
+	envNodeName     = "NODE_NAME"
+)
+
+// MetadataFields returns host and process fields for Config.GlobalFields:
+// hostname and pid, plus service, version and env when non-empty, and
+// k8s.pod, k8s.namespace and k8s.node from the POD_NAME, POD_NAMESPACE and
+// NODE_NAME environment variables when set. Call it once at startup.
+//
+// Example:
+//
+//	cfg := dd.DefaultConfig()
+//	cfg.GlobalFields = dd.MetadataFields("checkout", "1.4.2", "production")
+func MetadataFields(service, version, environment string) []Field {
+	fields := make([]Field, 0, 8)
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		fields = append(fields, String("hostname", hostname))
+	}
+	fields = append(fields, Int("pid", os.Getpid()))
+
+	for _, f := range []Field{
+		String("service", service),
+		String("version", version),
+		String("env", environment),
+		String("k8s.pod", os.Getenv(envPodName)),
+		String("k8s.namespace", os.Getenv(envPodNamespace)),
+		String("k8s.node", os.Getenv(envNodeName)),
+	} {
+		if f.Value != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,127 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGlobalFieldsText(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.GlobalFields = []Field{String("service", "api"), String("env", "prod")}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Info("plain")
+	logger.InfoWith("override", String("env", "staging"), Int("n", 1))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", buf.String())
+	}
+	if !strings.HasSuffix(lines[0], "plain service=api env=prod") {
+		t.Errorf("global fields missing: %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "service=api") || !strings.Contains(lines[1], "env=staging") || strings.Contains(lines[1], "env=prod") {
+		t.Errorf("entry fields should override global fields: %q", lines[1])
+	}
+}
+
+func TestGlobalFieldsJSON(t *testing.T) {
+	for _, order := range []JSONFieldOrder{JSONOrderAny, JSONOrderInsertion} {
+		var buf bytes.Buffer
+		cfg := JSONConfig()
+		cfg.Output = &buf
+		cfg.JSON.FieldOrder = order
+		cfg.GlobalFields = []Field{String("service", "api"), String("env", "prod")}
+		logger, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		logger.InfoWith("msg", String("env", "staging"))
+		logger.Close()
+
+		var entry struct {
+			Fields map[string]any `json:"fields"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+		}
+		if entry.Fields["service"] != "api" || entry.Fields["env"] != "staging" {
+			t.Errorf("order %v: unexpected fields %v", order, entry.Fields)
+		}
+	}
+}
+
+func TestGlobalFieldsValidationAndClone(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.GlobalFields = []Field{{Key: "", Value: 1}}
+	if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("expected ErrConfigValidation, got %v", err)
+	}
+
+	cfg.GlobalFields = []Field{String("a", "1")}
+	clone := cfg.Clone()
+	clone.GlobalFields[0] = String("b", "2")
+	if cfg.GlobalFields[0].Key != "a" {
+		t.Error("Clone should copy GlobalFields")
+	}
+}
+
+func TestMetadataFields(t *testing.T) {
+	t.Setenv(envPodName, "api-7d9f")
+	t.Setenv(envPodNamespace, "")
+
+	fields := MetadataFields("checkout", "", "production")
+	got := make(map[string]any, len(fields))
+	for _, f := range fields {
+		got[f.Key] = f.Value
+	}
+
+	if got["pid"] != os.Getpid() || got["service"] != "checkout" || got["env"] != "production" || got["k8s.pod"] != "api-7d9f" {
+		t.Errorf("unexpected fields %v", got)
+	}
+	if _, ok := got["version"]; ok {
+		t.Error("empty values should be omitted")
+	}
+	if _, ok := got["k8s.namespace"]; ok {
+		t.Error("unset environment variables should be omitted")
+	}
+}
+
+func TestGlobalFieldsNoExtraAllocations(t *testing.T) {
+	newLogger := func(global []Field) *Logger {
+		logger, _ := newTestLogger(t, func(cfg *Config) {
+			cfg.Output = io.Discard
+			cfg.Security = nil
+			cfg.GlobalFields = global
+		})
+		return logger
+	}
+	plain := newLogger(nil)
+	global := newLogger([]Field{String("service", "api"), Int("pid", 1)})
+
+	// The fewest of a few runs: sync.Pool drops items at random under the
+	// race detector.
+	allocs := func(logger *Logger) float64 {
+		fewest := testing.AllocsPerRun(100, func() { logger.InfoWith("msg", String("k", "v")) })
+		for i := 0; i < 4; i++ {
+			fewest = min(fewest, testing.AllocsPerRun(100, func() { logger.InfoWith("msg", String("k", "v")) }))
+		}
+		return fewest
+	}
+	base, with := allocs(plain), allocs(global)
+	if with > base {
+		t.Errorf("global fields should not allocate per call: %v > %v", with, base)
+	}
+}
@@ -0,0 +1,96 @@
+package dd
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// GoroutineIDKey is the field key of the goroutine ID added to entries when
+// Config.IncludeGoroutineID is set.
+const GoroutineIDKey = "goroutine"
+
+// goroutineFields maps goroutine IDs to the fields set with
+// GoroutineFields; goroutineFieldCount lets loggers skip the lookup, and
+// its cost, while no goroutine has any.
+var (
+	goroutineFields     sync.Map // map[uint64][]Field
+	goroutineFieldCount atomic.Int64
+)
+
+// GoroutineFields adds fields to every entry logged from the calling
+// goroutine, by any logger, until the returned function is called. Calls
+// nest: inner fields override outer ones with the same key, and each
+// returned function restores the fields that were set before its call.
+// Explicit, entry and context fields override goroutine fields.
+//
+// This suits worker pools, where threading a context or LoggerEntry into
+// every function is impractical. Fields are stored per goroutine ID: they
+// are not inherited by goroutines started later, and the returned function
+// must be called, typically deferred, or the fields stay in memory for the
+// life of the process. Where a context is available, ContextWithFields
+// carries fields across goroutines instead.
+//
+// Cost: while any goroutine has fields, every entry looks up the goroutine
+// ID (about 1µs) whether or not its goroutine has fields. Nothing is paid
+// when GoroutineFields is not in use.
+//
+// Example:
+//
+//	for i := range workers {
+//	    go func() {
+//	        defer dd.GoroutineFields(dd.Int("worker", i))()
+//	        for job := range jobs {
+//	            process(job) // entries carry worker=<i>
+//	        }
+//	    }()
+//	}
+func GoroutineFields(fields ...Field) func() {
+	id := internal.GoroutineID()
+	prev, hadPrev := goroutineFields.Load(id)
+
+	var outer []Field
+	if hadPrev {
+		outer = prev.([]Field)
+	} else {
+		goroutineFieldCount.Add(1)
+	}
+	goroutineFields.Store(id, mergeFieldSlices(outer, append([]Field(nil), fields...)))
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if hadPrev {
+				goroutineFields.Store(id, prev)
+				return
+			}
+			goroutineFields.Delete(id)
+			goroutineFieldCount.Add(-1)
+		})
+	}
+}
+
+// addGoroutineFields puts the goroutine ID, if configured, and the fields
+// of GoroutineFields in front of the entry's fields. Entries passed on by a
+// Tee already carry the goroutine fields.
+func (l *Logger) addGoroutineFields(entry *logEntry) {
+	withFields := entry.tee == nil && goroutineFieldCount.Load() > 0
+	if !l.goroutineID && !withFields {
+		return
+	}
+
+	id := internal.GoroutineID()
+	var extra []Field
+	if l.goroutineID {
+		extra = append(extra, Uint64(GoroutineIDKey, id))
+	}
+	if withFields {
+		if fields, ok := goroutineFields.Load(id); ok {
+			extra = append(extra, l.processFields(fields.([]Field))...)
+		}
+	}
+	if len(extra) > 0 {
+		entry.fields = mergeFieldSlices(extra, entry.fields)
+	}
+}
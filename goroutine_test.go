@@ -0,0 +1,95 @@
+package dd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+func TestIncludeGoroutineID(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) { cfg.IncludeGoroutineID = true })
+	logger.Info("hello")
+
+	want := fmt.Sprintf("goroutine=%d", internal.GoroutineID())
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("expected %q in %q", want, buf.String())
+	}
+}
+
+func TestGoroutineIDOffByDefault(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+	logger.Info("hello")
+
+	if strings.Contains(buf.String(), "goroutine=") {
+		t.Errorf("goroutine ID should be off by default: %q", buf.String())
+	}
+}
+
+func TestGoroutineFields(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	release := GoroutineFields(Int("worker", 3), String("pool", "io"))
+	logger.InfoWith("job", String("pool", "cpu"))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		logger.Info("elsewhere")
+	}()
+	wg.Wait()
+
+	release()
+	release() // second call is a no-op
+	logger.Info("released")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], "job worker=3 pool=cpu") {
+		t.Errorf("goroutine fields should come first and yield to explicit fields: %q", lines[0])
+	}
+	if strings.Contains(lines[1], "worker=") {
+		t.Errorf("other goroutines should not get the fields: %q", lines[1])
+	}
+	if strings.Contains(lines[2], "worker=") {
+		t.Errorf("fields should be gone after release: %q", lines[2])
+	}
+	if n := goroutineFieldCount.Load(); n != 0 {
+		t.Errorf("registry should be empty, count is %d", n)
+	}
+}
+
+func TestGoroutineFieldsNest(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	releaseOuter := GoroutineFields(Int("worker", 1), String("stage", "fetch"))
+	releaseInner := GoroutineFields(String("stage", "parse"))
+	logger.Info("inner")
+	releaseInner()
+	logger.Info("outer")
+	releaseOuter()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.Contains(lines[0], "worker=1 stage=parse") {
+		t.Errorf("inner fields should override outer ones: %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "worker=1 stage=fetch") {
+		t.Errorf("releasing the inner fields should restore the outer ones: %q", lines[1])
+	}
+}
+
+func TestGoroutineFieldsFiltered(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	defer GoroutineFields(String("password", "hunter2"))()
+	logger.Info("login")
+
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Errorf("goroutine fields should be filtered: %q", buf.String())
+	}
+}
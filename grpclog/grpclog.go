@@ -0,0 +1,288 @@
+// Package grpclog provides gRPC server interceptors and a grpclog.LoggerV2
+// adapter backed by a dd.Logger.
+//
+// *dd.Logger does not implement grpclog.LoggerV2 itself, as that would add
+// gRPC's Warning, *ln and V methods to the core API; NewLoggerV2 and
+// SetGRPCLogger adapt it instead.
+//
+// Example:
+//
+//	logger, _ := dd.New(dd.JSONConfig())
+//	srv := grpc.NewServer(
+//	    grpc.ChainUnaryInterceptor(grpclog.UnaryServerInterceptor(logger)),
+//	    grpc.ChainStreamInterceptor(grpclog.StreamServerInterceptor(logger)),
+//	)
+//	grpclog.SetGRPCLogger(logger)
+package grpclog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cybergodev/dd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys read from incoming requests to populate dd's context keys.
+const (
+	MetadataTraceID     = "x-trace-id"
+	MetadataSpanID      = "x-span-id"
+	MetadataRequestID   = "x-request-id"
+	MetadataTraceparent = "traceparent" // W3C Trace Context
+)
+
+// Config configures the interceptors. The zero value is usable.
+type Config struct {
+	// LogPayloads logs request and response messages at DEBUG level.
+	// Payloads are rendered as strings so the logger's sensitive data
+	// filter is applied to them.
+	LogPayloads bool
+
+	// MaxPayloadSize truncates rendered payloads (default: 4096 bytes).
+	MaxPayloadSize int
+
+	// CodeToLevel maps a status code to the level of the completion entry.
+	// Defaults to DefaultCodeToLevel.
+	CodeToLevel func(codes.Code) dd.LogLevel
+
+	// Skip returns true for methods that should not be logged,
+	// e.g. health checks. Trace and request IDs are still propagated.
+	Skip func(fullMethod string) bool
+}
+
+const defaultMaxPayloadSize = 4096
+
+func resolveConfig(cfgs []Config) Config {
+	var cfg Config
+	if len(cfgs) > 0 {
+		cfg = cfgs[0]
+	}
+	if cfg.MaxPayloadSize <= 0 {
+		cfg.MaxPayloadSize = defaultMaxPayloadSize
+	}
+	if cfg.CodeToLevel == nil {
+		cfg.CodeToLevel = DefaultCodeToLevel
+	}
+	return cfg
+}
+
+// DefaultCodeToLevel logs OK as INFO, client-side errors as WARN and
+// server-side errors as ERROR.
+func DefaultCodeToLevel(code codes.Code) dd.LogLevel {
+	switch code {
+	case codes.OK:
+		return dd.LevelInfo
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
+		codes.PermissionDenied, codes.Unauthenticated, codes.ResourceExhausted,
+		codes.FailedPrecondition, codes.Aborted, codes.OutOfRange:
+		return dd.LevelWarn
+	default:
+		return dd.LevelError
+	}
+}
+
+// UnaryServerInterceptor returns an interceptor that logs each unary call
+// with its method, status code, latency and peer address.
+func UnaryServerInterceptor(logger *dd.Logger, cfgs ...Config) grpc.UnaryServerInterceptor {
+	cfg := resolveConfig(cfgs)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = ContextFromMetadata(ctx)
+		if cfg.Skip != nil && cfg.Skip(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		if cfg.LogPayloads {
+			logPayload(ctx, logger, cfg, info.FullMethod, "grpc.request", req)
+		}
+
+		resp, err := handler(ctx, req)
+
+		if cfg.LogPayloads && err == nil {
+			logPayload(ctx, logger, cfg, info.FullMethod, "grpc.response", resp)
+		}
+		logCompletion(ctx, logger, cfg, info.FullMethod, "unary", start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns an interceptor that logs each stream
+// when it completes. With LogPayloads, every message sent or received
+// on the stream is logged at DEBUG level.
+func StreamServerInterceptor(logger *dd.Logger, cfgs ...Config) grpc.StreamServerInterceptor {
+	cfg := resolveConfig(cfgs)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ContextFromMetadata(ss.Context())
+		wrapped := &serverStream{ServerStream: ss, ctx: ctx}
+		if cfg.Skip != nil && cfg.Skip(info.FullMethod) {
+			return handler(srv, wrapped)
+		}
+
+		if cfg.LogPayloads {
+			wrapped.logger = logger
+			wrapped.cfg = cfg
+			wrapped.method = info.FullMethod
+		}
+
+		start := time.Now()
+		err := handler(srv, wrapped)
+		logCompletion(ctx, logger, cfg, info.FullMethod, streamType(info), start, err)
+		return err
+	}
+}
+
+// serverStream overrides Context so handlers see the propagated IDs,
+// and optionally logs stream messages.
+type serverStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	logger *dd.Logger // nil unless payload logging is enabled
+	cfg    Config
+	method string
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.logger != nil {
+		logPayload(s.ctx, s.logger, s.cfg, s.method, "grpc.request", m)
+	}
+	return err
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil && s.logger != nil {
+		logPayload(s.ctx, s.logger, s.cfg, s.method, "grpc.response", m)
+	}
+	return err
+}
+
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return "bidi_stream"
+	case info.IsClientStream:
+		return "client_stream"
+	default:
+		return "server_stream"
+	}
+}
+
+func logCompletion(ctx context.Context, logger *dd.Logger, cfg Config, method, kind string, start time.Time, err error) {
+	code := status.Code(err)
+	level := cfg.CodeToLevel(code)
+	if !logger.IsLevelEnabled(level) {
+		return
+	}
+
+	fields := make([]dd.Field, 0, 6)
+	fields = append(fields,
+		dd.String("grpc.method", method),
+		dd.String("grpc.type", kind),
+		dd.String("grpc.code", code.String()),
+		dd.Duration("grpc.duration", time.Since(start)),
+	)
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields = append(fields, dd.String("peer.address", p.Addr.String()))
+	}
+	if err != nil {
+		fields = append(fields, dd.Err(err))
+	}
+
+	// LogCtx adds trace/request IDs and ContextWithFields fields from ctx
+	logger.LogCtx(ctx, level, "grpc call finished", fields...)
+}
+
+func logPayload(ctx context.Context, logger *dd.Logger, cfg Config, method, key string, msg any) {
+	if !logger.IsDebugEnabled() {
+		return
+	}
+
+	logger.DebugCtx(ctx, "grpc payload",
+		dd.String("grpc.method", method),
+		dd.String(key, renderPayload(msg, cfg.MaxPayloadSize)),
+	)
+}
+
+// renderPayload renders msg as a string. Protobuf messages implement
+// fmt.Stringer, which yields the compact text format.
+func renderPayload(msg any, maxSize int) string {
+	var s string
+	if stringer, ok := msg.(fmt.Stringer); ok {
+		s = stringer.String()
+	} else {
+		s = fmt.Sprintf("%+v", msg)
+	}
+	if len(s) > maxSize {
+		// Cut at a rune boundary so the payload stays valid UTF-8
+		n := maxSize
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		s = s[:n] + "...[truncated]"
+	}
+	return s
+}
+
+// ContextFromMetadata copies trace, span and request IDs from incoming gRPC
+// metadata into ctx using dd's context keys, so they are picked up by the
+// logger's context extractors. Explicit x-* headers take precedence over
+// the W3C traceparent header.
+func ContextFromMetadata(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	traceID := firstValue(md, MetadataTraceID)
+	spanID := firstValue(md, MetadataSpanID)
+	if traceID == "" {
+		if t, s, ok := parseTraceparent(firstValue(md, MetadataTraceparent)); ok {
+			traceID = t
+			if spanID == "" {
+				spanID = s
+			}
+		}
+	}
+
+	if traceID != "" {
+		ctx = dd.WithTraceID(ctx, traceID)
+	}
+	if spanID != "" {
+		ctx = dd.WithSpanID(ctx, spanID)
+	}
+	if requestID := firstValue(md, MetadataRequestID); requestID != "" {
+		ctx = dd.WithRequestID(ctx, requestID)
+	}
+	return ctx
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// parseTraceparent extracts the trace and parent span IDs from a W3C
+// traceparent header ("00-<32 hex>-<16 hex>-<2 hex>").
+func parseTraceparent(header string) (traceID, spanID string, ok bool) {
+	parts := strings.Split(header, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
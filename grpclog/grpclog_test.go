@@ -0,0 +1,183 @@
+package grpclog
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/cybergodev/dd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func newTestLogger(t *testing.T) (*dd.Logger, *dd.LoggerRecorder) {
+	t.Helper()
+	recorder := dd.NewLoggerRecorder()
+	cfg := dd.DefaultConfig()
+	cfg.Level = dd.LevelDebug
+	cfg.Security = dd.DefaultSecurityConfig()
+	return recorder.NewLogger(cfg), recorder
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	logger, recorder := newTestLogger(t)
+	interceptor := UnaryServerInterceptor(logger)
+
+	md := metadata.Pairs(MetadataRequestID, "req-1", MetadataTraceparent,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+
+	var handlerCtx context.Context
+	handler := func(ctx context.Context, req any) (any, error) {
+		handlerCtx = ctx
+		return nil, status.Error(codes.NotFound, "missing")
+	}
+
+	_, err := interceptor(ctx, "req", &grpc.UnaryServerInfo{FullMethod: "/svc.Users/Get"}, handler)
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if got := dd.GetRequestID(handlerCtx); got != "req-1" {
+		t.Errorf("request ID = %q, want req-1", got)
+	}
+	if got := dd.GetTraceID(handlerCtx); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("trace ID = %q", got)
+	}
+
+	entry := recorder.LastEntry()
+	if entry == nil {
+		t.Fatal("expected a log entry")
+	}
+	if entry.Level != dd.LevelWarn {
+		t.Errorf("level = %v, want WARN", entry.Level)
+	}
+	for _, want := range []string{"/svc.Users/Get", "NotFound", "10.0.0.1:5000", "req-1"} {
+		if !strings.Contains(entry.RawOutput, want) {
+			t.Errorf("output missing %q: %s", want, entry.RawOutput)
+		}
+	}
+}
+
+func TestUnaryServerInterceptorPayloads(t *testing.T) {
+	logger, recorder := newTestLogger(t)
+	interceptor := UnaryServerInterceptor(logger, Config{LogPayloads: true})
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	}
+	_, err := interceptor(context.Background(), "password=hunter2secret", &grpc.UnaryServerInfo{FullMethod: "/svc.Auth/Login"}, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if recorder.Count() != 3 {
+		t.Fatalf("expected request, response and completion entries, got %d", recorder.Count())
+	}
+	for _, e := range recorder.Entries() {
+		if strings.Contains(e.RawOutput, "hunter2secret") {
+			t.Errorf("sensitive payload was not filtered: %s", e.RawOutput)
+		}
+	}
+}
+
+func TestUnaryServerInterceptorSkip(t *testing.T) {
+	logger, recorder := newTestLogger(t)
+	interceptor := UnaryServerInterceptor(logger, Config{
+		Skip: func(method string) bool { return strings.HasPrefix(method, "/grpc.health") },
+	})
+
+	handler := func(ctx context.Context, req any) (any, error) { return nil, nil }
+	_, _ = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler)
+
+	if recorder.HasEntries() {
+		t.Error("skipped method should not be logged")
+	}
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeStream) Context() context.Context { return s.ctx }
+func (s *fakeStream) RecvMsg(m any) error      { return nil }
+func (s *fakeStream) SendMsg(m any) error      { return nil }
+
+func TestStreamServerInterceptor(t *testing.T) {
+	logger, recorder := newTestLogger(t)
+	interceptor := StreamServerInterceptor(logger, Config{LogPayloads: true})
+
+	md := metadata.Pairs(MetadataTraceID, "trace-9")
+	ss := &fakeStream{ctx: metadata.NewIncomingContext(context.Background(), md)}
+	info := &grpc.StreamServerInfo{FullMethod: "/svc.Chat/Talk", IsClientStream: true, IsServerStream: true}
+
+	err := interceptor(nil, ss, info, func(srv any, stream grpc.ServerStream) error {
+		if got := dd.GetTraceID(stream.Context()); got != "trace-9" {
+			t.Errorf("trace ID = %q, want trace-9", got)
+		}
+		_ = stream.SendMsg("hello")
+		return errors.New("boom")
+	})
+	if err == nil {
+		t.Fatal("expected handler error")
+	}
+
+	entry := recorder.LastEntry()
+	if entry == nil || entry.Level != dd.LevelError {
+		t.Fatalf("expected ERROR completion entry, got %+v", entry)
+	}
+	if !strings.Contains(entry.RawOutput, "bidi_stream") {
+		t.Errorf("output missing stream type: %s", entry.RawOutput)
+	}
+	if recorder.Count() != 2 {
+		t.Errorf("expected payload and completion entries, got %d", recorder.Count())
+	}
+}
+
+func TestLoggerV2(t *testing.T) {
+	logger, recorder := newTestLogger(t)
+	l := NewLoggerV2(logger, 2)
+
+	l.Infoln("a", "b")
+	l.Warningf("w %d", 1)
+	l.Error("e")
+
+	entries := recorder.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	if entries[0].Message != "a b" || entries[1].Level != dd.LevelWarn || entries[2].Level != dd.LevelError {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+	if !l.V(2) || l.V(3) {
+		t.Error("V() does not honour verbosity")
+	}
+}
+
+func TestParseTraceparent(t *testing.T) {
+	if _, _, ok := parseTraceparent("garbage"); ok {
+		t.Error("expected invalid traceparent to be rejected")
+	}
+	traceID, spanID, ok := parseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if !ok || traceID != "4bf92f3577b34da6a3ce929d0e0e4736" || spanID != "00f067aa0ba902b7" {
+		t.Errorf("parseTraceparent() = %q, %q, %v", traceID, spanID, ok)
+	}
+}
+
+func TestRenderPayloadTruncatesAtRuneBoundary(t *testing.T) {
+	got := renderPayload("héllo", 2)
+	if got != "h...[truncated]" {
+		t.Errorf("renderPayload() = %q", got)
+	}
+	if !utf8.ValidString(got) {
+		t.Errorf("renderPayload() cut a rune: %q", got)
+	}
+}
@@ -0,0 +1,55 @@
+package grpclog
+
+import (
+	"fmt"
+
+	"github.com/cybergodev/dd"
+	grpclogv2 "google.golang.org/grpc/grpclog"
+)
+
+// loggerV2 adapts a dd.Logger to grpclog.LoggerV2.
+type loggerV2 struct {
+	logger    *dd.Logger
+	verbosity int
+}
+
+// NewLoggerV2 returns a grpclog.LoggerV2 that writes gRPC's internal logs
+// to logger. verbosity is the value reported to V(); gRPC only emits its
+// verbose logs for levels at or below it (default 0).
+func NewLoggerV2(logger *dd.Logger, verbosity ...int) grpclogv2.LoggerV2 {
+	l := &loggerV2{logger: logger}
+	if len(verbosity) > 0 {
+		l.verbosity = verbosity[0]
+	}
+	return l
+}
+
+// SetGRPCLogger installs logger as gRPC's global logger.
+// It must be called before any gRPC functions, as required by grpclog.
+func SetGRPCLogger(logger *dd.Logger, verbosity ...int) {
+	grpclogv2.SetLoggerV2(NewLoggerV2(logger, verbosity...))
+}
+
+func (l *loggerV2) Info(args ...any)                    { l.logger.Log(dd.LevelInfo, fmt.Sprint(args...)) }
+func (l *loggerV2) Infoln(args ...any)                  { l.logger.Log(dd.LevelInfo, sprintln(args...)) }
+func (l *loggerV2) Infof(format string, args ...any)    { l.logger.Logf(dd.LevelInfo, format, args...) }
+func (l *loggerV2) Warning(args ...any)                 { l.logger.Log(dd.LevelWarn, fmt.Sprint(args...)) }
+func (l *loggerV2) Warningln(args ...any)               { l.logger.Log(dd.LevelWarn, sprintln(args...)) }
+func (l *loggerV2) Warningf(format string, args ...any) { l.logger.Logf(dd.LevelWarn, format, args...) }
+func (l *loggerV2) Error(args ...any)                   { l.logger.Log(dd.LevelError, fmt.Sprint(args...)) }
+func (l *loggerV2) Errorln(args ...any)                 { l.logger.Log(dd.LevelError, sprintln(args...)) }
+func (l *loggerV2) Errorf(format string, args ...any)   { l.logger.Logf(dd.LevelError, format, args...) }
+func (l *loggerV2) Fatal(args ...any)                   { l.logger.Log(dd.LevelFatal, fmt.Sprint(args...)) }
+func (l *loggerV2) Fatalln(args ...any)                 { l.logger.Log(dd.LevelFatal, sprintln(args...)) }
+func (l *loggerV2) Fatalf(format string, args ...any)   { l.logger.Logf(dd.LevelFatal, format, args...) }
+
+// V reports whether verbosity level v is enabled.
+func (l *loggerV2) V(v int) bool {
+	return v <= l.verbosity
+}
+
+// sprintln formats like fmt.Sprintln without the trailing newline.
+func sprintln(args ...any) string {
+	s := fmt.Sprintln(args...)
+	return s[:len(s)-1]
+}
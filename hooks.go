@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
+	"slices"
 	"sync"
 	"time"
 )
@@ -31,6 +31,26 @@ const (
 
 	// HookOnError is triggered when a write error occurs.
 	HookOnError
+
+	// HookOnFatal is triggered after a FATAL entry is written, before the
+	// program exits. The context expires after Config.FatalTimeout; use it
+	// to flush metrics or shut down tracing.
+	HookOnFatal
+
+	// HookOnWriterQuarantined is triggered when a writer is quarantined
+	// after repeated write failures (see Config.Quarantine). Error is the
+	// last write error.
+	HookOnWriterQuarantined
+
+	// HookOnWriterRestored is triggered when a quarantined writer accepts
+	// a write again. Metadata holds "skipped_writes", "skipped_bytes" and
+	// "quarantined_for".
+	HookOnWriterRestored
+
+	// HookOnExtractorDisabled is triggered when a context extractor is
+	// disabled after repeated failures (see Config.ExtractorGuard). Error
+	// is the last failure; Metadata holds "index".
+	HookOnExtractorDisabled
 )
 
 // String returns the string representation of the hook event.
@@ -48,6 +68,14 @@ func (e HookEvent) String() string {
 		return "OnClose"
 	case HookOnError:
 		return "OnError"
+	case HookOnFatal:
+		return "OnFatal"
+	case HookOnWriterQuarantined:
+		return "OnWriterQuarantined"
+	case HookOnWriterRestored:
+		return "OnWriterRestored"
+	case HookOnExtractorDisabled:
+		return "OnExtractorDisabled"
 	default:
 		return "Unknown"
 	}
@@ -65,6 +93,7 @@ type HookContext struct {
 	Message string
 
 	// Fields are the structured fields attached to the log entry (after filtering).
+	// BeforeLog hooks change them with SetField and RemoveField.
 	Fields []Field
 
 	// OriginalFields are the fields before sensitive data filtering.
@@ -82,10 +111,77 @@ type HookContext struct {
 
 	// Additional metadata can be stored here.
 	Metadata map[string]any
+
+	// modified is set when a ResultHook returns HookModify or a hook
+	// calls SetField, RemoveField or SetMessage.
+	modified bool
+	// ownsFields is set once Fields is a private copy that can be changed
+	// in place.
+	ownsFields bool
+}
+
+// SetMessage replaces the message. In a BeforeLog hook the entry is
+// written with the new message.
+func (c *HookContext) SetMessage(msg string) {
+	c.Message = msg
+	c.modified = true
+}
+
+// SetField sets the value of the field key, adding it if the entry has no
+// such field. In a BeforeLog hook the entry is written with the change.
+// Values are written as given: they are not passed through the sensitive
+// data filter, which runs before hooks.
+//
+// Example:
+//
+//	registry.Add(dd.HookBeforeLog, func(ctx context.Context, hc *dd.HookContext) error {
+//	    hc.SetField("region", region)
+//	    hc.RemoveField("internal_debug")
+//	    return nil
+//	})
+func (c *HookContext) SetField(key string, value any) {
+	c.ownFields()
+	c.modified = true
+	for i := range c.Fields {
+		if c.Fields[i].Key == key {
+			c.Fields[i].Value = value
+			return
+		}
+	}
+	c.Fields = append(c.Fields, Field{Key: key, Value: value})
+}
+
+// RemoveField removes every field with key and reports whether there was
+// one. In a BeforeLog hook the entry is written without it.
+func (c *HookContext) RemoveField(key string) bool {
+	found := false
+	for _, f := range c.Fields {
+		if f.Key == key {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+	c.ownFields()
+	c.modified = true
+	c.Fields = slices.DeleteFunc(c.Fields, func(f Field) bool { return f.Key == key })
+	return true
+}
+
+// ownFields copies Fields before the first change, since the slice may be
+// shared with the LoggerEntry that logged it.
+func (c *HookContext) ownFields() {
+	if !c.ownsFields {
+		c.Fields = slices.Clone(c.Fields)
+		c.ownsFields = true
+	}
 }
 
 // Hook is a function that is called during logging lifecycle events.
-// If a BeforeLog hook returns an error, the log entry is not written.
+// If a BeforeLog hook returns an error, the log entry is not written
+// (unless the registry's HookErrorPolicy says otherwise).
 // For other events, the error is logged but does not prevent the operation.
 type Hook func(ctx context.Context, hookCtx *HookContext) error
 
@@ -102,10 +198,11 @@ type Hook func(ctx context.Context, hookCtx *HookContext) error
 // and logged to stderr.
 type HookErrorHandler func(event HookEvent, hookCtx *HookContext, err error)
 
-// DefaultHookErrorHandler logs hook errors to stderr.
+// DefaultHookErrorHandler reports hook errors as internal events (see
+// SetInternalErrorHandler), on stderr by default.
 // This is the default error handler used when no custom handler is set.
 func DefaultHookErrorHandler(event HookEvent, hookCtx *HookContext, err error) {
-	fmt.Fprintf(os.Stderr, "dd: hook error for event %s: %v\n", event, err)
+	reportInternal(nil, ComponentHooks, err, "hook error for event %s: %v", event, err)
 }
 
 // HookErrorRecorder records hook errors for later inspection.
@@ -190,25 +287,125 @@ func (r *HookErrorRecorder) HasErrors() bool {
 	return len(r.errors) > 0
 }
 
+// HookID identifies a registered hook so it can be removed with RemoveHook.
+// IDs are unique within a registry and preserved by Clone.
+type HookID uint64
+
+// HookResult is the explicit outcome reported by a ResultHook.
+type HookResult int
+
+const (
+	// HookContinue lets the remaining hooks and the log operation proceed.
+	HookContinue HookResult = iota
+
+	// HookAbort stops the remaining hooks. For BeforeLog, the entry is not
+	// written and Trigger returns ErrHookAborted.
+	HookAbort
+
+	// HookModify reports that the hook changed HookContext.Message or
+	// HookContext.Fields. For BeforeLog, the logger writes the modified
+	// message and fields instead of the originals. Hooks that change them
+	// with SetMessage, SetField or RemoveField need not report it.
+	HookModify
+)
+
+// String returns the string representation of the hook result.
+func (r HookResult) String() string {
+	switch r {
+	case HookContinue:
+		return "Continue"
+	case HookAbort:
+		return "Abort"
+	case HookModify:
+		return "Modify"
+	default:
+		return "Unknown"
+	}
+}
+
+// ResultHook is a hook that reports its outcome explicitly. A non-nil
+// error is handled according to the registry's HookErrorPolicy.
+type ResultHook func(ctx context.Context, hookCtx *HookContext) (HookResult, error)
+
+// HookErrorPolicy controls how the registry reacts to hook errors and panics.
+type HookErrorPolicy int
+
+const (
+	// HookErrorAbort treats an error as fatal for the operation: BeforeLog
+	// entries are not written. Without an error handler, the remaining hooks
+	// are skipped. This is the default.
+	HookErrorAbort HookErrorPolicy = iota
+
+	// HookErrorIgnore discards errors; all hooks run and the entry is written.
+	HookErrorIgnore
+
+	// HookErrorLog reports errors to the error handler (stderr when none is
+	// set); all hooks run and the entry is written.
+	HookErrorLog
+)
+
+// String returns the string representation of the error policy.
+func (p HookErrorPolicy) String() string {
+	switch p {
+	case HookErrorAbort:
+		return "Abort"
+	case HookErrorIgnore:
+		return "Ignore"
+	case HookErrorLog:
+		return "Log"
+	default:
+		return "Unknown"
+	}
+}
+
+// HookOptions configures how a hook is registered.
+type HookOptions struct {
+	// Priority orders hooks for the same event: higher values run first.
+	// Hooks with equal priority run in registration order. Default is 0.
+	Priority int
+
+	// Async runs the hook on a background worker with a bounded queue (see
+	// AsyncHookConfig) so slow hooks do not add latency to logging. Async
+	// hooks receive a copy of the HookContext; their results cannot abort
+	// or modify the entry, and errors go to the error handler (or stderr).
+	Async bool
+}
+
+// hookEntry is a registered hook with its ordering metadata.
+type hookEntry struct {
+	id       HookID
+	priority int
+	async    bool
+	fn       ResultHook
+}
+
 // HookRegistry manages a collection of hooks organized by event type.
 // It is thread-safe and supports dynamic hook registration.
 //
-// Error Handling Behavior:
+// Hooks run in descending priority order, then registration order.
+//
+// Error Handling Behavior (HookErrorAbort, the default):
 //   - By default, Trigger returns the first error from a hook and stops execution
 //   - If an error handler is set via SetErrorHandler, all hooks are executed
 //     regardless of errors, and errors are passed to the handler
 //   - For BeforeLog events, an error still prevents the log from being written
 //     even with an error handler set
+//
+// See HookErrorPolicy for the alternatives.
 type HookRegistry struct {
 	mu           sync.RWMutex
-	hooks        map[HookEvent][]Hook
+	hooks        map[HookEvent][]hookEntry // copy-on-write: slices are never mutated in place
 	errorHandler HookErrorHandler
+	errorPolicy  HookErrorPolicy
+	nextID       HookID
+	asyncConfig  AsyncHookConfig
+	async        *asyncHookWorker // shared with clones; nil until an async hook is added
 }
 
 // NewHookRegistry creates a new empty hook registry.
 func NewHookRegistry() *HookRegistry {
 	return &HookRegistry{
-		hooks: make(map[HookEvent][]Hook),
+		hooks: make(map[HookEvent][]hookEntry),
 	}
 }
 
@@ -217,7 +414,7 @@ func NewHookRegistry() *HookRegistry {
 // and errors are passed to the handler instead of being returned immediately.
 func NewHookRegistryWithErrorHandler(handler HookErrorHandler) *HookRegistry {
 	return &HookRegistry{
-		hooks:        make(map[HookEvent][]Hook),
+		hooks:        make(map[HookEvent][]hookEntry),
 		errorHandler: handler,
 	}
 }
@@ -230,17 +427,100 @@ func (r *HookRegistry) SetErrorHandler(handler HookErrorHandler) {
 	r.errorHandler = handler
 }
 
-// Add registers a hook for a specific event type.
-// If the hook is nil, it is ignored.
+// SetErrorPolicy sets how hook errors affect the operation being hooked.
+func (r *HookRegistry) SetErrorPolicy(policy HookErrorPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errorPolicy = policy
+}
+
+// ErrorPolicy returns the registry's hook error policy.
+func (r *HookRegistry) ErrorPolicy() HookErrorPolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.errorPolicy
+}
+
+// Add registers a hook for a specific event type with default options
+// and returns its ID. If the hook is nil, it is ignored and 0 is returned.
 // Multiple hooks can be registered for the same event.
-// Hooks are executed in the order they were added.
-func (r *HookRegistry) Add(event HookEvent, hook Hook) {
+func (r *HookRegistry) Add(event HookEvent, hook Hook) HookID {
+	return r.AddWithOptions(event, hook, HookOptions{})
+}
+
+// AddWithOptions registers a hook with the given options and returns its ID.
+// If the hook is nil, it is ignored and 0 is returned.
+func (r *HookRegistry) AddWithOptions(event HookEvent, hook Hook, opts HookOptions) HookID {
+	if hook == nil {
+		return 0
+	}
+	return r.AddResultHook(event, func(ctx context.Context, hookCtx *HookContext) (HookResult, error) {
+		return HookContinue, hook(ctx, hookCtx)
+	}, opts)
+}
+
+// AddResultHook registers a hook that reports an explicit HookResult and
+// returns its ID. If the hook is nil, it is ignored and 0 is returned.
+func (r *HookRegistry) AddResultHook(event HookEvent, hook ResultHook, opts ...HookOptions) HookID {
 	if hook == nil {
-		return
+		return 0
+	}
+	var opt HookOptions
+	if len(opts) > 0 {
+		opt = opts[0]
 	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.hooks[event] = append(r.hooks[event], hook)
+
+	r.nextID++
+	entry := hookEntry{id: r.nextID, priority: opt.Priority, async: opt.Async, fn: hook}
+	if entry.async {
+		r.asyncWorker()
+	}
+
+	existing := r.hooks[event]
+	pos := len(existing)
+	for i, e := range existing {
+		if e.priority < entry.priority {
+			pos = i
+			break
+		}
+	}
+	hooks := make([]hookEntry, 0, len(existing)+1)
+	hooks = append(hooks, existing[:pos]...)
+	hooks = append(hooks, entry)
+	hooks = append(hooks, existing[pos:]...)
+	r.hooks[event] = hooks
+	return entry.id
+}
+
+// RemoveHook removes the hook with the given ID.
+// Returns true if a hook was removed.
+func (r *HookRegistry) RemoveHook(id HookID) bool {
+	if r == nil || id == 0 {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for event, existing := range r.hooks {
+		for i, e := range existing {
+			if e.id != id {
+				continue
+			}
+			if len(existing) == 1 {
+				delete(r.hooks, event)
+				return true
+			}
+			hooks := make([]hookEntry, 0, len(existing)-1)
+			hooks = append(hooks, existing[:i]...)
+			hooks = append(hooks, existing[i+1:]...)
+			r.hooks[event] = hooks
+			return true
+		}
+	}
+	return false
 }
 
 // Remove removes all hooks for a specific event type.
@@ -252,8 +532,8 @@ func (r *HookRegistry) Remove(event HookEvent) {
 
 // Trigger executes all hooks registered for the given event.
 //
-// Error Handling Behavior:
-//   - If no error handler is set (default): hooks are executed in order;
+// Error Handling Behavior (HookErrorAbort, the default):
+//   - If no error handler is set: hooks are executed in order;
 //     if any hook returns an error or panics, execution stops and that error is returned.
 //   - If an error handler is set: all hooks are executed regardless of errors or panics;
 //     each error is passed to the error handler, and the first error is returned.
@@ -261,6 +541,10 @@ func (r *HookRegistry) Remove(event HookEvent) {
 // For BeforeLog events, an error prevents the log from being written
 // regardless of whether an error handler is set.
 //
+// With HookErrorIgnore or HookErrorLog, errors never stop the remaining
+// hooks and Trigger returns nil unless a ResultHook returns HookAbort, in
+// which case ErrHookAborted is returned.
+//
 // Panic Recovery: If a hook panics, the panic is recovered and converted to an error.
 // This ensures that a misbehaving hook cannot crash the application.
 func (r *HookRegistry) Trigger(ctx context.Context, event HookEvent, hookCtx *HookContext) (err error) {
@@ -271,6 +555,8 @@ func (r *HookRegistry) Trigger(ctx context.Context, event HookEvent, hookCtx *Ho
 	r.mu.RLock()
 	hooks := r.hooks[event]
 	handler := r.errorHandler
+	policy := r.errorPolicy
+	worker := r.async
 	r.mu.RUnlock()
 
 	if len(hooks) == 0 {
@@ -278,21 +564,52 @@ func (r *HookRegistry) Trigger(ctx context.Context, event HookEvent, hookCtx *Ho
 	}
 
 	var firstErr error
+	var asyncCtx *HookContext
 
 	for _, hook := range hooks {
+		if hook.async {
+			if asyncCtx == nil {
+				asyncCtx = copyHookContext(hookCtx)
+			}
+			worker.enqueue(asyncHookTask{ctx: ctx, event: event, hook: hook.fn, hookCtx: asyncCtx, handler: handler})
+			continue
+		}
+
 		// Execute hook with panic recovery
-		hookErr := r.executeHookWithRecovery(ctx, hook, hookCtx, event)
+		result, hookErr := executeHookWithRecovery(ctx, hook.fn, hookCtx, event)
 		if hookErr != nil {
-			if handler != nil {
+			switch policy {
+			case HookErrorIgnore:
+			case HookErrorLog:
+				if handler != nil {
+					handler(event, hookCtx, hookErr)
+				} else {
+					DefaultHookErrorHandler(event, hookCtx, hookErr)
+				}
+			default:
+				if handler == nil {
+					// Default behavior: stop on first error (including panic)
+					return hookErr
+				}
 				// Call the error handler and continue to next hook
 				handler(event, hookCtx, hookErr)
 				// Record first error to return later
 				if firstErr == nil {
 					firstErr = hookErr
 				}
-			} else {
-				// Default behavior: stop on first error (including panic)
-				return hookErr
+			}
+			continue
+		}
+
+		switch result {
+		case HookAbort:
+			if firstErr != nil {
+				return firstErr
+			}
+			return ErrHookAborted
+		case HookModify:
+			if hookCtx != nil {
+				hookCtx.modified = true
 			}
 		}
 	}
@@ -301,23 +618,24 @@ func (r *HookRegistry) Trigger(ctx context.Context, event HookEvent, hookCtx *Ho
 }
 
 // executeHookWithRecovery executes a hook with panic recovery.
-// If the hook panics, the panic is recovered, logged to stderr, and converted to an error.
-func (r *HookRegistry) executeHookWithRecovery(ctx context.Context, hook Hook, hookCtx *HookContext, event HookEvent) (err error) {
+// If the hook panics, the panic is recovered, reported as an internal event,
+// and converted to an error.
+func executeHookWithRecovery(ctx context.Context, hook ResultHook, hookCtx *HookContext, event HookEvent) (result HookResult, err error) {
 	defer func() {
 		if rec := recover(); rec != nil {
 			// Convert panic to error
 			panicErr := fmt.Errorf("hook panic for event %s: %v", event, rec)
-			// Log to stderr as a fallback
-			fmt.Fprintf(os.Stderr, "dd: %v\n", panicErr)
-			err = panicErr
+			reportInternal(nil, ComponentHooks, panicErr, "%v", panicErr)
+			result, err = HookContinue, panicErr
 		}
 	}()
 
 	return hook(ctx, hookCtx)
 }
 
-// Clone creates a copy of the registry with the same hooks and error handler.
-// The hooks themselves are shared (functions are not copied).
+// Clone creates a copy of the registry with the same hooks, IDs, error
+// handler and error policy. The hooks themselves are shared (functions
+// are not copied).
 func (r *HookRegistry) Clone() *HookRegistry {
 	if r == nil {
 		return nil
@@ -327,12 +645,17 @@ func (r *HookRegistry) Clone() *HookRegistry {
 	defer r.mu.RUnlock()
 
 	clone := &HookRegistry{
-		hooks:        make(map[HookEvent][]Hook, len(r.hooks)),
+		hooks:        make(map[HookEvent][]hookEntry, len(r.hooks)),
 		errorHandler: r.errorHandler,
+		errorPolicy:  r.errorPolicy,
+		nextID:       r.nextID,
+		asyncConfig:  r.asyncConfig,
+		async:        r.async,
 	}
 
+	// Slices are copy-on-write, so sharing them is safe
 	for event, hooks := range r.hooks {
-		clone.hooks[event] = append([]Hook(nil), hooks...)
+		clone.hooks[event] = hooks
 	}
 
 	return clone
@@ -367,7 +690,7 @@ func (r *HookRegistry) CountFor(event HookEvent) int {
 func (r *HookRegistry) Clear() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.hooks = make(map[HookEvent][]Hook)
+	r.hooks = make(map[HookEvent][]hookEntry)
 }
 
 // ClearFor removes all hooks for a specific event type.
@@ -404,8 +727,20 @@ type HooksConfig struct {
 	OnClose []Hook
 	// OnError hooks are called when a write error occurs.
 	OnError []Hook
+	// OnFatal hooks are called before the program exits on a FATAL entry.
+	OnFatal []Hook
+	// OnWriterQuarantined hooks are called when a failing writer is quarantined.
+	OnWriterQuarantined []Hook
+	// OnWriterRestored hooks are called when a quarantined writer recovers.
+	OnWriterRestored []Hook
+	// OnExtractorDisabled hooks are called when a failing context extractor is disabled.
+	OnExtractorDisabled []Hook
 	// ErrorHandler handles errors that occur during hook execution.
 	ErrorHandler HookErrorHandler
+	// ErrorPolicy controls whether hook errors abort the operation.
+	ErrorPolicy HookErrorPolicy
+	// Async configures the queue for hooks registered with HookOptions.Async.
+	Async AsyncHookConfig
 }
 
 // NewHooksFromConfig creates a HookRegistry from the configuration.
@@ -415,6 +750,8 @@ func NewHooksFromConfig(cfg HooksConfig) *HookRegistry {
 	if cfg.ErrorHandler != nil {
 		registry.SetErrorHandler(cfg.ErrorHandler)
 	}
+	registry.SetErrorPolicy(cfg.ErrorPolicy)
+	registry.SetAsyncConfig(cfg.Async)
 	for _, hook := range cfg.BeforeLog {
 		registry.Add(HookBeforeLog, hook)
 	}
@@ -433,5 +770,17 @@ func NewHooksFromConfig(cfg HooksConfig) *HookRegistry {
 	for _, hook := range cfg.OnError {
 		registry.Add(HookOnError, hook)
 	}
+	for _, hook := range cfg.OnFatal {
+		registry.Add(HookOnFatal, hook)
+	}
+	for _, hook := range cfg.OnWriterQuarantined {
+		registry.Add(HookOnWriterQuarantined, hook)
+	}
+	for _, hook := range cfg.OnWriterRestored {
+		registry.Add(HookOnWriterRestored, hook)
+	}
+	for _, hook := range cfg.OnExtractorDisabled {
+		registry.Add(HookOnExtractorDisabled, hook)
+	}
 	return registry
 }
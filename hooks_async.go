@@ -0,0 +1,228 @@
+package dd
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// HookQueuePolicy controls what happens when the async hook queue is full.
+type HookQueuePolicy int
+
+const (
+	// HookDropNewest discards the hook invocation being queued. This is the default.
+	HookDropNewest HookQueuePolicy = iota
+
+	// HookDropOldest discards the oldest queued invocation to make room.
+	HookDropOldest
+)
+
+// String returns the string representation of the queue policy.
+func (p HookQueuePolicy) String() string {
+	switch p {
+	case HookDropNewest:
+		return "DropNewest"
+	case HookDropOldest:
+		return "DropOldest"
+	default:
+		return "Unknown"
+	}
+}
+
+// AsyncHookConfig configures the background worker that runs async hooks.
+type AsyncHookConfig struct {
+	// QueueSize bounds the number of pending async hook invocations.
+	// Zero uses the default of 1024.
+	QueueSize int
+
+	// OnFull selects which invocation is dropped when the queue is full.
+	OnFull HookQueuePolicy
+}
+
+// asyncHookTask is a queued async hook invocation.
+type asyncHookTask struct {
+	ctx     context.Context
+	event   HookEvent
+	hook    ResultHook
+	hookCtx *HookContext
+	handler HookErrorHandler
+}
+
+// asyncHookWorker runs async hooks on a single goroutine that is started on
+// demand and exits when the queue is empty, so idle registries hold no
+// goroutines. It is shared between a registry and its clones.
+type asyncHookWorker struct {
+	queue   chan asyncHookTask
+	onFull  HookQueuePolicy
+	dropped atomic.Int64
+
+	mu      sync.Mutex
+	pending int           // queued plus running tasks
+	running bool          // whether the worker goroutine is active
+	idle    chan struct{} // closed when pending drops to zero
+}
+
+func newAsyncHookWorker(cfg AsyncHookConfig) *asyncHookWorker {
+	size := cfg.QueueSize
+	if size <= 0 {
+		size = defaultAsyncHookQueueSize
+	}
+	return &asyncHookWorker{
+		queue:  make(chan asyncHookTask, size),
+		onFull: cfg.OnFull,
+		idle:   make(chan struct{}),
+	}
+}
+
+// enqueue queues a task without blocking, applying the queue-full policy.
+func (w *asyncHookWorker) enqueue(task asyncHookTask) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for {
+		select {
+		case w.queue <- task:
+			w.pending++
+			if !w.running {
+				w.running = true
+				go w.run()
+			}
+			return
+		default:
+		}
+
+		if w.onFull != HookDropOldest {
+			w.dropped.Add(1)
+			return
+		}
+		select {
+		case <-w.queue:
+			w.pending--
+			w.dropped.Add(1)
+		default:
+			// The worker took the oldest task; retry the send
+		}
+	}
+}
+
+func (w *asyncHookWorker) run() {
+	for {
+		task := <-w.queue
+		// Async hooks cannot abort or modify the entry, so the result is ignored
+		if _, err := executeHookWithRecovery(task.ctx, task.hook, task.hookCtx, task.event); err != nil {
+			if task.handler != nil {
+				task.handler(task.event, task.hookCtx, err)
+			} else {
+				DefaultHookErrorHandler(task.event, task.hookCtx, err)
+			}
+		}
+
+		w.mu.Lock()
+		w.pending--
+		if w.pending == 0 {
+			w.running = false
+			close(w.idle)
+			w.idle = make(chan struct{})
+			w.mu.Unlock()
+			return
+		}
+		w.mu.Unlock()
+	}
+}
+
+// drain waits until all queued tasks have run or ctx is done.
+func (w *asyncHookWorker) drain(ctx context.Context) error {
+	w.mu.Lock()
+	if w.pending == 0 {
+		w.mu.Unlock()
+		return nil
+	}
+	idle := w.idle
+	w.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// copyHookContext snapshots hookCtx for an async hook, since the caller
+// reuses it after Trigger returns.
+func copyHookContext(hookCtx *HookContext) *HookContext {
+	if hookCtx == nil {
+		return nil
+	}
+	c := *hookCtx
+	if hookCtx.Fields != nil {
+		c.Fields = append([]Field(nil), hookCtx.Fields...)
+	}
+	if hookCtx.OriginalFields != nil {
+		c.OriginalFields = append([]Field(nil), hookCtx.OriginalFields...)
+	}
+	if hookCtx.Metadata != nil {
+		c.Metadata = make(map[string]any, len(hookCtx.Metadata))
+		for k, v := range hookCtx.Metadata {
+			c.Metadata[k] = v
+		}
+	}
+	return &c
+}
+
+// SetAsyncConfig configures the queue used by async hooks (see
+// HookOptions.Async). Call it before registering async hooks; invocations
+// already queued are not covered by a later Drain.
+func (r *HookRegistry) SetAsyncConfig(cfg AsyncHookConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.asyncConfig = cfg
+	r.async = nil
+	for _, hooks := range r.hooks {
+		for _, h := range hooks {
+			if h.async {
+				r.asyncWorker()
+				return
+			}
+		}
+	}
+}
+
+// asyncWorker returns the registry's worker, creating it on first use.
+// Caller must hold r.mu for writing.
+func (r *HookRegistry) asyncWorker() *asyncHookWorker {
+	if r.async == nil {
+		r.async = newAsyncHookWorker(r.asyncConfig)
+	}
+	return r.async
+}
+
+// Drain waits until all queued async hook invocations have completed or
+// ctx is done. Logger.Close and Logger.Shutdown call it automatically.
+func (r *HookRegistry) Drain(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	worker := r.async
+	r.mu.RUnlock()
+	if worker == nil {
+		return nil
+	}
+	return worker.drain(ctx)
+}
+
+// DroppedAsync returns the number of async hook invocations dropped
+// because the queue was full.
+func (r *HookRegistry) DroppedAsync() int64 {
+	if r == nil {
+		return 0
+	}
+	r.mu.RLock()
+	worker := r.async
+	r.mu.RUnlock()
+	if worker == nil {
+		return 0
+	}
+	return worker.dropped.Load()
+}
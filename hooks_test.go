@@ -0,0 +1,311 @@
+package dd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHookRegistryPriority(t *testing.T) {
+	registry := NewHookRegistry()
+	var order []string
+	record := func(name string) Hook {
+		return func(context.Context, *HookContext) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	registry.Add(HookBeforeLog, record("default-1"))
+	registry.AddWithOptions(HookBeforeLog, record("high"), HookOptions{Priority: 10})
+	registry.AddWithOptions(HookBeforeLog, record("low"), HookOptions{Priority: -5})
+	registry.Add(HookBeforeLog, record("default-2"))
+
+	if err := registry.Trigger(context.Background(), HookBeforeLog, &HookContext{}); err != nil {
+		t.Fatal(err)
+	}
+	want := "high,default-1,default-2,low"
+	if got := strings.Join(order, ","); got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+}
+
+func TestHookRegistryRemoveHook(t *testing.T) {
+	registry := NewHookRegistry()
+	calls := 0
+	hook := func(context.Context, *HookContext) error { calls++; return nil }
+
+	id1 := registry.Add(HookAfterLog, hook)
+	id2 := registry.Add(HookAfterLog, hook)
+	if id1 == 0 || id1 == id2 {
+		t.Fatalf("expected distinct non-zero IDs, got %d and %d", id1, id2)
+	}
+	if registry.Add(HookAfterLog, nil) != 0 {
+		t.Error("nil hook should return ID 0")
+	}
+
+	clone := registry.Clone()
+	if !registry.RemoveHook(id1) {
+		t.Fatal("RemoveHook() = false, want true")
+	}
+	if registry.RemoveHook(id1) {
+		t.Error("second RemoveHook() should return false")
+	}
+	if got := registry.CountFor(HookAfterLog); got != 1 {
+		t.Errorf("CountFor() = %d, want 1", got)
+	}
+	if got := clone.CountFor(HookAfterLog); got != 2 {
+		t.Errorf("clone CountFor() = %d, want 2", got)
+	}
+	if !clone.RemoveHook(id2) {
+		t.Error("IDs should be preserved by Clone")
+	}
+}
+
+func TestHookResult(t *testing.T) {
+	t.Run("abort", func(t *testing.T) {
+		registry := NewHookRegistry()
+		later := false
+		registry.AddResultHook(HookBeforeLog, func(context.Context, *HookContext) (HookResult, error) {
+			return HookAbort, nil
+		})
+		registry.Add(HookBeforeLog, func(context.Context, *HookContext) error { later = true; return nil })
+
+		err := registry.Trigger(context.Background(), HookBeforeLog, &HookContext{})
+		if !errors.Is(err, ErrHookAborted) {
+			t.Errorf("Trigger() error = %v, want ErrHookAborted", err)
+		}
+		if later {
+			t.Error("hooks after an abort should not run")
+		}
+	})
+
+	t.Run("modify", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, _ := New(&Config{Level: LevelInfo, Format: FormatText, Output: &buf, IncludeLevel: true})
+		defer logger.Close()
+
+		registry := NewHookRegistry()
+		registry.AddResultHook(HookBeforeLog, func(_ context.Context, hc *HookContext) (HookResult, error) {
+			hc.Message = "rewritten"
+			hc.Fields = append(hc.Fields, String("added", "yes"))
+			return HookModify, nil
+		})
+		if err := logger.SetHooks(registry); err != nil {
+			t.Fatal(err)
+		}
+
+		logger.InfoWith("original", String("k", "v"))
+		out := buf.String()
+		if strings.Contains(out, "original") || !strings.Contains(out, "rewritten") {
+			t.Errorf("expected rewritten message, got %q", out)
+		}
+		if !strings.Contains(out, "added=yes") || !strings.Contains(out, "k=v") {
+			t.Errorf("expected modified fields, got %q", out)
+		}
+	})
+}
+
+func TestHookErrorPolicy(t *testing.T) {
+	failing := func(context.Context, *HookContext) error { return errors.New("boom") }
+
+	tests := []struct {
+		policy    HookErrorPolicy
+		wantErr   bool
+		wantCalls int
+	}{
+		{HookErrorAbort, true, 0},
+		{HookErrorIgnore, false, 1},
+		{HookErrorLog, false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			recorder := NewHookErrorRecorder()
+			registry := NewHooksFromConfig(HooksConfig{ErrorPolicy: tt.policy})
+			if tt.policy == HookErrorLog {
+				registry.SetErrorHandler(recorder.Handler())
+			}
+			calls := 0
+			registry.Add(HookBeforeLog, failing)
+			registry.Add(HookBeforeLog, func(context.Context, *HookContext) error { calls++; return nil })
+
+			err := registry.Trigger(context.Background(), HookBeforeLog, &HookContext{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Trigger() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("later hook calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if tt.policy == HookErrorLog && recorder.Count() != 1 {
+				t.Errorf("recorded errors = %d, want 1", recorder.Count())
+			}
+		})
+	}
+}
+
+func TestLoggerAddHookWithOptions(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := New(&Config{Level: LevelInfo, Format: FormatText, Output: &buf})
+	defer logger.Close()
+
+	id, err := logger.AddHookWithOptions(HookBeforeLog, func(context.Context, *HookContext) error {
+		return errors.New("blocked")
+	}, HookOptions{Priority: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Info("first")
+	if !logger.RemoveHook(id) {
+		t.Fatal("RemoveHook() = false, want true")
+	}
+	logger.Info("second")
+
+	out := buf.String()
+	if strings.Contains(out, "first") || !strings.Contains(out, "second") {
+		t.Errorf("unexpected output %q", out)
+	}
+	if _, err := logger.AddHookWithOptions(HookBeforeLog, nil, HookOptions{}); !errors.Is(err, ErrNilHook) {
+		t.Errorf("expected ErrNilHook, got %v", err)
+	}
+}
+
+func TestHookContextMutations(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := New(&Config{Level: LevelInfo, Format: FormatText, Output: &buf})
+	defer logger.Close()
+
+	if err := logger.AddHook(HookBeforeLog, func(_ context.Context, hc *HookContext) error {
+		hc.SetMessage("[" + hc.Message + "]")
+		hc.SetField("region", "eu-west-1")
+		hc.SetField("user", "redacted")
+		hc.RemoveField("debug")
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := logger.WithFields(String("user", "alice"), String("debug", "x"))
+	entry.Info("hello")
+	entry.Info("again")
+
+	out := buf.String()
+	for _, want := range []string{"[hello]", "[again]", "region=eu-west-1", "user=redacted"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q: %q", want, out)
+		}
+	}
+	if strings.Contains(out, "debug=") || strings.Contains(out, "alice") {
+		t.Errorf("output = %q", out)
+	}
+	if n := strings.Count(out, "region="); n != 2 {
+		t.Errorf("region written %d times, want 2 (entry fields must not be shared)", n)
+	}
+	if got := entry.fields; len(got) != 2 || got[0].Value != "alice" {
+		t.Errorf("hook changed the entry's fields: %v", got)
+	}
+}
+
+func TestAsyncHooks(t *testing.T) {
+	t.Run("runs off the logging path and drains on close", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, _ := New(&Config{Level: LevelInfo, Format: FormatText, Output: &buf})
+
+		release := make(chan struct{})
+		var mu sync.Mutex
+		var messages []string
+		_, err := logger.AddHookWithOptions(HookAfterLog, func(_ context.Context, hc *HookContext) error {
+			<-release
+			mu.Lock()
+			messages = append(messages, hc.Message)
+			mu.Unlock()
+			return nil
+		}, HookOptions{Async: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		logger.Info("one")
+		logger.Info("two")
+		if !strings.Contains(buf.String(), "two") {
+			t.Fatal("logging should not wait for async hooks")
+		}
+
+		close(release)
+		if err := logger.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if strings.Join(messages, ",") != "one,two" {
+			t.Errorf("async hook messages = %v, want [one two]", messages)
+		}
+	})
+
+	for _, policy := range []HookQueuePolicy{HookDropNewest, HookDropOldest} {
+		t.Run(policy.String(), func(t *testing.T) {
+			registry := NewHooksFromConfig(HooksConfig{Async: AsyncHookConfig{QueueSize: 1, OnFull: policy}})
+
+			started := make(chan struct{})
+			release := make(chan struct{})
+			var mu sync.Mutex
+			var seen []string
+			registry.AddWithOptions(HookAfterLog, func(_ context.Context, hc *HookContext) error {
+				if hc.Message == "blocker" {
+					close(started)
+					<-release
+				}
+				mu.Lock()
+				seen = append(seen, hc.Message)
+				mu.Unlock()
+				return nil
+			}, HookOptions{Async: true})
+
+			ctx := context.Background()
+			_ = registry.Trigger(ctx, HookAfterLog, &HookContext{Message: "blocker"})
+			<-started
+			_ = registry.Trigger(ctx, HookAfterLog, &HookContext{Message: "first"})
+			_ = registry.Trigger(ctx, HookAfterLog, &HookContext{Message: "second"})
+			close(release)
+
+			if err := registry.Drain(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if got := registry.DroppedAsync(); got != 1 {
+				t.Errorf("DroppedAsync() = %d, want 1", got)
+			}
+
+			want := "blocker,first"
+			if policy == HookDropOldest {
+				want = "blocker,second"
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if got := strings.Join(seen, ","); got != want {
+				t.Errorf("processed = %s, want %s", got, want)
+			}
+		})
+	}
+
+	t.Run("drain honors context", func(t *testing.T) {
+		registry := NewHookRegistry()
+		release := make(chan struct{})
+		defer close(release)
+		registry.AddWithOptions(HookAfterLog, func(context.Context, *HookContext) error {
+			<-release
+			return nil
+		}, HookOptions{Async: true})
+		_ = registry.Trigger(context.Background(), HookAfterLog, &HookContext{})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := registry.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Drain() error = %v, want DeadlineExceeded", err)
+		}
+	})
+}
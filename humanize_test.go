@@ -0,0 +1,101 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestByteSizeField(t *testing.T) {
+	var text, js bytes.Buffer
+
+	cfg := DefaultConfig()
+	cfg.Output = &text
+	logger, _ := New(cfg)
+	logger.InfoWith("upload", ByteSize("size", 4404019))
+	logger.Close()
+
+	cfg = JSONConfig()
+	cfg.Output = &js
+	logger, _ = New(cfg)
+	logger.InfoWith("upload", ByteSize("size", 4404019))
+	logger.Close()
+
+	if !strings.Contains(text.String(), `size="4.2 MiB"`) {
+		t.Errorf("text output should humanize the size: %q", text.String())
+	}
+	if !strings.Contains(js.String(), `"size":4404019`) {
+		t.Errorf("JSON output should keep the number: %q", js.String())
+	}
+}
+
+func TestTextHumanDurations(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Text = &TextOptions{HumanDurations: true}
+	cfg.GlobalFields = []Field{Duration("timeout", 2500*time.Millisecond)}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.InfoWith("done",
+		Duration("elapsed", 1253459*time.Microsecond),
+		Durations("phases", []time.Duration{350200 * time.Microsecond}))
+	logger.Close()
+
+	out := buf.String()
+	for _, want := range []string{"timeout=2.5s", "elapsed=1.25s", `phases=["350ms"]`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+
+	buf.Reset()
+	cfg.Text = nil
+	logger, _ = New(cfg)
+	logger.InfoWith("done", Duration("elapsed", 1253459*time.Microsecond))
+	logger.Close()
+	if !strings.Contains(buf.String(), "elapsed=1.253459s") {
+		t.Errorf("durations should keep full precision by default: %q", buf.String())
+	}
+}
+
+func TestTextValueCoercion(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Text = &TextOptions{FloatPrecision: 2, TimeLayout: "2006-01-02 15:04", BoolAsInt: true}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	logger.InfoWith("reading", Float64("pi", 3.141592653589793), Time("at", at), Bool("ok", true), Bool("stale", false))
+	logger.Close()
+
+	out := buf.String()
+	for _, want := range []string{"pi=3.14", `at="2024-03-04 05:06"`, "ok=1", "stale=0"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+
+	buf.Reset()
+	jsonCfg := JSONConfig()
+	jsonCfg.Output = &buf
+	jsonCfg.Text = cfg.Text
+	logger, _ = New(jsonCfg)
+	logger.InfoWith("reading", Float64("pi", 3.141592653589793), Bool("ok", true))
+	logger.Close()
+	if !strings.Contains(buf.String(), `"pi":3.141592653589793`) || !strings.Contains(buf.String(), `"ok":true`) {
+		t.Errorf("JSON output changed: %q", buf.String())
+	}
+
+	cfg.Text = &TextOptions{FloatPrecision: -1}
+	if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("negative FloatPrecision: %v", err)
+	}
+}
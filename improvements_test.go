@@ -42,7 +42,7 @@ func TestFatalTimeout(t *testing.T) {
 		// Call handleFatal in a goroutine
 		go func() {
 			defer close(handleFatalDone)
-			logger.handleFatal()
+			logger.handleFatal(&logEntry{})
 		}()
 
 		// Wait for handleFatal to complete with a timeout
@@ -0,0 +1,182 @@
+package internal
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+// ConsoleOptions configures the console format.
+type ConsoleOptions struct {
+	// Color wraps levels and field keys in ANSI color codes.
+	Color bool
+}
+
+// consoleCallerWidth is the column width the caller is padded to, so that
+// messages line up across entries.
+const consoleCallerWidth = 24
+
+// consoleFieldIndent prefixes each field line; nested lines (stack frames,
+// multi-line values) get it twice.
+const consoleFieldIndent = "    "
+
+// ANSI escape sequences used by the console format.
+const (
+	ansiReset = "\x1b[0m"
+	ansiDim   = "\x1b[2m"
+	ansiCyan  = "\x1b[36m"
+)
+
+// consoleLevelColors holds the color of each level, indexed by LogLevel.
+var consoleLevelColors = [5]string{
+	"\x1b[90m",   // DEBUG: gray
+	"\x1b[32m",   // INFO: green
+	"\x1b[33m",   // WARN: yellow
+	"\x1b[31m",   // ERROR: red
+	"\x1b[1;35m", // FATAL: bold magenta
+}
+
+// consoleLevelNames holds the level names padded to a common width.
+var consoleLevelNames = [5]string{"DEBUG", "INFO ", "WARN ", "ERROR", "FATAL"}
+
+// formatConsole renders an entry for humans: aligned time, level and caller
+// columns, then one indented line per field.
+func (f *MessageFormatter) formatConsole(at time.Time, level LogLevel, callerDepth int, message string, fields []Field) string {
+	var buf bytes.Buffer
+	buf.Grow(64 + len(message) + len(fields)*EstimatedFieldSize)
+	color := f.console != nil && f.console.Color
+
+	if f.includeTime {
+		f.writeConsoleColored(&buf, ansiDim, f.timeCache.formattedTimeAt(at), color)
+		buf.WriteByte(' ')
+	}
+
+	if f.includeLevel {
+		name, levelColor := level.String(), ""
+		if int(level) >= 0 && int(level) < len(consoleLevelNames) {
+			name, levelColor = consoleLevelNames[level], consoleLevelColors[level]
+		}
+		f.writeConsoleColored(&buf, levelColor, name, color)
+		buf.WriteByte(' ')
+	}
+
+	if f.dynamicCaller {
+		if callerInfo := GetCaller(callerDepth, f.fullPath); callerInfo != "" {
+			f.writeConsoleColored(&buf, ansiDim, callerInfo, color)
+			for i := len(callerInfo); i < consoleCallerWidth; i++ {
+				buf.WriteByte(' ')
+			}
+			buf.WriteByte(' ')
+		}
+	}
+
+	buf.WriteString(message)
+
+	all := fields
+	if len(f.globalFields) > 0 {
+		all = make([]Field, 0, len(f.globalFields)+len(fields))
+		for _, g := range f.globalFields {
+			if !hasFieldKey(fields, g.Key) {
+				all = append(all, g)
+			}
+		}
+		all = append(all, fields...)
+	}
+
+	keyWidth := 0
+	for _, field := range all {
+		keyWidth = max(keyWidth, len(field.Key))
+	}
+
+	for _, field := range all {
+		if field.Key == "" {
+			continue
+		}
+		buf.WriteByte('\n')
+		buf.WriteString(consoleFieldIndent)
+		f.writeConsoleColored(&buf, ansiCyan, field.Key, color)
+		buf.WriteByte(':')
+		for i := len(field.Key); i <= keyWidth; i++ {
+			buf.WriteByte(' ')
+		}
+		writeConsoleValue(&buf, field.Value)
+	}
+
+	return buf.String()
+}
+
+// writeConsoleColored writes s, wrapped in the given color when enabled.
+func (f *MessageFormatter) writeConsoleColored(buf *bytes.Buffer, code, s string, color bool) {
+	if !color || code == "" {
+		buf.WriteString(s)
+		return
+	}
+	buf.WriteString(code)
+	buf.WriteString(s)
+	buf.WriteString(ansiReset)
+}
+
+// writeConsoleValue writes a field value. Strings are written unquoted,
+// multi-line strings (stack traces) continue on indented lines and
+// durations are rounded to a readable precision.
+func writeConsoleValue(buf *bytes.Buffer, v any) {
+	switch val := v.(type) {
+	case string:
+		if val == "" {
+			buf.WriteString(`""`)
+			return
+		}
+		first, rest, multiline := strings.Cut(val, "\n")
+		buf.WriteString(strings.TrimRight(first, "\r"))
+		if !multiline {
+			return
+		}
+		for _, line := range strings.Split(rest, "\n") {
+			line = strings.TrimRight(line, "\r")
+			if strings.TrimSpace(line) == "" || line == "Stack:" {
+				continue
+			}
+			buf.WriteByte('\n')
+			buf.WriteString(consoleFieldIndent)
+			buf.WriteString(consoleFieldIndent)
+			buf.WriteString(strings.TrimLeft(line, " \t"))
+		}
+	case time.Duration:
+		buf.WriteString(HumanizeDuration(val))
+	case StringerValue:
+		writeConsoleValue(buf, val.String())
+	default:
+		formatFieldValueBytes(buf, v)
+	}
+}
+
+// HumanizeDuration formats d with at most one decimal: 1.234567s becomes
+// "1.2s", 83.5s becomes "1m24s" and 12.34ms becomes "12ms".
+func HumanizeDuration(d time.Duration) string {
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+	var unit time.Duration
+	switch {
+	case abs < time.Microsecond:
+		return d.String()
+	case abs < time.Millisecond:
+		unit = time.Microsecond
+	case abs < time.Second:
+		unit = time.Millisecond
+	case abs < time.Minute:
+		unit = time.Second
+	case abs < time.Hour:
+		return d.Round(time.Second).String()
+	default:
+		return strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+	}
+
+	// One decimal below 10 units, whole units above
+	precision := unit / 10
+	if abs >= 10*unit {
+		precision = unit
+	}
+	return d.Round(precision).String()
+}
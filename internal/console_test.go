@@ -0,0 +1,28 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanizeDuration(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{500 * time.Nanosecond, "500ns"},
+		{1500 * time.Nanosecond, "1.5µs"},
+		{1234567 * time.Nanosecond, "1.2ms"},
+		{12345678 * time.Nanosecond, "12ms"},
+		{1234567 * time.Microsecond, "1.2s"},
+		{-1234567 * time.Microsecond, "-1.2s"},
+		{2 * time.Second, "2s"},
+		{83500 * time.Millisecond, "1m24s"},
+		{65*time.Minute + 20*time.Second, "1h5m"},
+	}
+	for _, tt := range tests {
+		if got := HumanizeDuration(tt.in); got != tt.want {
+			t.Errorf("HumanizeDuration(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
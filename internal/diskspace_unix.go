@@ -0,0 +1,25 @@
+//go:build !windows
+
+package internal
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// FreeDiskBytes returns the number of bytes available to unprivileged users
+// on the filesystem containing path.
+func FreeDiskBytes(path string) (int64, error) {
+	free, _, err := DiskUsage(path)
+	return free, err
+}
+
+// DiskUsage returns the bytes available to unprivileged users and the total
+// size of the filesystem containing path.
+func DiskUsage(path string) (free, total int64, err error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, fmt.Errorf("statfs: %w", err)
+	}
+	return int64(stat.Bavail) * int64(stat.Bsize), int64(stat.Blocks) * int64(stat.Bsize), nil
+}
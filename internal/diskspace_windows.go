@@ -0,0 +1,39 @@
+//go:build windows
+
+package internal
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+var getDiskFreeSpaceEx = kernel32.NewProc("GetDiskFreeSpaceExW")
+
+// FreeDiskBytes returns the number of bytes available to the calling user
+// on the volume containing path.
+func FreeDiskBytes(path string) (int64, error) {
+	free, _, err := DiskUsage(path)
+	return free, err
+}
+
+// DiskUsage returns the bytes available to the calling user and the total
+// size of the volume containing path.
+func DiskUsage(path string) (free, total int64, err error) {
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("encode path: %w", err)
+	}
+
+	var freeBytesAvailable, totalBytes uint64
+	ret, _, callErr := getDiskFreeSpaceEx.Call(
+		uintptr(unsafe.Pointer(pathPtr)),
+		uintptr(unsafe.Pointer(&freeBytesAvailable)),
+		uintptr(unsafe.Pointer(&totalBytes)),
+		0,
+	)
+	if ret == 0 {
+		return 0, 0, fmt.Errorf("GetDiskFreeSpaceExW: %w", callErr)
+	}
+	return int64(freeBytesAvailable), int64(totalBytes), nil
+}
@@ -16,6 +16,151 @@ type Field struct {
 	Value any
 }
 
+// StringerValue defers calling String() until the entry is actually logged.
+// The logger resolves it to a string before filtering and formatting.
+type StringerValue struct {
+	Stringer fmt.Stringer
+}
+
+// String returns the resolved value. Nil receivers and panicking String
+// methods are reported the same way fmt does.
+func (v StringerValue) String() string {
+	return fmt.Sprint(v.Stringer)
+}
+
+// LazyValue is a field value computed only when the entry is logged.
+type LazyValue interface {
+	ResolveValue() any
+}
+
+// ResolveValue implements LazyValue.
+func (v StringerValue) ResolveValue() any {
+	return v.String()
+}
+
+// ResolveLazyValues replaces LazyValue values with their resolved form.
+// The input slice is returned unchanged when there is nothing to resolve.
+func ResolveLazyValues(fields []Field) []Field {
+	var out []Field
+	for i, field := range fields {
+		lv, ok := field.Value.(LazyValue)
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = make([]Field, len(fields))
+			copy(out, fields)
+		}
+		out[i].Value = lv.ResolveValue()
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// dedupMapThreshold is the field count above which DedupFields uses a map
+// instead of a linear scan.
+const dedupMapThreshold = 16
+
+// DedupFields removes fields with repeated keys. With keepFirst the first
+// field for each key is kept, otherwise the last; either way the kept
+// fields stay in their original order. onDuplicate, if not nil, is called
+// once per removed field. The input slice is returned unchanged when all
+// keys are unique; otherwise a new slice is returned.
+func DedupFields(fields []Field, keepFirst bool, onDuplicate func(key string)) []Field {
+	if len(fields) < 2 {
+		return fields
+	}
+
+	var seen map[string]struct{}
+	if len(fields) > dedupMapThreshold {
+		seen = make(map[string]struct{}, len(fields))
+	}
+	// isDup reports whether fields[i] has the same key as a field that
+	// precedes it (keepFirst) or follows it (last wins).
+	isDup := func(i int) bool {
+		if seen != nil {
+			_, ok := seen[fields[i].Key]
+			seen[fields[i].Key] = struct{}{}
+			return ok
+		}
+		if keepFirst {
+			for j := 0; j < i; j++ {
+				if fields[j].Key == fields[i].Key {
+					return true
+				}
+			}
+			return false
+		}
+		for j := i + 1; j < len(fields); j++ {
+			if fields[j].Key == fields[i].Key {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Find the indexes to drop. The map-based scan walks backwards for
+	// last-wins so the later field is seen first.
+	var drop []bool
+	for n := 0; n < len(fields); n++ {
+		i := n
+		if seen != nil && !keepFirst {
+			i = len(fields) - 1 - n
+		}
+		if isDup(i) {
+			if drop == nil {
+				drop = make([]bool, len(fields))
+			}
+			drop[i] = true
+		}
+	}
+	if drop == nil {
+		return fields
+	}
+
+	result := make([]Field, 0, len(fields))
+	for i, f := range fields {
+		if drop[i] {
+			if onDuplicate != nil {
+				onDuplicate(f.Key)
+			}
+			continue
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+// ObjectValue is an ordered set of fields rendered as a nested object.
+// It is produced by LogObjectMarshaler implementations.
+type ObjectValue []Field
+
+// MarshalJSON encodes the fields as a JSON object, preserving their order.
+func (o ObjectValue) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if !writeJSONValueFast(&buf, o) {
+		// Some nested value needs reflection; encode field by field
+		buf.Reset()
+		buf.WriteByte('{')
+		for i, field := range o {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSONString(&buf, field.Key)
+			buf.WriteByte(':')
+			data, err := json.Marshal(field.Value)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(data)
+		}
+		buf.WriteByte('}')
+	}
+	return buf.Bytes(), nil
+}
+
 // Constants for field formatting
 const (
 	// FieldBuilderCapacity is the initial capacity for field builder
@@ -143,6 +288,19 @@ func formatFieldValueBytes(buf *bytes.Buffer, v any) {
 		buf.WriteString(val.Format(time.RFC3339))
 	case nil:
 		buf.WriteString("<nil>")
+	case StringerValue:
+		formatFieldValueBytes(buf, val.String())
+	case ByteSize:
+		formatFieldValueBytes(buf, val.String())
+	case ObjectValue:
+		if data, err := val.MarshalJSON(); err == nil {
+			buf.Write(data)
+		} else {
+			fmt.Fprint(buf, []Field(val))
+		}
+	case []string, []int, []int64, []uint64, []float64, []bool, []time.Duration:
+		// Same output as json.Marshal would give, without reflection
+		writeJSONValueFast(buf, val)
 	default:
 		if IsComplexValue(v) {
 			if jsonData, err := json.Marshal(v); err == nil {
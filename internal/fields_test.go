@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -108,6 +109,15 @@ func TestFormatFieldValue(t *testing.T) {
 		// Complex types (use JSON marshaling)
 		{"slice", []string{"a", "b"}, `["a","b"]`},
 		{"map", map[string]int{"x": 1}, `{"x":1}`},
+
+		// Typed slices (fast path)
+		{"int slice", []int{1, 2}, `[1,2]`},
+		{"uint64 slice", []uint64{18446744073709551615}, `[18446744073709551615]`},
+		{"duration slice", []time.Duration{time.Second, 2 * time.Millisecond}, `["1s","2ms"]`},
+		{"bool slice", []bool{true}, `[true]`},
+
+		// Lazy stringer
+		{"stringer value", StringerValue{Stringer: time.Minute}, "1m0s"},
 	}
 
 	for _, tt := range tests {
@@ -291,3 +301,64 @@ func BenchmarkNeedsQuoting(b *testing.B) {
 		NeedsQuoting(s)
 	}
 }
+
+func TestDedupFields(t *testing.T) {
+	keys := func(fields []Field) string {
+		var parts []string
+		for _, f := range fields {
+			parts = append(parts, fmt.Sprintf("%s=%v", f.Key, f.Value))
+		}
+		return strings.Join(parts, ",")
+	}
+	many := make([]Field, 0, 40)
+	for i := 0; i < 20; i++ {
+		many = append(many, Field{Key: fmt.Sprintf("k%d", i), Value: i})
+	}
+	many = append(many, Field{Key: "k3", Value: "again"})
+
+	tests := []struct {
+		name      string
+		fields    []Field
+		keepFirst bool
+		want      string
+		dups      int
+	}{
+		{"unique", []Field{{Key: "a", Value: 1}, {Key: "b", Value: 2}}, false, "a=1,b=2", 0},
+		{"last wins", []Field{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "a", Value: 3}}, false, "b=2,a=3", 1},
+		{"first wins", []Field{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "a", Value: 3}}, true, "a=1,b=2", 1},
+		{"triple", []Field{{Key: "a", Value: 1}, {Key: "a", Value: 2}, {Key: "a", Value: 3}}, false, "a=3", 2},
+		{"map last wins", many, false, "", 1},
+		{"map first wins", many, true, "", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dups := 0
+			got := DedupFields(tt.fields, tt.keepFirst, func(string) { dups++ })
+			if tt.want != "" && keys(got) != tt.want {
+				t.Errorf("got %s, want %s", keys(got), tt.want)
+			}
+			if dups != tt.dups || len(got) != len(tt.fields)-tt.dups {
+				t.Errorf("got %d fields and %d duplicates", len(got), dups)
+			}
+		})
+	}
+
+	got := DedupFields(many, false, nil)
+	if got[len(got)-1].Value != "again" {
+		t.Errorf("last wins should keep the later k3, got %v", got[len(got)-1])
+	}
+	got = DedupFields(many, true, nil)
+	if got[3].Value != 3 || len(got) != 20 {
+		t.Errorf("first wins should keep the earlier k3, got %v", got[3])
+	}
+}
+
+func TestDedupFieldsNoAllocWhenUnique(t *testing.T) {
+	fields := []Field{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}}
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = DedupFields(fields, false, nil)
+	})
+	if allocs != 0 {
+		t.Errorf("DedupFields allocated %.1f times for unique keys", allocs)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build !windows
+
+package internal
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// OpenFIFO opens the named pipe at path for writing without blocking. It
+// fails with ENXIO while no reader has the pipe open, instead of waiting
+// for one as a plain open does.
+func OpenFIFO(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if info.Mode()&os.ModeNamedPipe == 0 {
+		f.Close()
+		return nil, fmt.Errorf("%s is not a named pipe", path)
+	}
+	return f, nil
+}
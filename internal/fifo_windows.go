@@ -0,0 +1,13 @@
+//go:build windows
+
+package internal
+
+import (
+	"errors"
+	"os"
+)
+
+// OpenFIFO is not supported on Windows, which has no POSIX named pipes.
+func OpenFIFO(path string) (*os.File, error) {
+	return nil, errors.New("named pipes (FIFOs) are not supported on windows")
+}
@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"runtime"
+	"strings"
+)
+
+// NormalizeMessage replaces the variable parts of a log message with "?"
+// so messages that differ only in IDs group together: every word (a run of
+// letters, digits, '-' and '_') containing a digit, such as numbers, UUIDs
+// and hex IDs, and the contents of double-quoted strings.
+func NormalizeMessage(msg string) string {
+	var b strings.Builder
+	b.Grow(len(msg))
+	for i := 0; i < len(msg); {
+		c := msg[i]
+		switch {
+		case c == '"':
+			end := strings.IndexByte(msg[i+1:], '"')
+			if end < 0 {
+				b.WriteByte(c)
+				i++
+				continue
+			}
+			b.WriteString(`"?"`)
+			i += end + 2
+		case isWordByte(c):
+			j, digit := i, false
+			for j < len(msg) && isWordByte(msg[j]) {
+				digit = digit || msg[j] >= '0' && msg[j] <= '9'
+				j++
+			}
+			if digit {
+				b.WriteByte('?')
+			} else {
+				b.WriteString(msg[i:j])
+			}
+			i = j
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+func isWordByte(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-' || c == '_'
+}
+
+// UserFunction returns the name of the innermost function on the calling
+// goroutine's stack that is not part of the dd module, or "" if there is
+// none. Functions in _test.go files count as user code.
+func UserFunction() string {
+	var pcs [32]uintptr
+	n := runtime.Callers(2, pcs[:])
+	prefix := getDDPackagePrefix()
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" && (!isModuleFunction(frame.Function, prefix) || strings.HasSuffix(frame.File, "_test.go")) {
+			return frame.Function
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// isModuleFunction reports whether fn belongs to a package of the module
+// with the given path.
+func isModuleFunction(fn, module string) bool {
+	rest, ok := strings.CutPrefix(fn, module)
+	return ok && rest != "" && (rest[0] == '.' || rest[0] == '/')
+}
@@ -0,0 +1,29 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeMessage(t *testing.T) {
+	tests := []struct{ input, want string }{
+		{"user 42 not found", "user ? not found"},
+		{"order 7f3c9a2e-1b4d-4c8e-9f00-123456789abc failed", "order ? failed"},
+		{`file "a/b.txt" missing`, `file "?" missing`},
+		{`unterminated "quote 12`, `unterminated "quote ?`},
+		{"no variable parts", "no variable parts"},
+		{"retry-after: end-to-end req_9f2 at 10:42", "retry-after: end-to-end ? at ?:?"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeMessage(tt.input); got != tt.want {
+			t.Errorf("NormalizeMessage(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestUserFunction(t *testing.T) {
+	if fn := UserFunction(); !strings.HasSuffix(fn, "TestUserFunction") {
+		t.Errorf("UserFunction() = %q", fn)
+	}
+}
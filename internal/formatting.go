@@ -149,28 +149,103 @@ type cachedTimeEntry struct {
 // timeCache stores cached formatted timestamp for high-frequency logging.
 // Uses atomic pointer for lock-free reads with better cache locality.
 // Caches the formatted string within the same second to reduce time formatting overhead.
+// Formats with sub-second digits and sub-second epochs bypass the cache.
 type timeCache struct {
 	current    atomic.Pointer[cachedTimeEntry] // Atomic pointer to current cache entry
 	timeFormat string                          // Time format string (immutable after creation)
+	now        func() time.Time                // Time source (immutable after creation)
+	location   *time.Location                  // Time zone (nil keeps the clock's zone)
+	precision  TimePrecision                   // Sub-second digits or epoch unit
+	epoch      bool                            // Emit Unix epoch numbers instead of formatted strings
+	cacheable  bool                            // Whether a value is stable for a whole second
 }
 
-// newTimeCache creates a new time cache with the given format
-func newTimeCache(timeFormat string) *timeCache {
+// timeCacheOptions holds the optional timestamp settings of a timeCache.
+type timeCacheOptions struct {
+	location  *time.Location
+	precision TimePrecision
+	epoch     bool
+}
+
+// newTimeCache creates a new time cache with the given format.
+// A nil now uses time.Now.
+func newTimeCache(timeFormat string, now func() time.Time, opts timeCacheOptions) *timeCache {
+	if now == nil {
+		now = time.Now
+	}
 	tc := &timeCache{
-		timeFormat: timeFormat,
+		timeFormat: ApplyTimePrecision(timeFormat, opts.precision),
+		now:        now,
+		location:   opts.location,
+		precision:  opts.precision,
+		epoch:      opts.epoch,
+	}
+	if tc.epoch {
+		tc.cacheable = tc.precision.fractionDigits() == 0
+	} else {
+		tc.cacheable = !hasSubSecondLayout(tc.timeFormat)
 	}
 	// Initialize with zero entry to avoid nil checks
 	tc.current.Store(&cachedTimeEntry{sec: -1, formatted: ""})
 	return tc
 }
 
+// currentTime returns the clock time in the configured location.
+func (tc *timeCache) currentTime() time.Time {
+	now := tc.now()
+	if tc.location != nil {
+		now = now.In(tc.location)
+	}
+	return now
+}
+
+// format renders t as a formatted string or decimal epoch.
+func (tc *timeCache) format(t time.Time) string {
+	if tc.epoch {
+		return strconv.FormatInt(tc.precision.epoch(t), 10)
+	}
+	return t.Format(tc.timeFormat)
+}
+
+// timestampValue returns the current timestamp for JSON output: an int64
+// for epoch timestamps, otherwise the formatted string.
+func (tc *timeCache) timestampValue() any {
+	return tc.timestampValueAt(time.Time{})
+}
+
+// timestampValueAt is like timestampValue but returns at, if not zero.
+func (tc *timeCache) timestampValueAt(at time.Time) any {
+	if tc.epoch {
+		if at.IsZero() {
+			at = tc.now()
+		}
+		return tc.precision.epoch(at)
+	}
+	return tc.formattedTimeAt(at)
+}
+
+// formattedTimeAt returns at formatted in the configured location, or the
+// formatted current time if at is zero.
+func (tc *timeCache) formattedTimeAt(at time.Time) string {
+	if at.IsZero() {
+		return tc.getFormattedTime()
+	}
+	if tc.location != nil {
+		at = at.In(tc.location)
+	}
+	return tc.format(at)
+}
+
 // getFormattedTime returns the formatted current time.
 // Uses lock-free atomic operations for better concurrency performance.
 // Cache hit path is completely lock-free with no mutex contention.
 // SECURITY: Uses Compare-And-Swap to ensure atomic updates and prevent
 // race conditions that could cause inconsistent timestamp formatting.
 func (tc *timeCache) getFormattedTime() string {
-	now := time.Now()
+	now := tc.currentTime()
+	if !tc.cacheable {
+		return tc.format(now)
+	}
 	currentSec := now.Unix()
 
 	// Fast path: atomic load to check cache (completely lock-free)
@@ -183,7 +258,7 @@ func (tc *timeCache) getFormattedTime() string {
 	// SECURITY: Use CAS loop to ensure only one goroutine updates the cache
 	// This prevents race conditions where multiple goroutines format the same second
 	// with slightly different nanosecond offsets
-	formatted := now.Format(tc.timeFormat)
+	formatted := tc.format(now)
 	newEntry := &cachedTimeEntry{
 		sec:       currentSec,
 		formatted: formatted,
@@ -226,6 +301,19 @@ type FormatterConfig struct {
 	FullPath      bool
 	DynamicCaller bool
 	JSON          *JSONOptions
+	Text          *TextOptions
+	Console       *ConsoleOptions
+	// GlobalFields are added to the fields of every entry; fields logged
+	// with the entry override them.
+	GlobalFields []Field
+	// Now returns the entry timestamp (nil uses time.Now).
+	Now func() time.Time
+	// TimeLocation converts timestamps to a time zone (nil keeps the clock's zone).
+	TimeLocation *time.Location
+	// TimePrecision sets sub-second digits, or the unit of epoch timestamps.
+	TimePrecision TimePrecision
+	// TimeEpoch emits Unix epoch numbers instead of formatted timestamps.
+	TimeEpoch bool
 }
 
 // MessageFormatter handles formatting of log messages.
@@ -237,12 +325,20 @@ type MessageFormatter struct {
 	includeLevel  bool
 	fullPath      bool
 	dynamicCaller bool
+	stackMode     StackTraceMode
+	humanDur      bool
+	textValues    textValueOptions
+	contPrefix    string
+	console       *ConsoleOptions
 	// Cached JSON options to avoid repeated allocations
 	jsonOpts *JSONOptions
 	// Cached merged field names to avoid allocations during logging
 	cachedFieldNames *JSONFieldNames
 	// Time cache for reducing time formatting overhead
 	timeCache *timeCache
+	// Global fields and their pre-rendered text form
+	globalFields []Field
+	globalText   string
 }
 
 // NewMessageFormatter creates a new MessageFormatter with the given configuration.
@@ -254,15 +350,49 @@ func NewMessageFormatter(config *FormatterConfig) *MessageFormatter {
 		includeLevel:  config.IncludeLevel,
 		fullPath:      config.FullPath,
 		dynamicCaller: config.DynamicCaller,
-		timeCache:     newTimeCache(config.TimeFormat),
+		timeCache: newTimeCache(config.TimeFormat, config.Now, timeCacheOptions{
+			location:  config.TimeLocation,
+			precision: config.TimePrecision,
+			epoch:     config.TimeEpoch,
+		}),
+	}
+
+	if config.Text != nil {
+		mf.stackMode = config.Text.StackTrace
+		mf.humanDur = config.Text.HumanDurations
+		mf.textValues = textValueOptions{
+			floatPrecision: config.Text.FloatPrecision,
+			timeLayout:     config.Text.TimeLayout,
+			boolAsInt:      config.Text.BoolAsInt,
+		}
+		mf.contPrefix = config.Text.ContinuationPrefix
+	}
+	if config.Console != nil {
+		console := *config.Console
+		mf.console = &console
+	}
+
+	if len(config.GlobalFields) > 0 {
+		mf.globalFields = make([]Field, len(config.GlobalFields))
+		copy(mf.globalFields, config.GlobalFields)
+		mf.globalText = FormatFields(mf.textFields(mf.globalFields))
 	}
 
 	// Pre-compute JSON options to avoid allocations during logging
 	if config.JSON != nil {
 		mf.jsonOpts = &JSONOptions{
-			PrettyPrint: config.JSON.PrettyPrint,
-			Indent:      config.JSON.Indent,
-			FieldNames:  config.JSON.FieldNames,
+			PrettyPrint:   config.JSON.PrettyPrint,
+			Indent:        config.JSON.Indent,
+			FieldNames:    config.JSON.FieldNames,
+			FieldOrder:    config.JSON.FieldOrder,
+			FlattenFields: config.JSON.FlattenFields,
+			OmitEmpty:     config.JSON.OmitEmpty,
+			LevelNames:    config.JSON.LevelNames,
+			StaticFields:  config.JSON.StaticFields,
+			SingleLine:    config.JSON.SingleLine,
+			Strict:        config.JSON.Strict,
+			Ints:          config.JSON.Ints,
+			NonFinite:     config.JSON.NonFinite,
 		}
 		// Pre-merge field names at creation time
 		mf.cachedFieldNames = MergeWithDefaults(config.JSON.FieldNames)
@@ -373,6 +503,25 @@ func (f *MessageFormatter) formatArgToString(arg any) string {
 
 // FormatWithMessage formats a complete log message with level, caller, and fields.
 func (f *MessageFormatter) FormatWithMessage(level LogLevel, callerDepth int, message string, fields []Field) string {
+	// One extra frame for this function.
+	return f.FormatWithMessageAt(time.Time{}, level, callerDepth+1, message, fields)
+}
+
+// FormatWithMessageAt is like FormatWithMessage but stamps the entry with
+// at instead of the clock time when at is not zero.
+func (f *MessageFormatter) FormatWithMessageAt(at time.Time, level LogLevel, callerDepth int, message string, fields []Field) string {
+	// One extra frame for this function.
+	return f.formatAt(at, level, callerDepth+1, message, false, fields)
+}
+
+// FormatEventAt is like FormatWithMessageAt for an event, an entry without
+// a message: JSON output omits the message key.
+func (f *MessageFormatter) FormatEventAt(at time.Time, level LogLevel, callerDepth int, fields []Field) string {
+	// One extra frame for this function.
+	return f.formatAt(at, level, callerDepth+1, "", true, fields)
+}
+
+func (f *MessageFormatter) formatAt(at time.Time, level LogLevel, callerDepth int, message string, event bool, fields []Field) string {
 	// Adjust caller depth if dynamic detection is enabled
 	if f.dynamicCaller {
 		callerDepth = f.adjustCallerDepth(callerDepth)
@@ -380,13 +529,23 @@ func (f *MessageFormatter) FormatWithMessage(level LogLevel, callerDepth int, me
 
 	switch f.format {
 	case LogFormatJSON:
-		return f.formatJSON(level, callerDepth, message, fields)
+		opts := f.getJSONOptions()
+		entry := f.formatJSON(at, level, callerDepth, message, event, fields)
+		if opts.SingleLine {
+			entry = SingleLineJSON(entry)
+		}
+		if opts.Strict {
+			entry = StrictJSON(entry)
+		}
+		return entry
+	case LogFormatConsole:
+		return f.formatConsole(at, level, callerDepth, message, fields)
 	default:
-		return f.formatText(level, callerDepth, message, fields)
+		return f.formatText(at, level, callerDepth, message, fields)
 	}
 }
 
-func (f *MessageFormatter) formatText(level LogLevel, callerDepth int, message string, fields []Field) string {
+func (f *MessageFormatter) formatText(at time.Time, level LogLevel, callerDepth int, message string, fields []Field) string {
 	// Pre-calculate capacity to reduce memory allocations
 	// Base: timestamp (~35) + level (7) + brackets (2) + caller (~30) + message + fields
 	estimatedLen := 64 + len(message) + len(fields)*EstimatedFieldSize
@@ -421,7 +580,7 @@ func (f *MessageFormatter) formatText(level LogLevel, callerDepth int, message s
 
 		// Add timestamp (using cached time for performance)
 		if f.includeTime {
-			buf.WriteString(f.timeCache.getFormattedTime())
+			buf.WriteString(f.timeCache.formattedTimeAt(at))
 		}
 
 		// Add level with alignment (5 character width, left-padded with spaces)
@@ -450,25 +609,69 @@ func (f *MessageFormatter) formatText(level LogLevel, callerDepth int, message s
 		}
 	}
 
-	// Add message
+	// Add message, keeping any continuation lines for after the fields
 	if buf.Len() > 0 {
 		buf.WriteByte(' ')
 	}
+	continuation := ""
+	if f.contPrefix != "" {
+		var ok bool
+		if message, continuation, ok = strings.Cut(message, "\n"); ok {
+			message = strings.TrimSuffix(message, "\r")
+		}
+		message = strings.ReplaceAll(message, "\r", `\r`)
+	}
 	buf.WriteString(message)
 
-	// Add fields
+	// Add global fields not overridden by the entry's fields
+	if f.globalText != "" {
+		if globalText := f.globalTextFor(fields); globalText != "" {
+			buf.WriteByte(' ')
+			buf.WriteString(globalText)
+		}
+	}
+
+	// Add fields, splitting stack traces off into a block unless inlined
+	var stackBlocks []stackBlock
 	if len(fields) > 0 {
-		if fieldsStr := FormatFields(fields); fieldsStr != "" {
+		if f.stackMode != StackTraceInline {
+			fields, stackBlocks = extractStackFields(fields, f.stackMode)
+		}
+		if fieldsStr := FormatFields(f.textFields(fields)); fieldsStr != "" {
 			buf.WriteByte(' ')
 			buf.WriteString(fieldsStr)
 		}
 	}
 
+	if continuation != "" {
+		writeContinuation(buf, continuation, f.contPrefix)
+	}
+	if len(stackBlocks) > 0 {
+		prefix := stackBlockIndent
+		if f.contPrefix != "" {
+			prefix = f.contPrefix
+		}
+		writeStackBlocks(buf, stackBlocks, prefix)
+	}
+
 	return buf.String()
 }
 
-func (f *MessageFormatter) formatJSON(level LogLevel, callerDepth int, message string, fields []Field) string {
+func (f *MessageFormatter) formatJSON(at time.Time, level LogLevel, callerDepth int, message string, event bool, fields []Field) string {
 	fieldNames := f.getJSONFieldNames()
+	opts := f.getJSONOptions()
+
+	globalFields, err := opts.jsonNumbers(f.globalFields)
+	if err == nil {
+		fields, err = opts.jsonNumbers(fields)
+	}
+	if err != nil {
+		return marshalErrorJSON(err)
+	}
+
+	if opts.ordered() {
+		return f.formatJSONOrdered(at, level, callerDepth, message, event, globalFields, fields, fieldNames, opts)
+	}
 
 	// Use pooled entry map for better performance
 	entryPtr := jsonEntryMapPool.Get().(*map[string]any)
@@ -479,12 +682,12 @@ func (f *MessageFormatter) formatJSON(level LogLevel, callerDepth int, message s
 
 	// Add timestamp if enabled (using cached time for performance)
 	if f.includeTime {
-		entry[fieldNames.Timestamp] = f.timeCache.getFormattedTime()
+		entry[fieldNames.Timestamp] = f.timeCache.timestampValueAt(at)
 	}
 
 	// Add level if enabled
 	if f.includeLevel {
-		entry[fieldNames.Level] = level.String()
+		entry[fieldNames.Level] = opts.levelName(level)
 	}
 
 	// Add caller if enabled
@@ -494,17 +697,22 @@ func (f *MessageFormatter) formatJSON(level LogLevel, callerDepth int, message s
 		}
 	}
 
-	// Add message
-	entry[fieldNames.Message] = message
+	// Add message; events have none
+	if !event {
+		entry[fieldNames.Message] = message
+	}
 
 	// Add structured fields if present
 	var fieldsMapPtr *map[string]any
-	fieldsCount := len(fields)
+	fieldsCount := len(fields) + len(globalFields)
 	if fieldsCount > 0 {
 		// Use pooled fields map
 		fieldsMapPtr = jsonFieldsMapPool.Get().(*map[string]any)
 		fieldsMap := *fieldsMapPtr
 		clear(fieldsMap)
+		for _, field := range globalFields {
+			fieldsMap[field.Key] = field.Value
+		}
 		for _, field := range fields {
 			fieldsMap[field.Key] = field.Value
 		}
@@ -512,7 +720,7 @@ func (f *MessageFormatter) formatJSON(level LogLevel, callerDepth int, message s
 	}
 
 	// Format JSON
-	result := FormatJSON(entry, f.getJSONOptions())
+	result := FormatJSON(entry, opts)
 
 	// SECURITY: Clean up and return maps to pool
 	// For large maps, clear and discard to prevent sensitive data retention
@@ -536,6 +744,50 @@ func (f *MessageFormatter) formatJSON(level LogLevel, callerDepth int, message s
 	return result
 }
 
+// globalTextFor returns the text form of the global fields whose keys are
+// not among fields. Only an override needs a new rendering.
+func (f *MessageFormatter) globalTextFor(fields []Field) string {
+	overridden := false
+	for _, g := range f.globalFields {
+		if hasFieldKey(fields, g.Key) {
+			overridden = true
+			break
+		}
+	}
+	if !overridden {
+		return f.globalText
+	}
+
+	kept := make([]Field, 0, len(f.globalFields))
+	for _, g := range f.globalFields {
+		if !hasFieldKey(fields, g.Key) {
+			kept = append(kept, g)
+		}
+	}
+	return FormatFields(f.textFields(kept))
+}
+
+// textFields applies the TextOptions value rendering to fields.
+func (f *MessageFormatter) textFields(fields []Field) []Field {
+	if f.humanDur {
+		fields = humanizeDurations(fields)
+	}
+	if f.textValues.enabled() {
+		fields = coerceTextValues(fields, f.textValues)
+	}
+	return fields
+}
+
+// hasFieldKey reports whether fields contain a field with the given key.
+func hasFieldKey(fields []Field, key string) bool {
+	for _, field := range fields {
+		if field.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
 // getJSONFieldNames returns the cached JSON field names configuration.
 // Field names are pre-merged at formatter creation time to avoid allocations.
 func (f *MessageFormatter) getJSONFieldNames() *JSONFieldNames {
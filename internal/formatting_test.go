@@ -285,7 +285,7 @@ func TestFormatWithMessageDynamicCaller(t *testing.T) {
 }
 
 func TestTimeCache(t *testing.T) {
-	tc := newTimeCache(time.RFC3339)
+	tc := newTimeCache(time.RFC3339, nil, timeCacheOptions{})
 
 	// First call - should format time
 	result1 := tc.getFormattedTime()
@@ -0,0 +1,28 @@
+package internal
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGoroutineID(t *testing.T) {
+	id := GoroutineID()
+	if id == 0 {
+		t.Fatal("GoroutineID should not return 0")
+	}
+	if again := GoroutineID(); again != id {
+		t.Errorf("GoroutineID changed within a goroutine: %d then %d", id, again)
+	}
+
+	var other uint64
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		other = GoroutineID()
+	}()
+	wg.Wait()
+	if other == 0 || other == id {
+		t.Errorf("another goroutine should have a different ID, got %d and %d", id, other)
+	}
+}
@@ -0,0 +1,165 @@
+package internal
+
+import (
+	"strconv"
+	"time"
+)
+
+// ByteSize is a byte count field value. Text output renders it with IEC
+// units ("4.2 MiB"); JSON output keeps the number.
+type ByteSize int64
+
+var byteUnits = [...]string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// String formats b with one decimal in the largest unit that keeps the
+// value at or above 1, e.g. "512 B", "1.5 KiB", "4.2 MiB".
+func (b ByteSize) String() string {
+	n := int64(b)
+	sign := ""
+	if n < 0 {
+		sign = "-"
+		n = -n
+	}
+	if n < 1024 {
+		return sign + strconv.FormatInt(n, 10) + " B"
+	}
+
+	v := float64(n)
+	unit := 0
+	for v >= 1024 && unit < len(byteUnits)-1 {
+		v /= 1024
+		unit++
+	}
+	// 1023.96 KiB would print as "1024.0 KiB"
+	if v >= 1023.95 && unit < len(byteUnits)-1 {
+		v /= 1024
+		unit++
+	}
+	s := strconv.FormatFloat(v, 'f', 1, 64)
+	if len(s) > 2 && s[len(s)-2:] == ".0" {
+		s = s[:len(s)-2]
+	}
+	return sign + s + " " + byteUnits[unit]
+}
+
+// HumanDuration rounds d to three significant digits, or whole seconds
+// from a minute up: 1.253459s becomes "1.25s" and 350.2ms becomes "350ms".
+func HumanDuration(d time.Duration) string {
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+	var unit time.Duration
+	switch {
+	case abs < time.Microsecond:
+		return d.String()
+	case abs < time.Millisecond:
+		unit = time.Microsecond
+	case abs < time.Second:
+		unit = time.Millisecond
+	case abs < time.Minute:
+		unit = time.Second
+	default:
+		return d.Round(time.Second).String()
+	}
+
+	precision := unit / 100
+	switch {
+	case abs >= 100*unit:
+		precision = unit
+	case abs >= 10*unit:
+		precision = unit / 10
+	}
+	return d.Round(precision).String()
+}
+
+// humanizeDurations returns fields with Duration values replaced by their
+// HumanDuration form. The input slice is returned unchanged when it holds
+// no durations.
+func humanizeDurations(fields []Field) []Field {
+	var out []Field
+	for i, field := range fields {
+		var value any
+		switch v := field.Value.(type) {
+		case time.Duration:
+			value = HumanDuration(v)
+		case []time.Duration:
+			strs := make([]string, len(v))
+			for j, d := range v {
+				strs[j] = HumanDuration(d)
+			}
+			value = strs
+		default:
+			if out != nil {
+				out = append(out, field)
+			}
+			continue
+		}
+		if out == nil {
+			out = make([]Field, i, len(fields))
+			copy(out, fields[:i])
+		}
+		out = append(out, Field{Key: field.Key, Value: value})
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// textValueOptions is the float, time and bool rendering of TextOptions.
+type textValueOptions struct {
+	floatPrecision int
+	timeLayout     string
+	boolAsInt      bool
+}
+
+func (o textValueOptions) enabled() bool {
+	return o.floatPrecision > 0 || o.timeLayout != "" || o.boolAsInt
+}
+
+// coerceTextValues returns fields with float, time.Time and bool values
+// rendered as opts asks. Like humanizeDurations, the input slice is
+// returned unchanged when no value is affected.
+func coerceTextValues(fields []Field, opts textValueOptions) []Field {
+	var out []Field
+	for i, field := range fields {
+		var value any
+		switch v := field.Value.(type) {
+		case float64:
+			if opts.floatPrecision > 0 {
+				value = strconv.FormatFloat(v, 'f', opts.floatPrecision, 64)
+			}
+		case float32:
+			if opts.floatPrecision > 0 {
+				value = strconv.FormatFloat(float64(v), 'f', opts.floatPrecision, 32)
+			}
+		case time.Time:
+			if opts.timeLayout != "" {
+				value = v.Format(opts.timeLayout)
+			}
+		case bool:
+			if opts.boolAsInt {
+				value = 0
+				if v {
+					value = 1
+				}
+			}
+		}
+		if value == nil {
+			if out != nil {
+				out = append(out, field)
+			}
+			continue
+		}
+		if out == nil {
+			out = make([]Field, i, len(fields))
+			copy(out, fields[:i])
+		}
+		out = append(out, Field{Key: field.Key, Value: value})
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
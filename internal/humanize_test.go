@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestByteSizeString(t *testing.T) {
+	tests := []struct {
+		in   ByteSize
+		want string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1024, "1 KiB"},
+		{1536, "1.5 KiB"},
+		{4404019, "4.2 MiB"},
+		{1048575, "1 MiB"},
+		{-2048, "-2 KiB"},
+		{1 << 40, "1 TiB"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("ByteSize(%d) = %q, want %q", int64(tt.in), got, tt.want)
+		}
+	}
+}
+
+func TestHumanDuration(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{500 * time.Nanosecond, "500ns"},
+		{1234 * time.Nanosecond, "1.23µs"},
+		{350200 * time.Microsecond, "350ms"},
+		{12345678 * time.Nanosecond, "12.3ms"},
+		{1253459 * time.Microsecond, "1.25s"},
+		{-1253459 * time.Microsecond, "-1.25s"},
+		{83500 * time.Millisecond, "1m24s"},
+	}
+	for _, tt := range tests {
+		if got := HumanDuration(tt.in); got != tt.want {
+			t.Errorf("HumanDuration(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHumanizeDurationsKeepsSlice(t *testing.T) {
+	fields := []Field{{Key: "a", Value: 1}}
+	if got := humanizeDurations(fields); &got[0] != &fields[0] {
+		t.Error("fields without durations should be returned unchanged")
+	}
+	got := humanizeDurations([]Field{{Key: "a", Value: 1}, {Key: "d", Value: 1500 * time.Microsecond}})
+	if got[0].Value != 1 || got[1].Value != "1.5ms" {
+		t.Errorf("unexpected fields: %v", got)
+	}
+}
+
+func TestCoerceTextValuesKeepsSlice(t *testing.T) {
+	opts := textValueOptions{floatPrecision: 1, boolAsInt: true}
+	fields := []Field{{Key: "a", Value: 1}, {Key: "at", Value: time.Time{}}}
+	if got := coerceTextValues(fields, opts); &got[0] != &fields[0] {
+		t.Error("fields without affected values should be returned unchanged")
+	}
+	got := coerceTextValues([]Field{{Key: "a", Value: 1}, {Key: "f", Value: float32(2.26)}, {Key: "b", Value: false}}, opts)
+	if got[0].Value != 1 || got[1].Value != "2.3" || got[2].Value != 0 {
+		t.Errorf("unexpected fields: %v", got)
+	}
+}
@@ -4,9 +4,15 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 // jsonEncoderPool pools json.Encoder objects for JSON encoding.
@@ -107,7 +113,7 @@ func FormatJSON(entry map[string]any, opts *JSONOptions) string {
 	}
 
 	// Use standard encoder for pretty print
-	if opts.PrettyPrint {
+	if opts.pretty() {
 		return formatJSONStandard(entry, opts)
 	}
 
@@ -197,6 +203,9 @@ func writeJSONValueFastWithDepth(buf *bytes.Buffer, v any, depth int) bool {
 	case int64:
 		buf.WriteString(strconv.FormatInt(val, 10))
 		return true
+	case ByteSize:
+		buf.WriteString(strconv.FormatInt(int64(val), 10))
+		return true
 	case int32:
 		buf.WriteString(strconv.FormatInt(int64(val), 10))
 		return true
@@ -243,6 +252,24 @@ func writeJSONValueFastWithDepth(buf *bytes.Buffer, v any, depth int) bool {
 	case time.Duration:
 		writeJSONString(buf, val.String())
 		return true
+	case StringerValue:
+		writeJSONString(buf, val.String())
+		return true
+	case ObjectValue:
+		// Ordered nested object - recurse with depth tracking
+		buf.WriteByte('{')
+		for i, field := range val {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSONString(buf, field.Key)
+			buf.WriteByte(':')
+			if !writeJSONValueFastWithDepth(buf, field.Value, depth+1) {
+				return false
+			}
+		}
+		buf.WriteByte('}')
+		return true
 	case map[string]any:
 		// Nested map - recurse with depth tracking
 		buf.WriteByte('{')
@@ -293,6 +320,28 @@ func writeJSONValueFastWithDepth(buf *bytes.Buffer, v any, depth int) bool {
 		}
 		buf.WriteByte(']')
 		return true
+	case []uint64:
+		// Fast path for uint64 slices
+		buf.WriteByte('[')
+		for i, n := range val {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(strconv.FormatUint(n, 10))
+		}
+		buf.WriteByte(']')
+		return true
+	case []time.Duration:
+		// Durations render as strings, matching scalar Duration fields
+		buf.WriteByte('[')
+		for i, d := range val {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSONString(buf, d.String())
+		}
+		buf.WriteByte(']')
+		return true
 	case []float64:
 		// Fast path for float64 slices
 		buf.WriteByte('[')
@@ -398,14 +447,14 @@ func formatJSONStandard(entry map[string]any, opts *JSONOptions) string {
 	}()
 
 	// Reset encoder settings (escape HTML is already false from pool init)
-	if opts.PrettyPrint {
+	if opts.pretty() {
 		pe.enc.SetIndent("", opts.Indent)
 	} else {
 		pe.enc.SetIndent("", "") // Reset indent for non-pretty mode
 	}
 
 	if err := pe.enc.Encode(entry); err != nil {
-		return fmt.Sprintf(`{"error":"json marshal failed: %v"}`, err)
+		return marshalErrorJSON(err)
 	}
 
 	// Get bytes and convert to string
@@ -418,3 +467,408 @@ func formatJSONStandard(entry map[string]any, opts *JSONOptions) string {
 	// Convert to string - this is the only allocation in the hot path
 	return string(data)
 }
+
+// formatJSONOrdered builds the entry as an ObjectValue so key order is
+// deterministic, applying the FieldOrder, FlattenFields and OmitEmpty options.
+func (f *MessageFormatter) formatJSONOrdered(at time.Time, level LogLevel, callerDepth int, message string, event bool, globalFields, fields []Field, names *JSONFieldNames, opts *JSONOptions) string {
+	entry := make(ObjectValue, 0, 4+len(fields))
+	if f.includeTime {
+		entry = append(entry, Field{Key: names.Timestamp, Value: f.timeCache.timestampValueAt(at)})
+	}
+	if f.includeLevel {
+		entry = append(entry, Field{Key: names.Level, Value: opts.levelName(level)})
+	}
+	if f.dynamicCaller {
+		if callerInfo := GetCaller(callerDepth, f.fullPath); callerInfo != "" {
+			entry = append(entry, Field{Key: names.Caller, Value: callerInfo})
+		}
+	}
+	if !event {
+		entry = append(entry, Field{Key: names.Message, Value: message})
+	}
+	entry = append(entry, opts.StaticFields...)
+
+	if len(globalFields) > 0 {
+		fields = append(globalFields[:len(globalFields):len(globalFields)], fields...)
+	}
+	fields = orderFields(fields, opts)
+	if opts.FlattenFields {
+		standard := len(entry)
+		for _, field := range fields {
+			key := field.Key
+			for _, std := range entry[:standard] {
+				if std.Key == key {
+					key = names.Fields + "." + key
+					break
+				}
+			}
+			entry = append(entry, Field{Key: key, Value: field.Value})
+		}
+	} else if len(fields) > 0 {
+		entry = append(entry, Field{Key: names.Fields, Value: ObjectValue(fields)})
+	}
+
+	data, err := entry.MarshalJSON()
+	if err != nil {
+		return marshalErrorJSON(err)
+	}
+	if opts.pretty() {
+		var pretty bytes.Buffer
+		if json.Indent(&pretty, data, "", opts.Indent) == nil {
+			return pretty.String()
+		}
+	}
+	return string(data)
+}
+
+// orderFields drops empty keys (and empty values with OmitEmpty), keeps the
+// last value for duplicate keys at the position of the first, and sorts by
+// key for JSONOrderSorted. The input slice is not modified.
+func orderFields(fields []Field, opts *JSONOptions) []Field {
+	out := make([]Field, 0, len(fields))
+	for _, field := range fields {
+		if field.Key == "" || (opts.OmitEmpty && isEmptyValue(field.Value)) {
+			continue
+		}
+		replaced := false
+		for i := range out {
+			if out[i].Key == field.Key {
+				out[i].Value = field.Value
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			out = append(out, field)
+		}
+	}
+	if opts.FieldOrder == JSONOrderSorted {
+		sort.SliceStable(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	}
+	return out
+}
+
+// isEmptyValue reports whether v is a zero value for OmitEmpty purposes.
+func isEmptyValue(v any) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		return val == ""
+	case bool:
+		return !val
+	case int:
+		return val == 0
+	case int64:
+		return val == 0
+	case int32:
+		return val == 0
+	case uint64:
+		return val == 0
+	case float64:
+		return val == 0
+	case time.Duration:
+		return val == 0
+	case time.Time:
+		return val.IsZero()
+	case ObjectValue:
+		return len(val) == 0
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return rv.IsNil()
+	default:
+		return rv.IsZero()
+	}
+}
+
+// marshalErrorJSON returns the entry written in place of one that could not
+// be encoded.
+func marshalErrorJSON(err error) string {
+	var buf bytes.Buffer
+	buf.WriteString(`{"error":`)
+	writeJSONString(&buf, "json marshal failed: "+err.Error())
+	buf.WriteByte('}')
+	return buf.String()
+}
+
+// SingleLineJSON returns s with its raw line breaks removed: inside strings
+// they are escaped, elsewhere they are insignificant whitespace and
+// dropped.
+func SingleLineJSON(s string) string {
+	if !strings.ContainsAny(s, "\n\r") {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s) + 8)
+	inString, escaped := false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\n' || c == '\r':
+			if inString {
+				if c == '\n' {
+					b.WriteString(`\n`)
+				} else {
+					b.WriteString(`\r`)
+				}
+			}
+			escaped = false
+			continue
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// StrictJSON returns s, an encoded entry, as valid JSON in valid UTF-8:
+// invalid UTF-8 bytes are replaced with \ufffd escapes and U+2028 and
+// U+2029 with theirs. If s is still not valid JSON, it returns an error
+// entry holding s as a string, so the result is valid for any input.
+func StrictJSON(s string) string {
+	if !utf8.ValidString(s) || strings.ContainsAny(s, "\u2028\u2029") {
+		var b strings.Builder
+		b.Grow(len(s) + 16)
+		for i := 0; i < len(s); {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			switch {
+			case r == utf8.RuneError && size == 1:
+				b.WriteString(`\ufffd`)
+			case r == '\u2028':
+				b.WriteString(`\u2028`)
+			case r == '\u2029':
+				b.WriteString(`\u2029`)
+			default:
+				b.WriteString(s[i : i+size])
+			}
+			i += size
+		}
+		s = b.String()
+	}
+	if json.Valid([]byte(s)) {
+		return s
+	}
+	buf := []byte(`{"error":"invalid JSON entry","entry":`)
+	buf = AppendJSONString(buf, s)
+	return string(append(buf, '}'))
+}
+
+// AppendJSONString appends s to dst as a JSON string that is valid whatever
+// s holds. It escapes like the encoder, including <, > and &, and also
+// replaces invalid UTF-8 bytes with \ufffd and escapes U+2028 and U+2029,
+// which break JavaScript consumers.
+func AppendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch c {
+			case '"':
+				dst = append(dst, `\"`...)
+			case '\\':
+				dst = append(dst, `\\`...)
+			case '\n':
+				dst = append(dst, `\n`...)
+			case '\r':
+				dst = append(dst, `\r`...)
+			case '\t':
+				dst = append(dst, `\t`...)
+			case '<', '>', '&':
+				dst = append(dst, `\u00`...)
+				dst = append(dst, hexChars[c>>4], hexChars[c&0xf])
+			default:
+				if c < 0x20 {
+					dst = append(dst, `\u00`...)
+					dst = append(dst, hexChars[c>>4], hexChars[c&0xf])
+				} else {
+					dst = append(dst, c)
+				}
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			dst = append(dst, `\ufffd`...)
+		case r == '\u2028':
+			dst = append(dst, `\u2028`...)
+		case r == '\u2029':
+			dst = append(dst, `\u2029`...)
+		default:
+			dst = append(dst, s[i:i+size]...)
+		}
+		i += size
+	}
+	return append(dst, '"')
+}
+
+// EscapingCorpus returns inputs that have broken log encoders: quotes,
+// backslashes and line breaks, control and format characters, invalid and
+// truncated UTF-8, surrogate encodings and JSON-like fragments.
+func EscapingCorpus() []string {
+	return []string{
+		"",
+		"plain",
+		`quote " inside`,
+		`back\slash \" \\`,
+		"line\nbreak\r\n",
+		"tab\tand\x00nul\x1f\x7f",
+		"\x1b[31mansi\x1b[0m",
+		"<script>&amp;</script>",
+		"\u2028line\u2029paragraph",
+		"\u200b\u202e\ufeffformat",
+		"\xff\xfe invalid",
+		"truncated \xe2\x82",
+		"\xed\xa0\x80 surrogate",
+		"\xc0\xaf overlong",
+		"emoji 😀 and 日本語",
+		`{"key":"value"}`,
+		`"},{"injected":true`,
+		`\u0000 \ud800`,
+		"key=value other=\"x\"",
+	}
+}
+
+// maxSafeJSONInt is the largest integer a float64 represents exactly.
+const maxSafeJSONInt = 1<<53 - 1
+
+// jsonNumbers rewrites field values for the Ints and NonFinite options.
+// It returns fields itself, without allocating, when no value changes.
+func (o *JSONOptions) jsonNumbers(fields []Field) ([]Field, error) {
+	var out []Field
+	for i, field := range fields {
+		v, changed, err := o.jsonNumber(field.Value)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", field.Key, err)
+		}
+		if !changed {
+			continue
+		}
+		if out == nil {
+			out = slices.Clone(fields)
+		}
+		out[i].Value = v
+	}
+	if out == nil {
+		return fields, nil
+	}
+	return out, nil
+}
+
+// jsonNumber returns the value to encode in place of v and whether it
+// differs from v.
+func (o *JSONOptions) jsonNumber(v any) (any, bool, error) {
+	switch val := v.(type) {
+	case float64:
+		return o.jsonFloat(val)
+	case float32:
+		return o.jsonFloat(float64(val))
+	case int:
+		return o.jsonInt(int64(val))
+	case int64:
+		return o.jsonInt(val)
+	case uint:
+		return o.jsonUint(uint64(val))
+	case uint64:
+		return o.jsonUint(val)
+	case []float64:
+		return jsonNumberSlice(o, val)
+	case []int:
+		return jsonNumberSlice(o, val)
+	case []int64:
+		return jsonNumberSlice(o, val)
+	case []uint64:
+		return jsonNumberSlice(o, val)
+	case ObjectValue:
+		fields, err := o.jsonNumbers(val)
+		if err != nil {
+			return nil, false, err
+		}
+		return ObjectValue(fields), len(val) > 0 && &fields[0] != &val[0], nil
+	}
+	return v, false, nil
+}
+
+// jsonNumberSlice applies jsonNumber to each element, returning a []any
+// only if an element changes.
+func jsonNumberSlice[T any](o *JSONOptions, values []T) (any, bool, error) {
+	var out []any
+	for i, v := range values {
+		nv, changed, err := o.jsonNumber(v)
+		if err != nil {
+			return nil, false, err
+		}
+		if !changed {
+			if out != nil {
+				out[i] = v
+			}
+			continue
+		}
+		if out == nil {
+			out = make([]any, len(values))
+			for j := range i {
+				out[j] = values[j]
+			}
+		}
+		out[i] = nv
+	}
+	if out == nil {
+		return values, false, nil
+	}
+	return out, true, nil
+}
+
+// jsonFloat applies the NonFinite option.
+func (o *JSONOptions) jsonFloat(f float64) (any, bool, error) {
+	if !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return f, false, nil
+	}
+	var policy JSONNonFinite
+	if o != nil {
+		policy = o.NonFinite
+	}
+	switch policy {
+	case JSONNonFiniteNull:
+		return nil, true, nil
+	case JSONNonFiniteString:
+		switch {
+		case math.IsNaN(f):
+			return "NaN", true, nil
+		case f > 0:
+			return "+Inf", true, nil
+		default:
+			return "-Inf", true, nil
+		}
+	default:
+		return nil, false, fmt.Errorf("unsupported float value %v", f)
+	}
+}
+
+// jsonInt applies the Ints option to a signed integer.
+func (o *JSONOptions) jsonInt(n int64) (any, bool, error) {
+	if o == nil || o.Ints == JSONIntsNumber ||
+		(o.Ints == JSONIntsUnsafeAsString && n >= -maxSafeJSONInt && n <= maxSafeJSONInt) {
+		return n, false, nil
+	}
+	return strconv.FormatInt(n, 10), true, nil
+}
+
+// jsonUint applies the Ints option to an unsigned integer.
+func (o *JSONOptions) jsonUint(n uint64) (any, bool, error) {
+	if o == nil || o.Ints == JSONIntsNumber || (o.Ints == JSONIntsUnsafeAsString && n <= maxSafeJSONInt) {
+		return n, false, nil
+	}
+	return strconv.FormatUint(n, 10), true, nil
+}
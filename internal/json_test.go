@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 )
 
 func TestLevelToString(t *testing.T) {
@@ -248,3 +250,170 @@ newlines`
 		t.Error("Message with special characters not preserved correctly")
 	}
 }
+
+func TestFormatJSONTypedSlices(t *testing.T) {
+	entry := map[string]any{
+		"ids":   []uint64{1, 2},
+		"waits": []time.Duration{time.Second},
+		"lazy":  StringerValue{Stringer: time.Minute},
+	}
+	result, ok := formatJSONFast(entry)
+	if !ok {
+		t.Fatal("typed slices should use the fast path")
+	}
+	for _, want := range []string{`"ids":[1,2]`, `"waits":["1s"]`, `"lazy":"1m0s"`} {
+		if !strings.Contains(result, want) {
+			t.Errorf("formatJSONFast() = %s, missing %s", result, want)
+		}
+	}
+}
+
+func TestResolveLazyValues(t *testing.T) {
+	plain := []Field{{Key: "a", Value: 1}}
+	if got := ResolveLazyValues(plain); &got[0] != &plain[0] {
+		t.Error("fields without lazy values should be returned unchanged")
+	}
+
+	fields := []Field{{Key: "a", Value: 1}, {Key: "d", Value: StringerValue{Stringer: time.Second}}}
+	got := ResolveLazyValues(fields)
+	if got[1].Value != "1s" {
+		t.Errorf("resolved value = %v, want 1s", got[1].Value)
+	}
+	if _, ok := fields[1].Value.(StringerValue); !ok {
+		t.Error("input slice should not be modified")
+	}
+}
+
+func TestObjectValueMarshalJSON(t *testing.T) {
+	obj := ObjectValue{{Key: "z", Value: 1}, {Key: "a", Value: struct{ N int }{2}}}
+	data, err := json.Marshal(map[string]any{"obj": obj})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"obj":{"z":1,"a":{"N":2}}}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestFormatJSONOrdered(t *testing.T) {
+	fields := []Field{
+		{Key: "zeta", Value: 1},
+		{Key: "empty", Value: ""},
+		{Key: "alpha", Value: []string{}},
+		{Key: "message", Value: "clash"},
+		{Key: "zeta", Value: 2},
+	}
+
+	tests := []struct {
+		name string
+		opts JSONOptions
+		want string
+	}{
+		{
+			name: "insertion",
+			opts: JSONOptions{FieldOrder: JSONOrderInsertion},
+			want: `{"level":"INFO","message":"hi","fields":{"zeta":2,"empty":"","alpha":[],"message":"clash"}}`,
+		},
+		{
+			name: "sorted",
+			opts: JSONOptions{FieldOrder: JSONOrderSorted},
+			want: `{"level":"INFO","message":"hi","fields":{"alpha":[],"empty":"","message":"clash","zeta":2}}`,
+		},
+		{
+			name: "flatten omit empty",
+			opts: JSONOptions{FlattenFields: true, OmitEmpty: true},
+			want: `{"level":"INFO","message":"hi","zeta":2,"fields.message":"clash"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			formatter := NewMessageFormatter(&FormatterConfig{
+				Format:       LogFormatJSON,
+				IncludeLevel: true,
+				JSON:         &opts,
+			})
+			got := formatter.FormatWithMessage(LevelInfo, 0, "hi", fields)
+			if got != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("pretty", func(t *testing.T) {
+		formatter := NewMessageFormatter(&FormatterConfig{
+			Format: LogFormatJSON,
+			JSON:   &JSONOptions{FieldOrder: JSONOrderInsertion, PrettyPrint: true, Indent: "  "},
+		})
+		got := formatter.FormatWithMessage(LevelInfo, 0, "hi", []Field{{Key: "b", Value: 1}, {Key: "a", Value: 2}})
+		want := "{\n  \"message\": \"hi\",\n  \"fields\": {\n    \"b\": 1,\n    \"a\": 2\n  }\n}"
+		if got != want {
+			t.Errorf("got  %s\nwant %s", got, want)
+		}
+	})
+}
+
+func TestIsEmptyValue(t *testing.T) {
+	empty := []any{nil, "", 0, false, int64(0), 0.0, time.Duration(0), time.Time{}, []int{}, map[string]int{}, (*int)(nil), uint8(0)}
+	for _, v := range empty {
+		if !isEmptyValue(v) {
+			t.Errorf("isEmptyValue(%#v) = false, want true", v)
+		}
+	}
+	nonEmpty := []any{"x", 1, true, []int{0}, time.Now(), struct{ A int }{1}}
+	for _, v := range nonEmpty {
+		if isEmptyValue(v) {
+			t.Errorf("isEmptyValue(%#v) = true, want false", v)
+		}
+	}
+}
+
+func TestSingleLineJSON(t *testing.T) {
+	tests := []struct{ input, want string }{
+		{`{"a":1}`, `{"a":1}`},
+		{"{\n  \"a\": \"x\\\"\ny\"\r\n}", `{  "a": "x\"\ny"}`},
+		{"{\"a\":\"\\\\\"\n}", `{"a":"\\"}`},
+	}
+	for _, tt := range tests {
+		if got := SingleLineJSON(tt.input); got != tt.want {
+			t.Errorf("SingleLineJSON(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestStrictJSON(t *testing.T) {
+	tests := []struct{ input, want string }{
+		{`{"a":"b"}`, `{"a":"b"}`},
+		{"{\"a\":\"\xff\"}", `{"a":"\ufffd"}`},
+		{"{\"a\u2028\":\"\u2029\"}", `{"a\u2028":"\u2029"}`},
+		{`{"a":`, `{"error":"invalid JSON entry","entry":"{\"a\":"}`},
+		{"{\xff:1}", `{"error":"invalid JSON entry","entry":"{\\ufffd:1}"}`},
+	}
+	for _, tt := range tests {
+		got := StrictJSON(tt.input)
+		if got != tt.want {
+			t.Errorf("StrictJSON(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+		if !json.Valid([]byte(got)) || !utf8.ValidString(got) {
+			t.Errorf("StrictJSON(%q) = %q is not valid JSON", tt.input, got)
+		}
+	}
+}
+
+func TestAppendJSONString(t *testing.T) {
+	for _, s := range EscapingCorpus() {
+		data := AppendJSONString(nil, s)
+		if !utf8.Valid(data) || strings.ContainsAny(string(data), "\n\r\u2028\u2029<>&") {
+			t.Errorf("AppendJSONString(%q) = %s", s, data)
+		}
+		var got string
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Errorf("AppendJSONString(%q) = %s: %v", s, data, err)
+			continue
+		}
+		// Each invalid byte becomes U+FFFD, as in a []rune conversion
+		if want := string([]rune(s)); got != want {
+			t.Errorf("AppendJSONString(%q) decodes to %q, want %q", s, got, want)
+		}
+	}
+}
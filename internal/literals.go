@@ -0,0 +1,154 @@
+package internal
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxRequiredLiterals bounds the literal set kept for one pattern; larger
+// alternations are not worth checking one literal at a time.
+const maxRequiredLiterals = 16
+
+// RequiredLiterals returns a set of literals, folded with FoldString, such
+// that every match of re contains at least one of them. It returns nil when
+// no such set can be derived (for example for patterns made only of
+// character classes), in which case the pattern must always be run.
+//
+// Callers use it as a pre-filter: if the folded input contains none of the
+// literals, the pattern cannot match and the regex need not be run.
+func RequiredLiterals(re *regexp.Regexp) []string {
+	if re == nil {
+		return nil
+	}
+	parsed, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	return requiredLiterals(parsed.Simplify())
+}
+
+func requiredLiterals(re *syntax.Regexp) []string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		if len(re.Rune) == 0 {
+			return nil
+		}
+		return []string{FoldString(string(re.Rune))}
+
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+
+	case syntax.OpRepeat:
+		if re.Min < 1 {
+			return nil
+		}
+		return requiredLiterals(re.Sub[0])
+
+	case syntax.OpConcat:
+		// Every part must match, so the most selective part's set will do.
+		var best []string
+		for _, sub := range re.Sub {
+			if lits := requiredLiterals(sub); betterLiterals(lits, best) {
+				best = lits
+			}
+		}
+		return best
+
+	case syntax.OpAlternate:
+		// Any branch may match, so every branch must contribute.
+		var union []string
+		for _, sub := range re.Sub {
+			lits := requiredLiterals(sub)
+			if lits == nil {
+				return nil
+			}
+			union = append(union, lits...)
+			if len(union) > maxRequiredLiterals {
+				return nil
+			}
+		}
+		return union
+
+	default:
+		return nil
+	}
+}
+
+// betterLiterals reports whether a is a more selective literal set than b:
+// a longer shortest literal, then fewer literals.
+func betterLiterals(a, b []string) bool {
+	if a == nil {
+		return false
+	}
+	if b == nil {
+		return true
+	}
+	minA, minB := shortestLen(a), shortestLen(b)
+	if minA != minB {
+		return minA > minB
+	}
+	return len(a) < len(b)
+}
+
+func shortestLen(lits []string) int {
+	n := len(lits[0])
+	for _, lit := range lits[1:] {
+		if len(lit) < n {
+			n = len(lit)
+		}
+	}
+	return n
+}
+
+// FoldString maps every rune of s to the smallest rune it is equivalent to
+// under Unicode simple case folding, so two strings that match
+// case-insensitively fold to the same string.
+func FoldString(s string) string {
+	ascii := true
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		// In ASCII the smallest rune of each case orbit is the upper case one.
+		// 'k' and 's' also fold with non-ASCII runes, but those are larger.
+		return strings.ToUpper(s)
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		b.WriteRune(foldRune(r))
+	}
+	return b.String()
+}
+
+// foldRune returns the smallest rune in the simple case folding orbit of r.
+func foldRune(r rune) rune {
+	min := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < min {
+			min = f
+		}
+	}
+	return min
+}
+
+// ContainsAnyLiteral reports whether folded (a FoldString result) contains
+// any of the literals. A nil literal set always reports true.
+func ContainsAnyLiteral(folded string, literals []string) bool {
+	if literals == nil {
+		return true
+	}
+	for _, lit := range literals {
+		if strings.Contains(folded, lit) {
+			return true
+		}
+	}
+	return false
+}
@@ -13,53 +13,104 @@ import (
 // PatternDefinition represents a regex pattern for sensitive data detection.
 type PatternDefinition struct {
 	Pattern string
-	Basic   bool // Included in basic filter
+	Basic   bool   // Included in basic filter
+	Pack    string // Name of the pattern pack the pattern belongs to
+}
+
+// Built-in pattern pack names.
+const (
+	PackCredentials = "credentials"
+	PackPCI         = "pci"
+	PackPHI         = "phi"
+	PackNetwork     = "network"
+	PackIdentifiers = "identifiers"
+	PackInjection   = "injection"
+	PackUUID        = "uuid"
+)
+
+// PatternPack describes a named group of built-in patterns.
+type PatternPack struct {
+	Name        string
+	Description string
+	// Version is incremented whenever the pack's patterns change.
+	Version int
+	// Default reports whether the pack is part of the full filter.
+	Default bool
+}
+
+// PatternPacks lists the built-in pattern packs.
+var PatternPacks = []PatternPack{
+	{PackCredentials, "Passwords, API keys, tokens, private keys and connection strings", 1, true},
+	{PackPCI, "Payment card numbers, CVV codes, SWIFT/BIC codes and IBANs", 1, true},
+	{PackPHI, "Health identifiers (ICD-10, NPI, MRN, HICN) and biometric data", 1, true},
+	{PackNetwork, "IPv4 and IPv6 addresses, hosts and service endpoints", 1, true},
+	{PackIdentifiers, "Phone numbers, email addresses and national ID and tax numbers", 1, true},
+	{PackInjection, "JNDI lookups and suspicious protocol URLs (Log4Shell)", 1, true},
+	{PackUUID, "UUID-shaped identifiers", 1, false},
+}
+
+// LookupPatternPack returns the pack with the given name.
+func LookupPatternPack(name string) (PatternPack, bool) {
+	for _, pack := range PatternPacks {
+		if pack.Name == name {
+			return pack, true
+		}
+	}
+	return PatternPack{}, false
+}
+
+// CompiledPattern is a compiled built-in pattern.
+type CompiledPattern struct {
+	Regexp   *regexp.Regexp
+	Basic    bool
+	Pack     string
+	Literals []string // see RequiredLiterals
 }
 
 // AllPatterns is the centralized registry of all security patterns.
 var AllPatterns = []PatternDefinition{
 	// Credit card and SSN patterns
-	{`\b[0-9]{4}[- ]?[0-9]{4}[- ]?[0-9]{4}[- ]?[0-9]{3,7}\b`, true},
-	{`\b[0-9]{3}-[0-9]{2}-[0-9]{4}\b`, true},
-	{`(?i)((?:credit[_-]?card|card)[\s:=]+)[0-9]{13,19}\b`, true},
+	{`\b[0-9]{4}[- ]?[0-9]{4}[- ]?[0-9]{4}[- ]?[0-9]{3,7}\b`, true, PackPCI},
+	{`\b[0-9]{3}-[0-9]{2}-[0-9]{4}\b`, true, PackIdentifiers},
+	{`(?i)((?:credit[_-]?card|card)[\s:=]+)[0-9]{13,19}\b`, true, PackPCI},
 	// Credentials and secrets
-	{`(?i)((?:password|passwd|pwd|secret)[\s:=]+)[^\s]{1,128}\b`, true},
-	{`(?i)((?:token|api[_-]?key|bearer)[\s:=]+)[^\s]{1,256}\b`, true},
-	{`\beyJ[A-Za-z0-9_-]{10,100}\.eyJ[A-Za-z0-9_-]{10,100}\.[A-Za-z0-9_-]{10,100}\b`, false},
-	{`-----BEGIN[^-]{1,20}PRIVATE\s+KEY-----[A-Za-z0-9+/=\s]{1,4000}-----END[^-]{1,20}PRIVATE\s+KEY-----`, true},
+	{`(?i)((?:password|passwd|pwd|secret)[\s:=]+)[^\s]{1,128}\b`, true, PackCredentials},
+	{`(?i)((?:token|api[_-]?key|bearer)[\s:=]+)[^\s]{1,256}\b`, true, PackCredentials},
+	{`\beyJ[A-Za-z0-9_-]{10,100}\.eyJ[A-Za-z0-9_-]{10,100}\.[A-Za-z0-9_-]{10,100}\b`, false, PackCredentials},
+	{`-----BEGIN[^-]{1,20}PRIVATE\s+KEY-----[A-Za-z0-9+/=\s]{1,4000}-----END[^-]{1,20}PRIVATE\s+KEY-----`, true, PackCredentials},
 	// API keys
 	// Merged AWS Access Key patterns (AKIA for permanent, ASIA for temporary)
-	{`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`, true},
-	{`\bAIza[A-Za-z0-9_-]{35}\b`, false},
-	{`\bsk-[A-Za-z0-9]{16,48}\b`, true},
+	{`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`, true, PackCredentials},
+	{`\bAIza[A-Za-z0-9_-]{35}\b`, false, PackCredentials},
+	{`\bsk-[A-Za-z0-9]{16,48}\b`, true, PackCredentials},
 	// Email - only in full filter mode to avoid false positives on user@host format
-	{`\b[A-Za-z0-9._%+-]{1,64}@[A-Za-z0-9.-]{1,253}\.[A-Za-z]{2,6}\b`, false},
+	{`\b[A-Za-z0-9._%+-]{1,64}@[A-Za-z0-9.-]{1,253}\.[A-Za-z]{2,6}\b`, false, PackIdentifiers},
 	// IP addresses
-	{`\b(?:[0-9]{1,3}\.){3}[0-9]{1,3}\b`, false},
+	{`\b(?:[0-9]{1,3}\.){3}[0-9]{1,3}\b`, false, PackNetwork},
 	// IPv6 addresses (full and compressed formats)
-	{`\b(?:[0-9a-fA-F]{1,4}:){7}[0-9a-fA-F]{1,4}\b`, false},                               // Full IPv6
-	{`\b(?:[0-9a-fA-F]{1,4}:){1,7}:\b`, false},                                            // Trailing ::
-	{`\b::(?:[0-9a-fA-F]{1,4}:){0,5}[0-9a-fA-F]{1,4}\b`, false},                           // Leading ::
-	{`\b(?:[0-9a-fA-F]{1,4}:){1,4}::(?:[0-9a-fA-F]{1,4}:){0,3}[0-9a-fA-F]{1,4}\b`, false}, // Mixed :: in middle
-	{`\b(?:[0-9a-fA-F]{1,4}:){1,5}::[0-9a-fA-F]{1,4}\b`, false},                           // :: with 5 groups before
-	{`\b(?:[0-9a-fA-F]{1,4}:){1,6}::\b`, false},                                           // :: with 6 groups before
-	{`\b::(?:[0-9a-fA-F]{1,4}:){1,6}[0-9a-fA-F]{1,4}\b`, false},                           // :: with groups after
-	{`\b(?:[0-9a-fA-F]{1,4}:){6}(?:[0-9]{1,3}\.){3}[0-9]{1,3}\b`, false},                  // IPv6 with IPv4 suffix
+	{`\b(?:[0-9a-fA-F]{1,4}:){7}[0-9a-fA-F]{1,4}\b`, false, PackNetwork},                               // Full IPv6
+	{`\b(?:[0-9a-fA-F]{1,4}:){1,7}:\b`, false, PackNetwork},                                            // Trailing ::
+	{`\b::(?:[0-9a-fA-F]{1,4}:){0,5}[0-9a-fA-F]{1,4}\b`, false, PackNetwork},                           // Leading ::
+	{`\b(?:[0-9a-fA-F]{1,4}:){1,4}::(?:[0-9a-fA-F]{1,4}:){0,3}[0-9a-fA-F]{1,4}\b`, false, PackNetwork}, // Mixed :: in middle
+	{`\b(?:[0-9a-fA-F]{1,4}:){1,5}::[0-9a-fA-F]{1,4}\b`, false, PackNetwork},                           // :: with 5 groups before
+	{`\b(?:[0-9a-fA-F]{1,4}:){1,6}::\b`, false, PackNetwork},                                           // :: with 6 groups before
+	{`\b::(?:[0-9a-fA-F]{1,4}:){1,6}[0-9a-fA-F]{1,4}\b`, false, PackNetwork},                           // :: with groups after
+	{`\b(?:[0-9a-fA-F]{1,4}:){6}(?:[0-9]{1,3}\.){3}[0-9]{1,3}\b`, false, PackNetwork},                  // IPv6 with IPv4 suffix
 	// Database connection strings - preserve protocol name
-	{`(?i)((?:mysql|postgresql|mongodb|redis|sqlite|cassandra|influx|cockroach|timescale|postgres)://)[^\s]{1,200}\b`, true},
+	{`(?i)((?:mysql|postgresql|mongodb|redis|sqlite|cassandra|influx|cockroach|timescale|postgres)://)[^\s]{1,200}\b`, true, PackCredentials},
 	// JDBC connection strings - preserve jdbc:prefix
-	{`(?i)((?:jdbc:)(?:mysql|postgresql|sqlserver|oracle|mongodb|redis|cassandra)://)[^\s]{1,200}\b`, false},
-	{`(?i)((?:server|data source|host)[\s=:]+)[^\s;]{1,200}(?:;|\s|$)`, false},
-	{`(?i)((?:oracle|tns|sid)[\s=:]+)[^\s]{1,100}\b`, false},
-	{`(?i)(?:[\w.-]+:[\w.-]+@)(?:[\w.-]+|\([^\)]+\))(?::\d+)?(?:/[\w.-]+)?`, false},
+	{`(?i)((?:jdbc:)(?:mysql|postgresql|sqlserver|oracle|mongodb|redis|cassandra)://)[^\s]{1,200}\b`, false, PackCredentials},
+	{`(?i)((?:server|data source|host)[\s=:]+)[^\s;]{1,200}(?:;|\s|$)`, false, PackNetwork},
+	{`(?i)((?:oracle|tns|sid)[\s=:]+)[^\s]{1,100}\b`, false, PackNetwork},
+	{`(?i)(?:[\w.-]+:[\w.-]+@)(?:[\w.-]+|\([^\)]+\))(?::\d+)?(?:/[\w.-]+)?`, false, PackCredentials},
 	// Phone numbers - global patterns
-	{`(?i)((?:phone|mobile|tel|telephone|cell|cellular|fax|contact|number)[\s:=]+)[\+]?[(]?\d{1,4}[)]?[-\s.]?\(?\d{1,4}\)?[-\s.]?\d{1,9}[-\s.]?\d{0,9}\b`, true},
-	{`\+\d{1,3}[- ]?\d{6,14}\b`, true},                          // International: +XXXXXXXXXXXX (7-15 digits after +)
-	{`\+[\d\s\-\(\)]{7,20}\b`, true},                            // International phone with + and formatting (7-20 chars total, bounded)
-	{`\b00[1-9]\d{6,14}\b`, true},                               // 00 prefix international (8-16 digits total)
-	{`\b(?:\(\d{3}\)\s?|\d{3}[-.\s])\d{3}[-.\s]?\d{4}\b`, true}, // NANP with required separator: (415) 555-2671 or 415-555-2671
-	{`\b\d{3,5}[- ]\d{4,8}\b`, false},                           // Phone numbers with separators (7-13 digits total) - moved to full filter to avoid false positives on dates
-	{`\b0\d{3,5}[- ]?\d{4,8}\b`, true},                          // Starting with 0 and separators (10+ digits total)
+	{`(?i)((?:phone|mobile|tel|telephone|cell|cellular|fax|contact|number)[\s:=]+)[\+]?[(]?\d{1,4}[)]?[-\s.]?\(?\d{1,4}\)?[-\s.]?\d{1,9}[-\s.]?\d{0,9}\b`, true, PackIdentifiers},
+	{`\+\d{1,3}[- ]?\d{6,14}\b`, true, PackIdentifiers},                          // International: +XXXXXXXXXXXX (7-15 digits after +)
+	{`\+[\d\s\-\(\)]{7,20}\b`, true, PackIdentifiers},                            // International phone with + and formatting (7-20 chars total, bounded)
+	{`\b00[1-9]\d{6,14}\b`, true, PackIdentifiers},                               // 00 prefix international (8-16 digits total)
+	{`\b(?:\(\d{3}\)\s?|\d{3}[-.\s])\d{3}[-.\s]?\d{4}\b`, true, PackIdentifiers}, // NANP with required separator: (415) 555-2671 or 415-555-2671
+	{`\b\d{3,5}[- ]\d{4,8}\b`, false, PackIdentifiers},                           // Phone numbers with separators (7-13 digits total) - moved to full filter to avoid false positives on dates
+	{`\b0\d{3,5}[- ]?\d{4,8}\b`, true, PackIdentifiers},                          // Starting with 0 and separators (10+ digits total)
 
 	// ===== Enterprise Patterns =====
 
@@ -67,99 +118,107 @@ var AllPatterns = []PatternDefinition{
 	// SWIFT/BIC codes (8 or 11 characters: BBBBCCLLbbb)
 	// BBBB = bank code (4 letters), CC = country code (2 letters), LL = location code (2 alphanumeric), bbb = branch code (optional 3 alphanumeric)
 	// Context-aware pattern to reduce false positives - requires context keywords like "swift", "bic", "bank"
-	{`(?i)(?:swift|bic|bank[_-]?code|iban)[\s:=]+[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}(?:[A-Z0-9]{3})?\b`, true},
+	{`(?i)(?:swift|bic|bank[_-]?code|iban)[\s:=]+[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}(?:[A-Z0-9]{3})?\b`, true, PackPCI},
 	// IBAN (International Bank Account Number) - generic pattern
-	{`\b[A-Z]{2}[0-9]{2}[A-Z0-9]{4}[0-9]{7,30}\b`, false},
+	{`\b[A-Z]{2}[0-9]{2}[A-Z0-9]{4}[0-9]{7,30}\b`, false, PackPCI},
 	// CVV/CVC codes with context
-	{`(?i)(?:cvv|cvc|cv2|security[_-]?code|card[_-]?verification)[\s:=]+[0-9]{3,4}\b`, true},
+	{`(?i)(?:cvv|cvc|cv2|security[_-]?code|card[_-]?verification)[\s:=]+[0-9]{3,4}\b`, true, PackPCI},
 
 	// Healthcare (HIPAA compliance)
 	// ICD-10 Diagnosis codes with medical context (e.g., "diagnosis: A12.3", "icd10: S72.0")
 	// Requires context keywords to reduce false positives from generic codes
-	{`(?i)(?:icd[-_]?10?|diagnosis|diag|dx|diagnostic[_-]?code|clinical[_-]?code)[\s:=]+[A-Z][0-9]{2}(?:\.[0-9A-Z]{1,4})?\b`, true},
+	{`(?i)(?:icd[-_]?10?|diagnosis|diag|dx|diagnostic[_-]?code|clinical[_-]?code)[\s:=]+[A-Z][0-9]{2}(?:\.[0-9A-Z]{1,4})?\b`, true, PackPHI},
 	// US National Provider Identifier (NPI) - 10 digits starting with 1 or 2
 	// Context-aware pattern to reduce false positives from random 10-digit numbers
-	{`(?i)(?:npi|national[_-]?provider[_-]?identifier|provider[_-]?id)[\s:=]+[12][0-9]{9}\b`, true},
+	{`(?i)(?:npi|national[_-]?provider[_-]?identifier|provider[_-]?id)[\s:=]+[12][0-9]{9}\b`, true, PackPHI},
 	// Medical Record Numbers (MRN) with context
-	{`(?i)(?:mrn|medical[_-]?record[_-]?number|patient[_-]?id|health[_-]?record)[\s:=]+[A-Za-z0-9]{6,20}\b`, true},
+	{`(?i)(?:mrn|medical[_-]?record[_-]?number|patient[_-]?id|health[_-]?record)[\s:=]+[A-Za-z0-9]{6,20}\b`, true, PackPHI},
 	// Health Insurance Claim Number (HICN) - Medicare format
-	{`\b[0-9]{9}[A-Z]{1,2}\b`, false},
+	{`\b[0-9]{9}[A-Z]{1,2}\b`, false, PackPHI},
 
 	// Government/Identity
 	// US Passport numbers (9 digits, or 8 digits for older)
-	{`(?i)(?:passport[_-]?number|passport[_-]?no|passport[_-]?id)[\s:=]+[0-9]{8,9}\b`, true},
+	{`(?i)(?:passport[_-]?number|passport[_-]?no|passport[_-]?id)[\s:=]+[0-9]{8,9}\b`, true, PackIdentifiers},
 	// US Driver's License with context (state-specific, generic)
-	{`(?i)(?:driver[_-]?license|dl[_-]?number|license[_-]?number|drivers[_-]?license)[\s:=]+[A-Za-z0-9]{5,20}\b`, true},
+	{`(?i)(?:driver[_-]?license|dl[_-]?number|license[_-]?number|drivers[_-]?license)[\s:=]+[A-Za-z0-9]{5,20}\b`, true, PackIdentifiers},
 	// US Tax ID / Employer Identification Number (EIN)
-	{`\b[0-9]{2}-[0-9]{7}\b`, false},
+	{`\b[0-9]{2}-[0-9]{7}\b`, false, PackIdentifiers},
 	// UK National Insurance Number
-	{`\b[A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z][0-9]{6}[A-D]\b`, false},
+	{`\b[A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z][0-9]{6}[A-D]\b`, false, PackIdentifiers},
 	// Canadian Social Insurance Number (SIN) - with context to avoid false positives on phone numbers
-	{`(?i)(?:sin|social[_-]?insurance[_-]?number|canadian[_-]?sin)[\s:=]+[0-9]{3}[- ]?[0-9]{3}[- ]?[0-9]{3}\b`, true},
+	{`(?i)(?:sin|social[_-]?insurance[_-]?number|canadian[_-]?sin)[\s:=]+[0-9]{3}[- ]?[0-9]{3}[- ]?[0-9]{3}\b`, true, PackIdentifiers},
 
 	// Cloud Provider Tokens
 	// GitHub tokens (merged: p=personal, o=oauth, u=user-to-server, s=server-to-server, r=refresh)
-	{`\b(?:ghp_|gho_|ghu_|ghs_|ghr_)[A-Za-z0-9]{36}\b`, true},
+	{`\b(?:ghp_|gho_|ghu_|ghs_|ghr_)[A-Za-z0-9]{36}\b`, true, PackCredentials},
 	// Slack tokens
-	{`\bxox[baprs]-[0-9]{10,13}-[0-9]{10,13}-[a-zA-Z0-9]{24}\b`, true},
+	{`\bxox[baprs]-[0-9]{10,13}-[0-9]{10,13}-[a-zA-Z0-9]{24}\b`, true, PackCredentials},
 	// Stripe keys (merged: sk=secret, rk=restricted, stk=connect token)
-	{`\b(?:sk|rk|stk)_live_[0-9a-zA-Z]{24,64}\b`, true},
+	{`\b(?:sk|rk|stk)_live_[0-9a-zA-Z]{24,64}\b`, true, PackCredentials},
 	// GCP Service Account (JSON key structure indicator)
-	{`"private_key"\s*:\s*"[^"]{100,4000}"`, true}, // Bounded max
+	{`"private_key"\s*:\s*"[^"]{100,4000}"`, true, PackCredentials}, // Bounded max
 	// Azure Connection String
-	{`(?i)(?:connection[_-]?string|connstr|azure[_-]?connection)[\s:=]+[^\s]{50,500}`, true},
+	{`(?i)(?:connection[_-]?string|connstr|azure[_-]?connection)[\s:=]+[^\s]{50,500}`, true, PackCredentials},
 	// Generic OAuth/Refresh tokens with context
-	{`(?i)(?:refresh[_-]?token|access[_-]?token|auth[_-]?token|bearer)[\s:=]+[A-Za-z0-9_\-\.]{20,256}\b`, true}, // Bounded max
+	{`(?i)(?:refresh[_-]?token|access[_-]?token|auth[_-]?token|bearer)[\s:=]+[A-Za-z0-9_\-\.]{20,256}\b`, true, PackCredentials}, // Bounded max
 
 	// ===== Log4Shell and JNDI Injection Patterns =====
 	// CVE-2021-44228 - Log4Shell vulnerability patterns
-	{`\$\{jndi:[^}]{0,200}\}`, true},                  // Basic JNDI lookup (bounded)
-	{`\$\{(?:lower|upper) *: *j[a-z]{0,10}\}`, false}, // Obfuscated JNDI (bounded)
-	{`\$\{[^}]{0,100}jndi[^}]{0,100}\}`, true},        // Any JNDI in expression (bounded)
+	{`\$\{jndi:[^}]{0,200}\}`, true, PackInjection},                  // Basic JNDI lookup (bounded)
+	{`\$\{(?:lower|upper) *: *j[a-z]{0,10}\}`, false, PackInjection}, // Obfuscated JNDI (bounded)
+	{`\$\{[^}]{0,100}jndi[^}]{0,100}\}`, true, PackInjection},        // Any JNDI in expression (bounded)
 	// Suspicious protocols in logs (potential JNDI/RMI/LDAP injection)
-	{`(?i)(?:ldap|ldaps|rmi|dns|iiop|corba)://[^\s]{1,200}`, false},
+	{`(?i)(?:ldap|ldaps|rmi|dns|iiop|corba)://[^\s]{1,200}`, false, PackInjection},
 
 	// ===== Modern Authentication Tokens =====
 	// Anthropic and OpenAI API keys (merged: sk-ant, sk-proj)
-	{`\bsk-(?:ant|proj)-[A-Za-z0-9_-]{32,128}\b`, true},
+	{`\bsk-(?:ant|proj)-[A-Za-z0-9_-]{32,128}\b`, true, PackCredentials},
 	// GitLab Personal Access Tokens
-	{`\bglpat-[A-Za-z0-9_-]{20,128}\b`, true}, // Bounded max
+	{`\bglpat-[A-Za-z0-9_-]{20,128}\b`, true, PackCredentials}, // Bounded max
 	// Google OAuth tokens
-	{`\b(?:ya29\.|1//)[A-Za-z0-9_\-\.]{20,256}\b`, true}, // Bounded max
+	{`\b(?:ya29\.|1//)[A-Za-z0-9_\-\.]{20,256}\b`, true, PackCredentials}, // Bounded max
 	// AWS STS Session Tokens
-	{`\bFwoGZXIvYXdz[ A-Za-z0-9/+=]{40,256}\b`, false}, // Bounded max
+	{`\bFwoGZXIvYXdz[ A-Za-z0-9/+=]{40,256}\b`, false, PackCredentials}, // Bounded max
 
 	// ===== Message Queue and Streaming =====
 	// RabbitMQ connection strings
-	{`(?i)(?:amqp|amqps)://[^\s]{1,200}\b`, true},
+	{`(?i)(?:amqp|amqps)://[^\s]{1,200}\b`, true, PackCredentials},
 	// NATS connection strings
-	{`(?i)nats://[^\s]{1,200}\b`, false},
+	{`(?i)nats://[^\s]{1,200}\b`, false, PackCredentials},
 	// Kafka connection strings (bootstrap servers)
-	{`(?i)(?:kafka|bootstrap[_-]?server)[\s:=]+[a-z0-9._-]+:\d{1,5}`, false},
+	{`(?i)(?:kafka|bootstrap[_-]?server)[\s:=]+[a-z0-9._-]+:\d{1,5}`, false, PackNetwork},
 
 	// ===== International Identifiers =====
 	// Australia ABN (Australian Business Number) - requires separator to avoid matching generic 11-digit numbers
-	{`\b\d{2}[- ]\d{3}[- ]?\d{3}[- ]?\d{3}\b`, false},
+	{`\b\d{2}[- ]\d{3}[- ]?\d{3}[- ]?\d{3}\b`, false, PackIdentifiers},
 	// New Zealand IRD (Inland Revenue Department) Number
-	{`\b\d{8,9}\b`, false},
+	{`\b\d{8,9}\b`, false, PackIdentifiers},
 	// Chile RUT (Rol Único Tributario)
-	{`\b\d{1,2}\.\d{3}\.\d{3}-[\dKk]\b`, false},
+	{`\b\d{1,2}\.\d{3}\.\d{3}-[\dKk]\b`, false, PackIdentifiers},
 	// Brazil CPF
-	{`\b\d{3}\.\d{3}\.\d{3}-\d{2}\b`, false},
+	{`\b\d{3}\.\d{3}\.\d{3}-\d{2}\b`, false, PackIdentifiers},
 	// Mexico RFC (Registro Federal de Contribuyentes)
-	{`\b[A-ZÑ&]{3,4}\d{6}[A-Z0-9]{3}\b`, false},
+	{`\b[A-ZÑ&]{3,4}\d{6}[A-Z0-9]{3}\b`, false, PackIdentifiers},
 
 	// ===== Biometric and Identity =====
 	// Fingerprint template identifiers
-	{`(?i)(?:fingerprint[_-]?template|fp[_-]?id)[\s:=]+[A-Za-z0-9_-]{10,128}\b`, true}, // Bounded max
+	{`(?i)(?:fingerprint[_-]?template|fp[_-]?id)[\s:=]+[A-Za-z0-9_-]{10,128}\b`, true, PackPHI}, // Bounded max
 	// Face recognition template identifiers
-	{`(?i)(?:face[_-]?template|face[_-]?id)[\s:=]+[A-Za-z0-9_-]{10,128}\b`, true}, // Bounded max
+	{`(?i)(?:face[_-]?template|face[_-]?id)[\s:=]+[A-Za-z0-9_-]{10,128}\b`, true, PackPHI}, // Bounded max
 	// Biometric data indicators
-	{`(?i)(?:biometric[_-]?data|bio[_-]?hash)[\s:=]+[A-Za-z0-9+/=]{20,256}\b`, true}, // Bounded max
+	{`(?i)(?:biometric[_-]?data|bio[_-]?hash)[\s:=]+[A-Za-z0-9+/=]{20,256}\b`, true, PackPHI}, // Bounded max
+
+	// ===== Optional Packs =====
+	// UUIDs - not part of the full filter since they are commonly used as
+	// request and entity IDs; enabled by SecurityLevelParanoid
+	{`\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`, false, PackUUID},
 }
 
 // Pre-compiled regex cache to avoid repeated compilation.
+// CompiledPatterns holds every built-in pattern; CompiledFullPatterns those
+// in default packs and CompiledBasicPatterns the basic subset of those.
 var (
+	CompiledPatterns      []CompiledPattern
 	CompiledFullPatterns  []*regexp.Regexp
 	CompiledBasicPatterns []*regexp.Regexp
 	PatternsOnce          sync.Once
@@ -169,6 +228,7 @@ var (
 // This is called once on first use to avoid startup overhead.
 func InitPatterns() {
 	PatternsOnce.Do(func() {
+		CompiledPatterns = make([]CompiledPattern, 0, len(AllPatterns))
 		CompiledFullPatterns = make([]*regexp.Regexp, 0, len(AllPatterns))
 		CompiledBasicPatterns = make([]*regexp.Regexp, 0, len(AllPatterns))
 
@@ -182,6 +242,15 @@ func InitPatterns() {
 				}
 				continue
 			}
+			CompiledPatterns = append(CompiledPatterns, CompiledPattern{
+				Regexp:   re,
+				Basic:    pd.Basic,
+				Pack:     pd.Pack,
+				Literals: RequiredLiterals(re),
+			})
+			if pack, _ := LookupPatternPack(pd.Pack); !pack.Default {
+				continue
+			}
 			CompiledFullPatterns = append(CompiledFullPatterns, re)
 			if pd.Basic {
 				CompiledBasicPatterns = append(CompiledBasicPatterns, re)
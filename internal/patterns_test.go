@@ -296,3 +296,14 @@ func TestExactMatchOnlyKeywords(t *testing.T) {
 		}
 	}
 }
+
+func TestPatternPacksAssigned(t *testing.T) {
+	for _, pd := range AllPatterns {
+		if _, ok := LookupPatternPack(pd.Pack); !ok {
+			t.Errorf("pattern %q has unknown pack %q", pd.Pattern, pd.Pack)
+		}
+	}
+	if _, ok := LookupPatternPack("missing"); ok {
+		t.Error("LookupPatternPack should not find unknown packs")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
 	"time"
@@ -185,52 +186,88 @@ func GetBackupPath(basePath string, index int, compress bool) string {
 }
 
 func CompressFile(filePath string) error {
+	_, _, err := CompressFileWith(filePath, GzipCodec(gzip.DefaultCompression))
+	return err
+}
+
+// Codec describes a compression format for CompressFileWith.
+type Codec struct {
+	Ext       string // appended to the compressed file name, e.g. ".gz"
+	NewWriter func(w io.Writer) (io.WriteCloser, error)
+	NewReader func(r io.Reader) (io.ReadCloser, error)
+}
+
+// GzipCodec returns the gzip Codec for a compress/gzip level.
+func GzipCodec(level int) Codec {
+	return Codec{
+		Ext: ".gz",
+		NewWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriterLevel(w, level)
+		},
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		},
+	}
+}
+
+// CompressFileWith compresses filePath to filePath+codec.Ext, verifies the
+// result and removes the source. It returns the uncompressed and
+// compressed sizes.
+func CompressFileWith(filePath string, codec Codec) (in, out int64, err error) {
 	src, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("open source: %w", err)
+		return 0, 0, fmt.Errorf("open source: %w", err)
 	}
 	defer src.Close()
 
-	tmpPath := filePath + ".gz.tmp"
+	tmpPath := filePath + codec.Ext + ".tmp"
 	dst, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, FilePermissions)
 	if err != nil {
-		return fmt.Errorf("create temp: %w", err)
+		return 0, 0, fmt.Errorf("create temp: %w", err)
 	}
 	defer dst.Close()
 
-	gw := gzip.NewWriter(dst)
-	defer gw.Close()
+	cw, err := codec.NewWriter(dst)
+	if err != nil {
+		os.Remove(tmpPath)
+		return 0, 0, fmt.Errorf("compressor: %w", err)
+	}
+	defer cw.Close()
 
-	if _, err := io.Copy(gw, src); err != nil {
-		return fmt.Errorf("copy data: %w", err)
+	if in, err = io.Copy(cw, src); err != nil {
+		return 0, 0, fmt.Errorf("copy data: %w", err)
 	}
 
-	if err := gw.Close(); err != nil {
-		return fmt.Errorf("gzip close: %w", err)
+	if err := cw.Close(); err != nil {
+		return 0, 0, fmt.Errorf("compressor close: %w", err)
 	}
 
 	if err := dst.Close(); err != nil {
-		return fmt.Errorf("dst close: %w", err)
+		return 0, 0, fmt.Errorf("dst close: %w", err)
 	}
 
 	if err := src.Close(); err != nil {
-		return fmt.Errorf("src close: %w", err)
+		return 0, 0, fmt.Errorf("src close: %w", err)
 	}
 
-	if err := verifyGzipFile(tmpPath); err != nil {
+	if err := verifyCompressedFile(tmpPath, codec.NewReader); err != nil {
 		os.Remove(tmpPath)
-		return fmt.Errorf("verify: %w", err)
+		return 0, 0, fmt.Errorf("verify: %w", err)
 	}
 
-	finalPath := filePath + ".gz"
+	if info, err := os.Stat(tmpPath); err == nil {
+		out = info.Size()
+	}
+
+	finalPath := filePath + codec.Ext
 	removeWithRetry(finalPath, RetryAttempts, RetryDelay)
 	if err := os.Rename(tmpPath, finalPath); err != nil {
 		os.Remove(tmpPath)
-		return fmt.Errorf("rename: %w", err)
+		return 0, 0, fmt.Errorf("rename: %w", err)
 	}
 
 	removeWithRetry(filePath, RetryAttempts, RetryDelay)
-	return nil
+	return in, out, nil
 }
 
 func removeWithRetry(path string, attempts int, delay time.Duration) bool {
@@ -246,20 +283,27 @@ func removeWithRetry(path string, attempts int, delay time.Duration) bool {
 }
 
 func verifyGzipFile(path string) error {
+	return verifyCompressedFile(path, func(r io.Reader) (io.ReadCloser, error) {
+		return gzip.NewReader(r)
+	})
+}
+
+// verifyCompressedFile checks that path decompresses without error.
+func verifyCompressedFile(path string, newReader func(io.Reader) (io.ReadCloser, error)) error {
 	f, err := os.Open(path)
 	if err != nil {
 		return fmt.Errorf("open: %w", err)
 	}
 	defer f.Close()
 
-	gr, err := gzip.NewReader(f)
+	r, err := newReader(f)
 	if err != nil {
-		return fmt.Errorf("gzip reader: %w", err)
+		return fmt.Errorf("reader: %w", err)
 	}
-	defer gr.Close()
+	defer r.Close()
 
 	// Limit bytes read to prevent decompression bombs
-	limited := io.LimitReader(gr, MaxDecompressSize)
+	limited := io.LimitReader(r, MaxDecompressSize)
 	_, err = io.Copy(io.Discard, limited)
 	if err != nil {
 		return fmt.Errorf("decompress: %w", err)
@@ -315,3 +359,199 @@ func CleanupOldFiles(basePath string, maxAge time.Duration) error {
 
 	return firstErr
 }
+
+// ListBackups returns the paths of existing backup files for basePath,
+// ordered from oldest (lowest index) to newest. Both compressed and
+// uncompressed backups are included.
+func ListBackups(basePath string) []string {
+	plain := buildBackupPattern(basePath, false)
+	gz := buildBackupPattern(basePath, true)
+
+	entries, err := os.ReadDir(plain.dir)
+	if err != nil {
+		return nil
+	}
+
+	backups := make([]backupFileInfo, 0, 16)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+		if !strings.HasPrefix(name, plain.prefix+"_") {
+			continue
+		}
+
+		var index int
+		if strings.HasSuffix(name, gz.suffix) {
+			if _, err := fmt.Sscanf(name, gz.pattern, &index); err != nil {
+				continue
+			}
+		} else if _, err := fmt.Sscanf(name, plain.pattern, &index); err != nil {
+			continue
+		}
+		backups = append(backups, backupFileInfo{name: name, index: index})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].index < backups[j].index
+	})
+
+	paths := make([]string, len(backups))
+	for i, b := range backups {
+		paths[i] = filepath.Join(plain.dir, b.name)
+	}
+	return paths
+}
+
+// TemplateToGlob converts a backup name template to a filepath.Match
+// pattern. Actions naming a key of fields, such as ".Base" or ".Ext", are
+// replaced by its value and the template text is kept, both escaped; every
+// other action, such as {{.Time}} or {{.Seq}}, becomes "*".
+func TemplateToGlob(tmpl string, fields map[string]string) string {
+	return expandTemplate(tmpl, escapeGlob, func(action string) string {
+		if value, ok := fields[action]; ok {
+			return escapeGlob(value)
+		}
+		return "*"
+	})
+}
+
+// TemplateHasLiteral reports whether the text of tmpl, with the actions
+// naming a key of fields expanded and the others treated as separators,
+// contains s. It tells whether the names a template produces always
+// include s.
+func TemplateHasLiteral(tmpl string, fields map[string]string, s string) bool {
+	text := expandTemplate(tmpl, func(text string) string { return text }, func(action string) string {
+		if value, ok := fields[action]; ok {
+			return value
+		}
+		return "\x00"
+	})
+	return s != "" && strings.Contains(text, s)
+}
+
+// expandTemplate rewrites the text and the {{...}} actions of tmpl with
+// the given functions. Actions are passed without braces and spaces. An
+// unterminated action is treated as text.
+func expandTemplate(tmpl string, text, action func(string) string) string {
+	var sb strings.Builder
+	for {
+		start := strings.Index(tmpl, "{{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(tmpl[start:], "}}")
+		if end < 0 {
+			break
+		}
+		sb.WriteString(text(tmpl[:start]))
+		sb.WriteString(action(strings.TrimSpace(tmpl[start+2 : start+end])))
+		tmpl = tmpl[start+end+2:]
+	}
+	sb.WriteString(text(tmpl))
+	return sb.String()
+}
+
+// escapeGlob escapes the filepath.Match metacharacters of s. On Windows,
+// where the backslash is a path separator rather than an escape, they are
+// matched by "?" instead.
+func escapeGlob(s string) string {
+	if !strings.ContainsAny(s, `*?[\`) {
+		return s
+	}
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`*?[\`, r) {
+			if runtime.GOOS == "windows" {
+				sb.WriteByte('?')
+				continue
+			}
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// ListBackupsGlob returns the paths of files in dir whose names match
+// pattern (or pattern + ".gz", or pattern followed by one of exts),
+// ordered from oldest to newest by modification time. Names equal to
+// exclude are skipped.
+func ListBackupsGlob(dir, pattern, exclude string, exts ...string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	type backup struct {
+		path    string
+		modTime time.Time
+	}
+	backups := make([]backup, 0, 16)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name == exclude {
+			continue
+		}
+		matched, _ := filepath.Match(pattern, name)
+		if !matched {
+			matched, _ = filepath.Match(pattern+".gz", name)
+		}
+		for i := 0; !matched && i < len(exts); i++ {
+			matched, _ = filepath.Match(pattern+exts[i], name)
+		}
+		if !matched {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{path: filepath.Join(dir, name), modTime: info.ModTime()})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].modTime.Before(backups[j].modTime)
+	})
+
+	paths := make([]string, len(backups))
+	for i, b := range backups {
+		paths[i] = b.path
+	}
+	return paths
+}
+
+// CleanupBackupsGlob enforces maxBackups and maxAge on the backups returned
+// by ListBackupsGlob. A zero limit disables that check. Removal is
+// best-effort; the first error is returned.
+func CleanupBackupsGlob(dir, pattern, exclude string, maxBackups int, maxAge time.Duration, exts ...string) error {
+	backups := ListBackupsGlob(dir, pattern, exclude, exts...)
+
+	var firstErr error
+	remove := func(path string) {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) && firstErr == nil {
+			firstErr = fmt.Errorf("remove %s: %w", path, err)
+		}
+	}
+
+	if maxBackups > 0 && len(backups) > maxBackups {
+		excess := len(backups) - maxBackups
+		for _, path := range backups[:excess] {
+			remove(path)
+		}
+		backups = backups[excess:]
+	}
+
+	if maxAge > 0 {
+		cutoff := time.Now().Add(-maxAge)
+		for _, path := range backups {
+			if info, err := os.Stat(path); err == nil && info.ModTime().Before(cutoff) {
+				remove(path)
+			}
+		}
+	}
+
+	return firstErr
+}
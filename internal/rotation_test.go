@@ -3,6 +3,7 @@ package internal
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 )
@@ -674,3 +675,122 @@ func TestRotateBackupsMaxBackupsZero(t *testing.T) {
 		}
 	}
 }
+
+func TestListBackups(t *testing.T) {
+	tmpDir := t.TempDir()
+	basePath := filepath.Join(tmpDir, "app.log")
+
+	for _, name := range []string{"app_log_3.log", "app_log_1.log.gz", "app_log_2.log", "other.log", "app.log"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte("x"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := ListBackups(basePath)
+	want := []string{
+		filepath.Join(tmpDir, "app_log_1.log.gz"),
+		filepath.Join(tmpDir, "app_log_2.log"),
+		filepath.Join(tmpDir, "app_log_3.log"),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ListBackups() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ListBackups()[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	if backups := ListBackups(filepath.Join(tmpDir, "missing", "app.log")); len(backups) != 0 {
+		t.Errorf("ListBackups() on missing dir = %v, want empty", backups)
+	}
+}
+
+func TestFreeDiskBytes(t *testing.T) {
+	free, err := FreeDiskBytes(t.TempDir())
+	if err != nil {
+		t.Fatalf("FreeDiskBytes() error = %v", err)
+	}
+	if free <= 0 {
+		t.Errorf("FreeDiskBytes() = %d, want > 0", free)
+	}
+}
+
+func TestTemplateToGlob(t *testing.T) {
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{"app-{{.Time}}-{{.Seq}}.log", "app-*-*.log"},
+		{"{{.Base}}.{{.Seq}}{{.Ext}}", "app.*.log"},
+		{"{{ .Base }}-{{.Time.Format \"2006\"}}{{.Ext}}", "app-*.log"},
+		{"static.log", "static.log"},
+		{"broken-{{.Seq", "broken-{{.Seq"},
+	}
+	fields := map[string]string{".Base": "app", ".Ext": ".log"}
+	for _, tt := range tests {
+		if got := TemplateToGlob(tt.tmpl, fields); got != tt.want {
+			t.Errorf("TemplateToGlob(%q) = %q, want %q", tt.tmpl, got, tt.want)
+		}
+	}
+	if runtime.GOOS != "windows" {
+		if got := TemplateToGlob("{{.Base}}[1]-{{.Seq}}", map[string]string{".Base": "a*b"}); got != `a\*b\[1]-*` {
+			t.Errorf("metacharacters not escaped: %q", got)
+		}
+	}
+
+	for tmpl, want := range map[string]bool{
+		"{{.Base}}.{{.Seq}}{{.Ext}}": true,
+		"app-{{.Seq}}.log":           true,
+		"{{.Seq}}{{.Ext}}":           false,
+		"ap{{.Seq}}p.log":            false,
+		"{{.Time}}.{{.Seq}}":         false,
+	} {
+		if got := TemplateHasLiteral(tmpl, fields, "app"); got != want {
+			t.Errorf("TemplateHasLiteral(%q) = %v, want %v", tmpl, got, want)
+		}
+	}
+}
+
+func TestListAndCleanupBackupsGlob(t *testing.T) {
+	tmpDir := t.TempDir()
+	base := time.Now().Add(-time.Hour)
+	names := []string{"app-3.log", "app-1.log.gz", "app-2.log"}
+	for i, name := range names {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		modTime := base.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "app-active.log"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "other.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := ListBackupsGlob(tmpDir, "app-*.log", "app-active.log")
+	if len(got) != len(names) {
+		t.Fatalf("ListBackupsGlob() = %v, want %d entries", got, len(names))
+	}
+	for i, name := range names {
+		if got[i] != filepath.Join(tmpDir, name) {
+			t.Errorf("ListBackupsGlob()[%d] = %s, want %s", i, got[i], name)
+		}
+	}
+
+	if err := CleanupBackupsGlob(tmpDir, "app-*.log", "app-active.log", 1, 0); err != nil {
+		t.Fatalf("CleanupBackupsGlob() error = %v", err)
+	}
+	got = ListBackupsGlob(tmpDir, "app-*.log", "app-active.log")
+	if len(got) != 1 || got[0] != filepath.Join(tmpDir, "app-2.log") {
+		t.Errorf("after cleanup = %v, want only app-2.log", got)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "app-active.log")); err != nil {
+		t.Errorf("active file removed: %v", err)
+	}
+}
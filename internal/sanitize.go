@@ -3,6 +3,7 @@ package internal
 import (
 	"strings"
 	"sync"
+	"unicode/utf8"
 )
 
 // HexChars is a package-level constant for hex digit conversion.
@@ -351,3 +352,127 @@ func skipAnsiSequence(data []byte, start int) int {
 	// Unknown sequence, just skip the ESC and the next byte
 	return 1
 }
+
+// ControlCharPolicy selects how SanitizeControlCharsWith handles control
+// characters.
+type ControlCharPolicy int
+
+const (
+	// ControlCharEscape is the SanitizeControlChars behavior.
+	ControlCharEscape ControlCharPolicy = iota
+	// ControlCharEscapeUnicode writes control characters as \uXXXX.
+	ControlCharEscapeUnicode
+	// ControlCharStrip removes control characters.
+	ControlCharStrip
+	// ControlCharReplace replaces control characters with '?'.
+	ControlCharReplace
+)
+
+// IsValid reports whether p is a known policy.
+func (p ControlCharPolicy) IsValid() bool {
+	return p >= ControlCharEscape && p <= ControlCharReplace
+}
+
+// SanitizeControlCharsWith sanitizes message like SanitizeControlChars,
+// handling control characters according to policy. The input is decoded as
+// UTF-8: valid multi-byte sequences are kept intact and invalid bytes are
+// treated as control characters. Tabs are kept. Newlines and carriage
+// returns are kept when keepNewlines is set and escaped as \n and \r
+// otherwise, whatever the policy, so a multi-line message becomes one line.
+func SanitizeControlCharsWith(message string, policy ControlCharPolicy, keepNewlines bool) string {
+	if policy == ControlCharEscape && !keepNewlines {
+		return SanitizeControlChars(message)
+	}
+	if !needsControlSanitizing(message, keepNewlines) {
+		return message
+	}
+
+	var b strings.Builder
+	b.Grow(len(message) + 8)
+	for i := 0; i < len(message); {
+		r, size := utf8.DecodeRuneInString(message[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			writeInvalidByte(&b, message[i], policy)
+		case r == '\t':
+			b.WriteByte('\t')
+		case r == '\n' || r == '\r':
+			switch {
+			case keepNewlines:
+				b.WriteByte(byte(r))
+			case r == '\n':
+				b.WriteString(`\n`)
+			default:
+				b.WriteString(`\r`)
+			}
+		case r == 0x1b && policy != ControlCharEscapeUnicode:
+			// Drop the whole ANSI sequence, not just the ESC
+			i += 1 + skipAnsiSequence([]byte(message[i:min(len(message), i+258)]), 1)
+			if policy == ControlCharReplace {
+				b.WriteByte('?')
+			}
+			continue
+		case r < 0x20 || r == 0x7f || isUnicodeControlRune(r):
+			writeControlRune(&b, r, policy)
+		default:
+			b.WriteString(message[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// needsControlSanitizing reports whether SanitizeControlCharsWith would
+// change message.
+func needsControlSanitizing(message string, keepNewlines bool) bool {
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		if c >= utf8.RuneSelf {
+			// Decide per rune from here on
+			for _, r := range message[i:] {
+				if r == utf8.RuneError || r < 0x20 && r != '\t' && (!keepNewlines || r != '\n' && r != '\r') ||
+					r == 0x7f || isUnicodeControlRune(r) {
+					return true
+				}
+			}
+			return false
+		}
+		if c == 0x7f || c < 0x20 && c != '\t' && (!keepNewlines || c != '\n' && c != '\r') {
+			return true
+		}
+	}
+	return false
+}
+
+func writeControlRune(b *strings.Builder, r rune, policy ControlCharPolicy) {
+	switch policy {
+	case ControlCharEscapeUnicode:
+		b.WriteString(`\u`)
+		for shift := 12; shift >= 0; shift -= 4 {
+			b.WriteByte(HexChars[(r>>shift)&0x0f])
+		}
+	case ControlCharReplace:
+		b.WriteByte('?')
+	case ControlCharEscape:
+		// Only C0 characters other than NUL are shown, as in
+		// SanitizeControlChars
+		if r > 0 && r < 0x20 {
+			b.WriteString(`\x`)
+			b.WriteByte(HexChars[r>>4])
+			b.WriteByte(HexChars[r&0x0f])
+		}
+	}
+}
+
+func writeInvalidByte(b *strings.Builder, c byte, policy ControlCharPolicy) {
+	switch policy {
+	case ControlCharEscapeUnicode:
+		b.WriteString(`\ufffd`)
+	case ControlCharReplace:
+		b.WriteByte('?')
+	case ControlCharEscape:
+		b.WriteString(`\x`)
+		b.WriteByte(HexChars[c>>4])
+		b.WriteByte(HexChars[c&0x0f])
+	}
+}
@@ -230,3 +230,32 @@ func TestSanitizeCombinedAttack(t *testing.T) {
 		})
 	}
 }
+
+func TestSanitizeControlCharsWith(t *testing.T) {
+	input := "a\x01b\x1b[31mc\u200bd\xffé\n\t日本"
+	tests := []struct {
+		policy       ControlCharPolicy
+		keepNewlines bool
+		expected     string
+	}{
+		{ControlCharEscape, false, SanitizeControlChars(input)},
+		{ControlCharEscape, true, "a\\x01bcd\\xffé\n\t日本"},
+		{ControlCharEscapeUnicode, false, "a\\u0001b\\u001b[31mc\\u200bd\\ufffdé\\n\t日本"},
+		{ControlCharStrip, false, "abcdé\\n\t日本"},
+		{ControlCharReplace, false, "a?b?c?d?é\\n\t日本"},
+		{ControlCharReplace, true, "a?b?c?d?é\n\t日本"},
+	}
+
+	for _, tt := range tests {
+		if got := SanitizeControlCharsWith(input, tt.policy, tt.keepNewlines); got != tt.expected {
+			t.Errorf("policy %d keepNewlines %v: got %q, want %q", tt.policy, tt.keepNewlines, got, tt.expected)
+		}
+	}
+
+	clean := "plain text, ünïcode 日本\ttab"
+	for policy := ControlCharEscape; policy <= ControlCharReplace; policy++ {
+		if got := SanitizeControlCharsWith(clean, policy, true); got != clean {
+			t.Errorf("policy %d changed clean input: %q", policy, got)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// stackMarker is the separator written by ErrWithStack between the error
+// message and its frames.
+const stackMarker = "\nStack:"
+
+// stackBlockIndent prefixes every line of an indented stack block.
+const stackBlockIndent = "    "
+
+// stackBlock is a stack trace split off a field for text rendering.
+type stackBlock struct {
+	body string // everything after the first line
+}
+
+// isStackKey reports whether key conventionally holds a stack trace.
+func isStackKey(key string) bool {
+	switch key {
+	case "stack", "stacktrace", "stack_trace", "error.stack", "goroutines":
+		return true
+	}
+	return false
+}
+
+// splitStackField returns the first line and the remaining body of a field
+// value that holds a multi-line stack trace.
+func splitStackField(field Field) (head, body string, ok bool) {
+	s, isString := field.Value.(string)
+	if !isString {
+		return "", "", false
+	}
+	idx := strings.IndexByte(s, '\n')
+	if idx < 0 {
+		return "", "", false
+	}
+	if !isStackKey(field.Key) && !strings.Contains(s, stackMarker) {
+		return "", "", false
+	}
+	return s[:idx], s[idx+1:], true
+}
+
+// extractStackFields replaces stack trace field values with their first line
+// and returns the split-off bodies. The input slice is never modified; a copy
+// is made only when a stack field is present.
+func extractStackFields(fields []Field, mode StackTraceMode) ([]Field, []stackBlock) {
+	var out []Field
+	var blocks []stackBlock
+
+	for i, field := range fields {
+		head, body, ok := splitStackField(field)
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = make([]Field, len(fields))
+			copy(out, fields)
+		}
+		if mode == StackTraceFold {
+			out[i].Value = head + " [+" + strconv.Itoa(countStackFrames(body)) + " frames]"
+			continue
+		}
+		out[i].Value = head
+		blocks = append(blocks, stackBlock{body: body})
+	}
+
+	if out == nil {
+		return fields, nil
+	}
+	return out, blocks
+}
+
+// countStackFrames counts the frames in a stack body. Frames are the
+// non-indented lines; indented lines hold file positions and the
+// "Stack:" header written by ErrWithStack is ignored.
+func countStackFrames(body string) int {
+	lines := strings.Split(body, "\n")
+	frames := 0
+	indented := 0
+	for _, line := range lines {
+		switch {
+		case line == "" || line == "Stack:":
+		case line[0] == '\t' || line[0] == ' ':
+			indented++
+		default:
+			frames++
+		}
+	}
+	// ErrWithStack writes one indented line per frame
+	if frames == 0 {
+		return indented
+	}
+	return frames
+}
+
+// writeStackBlocks appends each block as lines starting with prefix after
+// the main line.
+func writeStackBlocks(buf *bytes.Buffer, blocks []stackBlock, prefix string) {
+	for _, block := range blocks {
+		for _, line := range strings.Split(block.body, "\n") {
+			line = strings.TrimRight(line, "\r")
+			if line == "" {
+				continue
+			}
+			buf.WriteByte('\n')
+			buf.WriteString(prefix)
+			buf.WriteString(line)
+		}
+	}
+}
+
+// writeContinuation appends the remaining lines of a multi-line message,
+// each starting with prefix. Carriage returns are escaped so a line cannot
+// overwrite its prefix on a terminal.
+func writeContinuation(buf *bytes.Buffer, body, prefix string) {
+	for _, line := range strings.Split(body, "\n") {
+		buf.WriteByte('\n')
+		buf.WriteString(prefix)
+		line = strings.TrimSuffix(line, "\r")
+		buf.WriteString(strings.ReplaceAll(line, "\r", `\r`))
+	}
+}
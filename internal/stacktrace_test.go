@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+const testStackValue = "boom\nStack:\n\t/app/main.go:10: main.run\n\t/app/main.go:5: main.main"
+
+func newTextFormatter(mode StackTraceMode) *MessageFormatter {
+	return NewMessageFormatter(&FormatterConfig{
+		Format: LogFormatText,
+		Text:   &TextOptions{StackTrace: mode},
+	})
+}
+
+func TestFormatTextStackTraceIndent(t *testing.T) {
+	f := newTextFormatter(StackTraceIndent)
+	fields := []Field{{Key: "error", Value: testStackValue}, {Key: "k", Value: "v"}}
+
+	out := f.FormatWithMessage(LevelError, 0, "failed", fields)
+	lines := strings.Split(out, "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d: %q", len(lines), out)
+	}
+	if lines[0] != "failed error=boom k=v" {
+		t.Errorf("main line = %q", lines[0])
+	}
+	if lines[1] != "    Stack:" || lines[2] != "    \t/app/main.go:10: main.run" {
+		t.Errorf("stack block not indented: %q", lines[1:])
+	}
+
+	// Caller's slice must not be modified
+	if fields[0].Value != testStackValue {
+		t.Error("input fields were modified")
+	}
+}
+
+func TestFormatTextStackTraceFold(t *testing.T) {
+	f := newTextFormatter(StackTraceFold)
+	out := f.FormatWithMessage(LevelError, 0, "failed", []Field{{Key: "error", Value: testStackValue}})
+
+	if strings.Contains(out, "\n") {
+		t.Errorf("folded output should be a single line: %q", out)
+	}
+	if !strings.Contains(out, "[+2 frames]") {
+		t.Errorf("expected frame count, got %q", out)
+	}
+}
+
+func TestFormatTextStackTraceInline(t *testing.T) {
+	f := newTextFormatter(StackTraceInline)
+	out := f.FormatWithMessage(LevelError, 0, "failed", []Field{{Key: "error", Value: testStackValue}})
+
+	if !strings.Contains(out, `error="boom`) || !strings.Contains(out, "main.main\"") {
+		t.Errorf("expected raw inline value, got %q", out)
+	}
+}
+
+func TestExtractStackFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		field Field
+		want  bool
+	}{
+		{"error with stack marker", Field{Key: "error", Value: testStackValue}, true},
+		{"stack key", Field{Key: "stack", Value: "goroutine 1 [running]:\nmain.main()\n\t/app/main.go:5 +0x1d"}, true},
+		{"plain multi-line value", Field{Key: "body", Value: "line1\nline2"}, false},
+		{"single-line stack key", Field{Key: "stack", Value: "none"}, false},
+		{"non-string", Field{Key: "stack", Value: 42}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, blocks := extractStackFields([]Field{tt.field}, StackTraceIndent)
+			if got := len(blocks) == 1; got != tt.want {
+				t.Errorf("stack detected = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountStackFrames(t *testing.T) {
+	if got := countStackFrames("Stack:\n\t/a.go:1: f\n\t/b.go:2: g"); got != 2 {
+		t.Errorf("ErrWithStack body frames = %d, want 2", got)
+	}
+	runtimeStack := "main.f()\n\t/a.go:1 +0x1\nmain.main()\n\t/b.go:2 +0x2"
+	if got := countStackFrames(runtimeStack); got != 2 {
+		t.Errorf("runtime stack frames = %d, want 2", got)
+	}
+}
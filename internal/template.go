@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"bytes"
+	"strings"
+)
+
+// RenderTemplate replaces {name} placeholders in template with the value of
+// the last field whose key is name. String values are inserted verbatim;
+// other values are formatted as in text output. Placeholders without a
+// matching field are kept as written. "{{" and "}}" produce literal braces.
+func RenderTemplate(template string, fields []Field) string {
+	if strings.IndexByte(template, '{') < 0 && strings.IndexByte(template, '}') < 0 {
+		return template
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(template) + 16*len(fields))
+
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		switch {
+		case c == '{' && i+1 < len(template) && template[i+1] == '{':
+			buf.WriteByte('{')
+			i++
+		case c == '}' && i+1 < len(template) && template[i+1] == '}':
+			buf.WriteByte('}')
+			i++
+		case c == '{':
+			end := strings.IndexAny(template[i+1:], "{}")
+			if end < 0 || template[i+1+end] != '}' || end == 0 {
+				buf.WriteByte(c)
+				continue
+			}
+			name := template[i+1 : i+1+end]
+			if value, ok := lookupField(fields, name); ok {
+				if s, isString := value.(string); isString {
+					buf.WriteString(s)
+				} else {
+					formatFieldValueBytes(&buf, value)
+				}
+			} else {
+				buf.WriteString(template[i : i+2+end])
+			}
+			i += end + 1
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	return buf.String()
+}
+
+// lookupField returns the value of the last field with key.
+func lookupField(fields []Field, key string) (any, bool) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		if fields[i].Key == key {
+			return fields[i].Value, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	fields := []Field{
+		{Key: "user", Value: "alice smith"},
+		{Key: "count", Value: 3},
+		{Key: "elapsed", Value: 1500 * time.Millisecond},
+		{Key: "ok", Value: true},
+		{Key: "tags", Value: []string{"a", "b"}},
+		{Key: "count", Value: 4},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"no placeholders", "plain message", "plain message"},
+		{"string verbatim", "user {user} logged in", "user alice smith logged in"},
+		{"last duplicate wins", "bought {count} items", "bought 4 items"},
+		{"formatted values", "{ok} after {elapsed}", "true after 1.5s"},
+		{"slice", "tags={tags}", `tags=["a","b"]`},
+		{"missing kept", "hello {name}", "hello {name}"},
+		{"escaped braces", "{{user}} is {user}", "{user} is alice smith"},
+		{"closing escape", "set }} {count}", "set } 4"},
+		{"empty placeholder", "a {} b", "a {} b"},
+		{"unterminated", "a {user", "a {user"},
+		{"nested open", "a {x{user}", "a {xalice smith"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderTemplate(tt.template, fields); got != tt.want {
+				t.Errorf("RenderTemplate(%q) = %q, want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"strings"
+	"time"
+)
+
+// TimePrecision selects the sub-second digits of formatted timestamps and
+// the unit of epoch timestamps.
+type TimePrecision int8
+
+const (
+	// TimePrecisionDefault keeps the time format unchanged; epoch
+	// timestamps are in seconds.
+	TimePrecisionDefault TimePrecision = iota
+	TimePrecisionSecond
+	TimePrecisionMilli
+	TimePrecisionMicro
+	TimePrecisionNano
+)
+
+func (p TimePrecision) String() string {
+	switch p {
+	case TimePrecisionDefault:
+		return "default"
+	case TimePrecisionSecond:
+		return "second"
+	case TimePrecisionMilli:
+		return "milli"
+	case TimePrecisionMicro:
+		return "micro"
+	case TimePrecisionNano:
+		return "nano"
+	default:
+		return "unknown"
+	}
+}
+
+// IsValid reports whether p is a known precision.
+func (p TimePrecision) IsValid() bool {
+	return p >= TimePrecisionDefault && p <= TimePrecisionNano
+}
+
+// fractionDigits returns the number of sub-second digits for p.
+func (p TimePrecision) fractionDigits() int {
+	switch p {
+	case TimePrecisionMilli:
+		return 3
+	case TimePrecisionMicro:
+		return 6
+	case TimePrecisionNano:
+		return 9
+	default:
+		return 0
+	}
+}
+
+// epoch returns t as a Unix timestamp in the unit of p.
+func (p TimePrecision) epoch(t time.Time) int64 {
+	switch p {
+	case TimePrecisionMilli:
+		return t.UnixMilli()
+	case TimePrecisionMicro:
+		return t.UnixMicro()
+	case TimePrecisionNano:
+		return t.UnixNano()
+	default:
+		return t.Unix()
+	}
+}
+
+// ApplyTimePrecision returns layout with the fractional seconds after its
+// seconds element ("05") replaced by the digits for p. Layouts without a
+// seconds element and TimePrecisionDefault are returned unchanged.
+func ApplyTimePrecision(layout string, p TimePrecision) string {
+	if p == TimePrecisionDefault {
+		return layout
+	}
+	i := strings.Index(layout, "05")
+	if i < 0 {
+		return layout
+	}
+	end := i + 2
+	rest := end + fractionLength(layout[end:])
+
+	var b strings.Builder
+	b.Grow(len(layout) + 10)
+	b.WriteString(layout[:end])
+	if digits := p.fractionDigits(); digits > 0 {
+		b.WriteByte('.')
+		b.WriteString(strings.Repeat("0", digits))
+	}
+	b.WriteString(layout[rest:])
+	return b.String()
+}
+
+// fractionLength returns the length of a fractional seconds element
+// (".000", ",999" ...) at the start of s, or 0 if there is none.
+func fractionLength(s string) int {
+	if len(s) < 2 || (s[0] != '.' && s[0] != ',') || (s[1] != '0' && s[1] != '9') {
+		return 0
+	}
+	n := 2
+	for n < len(s) && s[n] == s[1] {
+		n++
+	}
+	return n
+}
+
+// hasSubSecondLayout reports whether layout prints fractional seconds.
+func hasSubSecondLayout(layout string) bool {
+	i := strings.Index(layout, "05")
+	return i >= 0 && fractionLength(layout[i+2:]) > 0
+}
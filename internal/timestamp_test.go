@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyTimePrecision(t *testing.T) {
+	tests := []struct {
+		layout    string
+		precision TimePrecision
+		want      string
+	}{
+		{time.RFC3339, TimePrecisionDefault, time.RFC3339},
+		{time.RFC3339, TimePrecisionSecond, time.RFC3339},
+		{time.RFC3339, TimePrecisionMilli, "2006-01-02T15:04:05.000Z07:00"},
+		{time.RFC3339Nano, TimePrecisionMicro, "2006-01-02T15:04:05.000000Z07:00"},
+		{time.RFC3339Nano, TimePrecisionSecond, time.RFC3339},
+		{"2006-01-02 15:04:05,000", TimePrecisionNano, "2006-01-02 15:04:05.000000000"},
+		{time.Kitchen, TimePrecisionMilli, time.Kitchen},
+	}
+	for _, tt := range tests {
+		if got := ApplyTimePrecision(tt.layout, tt.precision); got != tt.want {
+			t.Errorf("ApplyTimePrecision(%q, %v) = %q, want %q", tt.layout, tt.precision, got, tt.want)
+		}
+	}
+}
+
+func TestTimeCacheOptions(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.FixedZone("X", 3600))
+	now := func() time.Time { return ts }
+
+	tc := newTimeCache(time.RFC3339, now, timeCacheOptions{location: time.UTC, precision: TimePrecisionMilli})
+	if got := tc.getFormattedTime(); got != "2024-01-02T02:04:05.123Z" {
+		t.Errorf("formatted = %q", got)
+	}
+
+	tc = newTimeCache(time.RFC3339, now, timeCacheOptions{epoch: true, precision: TimePrecisionMilli})
+	if got := tc.timestampValue(); got != ts.UnixMilli() {
+		t.Errorf("epoch value = %v", got)
+	}
+	if got := tc.getFormattedTime(); got != "1704161045123" {
+		t.Errorf("epoch text = %q", got)
+	}
+}
+
+func TestTimeCacheSubSecondNotCached(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 100, time.UTC)
+	tc := newTimeCache(time.RFC3339Nano, func() time.Time { return ts }, timeCacheOptions{})
+	first := tc.getFormattedTime()
+	ts = ts.Add(time.Millisecond)
+	if second := tc.getFormattedTime(); second == first {
+		t.Errorf("sub-second timestamps should not be cached: %q", second)
+	}
+}
@@ -2,9 +2,12 @@ package internal
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -21,6 +24,7 @@ type LogFormat int8
 const (
 	LogFormatText LogFormat = iota
 	LogFormatJSON
+	LogFormatConsole
 )
 
 func (f LogFormat) String() string {
@@ -29,11 +33,86 @@ func (f LogFormat) String() string {
 		return "text"
 	case LogFormatJSON:
 		return "json"
+	case LogFormatConsole:
+		return "console"
 	default:
+		if name, ok := formatNames.Load(f); ok {
+			return name.(string)
+		}
 		return "unknown"
 	}
 }
 
+// formatNames holds the names of formats registered by the root package.
+var formatNames sync.Map
+
+// SetFormatName sets the name returned by String for a registered format.
+func SetFormatName(f LogFormat, name string) {
+	formatNames.Store(f, name)
+}
+
+// ErrInvalidFormat is returned when a format value or name is not
+// recognized. It is re-exported as dd.ErrInvalidFormat.
+var ErrInvalidFormat = errors.New("invalid log format")
+
+// FormatNames returns the built-in format names followed by the
+// registered ones, sorted.
+func FormatNames() []string {
+	var registered []string
+	formatNames.Range(func(_, name any) bool {
+		registered = append(registered, name.(string))
+		return true
+	})
+	slices.Sort(registered)
+	return append([]string{"text", "json", "console"}, registered...)
+}
+
+// ParseFormat converts a format name to a LogFormat. Built-in names are
+// matched case-insensitively, registered names exactly or in lower case;
+// surrounding whitespace is ignored.
+func ParseFormat(s string) (LogFormat, error) {
+	name := strings.TrimSpace(s)
+	switch strings.ToLower(name) {
+	case "text":
+		return LogFormatText, nil
+	case "json":
+		return LogFormatJSON, nil
+	case "console":
+		return LogFormatConsole, nil
+	}
+	format, found := LogFormatText, false
+	formatNames.Range(func(f, registered any) bool {
+		if registered == name || registered == strings.ToLower(name) {
+			format, found = f.(LogFormat), true
+		}
+		return !found
+	})
+	if !found {
+		return LogFormatText, fmt.Errorf("%w: %q (valid: %s)", ErrInvalidFormat, s, strings.Join(FormatNames(), ", "))
+	}
+	return format, nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the format name.
+func (f LogFormat) MarshalText() ([]byte, error) {
+	name := f.String()
+	if name == "unknown" {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidFormat, f)
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the names
+// understood by ParseFormat.
+func (f *LogFormat) UnmarshalText(text []byte) error {
+	format, err := ParseFormat(string(text))
+	if err != nil {
+		return err
+	}
+	*f = format
+	return nil
+}
+
 type LogLevel int8
 
 const (
@@ -64,6 +143,49 @@ func (l LogLevel) IsValid() bool {
 	return l >= LevelDebug && l <= LevelFatal
 }
 
+// ErrInvalidLevel is returned when a level value or name is not recognized.
+// It is re-exported as dd.ErrInvalidLevel.
+var ErrInvalidLevel = errors.New("invalid log level")
+
+// ParseLevel converts a level name to a LogLevel. Matching is
+// case-insensitive and ignores surrounding whitespace; "warning" is
+// accepted as an alias for "warn".
+func ParseLevel(s string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	case "fatal":
+		return LevelFatal, nil
+	default:
+		return LevelInfo, fmt.Errorf("%w: %q (valid: debug, info, warn, error, fatal)", ErrInvalidLevel, s)
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler using the lower-case level name.
+func (l LogLevel) MarshalText() ([]byte, error) {
+	if !l.IsValid() {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidLevel, l)
+	}
+	return []byte(strings.ToLower(l.String())), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the names
+// understood by ParseLevel.
+func (l *LogLevel) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
 type JSONFieldNames struct {
 	Timestamp string
 	Level     string
@@ -81,10 +203,156 @@ func (j *JSONFieldNames) IsComplete() bool {
 		j.Fields != ""
 }
 
+// JSONFieldOrder controls the order of keys in JSON output.
+type JSONFieldOrder int8
+
+const (
+	// JSONOrderAny leaves key order unspecified. This is the default.
+	JSONOrderAny JSONFieldOrder = iota
+	// JSONOrderInsertion writes timestamp, level, caller and message first,
+	// then fields in the order they were logged.
+	JSONOrderInsertion
+	// JSONOrderSorted writes the standard keys first, then fields sorted by key.
+	JSONOrderSorted
+)
+
+// JSONInts selects how 64-bit integers are written in JSON.
+type JSONInts int8
+
+const (
+	// JSONIntsNumber writes integers as numbers. This is the default.
+	JSONIntsNumber JSONInts = iota
+	// JSONIntsUnsafeAsString writes integers outside ±(2^53-1), which
+	// JavaScript and float64-based parsers cannot represent exactly, as
+	// strings.
+	JSONIntsUnsafeAsString
+	// JSONIntsAsString writes every int, int64, uint and uint64 value as
+	// a string, so a field keeps one type whatever its value.
+	JSONIntsAsString
+)
+
+// JSONNonFinite selects how NaN and ±Inf floats, which JSON cannot
+// represent, are written.
+type JSONNonFinite int8
+
+const (
+	// JSONNonFiniteError replaces the entry with an entry holding an
+	// "error" key, as for any value that cannot be encoded. This is the
+	// default.
+	JSONNonFiniteError JSONNonFinite = iota
+	// JSONNonFiniteNull writes null.
+	JSONNonFiniteNull
+	// JSONNonFiniteString writes "NaN", "+Inf" or "-Inf".
+	JSONNonFiniteString
+)
+
 type JSONOptions struct {
 	PrettyPrint bool
 	Indent      string
 	FieldNames  *JSONFieldNames
+
+	// FieldOrder makes key order deterministic. FlattenFields and OmitEmpty
+	// imply at least JSONOrderInsertion.
+	FieldOrder JSONFieldOrder
+
+	// FlattenFields writes fields at the top level instead of under
+	// FieldNames.Fields. A field whose key collides with a standard key is
+	// written as "<FieldNames.Fields>.<key>".
+	FlattenFields bool
+
+	// OmitEmpty drops fields with zero values: nil, "", 0, false, empty
+	// slices and maps, and the zero time.
+	OmitEmpty bool
+
+	// LevelNames overrides the level value written for each level, e.g.
+	// "WARNING" for LevelWarn. Missing levels use LogLevel.String().
+	LevelNames map[LogLevel]string
+
+	// StaticFields are written after the message on every entry, e.g.
+	// {"ecs.version", "8.11.0"}. They imply JSONOrderInsertion.
+	StaticFields []Field
+
+	// SingleLine guarantees that every entry is written as exactly one
+	// line, as tail-based collectors expect: PrettyPrint is ignored and
+	// any raw line break left in the encoded entry is escaped or dropped.
+	SingleLine bool
+
+	// Strict guarantees that every entry is valid JSON encoded as UTF-8,
+	// whatever bytes the message, keys and values hold: invalid UTF-8 is
+	// replaced with U+FFFD, U+2028 and U+2029 are escaped, and an entry
+	// that still fails validation is written as an error entry holding it
+	// as a string. It is applied again after FilterScopeOutput redactions,
+	// which can cut through the encoding. It costs a validation pass per
+	// entry.
+	Strict bool
+
+	// Ints and NonFinite apply to field values, slices of numbers and
+	// Object fields. Values encoded by encoding/json, such as maps and
+	// structs, follow its rules.
+	Ints      JSONInts
+	NonFinite JSONNonFinite
+}
+
+// pretty reports whether entries are indented.
+func (o *JSONOptions) pretty() bool {
+	return o.PrettyPrint && !o.SingleLine
+}
+
+// ordered reports whether the deterministic writer is needed.
+func (o *JSONOptions) ordered() bool {
+	return o != nil && (o.FieldOrder != JSONOrderAny || o.FlattenFields || o.OmitEmpty || len(o.StaticFields) > 0)
+}
+
+// levelName returns the level value to write for level.
+func (o *JSONOptions) levelName(level LogLevel) string {
+	if o != nil {
+		if name, ok := o.LevelNames[level]; ok {
+			return name
+		}
+	}
+	return level.String()
+}
+
+// StackTraceMode controls how stack trace fields are rendered in text output.
+// JSON output always keeps the full value.
+type StackTraceMode int8
+
+const (
+	// StackTraceIndent renders the stack as an indented block after the main line.
+	StackTraceIndent StackTraceMode = iota
+	// StackTraceFold keeps only the first line and replaces the rest with a frame count.
+	StackTraceFold
+	// StackTraceInline writes the value like any other field, quoted on the entry's line.
+	StackTraceInline
+)
+
+// TextOptions configures text output format.
+type TextOptions struct {
+	StackTrace StackTraceMode
+
+	// HumanDurations rounds Duration fields to three significant digits
+	// ("1.25s", "350ms") instead of printing full precision. JSON output
+	// is unaffected.
+	HumanDurations bool
+
+	// FloatPrecision, if positive, writes float fields with this many
+	// digits after the decimal point ("3.14" for 2) instead of the
+	// shortest exact form ("3.141592653589793").
+	FloatPrecision int
+
+	// TimeLayout, if set, is the layout of time.Time fields instead of
+	// time.RFC3339. The entry timestamp keeps Config.TimeFormat.
+	TimeLayout string
+
+	// BoolAsInt writes bool fields as 1 and 0 instead of true and false.
+	BoolAsInt bool
+
+	// ContinuationPrefix, when set, writes the lines of a multi-line
+	// message after the first on lines of their own, each starting with
+	// the prefix (e.g. "    " or "  | "), after the entry's fields. Indented
+	// stack traces use it too. Without it, newlines in messages are escaped
+	// and every entry is one line.
+	ContinuationPrefix string
 }
 
 // IsComplexValue checks if a field value is a complex type that should be JSON-formatted.
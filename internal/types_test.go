@@ -472,3 +472,50 @@ func TestMaxConvertDepth(t *testing.T) {
 		t.Errorf("MaxConvertDepth = %d, want 100", MaxConvertDepth)
 	}
 }
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    LogLevel
+		wantErr bool
+	}{
+		{"debug", LevelDebug, false},
+		{"INFO", LevelInfo, false},
+		{" Warn ", LevelWarn, false},
+		{"warning", LevelWarn, false},
+		{"error", LevelError, false},
+		{"FATAL", LevelFatal, false},
+		{"verbose", LevelInfo, true},
+		{"", LevelInfo, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseLevel(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLevel(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("ParseLevel(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogLevelText(t *testing.T) {
+	data, err := LevelWarn.MarshalText()
+	if err != nil || string(data) != "warn" {
+		t.Errorf("MarshalText() = %q, %v", data, err)
+	}
+	if _, err := LogLevel(42).MarshalText(); err == nil {
+		t.Error("MarshalText() should fail for invalid level")
+	}
+
+	var level LogLevel
+	if err := level.UnmarshalText([]byte("Error")); err != nil || level != LevelError {
+		t.Errorf("UnmarshalText() = %v, %v", level, err)
+	}
+	if err := level.UnmarshalText([]byte("nope")); err == nil {
+		t.Error("UnmarshalText() should fail for unknown name")
+	}
+}
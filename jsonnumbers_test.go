@@ -0,0 +1,87 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestJSONIntOptions(t *testing.T) {
+	tests := []struct {
+		ints JSONInts
+		want string
+	}{
+		{JSONIntsNumber, `"big":9007199254740993,"max":18446744073709551615,"small":42,"list":[1,9007199254740993]`},
+		{JSONIntsUnsafeAsString, `"big":"9007199254740993","max":"18446744073709551615","small":42,"list":[1,"9007199254740993"]`},
+		{JSONIntsAsString, `"big":"9007199254740993","max":"18446744073709551615","small":"42","list":["1","9007199254740993"]`},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		cfg := JSONConfig()
+		cfg.Output = &buf
+		cfg.JSON.FieldOrder = JSONOrderInsertion
+		cfg.JSON.Ints = tt.ints
+		logger, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		logger.InfoWith("n",
+			Int64("big", 1<<53+1),
+			Uint64("max", math.MaxUint64),
+			Int("small", 42),
+			Any("list", []int64{1, 1<<53 + 1}),
+		)
+		logger.Close()
+		if !strings.Contains(buf.String(), tt.want) {
+			t.Errorf("Ints=%d: got %s, want %s", tt.ints, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestJSONNonFiniteOptions(t *testing.T) {
+	tests := []struct {
+		policy JSONNonFinite
+		check  func(map[string]any) bool
+	}{
+		{JSONNonFiniteError, func(m map[string]any) bool {
+			s, _ := m["error"].(string)
+			return strings.Contains(s, `"ratio"`)
+		}},
+		{JSONNonFiniteNull, func(m map[string]any) bool {
+			f, _ := m["fields"].(map[string]any)
+			v, ok := f["ratio"]
+			return ok && v == nil && f["inf"] == nil
+		}},
+		{JSONNonFiniteString, func(m map[string]any) bool {
+			f, _ := m["fields"].(map[string]any)
+			return f["ratio"] == "NaN" && f["inf"] == "-Inf"
+		}},
+	}
+	for _, ordered := range []bool{false, true} {
+		for _, tt := range tests {
+			var buf bytes.Buffer
+			cfg := JSONConfig()
+			cfg.Output = &buf
+			cfg.JSON.NonFinite = tt.policy
+			if ordered {
+				cfg.JSON.FieldOrder = JSONOrderSorted
+			}
+			logger, err := New(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			logger.InfoWith("n", Float64("ratio", math.NaN()), Float64("inf", math.Inf(-1)))
+			logger.Close()
+
+			var m map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+				t.Fatalf("NonFinite=%d ordered=%v: invalid JSON %q: %v", tt.policy, ordered, buf.String(), err)
+			}
+			if !tt.check(m) {
+				t.Errorf("NonFinite=%d ordered=%v: got %s", tt.policy, ordered, buf.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package dd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxLevelRequestSize bounds the body of a LevelHandler PUT request.
+const maxLevelRequestSize = 4 * 1024
+
+// LevelStatus is the JSON document served by LevelHandler.
+type LevelStatus struct {
+	Level    string          `json:"level"`
+	Revert   *LevelRevert    `json:"revert,omitempty"`
+	Sampling *SamplingStatus `json:"sampling"`
+	Writers  []WriterStatus  `json:"writers"`
+}
+
+// LevelRevert describes a pending automatic level revert.
+type LevelRevert struct {
+	Level string    `json:"level"`
+	At    time.Time `json:"at"`
+}
+
+// SamplingStatus summarizes the logger's sampling configuration.
+type SamplingStatus struct {
+	Initial       int     `json:"initial"`
+	Thereafter    int     `json:"thereafter"`
+	Tick          string  `json:"tick"`
+	Deterministic bool    `json:"deterministic,omitempty"`
+	Rate          float64 `json:"rate,omitempty"`
+}
+
+// WriterStatus is the JSON form of WriterStats.
+type WriterStatus struct {
+	Index             int       `json:"index"`
+	Writer            string    `json:"writer"`
+	Writes            int64     `json:"writes"`
+	BytesWritten      int64     `json:"bytes_written"`
+	Errors            int64     `json:"errors"`
+	ConsecutiveErrors int64     `json:"consecutive_errors"`
+	Dropped           int64     `json:"dropped"`
+	LastError         string    `json:"last_error,omitempty"`
+	LastErrorTime     time.Time `json:"last_error_time,omitzero"`
+	MaxLatency        string    `json:"max_latency"`
+}
+
+// levelRequest is the body of a PUT request.
+type levelRequest struct {
+	Level string `json:"level"`
+	TTL   string `json:"ttl"`
+}
+
+// LevelHandler returns an HTTP handler for changing logger's level at
+// runtime, e.g. to turn on debug logging during an incident without a
+// restart.
+//
+// GET returns a LevelStatus: the current level, any pending revert, the
+// sampling configuration and the writer statistics.
+//
+// PUT sets the level from a JSON body ({"level": "debug", "ttl": "10m"})
+// or the query parameters level and ttl, and returns the new LevelStatus.
+// With a ttl the previous level is restored when it expires, unless the
+// level was changed by other means meanwhile. A later PUT replaces the
+// pending revert but keeps its target, so extending "debug for 10m" still
+// ends at the original level. A PUT without ttl cancels the revert.
+//
+// The handler can change what gets logged and shows writer details, so
+// serve it only on an internal or authenticated endpoint.
+//
+// Example:
+//
+//	mux.Handle("/debug/log/level", dd.LevelHandler(logger))
+//
+//	// curl -X PUT 'localhost:6060/debug/log/level?level=debug&ttl=10m'
+func LevelHandler(logger *Logger) http.Handler {
+	return &levelHandler{logger: logger}
+}
+
+type levelHandler struct {
+	logger *Logger
+
+	mu       sync.Mutex
+	timer    *time.Timer // pending revert, nil if none
+	gen      uint64      // incremented by each PUT; stale reverts do nothing
+	revertTo LogLevel
+	revertAt time.Time
+	setLevel LogLevel // level the pending revert expects to find
+}
+
+func (h *levelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPut:
+		if err := h.set(w, r); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(h.status())
+}
+
+// set applies a PUT request.
+func (h *levelHandler) set(w http.ResponseWriter, r *http.Request) error {
+	req := levelRequest{
+		Level: r.URL.Query().Get("level"),
+		TTL:   r.URL.Query().Get("ttl"),
+	}
+	if req.Level == "" {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxLevelRequestSize))
+		if err != nil {
+			return fmt.Errorf("read body: %w", err)
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			return fmt.Errorf("invalid body: %w", err)
+		}
+	}
+	if req.Level == "" {
+		return errors.New("level is required")
+	}
+
+	level, err := ParseLevel(req.Level)
+	if err != nil {
+		return err
+	}
+	var ttl time.Duration
+	if req.TTL != "" {
+		if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl <= 0 {
+			return fmt.Errorf("invalid ttl %q", req.TTL)
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	revertTo := h.logger.GetLevel()
+	if h.timer != nil {
+		h.timer.Stop()
+		h.timer = nil
+		revertTo = h.revertTo
+	}
+	if err := h.logger.SetLevel(level); err != nil {
+		return err
+	}
+	h.gen++
+	if ttl > 0 {
+		gen := h.gen
+		h.revertTo, h.setLevel = revertTo, level
+		h.revertAt = time.Now().Add(ttl)
+		h.timer = time.AfterFunc(ttl, func() { h.revert(gen) })
+	}
+	return nil
+}
+
+// revert restores the level saved by the PUT of generation gen.
+func (h *levelHandler) revert(gen uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if gen != h.gen {
+		return
+	}
+	h.timer = nil
+	if h.logger.GetLevel() == h.setLevel {
+		_ = h.logger.SetLevel(h.revertTo)
+	}
+}
+
+// status builds the GET response.
+func (h *levelHandler) status() LevelStatus {
+	status := LevelStatus{
+		Level:   h.logger.GetLevel().String(),
+		Writers: []WriterStatus{},
+	}
+
+	h.mu.Lock()
+	if h.timer != nil {
+		status.Revert = &LevelRevert{Level: h.revertTo.String(), At: h.revertAt}
+	}
+	h.mu.Unlock()
+
+	if s := h.logger.GetSampling(); s != nil {
+		status.Sampling = &SamplingStatus{
+			Initial:       s.Initial,
+			Thereafter:    s.Thereafter,
+			Tick:          s.Tick.String(),
+			Deterministic: s.Deterministic,
+			Rate:          s.Rate,
+		}
+	}
+
+	for _, s := range h.logger.WriterStats() {
+		ws := WriterStatus{
+			Index:             s.Index,
+			Writer:            fmt.Sprintf("%T", s.Writer),
+			Writes:            s.Writes,
+			BytesWritten:      s.BytesWritten,
+			Errors:            s.Errors,
+			ConsecutiveErrors: s.ConsecutiveErrors,
+			Dropped:           s.Dropped,
+			LastErrorTime:     s.LastErrorTime,
+			MaxLatency:        s.MaxLatency.String(),
+		}
+		if s.LastError != nil {
+			ws.LastError = s.LastError.Error()
+		}
+		status.Writers = append(status.Writers, ws)
+	}
+	return status
+}
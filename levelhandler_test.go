@@ -0,0 +1,127 @@
+package dd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func serveLevel(t *testing.T, h http.Handler, method, target, body string) (*httptest.ResponseRecorder, LevelStatus) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	var status LevelStatus
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+			t.Fatalf("invalid response %q: %v", rec.Body.String(), err)
+		}
+	}
+	return rec, status
+}
+
+func TestLevelHandlerGet(t *testing.T) {
+	logger, _ := newTestLogger(t, func(cfg *Config) {
+		cfg.Sampling = &SamplingConfig{Enabled: true, Initial: 10, Thereafter: 5, Tick: time.Second}
+	})
+	logger.Info("one")
+	h := LevelHandler(logger)
+
+	rec, status := serveLevel(t, h, http.MethodGet, "/", "")
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected response: %d %v", rec.Code, rec.Header())
+	}
+	if status.Level != "INFO" || status.Revert != nil {
+		t.Errorf("unexpected level status: %+v", status)
+	}
+	if status.Sampling == nil || status.Sampling.Initial != 10 || status.Sampling.Tick != "1s" {
+		t.Errorf("unexpected sampling status: %+v", status.Sampling)
+	}
+	if len(status.Writers) != 1 || status.Writers[0].Writes != 1 || status.Writers[0].Writer != "*bytes.Buffer" {
+		t.Errorf("unexpected writer status: %+v", status.Writers)
+	}
+}
+
+func TestLevelHandlerPut(t *testing.T) {
+	logger, _ := newTestLogger(t, nil)
+	h := LevelHandler(logger)
+
+	if _, status := serveLevel(t, h, http.MethodPut, "/?level=warn", ""); status.Level != "WARN" {
+		t.Errorf("query PUT should set WARN: %+v", status)
+	}
+	if _, status := serveLevel(t, h, http.MethodPut, "/", `{"level":"error"}`); status.Level != "ERROR" {
+		t.Errorf("JSON PUT should set ERROR: %+v", status)
+	}
+	if logger.GetLevel() != LevelError {
+		t.Errorf("logger level should be ERROR, got %v", logger.GetLevel())
+	}
+
+	for _, body := range []string{`{"level":"loud"}`, `{}`, `not json`, `{"level":"debug","ttl":"-1m"}`} {
+		if rec, _ := serveLevel(t, h, http.MethodPut, "/", body); rec.Code != http.StatusBadRequest {
+			t.Errorf("PUT %s: expected 400, got %d", body, rec.Code)
+		}
+	}
+	if rec, _ := serveLevel(t, h, http.MethodPost, "/", ""); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST should not be allowed, got %d", rec.Code)
+	}
+	if logger.GetLevel() != LevelError {
+		t.Errorf("rejected requests should not change the level, got %v", logger.GetLevel())
+	}
+}
+
+func TestLevelHandlerRevert(t *testing.T) {
+	logger, _ := newTestLogger(t, nil)
+	h := LevelHandler(logger)
+
+	_, status := serveLevel(t, h, http.MethodPut, "/?level=debug&ttl=10m", "")
+	if status.Level != "DEBUG" || status.Revert == nil || status.Revert.Level != "INFO" {
+		t.Fatalf("expected DEBUG with a revert to INFO: %+v", status)
+	}
+
+	// Extending keeps the original target
+	_, status = serveLevel(t, h, http.MethodPut, "/?level=debug&ttl=30ms", "")
+	if status.Revert == nil || status.Revert.Level != "INFO" {
+		t.Fatalf("revert target should stay INFO: %+v", status.Revert)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for logger.GetLevel() != LevelInfo && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if logger.GetLevel() != LevelInfo {
+		t.Fatalf("level should revert to INFO, got %v", logger.GetLevel())
+	}
+	if _, status = serveLevel(t, h, http.MethodGet, "/", ""); status.Revert != nil {
+		t.Errorf("no revert should be pending: %+v", status.Revert)
+	}
+}
+
+func TestLevelHandlerRevertSkippedAfterManualChange(t *testing.T) {
+	logger, _ := newTestLogger(t, nil)
+	h := LevelHandler(logger).(*levelHandler)
+
+	serveLevel(t, h, http.MethodPut, "/?level=debug&ttl=10m", "")
+	_ = logger.SetLevel(LevelWarn)
+	h.revert(h.gen)
+
+	if logger.GetLevel() != LevelWarn {
+		t.Errorf("a level set by other means should be kept, got %v", logger.GetLevel())
+	}
+}
+
+func TestLevelHandlerPutWithoutTTLCancelsRevert(t *testing.T) {
+	logger, _ := newTestLogger(t, nil)
+	h := LevelHandler(logger)
+
+	serveLevel(t, h, http.MethodPut, "/?level=debug&ttl=20ms", "")
+	_, status := serveLevel(t, h, http.MethodPut, "/?level=warn", "")
+	if status.Revert != nil {
+		t.Errorf("PUT without ttl should cancel the revert: %+v", status.Revert)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if logger.GetLevel() != LevelWarn {
+		t.Errorf("level should stay WARN, got %v", logger.GetLevel())
+	}
+}
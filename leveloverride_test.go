@@ -0,0 +1,72 @@
+package dd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestWithLevelOverride(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := New(&Config{Level: LevelInfo, Format: FormatText, Output: &buf})
+	defer logger.Close()
+
+	debugCtx := WithLevelOverride(context.Background(), LevelDebug)
+	logger.DebugCtx(context.Background(), "plain debug")
+	logger.DebugCtx(debugCtx, "request debug")
+	logger.EventCtx(debugCtx, LevelDebug).Str("k", "v").Msg("event debug")
+	logger.Debug("no context")
+
+	quietCtx := WithLevelOverride(context.Background(), LevelError)
+	logger.WarnCtx(quietCtx, "quiet warn")
+	logger.ErrorCtx(quietCtx, "quiet error")
+
+	out := buf.String()
+	for _, want := range []string{"request debug", "event debug", "quiet error"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q: %q", want, out)
+		}
+	}
+	for _, unwanted := range []string{"plain debug", "no context", "quiet warn"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("unexpected %q: %q", unwanted, out)
+		}
+	}
+}
+
+func TestWithLevelOverrideBeatsResolverAndTenant(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := New(&Config{Level: LevelInfo, Format: FormatText, Output: &buf})
+	defer logger.Close()
+	logger.SetLevelResolver(func(context.Context) LogLevel { return LevelError })
+
+	ctx := WithLevelOverride(context.Background(), LevelDebug)
+	logger.DebugCtx(ctx, "resolver overridden")
+	logger.WithFields(String("a", "b")).DebugCtx(ctx, "entry overridden")
+
+	if err := logger.SetTenantLevel("acme", LevelError); err != nil {
+		t.Fatal(err)
+	}
+	logger.Tenant("acme").DebugCtx(ctx, "tenant overridden")
+
+	out := buf.String()
+	for _, want := range []string{"resolver overridden", "entry overridden", "tenant overridden"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q: %q", want, out)
+		}
+	}
+}
+
+func TestLevelOverride(t *testing.T) {
+	if _, ok := LevelOverride(context.Background()); ok {
+		t.Error("plain context should have no override")
+	}
+	ctx := WithLevelOverride(context.Background(), LevelWarn)
+	if level, ok := LevelOverride(ctx); !ok || level != LevelWarn {
+		t.Errorf("LevelOverride = %v, %v", level, ok)
+	}
+	if WithLevelOverride(ctx, LogLevel(42)) != ctx {
+		t.Error("an invalid level should leave the context unchanged")
+	}
+}
@@ -0,0 +1,102 @@
+package dd
+
+import "io"
+
+// levelSet is a bit set of log levels.
+type levelSet uint8
+
+const allLevels = levelSet(1<<(LevelFatal+1) - 1)
+
+func (s levelSet) has(level LogLevel) bool {
+	return level >= LevelDebug && level <= LevelFatal && s&(1<<level) != 0
+}
+
+// levelRouteWriter passes only entries whose level is in levels. It backs
+// Config.LevelOutputs. Unlike LevelFilterWriter it is not a MinLevelWriter,
+// so the logger level still applies.
+type levelRouteWriter struct {
+	writer io.Writer
+	levels levelSet
+}
+
+// Write writes p to the wrapped writer unconditionally.
+func (rw *levelRouteWriter) Write(p []byte) (int, error) {
+	return rw.writer.Write(p)
+}
+
+// WriteLevel implements LevelWriter.
+func (rw *levelRouteWriter) WriteLevel(level LogLevel, p []byte) (int, error) {
+	if !rw.levels.has(level) {
+		return len(p), nil
+	}
+	if w, ok := rw.writer.(LevelWriter); ok {
+		return w.WriteLevel(level, p)
+	}
+	return rw.writer.Write(p)
+}
+
+// WriteRecord implements RecordWriter.
+func (rw *levelRouteWriter) WriteRecord(rec *Record, p []byte) (int, error) {
+	if !rw.levels.has(rec.Level) {
+		return len(p), nil
+	}
+	return forwardRecord(rw.writer, rec, p)
+}
+
+// Flush flushes the wrapped writer if it implements Flusher.
+func (rw *levelRouteWriter) Flush() error {
+	if f, ok := rw.writer.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Close closes the wrapped writer.
+func (rw *levelRouteWriter) Close() error {
+	return closeWriter(rw.writer)
+}
+
+// routeLevelOutputs combines the regular writers with the per-level routes.
+// Regular writers receive the levels without a route. A writer that appears
+// more than once is wrapped once with the union of its levels, so it never
+// receives an entry twice.
+func routeLevelOutputs(regular []io.Writer, routes map[LogLevel][]io.Writer) []io.Writer {
+	var routed levelSet
+	for level, writers := range routes {
+		if len(writers) > 0 {
+			routed |= 1 << level
+		}
+	}
+
+	var order []io.Writer
+	var sets []levelSet
+	add := func(w io.Writer, levels levelSet) {
+		for i, existing := range order {
+			if existing == w {
+				sets[i] |= levels
+				return
+			}
+		}
+		order = append(order, w)
+		sets = append(sets, levels)
+	}
+
+	for _, w := range regular {
+		add(w, allLevels&^routed)
+	}
+	for level := LevelDebug; level <= LevelFatal; level++ {
+		for _, w := range routes[level] {
+			add(w, 1<<level)
+		}
+	}
+
+	result := make([]io.Writer, len(order))
+	for i, w := range order {
+		if sets[i] == allLevels {
+			result[i] = w
+		} else {
+			result[i] = &levelRouteWriter{writer: w, levels: sets[i]}
+		}
+	}
+	return result
+}
@@ -0,0 +1,160 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRouteLevel(t *testing.T) {
+	var stdout, stderr, errFile bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Level = LevelDebug
+	cfg.Output = &stdout
+	cfg.RouteLevel(LevelError, &stderr, &errFile)
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Debug("debug entry")
+	logger.Info("info entry")
+	logger.Warn("warn entry")
+	logger.ErrorWith("error entry", String("k", "v"))
+
+	for _, msg := range []string{"debug entry", "info entry", "warn entry"} {
+		if !strings.Contains(stdout.String(), msg) {
+			t.Errorf("stdout missing %q", msg)
+		}
+		if strings.Contains(stderr.String(), msg) || strings.Contains(errFile.String(), msg) {
+			t.Errorf("%q should not be routed to error outputs", msg)
+		}
+	}
+	if strings.Contains(stdout.String(), "error entry") {
+		t.Error("error entry should not reach stdout")
+	}
+	if !strings.Contains(stderr.String(), "error entry") || !strings.Contains(errFile.String(), "error entry") {
+		t.Error("error entry should reach both error outputs")
+	}
+}
+
+func TestLevelOutputsExactLevel(t *testing.T) {
+	var regular, warnOnly bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &regular
+	cfg.LevelOutputs = map[LogLevel][]io.Writer{LevelWarn: {&warnOnly}}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Info("info entry")
+	logger.Warn("warn entry")
+	logger.Error("error entry")
+
+	if got := warnOnly.String(); !strings.Contains(got, "warn entry") || strings.Contains(got, "error entry") {
+		t.Errorf("warn writer should only get WARN, got %q", got)
+	}
+	if got := regular.String(); !strings.Contains(got, "info entry") || !strings.Contains(got, "error entry") || strings.Contains(got, "warn entry") {
+		t.Errorf("regular writer should get unrouted levels only, got %q", got)
+	}
+}
+
+func TestLevelOutputsSharedWriter(t *testing.T) {
+	var all bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &all
+	cfg.RouteLevel(LevelError, &all)
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Info("info entry")
+	logger.Error("error entry")
+
+	if logger.WriterCount() != 1 {
+		t.Errorf("shared writer should be registered once, got %d", logger.WriterCount())
+	}
+	if n := strings.Count(all.String(), "error entry"); n != 1 {
+		t.Errorf("error entry written %d times, want 1", n)
+	}
+	if !strings.Contains(all.String(), "info entry") {
+		t.Error("info entry missing")
+	}
+}
+
+func TestLevelOutputsRespectLoggerLevel(t *testing.T) {
+	var debugOut bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Level = LevelInfo
+	cfg.Output = io.Discard
+	cfg.RouteLevel(LevelDebug, &debugOut)
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Debug("hidden")
+	logger.Info("shown")
+
+	if strings.Contains(debugOut.String(), "hidden") {
+		t.Error("routes must not bypass the logger level")
+	}
+	if !strings.Contains(debugOut.String(), "shown") {
+		t.Error("routed writer should receive INFO")
+	}
+}
+
+func TestLevelOutputsRecordWriter(t *testing.T) {
+	rw := &recordingWriter{}
+	cfg := DefaultConfig()
+	cfg.Output = io.Discard
+	cfg.RouteLevel(LevelWarn, rw)
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Info("info entry")
+	logger.WarnWith("warn entry", String("k", "v"))
+
+	if len(rw.records) != 1 || rw.records[0].Message != "warn entry" {
+		t.Errorf("expected only the warn record, got %+v", rw.records)
+	}
+}
+
+func TestLevelOutputsValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.LevelOutputs = map[LogLevel][]io.Writer{LogLevel(42): {io.Discard}}
+	if _, err := New(cfg); !errors.Is(err, ErrInvalidLevel) {
+		t.Errorf("expected ErrInvalidLevel, got %v", err)
+	}
+
+	cfg = DefaultConfig()
+	cfg.LevelOutputs = map[LogLevel][]io.Writer{LevelError: {nil}}
+	if _, err := New(cfg); err == nil {
+		t.Error("expected error for nil routed writer")
+	}
+}
+
+func TestLevelOutputsClone(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RouteLevel(LevelError, io.Discard)
+	clone := cfg.Clone()
+	clone.RouteLevel(LevelFatal, &bytes.Buffer{})
+
+	if len(cfg.LevelOutputs[LevelFatal]) != 1 {
+		t.Errorf("clone should not share LevelOutputs slices, got %d", len(cfg.LevelOutputs[LevelFatal]))
+	}
+	if len(clone.LevelOutputs[LevelFatal]) != 2 {
+		t.Errorf("clone should have 2 fatal writers, got %d", len(clone.LevelOutputs[LevelFatal]))
+	}
+}
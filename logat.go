@@ -0,0 +1,55 @@
+package dd
+
+import "time"
+
+// IngestTimeKey is the field holding the time an entry with an explicit
+// timestamp was logged, when Config.IngestTime is set.
+const IngestTimeKey = "ingest_time"
+
+// LogAt logs a structured message stamped with t instead of the clock
+// time, so replayed or imported events keep their original timestamps. A
+// zero t uses the clock time. With Config.IngestTime the entry also gets
+// an IngestTimeKey field holding the time it was logged.
+//
+// Example:
+//
+//	for _, ev := range imported {
+//	    logger.LogAt(ev.Time, dd.LevelInfo, ev.Message, dd.String("source", "backfill"))
+//	}
+func (l *Logger) LogAt(t time.Time, level LogLevel, msg string, fields ...Field) {
+	if !l.shouldLog(level) {
+		return
+	}
+
+	var originalFields []Field
+	if l.hooks.Load() != nil && len(fields) > 0 {
+		originalFields = make([]Field, len(fields))
+		copy(originalFields, fields)
+	}
+
+	l.logCore(level, logEntry{
+		msg:            l.applyMessageSecurity(msg),
+		fields:         l.processFields(fields),
+		originalFields: originalFields,
+		time:           t,
+	})
+}
+
+// At stamps the event with t instead of the clock time. See Logger.LogAt.
+// Time, by contrast, adds a time field.
+func (e *Event) At(t time.Time) *Event {
+	if e == nil {
+		return nil
+	}
+	e.time = t
+	return e
+}
+
+// addIngestTime adds an IngestTimeKey field to entries with an explicit
+// timestamp when Config.IngestTime is set.
+func (l *Logger) addIngestTime(entry *logEntry) {
+	if !l.ingestTime || entry.time.IsZero() {
+		return
+	}
+	entry.fields = append(entry.fields[:len(entry.fields):len(entry.fields)], Time(IngestTimeKey, l.clock.Now()))
+}
@@ -0,0 +1,68 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogAt(t *testing.T) {
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	past := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var buf bytes.Buffer
+	cfg := JSONConfig()
+	cfg.Output = &buf
+	cfg.Clock = NewManualClock(now)
+	cfg.IngestTime = true
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.LogAt(past, LevelInfo, "imported", String("source", "backfill"))
+	logger.Event(LevelWarn).At(past).Str("k", "v").Msg("event")
+	logger.Info("live")
+	logger.Close()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines: %q", len(lines), buf.String())
+	}
+	for i, want := range []string{"2020-01-02T03:04:05Z", "2020-01-02T03:04:05Z", "2026-03-04T05:06:07Z"} {
+		var entry struct {
+			Timestamp string         `json:"timestamp"`
+			Fields    map[string]any `json:"fields"`
+		}
+		if err := json.Unmarshal([]byte(lines[i]), &entry); err != nil {
+			t.Fatal(err)
+		}
+		if entry.Timestamp != want {
+			t.Errorf("line %d: timestamp = %q, want %q", i, entry.Timestamp, want)
+		}
+		ingest, ok := entry.Fields[IngestTimeKey]
+		if explicit := i < 2; ok != explicit {
+			t.Errorf("line %d: has %s = %v, want %v", i, IngestTimeKey, ok, explicit)
+		} else if ok && ingest != "2026-03-04T05:06:07Z" {
+			t.Errorf("line %d: %s = %v", i, IngestTimeKey, ingest)
+		}
+	}
+}
+
+func TestLogAtText(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.TimeFormat = time.DateTime
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.LogAt(time.Date(2019, 5, 6, 7, 8, 9, 0, time.UTC), LevelInfo, "old")
+	logger.Close()
+
+	if got := buf.String(); !strings.HasPrefix(got, "[2019-05-06 07:08:09") || strings.Contains(got, IngestTimeKey) {
+		t.Errorf("output = %q", got)
+	}
+}
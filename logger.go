@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -37,6 +39,38 @@ const (
 	LevelFatal = internal.LevelFatal
 )
 
+// ParseLevel converts a level name ("debug", "info", "warn"/"warning",
+// "error", "fatal") to a LogLevel. Matching is case-insensitive.
+// Returns an error wrapping ErrInvalidLevel for unknown names.
+//
+// LogLevel also implements encoding.TextMarshaler and TextUnmarshaler,
+// so levels can be used directly in JSON, YAML or flag configuration.
+//
+// Example:
+//
+//	level, err := dd.ParseLevel(os.Getenv("LOG_LEVEL"))
+func ParseLevel(s string) (LogLevel, error) {
+	return internal.ParseLevel(s)
+}
+
+// ParseFormat converts a format name ("text", "json", "console" or a name
+// passed to RegisterFormat) to a LogFormat, so config loaders can reject
+// typos instead of falling back to text. Built-in names are matched
+// case-insensitively. Returns an error wrapping ErrInvalidFormat for
+// unknown names.
+//
+// LogFormat also implements encoding.TextMarshaler and TextUnmarshaler.
+//
+// Example:
+//
+//	format, err := dd.ParseFormat(*formatFlag)
+//	if err != nil {
+//	    log.Fatal(err) // invalid log format: "jsno" (valid: text, json, console)
+//	}
+func ParseFormat(s string) (LogFormat, error) {
+	return internal.ParseFormat(s)
+}
+
 type FatalHandler func()
 
 type WriteErrorHandler func(writer io.Writer, err error)
@@ -69,8 +103,25 @@ type Logger struct {
 	level  atomic.Int32
 	closed atomic.Bool
 
+	// nopEntry is non-nil for loggers created by Nop, which discard every
+	// entry. WithFields returns it so discarded calls do not allocate.
+	nopEntry *LoggerEntry
+
+	// tee is non-nil for loggers created by Tee, which pass entries on to
+	// other loggers instead of writing them.
+	tee *teeState
+
 	callerDepth       int
+	clock             Clock
 	fatalHandler      FatalHandler
+	fatalPolicy       FatalPolicy
+	fatalTimeout      time.Duration
+	fatalStackDump    bool
+	crashDumpPath     string
+	goroutineID       bool         // Config.IncludeGoroutineID
+	jsonFormat        bool         // Config.Format is FormatJSON
+	strictJSON        bool         // Config.JSON.Strict applies
+	multilineText     bool         // Config.Text.ContinuationPrefix applies
 	writeErrorHandler atomic.Value // stores WriteErrorHandler
 	formatter         *internal.MessageFormatter
 
@@ -83,13 +134,29 @@ type Logger struct {
 	// When set, field keys are validated against the configured naming convention.
 	fieldValidation atomic.Pointer[FieldValidationConfig]
 
+	// fieldStats counts field validation violations.
+	fieldStats fieldValidationCounters
+
+	// schema is Config.Schema (nil when disabled).
+	schema *LogSchema
+
+	// schemaStats counts schema violations.
+	schemaStats schemaCounters
+
 	// writersPtr stores an immutable slice of writers using atomic pointer.
 	// This eliminates slice copying during write operations.
 	// The slice is replaced atomically when writers are added/removed.
 	writersPtr     atomic.Pointer[[]io.Writer]
-	writersMu      sync.Mutex // protects AddWriter/RemoveWriter operations
+	writerStats    atomic.Pointer[writerStatsSet] // per-writer counters (see WriterStats)
+	writersMu      sync.Mutex                     // protects AddWriter/RemoveWriter operations
+	writerMW       []WriterMiddleware             // Config.WriterMiddleware, applied by AddWriter
 	securityConfig atomic.Value
 
+	// hasMinLevelWriters and minWriterLevel cache the lowest MinLevel among
+	// writers implementing MinLevelWriter. Updated whenever writers change.
+	hasMinLevelWriters atomic.Bool
+	minWriterLevel     atomic.Int32
+
 	// contextExtractors stores the ContextExtractorRegistry for extracting
 	// fields from context. If nil, default extractors are used.
 	contextExtractors atomic.Value // stores *ContextExtractorRegistry
@@ -103,6 +170,82 @@ type Logger struct {
 
 	// sampling stores the sampling configuration and state.
 	sampling atomic.Value // stores *samplingState
+	// samplingCounts counts the entries sampling kept and dropped.
+	samplingCounts samplingCounters
+
+	// rateLimit stores the rate limiter state; rateLimitDropped counts the
+	// entries it dropped per level over the logger's lifetime.
+	rateLimit        atomic.Pointer[rateLimitState]
+	rateLimitDropped [LevelFatal + 1]atomic.Int64
+
+	// adaptive is the adaptive sampling controller (nil when disabled).
+	adaptive *adaptiveState
+
+	// events holds the Config.Events rules and counters of Emit.
+	events *eventsState
+
+	// memory enforces Config.MaxMemoryBytes (nil when unlimited).
+	memory *memoryState
+
+	// quarantine is the QuarantineConfig with defaults applied (nil when
+	// disabled); the per-writer state is in writerStats.
+	quarantine *QuarantineConfig
+
+	// extractorGuard is the ExtractorGuardConfig with defaults applied
+	// (nil when disabled).
+	extractorGuard *ExtractorGuardConfig
+
+	// runtimeStats is Config.RuntimeStats (nil when disabled).
+	runtimeStats *RuntimeStatsConfig
+
+	// gates holds the per-key state of Once, EveryN and Every.
+	gates logGates
+
+	// fingerprint computes FingerprintKey values (nil when disabled).
+	fingerprint FingerprintFunc
+
+	// ingestTime is Config.IngestTime.
+	ingestTime bool
+
+	// shutdownHooks are the hooks registered with OnShutdown, in
+	// registration order; shutdownMu protects them.
+	shutdownMu    sync.Mutex
+	shutdownHooks []ShutdownHook
+
+	// internalHandler is Config.InternalErrorHandler (nil for the package
+	// handler or stderr).
+	internalHandler InternalErrorHandler
+
+	// valueEncoders render field values of types the formatter does not
+	// know (Config.ValueEncoders).
+	valueEncoders []ValueEncoder
+
+	// encoder renders entries of a format added with RegisterFormat (nil
+	// for built-in formats); globalFields are passed to it with each entry.
+	encoder      FormatEncoder
+	globalFields []Field
+
+	// contextPolicy stores the ContextPolicy for *Ctx methods (nil for none).
+	contextPolicy atomic.Pointer[ContextPolicy]
+
+	// fieldConflicts stores the FieldConflictPolicy.
+	fieldConflicts atomic.Int32
+
+	// tenants maps tenant IDs to their per-tenant overrides (see Tenant).
+	tenants sync.Map // map[string]*tenantState
+
+	// names maps logger names to their level state (see Named); nameRules
+	// holds the SetLevels rules. namesMu guards nameRules and the creation
+	// of states so none misses a SetLevels call.
+	names     sync.Map // map[string]*tenantState
+	nameRules map[string]LogLevel
+	namesMu   sync.Mutex
+
+	// children holds the loggers created with Child.
+	children childSet
+
+	// securityFilters caches the filters built for WithSecurity.
+	securityFilters sync.Map // map[SecurityLevel]*entrySecurity
 
 	// ctx and cancel provide graceful shutdown for background operations.
 	// When Close() is called, cancel() signals all background goroutines
@@ -114,10 +257,12 @@ type Logger struct {
 
 // samplingState holds the runtime state for log sampling.
 type samplingState struct {
-	config  *SamplingConfig
-	counter atomic.Int64 // Atomic counter for thread-safe increment
-	start   time.Time
-	startMu sync.Mutex // Only protects start time reset during tick
+	config      *SamplingConfig
+	defaultRule *sampleRule                 // Shared by levels without an override
+	rules       [LevelFatal + 1]*sampleRule // Per level; counters are atomic
+	start       time.Time
+	startMu     sync.Mutex // Only protects start time reset during tick
+	clock       Clock
 }
 
 var (
@@ -166,19 +311,38 @@ func newFromInternalConfig(config *internalConfig) (*Logger, error) {
 	formatterConfig := &internal.FormatterConfig{
 		Format:        internal.LogFormat(config.format),
 		TimeFormat:    config.timeFormat,
+		TimeLocation:  config.timeLocation,
+		TimePrecision: config.timePrecision,
+		TimeEpoch:     config.timeEpoch,
 		IncludeTime:   config.includeTime,
 		IncludeLevel:  config.includeLevel,
 		FullPath:      config.fullPath,
 		DynamicCaller: config.dynamicCaller,
 		JSON:          config.json,
+		Text:          config.text,
+		Console:       config.console,
+		GlobalFields:  config.globalFields,
 	}
+	clock := clockOrSystem(config.clock)
+	formatterConfig.Now = clock.Now
 
 	l := &Logger{
-		callerDepth:  defaultCallerDepth,
-		fatalHandler: config.fatalHandler,
-		formatter:    internal.NewMessageFormatter(formatterConfig),
-		ctx:          ctx,
-		cancel:       cancel,
+		callerDepth:    defaultCallerDepth,
+		clock:          clock,
+		fatalHandler:   config.fatalHandler,
+		fatalPolicy:    config.fatalPolicy,
+		fatalTimeout:   config.fatalTimeout,
+		fatalStackDump: config.fatalStackDump,
+		crashDumpPath:  config.crashDumpPath,
+		goroutineID:    config.goroutineID,
+		jsonFormat:     config.format == FormatJSON,
+		strictJSON:     config.format == FormatJSON && config.json != nil && config.json.Strict,
+		multilineText:  config.format == FormatText && config.text != nil && config.text.ContinuationPrefix != "",
+		formatter:      internal.NewMessageFormatter(formatterConfig),
+		encoder:        formatEncoder(config.format),
+		globalFields:   config.globalFields,
+		ctx:            ctx,
+		cancel:         cancel,
 	}
 
 	// Initialize writers pointer with empty slice
@@ -215,14 +379,77 @@ func newFromInternalConfig(config *internalConfig) (*Logger, error) {
 		l.SetSampling(config.sampling)
 	}
 
+	if config.rateLimit != nil {
+		l.SetRateLimit(config.rateLimit)
+	}
+
+	l.events = newEventsState(config.events)
+
+	if config.maxMemoryBytes > 0 {
+		l.memory = newMemoryState(config.maxMemoryBytes, config.memoryPolicy)
+	}
+
+	if config.adaptive != nil {
+		l.adaptive = newAdaptiveState(config.adaptive)
+	}
+
+	if config.quarantine != nil {
+		quarantine := config.quarantine.withDefaults()
+		l.quarantine = &quarantine
+	}
+
+	if config.extractorGuard != nil {
+		guard := config.extractorGuard.withDefaults()
+		l.extractorGuard = &guard
+	}
+
+	if config.runtimeStats != nil {
+		l.runtimeStats = config.runtimeStats
+	}
+
+	l.valueEncoders = config.valueEncoders
+	l.ingestTime = config.ingestTime
+	l.schema = config.schema
+	l.internalHandler = config.internalHandler
+	l.shutdownHooks = config.shutdownHooks
+	if config.fingerprintFunc != nil {
+		l.fingerprint = config.fingerprintFunc
+	} else if config.fingerprint {
+		l.fingerprint = DefaultFingerprint
+	}
+
+	if config.contextPolicy != nil {
+		l.SetContextPolicy(config.contextPolicy)
+	}
+
+	l.fieldConflicts.Store(int32(config.fieldConflicts))
+
 	if config.writers != nil {
 		for _, writer := range config.writers {
-			if err := l.AddWriter(writer); err != nil {
+			writer, err := applyConfigMiddleware(writer, config.writerMiddleware)
+			if err == nil {
+				err = l.AddWriter(writer)
+			}
+			if err != nil {
 				cancel()
 				return nil, fmt.Errorf("failed to add writer: %w", err)
 			}
 		}
 	}
+	l.writerMW = config.writerMiddleware
+
+	if config.buildInfo == BuildInfoBanner {
+		l.logBuildInfo()
+	}
+
+	// Background goroutines log through l, so they start once it is
+	// fully built.
+	if l.adaptive != nil {
+		go l.runAdaptive()
+	}
+	if l.runtimeStats != nil && l.runtimeStats.Interval > 0 {
+		go l.runRuntimeStats(l.runtimeStats.Interval)
+	}
 
 	return l, nil
 }
@@ -231,6 +458,9 @@ func newFromInternalConfig(config *internalConfig) (*Logger, error) {
 // When a write operation fails, the handler is called with the writer and error.
 // If no handler is set, write errors are silently ignored.
 func (l *Logger) SetWriteErrorHandler(handler WriteErrorHandler) {
+	if l == nil {
+		return
+	}
 	if handler != nil {
 		l.writeErrorHandler.Store(handler)
 	} else {
@@ -249,24 +479,59 @@ func (l *Logger) getWriteErrorHandler() WriteErrorHandler {
 
 // shouldLog checks if a message should be logged based on level and logger state
 func (l *Logger) shouldLog(level LogLevel) bool {
-	// Check dynamic level resolver first
+	return l.shouldLogCtx(context.Background(), level)
+}
+
+// shouldLogCtx is like shouldLog but passes ctx to the level resolver.
+func (l *Logger) shouldLogCtx(ctx context.Context, level LogLevel) bool {
+	if l == nil || level > LevelFatal || l.nopEntry != nil {
+		return false
+	}
+	if level < l.effectiveLevel(ctx) && !l.wantedByMinLevelWriter(level) {
+		return false
+	}
+	if l.closed.Load() || l.skipForContext(ctx, level) {
+		return false
+	}
+	return l.shouldSample(ctx, level) && l.allowAdaptive(level) && l.allowRate(level)
+}
+
+// effectiveLevel returns the level set by WithLevelOverride, the level
+// from the dynamic resolver if set, or the static level, in that order.
+func (l *Logger) effectiveLevel(ctx context.Context) LogLevel {
+	if level, ok := LevelOverride(ctx); ok {
+		return level
+	}
 	if resolver := l.getLevelResolver(); resolver != nil {
 		// Use context.Background() as default to prevent nil pointer panics
-		effectiveLevel := resolver(context.Background())
-		if level < effectiveLevel || level > LevelFatal {
-			return false
-		}
-	} else {
-		// Use static level
-		currentLevel := LogLevel(l.level.Load())
-		if level < currentLevel || level > LevelFatal {
-			return false
+		if ctx == nil {
+			ctx = context.Background()
 		}
+		return resolver(ctx)
 	}
-	if l.closed.Load() {
-		return false
+	return LogLevel(l.level.Load())
+}
+
+// wantedByMinLevelWriter reports whether any MinLevelWriter accepts level.
+func (l *Logger) wantedByMinLevelWriter(level LogLevel) bool {
+	return l.hasMinLevelWriters.Load() && level >= LogLevel(l.minWriterLevel.Load())
+}
+
+// storeWriters atomically publishes writers and refreshes the cached
+// MinLevelWriter threshold. Caller must hold writersMu.
+func (l *Logger) storeWriters(writers []io.Writer) {
+	found := false
+	lowest := LevelFatal
+	for _, w := range writers {
+		if mlw, ok := w.(MinLevelWriter); ok {
+			found = true
+			lowest = min(lowest, mlw.MinLevel())
+		}
 	}
-	return l.shouldSample()
+	l.minWriterLevel.Store(int32(lowest))
+	l.hasMinLevelWriters.Store(found)
+	l.storeWriterStats(&writers)
+	l.writersPtr.Store(&writers)
 }
 
 // ============================================================================
@@ -275,11 +540,17 @@ func (l *Logger) shouldLog(level LogLevel) bool {
 
 // GetLevel returns the current log level (thread-safe).
 func (l *Logger) GetLevel() LogLevel {
+	if l == nil {
+		return LevelFatal
+	}
 	return LogLevel(l.level.Load())
 }
 
 // SetLevel atomically sets the log level (thread-safe).
 func (l *Logger) SetLevel(level LogLevel) error {
+	if l == nil {
+		return ErrNilLogger
+	}
 	if level < LevelDebug || level > LevelFatal {
 		return ErrInvalidLevel
 	}
@@ -288,7 +559,8 @@ func (l *Logger) SetLevel(level LogLevel) error {
 }
 
 // IsLevelEnabled checks if logging is enabled for the given level (thread-safe).
-// Returns true if the logger's level is at or below the specified level.
+// Returns true if the logger's level is at or below the specified level,
+// or if a MinLevelWriter accepts it.
 //
 // Example:
 //
@@ -297,8 +569,11 @@ func (l *Logger) SetLevel(level LogLevel) error {
 //	    logger.DebugWith("Details", dd.Any("data", computeExpensiveDebugInfo()))
 //	}
 func (l *Logger) IsLevelEnabled(level LogLevel) bool {
+	if l == nil || l.nopEntry != nil {
+		return false
+	}
 	currentLevel := LogLevel(l.level.Load())
-	return level >= currentLevel
+	return level >= currentLevel || l.wantedByMinLevelWriter(level)
 }
 
 // IsDebugEnabled returns true if debug level logging is enabled.
@@ -342,6 +617,9 @@ func (l *Logger) IsFatalEnabled() bool {
 //	    return LevelDebug
 //	})
 func (l *Logger) SetLevelResolver(resolver LevelResolver) {
+	if l == nil {
+		return
+	}
 	if resolver == nil {
 		l.levelResolver.Store(nil)
 	} else {
@@ -352,6 +630,9 @@ func (l *Logger) SetLevelResolver(resolver LevelResolver) {
 // GetLevelResolver returns the current level resolver function.
 // Returns nil if no resolver is set.
 func (l *Logger) GetLevelResolver() LevelResolver {
+	if l == nil {
+		return nil
+	}
 	return l.getLevelResolver()
 }
 
@@ -372,6 +653,9 @@ func (l *Logger) getLevelResolver() LevelResolver {
 // If the logger has no extractors, the provided extractor becomes the first one.
 // Returns ErrNilExtractor if the extractor is nil, or ErrLoggerClosed if the logger is closed.
 func (l *Logger) AddContextExtractor(extractor ContextExtractor) error {
+	if l == nil {
+		return ErrNilLogger
+	}
 	if extractor == nil {
 		return ErrNilExtractor
 	}
@@ -399,6 +683,9 @@ func (l *Logger) AddContextExtractor(extractor ContextExtractor) error {
 // Pass no arguments to clear all extractors (which will use default behavior).
 // Returns ErrLoggerClosed if the logger is closed.
 func (l *Logger) SetContextExtractors(extractors ...ContextExtractor) error {
+	if l == nil {
+		return ErrNilLogger
+	}
 	if l.closed.Load() {
 		return ErrLoggerClosed
 	}
@@ -420,15 +707,11 @@ func (l *Logger) SetContextExtractors(extractors ...ContextExtractor) error {
 // GetContextExtractors returns a copy of the current context extractors (thread-safe).
 // Returns nil if no custom extractors are registered.
 func (l *Logger) GetContextExtractors() []ContextExtractor {
+	if l == nil {
+		return nil
+	}
 	if v := l.contextExtractors.Load(); v != nil {
-		registry := v.(*ContextExtractorRegistry)
-		extractorsPtr := registry.extractorsPtr.Load()
-		if extractorsPtr == nil {
-			return nil
-		}
-		extractors := make([]ContextExtractor, len(*extractorsPtr))
-		copy(extractors, *extractorsPtr)
-		return extractors
+		return v.(*ContextExtractorRegistry).extractors()
 	}
 	return nil
 }
@@ -441,6 +724,9 @@ func (l *Logger) GetContextExtractors() []ContextExtractor {
 // Hooks are called in order during the logging lifecycle.
 // Returns ErrNilHook if the hook is nil, or ErrLoggerClosed if the logger is closed.
 func (l *Logger) AddHook(event HookEvent, hook Hook) error {
+	if l == nil {
+		return ErrNilLogger
+	}
 	if hook == nil {
 		return ErrNilHook
 	}
@@ -464,10 +750,63 @@ func (l *Logger) AddHook(event HookEvent, hook Hook) error {
 	return nil
 }
 
+// AddHookWithOptions registers a hook with the given options (thread-safe)
+// and returns its ID for use with RemoveHook.
+// Returns ErrNilHook if the hook is nil, or ErrLoggerClosed if the logger is closed.
+func (l *Logger) AddHookWithOptions(event HookEvent, hook Hook, opts HookOptions) (HookID, error) {
+	if l == nil {
+		return 0, ErrNilLogger
+	}
+	if hook == nil {
+		return 0, ErrNilHook
+	}
+	if l.closed.Load() {
+		return 0, ErrLoggerClosed
+	}
+
+	l.hooksMu.Lock()
+	defer l.hooksMu.Unlock()
+
+	var registry *HookRegistry
+	if v := l.hooks.Load(); v != nil {
+		registry = v.(*HookRegistry).Clone()
+	} else {
+		registry = NewHookRegistry()
+	}
+
+	id := registry.AddWithOptions(event, hook, opts)
+	l.hooks.Store(registry)
+	return id, nil
+}
+
+// RemoveHook removes the hook with the given ID (thread-safe).
+// Returns true if a hook was removed.
+func (l *Logger) RemoveHook(id HookID) bool {
+	if l == nil {
+		return false
+	}
+	l.hooksMu.Lock()
+	defer l.hooksMu.Unlock()
+
+	v := l.hooks.Load()
+	if v == nil {
+		return false
+	}
+	registry := v.(*HookRegistry).Clone()
+	if !registry.RemoveHook(id) {
+		return false
+	}
+	l.hooks.Store(registry)
+	return true
+}
+
 // SetHooks replaces the hook registry with the provided one (thread-safe).
 // Pass nil to clear all hooks.
 // Returns ErrLoggerClosed if the logger is closed.
 func (l *Logger) SetHooks(registry *HookRegistry) error {
+	if l == nil {
+		return ErrNilLogger
+	}
 	if l.closed.Load() {
 		return ErrLoggerClosed
 	}
@@ -488,12 +827,27 @@ func (l *Logger) SetHooks(registry *HookRegistry) error {
 // GetHooks returns a copy of the current hook registry (thread-safe).
 // Returns nil if no hooks are registered.
 func (l *Logger) GetHooks() *HookRegistry {
+	if l == nil {
+		return nil
+	}
 	if v := l.hooks.Load(); v != nil {
 		return v.(*HookRegistry).Clone()
 	}
 	return nil
 }
 
+// drainHooks waits for queued async hooks, bounded by ctx and
+// defaultHookDrainTimeout.
+func (l *Logger) drainHooks(ctx context.Context) error {
+	v := l.hooks.Load()
+	if v == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultHookDrainTimeout)
+	defer cancel()
+	return v.(*HookRegistry).Drain(ctx)
+}
+
 // triggerHooks triggers hooks for the given event and context.
 // Returns an error if any hook returns an error.
 func (l *Logger) triggerHooks(ctx context.Context, hookCtx *HookContext) error {
@@ -511,11 +865,13 @@ func (l *Logger) triggerHooks(ctx context.Context, hookCtx *HookContext) error {
 // shouldSample determines if a log message should be recorded based on sampling configuration.
 // Returns true if:
 //   - Sampling is disabled (default)
+//   - The level's rule keeps every entry
+//   - In deterministic mode, the hash of the context's trace ID is below the rate
 //   - The counter is less than Initial
 //   - The counter modulo Thereafter equals 0
 //
 // Thread-safe using atomic operations for counter and mutex only for tick reset.
-func (l *Logger) shouldSample() bool {
+func (l *Logger) shouldSample(ctx context.Context, level LogLevel) bool {
 	v := l.sampling.Load()
 	if v == nil {
 		return true // No sampling configured
@@ -525,69 +881,149 @@ func (l *Logger) shouldSample() bool {
 	if state.config == nil || !state.config.Enabled {
 		return true
 	}
+	kept := state.sample(ctx, level)
+	l.countSample(level, kept)
+	return kept
+}
+
+// sample advances the sampling counter and reports whether the entry is kept.
+func (state *samplingState) sample(ctx context.Context, level LogLevel) bool {
+	if state.config == nil || !state.config.Enabled {
+		return true
+	}
+
+	rule := state.defaultRule
+	if level >= LevelDebug && level <= LevelFatal {
+		rule = state.rules[level]
+	}
+	if rule.keepAll {
+		return true
+	}
+
+	if state.config.Deterministic && ctx != nil {
+		if traceID := GetTraceID(ctx); traceID != "" {
+			return rule.keepAllTraces || sampleHash(state.config.Seed, traceID) < rule.threshold
+		}
+	}
 
 	// Check if tick interval has passed and reset if needed
 	// This is the only part that needs mutex protection
 	// The time.Since calculation is done inside the lock to ensure strict thread safety
 	if state.config.Tick > 0 {
 		state.startMu.Lock()
-		elapsed := time.Since(state.start)
-		if elapsed >= state.config.Tick {
-			state.counter.Store(0)
-			state.start = time.Now()
+		now := state.clock.Now()
+		if now.Sub(state.start) >= state.config.Tick {
+			state.defaultRule.counter.Store(0)
+			for _, r := range state.rules {
+				r.counter.Store(0)
+			}
+			state.start = now
 		}
 		state.startMu.Unlock()
 	}
 
 	// Atomic increment - no mutex needed
-	count := state.counter.Add(1)
+	count := rule.counter.Add(1)
 
 	// Always log the first Initial messages
-	if count <= int64(state.config.Initial) {
+	if count <= int64(rule.initial) {
 		return true
 	}
 
 	// Log 1 out of every Thereafter messages after Initial
-	if state.config.Thereafter > 0 {
-		return (count-int64(state.config.Initial))%int64(state.config.Thereafter) == 0
+	if rule.thereafter > 0 {
+		return (count-int64(rule.initial))%int64(rule.thereafter) == 0
 	}
 
 	// If Thereafter is 0 after Initial, don't log anymore
 	return false
 }
 
+// sampleRule is the normalized sampling of one or more levels.
+type sampleRule struct {
+	initial       int
+	thereafter    int
+	keepAll       bool
+	keepAllTraces bool   // deterministic rate of 1
+	threshold     uint64 // deterministic: keep hashes below this
+	counter       atomic.Int64
+}
+
+// newSampleRule normalizes one level's settings.
+func newSampleRule(initial, thereafter int, rate float64, keepAll bool) *sampleRule {
+	if initial < 0 {
+		initial = 0
+	}
+	// Thereafter=0 is valid and means "log nothing after Initial"
+	// Thereafter<0 is treated as "log everything" (set to 1)
+	if thereafter < 0 {
+		thereafter = 1
+	}
+	if rate <= 0 && thereafter > 0 {
+		rate = 1 / float64(thereafter)
+	}
+
+	rule := &sampleRule{initial: initial, thereafter: thereafter, keepAll: keepAll}
+	switch {
+	case rate >= 1:
+		rule.keepAllTraces = true
+	case rate > 0:
+		rule.threshold = uint64(math.Ldexp(rate, 64))
+	}
+	return rule
+}
+
+// sampleHash is FNV-1a over the little-endian seed followed by the trace
+// ID. It is stable across processes and releases so that services agree
+// on which traces are kept.
+func sampleHash(seed uint64, traceID string) uint64 {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+	h := uint64(offset64)
+	for i := 0; i < 8; i++ {
+		h ^= (seed >> (8 * i)) & 0xff
+		h *= prime64
+	}
+	for i := 0; i < len(traceID); i++ {
+		h ^= uint64(traceID[i])
+		h *= prime64
+	}
+	return h
+}
+
 // SetSampling enables or disables log sampling at runtime (thread-safe).
 // Pass nil to disable sampling.
 // Note: This method creates a copy of the config to avoid mutating the caller's data.
 func (l *Logger) SetSampling(config *SamplingConfig) {
+	if l == nil {
+		return
+	}
 	if l.closed.Load() {
 		return
 	}
 
+	l.sampling.Store(newSamplingState(config, l.clock))
+}
+
+// newSamplingState returns the runtime state for config, normalizing a copy
+// of it. A nil or disabled config yields a state that keeps every entry.
+func newSamplingState(config *SamplingConfig, clock Clock) *samplingState {
 	if config == nil || !config.Enabled {
-		// Don't store nil in atomic.Value - use a disabled state instead
-		disabledState := &samplingState{
+		// Use a disabled state rather than nil so callers can always sample
+		return &samplingState{
 			config: &SamplingConfig{Enabled: false},
 		}
-		disabledState.counter.Store(0)
-		l.sampling.Store(disabledState)
-		return
 	}
 
 	// Create a copy to avoid mutating caller's config
-	cfg := &SamplingConfig{
-		Enabled:    config.Enabled,
-		Initial:    config.Initial,
-		Thereafter: config.Thereafter,
-		Tick:       config.Tick,
-	}
+	cfg := config.Clone()
 
 	// Apply defaults to the copy
 	if cfg.Initial < 0 {
 		cfg.Initial = 0
 	}
-	// Thereafter=0 is valid and means "log nothing after Initial"
-	// Thereafter<0 is treated as "log everything" (set to 1)
 	if cfg.Thereafter < 0 {
 		cfg.Thereafter = 1
 	}
@@ -596,16 +1032,26 @@ func (l *Logger) SetSampling(config *SamplingConfig) {
 	}
 
 	newState := &samplingState{
-		config: cfg,
-		start:  time.Now(),
+		config:      cfg,
+		start:       clock.Now(),
+		clock:       clock,
+		defaultRule: newSampleRule(cfg.Initial, cfg.Thereafter, cfg.Rate, false),
+	}
+	for level := range newState.rules {
+		newState.rules[level] = newState.defaultRule
+		if ls, ok := cfg.Levels[LogLevel(level)]; ok {
+			newState.rules[level] = newSampleRule(ls.Initial, ls.Thereafter, ls.Rate, ls.KeepAll)
+		}
 	}
-	newState.counter.Store(0)
-	l.sampling.Store(newState)
+	return newState
 }
 
 // GetSampling returns the current sampling configuration (thread-safe).
 // Returns nil if sampling is not enabled.
 func (l *Logger) GetSampling() *SamplingConfig {
+	if l == nil {
+		return nil
+	}
 	v := l.sampling.Load()
 	if v == nil {
 		return nil
@@ -623,6 +1069,9 @@ func (l *Logger) GetSampling() *SamplingConfig {
 
 // SetSecurityConfig atomically sets the security configuration (thread-safe).
 func (l *Logger) SetSecurityConfig(config *SecurityConfig) {
+	if l == nil {
+		return
+	}
 	if config == nil {
 		config = DefaultSecurityConfig()
 	}
@@ -634,6 +1083,9 @@ func (l *Logger) SetSecurityConfig(config *SecurityConfig) {
 // The returned config is a clone, so modifications do not affect the logger's config.
 // For internal use within the logger, use getSecurityConfig() which returns the original.
 func (l *Logger) GetSecurityConfig() *SecurityConfig {
+	if l == nil {
+		return DefaultSecurityConfig()
+	}
 	config := l.securityConfig.Load()
 	if config == nil {
 		return DefaultSecurityConfig()
@@ -659,22 +1111,37 @@ func (l *Logger) getSecurityConfig() *SecurityConfig {
 
 // processFields processes and filters structured fields
 func (l *Logger) processFields(fields []Field) []Field {
+	return l.processFieldsWith(fields, l.sensitiveFilter(nil))
+}
+
+// processFieldsWith is like processFields but filters with the given filter.
+func (l *Logger) processFieldsWith(fields []Field, filter *SensitiveDataFilter) []Field {
 	if len(fields) == 0 {
 		return fields
 	}
 
+	// Lazy values are resolved first so they are validated and filtered
+	fields = l.encodeValues(internal.ResolveLazyValues(fields))
+
 	// Validate field keys if validation is enabled
-	l.validateFields(fields)
+	fields = l.validateFields(fields)
 
-	secConfig := l.getSecurityConfig()
-	if secConfig == nil || secConfig.SensitiveFilter == nil || !secConfig.SensitiveFilter.IsEnabled() {
+	if filter == nil || !filter.IsEnabled() {
 		return fields // Early return - no allocation
 	}
+	if !l.filterScope().filtersValues() {
+		return l.redactSensitiveKeys(fields, filter)
+	}
+	return l.filterFieldValues(fields, filter, l.auditsRedactions())
+}
 
+// filterFieldValues applies filter to the field values, auditing the
+// redactions if audit is set.
+func (l *Logger) filterFieldValues(fields []Field, filter *SensitiveDataFilter, audit bool) []Field {
 	// First pass: check if any field actually needs filtering
 	// This avoids allocation when all values are non-sensitive
 	needsFiltering := false
-	hasPatterns := secConfig.SensitiveFilter.PatternCount() > 0
+	hasPatterns := filter.PatternCount() > 0
 
 	for _, field := range fields {
 		// Check if key is sensitive (requires redaction regardless of patterns)
@@ -703,10 +1170,11 @@ func (l *Logger) processFields(fields []Field) []Field {
 	result := make([]Field, 0, len(fields))
 
 	for _, field := range fields {
-		result = append(result, Field{
-			Key:   field.Key,
-			Value: secConfig.SensitiveFilter.FilterValueRecursive(field.Key, field.Value),
-		})
+		value := filter.FilterValueRecursive(field.Key, field.Value)
+		if audit && redacted(field.Key, field.Value, value) {
+			l.auditRedaction(filter, field.Key, field.Value)
+		}
+		result = append(result, Field{Key: field.Key, Value: value})
 	}
 
 	return result
@@ -714,53 +1182,70 @@ func (l *Logger) processFields(fields []Field) []Field {
 
 // applyMessageSecurity applies sensitive data filtering to the raw message (before formatting)
 func (l *Logger) applyMessageSecurity(message string) string {
-	secConfig := l.getSecurityConfig()
-	if secConfig == nil {
-		return internal.SanitizeControlChars(message)
-	}
-
-	if secConfig.SensitiveFilter != nil && secConfig.SensitiveFilter.IsEnabled() {
-		message = secConfig.SensitiveFilter.Filter(message)
-	}
-
-	return internal.SanitizeControlChars(message)
+	return l.applyMessageSecurityWith(message, l.sensitiveFilter(nil))
 }
 
-// applySizeLimit applies message size limit to the formatted message (after formatting)
-func (l *Logger) applySizeLimit(message string) string {
-	secConfig := l.getSecurityConfig()
-	if secConfig == nil {
-		return message
+// applyMessageSecurityWith is like applyMessageSecurity but filters with the given filter.
+func (l *Logger) applyMessageSecurityWith(message string, filter *SensitiveDataFilter) string {
+	if filter != nil && filter.IsEnabled() && l.filterScope().filtersValues() {
+		filtered := filter.Filter(message)
+		if filtered != message && l.auditsRedactions() {
+			l.auditRedaction(filter, "", message)
+		}
+		message = filtered
 	}
+	return l.sanitizeControlChars(message)
+}
 
-	if secConfig.MaxMessageSize > 0 && len(message) > secConfig.MaxMessageSize {
-		message = message[:secConfig.MaxMessageSize] + "..."
+// sanitizeControlChars applies SecurityConfig.ControlChars to a message.
+func (l *Logger) sanitizeControlChars(message string) string {
+	policy, keepNewlines := ControlCharEscape, l.multilineText
+	if sc := l.getSecurityConfig(); sc != nil {
+		policy = sc.ControlChars
+		keepNewlines = keepNewlines || sc.KeepNewlines && l.jsonFormat
 	}
-
-	return message
+	return internal.SanitizeControlCharsWith(message, policy, keepNewlines)
 }
 
 // validateFields validates field keys against the configured naming convention.
-// In warn mode, validation errors are logged as warnings.
-// In strict mode, validation errors are logged as errors.
-func (l *Logger) validateFields(fields []Field) {
+// Violations are reported to OnViolation or the internal diagnostics
+// channel; in strict mode offending fields are then dropped or renamed as
+// StrictAction says. fields is returned unchanged unless a field is.
+func (l *Logger) validateFields(fields []Field) []Field {
 	fv := l.getFieldValidation()
 	if fv == nil || fv.Mode == FieldValidationNone {
-		return
+		return fields
 	}
 
-	for _, field := range fields {
-		if err := fv.ValidateFieldKey(field.Key); err != nil {
-			switch fv.Mode {
-			case FieldValidationWarn:
-				// Log warning without affecting the log output
-				fmt.Fprintf(os.Stderr, "dd: field validation warning: %v\n", err)
-			case FieldValidationStrict:
-				// Log error without affecting the log output
-				fmt.Fprintf(os.Stderr, "dd: field validation error: %v\n", err)
+	var result []Field
+	for i, field := range fields {
+		err := fv.ValidateFieldKey(field.Key)
+		if err != nil {
+			l.fieldStats.violations.Add(1)
+			l.reportFieldViolation(fv, field.Key, err)
+
+			action := fv.strictAction()
+			if action != InvalidFieldKeep && result == nil {
+				result = make([]Field, i, len(fields))
+				copy(result, fields[:i])
 			}
+			switch action {
+			case InvalidFieldDrop:
+				l.fieldStats.dropped.Add(1)
+				continue
+			case InvalidFieldRename:
+				l.fieldStats.renamed.Add(1)
+				field.Key = fv.renamePrefix() + field.Key
+			}
+		}
+		if result != nil {
+			result = append(result, field)
 		}
 	}
+	if result == nil {
+		return fields
+	}
+	return result
 }
 
 // getFieldValidation safely returns the field validation configuration.
@@ -779,6 +1264,9 @@ func (l *Logger) getFieldValidation() *FieldValidationConfig {
 //	// Enable strict snake_case validation
 //	logger.SetFieldValidation(dd.StrictSnakeCaseConfig())
 func (l *Logger) SetFieldValidation(config *FieldValidationConfig) {
+	if l == nil {
+		return
+	}
 	if config == nil || config.Mode == FieldValidationNone {
 		l.fieldValidation.Store(nil)
 	} else {
@@ -789,6 +1277,9 @@ func (l *Logger) SetFieldValidation(config *FieldValidationConfig) {
 // GetFieldValidation returns the current field validation configuration.
 // Returns nil if no validation is configured.
 func (l *Logger) GetFieldValidation() *FieldValidationConfig {
+	if l == nil {
+		return nil
+	}
 	return l.getFieldValidation()
 }
 
@@ -798,6 +1289,9 @@ func (l *Logger) GetFieldValidation() *FieldValidationConfig {
 
 // AddWriter adds a writer to the logger in a thread-safe manner.
 func (l *Logger) AddWriter(writer io.Writer) error {
+	if l == nil {
+		return ErrNilLogger
+	}
 	if writer == nil {
 		return ErrNilWriter
 	}
@@ -819,18 +1313,26 @@ func (l *Logger) AddWriter(writer io.Writer) error {
 		return ErrMaxWritersExceeded
 	}
 
+	writer, err := applyWriterMiddleware(writer, l.writerMW)
+	if err != nil {
+		return err
+	}
+
 	// Create new slice with the new writer added
 	newWriters := make([]io.Writer, len(*currentWriters)+1)
 	copy(newWriters, *currentWriters)
 	newWriters[len(*currentWriters)] = writer
 
 	// Atomically swap the pointer
-	l.writersPtr.Store(&newWriters)
+	l.storeWriters(newWriters)
 	return nil
 }
 
 // RemoveWriter removes a writer from the logger in a thread-safe manner.
 func (l *Logger) RemoveWriter(writer io.Writer) error {
+	if l == nil {
+		return ErrNilLogger
+	}
 	if writer == nil {
 		return ErrNilWriter
 	}
@@ -850,14 +1352,14 @@ func (l *Logger) RemoveWriter(writer io.Writer) error {
 
 	writerCount := len(*currentWriters)
 	for i := 0; i < writerCount; i++ {
-		if (*currentWriters)[i] == writer {
+		if w := (*currentWriters)[i]; w == writer || unwrapMiddleware(w) == writer {
 			// Create new slice without the removed writer
 			newWriters := make([]io.Writer, writerCount-1)
 			copy(newWriters, (*currentWriters)[:i])
 			copy(newWriters[i:], (*currentWriters)[i+1:])
 
 			// Atomically swap the pointer
-			l.writersPtr.Store(&newWriters)
+			l.storeWriters(newWriters)
 			return nil
 		}
 	}
@@ -867,6 +1369,9 @@ func (l *Logger) RemoveWriter(writer io.Writer) error {
 
 // WriterCount returns the number of registered writers (thread-safe).
 func (l *Logger) WriterCount() int {
+	if l == nil {
+		return 0
+	}
 	writersPtr := l.writersPtr.Load()
 	if writersPtr == nil {
 		return 0
@@ -877,6 +1382,13 @@ func (l *Logger) WriterCount() int {
 // Flush flushes all buffered writers (thread-safe).
 // Writers that implement Flusher interface will be flushed.
 func (l *Logger) Flush() error {
+	if l == nil {
+		return nil
+	}
+	if l.tee != nil {
+		return l.tee.each((*Logger).Flush)
+	}
+
 	writersPtr := l.writersPtr.Load()
 	if writersPtr == nil {
 		return nil
@@ -893,12 +1405,23 @@ func (l *Logger) Flush() error {
 	return firstErr
 }
 
-// writeMessage writes a message to all configured writers
-func (l *Logger) writeMessage(message string) {
+// writeMessage writes a message to all configured writers.
+// Writers implementing RecordWriter or LevelWriter receive the entry
+// metadata via WriteRecord or WriteLevel.
+func (l *Logger) writeMessage(level LogLevel, entry *logEntry, message string) {
 	if l.closed.Load() || len(message) == 0 {
 		return
 	}
 
+	needed := len(message) + 1
+	// FATAL entries are never dropped at the memory limit
+	if level < LevelFatal {
+		if !l.reserveMemory(int64(needed)) {
+			return
+		}
+		defer l.releaseMemory(int64(needed))
+	}
+
 	bufPtr := messagePool.Get().(*[]byte)
 	buf := *bufPtr
 	defer func() {
@@ -912,7 +1435,6 @@ func (l *Logger) writeMessage(message string) {
 		messagePool.Put(bufPtr)
 	}()
 
-	needed := len(message) + 1
 	if cap(buf) < needed {
 		buf = make([]byte, 0, max(needed, defaultBufferSize))
 	} else {
@@ -927,26 +1449,121 @@ func (l *Logger) writeMessage(message string) {
 	if writersPtr == nil || len(*writersPtr) == 0 {
 		return
 	}
+	if entry.tee != nil {
+		entry.tee.delivered++
+	}
 
 	writers := *writersPtr
-	writerCount := len(writers)
+	stats := l.statsFor(writersPtr)
+	w := entryWriter{logger: l, level: level, entry: entry, buf: buf, clock: l.clock}
+
+	if entry.event && len(l.events.config.Outputs) > 0 {
+		l.writeEvent(&w)
+		return
+	}
 
-	if writerCount == 1 {
-		w := writers[0]
-		if _, err := w.Write(buf); err != nil {
-			l.handleWriteError(w, err)
+	// With MinLevelWriters present, entries below the logger level may reach
+	// here; those are only for writers whose own MinLevel accepts them.
+	if l.hasMinLevelWriters.Load() {
+		belowLevel := !entry.event && level < l.entryLevel(entry)
+		for i, writer := range writers {
+			if mlw, ok := writer.(MinLevelWriter); ok {
+				if level < mlw.MinLevel() {
+					continue
+				}
+			} else if belowLevel {
+				continue
+			}
+			l.writeToWriter(&w, i, writer, stats)
 		}
 		return
 	}
 
 	// Iterate directly over the immutable slice - no copy needed
-	for _, writer := range writers {
-		if _, err := writer.Write(buf); err != nil {
+	for i, writer := range writers {
+		l.writeToWriter(&w, i, writer, stats)
+	}
+}
+
+// writeToWriter writes the entry to writer i, recording its statistics
+// and reporting errors.
+func (l *Logger) writeToWriter(w *entryWriter, i int, writer io.Writer, stats []*writerStats) {
+	if w.entry != nil && w.entry.tee != nil && !w.entry.tee.claim(writer) {
+		return
+	}
+	if stats == nil {
+		if _, err := w.writeTo(writer); err != nil {
 			l.handleWriteError(writer, err)
 		}
+		return
+	}
+
+	// A quarantined writer only receives an occasional probe entry
+	probe := false
+	if l.quarantine != nil && stats[i].quarantine.active.Load() {
+		if probe = stats[i].quarantine.startProbe(l.clock.Now()); !probe {
+			stats[i].quarantine.skip(len(w.buf))
+			return
+		}
+	}
+
+	start := time.Now()
+	n, err := w.writeTo(writer)
+	stats[i].record(n, len(w.buf), err, time.Since(start), l.clock.Now)
+	if err != nil {
+		l.handleWriteError(writer, err)
+	}
+	if l.quarantine != nil {
+		l.updateQuarantine(stats[i], probe, err)
+	}
+}
+
+// entryWriter dispatches one formatted entry to writers, building the
+// Record lazily so plain io.Writers pay nothing for it.
+type entryWriter struct {
+	logger *Logger
+	level  LogLevel
+	entry  *logEntry
+	buf    []byte
+	rec    *Record
+	clock  Clock
+}
+
+func (w *entryWriter) writeTo(writer io.Writer) (int, error) {
+	switch tw := writer.(type) {
+	case RecordWriter:
+		if w.rec == nil {
+			w.rec = newRecord(w.clock.Now(), w.level, w.entry)
+			if w.logger != nil && w.entry != nil {
+				w.rec.Message, w.rec.Fields = w.logger.filterEntryValues(w.entry.security, w.rec.Message, w.rec.Fields)
+			}
+		}
+		return tw.WriteRecord(w.rec, w.buf)
+	case LevelWriter:
+		return tw.WriteLevel(w.level, w.buf)
+	default:
+		return writer.Write(w.buf)
 	}
 }
 
+// newRecord builds the Record passed to RecordWriters, stamped with the
+// explicit time of the entry (LogAt, Event.At) or else now.
+func newRecord(now time.Time, level LogLevel, entry *logEntry) *Record {
+	rec := &Record{
+		Time:  now,
+		Level: level,
+	}
+	if entry != nil {
+		if !entry.time.IsZero() {
+			rec.Time = entry.time
+		}
+		rec.Message = entry.msg
+		rec.Fields = entry.fields
+		rec.Classification = classificationOf(entry.fields)
+	}
+	return rec
+}
+
 // handleWriteError handles write errors by calling both legacy handler and hooks.
 func (l *Logger) handleWriteError(writer io.Writer, err error) {
 	// Call legacy write error handler
@@ -959,7 +1576,7 @@ func (l *Logger) handleWriteError(writer io.Writer, err error) {
 		Event:     HookOnError,
 		Error:     err,
 		Writer:    writer,
-		Timestamp: time.Now(),
+		Timestamp: l.clock.Now(),
 	}
 	_ = l.triggerHooks(l.ctx, hookCtx)
 }
@@ -970,31 +1587,46 @@ func (l *Logger) handleWriteError(writer io.Writer, err error) {
 
 // Close closes the logger and all associated resources (thread-safe).
 // If multiple writers fail to close, all errors are collected and returned.
-// Triggers OnClose hooks before closing writers.
+// Runs shutdown hooks (see OnShutdown) and OnClose hooks before closing
+// writers.
 func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
 	if !l.closed.CompareAndSwap(false, true) {
 		return nil
 	}
 
+	var errs []error
+	if err := l.runShutdownHooks(context.Background()); err != nil {
+		errs = append(errs, err)
+	}
+
 	// Trigger OnClose hook
 	hookCtx := &HookContext{
 		Event:     HookOnClose,
-		Timestamp: time.Now(),
+		Timestamp: l.clock.Now(),
 	}
 	_ = l.triggerHooks(context.Background(), hookCtx)
+	if err := l.drainHooks(context.Background()); err != nil {
+		l.internalError(ComponentHooks, err, "async hooks not drained on close: %v", err)
+	}
 
 	l.cancel()
 
+	if l.tee != nil {
+		errs = append(errs, l.tee.each((*Logger).Close))
+	}
+
 	l.writersMu.Lock()
 	defer l.writersMu.Unlock()
 
 	// Load and clear writers atomically
 	currentWriters := l.writersPtr.Swap(nil)
 	if currentWriters == nil {
-		return nil
+		return errors.Join(errs...)
 	}
 
-	var errs []error
 	for _, writer := range *currentWriters {
 		if err := closeWriter(writer); err != nil {
 			errs = append(errs, fmt.Errorf("failed to close writer: %w", err))
@@ -1004,17 +1636,22 @@ func (l *Logger) Close() error {
 	return errors.Join(errs...)
 }
 
-// Shutdown gracefully closes the logger with a timeout.
+// Shutdown gracefully closes the logger, bounded by ctx.
 // This is the recommended way to close a logger in production environments.
 //
-// The method performs the following steps:
+// The method performs the following steps in order:
 //  1. Marks the logger as closed to prevent new log entries
-//  2. Triggers OnClose hooks with the provided context
-//  3. Waits for any in-flight operations to complete
-//  4. Flushes and closes all writers with the specified timeout
+//  2. Runs shutdown hooks in reverse registration order (see OnShutdown)
+//  3. Triggers OnClose hooks with the provided context
+//  4. Waits for queued async hooks and active filter goroutines
+//  5. Flushes all writers implementing Flusher
+//  6. Closes all writers
 //
-// If the timeout is exceeded, Shutdown returns a context.DeadlineExceeded error
-// along with any other errors that occurred during shutdown.
+// Errors from every step are collected and returned together. If ctx is
+// done before the steps complete, Shutdown returns immediately with the
+// errors collected so far joined with ctx.Err(); the remaining steps keep
+// running in the background, and every writer is still flushed and
+// closed. Calling Shutdown or Close again is a no-op.
 //
 // Recommended usage:
 //
@@ -1027,87 +1664,125 @@ func (l *Logger) Close() error {
 //	    }
 //	}()
 func (l *Logger) Shutdown(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
 	if !l.closed.CompareAndSwap(false, true) {
 		return nil // Already closed
 	}
 
-	// Create a done channel to signal completion
-	done := make(chan error, 1)
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	addErr := func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}
+	done := make(chan struct{})
 
 	go func() {
+		defer close(done)
+
+		if err := l.runShutdownHooks(ctx); err != nil {
+			addErr(err)
+		}
+
 		// Trigger OnClose hook
 		hookCtx := &HookContext{
 			Event:     HookOnClose,
-			Timestamp: time.Now(),
+			Timestamp: l.clock.Now(),
 		}
 		_ = l.triggerHooks(ctx, hookCtx)
+		if err := l.drainHooks(ctx); err != nil {
+			addErr(fmt.Errorf("async hooks not drained: %w", err))
+		}
+		if !l.waitFilterGoroutines(ctx) {
+			addErr(fmt.Errorf("%d filter goroutines still active", l.ActiveFilterGoroutines()))
+		}
 
 		l.cancel()
 
+		if l.tee != nil {
+			if err := l.tee.each(func(m *Logger) error { return m.Shutdown(ctx) }); err != nil {
+				addErr(err)
+			}
+		}
+
 		l.writersMu.Lock()
 		defer l.writersMu.Unlock()
 
 		// Load and clear writers atomically
 		currentWriters := l.writersPtr.Swap(nil)
 		if currentWriters == nil {
-			done <- nil
 			return
 		}
 
-		var errs []error
+		// Writers are flushed and closed even after the deadline, so none
+		// is leaked; the deadline is reported once.
+		defer func() {
+			if err := ctx.Err(); err != nil {
+				mu.Lock()
+				if !errors.Is(errors.Join(errs...), err) {
+					errs = append(errs, err)
+				}
+				mu.Unlock()
+			}
+		}()
 		for _, writer := range *currentWriters {
-			// Check context for cancellation
-			select {
-			case <-ctx.Done():
-				done <- ctx.Err()
-				return
-			default:
+			if flusher, ok := writer.(Flusher); ok {
+				if err := flusher.Flush(); err != nil {
+					addErr(fmt.Errorf("failed to flush writer: %w", err))
+				}
 			}
-
+		}
+		for _, writer := range *currentWriters {
 			if err := closeWriter(writer); err != nil {
-				errs = append(errs, fmt.Errorf("failed to close writer: %w", err))
+				addErr(fmt.Errorf("failed to close writer: %w", err))
 			}
 		}
-
-		done <- errors.Join(errs...)
 	}()
 
 	// Wait for completion or timeout
 	select {
-	case err := <-done:
-		return err
+	case <-done:
+		mu.Lock()
+		defer mu.Unlock()
+		return errors.Join(errs...)
 	case <-ctx.Done():
-		return ctx.Err()
+		mu.Lock()
+		defer mu.Unlock()
+		err := errors.Join(errs...)
+		if !errors.Is(err, ctx.Err()) {
+			err = errors.Join(err, ctx.Err())
+		}
+		return err
 	}
 }
 
-// IsClosed returns true if the logger has been closed (thread-safe).
-func (l *Logger) IsClosed() bool {
-	return l.closed.Load()
-}
-
-// handleFatal handles fatal log messages with timeout protection.
-// If Close() takes longer than defaultFatalFlushTimeout, a warning is printed
-// and the program exits anyway to prevent indefinite hanging.
-func (l *Logger) handleFatal() {
-	done := make(chan struct{})
-	go func() {
-		defer close(done)
-		_ = l.Close()
-	}()
-
-	select {
-	case <-done:
-		// Close completed successfully
-	case <-time.After(defaultFatalFlushTimeout):
-		fmt.Fprintln(os.Stderr, "[dd] Warning: logger close timed out after 5 seconds")
+// waitFilterGoroutines waits for active filter goroutines until ctx is done
+// or, without a deadline, for at most defaultHookDrainTimeout.
+func (l *Logger) waitFilterGoroutines(ctx context.Context) bool {
+	if l.ActiveFilterGoroutines() == 0 {
+		return true
+	}
+	timeout := defaultHookDrainTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	if timeout <= 0 {
+		return false
 	}
+	return l.WaitForFilterGoroutines(timeout)
+}
 
-	if l.fatalHandler != nil {
-		l.fatalHandler()
-	} else {
-		os.Exit(1)
+// IsClosed returns true if the logger has been closed (thread-safe).
+func (l *Logger) IsClosed() bool {
+	if l == nil {
+		return false
 	}
+	return l.closed.Load()
 }
 
 // ActiveFilterGoroutines returns the number of currently active filter goroutines
@@ -1115,11 +1790,14 @@ func (l *Logger) handleFatal() {
 // goroutine leaks in high-concurrency scenarios. A consistently high count may
 // indicate that filter operations are timing out frequently.
 func (l *Logger) ActiveFilterGoroutines() int32 {
-	secConfig := l.getSecurityConfig()
-	if secConfig == nil || secConfig.SensitiveFilter == nil {
+	if l == nil {
 		return 0
 	}
-	return secConfig.SensitiveFilter.ActiveGoroutineCount()
+	var count int32
+	l.eachSensitiveFilter(func(filter *SensitiveDataFilter) {
+		count += filter.ActiveGoroutineCount()
+	})
+	return count
 }
 
 // WaitForFilterGoroutines waits for all active filter goroutines to complete
@@ -1142,11 +1820,17 @@ func (l *Logger) ActiveFilterGoroutines() int32 {
 //
 // Returns true if all goroutines completed, false if timeout was reached.
 func (l *Logger) WaitForFilterGoroutines(timeout time.Duration) bool {
-	secConfig := l.getSecurityConfig()
-	if secConfig == nil || secConfig.SensitiveFilter == nil {
+	if l == nil {
 		return true
 	}
-	return secConfig.SensitiveFilter.WaitForGoroutines(timeout)
+	deadline := time.Now().Add(timeout)
+	done := true
+	l.eachSensitiveFilter(func(filter *SensitiveDataFilter) {
+		if !filter.WaitForGoroutines(time.Until(deadline)) {
+			done = false
+		}
+	})
+	return done
 }
 
 // ============================================================================
@@ -1155,9 +1839,28 @@ func (l *Logger) WaitForFilterGoroutines(timeout time.Duration) bool {
 
 // logEntry contains the data needed to write a log entry
 type logEntry struct {
+	ctx            context.Context // nil for methods without a context
 	msg            string
 	fields         []Field
 	originalFields []Field // fields before processing (for hooks)
+	template       bool    // msg is a template rendered from fields (see LogT)
+	tenant         *tenantState
+	fatalPanic     bool           // FatalDefer: panic instead of exiting
+	fatalDump      string         // goroutine dump captured for a FATAL entry
+	security       *entrySecurity // WithSecurity override, for output filtering
+	tee            *teeWriters    // writers already written to by a Tee
+	format         string         // Logf format string, for fingerprints
+	errType        string         // type of the first error argument, for fingerprints
+	time           time.Time      // timestamp given to LogAt or Event.At (zero for now)
+	event          bool           // written by Emit
+}
+
+// context returns the entry context, or context.Background() if none.
+func (e *logEntry) context() context.Context {
+	if e == nil || e.ctx == nil {
+		return context.Background()
+	}
+	return e.ctx
 }
 
 // logCore is the internal implementation for all log methods.
@@ -1169,37 +1872,77 @@ func (l *Logger) logCore(level LogLevel, entry logEntry) {
 // logCoreWithDepth is like logCore but accepts an additional caller depth offset.
 // This is used by LoggerEntry to skip the extra stack frames introduced by the entry wrapper.
 func (l *Logger) logCoreWithDepth(level LogLevel, entry logEntry, extraDepth int) {
+	l.addGoroutineFields(&entry)
+	l.addIngestTime(&entry)
+
 	// Fast path: check if hooks exist before allocating HookContext
 	hasHooks := l.hooks.Load() != nil
 
 	var hookCtx *HookContext
 	if hasHooks {
 		// Only allocate HookContext and call time.Now() when hooks are registered
+		msg, fields := l.filterEntryValues(entry.security, entry.msg, entry.fields)
 		hookCtx = &HookContext{
 			Event:          HookBeforeLog,
 			Level:          level,
-			Message:        entry.msg,
-			Fields:         entry.fields,
+			Message:        msg,
+			Fields:         fields,
 			OriginalFields: entry.originalFields,
-			Timestamp:      time.Now(),
+			Timestamp:      entry.time,
 		}
-		if err := l.triggerHooks(context.Background(), hookCtx); err != nil {
+		if hookCtx.Timestamp.IsZero() {
+			hookCtx.Timestamp = l.clock.Now()
+		}
+		if err := l.triggerHooks(entry.context(), hookCtx); err != nil {
 			return // Hook aborted the log
 		}
+		if hookCtx.modified {
+			entry.msg = hookCtx.Message
+			entry.fields = hookCtx.Fields
+		}
+	}
+	if !l.enforceSchema(level, &entry) {
+		return
 	}
 
+	l.addRuntimeStats(level, &entry)
+	l.addFingerprint(level, &entry)
+	l.addSamplingFields(level, &entry)
+
 	callerDepth := l.callerDepth + extraDepth
-	message := l.formatter.FormatWithMessage(level, callerDepth, entry.msg, entry.fields)
-	l.writeMessage(l.applySizeLimit(message))
+	if l.tee != nil {
+		l.writeTee(level, &entry, callerDepth)
+	} else {
+		if level == LevelFatal {
+			l.addFatalDump(&entry)
+		}
+		entry.fields = l.truncateFields(l.resolveFieldConflicts(entry.fields))
+
+		if entry.template {
+			// Field values are inserted raw, so the rendered message is
+			// sanitized again to keep them from forging lines.
+			entry.msg = l.sanitizeControlChars(internal.RenderTemplate(entry.msg, entry.fields))
+		}
+
+		message := l.formatWithinLimit(entry.time, level, entry.event, callerDepth, entry.msg, entry.fields)
+		message = l.fitEntrySize(entry.time, level, entry.event, callerDepth, entry.msg, entry.fields, message)
+		l.writeMessage(level, &entry, l.filterOutput(entry.security, message))
+	}
 
 	// Trigger AfterLog hook (only if hooks exist)
 	if hasHooks {
 		hookCtx.Event = HookAfterLog
-		_ = l.triggerHooks(context.Background(), hookCtx)
+		_ = l.triggerHooks(entry.context(), hookCtx)
 	}
 
 	if level == LevelFatal {
-		l.handleFatal()
+		if entry.tee != nil {
+			// The Tee ends the program once every logger has the entry
+			l.writeCrashDump(&entry)
+			l.runFatalHooks(entry.msg)
+			return
+		}
+		l.handleFatal(&entry)
 	}
 }
 
@@ -1210,7 +1953,11 @@ func (l *Logger) Log(level LogLevel, args ...any) {
 	}
 
 	msg := l.applyMessageSecurity(l.formatter.FormatArgsToString(args...))
-	l.logCore(level, logEntry{msg: msg})
+	entry := logEntry{msg: msg}
+	if l.fingerprint != nil && level >= LevelError {
+		entry.errType = argsErrorType(args)
+	}
+	l.logCore(level, entry)
 }
 
 // Logf logs a formatted message at the specified level
@@ -1220,7 +1967,11 @@ func (l *Logger) Logf(level LogLevel, format string, args ...any) {
 	}
 
 	msg := l.applyMessageSecurity(fmt.Sprintf(format, args...))
-	l.logCore(level, logEntry{msg: msg})
+	entry := logEntry{msg: msg}
+	if l.fingerprint != nil && level >= LevelError {
+		entry.format, entry.errType = format, argsErrorType(args)
+	}
+	l.logCore(level, entry)
 }
 
 // LogWith logs a structured message with fields at the specified level
@@ -1273,6 +2024,81 @@ func (l *Logger) ErrorWith(msg string, fields ...Field) { l.LogWith(LevelError,
 // WARNING: defer statements will NOT execute. For graceful shutdown, use ErrorWith() with custom logic.
 func (l *Logger) FatalWith(msg string, fields ...Field) { l.LogWith(LevelFatal, msg, fields...) }
 
+// LogCtx logs a structured message with fields taken from ctx: the output of
+// the logger's context extractors (trace_id, span_id and request_id by
+// default) followed by fields added with ContextWithFields. Explicit fields
+// override context fields with the same key. ctx is also passed to the level
+// resolver and hooks.
+func (l *Logger) LogCtx(ctx context.Context, level LogLevel, msg string, fields ...Field) {
+	if !l.shouldLogCtx(ctx, level) {
+		return
+	}
+
+	fields = mergeFieldSlices(l.contextFields(ctx), fields)
+
+	// Only copy original fields if hooks are registered (they may need them)
+	var originalFields []Field
+	if l.hooks.Load() != nil && len(fields) > 0 {
+		originalFields = make([]Field, len(fields))
+		copy(originalFields, fields)
+	}
+
+	msg = l.applyMessageSecurity(msg)
+	processedFields := l.processFields(fields)
+
+	l.logCore(level, logEntry{
+		ctx:            ctx,
+		msg:            msg,
+		fields:         processedFields,
+		originalFields: originalFields,
+	})
+}
+
+func (l *Logger) DebugCtx(ctx context.Context, msg string, fields ...Field) {
+	l.LogCtx(ctx, LevelDebug, msg, fields...)
+}
+func (l *Logger) InfoCtx(ctx context.Context, msg string, fields ...Field) {
+	l.LogCtx(ctx, LevelInfo, msg, fields...)
+}
+func (l *Logger) WarnCtx(ctx context.Context, msg string, fields ...Field) {
+	l.LogCtx(ctx, LevelWarn, msg, fields...)
+}
+func (l *Logger) ErrorCtx(ctx context.Context, msg string, fields ...Field) {
+	l.LogCtx(ctx, LevelError, msg, fields...)
+}
+
+// FatalCtx logs a structured message at FATAL level and terminates the program via os.Exit(1).
+// WARNING: defer statements will NOT execute. For graceful shutdown, use ErrorCtx() with custom logic.
+func (l *Logger) FatalCtx(ctx context.Context, msg string, fields ...Field) {
+	l.LogCtx(ctx, LevelFatal, msg, fields...)
+}
+
+// contextFields returns the fields extracted from ctx by the logger's
+// extractors (or the default extractors) plus fields from ContextWithFields.
+func (l *Logger) contextFields(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+
+	registry := DefaultContextExtractorRegistry()
+	if v := l.contextExtractors.Load(); v != nil {
+		if custom := v.(*ContextExtractorRegistry); custom.Count() > 0 {
+			registry = custom
+		}
+	}
+
+	fields := mergeFieldSlices(registry.extract(ctx, l.extractorGuard, l.extractorDisabled), FieldsFromContext(ctx))
+	if policy := l.contextPolicy.Load(); policy != nil {
+		if policy.Annotate {
+			fields = mergeFieldSlices(fields, ContextStatusExtractor(ctx))
+		}
+		if policy.MarkMissingRequestID && !hasRequestID(fields) {
+			fields = append(fields[:len(fields):len(fields)], Bool(RequestIDMissingKey, true))
+		}
+	}
+	return fields
+}
+
 // fmt package replacement methods - output via logger's writers with caller info
 //
 // IMPORTANT: These Logger methods are DIFFERENT from the package-level dd.Print functions!
@@ -1316,25 +2142,63 @@ func (l *Logger) Printf(format string, args ...any) {
 //
 // SECURITY WARNING: This method does NOT apply sensitive data filtering.
 // Do not use with sensitive data in production environments. For secure logging,
-// use logger.Info(), logger.Debug(), etc. which apply sensitive data filtering.
+// use logger.Info(), logger.Debug(), etc. which apply sensitive data filtering,
+// or SecureText.
 func (l *Logger) Text(data ...any) {
+	if l == nil || l.nopEntry != nil {
+		return
+	}
 	internal.OutputTextData(os.Stdout, data...)
 }
 
 // Textf outputs formatted text to stdout for debugging.
 func (l *Logger) Textf(format string, args ...any) {
+	if l == nil || l.nopEntry != nil {
+		return
+	}
 	formatted := fmt.Sprintf(format, args...)
 	fmt.Fprintln(os.Stdout, formatted)
 }
 
 // JSON outputs data as JSON to stdout for debugging.
 func (l *Logger) JSON(data ...any) {
+	if l == nil || l.nopEntry != nil {
+		return
+	}
 	caller := internal.GetCaller(debugVisualizationDepth, false)
 	internal.OutputJSON(os.Stdout, caller, data...)
 }
 
+// SecureText is like Text but runs the logger's sensitive data filter
+// recursively over data first, so a struct or map dumped while debugging
+// does not print passwords or tokens. Keys such as "password" are redacted
+// as in log fields. Without a configured filter, the one from
+// NewSensitiveDataFilter is used.
+//
+// Example:
+//
+//	logger.SecureText(req) // {"password": "[REDACTED]", "user": "alice"}
+func (l *Logger) SecureText(data ...any) {
+	if l == nil || l.nopEntry != nil {
+		return
+	}
+	internal.OutputTextData(os.Stdout, l.redactDebugData(data)...)
+}
+
+// SecureJSON is like JSON but redacts data as SecureText does.
+func (l *Logger) SecureJSON(data ...any) {
+	if l == nil || l.nopEntry != nil {
+		return
+	}
+	caller := internal.GetCaller(debugVisualizationDepth, false)
+	internal.OutputJSON(os.Stdout, caller, l.redactDebugData(data)...)
+}
+
 // JSONF outputs formatted JSON to stdout for debugging.
 func (l *Logger) JSONF(format string, args ...any) {
+	if l == nil || l.nopEntry != nil {
+		return
+	}
 	formatted := fmt.Sprintf(format, args...)
 	caller := internal.GetCaller(debugVisualizationDepth, false)
 	internal.OutputJSON(os.Stdout, caller, formatted)
@@ -1354,6 +2218,13 @@ var (
 	defaultOnce         sync.Once
 	defaultInitErr      atomic.Value // stores error from initialization (errNoInit means no error)
 	defaultUsedFallback atomic.Bool  // true if fallback logger was created
+
+	// defaultInitHooks holds the OnDefaultInit callbacks (copy-on-write)
+	defaultInitHooks   atomic.Pointer[[]func(*Logger)]
+	defaultInitHooksMu sync.Mutex
+
+	// replacedDefaults tracks the background closes of replaced defaults
+	replacedDefaults sync.WaitGroup
 )
 
 func init() {
@@ -1407,7 +2278,11 @@ func DefaultWithErr() (*Logger, error) {
 // Default returns the default global logger (thread-safe).
 // The logger is created on first call with default configuration.
 // Package-level convenience functions use this logger.
-// Note: If SetDefault() is called before Default(), the custom logger is returned.
+//
+// A logger installed explicitly with SetDefault, SwapDefault, InitDefault or
+// ReplaceDefaultFromEnv always wins over this lazy initialization, even when
+// the two race: the lazily created logger is only installed if no default
+// exists yet, and is closed otherwise.
 //
 // To check if the default logger was initialized correctly, use DefaultInitError()
 // or DefaultWithErr():
@@ -1422,64 +2297,99 @@ func Default() *Logger {
 
 	defaultOnce.Do(func() {
 		// Only create if not already set by SetDefault()
-		if defaultLogger.Load() == nil {
-			logger, err := New()
-			if err != nil {
-				// Store the error for later retrieval
-				defaultInitErr.Store(err)
-				defaultUsedFallback.Store(true)
-
-				// Print warning to stderr about fallback logger creation
-				fmt.Fprintf(os.Stderr, "[dd] WARNING: Default logger initialization failed: %v\n", err)
-				fmt.Fprintln(os.Stderr, "[dd] WARNING: Using fallback logger with stderr output")
-
-				// Create fallback logger using standard initialization path
-				// This ensures all future initialization logic is included
-				fallbackCfg := defaultConfig()
-				fallbackInternalCfg := &internalConfig{
-					level:          fallbackCfg.Level,
-					format:         fallbackCfg.Format,
-					timeFormat:     fallbackCfg.TimeFormat,
-					includeTime:    fallbackCfg.IncludeTime,
-					includeLevel:   fallbackCfg.IncludeLevel,
-					fullPath:       fallbackCfg.FullPath,
-					dynamicCaller:  fallbackCfg.DynamicCaller,
-					writers:        []io.Writer{os.Stderr},
-					json:           fallbackCfg.JSON,
-					securityConfig: fallbackCfg.Security,
-					fatalHandler:   fallbackCfg.FatalHandler,
-				}
-				// newFromInternalConfig always returns nil error, so we can safely ignore it
-				logger, _ = newFromInternalConfig(fallbackInternalCfg)
+		if defaultLogger.Load() != nil {
+			return
+		}
+		logger, err := New()
+		usedFallback := err != nil
+		if usedFallback {
+			reportInternal(nil, ComponentLogger, err,
+				"default logger initialization failed, using fallback logger with stderr output: %v", err)
+
+			// Create fallback logger using standard initialization path
+			// This ensures all future initialization logic is included
+			fallbackCfg := defaultConfig()
+			fallbackInternalCfg := &internalConfig{
+				level:          fallbackCfg.Level,
+				format:         fallbackCfg.Format,
+				timeFormat:     fallbackCfg.TimeFormat,
+				includeTime:    fallbackCfg.IncludeTime,
+				includeLevel:   fallbackCfg.IncludeLevel,
+				fullPath:       fallbackCfg.FullPath,
+				dynamicCaller:  fallbackCfg.DynamicCaller,
+				writers:        []io.Writer{os.Stderr},
+				json:           fallbackCfg.JSON,
+				securityConfig: fallbackCfg.Security,
+				fatalHandler:   fallbackCfg.FatalHandler,
 			}
-			defaultLogger.Store(logger)
+			// newFromInternalConfig always returns nil error, so we can safely ignore it
+			logger, _ = newFromInternalConfig(fallbackInternalCfg)
+		}
+
+		// An explicitly installed logger may have appeared meanwhile; it wins
+		if !defaultLogger.CompareAndSwap(nil, logger) {
+			_ = logger.Close()
+			return
 		}
+		if usedFallback {
+			defaultInitErr.Store(err)
+			defaultUsedFallback.Store(true)
+		}
+		runDefaultInitHooks(logger)
 	})
 
 	return defaultLogger.Load()
 }
 
 // SetDefault sets the default global logger (thread-safe).
-// If a previous default logger exists, it is safely closed in background.
+// If a previous default logger exists, it is closed in the background after
+// a short delay so in-flight calls can finish. Use SwapDefault to keep
+// control of the previous logger instead.
 // Passing nil is ignored (no change).
 func SetDefault(logger *Logger) {
-	if logger == nil {
-		return
+	if oldLogger := SwapDefault(logger); oldLogger != nil {
+		closeReplacedDefault(oldLogger)
 	}
+}
 
+// SwapDefault installs logger as the default global logger and returns the
+// previous one, or nil if none was installed. Unlike SetDefault, the
+// previous logger is left open; the caller decides when to close it.
+// Passing nil is ignored and returns nil.
+//
+// Example:
+//
+//	old := dd.SwapDefault(logger)
+//	defer func() { dd.SwapDefault(old) }() // restore in tests
+func SwapDefault(logger *Logger) *Logger {
+	if logger == nil {
+		return nil
+	}
 	oldLogger := defaultLogger.Swap(logger)
 
-	if oldLogger != nil {
-		go func() {
-			time.Sleep(defaultLoggerCloseDelay)
-			_ = oldLogger.Close()
-		}()
-	}
+	// An explicit logger supersedes any lazy fallback state
+	defaultInitErr.Store(errNoInit)
+	defaultUsedFallback.Store(false)
+
+	runDefaultInitHooks(logger)
+	return oldLogger
+}
+
+// closeReplacedDefault closes a replaced default logger after
+// defaultLoggerCloseDelay so in-flight log calls can complete.
+func closeReplacedDefault(logger *Logger) {
+	replacedDefaults.Add(1)
+	go func() {
+		defer replacedDefaults.Done()
+		time.Sleep(defaultLoggerCloseDelay)
+		_ = logger.Close()
+	}()
 }
 
 // InitDefault initializes the default logger with the provided configuration.
-// Returns an error if initialization fails. If a default logger already exists,
-// it is closed and replaced with a new one.
+// Returns an error if initialization fails, in which case the current default
+// is left unchanged. If a default logger already exists, it is replaced and
+// closed as with SetDefault.
 //
 // Example:
 //
@@ -1498,19 +2408,112 @@ func InitDefault(cfg *Config) error {
 		return err
 	}
 
-	oldLogger := defaultLogger.Swap(logger)
-	if oldLogger != nil {
-		go func() {
-			time.Sleep(defaultLoggerCloseDelay)
-			_ = oldLogger.Close()
-		}()
+	SetDefault(logger)
+	return nil
+}
+
+// Environment variables read by ReplaceDefaultFromEnv.
+const (
+	EnvLevel  = "DD_LEVEL"  // debug, info, warn, error or fatal
+	EnvFormat = "DD_FORMAT" // text, json or console
+	EnvOutput = "DD_OUTPUT" // stdout, stderr or a file path
+)
+
+// ReplaceDefaultFromEnv builds a logger from DefaultConfig with overrides
+// from the DD_LEVEL, DD_FORMAT and DD_OUTPUT environment variables and
+// installs it as the default, as InitDefault does. Unset or empty variables
+// keep the defaults. Invalid values return an error wrapping
+// ErrConfigValidation and leave the current default unchanged.
+//
+// Example:
+//
+//	// DD_LEVEL=debug DD_FORMAT=json DD_OUTPUT=/var/log/app.log ./app
+//	func init() {
+//	    if err := dd.ReplaceDefaultFromEnv(); err != nil {
+//	        fmt.Fprintln(os.Stderr, err)
+//	    }
+//	}
+func ReplaceDefaultFromEnv() error {
+	cfg, err := configFromEnv()
+	if err != nil {
+		return err
 	}
+	return InitDefault(cfg)
+}
 
-	// Clear any previous initialization error
-	defaultInitErr.Store(errNoInit)
-	defaultUsedFallback.Store(false)
+// configFromEnv returns DefaultConfig with the DD_* environment overrides.
+func configFromEnv() (*Config, error) {
+	cfg := DefaultConfig()
 
-	return nil
+	if value := strings.TrimSpace(os.Getenv(EnvLevel)); value != "" {
+		level, err := ParseLevel(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrConfigValidation, EnvLevel, err)
+		}
+		cfg.Level = level
+	}
+
+	if value := strings.TrimSpace(os.Getenv(EnvFormat)); value != "" {
+		format, err := ParseFormat(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrConfigValidation, EnvFormat, err)
+		}
+		cfg.Format = format
+	}
+
+	if value := strings.TrimSpace(os.Getenv(EnvOutput)); value != "" {
+		switch strings.ToLower(value) {
+		case "stdout":
+			cfg.Output = os.Stdout
+		case "stderr":
+			cfg.Output = os.Stderr
+		default:
+			cfg.File = &FileConfig{Path: value}
+		}
+	}
+
+	return cfg, nil
+}
+
+// OnDefaultInit registers fn to be called each time a logger becomes the
+// default: on lazy initialization by Default and on every SetDefault,
+// SwapDefault, InitDefault or ReplaceDefaultFromEnv. fn runs synchronously
+// in the installing goroutine, after the logger is visible to Default.
+// Use it to attach writers or hooks to whichever logger ends up installed.
+//
+// Registering does not call fn for a logger that is already installed.
+// Panics in fn are recovered and reported on stderr.
+func OnDefaultInit(fn func(logger *Logger)) {
+	if fn == nil {
+		return
+	}
+	defaultInitHooksMu.Lock()
+	defer defaultInitHooksMu.Unlock()
+
+	var hooks []func(*Logger)
+	if current := defaultInitHooks.Load(); current != nil {
+		hooks = append(hooks, *current...)
+	}
+	hooks = append(hooks, fn)
+	defaultInitHooks.Store(&hooks)
+}
+
+// runDefaultInitHooks calls the OnDefaultInit callbacks for logger.
+func runDefaultInitHooks(logger *Logger) {
+	hooks := defaultInitHooks.Load()
+	if hooks == nil {
+		return
+	}
+	for _, fn := range *hooks {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					reportInternal(nil, ComponentLogger, nil, "OnDefaultInit callback panic: %v", r)
+				}
+			}()
+			fn(logger)
+		}()
+	}
 }
 
 // ============================================================================
@@ -1535,6 +2538,10 @@ func Errorf(format string, args ...any) { Default().Logf(LevelError, format, arg
 // WARNING: defer statements will NOT execute. For graceful shutdown, use Errorf() with custom logic.
 func Fatalf(format string, args ...any) { Default().Logf(LevelFatal, format, args...) }
 
+// FatalDefer logs a message at FATAL level using the default logger and panics
+// with a *FatalPanic so defer statements run. Recover it with ExitOnFatal in main.
+func FatalDefer(args ...any) { Default().fatalDefer(args...) }
+
 // SetLevel sets the log level for the default logger.
 // Returns ErrInvalidLevel if the level is outside the valid range [LevelDebug, LevelFatal].
 func SetLevel(level LogLevel) error {
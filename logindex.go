@@ -0,0 +1,411 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultLogIndexCapacity is the number of entries a LogIndex keeps when
+// LogIndexConfig.Capacity is not set.
+const defaultLogIndexCapacity = 10000
+
+// defaultLogIndexQueryLimit and maxLogIndexQueryLimit bound the entries
+// returned by LogIndexHandler.
+const (
+	defaultLogIndexQueryLimit = 100
+	maxLogIndexQueryLimit     = 10000
+)
+
+// LogIndexConfig configures a LogIndex. Zero values select the defaults.
+type LogIndexConfig struct {
+	// Capacity is the number of entries kept; the oldest are evicted
+	// when full (default: 10000).
+	Capacity int
+	// MinLevel is the writer's own level (default: LevelDebug), which
+	// overrides the logger level for this writer. See MinLevelWriter.
+	MinLevel LogLevel
+	// IndexFields are the field keys looked up by value rather than by
+	// scanning (default: request_id and trace_id).
+	IndexFields []string
+}
+
+// LogIndex is a writer that keeps recent entries in memory, indexed by
+// level, time and selected fields, so a debugging endpoint can answer
+// questions like "the last 50 entries for request X". It is a
+// MinLevelWriter and a RecordWriter: entries keep their level and fields
+// as logged, after sensitive data filtering.
+//
+// Example:
+//
+//	index := dd.NewLogIndex(dd.LogIndexConfig{Capacity: 50000})
+//	cfg := dd.DefaultConfig()
+//	cfg.Outputs = []io.Writer{os.Stdout, index}
+//	logger, _ := dd.New(cfg)
+//
+//	recent := index.Query(dd.LogQuery{
+//	    Fields: map[string]string{"request_id": id},
+//	    Limit:  50,
+//	})
+//	mux.Handle("/debug/logs", dd.LogIndexHandler(index))
+type LogIndex struct {
+	minLevel LogLevel
+	indexed  map[string]bool
+
+	mu      sync.RWMutex
+	entries []Record // entry with sequence number s is at s % len(entries)
+	seq     uint64   // sequence number of the next entry
+	// postings maps an indexed key and value to the ascending sequence
+	// numbers of the entries carrying it.
+	postings map[string]map[string][]uint64
+}
+
+// NewLogIndex creates an empty LogIndex.
+func NewLogIndex(opts ...LogIndexConfig) *LogIndex {
+	var config LogIndexConfig
+	if len(opts) > 0 {
+		config = opts[0]
+	}
+	if config.Capacity <= 0 {
+		config.Capacity = defaultLogIndexCapacity
+	}
+	if config.IndexFields == nil {
+		config.IndexFields = []string{"request_id", "trace_id"}
+	}
+
+	ix := &LogIndex{
+		indexed:  make(map[string]bool, len(config.IndexFields)),
+		entries:  make([]Record, config.Capacity),
+		postings: make(map[string]map[string][]uint64),
+	}
+	if config.MinLevel.IsValid() {
+		ix.minLevel = config.MinLevel
+	}
+	for _, key := range config.IndexFields {
+		ix.indexed[key] = true
+	}
+	return ix
+}
+
+// MinLevel implements MinLevelWriter.
+func (ix *LogIndex) MinLevel() LogLevel {
+	return ix.minLevel
+}
+
+// Write parses p as one entry of text or JSON output, like Reader, and
+// stores it. Entries from a Logger arrive through WriteRecord instead.
+func (ix *LogIndex) Write(p []byte) (int, error) {
+	r := NewReader(bytes.NewReader(p))
+	if r.Next() {
+		ix.add(*r.Record())
+	}
+	return len(p), nil
+}
+
+// WriteRecord implements RecordWriter.
+func (ix *LogIndex) WriteRecord(rec *Record, p []byte) (int, error) {
+	stored := *rec
+	// The logger may reuse the fields after the call returns
+	stored.Fields = slices.Clone(rec.Fields)
+	ix.add(stored)
+	return len(p), nil
+}
+
+// add stores rec, evicting the oldest entry when full.
+func (ix *LogIndex) add(rec Record) {
+	if rec.Time.IsZero() {
+		rec.Time = time.Now()
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	slot := ix.seq % uint64(len(ix.entries))
+	if ix.seq >= uint64(len(ix.entries)) {
+		ix.unindex(ix.entries[slot])
+	}
+	ix.entries[slot] = rec
+	for _, field := range rec.Fields {
+		if !ix.indexed[field.Key] {
+			continue
+		}
+		values := ix.postings[field.Key]
+		if values == nil {
+			values = make(map[string][]uint64)
+			ix.postings[field.Key] = values
+		}
+		value := indexValue(field.Value)
+		values[value] = append(values[value], ix.seq)
+	}
+	ix.seq++
+}
+
+// unindex removes the evicted entry rec, the oldest one, from the postings.
+func (ix *LogIndex) unindex(rec Record) {
+	for _, field := range rec.Fields {
+		values := ix.postings[field.Key]
+		if values == nil {
+			continue
+		}
+		value := indexValue(field.Value)
+		if seqs := values[value]; len(seqs) > 1 {
+			values[value] = seqs[1:]
+		} else {
+			delete(values, value)
+		}
+	}
+}
+
+// LogQuery selects entries from a LogIndex. Zero values match everything.
+type LogQuery struct {
+	MinLevel LogLevel  // lowest level to return
+	Since    time.Time // entries at or after this time
+	Until    time.Time // entries before this time
+	// Fields must all be present with these values, compared as
+	// fmt.Sprint renders them. Indexed keys are looked up directly.
+	Fields map[string]string
+	// Contains must occur in the message.
+	Contains string
+	// Limit is the maximum number of entries returned, keeping the
+	// newest (0 means no limit).
+	Limit int
+}
+
+// Query returns the entries matching q, oldest first. The Fields slices
+// of the returned records are shared with the index and must not be
+// modified.
+func (ix *LogIndex) Query(q LogQuery) []Record {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	oldest := uint64(0)
+	if ix.seq > uint64(len(ix.entries)) {
+		oldest = ix.seq - uint64(len(ix.entries))
+	}
+
+	var result []Record
+	match := func(seq uint64) bool {
+		rec := &ix.entries[seq%uint64(len(ix.entries))]
+		if q.matches(rec) {
+			result = append(result, *rec)
+		}
+		return q.Limit > 0 && len(result) >= q.Limit
+	}
+
+	if seqs, ok := ix.candidates(q); ok {
+		for i := len(seqs) - 1; i >= 0; i-- {
+			if match(seqs[i]) {
+				break
+			}
+		}
+	} else {
+		for seq := ix.seq; seq > oldest; seq-- {
+			if match(seq - 1) {
+				break
+			}
+		}
+	}
+	slices.Reverse(result)
+	return result
+}
+
+// candidates returns the shortest posting list among the indexed fields
+// of q, or false if q has no indexed field.
+func (ix *LogIndex) candidates(q LogQuery) ([]uint64, bool) {
+	var best []uint64
+	found := false
+	for key, value := range q.Fields {
+		if !ix.indexed[key] {
+			continue
+		}
+		seqs := ix.postings[key][value]
+		if !found || len(seqs) < len(best) {
+			best, found = seqs, true
+		}
+	}
+	return best, found
+}
+
+// matches reports whether rec satisfies every condition of q.
+func (q *LogQuery) matches(rec *Record) bool {
+	if rec.Level < q.MinLevel {
+		return false
+	}
+	if !q.Since.IsZero() && rec.Time.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !rec.Time.Before(q.Until) {
+		return false
+	}
+	if q.Contains != "" && !strings.Contains(rec.Message, q.Contains) {
+		return false
+	}
+	for key, want := range q.Fields {
+		found := false
+		for _, field := range rec.Fields {
+			if field.Key == key && indexValue(field.Value) == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Len returns the number of stored entries.
+func (ix *LogIndex) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return int(min(ix.seq, uint64(len(ix.entries))))
+}
+
+// Reset discards all stored entries.
+func (ix *LogIndex) Reset() {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	clear(ix.entries)
+	clear(ix.postings)
+	ix.seq = 0
+}
+
+// indexValue is the string a field value is indexed and matched by.
+func indexValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// LogIndexEntry is the JSON form of a Record served by LogIndexHandler.
+type LogIndexEntry struct {
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Message string         `json:"message"`
+	Fields  map[string]any `json:"fields,omitempty"`
+}
+
+// LogIndexHandler returns an HTTP handler serving index queries as JSON,
+// {"entries": [...]}, oldest first. Query parameters:
+//
+//	level     lowest level, e.g. warn
+//	since     RFC 3339 time, or a duration meaning that long ago (e.g. 15m)
+//	until     RFC 3339 time
+//	q         substring of the message
+//	limit     maximum entries, newest kept (default 100, at most 10000)
+//
+// Any other parameter filters on a field, e.g. request_id=abc.
+//
+// Entries can hold internal details, so serve it only on an internal or
+// authenticated endpoint.
+//
+// Example:
+//
+//	mux.Handle("/debug/logs", dd.LogIndexHandler(index))
+//
+//	// curl 'localhost:6060/debug/logs?request_id=abc&limit=50'
+func LogIndexHandler(index *LogIndex) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		q, err := parseLogQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		records := index.Query(q)
+		entries := make([]LogIndexEntry, len(records))
+		for i, rec := range records {
+			entries[i] = LogIndexEntry{
+				Time:    rec.Time,
+				Level:   rec.Level.String(),
+				Message: rec.Message,
+			}
+			if len(rec.Fields) > 0 {
+				entries[i].Fields = make(map[string]any, len(rec.Fields))
+				for _, field := range rec.Fields {
+					entries[i].Fields[field.Key] = jsonFieldValue(field.Value)
+				}
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(struct {
+			Entries []LogIndexEntry `json:"entries"`
+		}{entries})
+	})
+}
+
+// parseLogQuery builds a LogQuery from the handler's query parameters.
+func parseLogQuery(r *http.Request) (LogQuery, error) {
+	q := LogQuery{Limit: defaultLogIndexQueryLimit}
+	for key, values := range r.URL.Query() {
+		value := values[0]
+		var err error
+		switch key {
+		case "level":
+			q.MinLevel, err = ParseLevel(value)
+		case "since":
+			if d, derr := time.ParseDuration(value); derr == nil {
+				q.Since = time.Now().Add(-d)
+			} else {
+				q.Since, err = time.Parse(time.RFC3339Nano, value)
+			}
+		case "until":
+			q.Until, err = time.Parse(time.RFC3339Nano, value)
+		case "q":
+			q.Contains = value
+		case "limit":
+			q.Limit, err = strconv.Atoi(value)
+			if err == nil && (q.Limit <= 0 || q.Limit > maxLogIndexQueryLimit) {
+				err = fmt.Errorf("limit must be between 1 and %d", maxLogIndexQueryLimit)
+			}
+		default:
+			if q.Fields == nil {
+				q.Fields = make(map[string]string)
+			}
+			q.Fields[key] = value
+		}
+		if err != nil {
+			return q, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	return q, nil
+}
+
+// jsonFieldValue returns v in a form encoding/json renders usefully.
+func jsonFieldValue(v any) any {
+	switch v := v.(type) {
+	case nil, string, bool, int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		time.Time, json.Marshaler:
+		return v
+	case float32:
+		return jsonFieldValue(float64(v))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Sprint(v)
+		}
+		return v
+	case error:
+		return v.Error()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,133 @@
+package dd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogIndexQuery(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	index := NewLogIndex()
+	logger := mustNewTestLogger(t, &Config{Level: LevelInfo, Format: FormatJSON, Outputs: []io.Writer{index}, Clock: clock})
+
+	for i := 0; i < 10; i++ {
+		logger.InfoWith(fmt.Sprintf("step %d", i), String("request_id", fmt.Sprint("req-", i%2)), Int("n", i))
+		clock.Advance(time.Second)
+	}
+	logger.Debug("below the logger level but kept by the index")
+	logger.ErrorWith("failed", String("request_id", "req-1"))
+
+	if index.Len() != 12 {
+		t.Fatalf("Len = %d", index.Len())
+	}
+
+	got := index.Query(LogQuery{Fields: map[string]string{"request_id": "req-1"}, Limit: 3})
+	if len(got) != 3 || got[0].Message != "step 7" || got[2].Message != "failed" {
+		t.Errorf("indexed query: %+v", got)
+	}
+
+	got = index.Query(LogQuery{Fields: map[string]string{"n": "4"}})
+	if len(got) != 1 || got[0].Message != "step 4" {
+		t.Errorf("unindexed field query: %+v", got)
+	}
+
+	got = index.Query(LogQuery{MinLevel: LevelWarn})
+	if len(got) != 1 || got[0].Level != LevelError {
+		t.Errorf("level query: %+v", got)
+	}
+
+	start := time.Date(2024, 1, 2, 3, 4, 7, 0, time.UTC)
+	got = index.Query(LogQuery{Since: start, Until: start.Add(2 * time.Second)})
+	if len(got) != 2 || got[0].Message != "step 2" || got[1].Message != "step 3" {
+		t.Errorf("time range query: %+v", got)
+	}
+
+	if got = index.Query(LogQuery{Contains: "below"}); len(got) != 1 || got[0].Level != LevelDebug {
+		t.Errorf("message query: %+v", got)
+	}
+}
+
+func TestLogIndexEviction(t *testing.T) {
+	index := NewLogIndex(LogIndexConfig{Capacity: 4, IndexFields: []string{"user"}})
+	logger := mustNewTestLogger(t, &Config{Level: LevelInfo, Format: FormatJSON, Outputs: []io.Writer{index}})
+
+	for i := 0; i < 6; i++ {
+		logger.InfoWith(fmt.Sprint("entry ", i), String("user", fmt.Sprint("u", i%3)))
+	}
+	if index.Len() != 4 {
+		t.Fatalf("Len = %d", index.Len())
+	}
+	all := index.Query(LogQuery{})
+	if len(all) != 4 || all[0].Message != "entry 2" || all[3].Message != "entry 5" {
+		t.Errorf("oldest entries should be evicted: %+v", all)
+	}
+	got := index.Query(LogQuery{Fields: map[string]string{"user": "u0"}})
+	if len(got) != 1 || got[0].Message != "entry 3" {
+		t.Errorf("evicted entries should leave the index: %+v", got)
+	}
+
+	index.Reset()
+	if index.Len() != 0 || len(index.Query(LogQuery{Fields: map[string]string{"user": "u2"}})) != 0 {
+		t.Error("Reset should empty the index")
+	}
+}
+
+func TestLogIndexWrite(t *testing.T) {
+	index := NewLogIndex()
+	index.Write([]byte("[2024-01-02T03:04:05Z  ERROR] main.go:10 boom request_id=abc\n"))
+
+	got := index.Query(LogQuery{Fields: map[string]string{"request_id": "abc"}})
+	if len(got) != 1 || got[0].Level != LevelError || got[0].Message != "boom" {
+		t.Errorf("parsed entry: %+v", got)
+	}
+}
+
+func TestLogIndexHandler(t *testing.T) {
+	index := NewLogIndex()
+	logger := mustNewTestLogger(t, &Config{Level: LevelInfo, Format: FormatJSON, Outputs: []io.Writer{index}})
+	logger.InfoWith("one", String("request_id", "abc"), Float64("ratio", 0.5))
+	logger.WarnWith("two", String("request_id", "abc"))
+	logger.WarnWith("three", String("request_id", "xyz"))
+
+	handler := LogIndexHandler(index)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/logs?request_id=abc&limit=5", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+	var body struct {
+		Entries []LogIndexEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Entries) != 2 || body.Entries[0].Message != "one" || body.Entries[0].Fields["ratio"] != 0.5 {
+		t.Errorf("unexpected entries: %+v", body.Entries)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/logs?level=warn&since=1h", nil))
+	if !strings.Contains(rec.Body.String(), "three") || strings.Contains(rec.Body.String(), `"one"`) {
+		t.Errorf("level and since filters: %s", rec.Body)
+	}
+
+	for _, query := range []string{"level=loud", "limit=0", "since=yesterday"} {
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/logs?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d", query, rec.Code)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/logs", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST: status %d", rec.Code)
+	}
+}
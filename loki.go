@@ -0,0 +1,449 @@
+package dd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultLokiBatchSize    = 500
+	defaultLokiBatchAge     = time.Second
+	defaultLokiMaxRetries   = 3
+	defaultLokiRetryBackoff = 500 * time.Millisecond
+	defaultLokiTimeout      = 10 * time.Second
+	defaultLokiLevelLabel   = "level"
+
+	// lokiPendingBatches bounds buffered entries to this many batches while
+	// pushes are failing or slow; further entries are dropped.
+	lokiPendingBatches = 10
+)
+
+// lokiLabelName matches the label names Loki accepts.
+var lokiLabelName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// LokiConfig configures a LokiWriter.
+type LokiConfig struct {
+	// URL is the push endpoint, e.g. "http://loki:3100/loki/api/v1/push".
+	URL string
+
+	// Labels are attached to every stream.
+	Labels map[string]string
+
+	// LevelLabel is the label carrying the entry level. Empty uses "level".
+	LevelLabel string
+
+	// LabelFields lists field keys promoted to labels; they are removed from
+	// the line body. Nil uses {"service", "env"}. Keep this set small:
+	// every distinct label combination is a separate Loki stream.
+	LabelFields []string
+
+	// Headers are added to each push request (e.g. "X-Scope-OrgID" or
+	// "Authorization").
+	Headers map[string]string
+
+	// MaxBatchSize is the number of entries that triggers a push.
+	// Zero uses 500.
+	MaxBatchSize int
+
+	// MaxBatchAge bounds how long an entry waits before it is pushed.
+	// Zero uses 1s.
+	MaxBatchAge time.Duration
+
+	// MaxRetries is the number of retries for a push that fails with a
+	// network error, 429 or 5xx. Zero uses 3; negative disables retries.
+	MaxRetries int
+
+	// RetryBackoff is the delay before the first retry; it doubles on each
+	// further attempt. Zero uses 500ms.
+	RetryBackoff time.Duration
+
+	// Client sends the push requests. Nil uses a client with a 10s timeout.
+	Client *http.Client
+}
+
+// LokiWriter batches log entries and pushes them to Grafana Loki's HTTP API.
+// The level and the fields named in LokiConfig.LabelFields become stream
+// labels; the message and remaining fields become a JSON line body, which
+// Loki's "| json" parser can expand.
+//
+// Entries written through plain Write (without a Record) are pushed as-is
+// with the static labels only. Pushes happen on a background goroutine, so
+// logging never waits for Loki.
+//
+// Example:
+//
+//	loki, err := dd.NewLokiWriter(dd.LokiConfig{
+//	    URL:    "http://loki:3100/loki/api/v1/push",
+//	    Labels: map[string]string{"app": "billing"},
+//	})
+//	cfg := dd.JSONConfig()
+//	cfg.Outputs = []io.Writer{os.Stdout, loki}
+type LokiWriter struct {
+	url         string
+	labels      map[string]string
+	levelLabel  string
+	labelFields map[string]string // field key -> label name
+	headers     map[string]string
+	batchSize   int
+	batchAge    time.Duration
+	maxRetries  int
+	backoff     time.Duration
+	client      *http.Client
+
+	mu           sync.Mutex
+	pending      []lokiEntry
+	pendingBytes atomic.Int64 // size of the buffered lines, including those being pushed
+	sendMu       sync.Mutex   // serializes pushes so batches arrive in order
+	flushCh      chan struct{}
+	dropped      atomic.Int64
+	closed       atomic.Bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// lokiEntry is one buffered line with its stream labels.
+type lokiEntry struct {
+	labels map[string]string
+	ts     time.Time
+	line   string
+}
+
+// NewLokiWriter creates a LokiWriter and starts its background pusher.
+// Call Close to push the remaining entries and stop it.
+func NewLokiWriter(cfg LokiConfig) (*LokiWriter, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("%w: Loki URL cannot be empty", ErrConfigValidation)
+	}
+	if !strings.HasPrefix(cfg.URL, "http://") && !strings.HasPrefix(cfg.URL, "https://") {
+		return nil, fmt.Errorf("%w: Loki URL must use http or https", ErrConfigValidation)
+	}
+	if cfg.MaxBatchSize < 0 || cfg.MaxBatchAge < 0 || cfg.RetryBackoff < 0 {
+		return nil, fmt.Errorf("%w: Loki batch and retry settings cannot be negative", ErrConfigValidation)
+	}
+
+	lw := &LokiWriter{
+		url:         cfg.URL,
+		labels:      make(map[string]string, len(cfg.Labels)),
+		levelLabel:  cfg.LevelLabel,
+		labelFields: make(map[string]string),
+		headers:     make(map[string]string, len(cfg.Headers)),
+		batchSize:   cfg.MaxBatchSize,
+		batchAge:    cfg.MaxBatchAge,
+		maxRetries:  cfg.MaxRetries,
+		backoff:     cfg.RetryBackoff,
+		client:      cfg.Client,
+		flushCh:     make(chan struct{}, 1),
+	}
+	if lw.levelLabel == "" {
+		lw.levelLabel = defaultLokiLevelLabel
+	}
+	if lw.batchSize == 0 {
+		lw.batchSize = defaultLokiBatchSize
+	}
+	if lw.batchAge == 0 {
+		lw.batchAge = defaultLokiBatchAge
+	}
+	if lw.maxRetries == 0 {
+		lw.maxRetries = defaultLokiMaxRetries
+	} else if lw.maxRetries < 0 {
+		lw.maxRetries = 0
+	}
+	if lw.backoff == 0 {
+		lw.backoff = defaultLokiRetryBackoff
+	}
+	if lw.client == nil {
+		lw.client = &http.Client{Timeout: defaultLokiTimeout}
+	}
+
+	if !lokiLabelName.MatchString(lw.levelLabel) {
+		return nil, fmt.Errorf("%w: invalid Loki label name %q", ErrConfigValidation, lw.levelLabel)
+	}
+	for name, value := range cfg.Labels {
+		if !lokiLabelName.MatchString(name) {
+			return nil, fmt.Errorf("%w: invalid Loki label name %q", ErrConfigValidation, name)
+		}
+		lw.labels[name] = value
+	}
+	labelFields := cfg.LabelFields
+	if labelFields == nil {
+		labelFields = []string{"service", "env"}
+	}
+	for _, key := range labelFields {
+		// Dotted or dashed field keys map to underscores, as Loki requires
+		name := strings.NewReplacer(".", "_", "-", "_").Replace(key)
+		if !lokiLabelName.MatchString(name) {
+			return nil, fmt.Errorf("%w: field %q cannot be used as a Loki label", ErrConfigValidation, key)
+		}
+		lw.labelFields[key] = name
+	}
+	for k, v := range cfg.Headers {
+		lw.headers[k] = v
+	}
+
+	lw.ctx, lw.cancel = context.WithCancel(context.Background())
+	lw.wg.Add(1)
+	go lw.run()
+
+	return lw, nil
+}
+
+// Write buffers p as a line carrying only the static labels.
+func (lw *LokiWriter) Write(p []byte) (int, error) {
+	return lw.add(lw.labels, time.Now(), string(bytes.TrimRight(p, "\n")), len(p))
+}
+
+// WriteLevel implements LevelWriter.
+func (lw *LokiWriter) WriteLevel(level LogLevel, p []byte) (int, error) {
+	labels := lw.streamLabels(level, nil)
+	return lw.add(labels, time.Now(), string(bytes.TrimRight(p, "\n")), len(p))
+}
+
+// WriteRecord implements RecordWriter. The formatted entry p is not used:
+// the line body is rebuilt from the record without the label fields.
+func (lw *LokiWriter) WriteRecord(rec *Record, p []byte) (int, error) {
+	body := make([]Field, 0, len(rec.Fields)+1)
+	body = append(body, Field{Key: "message", Value: rec.Message})
+	for _, f := range rec.Fields {
+		if _, ok := lw.labelFields[f.Key]; !ok {
+			body = append(body, f)
+		}
+	}
+	line, err := ObjectValue(body).MarshalJSON()
+	if err != nil {
+		return 0, err
+	}
+	return lw.add(lw.streamLabels(rec.Level, rec.Fields), rec.Time, string(line), len(p))
+}
+
+// streamLabels returns the labels for an entry at level with fields.
+func (lw *LokiWriter) streamLabels(level LogLevel, fields []Field) map[string]string {
+	labels := make(map[string]string, len(lw.labels)+1+len(lw.labelFields))
+	for k, v := range lw.labels {
+		labels[k] = v
+	}
+	labels[lw.levelLabel] = strings.ToLower(level.String())
+	for _, f := range fields {
+		if name, ok := lw.labelFields[f.Key]; ok {
+			labels[name] = fmt.Sprint(f.Value)
+		}
+	}
+	return labels
+}
+
+// add buffers one entry, requesting a push when the batch is full.
+func (lw *LokiWriter) add(labels map[string]string, ts time.Time, line string, n int) (int, error) {
+	if lw.closed.Load() {
+		return 0, ErrLoggerClosed
+	}
+
+	lw.mu.Lock()
+	if len(lw.pending) >= lw.batchSize*lokiPendingBatches {
+		lw.mu.Unlock()
+		lw.dropped.Add(1)
+		return n, nil
+	}
+	lw.pending = append(lw.pending, lokiEntry{labels: labels, ts: ts, line: line})
+	lw.pendingBytes.Add(int64(len(line)))
+	full := len(lw.pending) >= lw.batchSize
+	lw.mu.Unlock()
+
+	if full {
+		select {
+		case lw.flushCh <- struct{}{}:
+		default:
+		}
+	}
+	return n, nil
+}
+
+// run pushes batches when they fill up or age out.
+func (lw *LokiWriter) run() {
+	defer lw.wg.Done()
+	ticker := time.NewTicker(lw.batchAge)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-lw.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-lw.flushCh:
+		}
+		if err := lw.push(lw.ctx); err != nil {
+			reportInternal(nil, ComponentWriter, err, "Loki push to %s failed: %v", lw.url, err)
+		}
+	}
+}
+
+// Flush pushes all buffered entries and returns the first push error.
+func (lw *LokiWriter) Flush() error {
+	return lw.push(context.Background())
+}
+
+// push sends the pending entries in batches of at most batchSize. Entries
+// of a batch that ultimately fails are dropped.
+func (lw *LokiWriter) push(ctx context.Context) error {
+	lw.sendMu.Lock()
+	defer lw.sendMu.Unlock()
+
+	var firstErr error
+	for {
+		lw.mu.Lock()
+		n := min(len(lw.pending), lw.batchSize)
+		batch := lw.pending[:n:n]
+		lw.pending = lw.pending[n:]
+		if len(lw.pending) == 0 {
+			lw.pending = nil
+		}
+		lw.mu.Unlock()
+
+		if n == 0 {
+			return firstErr
+		}
+		err := lw.send(ctx, batch)
+		for _, entry := range batch {
+			lw.pendingBytes.Add(-int64(len(entry.line)))
+		}
+		if err != nil {
+			lw.dropped.Add(int64(n))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+}
+
+// lokiPushRequest is the JSON body of POST /loki/api/v1/push.
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// encodeLokiBatch groups a batch into streams by label set.
+func encodeLokiBatch(batch []lokiEntry) ([]byte, error) {
+	var req lokiPushRequest
+	index := make(map[string]int)
+	for _, e := range batch {
+		key := lokiStreamKey(e.labels)
+		i, ok := index[key]
+		if !ok {
+			i = len(req.Streams)
+			index[key] = i
+			req.Streams = append(req.Streams, lokiStream{Stream: e.labels})
+		}
+		req.Streams[i].Values = append(req.Streams[i].Values,
+			[2]string{strconv.FormatInt(e.ts.UnixNano(), 10), e.line})
+	}
+	return json.Marshal(req)
+}
+
+// lokiStreamKey returns a canonical key for a label set.
+func lokiStreamKey(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var sb strings.Builder
+	for _, name := range names {
+		sb.WriteString(name)
+		sb.WriteByte('=')
+		sb.WriteString(strconv.Quote(labels[name]))
+		sb.WriteByte(',')
+	}
+	return sb.String()
+}
+
+// send pushes one batch, retrying network errors, 429 and 5xx responses.
+// Cancelling ctx stops further retries but not a request in flight.
+func (lw *LokiWriter) send(ctx context.Context, batch []lokiEntry) error {
+	body, err := encodeLokiBatch(batch)
+	if err != nil {
+		return err
+	}
+
+	backoff := lw.backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := lw.post(context.WithoutCancel(ctx), body)
+		if err == nil || !retry || attempt >= lw.maxRetries {
+			return err
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// post performs a single push request and reports whether a failure is
+// worth retrying.
+func (lw *LokiWriter) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, lw.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range lw.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := lw.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	_ = resp.Body.Close()
+
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("loki returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+}
+
+// Dropped returns the number of entries discarded because the buffer was
+// full or their push failed after all retries.
+func (lw *LokiWriter) Dropped() int64 {
+	return lw.dropped.Load()
+}
+
+// QueueDepth returns the number of buffered entries not yet pushed.
+func (lw *LokiWriter) QueueDepth() int {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	return len(lw.pending)
+}
+
+// MemoryUsage implements MemoryUser: the size of the buffered lines.
+func (lw *LokiWriter) MemoryUsage() int64 {
+	return lw.pendingBytes.Load()
+}
+
+// Close stops the background pusher and pushes the remaining entries.
+func (lw *LokiWriter) Close() error {
+	if !lw.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	lw.cancel()
+	lw.wg.Wait()
+	return lw.push(context.Background())
+}
@@ -0,0 +1,253 @@
+package dd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// lokiServer records push requests and answers with the given statuses in
+// turn (200 once they run out).
+type lokiServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	pushes   []lokiPushRequest
+	headers  []http.Header
+	statuses []int
+	calls    atomic.Int32
+}
+
+func newLokiServer(t *testing.T, statuses ...int) *lokiServer {
+	t.Helper()
+	s := &lokiServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(s.calls.Add(1)) - 1
+		if n < len(s.statuses) && s.statuses[n] != http.StatusOK {
+			w.WriteHeader(s.statuses[n])
+			return
+		}
+		var req lokiPushRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode push: %v", err)
+		}
+		s.mu.Lock()
+		s.pushes = append(s.pushes, req)
+		s.headers = append(s.headers, r.Header.Clone())
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *lokiServer) streams() []lokiStream {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []lokiStream
+	for _, p := range s.pushes {
+		out = append(out, p.Streams...)
+	}
+	return out
+}
+
+func TestLokiWriterLabelMapping(t *testing.T) {
+	srv := newLokiServer(t)
+	lw, err := NewLokiWriter(LokiConfig{
+		URL:     srv.URL,
+		Labels:  map[string]string{"app": "billing"},
+		Headers: map[string]string{"X-Scope-OrgID": "tenant-1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := JSONConfig()
+	cfg.Outputs = nil
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.AddWriter(lw); err != nil {
+		t.Fatal(err)
+	}
+	logger.InfoWith("charged", String("service", "api"), String("env", "prod"), Int("amount", 42))
+	logger.ErrorWith("declined", String("service", "api"), String("env", "prod"))
+	logger.InfoWith("charged", String("service", "worker"), String("env", "prod"))
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := lw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	streams := srv.streams()
+	if len(streams) != 3 {
+		t.Fatalf("expected 3 streams, got %d: %+v", len(streams), streams)
+	}
+	first := streams[0]
+	wantLabels := map[string]string{"app": "billing", "level": "info", "service": "api", "env": "prod"}
+	for k, v := range wantLabels {
+		if first.Stream[k] != v {
+			t.Errorf("label %s = %q, want %q", k, first.Stream[k], v)
+		}
+	}
+	if len(first.Values) != 1 {
+		t.Fatalf("expected 1 value, got %d", len(first.Values))
+	}
+	var line map[string]any
+	if err := json.Unmarshal([]byte(first.Values[0][1]), &line); err != nil {
+		t.Fatalf("line is not JSON: %v", err)
+	}
+	if line["message"] != "charged" || line["amount"] != float64(42) {
+		t.Errorf("unexpected line body: %v", line)
+	}
+	if _, ok := line["service"]; ok {
+		t.Error("label field should not be repeated in the line body")
+	}
+	if streams[1].Stream["level"] != "error" {
+		t.Errorf("expected error stream, got %v", streams[1].Stream)
+	}
+	if got := srv.headers[0].Get("X-Scope-OrgID"); got != "tenant-1" {
+		t.Errorf("X-Scope-OrgID = %q", got)
+	}
+}
+
+func TestLokiWriterBatching(t *testing.T) {
+	srv := newLokiServer(t)
+	lw, err := NewLokiWriter(LokiConfig{URL: srv.URL, MaxBatchSize: 3, MaxBatchAge: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lw.Close()
+
+	for i := 0; i < 3; i++ {
+		_, _ = lw.Write([]byte("line\n"))
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for srv.calls.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	streams := srv.streams()
+	if len(streams) != 1 || len(streams[0].Values) != 3 {
+		t.Fatalf("expected one full batch, got %+v", streams)
+	}
+	if streams[0].Values[0][1] != "line" {
+		t.Errorf("trailing newline should be trimmed, got %q", streams[0].Values[0][1])
+	}
+}
+
+func TestLokiWriterMaxBatchAge(t *testing.T) {
+	srv := newLokiServer(t)
+	lw, err := NewLokiWriter(LokiConfig{URL: srv.URL, MaxBatchAge: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lw.Close()
+
+	_, _ = lw.WriteLevel(LevelWarn, []byte("aged\n"))
+	deadline := time.Now().Add(2 * time.Second)
+	for srv.calls.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	streams := srv.streams()
+	if len(streams) != 1 || streams[0].Stream["level"] != "warn" {
+		t.Fatalf("expected an aged-out warn batch, got %+v", streams)
+	}
+}
+
+func TestLokiWriterRetry(t *testing.T) {
+	srv := newLokiServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	lw, err := NewLokiWriter(LokiConfig{URL: srv.URL, MaxBatchAge: time.Hour, RetryBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lw.Close()
+
+	_, _ = lw.Write([]byte("retried"))
+	if err := lw.Flush(); err != nil {
+		t.Fatalf("Flush should succeed after retries: %v", err)
+	}
+	if got := srv.calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+	if lw.Dropped() != 0 {
+		t.Errorf("expected no drops, got %d", lw.Dropped())
+	}
+}
+
+func TestLokiWriterClientErrorNotRetried(t *testing.T) {
+	srv := newLokiServer(t, http.StatusBadRequest)
+	lw, err := NewLokiWriter(LokiConfig{URL: srv.URL, MaxBatchAge: time.Hour, RetryBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lw.Close()
+
+	_, _ = lw.Write([]byte("bad"))
+	if err := lw.Flush(); err == nil || !strings.Contains(err.Error(), "400") {
+		t.Fatalf("expected 400 error, got %v", err)
+	}
+	if got := srv.calls.Load(); got != 1 {
+		t.Errorf("4xx should not be retried, got %d attempts", got)
+	}
+	if lw.Dropped() != 1 {
+		t.Errorf("expected 1 dropped entry, got %d", lw.Dropped())
+	}
+}
+
+func TestLokiWriterClose(t *testing.T) {
+	srv := newLokiServer(t)
+	lw, err := NewLokiWriter(LokiConfig{URL: srv.URL, MaxBatchAge: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = lw.Write([]byte("pending"))
+	if err := lw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if streams := srv.streams(); len(streams) != 1 {
+		t.Fatalf("Close should push pending entries, got %+v", streams)
+	}
+	if _, err := lw.Write([]byte("late")); !errors.Is(err, ErrLoggerClosed) {
+		t.Errorf("expected ErrLoggerClosed after Close, got %v", err)
+	}
+	if err := lw.Close(); err != nil {
+		t.Errorf("second Close should be a no-op, got %v", err)
+	}
+}
+
+func TestLokiWriterConfigValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  LokiConfig
+	}{
+		{"empty url", LokiConfig{}},
+		{"bad scheme", LokiConfig{URL: "ftp://loki"}},
+		{"bad label", LokiConfig{URL: "http://loki", Labels: map[string]string{"1app": "x"}}},
+		{"bad level label", LokiConfig{URL: "http://loki", LevelLabel: "lvl!"}},
+		{"bad label field", LokiConfig{URL: "http://loki", LabelFields: []string{"a b"}}},
+		{"negative batch", LokiConfig{URL: "http://loki", MaxBatchSize: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewLokiWriter(tt.cfg); !errors.Is(err, ErrConfigValidation) {
+				t.Errorf("expected ErrConfigValidation, got %v", err)
+			}
+		})
+	}
+
+	lw, err := NewLokiWriter(LokiConfig{URL: "http://loki", LabelFields: []string{"k8s.namespace"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lw.Close()
+	if lw.labelFields["k8s.namespace"] != "k8s_namespace" {
+		t.Errorf("dotted field should map to k8s_namespace, got %q", lw.labelFields["k8s.namespace"])
+	}
+}
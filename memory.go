@@ -0,0 +1,246 @@
+package dd
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// MemoryPolicy selects what the logger does with an entry that would take
+// its memory use above Config.MaxMemoryBytes.
+type MemoryPolicy int
+
+const (
+	// MemoryDrop drops the entry. This is the default.
+	MemoryDrop MemoryPolicy = iota
+
+	// MemoryBlock waits, up to a second, for writers to drain their queues
+	// and for entries being written to finish. The entry is dropped if it
+	// still does not fit.
+	MemoryBlock
+
+	// MemoryShrinkCaches empties the sensitive data filter caches and
+	// retries once. The entry is dropped if it still does not fit.
+	MemoryShrinkCaches
+)
+
+// Timing of MemoryBlock.
+const (
+	memoryBlockTimeout = time.Second
+	memoryBlockPoll    = time.Millisecond
+)
+
+// memoryScanInterval bounds how often entries rescan the filter caches and
+// writers. Between scans the writer total grows by each admitted entry.
+const memoryScanInterval = time.Millisecond
+
+// memoryCacheShare bounds the filter caches of a logger with
+// MaxMemoryBytes to 1/memoryCacheShare of the limit.
+const memoryCacheShare = 4
+
+// String returns the name of the policy.
+func (p MemoryPolicy) String() string {
+	switch p {
+	case MemoryDrop:
+		return "Drop"
+	case MemoryBlock:
+		return "Block"
+	case MemoryShrinkCaches:
+		return "ShrinkCaches"
+	default:
+		return "Unknown"
+	}
+}
+
+// isValid reports whether p is a known policy.
+func (p MemoryPolicy) isValid() bool {
+	return p >= MemoryDrop && p <= MemoryShrinkCaches
+}
+
+// MemoryUser is implemented by writers that hold log data in memory, such
+// as RingBufferWriter, SocketWriter, LokiWriter and BufferedWriter. Their
+// usage counts towards Config.MaxMemoryBytes.
+type MemoryUser interface {
+	// MemoryUsage returns the bytes of log data the writer holds.
+	MemoryUsage() int64
+}
+
+// MemoryStats reports the memory held by a logger. FilterCache and
+// Writers are measured on every call; the other fields are only tracked
+// with Config.MaxMemoryBytes.
+type MemoryStats struct {
+	Limit int64 // Config.MaxMemoryBytes, or 0 if unlimited
+	InUse int64 // FilterCache + InFlight + Writers
+	Peak  int64 // Highest InUse seen when an entry was admitted
+
+	FilterCache int64 // Sensitive data filter result caches
+	InFlight    int64 // Buffers of entries being written
+	Writers     int64 // Data held by writers implementing MemoryUser
+
+	Dropped int64 // Entries dropped at the limit
+	Blocked int64 // Entries that waited for memory (MemoryBlock)
+	Shrinks int64 // Times the caches were emptied (MemoryShrinkCaches)
+}
+
+// memoryState is the runtime state of Config.MaxMemoryBytes.
+type memoryState struct {
+	limit  int64
+	policy MemoryPolicy
+
+	inFlight atomic.Int64
+	peak     atomic.Int64
+	dropped  atomic.Int64
+	blocked  atomic.Int64
+	shrinks  atomic.Int64
+
+	// over is set while entries are dropped, so the limit is reported
+	// once per episode rather than once per entry.
+	over atomic.Bool
+
+	// Totals of the last scan (see scan). holders is the number of writers
+	// implementing MemoryUser, each of which may keep a copy of an entry.
+	start   time.Time
+	scanned atomic.Int64 // Nanoseconds after start, 0 before the first scan
+	caches  atomic.Int64
+	writers atomic.Int64
+	holders atomic.Int64
+}
+
+// newMemoryState returns the state for a limit of limit bytes.
+func newMemoryState(limit int64, policy MemoryPolicy) *memoryState {
+	return &memoryState{limit: limit, policy: policy, start: time.Now()}
+}
+
+// memoryUsage returns the memory held by the filter caches and by writers
+// implementing MemoryUser, and the number of such writers. With a limit it
+// also bounds the caches to their share of it.
+func (l *Logger) memoryUsage() (caches, writers, holders int64) {
+	var cacheLimit int64
+	if l.memory != nil {
+		cacheLimit = max(l.memory.limit/memoryCacheShare, 1)
+	}
+	l.eachSensitiveFilter(func(filter *SensitiveDataFilter) {
+		if cacheLimit > 0 {
+			filter.limitCache(cacheLimit)
+		}
+		caches += filter.cacheBytes.Load()
+	})
+	if writersPtr := l.writersPtr.Load(); writersPtr != nil {
+		for _, w := range *writersPtr {
+			if mu, ok := unwrapMiddleware(w).(MemoryUser); ok {
+				writers += mu.MemoryUsage()
+				holders++
+			}
+		}
+	}
+	return caches, writers, holders
+}
+
+// scan returns the memory held by the filter caches and writers, measuring
+// it at most once per maxAge; callers in between get the totals of the
+// last scan.
+func (l *Logger) scan(m *memoryState, maxAge time.Duration) (caches, writers int64) {
+	now := max(int64(time.Since(m.start)), 1)
+	last := m.scanned.Load()
+	if last == 0 || now-last >= int64(maxAge) {
+		if m.scanned.CompareAndSwap(last, now) {
+			caches, writers, holders := l.memoryUsage()
+			m.caches.Store(caches)
+			m.writers.Store(writers)
+			m.holders.Store(holders)
+			return caches, writers
+		}
+	}
+	return m.caches.Load(), m.writers.Load()
+}
+
+// reserveMemory admits an entry of size bytes under Config.MaxMemoryBytes,
+// applying the MemoryPolicy when it does not fit. FATAL entries bypass it. An admitted entry must
+// be released with releaseMemory once written.
+func (l *Logger) reserveMemory(size int64) bool {
+	m := l.memory
+	if m == nil {
+		return true
+	}
+
+	var waited time.Duration
+	shrunk := false
+	maxAge := memoryScanInterval
+	for {
+		inFlight := m.inFlight.Add(size)
+		caches, writers := l.scan(m, maxAge)
+		used := caches + writers + inFlight
+		if used <= m.limit {
+			for {
+				peak := m.peak.Load()
+				if used <= peak || m.peak.CompareAndSwap(peak, used) {
+					break
+				}
+			}
+			m.over.Store(false)
+			return true
+		}
+		m.inFlight.Add(-size)
+
+		switch {
+		case m.policy == MemoryShrinkCaches && !shrunk:
+			shrunk = true
+			l.eachSensitiveFilter(func(filter *SensitiveDataFilter) {
+				filter.shrinkCache()
+			})
+			m.shrinks.Add(1)
+			maxAge = 0
+			continue
+		case m.policy == MemoryBlock && size <= m.limit && waited < memoryBlockTimeout && !l.closed.Load():
+			if waited == 0 {
+				m.blocked.Add(1)
+			}
+			time.Sleep(memoryBlockPoll)
+			waited += memoryBlockPoll
+			continue
+		}
+
+		m.dropped.Add(1)
+		if m.over.CompareAndSwap(false, true) {
+			l.internalError(ComponentMemory, nil, "memory limit of %d bytes reached (%d in use), dropping entries", m.limit, used)
+		}
+		return false
+	}
+}
+
+// releaseMemory releases an entry admitted by reserveMemory. Until the
+// next scan the entry is assumed to be held by every MemoryUser writer.
+func (l *Logger) releaseMemory(size int64) {
+	if m := l.memory; m != nil {
+		if holders := m.holders.Load(); holders > 0 {
+			m.writers.Add(size * holders)
+		}
+		m.inFlight.Add(-size)
+	}
+}
+
+// MemoryStats returns the memory held by the logger's filter caches,
+// writers and entries being written, and the entries affected by
+// Config.MaxMemoryBytes.
+//
+// Example:
+//
+//	s := logger.MemoryStats()
+//	metrics.Gauge("log.memory_bytes", float64(s.InUse))
+//	metrics.Counter("log.memory_dropped", float64(s.Dropped))
+func (l *Logger) MemoryStats() MemoryStats {
+	if l == nil {
+		return MemoryStats{}
+	}
+	caches, writers, _ := l.memoryUsage()
+	stats := MemoryStats{FilterCache: caches, Writers: writers}
+	if m := l.memory; m != nil {
+		stats.Limit = m.limit
+		stats.InFlight = m.inFlight.Load()
+		stats.Peak = m.peak.Load()
+		stats.Dropped = m.dropped.Load()
+		stats.Blocked = m.blocked.Load()
+		stats.Shrinks = m.shrinks.Load()
+	}
+	stats.InUse = stats.FilterCache + stats.InFlight + stats.Writers
+	return stats
+}
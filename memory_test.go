@@ -0,0 +1,209 @@
+package dd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countMemoryEvents returns an InternalErrorHandler that counts memory events.
+func countMemoryEvents(events *atomic.Int64) func(InternalEvent) {
+	return func(ev InternalEvent) {
+		if ev.Component == ComponentMemory {
+			events.Add(1)
+		}
+	}
+}
+
+func TestMaxMemoryBytesDrop(t *testing.T) {
+	ring := NewRingBufferWriter(1000)
+	var events atomic.Int64
+	logger, _ := newTestLogger(t, func(cfg *Config) {
+		cfg.Output = ring
+		cfg.IncludeTime = false
+		cfg.MaxMemoryBytes = 2048
+		cfg.MemoryPolicy = MemoryDrop
+		cfg.InternalErrorHandler = countMemoryEvents(&events)
+	})
+
+	payload := strings.Repeat("x", 100)
+	for i := 0; i < 50; i++ {
+		logger.Info(payload, Int("i", i))
+	}
+
+	s := logger.MemoryStats()
+	if s.Limit != 2048 || s.Dropped == 0 || ring.Len()+int(s.Dropped) != 50 {
+		t.Fatalf("stats = %+v with %d lines kept", s, ring.Len())
+	}
+	if s.Writers != ring.MemoryUsage() || s.InUse > s.Limit || s.Peak > s.Limit || s.InFlight != 0 {
+		t.Errorf("accounting = %+v, ring holds %d", s, ring.MemoryUsage())
+	}
+	if n := events.Load(); n != 1 {
+		t.Errorf("%d memory events, want 1 per episode", n)
+	}
+
+	ring.Reset()
+	time.Sleep(memoryScanInterval)
+	logger.Info("fits again")
+	if ring.Len() != 1 {
+		t.Error("entry dropped after memory was released")
+	}
+}
+
+func TestMaxMemoryBytesKeepsFatal(t *testing.T) {
+	ring := NewRingBufferWriter(1000)
+	var exits atomic.Int32
+	logger, _ := newTestLogger(t, func(cfg *Config) {
+		cfg.Output = ring
+		cfg.IncludeTime = false
+		cfg.MaxMemoryBytes = 1024
+		cfg.InternalErrorHandler = func(InternalEvent) {}
+		cfg.FatalHandler = func() { exits.Add(1) }
+	})
+
+	for logger.MemoryStats().Dropped == 0 {
+		logger.Info(strings.Repeat("y", 100))
+	}
+	dropped := logger.MemoryStats().Dropped
+	logger.Fatal("fatal at the limit")
+
+	lines := ring.Snapshot()
+	if exits.Load() != 1 || !strings.Contains(lines[len(lines)-1], "fatal at the limit") {
+		t.Errorf("fatal entry not written: %q", lines[len(lines)-1])
+	}
+	if s := logger.MemoryStats(); s.Dropped != dropped {
+		t.Errorf("fatal entry counted as dropped: %+v", s)
+	}
+}
+
+func TestMaxMemoryBytesBlock(t *testing.T) {
+	ring := NewRingBufferWriter(1000)
+	var events atomic.Int64
+	logger, _ := newTestLogger(t, func(cfg *Config) {
+		cfg.Output = ring
+		cfg.IncludeTime = false
+		cfg.MaxMemoryBytes = 1024
+		cfg.MemoryPolicy = MemoryBlock
+		cfg.InternalErrorHandler = countMemoryEvents(&events)
+	})
+
+	for logger.MemoryStats().Writers < 900 {
+		logger.Info(strings.Repeat("y", 100))
+	}
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		ring.Reset()
+	}()
+	logger.Info(strings.Repeat("z", 200))
+
+	s := logger.MemoryStats()
+	if s.Blocked != 1 || s.Dropped != 0 || events.Load() != 0 {
+		t.Errorf("stats = %+v", s)
+	}
+	if lines := ring.Snapshot(); len(lines) != 1 || !strings.Contains(lines[0], "zzz") {
+		t.Errorf("ring = %q", lines)
+	}
+}
+
+func TestMaxMemoryBytesShrinkCaches(t *testing.T) {
+	ring := NewRingBufferWriter(1000)
+	var events atomic.Int64
+	logger, _ := newTestLogger(t, func(cfg *Config) {
+		cfg.Output = ring
+		cfg.IncludeTime = false
+		cfg.MaxMemoryBytes = 4096
+		cfg.MemoryPolicy = MemoryShrinkCaches
+		cfg.InternalErrorHandler = countMemoryEvents(&events)
+	})
+
+	for i := 0; logger.MemoryStats().Dropped == 0 && i < 1000; i++ {
+		logger.Info(fmt.Sprintf("password=%d", i))
+	}
+	s := logger.MemoryStats()
+	if s.Shrinks == 0 || s.Dropped == 0 {
+		t.Fatalf("stats = %+v", s)
+	}
+	if s.FilterCache > s.Limit/memoryCacheShare {
+		t.Errorf("filter cache %d above its share of %d", s.FilterCache, s.Limit)
+	}
+}
+
+func TestFilterCacheLimit(t *testing.T) {
+	filter := NewSensitiveDataFilter()
+	filter.limitCache(1000)
+	for i := 0; i < 100; i++ {
+		filter.Filter(fmt.Sprintf("password=secret%d", i))
+	}
+	size := filter.GetFilterStats().CacheBytes
+	if size == 0 || size > 1000 {
+		t.Fatalf("cache bytes = %d, want (0, 1000]", size)
+	}
+	if freed := filter.shrinkCache(); freed != size {
+		t.Errorf("shrinkCache freed %d, want %d", freed, size)
+	}
+	if filter.GetFilterStats().CacheBytes != 0 || filter.shrinkCache() != 0 {
+		t.Error("cache not empty after shrinkCache")
+	}
+}
+
+func TestMaxMemoryBytesValidation(t *testing.T) {
+	for name, mutate := range map[string]func(*Config){
+		"negative": func(c *Config) { c.MaxMemoryBytes = -1 },
+		"policy":   func(c *Config) { c.MemoryPolicy = MemoryShrinkCaches + 1 },
+	} {
+		cfg := DefaultConfig()
+		mutate(cfg)
+		if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+			t.Errorf("%s: New = %v", name, err)
+		}
+	}
+	if s := Nop().MemoryStats(); s.Limit != 0 || s.Dropped != 0 {
+		t.Errorf("Nop stats = %+v", s)
+	}
+	if MemoryBlock.String() != "Block" || MemoryPolicy(9).String() != "Unknown" {
+		t.Error("unexpected MemoryPolicy names")
+	}
+}
+
+func TestMaxMemoryBytesScan(t *testing.T) {
+	ring := NewRingBufferWriter(1000)
+	var events atomic.Int64
+	logger, _ := newTestLogger(t, func(cfg *Config) {
+		cfg.Output = ring
+		cfg.IncludeTime = false
+		cfg.MaxMemoryBytes = 1 << 20
+		cfg.MemoryPolicy = MemoryDrop
+		cfg.InternalErrorHandler = countMemoryEvents(&events)
+	})
+	m := logger.memory
+
+	logger.Info("first")
+	scanned := m.scanned.Load()
+	for i := 0; i < 10; i++ {
+		logger.Info("between scans")
+	}
+	if m.scanned.Load() == scanned {
+		if got, want := m.writers.Load(), ring.MemoryUsage(); got < want {
+			t.Errorf("cached writer total %d below actual %d", got, want)
+		}
+	}
+
+	time.Sleep(memoryScanInterval)
+	logger.Info("rescan")
+	if m.scanned.Load() == scanned || m.holders.Load() != 1 {
+		t.Errorf("no rescan after %v: holders = %d", memoryScanInterval, m.holders.Load())
+	}
+}
+
+func TestConfigCloneMemoryLimit(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxMemoryBytes = 1 << 20
+	cfg.MemoryPolicy = MemoryShrinkCaches
+	clone := cfg.Clone()
+	if clone.MaxMemoryBytes != cfg.MaxMemoryBytes || clone.MemoryPolicy != cfg.MemoryPolicy {
+		t.Errorf("Clone = %d/%v, want %d/%v", clone.MaxMemoryBytes, clone.MemoryPolicy, cfg.MaxMemoryBytes, cfg.MemoryPolicy)
+	}
+}
@@ -0,0 +1,69 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTextContinuationPrefix(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.IncludeTime = false
+	cfg.DynamicCaller = false
+	cfg.Text = &TextOptions{ContinuationPrefix: "  | "}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.InfoWith("panic: boom\r\ngoroutine 1 [running]:\n\tmain.go:12\rX", String("svc", "api"))
+	want := "[  INFO] panic: boom svc=api\n  | goroutine 1 [running]:\n  | \tmain.go:12\\rX\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q\nwant %q", got, want)
+	}
+
+	buf.Reset()
+	logger.Info("single line")
+	if buf.String() != "[  INFO] single line\n" {
+		t.Errorf("single-line messages are unchanged, got %q", buf.String())
+	}
+}
+
+func TestJSONSingleLine(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := JSONConfig()
+	cfg.Output = &buf
+	cfg.JSON.PrettyPrint = true
+	cfg.JSON.SingleLine = true
+	cfg.Security = &SecurityConfig{KeepNewlines: true}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.ErrorWith("panic: boom\ngoroutine 1", Any("ctx", map[string]any{"a": 1}))
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Fatalf("entry should be one line: %q", buf.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["message"] != "panic: boom\ngoroutine 1" {
+		t.Errorf("message = %q", entry["message"])
+	}
+}
+
+func TestContinuationPrefixValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Text = &TextOptions{ContinuationPrefix: "\n> "}
+	if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("expected ErrConfigValidation, got %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package dd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LoggerNameKey is the field key Named attaches to every entry.
+const LoggerNameKey = "logger"
+
+// Named returns a child logger for the module name. Entries carry a
+// LoggerNameKey field and go through the parent's writers, hooks and
+// security settings. Dots in name form a hierarchy ("http.client" is a
+// child of "http") whose levels are set with SetLevels; until a rule
+// matches, a named logger uses the parent's level.
+//
+// Named is cheap to call repeatedly; level rules live on the parent and
+// apply to every entry of the name, including ones created earlier.
+//
+// Example:
+//
+//	db := logger.Named("db")
+//	client := logger.Named("http").Named("client") // "http.client"
+//	_ = logger.SetLevels("db=debug,http.*=warn")
+//	db.Debug("query") // logged
+//	client.Info("GET /") // dropped
+func (l *Logger) Named(name string) *LoggerEntry {
+	if l == nil {
+		return nilLogger().nopEntry
+	}
+	if l.nopEntry != nil {
+		return l.nopEntry
+	}
+	entry := newLoggerEntry(l, []Field{{Key: LoggerNameKey, Value: name}})
+	entry.name = name
+	entry.tenant = l.namedState(name)
+	return entry
+}
+
+// Named returns a child of the entry's logger named "<name>.<child>", or
+// child if the entry has no name. Entry fields are kept. The level of the
+// new entry follows the SetLevels rules for its name, replacing any Tenant
+// level override of e.
+func (e *LoggerEntry) Named(child string) *LoggerEntry {
+	if e.logger.nopEntry != nil {
+		return e
+	}
+	name := child
+	if e.name != "" {
+		name = e.name + "." + child
+	}
+	entry := newLoggerEntry(e.logger, mergeFieldSlices(e.fields, []Field{{Key: LoggerNameKey, Value: name}}))
+	entry.name = name
+	entry.tenant = e.logger.namedState(name)
+	entry.security = e.security
+	return entry
+}
+
+// Name returns the name given with Named, or "" for unnamed entries.
+func (e *LoggerEntry) Name() string {
+	return e.name
+}
+
+// namedState returns the level state for name, creating it on first use.
+// Named loggers share the tenant override machinery for their level.
+func (l *Logger) namedState(name string) *tenantState {
+	if v, ok := l.names.Load(name); ok {
+		return v.(*tenantState)
+	}
+	l.namesMu.Lock()
+	defer l.namesMu.Unlock()
+	if v, ok := l.names.Load(name); ok {
+		return v.(*tenantState)
+	}
+	state := &tenantState{}
+	state.level.Store(namedLevel(l.nameRules, name))
+	l.names.Store(name, state)
+	return state
+}
+
+// SetLevels sets the levels of named loggers from a comma-separated list
+// of pattern=level rules, replacing the previous rules:
+//
+//	"db"      the logger "db" and its descendants ("db.pool")
+//	"http.*"  the descendants of "http", but not "http" itself
+//	"*"       every named logger
+//
+// The most specific rule wins: the one naming the deepest ancestor, with
+// "x.*" preferred over "x" for the descendants of x. Named loggers no rule
+// matches use the parent's level. An empty spec removes all rules.
+//
+// Example:
+//
+//	_ = logger.SetLevels("*=warn, db=debug, http.client=error")
+func (l *Logger) SetLevels(spec string) error {
+	if l == nil {
+		return ErrNilLogger
+	}
+	rules, err := parseLevelRules(spec)
+	if err != nil {
+		return err
+	}
+
+	l.namesMu.Lock()
+	defer l.namesMu.Unlock()
+	l.nameRules = rules
+	l.names.Range(func(key, value any) bool {
+		value.(*tenantState).level.Store(namedLevel(rules, key.(string)))
+		return true
+	})
+	return nil
+}
+
+// Levels returns the rules set with SetLevels in the same syntax, sorted
+// by pattern.
+func (l *Logger) Levels() string {
+	if l == nil {
+		return ""
+	}
+	l.namesMu.Lock()
+	defer l.namesMu.Unlock()
+
+	patterns := make([]string, 0, len(l.nameRules))
+	for pattern := range l.nameRules {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for i, pattern := range patterns {
+		patterns[i] = pattern + "=" + strings.ToLower(l.nameRules[pattern].String())
+	}
+	return strings.Join(patterns, ",")
+}
+
+// NamedLevel returns the level the SetLevels rules give the logger name,
+// and false if no rule matches.
+func (l *Logger) NamedLevel(name string) (LogLevel, bool) {
+	if l == nil {
+		return 0, false
+	}
+	l.namesMu.Lock()
+	defer l.namesMu.Unlock()
+	level := namedLevel(l.nameRules, name)
+	if level == noTenantLevel {
+		return 0, false
+	}
+	return LogLevel(level), true
+}
+
+// parseLevelRules parses a SetLevels spec.
+func parseLevelRules(spec string) (map[string]LogLevel, error) {
+	rules := make(map[string]LogLevel)
+	for _, rule := range strings.Split(spec, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		pattern, levelName, ok := strings.Cut(rule, "=")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || !validLevelPattern(pattern) {
+			return nil, fmt.Errorf("%w: invalid level rule %q", ErrInvalidLevel, rule)
+		}
+		level, err := ParseLevel(strings.TrimSpace(levelName))
+		if err != nil {
+			return nil, fmt.Errorf("invalid level rule %q: %w", rule, err)
+		}
+		rules[pattern] = level
+	}
+	return rules, nil
+}
+
+// validLevelPattern reports whether pattern is a dot-separated name,
+// optionally ending in ".*", or "*".
+func validLevelPattern(pattern string) bool {
+	if pattern == "*" {
+		return true
+	}
+	pattern = strings.TrimSuffix(pattern, ".*")
+	for _, segment := range strings.Split(pattern, ".") {
+		if segment == "" || strings.Contains(segment, "*") {
+			return false
+		}
+	}
+	return true
+}
+
+// namedLevel returns the level rules give name, or noTenantLevel.
+func namedLevel(rules map[string]LogLevel, name string) int32 {
+	if len(rules) == 0 {
+		return noTenantLevel
+	}
+	if level, ok := rules[name]; ok {
+		return int32(level)
+	}
+	for parent := name; ; {
+		idx := strings.LastIndexByte(parent, '.')
+		if idx < 0 {
+			break
+		}
+		parent = parent[:idx]
+		if level, ok := rules[parent+".*"]; ok {
+			return int32(level)
+		}
+		if level, ok := rules[parent]; ok {
+			return int32(level)
+		}
+	}
+	if level, ok := rules["*"]; ok {
+		return int32(level)
+	}
+	return noTenantLevel
+}
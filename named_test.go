@@ -0,0 +1,125 @@
+package dd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNamedAddsField(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	client := logger.Named("http").WithFields(String("svc", "api")).Named("client")
+	client.Info("GET /")
+
+	if client.Name() != "http.client" {
+		t.Errorf("Name() = %q", client.Name())
+	}
+	if !strings.Contains(buf.String(), "GET / svc=api logger=http.client") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestSetLevels(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+	db := logger.Named("db")
+	pool := db.Named("pool")
+	http := logger.Named("http")
+	client := http.Named("client")
+	other := logger.Named("cache")
+
+	if err := logger.SetLevels("db=debug, http.*=warn"); err != nil {
+		t.Fatal(err)
+	}
+
+	db.Debug("db debug")
+	pool.Debug("pool debug")
+	http.Info("http info")
+	client.Info("client info")
+	client.Warn("client warn")
+	other.Debug("cache debug")
+	other.Info("cache info")
+	logger.Debug("root debug")
+
+	out := buf.String()
+	for _, want := range []string{"db debug", "pool debug", "http info", "client warn", "cache info"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output", want)
+		}
+	}
+	for _, unwanted := range []string{"client info", "cache debug", "root debug"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("did not expect %q in output", unwanted)
+		}
+	}
+}
+
+func TestSetLevelsReplacesRules(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+	db := logger.Named("db")
+
+	_ = logger.SetLevels("db=debug")
+	_ = logger.SetLevels("*=error")
+	db.Warn("dropped")
+	logger.Named("new").Error("kept")
+
+	if strings.Contains(buf.String(), "dropped") || !strings.Contains(buf.String(), "kept") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+	if got := logger.Levels(); got != "*=error" {
+		t.Errorf("Levels() = %q", got)
+	}
+
+	_ = logger.SetLevels("")
+	db.Info("inherits")
+	if !strings.Contains(buf.String(), "inherits") {
+		t.Errorf("empty spec should restore the parent level: %q", buf.String())
+	}
+}
+
+func TestNamedLevelPrecedence(t *testing.T) {
+	logger, _ := newTestLogger(t, nil)
+	_ = logger.SetLevels("*=error,a=warn,a.*=info,a.b=debug")
+
+	tests := []struct {
+		name  string
+		level LogLevel
+	}{
+		{"a", LevelWarn},
+		{"a.x", LevelInfo},
+		{"a.b", LevelDebug},
+		{"a.b.c", LevelDebug},
+		{"z", LevelError},
+	}
+	for _, tt := range tests {
+		if level, ok := logger.NamedLevel(tt.name); !ok || level != tt.level {
+			t.Errorf("NamedLevel(%q) = %v, %v; want %v", tt.name, level, ok, tt.level)
+		}
+	}
+
+	_ = logger.SetLevels("a=warn")
+	if _, ok := logger.NamedLevel("b"); ok {
+		t.Error("no rule should match b")
+	}
+}
+
+func TestSetLevelsInvalid(t *testing.T) {
+	logger, _ := newTestLogger(t, nil)
+	_ = logger.SetLevels("db=debug")
+
+	for _, spec := range []string{"db", "=debug", "db=loud", "a..b=info", "a*=info", "*.a=info"} {
+		if err := logger.SetLevels(spec); !errors.Is(err, ErrInvalidLevel) {
+			t.Errorf("SetLevels(%q) = %v, want ErrInvalidLevel", spec, err)
+		}
+	}
+	if got := logger.Levels(); got != "db=debug" {
+		t.Errorf("invalid specs should keep the rules, got %q", got)
+	}
+}
+
+func TestNamedNop(t *testing.T) {
+	logger := Nop()
+	if entry := logger.Named("db").Named("pool"); entry.Name() != "" {
+		t.Errorf("Nop should return its shared entry, got %q", entry.Name())
+	}
+}
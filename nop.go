@@ -0,0 +1,66 @@
+package dd
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// Nop returns a Logger that discards every entry without formatting it.
+// It implements LogProvider in full, so libraries can use it as a default
+// when the caller does not supply a logger. Logging calls, WithFields and
+// the level checks (which always report false) do no work and do not
+// allocate; the only remaining cost is the []Field the compiler builds at
+// the call site for variadic fields, as with any disabled level.
+//
+// Writers, hooks and other settings can still be configured on the returned
+// logger but have no effect on output. Fatal entries are discarded too and
+// do not exit the process. Each call returns an independent logger.
+//
+// A nil *Logger behaves like Nop for logging, level checks and entry
+// builders, so optional logger fields need no nil checks at call sites.
+// On a nil logger, setters do nothing or return ErrNilLogger, getters
+// return zero values, and Flush, Close and Shutdown return nil.
+//
+// Example:
+//
+//	func NewClient(opts Options) *Client {
+//	    logger := opts.Logger
+//	    if logger == nil {
+//	        logger = dd.Nop()
+//	    }
+//	    return &Client{logger: logger}
+//	}
+func Nop() *Logger {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &Logger{
+		callerDepth: defaultCallerDepth,
+		clock:       systemClock{},
+		formatter: internal.NewMessageFormatter(&internal.FormatterConfig{
+			Format:     internal.LogFormatText,
+			TimeFormat: DefaultTimeFormat,
+		}),
+		events: newEventsState(nil),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	l.nopEntry = &LoggerEntry{logger: l}
+	l.level.Store(int32(LevelFatal))
+	writers := make([]io.Writer, 0)
+	l.writersPtr.Store(&writers)
+	return l
+}
+
+// nilLogger stands in for a nil *Logger in methods that return an entry,
+// writer or progress reporter, so their results are safe to use.
+var nilLogger = sync.OnceValue(Nop)
+
+// IsNop reports whether l was created by Nop or is nil.
+func (l *Logger) IsNop() bool {
+	if l == nil {
+		return true
+	}
+	return l.nopEntry != nil
+}
@@ -0,0 +1,134 @@
+package dd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNopDiscardsEverything(t *testing.T) {
+	var provider LogProvider = Nop()
+	logger := provider.(*Logger)
+	if !logger.IsNop() {
+		t.Fatal("Nop logger should report IsNop")
+	}
+
+	var buf bytes.Buffer
+	if err := logger.AddWriter(&buf); err != nil {
+		t.Fatalf("AddWriter: %v", err)
+	}
+
+	logger.Debug("debug")
+	logger.Infof("info %d", 1)
+	logger.ErrorWith("error", String("k", "v"))
+	logger.InfoCtx(context.Background(), "ctx")
+	logger.WithFields(String("a", "b")).Warn("entry")
+	logger.WithField("a", "b").WithFields(Int("n", 1)).Errorf("entry %d", 2)
+	logger.Fatal("fatal must not exit")
+	logger.Print("print")
+
+	if buf.Len() != 0 {
+		t.Errorf("Nop logger wrote output: %q", buf.String())
+	}
+	for _, level := range []LogLevel{LevelDebug, LevelInfo, LevelWarn, LevelError, LevelFatal} {
+		if logger.IsLevelEnabled(level) {
+			t.Errorf("IsLevelEnabled(%v) should be false", level)
+		}
+	}
+	if err := logger.Flush(); err != nil {
+		t.Errorf("Flush: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+}
+
+func TestNopZeroAllocations(t *testing.T) {
+	logger := Nop()
+	ctx := context.Background()
+	entry := logger.WithFields(String("service", "api"))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		logger.Info("message")
+		logger.InfoCtx(ctx, "message")
+		logger.Debugf("value %d", 42)
+		logger.WithFields(String("service", "api")).Info("message")
+		logger.WithField("k", "v").Warnf("value %d", 7)
+		entry.InfoWith("message")
+		_ = logger.IsDebugEnabled()
+	})
+	if allocs != 0 {
+		t.Errorf("Nop logger allocated %.1f times per run", allocs)
+	}
+}
+
+func TestNopIndependentInstances(t *testing.T) {
+	a, b := Nop(), Nop()
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if b.IsClosed() {
+		t.Error("closing one Nop logger should not close another")
+	}
+}
+
+func TestNilLoggerMethods(t *testing.T) {
+	var logger *Logger
+	v := reflect.ValueOf(logger)
+	for i := 0; i < v.NumMethod(); i++ {
+		method := v.Type().Method(i)
+		fn := v.Method(i)
+		args := make([]reflect.Value, fn.Type().NumIn())
+		for j := range args {
+			args[j] = reflect.Zero(fn.Type().In(j))
+		}
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("%s panicked on a nil logger: %v", method.Name, r)
+				}
+			}()
+			if fn.Type().IsVariadic() {
+				fn.CallSlice(args)
+			} else {
+				fn.Call(args)
+			}
+		}()
+	}
+
+	logger.WithFields(String("k", "v")).Child("c").InfoWith("message")
+	logger.Once("key").Warn("message")
+	_, _ = logger.WriterLevel(LevelInfo).Write([]byte("line\n"))
+	if err := logger.SetLevel(LevelDebug); !errors.Is(err, ErrNilLogger) {
+		t.Errorf("SetLevel: err = %v", err)
+	}
+	if !logger.IsNop() {
+		t.Error("nil logger should report IsNop")
+	}
+}
+
+func TestNilLoggerZeroAllocations(t *testing.T) {
+	var logger *Logger
+	ctx := context.Background()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		logger.Info("message")
+		logger.InfoCtx(ctx, "message")
+		logger.Debugf("value %d", 42)
+		logger.WithFields(String("service", "api")).Info("message")
+		_ = logger.IsDebugEnabled()
+	})
+	if allocs != 0 {
+		t.Errorf("nil logger allocated %.1f times per run", allocs)
+	}
+}
+
+func BenchmarkNop(b *testing.B) {
+	logger := Nop()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.InfoWith("message", String("key", "value"), Int("n", i))
+	}
+}
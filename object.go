@@ -0,0 +1,126 @@
+package dd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// LogObjectMarshaler is implemented by types that control their own
+// structured representation. Use it with Object to log a value without
+// reflection; only the fields added to the encoder are logged, in order.
+//
+// Example:
+//
+//	func (u User) MarshalLogObject(enc dd.FieldEncoder) error {
+//	    enc.AddString("id", u.ID)
+//	    enc.AddInt("age", u.Age)
+//	    return nil
+//	}
+//
+//	logger.InfoWith("login", dd.Object("user", u))
+type LogObjectMarshaler interface {
+	MarshalLogObject(enc FieldEncoder) error
+}
+
+// FieldEncoder receives the fields of a LogObjectMarshaler.
+type FieldEncoder interface {
+	AddString(key, value string)
+	AddInt(key string, value int)
+	AddInt64(key string, value int64)
+	AddUint64(key string, value uint64)
+	AddFloat64(key string, value float64)
+	AddBool(key string, value bool)
+	AddDuration(key string, value time.Duration)
+	AddTime(key string, value time.Time)
+	// AddAny adds an arbitrary value, formatted like an Any field.
+	AddAny(key string, value any)
+	// AddObject adds a nested object.
+	AddObject(key string, value LogObjectMarshaler) error
+}
+
+// ObjectValue is the resolved form of an Object field: its fields in the
+// order they were added. Hooks and RecordWriters see this type.
+type ObjectValue = internal.ObjectValue
+
+// maxObjectDepth bounds nesting through FieldEncoder.AddObject.
+const maxObjectDepth = 32
+
+// Object creates a field whose value is produced by value.MarshalLogObject.
+// Marshaling happens only if the entry is logged, and the result passes
+// through sensitive data filtering like any other field. If marshaling
+// fails or panics, the fields added so far are kept and an "error" field
+// is appended.
+func Object(key string, value LogObjectMarshaler) Field {
+	if value == nil {
+		return Field{Key: key, Value: nil}
+	}
+	return Field{Key: key, Value: objectValue{value}}
+}
+
+// objectValue defers marshaling until the field is resolved by the logger.
+type objectValue struct {
+	marshaler LogObjectMarshaler
+}
+
+// ResolveValue implements internal.LazyValue.
+func (v objectValue) ResolveValue() any {
+	return marshalObject(v.marshaler, 0)
+}
+
+// String renders the object for contexts that bypass resolution.
+func (v objectValue) String() string {
+	data, err := marshalObject(v.marshaler, 0).MarshalJSON()
+	if err != nil {
+		return fmt.Sprintf("<object: %v>", err)
+	}
+	return string(data)
+}
+
+// marshalObject encodes m into an ObjectValue, recovering from panics.
+func marshalObject(m LogObjectMarshaler, depth int) (result ObjectValue) {
+	enc := &objectEncoder{depth: depth}
+	defer func() {
+		if r := recover(); r != nil {
+			result = append(enc.fields, Field{Key: "error", Value: fmt.Sprintf("marshal panic: %v", r)})
+		}
+	}()
+	if err := m.MarshalLogObject(enc); err != nil {
+		enc.fields = append(enc.fields, Field{Key: "error", Value: err.Error()})
+	}
+	return enc.fields
+}
+
+// objectEncoder is the FieldEncoder used by Object.
+type objectEncoder struct {
+	fields ObjectValue
+	depth  int
+}
+
+func (e *objectEncoder) add(key string, value any) {
+	e.fields = append(e.fields, Field{Key: key, Value: value})
+}
+
+func (e *objectEncoder) AddString(key, value string)                 { e.add(key, value) }
+func (e *objectEncoder) AddInt(key string, value int)                { e.add(key, value) }
+func (e *objectEncoder) AddInt64(key string, value int64)            { e.add(key, value) }
+func (e *objectEncoder) AddUint64(key string, value uint64)          { e.add(key, value) }
+func (e *objectEncoder) AddFloat64(key string, value float64)        { e.add(key, value) }
+func (e *objectEncoder) AddBool(key string, value bool)              { e.add(key, value) }
+func (e *objectEncoder) AddDuration(key string, value time.Duration) { e.add(key, value) }
+func (e *objectEncoder) AddTime(key string, value time.Time)         { e.add(key, value) }
+func (e *objectEncoder) AddAny(key string, value any)                { e.add(key, value) }
+
+func (e *objectEncoder) AddObject(key string, value LogObjectMarshaler) error {
+	if value == nil {
+		e.add(key, nil)
+		return nil
+	}
+	if e.depth >= maxObjectDepth {
+		e.add(key, "[MAX_DEPTH_EXCEEDED]")
+		return nil
+	}
+	e.add(key, marshalObject(value, e.depth+1))
+	return nil
+}
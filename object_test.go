@@ -0,0 +1,92 @@
+package dd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testAddress struct {
+	City string
+}
+
+func (a testAddress) MarshalLogObject(enc FieldEncoder) error {
+	enc.AddString("city", a.City)
+	return nil
+}
+
+type testUser struct {
+	ID       string
+	Age      int
+	Password string
+	Address  testAddress
+	internal string
+}
+
+func (u testUser) MarshalLogObject(enc FieldEncoder) error {
+	enc.AddString("id", u.ID)
+	enc.AddInt("age", u.Age)
+	enc.AddString("password", u.Password)
+	enc.AddDuration("session", time.Minute)
+	return enc.AddObject("address", u.Address)
+}
+
+type failingMarshaler struct{ panics bool }
+
+func (f failingMarshaler) MarshalLogObject(enc FieldEncoder) error {
+	enc.AddBool("partial", true)
+	if f.panics {
+		panic("boom")
+	}
+	return errors.New("broken")
+}
+
+func TestObjectField(t *testing.T) {
+	user := testUser{ID: "u1", Age: 30, Password: "hunter2", Address: testAddress{City: "Oslo"}, internal: "x"}
+	want := `{"id":"u1","age":30,"password":"[REDACTED]","session":"1m0s","address":{"city":"Oslo"}}`
+
+	t.Run("json", func(t *testing.T) {
+		logger, buf := newTestLogger(t, func(cfg *Config) { cfg.Format = FormatJSON })
+		logger.InfoWith("login", Object("user", user))
+		if !strings.Contains(buf.String(), `"user":`+want) {
+			t.Errorf("unexpected output: %s", buf.String())
+		}
+	})
+
+	t.Run("text", func(t *testing.T) {
+		logger, buf := newTestLogger(t, nil)
+		logger.InfoWith("login", Object("user", user))
+		if !strings.Contains(buf.String(), "user="+want) {
+			t.Errorf("unexpected output: %s", buf.String())
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		if f := Object("user", nil); f.Value != nil {
+			t.Errorf("Object(nil).Value = %v, want nil", f.Value)
+		}
+	})
+}
+
+func TestObjectFieldErrors(t *testing.T) {
+	for _, panics := range []bool{false, true} {
+		logger, buf := newTestLogger(t, func(cfg *Config) { cfg.Format = FormatJSON })
+		logger.InfoWith("msg", Object("obj", failingMarshaler{panics: panics}))
+		logger.Close()
+
+		out := buf.String()
+		if !strings.Contains(out, `"partial":true`) || !strings.Contains(out, `"error":`) {
+			t.Errorf("panics=%v: expected partial fields and error, got %s", panics, out)
+		}
+	}
+}
+
+func TestObjectFieldLazy(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) { cfg.Format = FormatJSON })
+
+	logger.DebugWith("skipped", Object("obj", failingMarshaler{panics: true}))
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %s", buf.String())
+	}
+}
@@ -0,0 +1,72 @@
+package dd
+
+import "github.com/cybergodev/dd/internal"
+
+// filterScope returns the logger's SecurityConfig.FilterScope.
+func (l *Logger) filterScope() FilterScope {
+	if sc := l.getSecurityConfig(); sc != nil {
+		return sc.FilterScope
+	}
+	return FilterScopeValues
+}
+
+// filterOutput applies the sensitive data filter of override (or the
+// logger) to an encoded entry when the FilterScope includes the output.
+// A redaction can break the encoding, so with JSONOptions.Strict the
+// result is made valid JSON again.
+func (l *Logger) filterOutput(override *entrySecurity, message string) string {
+	if !l.filterScope().filtersOutput() {
+		return message
+	}
+	filter := l.sensitiveFilter(override)
+	if filter == nil || !filter.IsEnabled() {
+		return message
+	}
+	filtered := filter.Filter(message)
+	if filtered != message && l.auditsRedactions() {
+		l.auditRedaction(filter, "", message)
+	}
+	if filtered != message && l.strictJSON {
+		filtered = internal.StrictJSON(filtered)
+	}
+	return filtered
+}
+
+// filterEntryValues returns msg and fields with the sensitive data filter
+// of override (or the logger) applied under FilterScopeOutput, where only
+// the encoded entry is filtered otherwise. Hooks and RecordWriters get the
+// values rather than the encoded entry, so they need this. Under the other
+// scopes the values are already filtered and are returned unchanged.
+func (l *Logger) filterEntryValues(override *entrySecurity, msg string, fields []Field) (string, []Field) {
+	if l.filterScope() != FilterScopeOutput {
+		return msg, fields
+	}
+	filter := l.sensitiveFilter(override)
+	if filter == nil || !filter.IsEnabled() {
+		return msg, fields
+	}
+	// Redactions are audited once, by filterOutput
+	return filter.Filter(msg), l.filterFieldValues(fields, filter, false)
+}
+
+// redactSensitiveKeys redacts the values of fields with sensitive keys,
+// for FilterScopeOutput where values are not filtered before encoding.
+func (l *Logger) redactSensitiveKeys(fields []Field, filter *SensitiveDataFilter) []Field {
+	var result []Field
+	for i, field := range fields {
+		if field.Value == nil || !internal.IsSensitiveKey(field.Key) {
+			continue
+		}
+		if result == nil {
+			result = append([]Field(nil), fields...)
+		}
+		if l.auditsRedactions() {
+			l.auditRedaction(filter, field.Key, field.Value)
+		}
+		result[i].Value = "[REDACTED]"
+	}
+	if result == nil {
+		return fields
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// dsnText is a named string type, which value filtering does not see.
+type dsnText string
+
+func TestFilterScope(t *testing.T) {
+	secret := "api_key=sk_live_abcdefghijklmnop"
+	tests := []struct {
+		scope       FilterScope
+		wantLeakAny bool
+	}{
+		{FilterScopeValues, true},
+		{FilterScopeOutput, false},
+		{FilterScopeAll, false},
+	}
+	for _, tt := range tests {
+		logger, buf := newTestLogger(t, func(cfg *Config) { cfg.Security.FilterScope = tt.scope })
+		logger.InfoWith("connect "+secret,
+			Any("target", dsnText("db "+secret)),
+			String("password", "hunter2"),
+		)
+		out := buf.String()
+		if strings.Contains(out, "hunter2") {
+			t.Errorf("scope %d: password leaked: %q", tt.scope, out)
+		}
+		if strings.Contains(out, "connect "+secret) {
+			t.Errorf("scope %d: message not filtered: %q", tt.scope, out)
+		}
+		if leaked := strings.Contains(out, "db "+secret); leaked != tt.wantLeakAny {
+			t.Errorf("scope %d: named string value leaked = %v, want %v: %q", tt.scope, leaked, tt.wantLeakAny, out)
+		}
+	}
+}
+
+func TestFilterScopeValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Security.FilterScope = FilterScope(9)
+	if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestFilterScopeOutputStrictJSON(t *testing.T) {
+	filter, err := NewCustomSensitiveDataFilter(`token=\S+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	cfg := JSONConfig()
+	cfg.Output = &buf
+	cfg.JSON.Strict = true
+	cfg.Security.SensitiveFilter = filter
+	cfg.Security.FilterScope = FilterScopeOutput
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Info("login token=abc123")
+	out := strings.TrimSuffix(buf.String(), "\n")
+	if !json.Valid([]byte(out)) {
+		t.Fatalf("invalid JSON entry: %q", out)
+	}
+	if strings.Contains(out, "abc123") {
+		t.Errorf("token leaked: %q", out)
+	}
+}
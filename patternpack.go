@@ -0,0 +1,169 @@
+package dd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// PatternPack describes a named, versioned group of built-in sensitive data
+// patterns. Packs marked Default are enabled by NewSensitiveDataFilter and
+// NewBasicSensitiveDataFilter; the others must be enabled with EnablePack.
+type PatternPack = internal.PatternPack
+
+// Built-in pattern pack names.
+const (
+	PackCredentials = internal.PackCredentials // passwords, API keys, tokens, private keys
+	PackPCI         = internal.PackPCI         // payment cards, CVV, SWIFT/BIC, IBAN
+	PackPHI         = internal.PackPHI         // health identifiers
+	PackNetwork     = internal.PackNetwork     // IP addresses, hosts, endpoints
+	PackIdentifiers = internal.PackIdentifiers // phone, email, national IDs
+	PackInjection   = internal.PackInjection   // JNDI / Log4Shell payloads
+	PackUUID        = internal.PackUUID        // UUIDs (off by default)
+)
+
+// PatternPacks returns the built-in pattern packs with their descriptions
+// and versions.
+func PatternPacks() []PatternPack {
+	packs := make([]PatternPack, len(internal.PatternPacks))
+	copy(packs, internal.PatternPacks)
+	return packs
+}
+
+// packPattern is a filter pattern with the pack it belongs to ("" for
+// patterns added with AddPattern) and the literals one of which every match
+// contains (nil if unknown).
+type packPattern struct {
+	re       *regexp.Regexp
+	pack     string
+	literals []string
+}
+
+// builtinPatterns returns the built-in patterns of the default packs,
+// limited to the basic subset when basic is true.
+func builtinPatterns(basic bool) []packPattern {
+	internal.InitPatterns()
+	patterns := make([]packPattern, 0, len(internal.CompiledPatterns))
+	for _, cp := range internal.CompiledPatterns {
+		if basic && !cp.Basic {
+			continue
+		}
+		if pack, _ := internal.LookupPatternPack(cp.Pack); !pack.Default {
+			continue
+		}
+		patterns = append(patterns, packPattern{re: cp.Regexp, pack: cp.Pack, literals: cp.Literals})
+	}
+	return patterns
+}
+
+// EnablePack enables the built-in pattern pack with the given name, adding
+// any of its patterns the filter does not have yet. Returns an error if the
+// pack does not exist.
+func (f *SensitiveDataFilter) EnablePack(name string) error {
+	if f == nil {
+		return ErrNilFilter
+	}
+	if _, ok := internal.LookupPatternPack(name); !ok {
+		return fmt.Errorf("%w: unknown pattern pack %q", ErrConfigValidation, name)
+	}
+	internal.InitPatterns()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.disabledPacks, name)
+
+	have := make(map[*regexp.Regexp]bool, len(f.sources))
+	for _, p := range f.sources {
+		have[p.re] = true
+	}
+	sources := f.sources[:len(f.sources):len(f.sources)]
+	for _, cp := range internal.CompiledPatterns {
+		if cp.Pack == name && !have[cp.Regexp] {
+			sources = append(sources, packPattern{re: cp.Regexp, pack: name, literals: cp.Literals})
+		}
+	}
+	f.sources = sources
+	f.publishPatterns()
+	return nil
+}
+
+// DisablePack stops the filter from applying the patterns of the given
+// pack, including patterns added to the pack by security presets. Custom
+// patterns added with AddPattern are not affected. Returns an error if the
+// pack does not exist.
+func (f *SensitiveDataFilter) DisablePack(name string) error {
+	if f == nil {
+		return ErrNilFilter
+	}
+	if _, ok := internal.LookupPatternPack(name); !ok {
+		return fmt.Errorf("%w: unknown pattern pack %q", ErrConfigValidation, name)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.disabledPacks == nil {
+		f.disabledPacks = make(map[string]bool)
+	}
+	f.disabledPacks[name] = true
+	f.publishPatterns()
+	return nil
+}
+
+// EnabledPacks returns the sorted names of the packs with at least one
+// active pattern in the filter.
+func (f *SensitiveDataFilter) EnabledPacks() []string {
+	if f == nil {
+		return nil
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	packs := make([]string, 0, len(internal.PatternPacks))
+	for _, p := range f.sources {
+		if p.pack == "" || seen[p.pack] || f.disabledPacks[p.pack] {
+			continue
+		}
+		seen[p.pack] = true
+		packs = append(packs, p.pack)
+	}
+	sort.Strings(packs)
+	return packs
+}
+
+// addPackPatterns compiles and adds patterns as members of the given pack.
+func (f *SensitiveDataFilter) addPackPatterns(pack string, patterns ...string) {
+	for _, pattern := range patterns {
+		_ = f.addPattern(pattern, pack)
+	}
+}
+
+// publishPatterns stores the active pattern set, which is every source not
+// in a disabled pack, and drops cached results computed with the old set.
+// The caller must hold f.mu (or own f exclusively).
+func (f *SensitiveDataFilter) publishPatterns() {
+	active := make([]*regexp.Regexp, 0, len(f.sources))
+	literals := make([][]string, 0, len(f.sources))
+	for _, p := range f.sources {
+		if p.pack != "" && f.disabledPacks[p.pack] {
+			continue
+		}
+		active = append(active, p.re)
+		literals = append(literals, p.literals)
+	}
+	f.literalsPtr.Store(&patternLiterals{patterns: &active, literals: literals})
+	f.patternsPtr.Store(&active)
+	f.patternCount.Store(int32(len(active)))
+
+	f.cacheMu.Lock()
+	if f.cache != nil {
+		f.cache = make(map[uint64]filterCacheEntry)
+		f.cacheSize = 0
+	}
+	f.cacheMu.Unlock()
+}
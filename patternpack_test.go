@@ -0,0 +1,145 @@
+package dd
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPatternPacksListing(t *testing.T) {
+	packs := PatternPacks()
+	if len(packs) == 0 {
+		t.Fatal("expected built-in packs")
+	}
+	for _, p := range packs {
+		if p.Name == "" || p.Description == "" || p.Version < 1 {
+			t.Errorf("incomplete pack %+v", p)
+		}
+		if p.Name == PackUUID && p.Default {
+			t.Error("uuid pack should be off by default")
+		}
+	}
+
+	packs[0].Name = "changed"
+	if PatternPacks()[0].Name == "changed" {
+		t.Error("PatternPacks should return a copy")
+	}
+}
+
+func TestDisableAndEnablePack(t *testing.T) {
+	filter := NewSensitiveDataFilter()
+	input := "connect from 192.168.1.10"
+
+	if got := filter.Filter(input); strings.Contains(got, "192.168.1.10") {
+		t.Fatalf("network pack should redact IPs by default: %q", got)
+	}
+	if !slices.Contains(filter.EnabledPacks(), PackNetwork) {
+		t.Errorf("EnabledPacks = %v", filter.EnabledPacks())
+	}
+
+	if err := filter.DisablePack(PackNetwork); err != nil {
+		t.Fatal(err)
+	}
+	if got := filter.Filter(input); got != input {
+		t.Errorf("disabled pack should not redact: %q", got)
+	}
+	if slices.Contains(filter.EnabledPacks(), PackNetwork) {
+		t.Errorf("EnabledPacks = %v", filter.EnabledPacks())
+	}
+	if got := filter.Filter("password=hunter22"); strings.Contains(got, "hunter22") {
+		t.Errorf("other packs should stay enabled: %q", got)
+	}
+
+	if err := filter.EnablePack(PackNetwork); err != nil {
+		t.Fatal(err)
+	}
+	if got := filter.Filter(input); strings.Contains(got, "192.168.1.10") {
+		t.Errorf("re-enabled pack should redact again: %q", got)
+	}
+}
+
+func TestEnablePackAddsPatterns(t *testing.T) {
+	filter := NewBasicSensitiveDataFilter()
+	input := "request 123e4567-e89b-12d3-a456-426614174000"
+
+	if got := filter.Filter(input); got != input {
+		t.Fatalf("UUIDs should be kept by default: %q", got)
+	}
+	before := filter.PatternCount()
+	if err := filter.EnablePack(PackUUID); err != nil {
+		t.Fatal(err)
+	}
+	if err := filter.EnablePack(PackUUID); err != nil {
+		t.Fatal(err)
+	}
+	if filter.PatternCount() != before+1 {
+		t.Errorf("enabling a pack twice should add its patterns once: %d -> %d", before, filter.PatternCount())
+	}
+	if got := filter.Filter(input); strings.Contains(got, "426614174000") {
+		t.Errorf("uuid pack should redact UUIDs: %q", got)
+	}
+}
+
+func TestUnknownPack(t *testing.T) {
+	filter := NewSensitiveDataFilter()
+	if err := filter.EnablePack("nope"); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("expected ErrConfigValidation, got %v", err)
+	}
+	if err := filter.DisablePack("nope"); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("expected ErrConfigValidation, got %v", err)
+	}
+
+	var nilFilter *SensitiveDataFilter
+	if err := nilFilter.EnablePack(PackPCI); !errors.Is(err, ErrNilFilter) {
+		t.Errorf("expected ErrNilFilter, got %v", err)
+	}
+}
+
+func TestParanoidWithoutUUIDPack(t *testing.T) {
+	filter := SecurityConfigForLevel(SecurityLevelParanoid).SensitiveFilter
+	input := "request 123e4567-e89b-12d3-a456-426614174000"
+
+	if got := filter.Filter(input); strings.Contains(got, "426614174000") {
+		t.Fatalf("paranoid level should redact UUIDs: %q", got)
+	}
+	if err := filter.DisablePack(PackUUID); err != nil {
+		t.Fatal(err)
+	}
+	if got := filter.Filter(input); got != input {
+		t.Errorf("request IDs should survive once the uuid pack is disabled: %q", got)
+	}
+}
+
+func TestDisablePackKeepsCustomPatterns(t *testing.T) {
+	filter := NewSensitiveDataFilter()
+	if err := filter.AddPattern(`\bcustom-[0-9]+\b`); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range PatternPacks() {
+		if err := filter.DisablePack(p.Name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if filter.PatternCount() != 1 {
+		t.Errorf("only the custom pattern should remain, got %d", filter.PatternCount())
+	}
+	if got := filter.Filter("id custom-42"); strings.Contains(got, "custom-42") {
+		t.Errorf("custom pattern should still apply: %q", got)
+	}
+}
+
+func TestPackStateCloned(t *testing.T) {
+	filter := NewSensitiveDataFilter()
+	clone := filter.Clone()
+	if err := clone.DisablePack(PackNetwork); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Contains(filter.EnabledPacks(), PackNetwork) {
+		t.Error("disabling a pack on a clone should not affect the original")
+	}
+	if slices.Contains(clone.EnabledPacks(), PackNetwork) {
+		t.Error("clone should have the pack disabled")
+	}
+}
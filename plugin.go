@@ -0,0 +1,223 @@
+package dd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// WriterFactory creates a writer from the parameters of a SinkConfig.
+// params is nil when the sink has none. The logger closes the writer on
+// Close if it implements io.Closer.
+type WriterFactory func(params map[string]any) (io.Writer, error)
+
+// FormatEncoder renders entries for a format registered with RegisterFormat.
+// EncodeRecord returns the entry without a trailing newline; the logger adds
+// one. Record.Caller is empty. rec and its Fields must not be retained.
+type FormatEncoder interface {
+	EncodeRecord(rec *Record) ([]byte, error)
+}
+
+// FormatEncoderFunc adapts a function to FormatEncoder.
+type FormatEncoderFunc func(rec *Record) ([]byte, error)
+
+// EncodeRecord implements FormatEncoder.
+func (f FormatEncoderFunc) EncodeRecord(rec *Record) ([]byte, error) {
+	return f(rec)
+}
+
+// FormatErrorKey holds the error of a FormatEncoder. Entries it fails to
+// encode are written in FormatText with this field added.
+const FormatErrorKey = "format_error"
+
+// firstCustomFormat is the LogFormat of the first registered format.
+const firstCustomFormat LogFormat = 16
+
+// registeredFormat is a format added with RegisterFormat.
+type registeredFormat struct {
+	name    string
+	format  LogFormat
+	encoder FormatEncoder
+}
+
+var (
+	pluginsMu       sync.RWMutex
+	writerFactories = map[string]WriterFactory{}
+	formatsByName   = map[string]*registeredFormat{}
+	formatsByValue  = map[LogFormat]*registeredFormat{}
+)
+
+// RegisterWriterFactory registers a writer type so configuration loaded at
+// runtime can create it by name through Config.Sinks or NewSinkWriter.
+// Names are unique: registering a name twice is an error.
+//
+// Example:
+//
+//	func init() {
+//	    _ = dd.RegisterWriterFactory("kafka", func(params map[string]any) (io.Writer, error) {
+//	        topic, _ := params["topic"].(string)
+//	        return kafkasink.New(topic)
+//	    })
+//	}
+func RegisterWriterFactory(name string, factory WriterFactory) error {
+	if name == "" {
+		return fmt.Errorf("%w: writer factory name cannot be empty", ErrConfigValidation)
+	}
+	if factory == nil {
+		return fmt.Errorf("%w: writer factory %q is nil", ErrConfigValidation, name)
+	}
+
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	if _, ok := writerFactories[name]; ok {
+		return fmt.Errorf("%w: writer factory %q already registered", ErrConfigValidation, name)
+	}
+	writerFactories[name] = factory
+	return nil
+}
+
+// WriterFactories returns the names of the registered writer factories,
+// sorted.
+func WriterFactories() []string {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	names := make([]string, 0, len(writerFactories))
+	for name := range writerFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Formats returns the names of the built-in formats ("text", "json",
+// "console") followed by the registered formats, sorted. Use it to list
+// the values ParseFormat accepts.
+func Formats() []string {
+	return internal.FormatNames()
+}
+
+// NewSinkWriter creates a writer with the factory registered as name.
+func NewSinkWriter(name string, params map[string]any) (io.Writer, error) {
+	pluginsMu.RLock()
+	factory, ok := writerFactories[name]
+	pluginsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrWriterFactoryNotFound, name)
+	}
+
+	w, err := factory(params)
+	if err != nil {
+		return nil, fmt.Errorf("sink %q: %w", name, err)
+	}
+	if w == nil {
+		return nil, fmt.Errorf("sink %q: %w", name, ErrNilWriter)
+	}
+	return w, nil
+}
+
+// RegisterFormat registers an output format and returns its LogFormat for
+// Config.Format. The format can also be selected by name with the
+// DD_FORMAT environment variable. Names are unique and cannot be those of
+// the built-in formats ("text", "json", "console").
+//
+// Example:
+//
+//	var FormatLogfmt, _ = dd.RegisterFormat("logfmt", dd.FormatEncoderFunc(encodeLogfmt))
+//
+//	cfg := dd.DefaultConfig()
+//	cfg.Format = FormatLogfmt
+func RegisterFormat(name string, encoder FormatEncoder) (LogFormat, error) {
+	if name == "" {
+		return 0, fmt.Errorf("%w: format name cannot be empty", ErrConfigValidation)
+	}
+	if encoder == nil {
+		return 0, fmt.Errorf("%w: format %q has no encoder", ErrConfigValidation, name)
+	}
+	if _, ok := builtinFormat(name); ok {
+		return 0, fmt.Errorf("%w: format %q already registered", ErrConfigValidation, name)
+	}
+
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	if _, ok := formatsByName[name]; ok {
+		return 0, fmt.Errorf("%w: format %q already registered", ErrConfigValidation, name)
+	}
+	next := int(firstCustomFormat) + len(formatsByName)
+	if next > 127 {
+		return 0, fmt.Errorf("%w: too many registered formats", ErrConfigValidation)
+	}
+	rf := &registeredFormat{name: name, format: LogFormat(next), encoder: encoder}
+	formatsByName[name] = rf
+	formatsByValue[rf.format] = rf
+	internal.SetFormatName(rf.format, name)
+	return rf.format, nil
+}
+
+// builtinFormat returns the built-in format called name.
+func builtinFormat(name string) (LogFormat, bool) {
+	switch name {
+	case "text":
+		return FormatText, true
+	case "json":
+		return FormatJSON, true
+	case "console":
+		return FormatConsole, true
+	}
+	return 0, false
+}
+
+// formatEncoder returns the encoder of a registered format, or nil.
+func formatEncoder(format LogFormat) FormatEncoder {
+	if format < firstCustomFormat {
+		return nil
+	}
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	if rf, ok := formatsByValue[format]; ok {
+		return rf.encoder
+	}
+	return nil
+}
+
+// formatEntry formats an entry with the registered encoder of the logger's
+// format, or the built-in formatter. A zero at stamps the entry with the
+// current time. Events (entries written by Emit) have no message.
+func (l *Logger) formatEntry(at time.Time, level LogLevel, event bool, callerDepth int, msg string, fields []Field) string {
+	// One extra frame for this function.
+	callerDepth++
+	if l.encoder == nil {
+		if event {
+			return l.formatter.FormatEventAt(at, level, callerDepth, fields)
+		}
+		return l.formatter.FormatWithMessageAt(at, level, callerDepth, msg, fields)
+	}
+
+	if at.IsZero() {
+		at = l.clock.Now()
+	}
+	rec := &Record{
+		Time:    at,
+		Level:   level,
+		Message: msg,
+		Fields:  mergeFieldSlices(l.globalFields, fields),
+	}
+	out, err := l.encoder.EncodeRecord(rec)
+	if err != nil {
+		fields = append(fields[:len(fields):len(fields)], Field{Key: FormatErrorKey, Value: err.Error()})
+		return l.formatter.FormatWithMessageAt(at, level, callerDepth, msg, fields)
+	}
+	return string(bytes.TrimSuffix(out, []byte{'\n'}))
+}
+
+// SinkConfig selects a writer registered with RegisterWriterFactory.
+type SinkConfig struct {
+	// Type is the name the writer factory was registered with.
+	Type string
+	// Params are passed to the factory.
+	Params map[string]any
+}
@@ -0,0 +1,153 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRegisterWriterFactory(t *testing.T) {
+	var buf bytes.Buffer
+	var got map[string]any
+	err := RegisterWriterFactory("test-buffer", func(params map[string]any) (io.Writer, error) {
+		got = params
+		return &buf, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterWriterFactory("test-buffer", func(map[string]any) (io.Writer, error) { return io.Discard, nil }); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("duplicate: err = %v", err)
+	}
+	if !slices.Contains(WriterFactories(), "test-buffer") {
+		t.Errorf("WriterFactories = %v", WriterFactories())
+	}
+
+	cfg := DefaultConfig()
+	cfg.Output = io.Discard
+	cfg.Sinks = []SinkConfig{{Type: "test-buffer", Params: map[string]any{"topic": "logs"}}}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("to sink")
+	logger.Close()
+
+	if got["topic"] != "logs" {
+		t.Errorf("params = %v", got)
+	}
+	if !strings.Contains(buf.String(), "to sink") {
+		t.Errorf("sink = %q", buf.String())
+	}
+
+	cfg.Sinks = []SinkConfig{{Type: "test-missing"}}
+	if _, err := New(cfg); !errors.Is(err, ErrWriterFactoryNotFound) {
+		t.Errorf("unknown sink: err = %v", err)
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	format, err := RegisterFormat("test-kv", FormatEncoderFunc(func(rec *Record) ([]byte, error) {
+		if rec.Message == "fail" {
+			return nil, errors.New("boom")
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "level=%s msg=%q", rec.Level, rec.Message)
+		for _, f := range rec.Fields {
+			fmt.Fprintf(&b, " %s=%v", f.Key, f.Value)
+		}
+		return []byte(b.String()), nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format.String() != "test-kv" {
+		t.Errorf("String = %q", format.String())
+	}
+	if _, err := RegisterFormat("json", FormatEncoderFunc(func(*Record) ([]byte, error) { return nil, nil })); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("built-in name: err = %v", err)
+	}
+
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Format = format
+	cfg.GlobalFields = []Field{String("service", "api")}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.InfoWith("hello", Int("n", 1))
+	logger.Info("fail")
+	logger.Close()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines: %q", len(lines), buf.String())
+	}
+	if lines[0] != `level=INFO msg="hello" service=api n=1` {
+		t.Errorf("encoded = %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "fail") || !strings.Contains(lines[1], FormatErrorKey+"=boom") {
+		t.Errorf("fallback = %q", lines[1])
+	}
+
+	t.Setenv(EnvFormat, "test-kv")
+	envCfg, err := configFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if envCfg.Format != format {
+		t.Errorf("DD_FORMAT: Format = %v", envCfg.Format)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := RegisterFormat("test-parse", FormatEncoderFunc(func(*Record) ([]byte, error) { return nil, nil }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in   string
+		want LogFormat
+	}{
+		{"json", FormatJSON},
+		{" JSON ", FormatJSON},
+		{"Console", FormatConsole},
+		{"text", FormatText},
+		{"test-parse", format},
+	}
+	for _, tt := range tests {
+		if got, err := ParseFormat(tt.in); err != nil || got != tt.want {
+			t.Errorf("ParseFormat(%q) = %v, %v", tt.in, got, err)
+		}
+	}
+	_, err = ParseFormat("jsno")
+	if !errors.Is(err, ErrInvalidFormat) || !strings.Contains(err.Error(), "test-parse") {
+		t.Errorf("ParseFormat(typo) = %v", err)
+	}
+
+	names := Formats()
+	if !slices.Equal(names[:3], []string{"text", "json", "console"}) || !slices.Contains(names, "test-parse") {
+		t.Errorf("Formats = %v", names)
+	}
+
+	var decoded struct{ Format LogFormat }
+	if err := json.Unmarshal([]byte(`{"Format":"test-parse"}`), &decoded); err != nil || decoded.Format != format {
+		t.Errorf("UnmarshalText = %v, %v", decoded.Format, err)
+	}
+	if data, err := json.Marshal(struct{ Format LogFormat }{FormatConsole}); err != nil || string(data) != `{"Format":"console"}` {
+		t.Errorf("MarshalText = %s, %v", data, err)
+	}
+	if _, err := LogFormat(99).MarshalText(); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("MarshalText(99) = %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"Format":"xml"}`), &decoded); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("UnmarshalText(xml) = %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package dd
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Names of the built-in presets.
+const (
+	PresetDefault     = "default"     // DefaultConfig
+	PresetDevelopment = "development" // DevelopmentConfig
+	PresetJSON        = "json"        // JSONConfig
+	PresetECS         = "ecs"         // ECSConfig
+	PresetGCP         = "gcp"         // GCPConfig
+)
+
+var (
+	presetsMu sync.RWMutex
+	presets   = map[string]func() *Config{
+		PresetDefault:     DefaultConfig,
+		PresetDevelopment: DevelopmentConfig,
+		PresetJSON:        JSONConfig,
+		PresetECS:         ECSConfig,
+		PresetGCP:         GCPConfig,
+	}
+)
+
+// RegisterPreset registers a named configuration so it can be used with
+// NewFromPreset and PresetConfig. fn is called for every use and must
+// return a new Config each time. Names are unique: registering a name
+// twice, including a built-in one, is an error.
+//
+// Platform teams typically register their presets from an init function
+// in a shared package:
+//
+//	func init() {
+//	    _ = dd.RegisterPreset("acme-prod", func() *dd.Config {
+//	        cfg := dd.ECSConfig()
+//	        cfg.Level = dd.LevelWarn
+//	        return cfg
+//	    })
+//	}
+func RegisterPreset(name string, fn func() *Config) error {
+	if name == "" {
+		return fmt.Errorf("%w: preset name cannot be empty", ErrConfigValidation)
+	}
+	if fn == nil {
+		return fmt.Errorf("%w: preset %q has no config function", ErrConfigValidation, name)
+	}
+
+	presetsMu.Lock()
+	defer presetsMu.Unlock()
+	if _, ok := presets[name]; ok {
+		return fmt.Errorf("%w: preset %q already registered", ErrConfigValidation, name)
+	}
+	presets[name] = fn
+	return nil
+}
+
+// PresetConfig returns a new Config from the named preset, for callers
+// that adjust it before calling New.
+func PresetConfig(name string) (*Config, error) {
+	presetsMu.RLock()
+	fn, ok := presets[name]
+	presetsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrPresetNotFound, name)
+	}
+
+	cfg := fn()
+	if cfg == nil {
+		return nil, fmt.Errorf("preset %q: %w", name, ErrNilConfig)
+	}
+	return cfg, nil
+}
+
+// NewFromPreset creates a Logger from the named preset.
+//
+// Example:
+//
+//	logger, err := dd.NewFromPreset("acme-prod")
+func NewFromPreset(name string) (*Logger, error) {
+	cfg, err := PresetConfig(name)
+	if err != nil {
+		return nil, err
+	}
+	return New(cfg)
+}
+
+// Presets returns the names of all registered presets, sorted.
+func Presets() []string {
+	presetsMu.RLock()
+	defer presetsMu.RUnlock()
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,88 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinPresets(t *testing.T) {
+	for _, name := range []string{PresetDefault, PresetDevelopment, PresetJSON, PresetECS, PresetGCP} {
+		if !slices.Contains(Presets(), name) {
+			t.Errorf("built-in preset %q not registered", name)
+		}
+		logger, err := NewFromPreset(name)
+		if err != nil {
+			t.Errorf("NewFromPreset(%q) error = %v", name, err)
+			continue
+		}
+		logger.Close()
+	}
+
+	cfg, err := PresetConfig(PresetECS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.JSON == nil || cfg.JSON.FieldNames.Timestamp != "@timestamp" {
+		t.Error("ecs preset should return ECSConfig")
+	}
+}
+
+func TestRegisterPreset(t *testing.T) {
+	var buf bytes.Buffer
+	err := RegisterPreset("test-company-prod", func() *Config {
+		cfg := JSONConfig()
+		cfg.Level = LevelWarn
+		cfg.Output = &buf
+		return cfg
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger, err := NewFromPreset("test-company-prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("dropped")
+	logger.Warn("kept")
+	logger.Close()
+
+	if strings.Contains(buf.String(), "dropped") || !strings.Contains(buf.String(), `"message":"kept"`) {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+
+	// Each use gets its own Config
+	a, _ := PresetConfig("test-company-prod")
+	b, _ := PresetConfig("test-company-prod")
+	if a == b {
+		t.Error("PresetConfig should return a new Config each time")
+	}
+}
+
+func TestRegisterPresetErrors(t *testing.T) {
+	for name, fn := range map[string]func() *Config{
+		"":           DefaultConfig,
+		"test-nofn":  nil,
+		PresetJSON:   DefaultConfig,
+		"test-again": DefaultConfig,
+	} {
+		if name == "test-again" {
+			_ = RegisterPreset(name, fn)
+		}
+		if err := RegisterPreset(name, fn); !errors.Is(err, ErrConfigValidation) {
+			t.Errorf("RegisterPreset(%q) = %v, want ErrConfigValidation", name, err)
+		}
+	}
+
+	if _, err := NewFromPreset("test-missing"); !errors.Is(err, ErrPresetNotFound) {
+		t.Errorf("unknown preset: %v", err)
+	}
+
+	_ = RegisterPreset("test-nil", func() *Config { return nil })
+	if _, err := NewFromPreset("test-nil"); !errors.Is(err, ErrNilConfig) {
+		t.Errorf("nil config: %v", err)
+	}
+}
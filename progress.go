@@ -0,0 +1,183 @@
+package dd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// ProgressConfig configures a Progress.
+type ProgressConfig struct {
+	// Interval is the minimum time between progress entries
+	// (default 10s).
+	Interval time.Duration
+	// Fields are added to every progress entry.
+	Fields []Field
+}
+
+// Progress reports the progress of a long-running job at most once per
+// interval, so a batch job can count millions of items without logging
+// millions of lines. Its methods are safe for concurrent use.
+type Progress struct {
+	logger   *Logger
+	name     string
+	total    int64
+	interval time.Duration
+	fields   []Field
+	start    time.Time
+
+	done       atomic.Int64
+	lastReport atomic.Int64 // UnixNano of the last progress entry
+	finished   atomic.Bool
+}
+
+// Progress returns a Progress for a job of total items (zero or negative
+// if unknown). Increment counts items and logs an INFO entry such as
+//
+//	import 42% (4.2M/10M) done=4200000 total=10000000 rate=12000 eta=8m3s
+//
+// when the interval has passed since the last one; Done logs the final
+// count. The rate is in items per second.
+//
+// Example:
+//
+//	p := logger.Progress("import", int64(len(rows)), dd.ProgressConfig{Interval: 30 * time.Second})
+//	defer p.Done()
+//	for _, row := range rows {
+//	    insert(row)
+//	    p.Increment(1)
+//	}
+func (l *Logger) Progress(name string, total int64, opts ...ProgressConfig) *Progress {
+	if l == nil {
+		l = nilLogger()
+	}
+	var cfg ProgressConfig
+	if len(opts) > 0 {
+		cfg = opts[0]
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultProgressInterval
+	}
+
+	p := &Progress{
+		logger:   l,
+		name:     name,
+		total:    total,
+		interval: cfg.Interval,
+		fields:   append([]Field(nil), cfg.Fields...),
+		start:    l.clock.Now(),
+	}
+	p.lastReport.Store(p.start.UnixNano())
+	return p
+}
+
+// Increment adds n items and logs the progress if the interval has passed.
+func (p *Progress) Increment(n int64) {
+	done := p.done.Add(n)
+	if p.finished.Load() {
+		return
+	}
+
+	now := p.logger.clock.Now()
+	last := p.lastReport.Load()
+	if now.UnixNano()-last < int64(p.interval) {
+		return
+	}
+	// Only the goroutine that moves lastReport logs
+	if !p.lastReport.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	p.report(done, now)
+}
+
+// Count returns the number of items counted so far.
+func (p *Progress) Count() int64 {
+	return p.done.Load()
+}
+
+// Done logs the final count, rate and elapsed time. Calls after the first
+// do nothing.
+func (p *Progress) Done() {
+	if !p.finished.CompareAndSwap(false, true) {
+		return
+	}
+
+	done := p.done.Load()
+	elapsed := p.logger.clock.Now().Sub(p.start)
+	msg := p.name + " done (" + shortCount(done)
+	if p.total > 0 && done != p.total {
+		msg += "/" + shortCount(p.total)
+	}
+	msg += " in " + elapsed.Round(time.Millisecond).String() + ")"
+
+	fields := make([]Field, 0, len(p.fields)+3)
+	fields = append(fields, p.fields...)
+	fields = append(fields,
+		Int64("done", done),
+		Float64("rate", progressRate(done, elapsed)),
+		Duration("elapsed", elapsed),
+	)
+	p.logger.InfoWith(msg, fields...)
+}
+
+// report logs an intermediate progress entry.
+func (p *Progress) report(done int64, now time.Time) {
+	if !p.logger.IsLevelEnabled(LevelInfo) {
+		return
+	}
+
+	elapsed := now.Sub(p.start)
+	rate := progressRate(done, elapsed)
+
+	fields := make([]Field, 0, len(p.fields)+4)
+	fields = append(fields, p.fields...)
+	fields = append(fields, Int64("done", done))
+
+	var msg string
+	if p.total > 0 {
+		percent := min(done*100/p.total, 100)
+		msg = fmt.Sprintf("%s %d%% (%s/%s)", p.name, percent, shortCount(done), shortCount(p.total))
+		fields = append(fields, Int64("total", p.total), Float64("rate", rate))
+		if rate > 0 && done < p.total {
+			eta := time.Duration(float64(p.total-done) / rate * float64(time.Second))
+			fields = append(fields, Duration("eta", eta.Round(time.Second)))
+		}
+	} else {
+		msg = p.name + " " + shortCount(done)
+		fields = append(fields, Float64("rate", rate))
+	}
+	p.logger.InfoWith(msg, fields...)
+}
+
+// progressRate returns items per second, rounded to one decimal.
+func progressRate(done int64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return math.Round(float64(done)/elapsed.Seconds()*10) / 10
+}
+
+// shortCount abbreviates n with a k, M, G or T suffix: 950, 12k, 4.2M.
+func shortCount(n int64) string {
+	const units = "kMGT"
+	if n < 1000 && n > -1000 {
+		return strconv.FormatInt(n, 10)
+	}
+	v := float64(n)
+	unit := -1
+	for math.Abs(v) >= 999.5 && unit < len(units)-1 {
+		v /= 1000
+		unit++
+	}
+	prec := 0
+	if math.Abs(v) < 10 {
+		prec = 1
+	}
+	s := strconv.FormatFloat(v, 'f', prec, 64)
+	if prec == 1 && s[len(s)-2:] == ".0" {
+		s = s[:len(s)-2]
+	}
+	return s + units[unit:unit+1]
+}
@@ -0,0 +1,94 @@
+package dd
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestProgressThrottles(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	logger, buf := newTestLogger(t, func(cfg *Config) { cfg.Clock = clock })
+
+	p := logger.Progress("import", 10_000_000, ProgressConfig{Interval: time.Minute, Fields: []Field{String("job", "rows")}})
+	for i := 0; i < 1000; i++ {
+		p.Increment(1000)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("nothing should be logged before the interval: %q", buf.String())
+	}
+
+	clock.Advance(time.Minute)
+	p.Increment(3_200_000)
+	p.Increment(1)
+	out := buf.String()
+	if strings.Count(out, "\n") != 1 {
+		t.Fatalf("expected one progress entry, got %q", out)
+	}
+	for _, want := range []string{"import 42% (4.2M/10M)", "job=rows", "done=4200000", "total=10000000", "rate=70000", "eta=1m23s"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("progress entry missing %q: %s", want, out)
+		}
+	}
+
+	p.Done()
+	p.Done()
+	if got := strings.Count(buf.String(), "import done (4.2M/10M in 1m0s)"); got != 1 {
+		t.Errorf("Done should log once, got %d: %q", got, buf.String())
+	}
+	if p.Count() != 4_200_001 {
+		t.Errorf("Count = %d", p.Count())
+	}
+}
+
+func TestProgressUnknownTotal(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	logger, buf := newTestLogger(t, func(cfg *Config) { cfg.Clock = clock })
+
+	p := logger.Progress("scan", 0)
+	clock.Advance(defaultProgressInterval)
+	p.Increment(12_345)
+	if !strings.Contains(buf.String(), "scan 12k") || strings.Contains(buf.String(), "eta=") {
+		t.Errorf("unexpected entry for an unknown total: %q", buf.String())
+	}
+}
+
+func TestProgressConcurrent(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	logger, buf := newTestLogger(t, func(cfg *Config) { cfg.Clock = clock })
+
+	p := logger.Progress("work", 800, ProgressConfig{Interval: time.Second})
+	clock.Advance(time.Second)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				p.Increment(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if p.Count() != 800 || strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("count %d, output %q", p.Count(), buf.String())
+	}
+}
+
+func TestShortCount(t *testing.T) {
+	tests := map[int64]string{
+		950:           "950",
+		1000:          "1k",
+		12_345:        "12k",
+		999_999:       "1M",
+		4_200_000:     "4.2M",
+		10_000_000:    "10M",
+		3_000_000_000: "3G",
+	}
+	for n, want := range tests {
+		if got := shortCount(n); got != want {
+			t.Errorf("shortCount(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package dd
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// quarantineState is the quarantine state of one writer (see
+// QuarantineConfig). It lives in the writer's writerStats so it survives
+// changes to the writer list.
+type quarantineState struct {
+	active   atomic.Bool  // the writer is quarantined
+	failures atomic.Int64 // failed writes in the current run
+
+	skipped      atomic.Int64 // entries skipped while quarantined
+	skippedBytes atomic.Int64
+
+	mu          sync.Mutex
+	runStart    time.Time     // first failure of the current run
+	since       time.Time     // start of the quarantine
+	nextProbe   time.Time     // earliest time for the next probe write
+	backoff     time.Duration // current time between probes
+	probing     bool          // a probe write is in flight
+	skippedBase int64         // skipped counters at the start of the quarantine
+	bytesBase   int64
+}
+
+// withDefaults returns c with zero values replaced by the defaults.
+func (c QuarantineConfig) withDefaults() QuarantineConfig {
+	if c.MaxFailures == 0 {
+		c.MaxFailures = defaultQuarantineFailures
+	}
+	if c.Window == 0 {
+		c.Window = defaultQuarantineWindow
+	}
+	if c.InitialBackoff == 0 {
+		c.InitialBackoff = defaultQuarantineBackoff
+	}
+	if c.MaxBackoff == 0 {
+		c.MaxBackoff = max(defaultQuarantineMaxBackoff, c.InitialBackoff)
+	}
+	return c
+}
+
+// startProbe reports whether a write to the quarantined writer should go
+// through as a probe.
+func (q *quarantineState) startProbe(now time.Time) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.probing || now.Before(q.nextProbe) {
+		return false
+	}
+	q.probing = true
+	return true
+}
+
+// skip counts an entry the quarantined writer did not receive.
+func (q *quarantineState) skip(size int) {
+	q.skipped.Add(1)
+	q.skippedBytes.Add(int64(size))
+}
+
+// updateQuarantine updates the quarantine state of s after a write, which
+// was a probe if probe is set, and reports transitions to the hooks.
+func (l *Logger) updateQuarantine(s *writerStats, probe bool, err error) {
+	q := &s.quarantine
+	if err == nil {
+		if probe {
+			l.restoreWriter(s)
+		} else if q.failures.Load() != 0 {
+			q.failures.Store(0)
+		}
+		return
+	}
+
+	cfg := l.quarantine
+	now := l.clock.Now()
+	q.mu.Lock()
+	if probe {
+		q.backoff = min(2*q.backoff, cfg.MaxBackoff)
+		q.nextProbe = now.Add(q.backoff)
+		q.probing = false
+		q.mu.Unlock()
+		return
+	}
+	if q.active.Load() {
+		// A write that started before the writer was quarantined
+		q.mu.Unlock()
+		return
+	}
+	if q.failures.Load() == 0 || now.Sub(q.runStart) > cfg.Window {
+		q.runStart = now
+		q.failures.Store(1)
+	} else {
+		q.failures.Add(1)
+	}
+	failures := q.failures.Load()
+	if failures < int64(cfg.MaxFailures) {
+		q.mu.Unlock()
+		return
+	}
+	q.active.Store(true)
+	q.since = now
+	q.backoff = cfg.InitialBackoff
+	q.nextProbe = now.Add(q.backoff)
+	q.skippedBase = q.skipped.Load()
+	q.bytesBase = q.skippedBytes.Load()
+	q.mu.Unlock()
+
+	l.internalError(ComponentWriter, err, "writer %T quarantined after %d failed writes: %v", s.writer, failures, err)
+	_ = l.triggerHooks(l.ctx, &HookContext{
+		Event:     HookOnWriterQuarantined,
+		Error:     err,
+		Writer:    s.writer,
+		Timestamp: now,
+		Metadata:  map[string]any{"failures": failures},
+	})
+}
+
+// restoreWriter ends the quarantine of s after a successful probe.
+func (l *Logger) restoreWriter(s *writerStats) {
+	q := &s.quarantine
+	now := l.clock.Now()
+	q.mu.Lock()
+	q.active.Store(false)
+	q.probing = false
+	q.failures.Store(0)
+	quarantinedFor := now.Sub(q.since)
+	skipped := q.skipped.Load() - q.skippedBase
+	skippedBytes := q.skippedBytes.Load() - q.bytesBase
+	q.mu.Unlock()
+
+	l.internalError(ComponentWriter, nil, "writer %T restored after %v, %d entries skipped", s.writer, quarantinedFor, skipped)
+	_ = l.triggerHooks(l.ctx, &HookContext{
+		Event:     HookOnWriterRestored,
+		Writer:    s.writer,
+		Timestamp: now,
+		Metadata: map[string]any{
+			"skipped_writes":  skipped,
+			"skipped_bytes":   skippedBytes,
+			"quarantined_for": quarantinedFor,
+		},
+	})
+}
@@ -0,0 +1,140 @@
+package dd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyWriter fails every write while down is set.
+type flakyWriter struct {
+	down   atomic.Bool
+	writes atomic.Int64
+}
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	w.writes.Add(1)
+	if w.down.Load() {
+		return 0, errors.New("mount gone")
+	}
+	return len(p), nil
+}
+
+// quarantineConfig configures flaky as a second writer that is quarantined
+// after three failures, recording quarantine hook events into events.
+func quarantineConfig(flaky *flakyWriter, clock *ManualClock, events *[]HookContext) func(*Config) {
+	var mu sync.Mutex
+	record := func(_ context.Context, hookCtx *HookContext) error {
+		mu.Lock()
+		*events = append(*events, *hookCtx)
+		mu.Unlock()
+		return nil
+	}
+	return func(cfg *Config) {
+		cfg.Outputs = []io.Writer{flaky}
+		cfg.Clock = clock
+		cfg.Quarantine = &QuarantineConfig{MaxFailures: 3, Window: time.Minute, InitialBackoff: time.Second, MaxBackoff: 3 * time.Second}
+		cfg.Hooks = NewHooksFromConfig(HooksConfig{
+			OnWriterQuarantined: []Hook{record},
+			OnWriterRestored:    []Hook{record},
+		})
+	}
+}
+
+func TestWriterQuarantine(t *testing.T) {
+	flaky := &flakyWriter{}
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var events []HookContext
+	logger, _ := newTestLogger(t, quarantineConfig(flaky, clock, &events))
+
+	flaky.down.Store(true)
+	for range 3 {
+		logger.Info("failing")
+	}
+	if len(events) != 1 || events[0].Event != HookOnWriterQuarantined || events[0].Writer != flaky {
+		t.Fatalf("expected a quarantine event, got %+v", events)
+	}
+
+	for range 4 {
+		logger.Info("skipped")
+	}
+	if flaky.writes.Load() != 3 {
+		t.Errorf("quarantined writer should not be written to, got %d writes", flaky.writes.Load())
+	}
+	stats := logger.WriterStats()[1]
+	if !stats.Quarantined || stats.SkippedWrites != 4 || stats.SkippedBytes == 0 || stats.Dropped != 7 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if other := logger.WriterStats()[0]; other.Writes != 7 || other.Quarantined {
+		t.Errorf("healthy writer should get every entry: %+v", other)
+	}
+
+	// A failed probe doubles the backoff
+	clock.Advance(time.Second)
+	logger.Info("probe")
+	clock.Advance(time.Second)
+	logger.Info("skipped")
+	if flaky.writes.Load() != 4 {
+		t.Errorf("expected one probe write, got %d writes", flaky.writes.Load())
+	}
+
+	flaky.down.Store(false)
+	clock.Advance(time.Second)
+	logger.Info("restored")
+	logger.Info("after")
+	if flaky.writes.Load() != 6 {
+		t.Errorf("restored writer should receive entries, got %d writes", flaky.writes.Load())
+	}
+	if len(events) != 2 || events[1].Event != HookOnWriterRestored {
+		t.Fatalf("expected a restore event, got %+v", events)
+	}
+	if events[1].Metadata["skipped_writes"] != int64(5) || events[1].Metadata["quarantined_for"] != 3*time.Second {
+		t.Errorf("unexpected restore metadata: %v", events[1].Metadata)
+	}
+	if logger.WriterStats()[1].Quarantined {
+		t.Error("writer should no longer be quarantined")
+	}
+}
+
+func TestWriterQuarantineWindow(t *testing.T) {
+	flaky := &flakyWriter{}
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var events []HookContext
+	logger, _ := newTestLogger(t, quarantineConfig(flaky, clock, &events))
+
+	flaky.down.Store(true)
+	logger.Info("one")
+	logger.Info("two")
+	clock.Advance(2 * time.Minute)
+	logger.Info("new run")
+	logger.Info("two")
+	if len(events) != 0 {
+		t.Fatalf("failures spread beyond the window should not quarantine: %+v", events)
+	}
+
+	flaky.down.Store(false)
+	logger.Info("ok")
+	flaky.down.Store(true)
+	logger.Info("one")
+	logger.Info("two")
+	if len(events) != 0 {
+		t.Fatalf("a successful write should reset the run: %+v", events)
+	}
+}
+
+func TestQuarantineConfigValidation(t *testing.T) {
+	for _, q := range []*QuarantineConfig{
+		{MaxFailures: -1},
+		{InitialBackoff: time.Minute, MaxBackoff: time.Second},
+	} {
+		cfg := DefaultConfig()
+		cfg.Quarantine = q
+		if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+			t.Errorf("%+v: expected ErrConfigValidation, got %v", q, err)
+		}
+	}
+}
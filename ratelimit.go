@@ -0,0 +1,159 @@
+package dd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// RateLimitStats reports the entries dropped by the rate limiter.
+type RateLimitStats struct {
+	Dropped        int64              // Total entries dropped
+	DroppedByLevel map[LogLevel]int64 // Dropped entries per level (levels with drops only)
+}
+
+// rateLimitState is the runtime form of a RateLimitConfig.
+type rateLimitState struct {
+	config *RateLimitConfig
+	// limiters holds the limiter for each level; nil means no limit.
+	// Levels without a PerLevel entry share one limiter.
+	limiters [LevelFatal + 1]*internal.RateLimiter
+	stop     chan struct{}
+}
+
+func newRateLimitState(config *RateLimitConfig) *rateLimitState {
+	state := &rateLimitState{config: config, stop: make(chan struct{})}
+	shared := newLevelLimiter(LevelRateLimit{PerSecond: config.PerSecond, Burst: config.Burst})
+	for level := LevelDebug; level < LevelFatal; level++ {
+		if limit, ok := config.PerLevel[level]; ok {
+			state.limiters[level] = newLevelLimiter(limit)
+		} else {
+			state.limiters[level] = shared
+		}
+	}
+	return state
+}
+
+func newLevelLimiter(limit LevelRateLimit) *internal.RateLimiter {
+	if limit.PerSecond <= 0 {
+		return nil
+	}
+	return internal.NewRateLimiter(&internal.RateLimitConfig{
+		MaxMessagesPerSecond: limit.PerSecond,
+		BurstSize:            limit.Burst,
+		Strategy:             internal.RateLimitStrategyDrop,
+	})
+}
+
+// SetRateLimit replaces the rate limit configuration at runtime
+// (thread-safe). Pass nil to disable rate limiting. Drop counts are kept
+// across changes.
+func (l *Logger) SetRateLimit(config *RateLimitConfig) {
+	if l == nil {
+		return
+	}
+	if l.closed.Load() {
+		return
+	}
+
+	var state *rateLimitState
+	if config != nil {
+		state = newRateLimitState(config.Clone())
+	}
+	if old := l.rateLimit.Swap(state); old != nil {
+		close(old.stop)
+	}
+	if state != nil && state.config.SummaryInterval > 0 {
+		go l.runRateLimitSummary(state, l.droppedByLevel())
+	}
+}
+
+// GetRateLimit returns a copy of the current rate limit configuration,
+// or nil if rate limiting is disabled.
+func (l *Logger) GetRateLimit() *RateLimitConfig {
+	if l == nil {
+		return nil
+	}
+	state := l.rateLimit.Load()
+	if state == nil {
+		return nil
+	}
+	return state.config.Clone()
+}
+
+// RateLimitStats returns the number of entries dropped by the rate limiter
+// since the logger was created.
+func (l *Logger) RateLimitStats() RateLimitStats {
+	if l == nil {
+		return RateLimitStats{DroppedByLevel: make(map[LogLevel]int64)}
+	}
+	stats := RateLimitStats{DroppedByLevel: make(map[LogLevel]int64)}
+	for level := LevelDebug; level <= LevelFatal; level++ {
+		if n := l.rateLimitDropped[level].Load(); n > 0 {
+			stats.DroppedByLevel[level] = n
+			stats.Dropped += n
+		}
+	}
+	return stats
+}
+
+// droppedByLevel returns a snapshot of the rate limiter drop counts.
+func (l *Logger) droppedByLevel() [LevelFatal + 1]int64 {
+	var counts [LevelFatal + 1]int64
+	for level := range counts {
+		counts[level] = l.rateLimitDropped[level].Load()
+	}
+	return counts
+}
+
+// allowRate reports whether the rate limiter lets an entry at level
+// through, counting it if not.
+func (l *Logger) allowRate(level LogLevel) bool {
+	state := l.rateLimit.Load()
+	if state == nil || level < LevelDebug || level >= LevelFatal {
+		return true
+	}
+	limiter := state.limiters[level]
+	if limiter == nil || !limiter.ShouldRateLimit(0) {
+		return true
+	}
+	l.rateLimitDropped[level].Add(1)
+	return false
+}
+
+// runRateLimitSummary writes a WARN entry with the entries dropped during
+// each interval until state is replaced or the logger closes.
+// last holds the drop counts when state was installed.
+func (l *Logger) runRateLimitSummary(state *rateLimitState, last [LevelFatal + 1]int64) {
+	ticker := time.NewTicker(state.config.SummaryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case <-state.stop:
+			return
+		case <-ticker.C:
+		}
+
+		var total int64
+		fields := make([]Field, 0, len(last)+1)
+		fields = append(fields, Field{})
+		for level := LevelDebug; level <= LevelFatal; level++ {
+			current := l.rateLimitDropped[level].Load()
+			if n := current - last[level]; n > 0 {
+				fields = append(fields, Int64("dropped_"+strings.ToLower(level.String()), n))
+				total += n
+			}
+			last[level] = current
+		}
+		if total == 0 || l.closed.Load() || !l.IsLevelEnabled(LevelWarn) {
+			continue
+		}
+		fields[0] = Int64("dropped", total)
+		fields = append(fields, Duration("interval", state.config.SummaryInterval))
+		l.logCore(LevelWarn, logEntry{msg: "rate limit dropped log entries", fields: fields})
+	}
+}
@@ -0,0 +1,205 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer safe for the summary goroutine to write to.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestRateLimitDropsAboveRate(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.RateLimit = &RateLimitConfig{PerSecond: 5, Burst: 2}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	for i := 0; i < 20; i++ {
+		logger.Info("flood")
+	}
+
+	written := strings.Count(buf.String(), "flood")
+	// A second boundary during the loop can refill the limit once.
+	if written < 7 || written > 14 {
+		t.Errorf("wrote %d entries, want 7 (PerSecond+Burst)", written)
+	}
+	stats := logger.RateLimitStats()
+	if stats.Dropped != int64(20-written) || stats.DroppedByLevel[LevelInfo] != stats.Dropped {
+		t.Errorf("unexpected stats %+v for %d written", stats, written)
+	}
+}
+
+func TestRateLimitPerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Level = LevelDebug
+	cfg.Output = &buf
+	cfg.RateLimit = &RateLimitConfig{
+		PerSecond: 2,
+		PerLevel: map[LogLevel]LevelRateLimit{
+			LevelDebug: {PerSecond: 1},
+			LevelError: {},
+		},
+	}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	for i := 0; i < 10; i++ {
+		logger.Debug("debug entry")
+		logger.Error("error entry")
+	}
+
+	if n := strings.Count(buf.String(), "debug entry"); n == 0 || n > 2 {
+		t.Errorf("debug wrote %d entries, want its own limit of 1", n)
+	}
+	if n := strings.Count(buf.String(), "error entry"); n != 10 {
+		t.Errorf("error wrote %d entries, want all 10 (exempt)", n)
+	}
+	if _, ok := logger.RateLimitStats().DroppedByLevel[LevelError]; ok {
+		t.Error("exempt level should have no drops")
+	}
+}
+
+func TestRateLimitRuntimeChange(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	if logger.GetRateLimit() != nil {
+		t.Error("rate limiting should be off by default")
+	}
+
+	logger.SetRateLimit(&RateLimitConfig{PerSecond: 1})
+	for i := 0; i < 5; i++ {
+		logger.Warn("limited")
+	}
+	if got := logger.GetRateLimit(); got == nil || got.PerSecond != 1 {
+		t.Errorf("GetRateLimit = %+v", got)
+	}
+
+	logger.SetRateLimit(nil)
+	buf.Reset()
+	for i := 0; i < 5; i++ {
+		logger.Warn("unlimited")
+	}
+	if n := strings.Count(buf.String(), "unlimited"); n != 5 {
+		t.Errorf("disabling the limit should let all entries through, got %d", n)
+	}
+	if logger.RateLimitStats().Dropped == 0 {
+		t.Error("drop counts should survive SetRateLimit")
+	}
+}
+
+func TestRateLimitSummary(t *testing.T) {
+	buf := &syncBuffer{}
+	cfg := DefaultConfig()
+	cfg.Output = buf
+	cfg.RateLimit = &RateLimitConfig{PerSecond: 1, SummaryInterval: 20 * time.Millisecond}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	for i := 0; i < 10; i++ {
+		logger.Info("flood")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(buf.String(), "rate limit dropped log entries") {
+		if time.Now().After(deadline) {
+			t.Fatalf("no summary written, output: %q", buf.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !strings.Contains(buf.String(), "dropped_info=") {
+		t.Errorf("summary should include per-level counts: %q", buf.String())
+	}
+}
+
+func TestRateLimitNeverDropsFatal(t *testing.T) {
+	var buf bytes.Buffer
+	fatalCalls := 0
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.FatalHandler = func() { fatalCalls++ }
+	cfg.RateLimit = &RateLimitConfig{PerSecond: 1}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	for i := 0; i < 5; i++ {
+		logger.Error("error entry")
+	}
+	logger.Fatal("fatal entry")
+	if fatalCalls != 1 || !strings.Contains(buf.String(), "fatal entry") {
+		t.Errorf("fatal entry must bypass the limit, handler called %d times", fatalCalls)
+	}
+}
+
+func TestRateLimitValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *RateLimitConfig
+		target error
+	}{
+		{"negative rate", &RateLimitConfig{PerSecond: -1}, ErrConfigValidation},
+		{"negative interval", &RateLimitConfig{SummaryInterval: -time.Second}, ErrConfigValidation},
+		{"negative level rate", &RateLimitConfig{PerLevel: map[LogLevel]LevelRateLimit{LevelInfo: {Burst: -1}}}, ErrConfigValidation},
+		{"invalid level", &RateLimitConfig{PerLevel: map[LogLevel]LevelRateLimit{LogLevel(9): {}}}, ErrInvalidLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.RateLimit = tt.config
+			if _, err := New(cfg); !errors.Is(err, tt.target) {
+				t.Errorf("expected %v, got %v", tt.target, err)
+			}
+		})
+	}
+}
+
+func TestRateLimitConfigClone(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RateLimit = &RateLimitConfig{PerLevel: map[LogLevel]LevelRateLimit{LevelDebug: {PerSecond: 1}}}
+	clone := cfg.Clone()
+	clone.RateLimit.PerLevel[LevelInfo] = LevelRateLimit{PerSecond: 2}
+
+	if len(cfg.RateLimit.PerLevel) != 1 {
+		t.Error("Clone should deep copy RateLimit.PerLevel")
+	}
+}
@@ -0,0 +1,626 @@
+package dd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// defaultMaxLineSize bounds a single line (or a pretty-printed JSON entry)
+// read by Reader.
+const defaultMaxLineSize = 1024 * 1024
+
+// ReaderConfig configures a Reader. The zero value reads dd's default text
+// and JSON output.
+type ReaderConfig struct {
+	// TimeFormat is the layout timestamps were written with
+	// (Config.TimeFormat). DefaultTimeFormat, RFC 3339 and the development
+	// layout are always tried as well.
+	TimeFormat string
+
+	// FieldNames are the JSON keys the entries were written with
+	// (JSONOptions.FieldNames). Nil uses the defaults.
+	FieldNames *JSONFieldNames
+
+	// MaxLineSize bounds a single entry in bytes (default: 1MB).
+	MaxLineSize int
+}
+
+// Reader parses log output written by dd back into Records. Each line is
+// detected as JSON (starting with '{') or text, so mixed files are fine.
+// Indented continuation lines, such as stack traces in text output, are
+// attached to the preceding entry.
+//
+// Text output is ambiguous where a message itself ends with key=value
+// pairs; those are read as fields. The console format is not supported.
+//
+// Example:
+//
+//	r, err := dd.OpenLogFile("logs/app.log.1.gz")
+//	if err != nil { ... }
+//	defer r.Close()
+//	for r.Next() {
+//	    rec := r.Record()
+//	    if rec.Level >= dd.LevelError {
+//	        fmt.Println(rec.Time, rec.Message)
+//	    }
+//	}
+//	if err := r.Err(); err != nil { ... }
+type Reader struct {
+	scanner    *bufio.Scanner
+	closers    []io.Closer
+	timeFormat string
+	names      *JSONFieldNames
+	maxLine    int
+
+	pending    string
+	hasPending bool
+	record     *Record
+	line       int
+	err        error
+}
+
+// NewReader returns a Reader parsing r.
+func NewReader(r io.Reader, cfg ...ReaderConfig) *Reader {
+	var c ReaderConfig
+	if len(cfg) > 0 {
+		c = cfg[0]
+	}
+	if c.MaxLineSize <= 0 {
+		c.MaxLineSize = defaultMaxLineSize
+	}
+	names := internal.MergeWithDefaults(c.FieldNames)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), c.MaxLineSize)
+	return &Reader{
+		scanner:    scanner,
+		timeFormat: c.TimeFormat,
+		names:      names,
+		maxLine:    c.MaxLineSize,
+	}
+}
+
+// OpenLogFile opens a log file for reading. Files ending in the extension
+// of a registered compressor (".gz", or ".zst" with the zstd module), such
+// as compressed rotation backups, are decompressed. Close the Reader when
+// done.
+func OpenLogFile(path string, cfg ...ReaderConfig) (*Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var src io.Reader = f
+	closers := []io.Closer{f}
+	if c, ok := compressorForFile(path); ok {
+		dr, err := c.NewReader(f)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("decompress %s: %w", path, err)
+		}
+		src = dr
+		closers = append([]io.Closer{dr}, closers...)
+	}
+
+	r := NewReader(src, cfg...)
+	r.closers = closers
+	return r, nil
+}
+
+// Next advances to the next entry. It returns false at the end of the
+// input or on a read error (see Err).
+func (r *Reader) Next() bool {
+	r.record = nil
+	if r.err != nil {
+		return false
+	}
+
+	for {
+		line, ok := r.nextLine()
+		if !ok {
+			return false
+		}
+		if strings.TrimSpace(line) == "" || isContinuation(line) {
+			continue // orphan continuation lines before the first entry
+		}
+
+		var err error
+		if strings.HasPrefix(line, "{") {
+			r.record, err = r.parseJSONEntry(line)
+		} else {
+			r.record = r.parseTextLine(line)
+			r.attachContinuation(r.record)
+		}
+		if err != nil {
+			r.err = fmt.Errorf("line %d: %w", r.line, err)
+			return false
+		}
+		return true
+	}
+}
+
+// Record returns the entry read by the last call to Next.
+func (r *Reader) Record() *Record {
+	return r.record
+}
+
+// Err returns the first read or parse error.
+func (r *Reader) Err() error {
+	return r.err
+}
+
+// Close closes the underlying file opened by OpenLogFile.
+func (r *Reader) Close() error {
+	var firstErr error
+	for _, c := range r.closers {
+		if err := c.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	r.closers = nil
+	return firstErr
+}
+
+// nextLine returns the pushed-back line or reads a new one.
+func (r *Reader) nextLine() (string, bool) {
+	if r.hasPending {
+		r.hasPending = false
+		return r.pending, true
+	}
+	if !r.scanner.Scan() {
+		r.err = r.scanner.Err()
+		return "", false
+	}
+	r.line++
+	return strings.TrimRight(r.scanner.Text(), "\r"), true
+}
+
+// unread pushes line back for the next call to nextLine.
+func (r *Reader) unread(line string) {
+	r.pending = line
+	r.hasPending = true
+}
+
+// isContinuation reports whether line continues the previous entry.
+func isContinuation(line string) bool {
+	return line != "" && (line[0] == ' ' || line[0] == '\t')
+}
+
+// attachContinuation appends indented lines that follow a text entry to its
+// stack trace field, or to the message if there is none.
+func (r *Reader) attachContinuation(rec *Record) {
+	var body []string
+	for {
+		line, ok := r.nextLine()
+		if !ok {
+			break
+		}
+		if !isContinuation(line) {
+			r.unread(line)
+			break
+		}
+		body = append(body, strings.TrimPrefix(line, "    "))
+	}
+	if len(body) == 0 {
+		return
+	}
+
+	text := "\n" + strings.Join(body, "\n")
+	for i := len(rec.Fields) - 1; i >= 0; i-- {
+		key := rec.Fields[i].Key
+		if key == "error" || key == "stack" || key == "stacktrace" || key == "stack_trace" || key == "error.stack" || key == GoroutinesKey {
+			rec.Fields[i].Value = fmt.Sprint(rec.Fields[i].Value) + text
+			return
+		}
+	}
+	rec.Message += text
+}
+
+// parseTextLine parses "[time LEVEL] caller message key=value ...".
+func (r *Reader) parseTextLine(line string) *Record {
+	rec := &Record{Level: LevelInfo}
+	rest := line
+
+	if strings.HasPrefix(rest, "[") {
+		if end := strings.IndexByte(rest, ']'); end > 0 {
+			header := strings.TrimSpace(rest[1:end])
+			if idx := strings.LastIndexByte(header, ' '); idx >= 0 {
+				if level, err := ParseLevel(header[idx+1:]); err == nil {
+					rec.Level = level
+					header = strings.TrimSpace(header[:idx])
+				}
+			} else if level, err := ParseLevel(header); err == nil {
+				rec.Level = level
+				header = ""
+			}
+			if header != "" {
+				rec.Time = r.parseTime(header)
+			}
+			rest = strings.TrimPrefix(rest[end+1:], " ")
+		}
+	}
+
+	// Caller: "file.go:123"
+	if token, after, _ := strings.Cut(rest, " "); isCallerToken(token) {
+		rec.Caller = token
+		rest = after
+	}
+
+	rec.Message, rec.Fields = splitTextFields(rest)
+	return rec
+}
+
+// isCallerToken reports whether s looks like "path/file.go:123".
+func isCallerToken(s string) bool {
+	idx := strings.LastIndex(s, ".go:")
+	if idx <= 0 || idx+4 == len(s) {
+		return false
+	}
+	_, err := strconv.Atoi(s[idx+4:])
+	return err == nil
+}
+
+// textToken is a space-separated token of a text entry.
+type textToken struct {
+	start int
+	key   string
+	value any
+	field bool
+}
+
+// splitTextFields separates the trailing key=value pairs of s from the
+// message in front of them.
+func splitTextFields(s string) (string, []Field) {
+	tokens := tokenizeText(s)
+
+	first := len(tokens)
+	for first > 0 && tokens[first-1].field {
+		first--
+	}
+	if first == len(tokens) {
+		return s, nil
+	}
+
+	fields := make([]Field, 0, len(tokens)-first)
+	for _, t := range tokens[first:] {
+		fields = append(fields, Field{Key: t.key, Value: t.value})
+	}
+	message := ""
+	if first > 0 {
+		message = strings.TrimRight(s[:tokens[first].start], " ")
+	}
+	return message, fields
+}
+
+// tokenizeText splits s on spaces, keeping quoted values and JSON values of
+// key=value tokens intact.
+func tokenizeText(s string) []textToken {
+	var tokens []textToken
+	i := 0
+	for i < len(s) {
+		if s[i] == ' ' {
+			i++
+			continue
+		}
+		start := i
+		eq := -1
+		for i < len(s) && s[i] != ' ' && s[i] != '=' {
+			i++
+		}
+		if i < len(s) && s[i] == '=' && i > start && isFieldKey(s[start:i]) {
+			eq = i
+			i++
+		}
+
+		if eq < 0 {
+			for i < len(s) && s[i] != ' ' {
+				i++
+			}
+			tokens = append(tokens, textToken{start: start})
+			continue
+		}
+
+		valueStart := i
+		end, ok := scanTextValue(s, i)
+		if !ok {
+			for i < len(s) && s[i] != ' ' {
+				i++
+			}
+			tokens = append(tokens, textToken{start: start})
+			continue
+		}
+		i = end
+		tokens = append(tokens, textToken{
+			start: start,
+			key:   s[start:eq],
+			value: parseTextValue(s[valueStart:end]),
+			field: true,
+		})
+	}
+	return tokens
+}
+
+// isFieldKey reports whether s is a plausible field key.
+func isFieldKey(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// scanTextValue returns the end of the value starting at i: a quoted
+// string, a JSON object or array, or a run of non-space bytes.
+func scanTextValue(s string, i int) (int, bool) {
+	if i >= len(s) {
+		return i, true
+	}
+	switch s[i] {
+	case '"':
+		for j := i + 1; j < len(s); j++ {
+			switch s[j] {
+			case '\\':
+				j++
+			case '"':
+				return j + 1, j+1 == len(s) || s[j+1] == ' '
+			}
+		}
+		return 0, false
+	case '{', '[':
+		depth, inString := 0, false
+		for j := i; j < len(s); j++ {
+			c := s[j]
+			switch {
+			case inString && c == '\\':
+				j++
+			case c == '"':
+				inString = !inString
+			case inString:
+			case c == '{' || c == '[':
+				depth++
+			case c == '}' || c == ']':
+				depth--
+				if depth == 0 && json.Valid([]byte(s[i:j+1])) {
+					return j + 1, true
+				}
+			}
+		}
+	}
+	j := i
+	for j < len(s) && s[j] != ' ' {
+		j++
+	}
+	return j, true
+}
+
+// parseTextValue converts a rendered value back to a Go value.
+func parseTextValue(v string) any {
+	switch {
+	case v == "":
+		return ""
+	case v == "<nil>":
+		return nil
+	case v == "true":
+		return true
+	case v == "false":
+		return false
+	case v[0] == '"':
+		var b strings.Builder
+		for i := 1; i < len(v)-1; i++ {
+			if v[i] == '\\' && i+1 < len(v)-1 {
+				i++
+			}
+			b.WriteByte(v[i])
+		}
+		return b.String()
+	case v[0] == '{' || v[0] == '[':
+		if value, err := decodeJSONValue([]byte(v), 2); err == nil {
+			return value
+		}
+		return v
+	}
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(v, 64); err == nil {
+		return f
+	}
+	return v
+}
+
+// parseJSONEntry parses one JSON entry, reading further lines for
+// pretty-printed output.
+func (r *Reader) parseJSONEntry(line string) (*Record, error) {
+	data := []byte(line)
+	for !json.Valid(data) {
+		next, ok := r.nextLine()
+		if !ok {
+			if r.err != nil {
+				return nil, r.err
+			}
+			return nil, fmt.Errorf("%w: incomplete JSON entry", ErrInvalidFormat)
+		}
+		if len(data)+len(next) > r.maxLine {
+			return nil, fmt.Errorf("%w: JSON entry exceeds %d bytes", ErrInvalidFormat, r.maxLine)
+		}
+		data = append(append(data, '\n'), next...)
+	}
+
+	members, err := decodeJSONEntry(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+
+	rec := &Record{Level: LevelInfo}
+	for _, m := range members {
+		switch m.Key {
+		case r.names.Timestamp:
+			rec.Time = r.parseTimeValue(m.Value)
+		case r.names.Level:
+			if s, ok := m.Value.(string); ok {
+				if level, err := ParseLevel(s); err == nil {
+					rec.Level = level
+				}
+			}
+		case r.names.Caller:
+			rec.Caller = fmt.Sprint(m.Value)
+		case r.names.Message:
+			rec.Message = fmt.Sprint(m.Value)
+		case r.names.Fields:
+			if nested, ok := m.Value.([]Field); ok {
+				rec.Fields = append(rec.Fields, nested...)
+				continue
+			}
+			rec.Fields = append(rec.Fields, m)
+		default:
+			// Flattened fields, static fields and colliding keys
+			// ("fields.<key>") are kept in output order
+			m.Key = strings.TrimPrefix(m.Key, r.names.Fields+".")
+			if nested, ok := m.Value.([]Field); ok {
+				m.Value = fieldsToMap(nested)
+			}
+			rec.Fields = append(rec.Fields, m)
+		}
+	}
+	return rec, nil
+}
+
+// decodeJSONEntry decodes a JSON entry into its members in document order.
+// The entry and its fields object become []Field so that field order is
+// kept; deeper objects become map[string]any.
+func decodeJSONEntry(data []byte) ([]Field, error) {
+	value, err := decodeJSONValue(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	members, ok := value.([]Field)
+	if !ok {
+		return nil, fmt.Errorf("entry is not a JSON object")
+	}
+	return members, nil
+}
+
+// decodeJSONValue decodes a JSON value found at the given nesting depth.
+// Numbers become int64 or float64.
+func decodeJSONValue(data []byte, depth int) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeJSONToken(dec, depth)
+}
+
+// fieldsToMap converts ordered members to a map.
+func fieldsToMap(fields []Field) map[string]any {
+	m := make(map[string]any, len(fields))
+	for _, f := range fields {
+		m[f.Key] = f.Value
+	}
+	return m
+}
+
+// decodeJSONToken decodes the next value from dec.
+func decodeJSONToken(dec *json.Decoder, depth int) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			var members []Field
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := decodeJSONToken(dec, depth+1)
+				if err != nil {
+					return nil, err
+				}
+				members = append(members, Field{Key: keyTok.(string), Value: value})
+			}
+			if _, err := dec.Token(); err != nil { // '}'
+				return nil, err
+			}
+			if depth > 1 {
+				return fieldsToMap(members), nil
+			}
+			return members, nil
+		case '[':
+			values := []any{}
+			for dec.More() {
+				value, err := decodeJSONToken(dec, depth+2)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, value)
+			}
+			_, err := dec.Token() // ']'
+			return values, err
+		}
+	case json.Number:
+		if n, err := t.Int64(); err == nil {
+			return n, nil
+		}
+		return t.Float64()
+	}
+	return tok, nil
+}
+
+// parseTimeValue converts a JSON timestamp: a formatted string or Unix
+// epoch number (unit inferred from magnitude).
+func (r *Reader) parseTimeValue(v any) time.Time {
+	switch t := v.(type) {
+	case string:
+		return r.parseTime(t)
+	case int64:
+		return epochTime(t)
+	case float64:
+		return epochTime(int64(math.Round(t)))
+	}
+	return time.Time{}
+}
+
+// epochTime interprets n as seconds, milliseconds, microseconds or
+// nanoseconds since the Unix epoch, whichever gives a plausible date.
+func epochTime(n int64) time.Time {
+	switch abs := max(n, -n); {
+	case abs < 1e11:
+		return time.Unix(n, 0)
+	case abs < 1e14:
+		return time.UnixMilli(n)
+	case abs < 1e17:
+		return time.UnixMicro(n)
+	default:
+		return time.Unix(0, n)
+	}
+}
+
+// parseTime parses s with the configured layout or a known default.
+func (r *Reader) parseTime(s string) time.Time {
+	layouts := [...]string{r.timeFormat, DefaultTimeFormat, time.RFC3339Nano, devTimeFormat, time.DateTime, time.StampMilli}
+	for _, layout := range layouts {
+		if layout == "" {
+			continue
+		}
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return epochTime(n)
+	}
+	return time.Time{}
+}
@@ -0,0 +1,178 @@
+package dd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readAll(t *testing.T, r *Reader) []*Record {
+	t.Helper()
+	var records []*Record
+	for r.Next() {
+		records = append(records, r.Record())
+	}
+	if err := r.Err(); err != nil {
+		t.Fatalf("read error: %v", err)
+	}
+	return records
+}
+
+func TestReaderTextRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Level = LevelDebug
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.InfoWith("user logged in", String("user", "bob smith"), Int("attempts", 3), Bool("mfa", true), Any("tags", []string{"a", "b"}))
+	logger.ErrorWith("charge failed", ErrWithStack(errors.New("declined")))
+	logger.Debug("plain message")
+	logger.Close()
+
+	records := readAll(t, NewReader(&buf))
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d from %q", len(records), buf.String())
+	}
+
+	rec := records[0]
+	if rec.Level != LevelInfo || rec.Message != "user logged in" || rec.Time.IsZero() {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+	want := []Field{
+		{Key: "user", Value: "bob smith"},
+		{Key: "attempts", Value: int64(3)},
+		{Key: "mfa", Value: true},
+		{Key: "tags", Value: []any{"a", "b"}},
+	}
+	if len(rec.Fields) != len(want) {
+		t.Fatalf("fields = %#v", rec.Fields)
+	}
+	for i, f := range want {
+		got := rec.Fields[i]
+		if got.Key != f.Key || !reflect.DeepEqual(got.Value, f.Value) {
+			t.Errorf("field %d = %#v, want %#v", i, got, f)
+		}
+	}
+
+	stack := records[1]
+	if stack.Level != LevelError || len(stack.Fields) != 1 || !strings.HasPrefix(stack.Fields[0].Value.(string), "declined\nStack:") {
+		t.Errorf("stack trace should be reattached to the error field: %#v", stack.Fields)
+	}
+
+	if records[2].Level != LevelDebug || records[2].Message != "plain message" || records[2].Fields != nil {
+		t.Errorf("unexpected record: %+v", records[2])
+	}
+}
+
+func TestReaderTextCaller(t *testing.T) {
+	input := "[2024-01-02T03:04:05Z   WARN] pkg/server.go:42 slow request path=/api took=1.5s\n"
+	records := readAll(t, NewReader(strings.NewReader(input)))
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	rec := records[0]
+	if rec.Caller != "pkg/server.go:42" || rec.Message != "slow request" || rec.Level != LevelWarn {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+	if !rec.Time.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("time = %v", rec.Time)
+	}
+	if len(rec.Fields) != 2 || rec.Fields[0].Value != "/api" || rec.Fields[1].Value != "1.5s" {
+		t.Errorf("fields = %#v", rec.Fields)
+	}
+}
+
+func TestReaderJSON(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := JSONConfig()
+	cfg.Output = &buf
+	cfg.DynamicCaller = false
+	cfg.JSON.FieldNames = &JSONFieldNames{Message: "msg", Level: "severity"}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.WarnWith("disk low", Int("free_mb", 512), Float64("ratio", 0.05), Any("meta", map[string]any{"dev": "sda"}))
+	logger.Close()
+
+	records := readAll(t, NewReader(&buf, ReaderConfig{FieldNames: &JSONFieldNames{Message: "msg", Level: "severity"}}))
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	rec := records[0]
+	if rec.Level != LevelWarn || rec.Message != "disk low" || rec.Time.IsZero() {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+	// The default JSON layout does not keep field order
+	fields := fieldsToMap(rec.Fields)
+	if len(rec.Fields) != 3 || fields["free_mb"] != int64(512) || fields["ratio"] != 0.05 {
+		t.Fatalf("fields = %#v", rec.Fields)
+	}
+	if meta, ok := fields["meta"].(map[string]any); !ok || meta["dev"] != "sda" {
+		t.Errorf("nested object should be a map: %#v", fields["meta"])
+	}
+}
+
+func TestReaderJSONVariants(t *testing.T) {
+	input := `{
+  "timestamp": 1704164645123,
+  "level": "ERROR",
+  "message": "pretty",
+  "service": "api"
+}
+not json but text
+{"level":"INFO","message":"broken"
+`
+	r := NewReader(strings.NewReader(input))
+	if !r.Next() {
+		t.Fatalf("expected a pretty-printed record: %v", r.Err())
+	}
+	rec := r.Record()
+	if rec.Message != "pretty" || rec.Level != LevelError || rec.Time.UnixMilli() != 1704164645123 {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+	if len(rec.Fields) != 1 || rec.Fields[0].Key != "service" {
+		t.Errorf("flattened fields = %#v", rec.Fields)
+	}
+
+	if !r.Next() || r.Record().Message != "not json but text" {
+		t.Errorf("text line not read: %+v", r.Record())
+	}
+	if r.Next() {
+		t.Error("truncated JSON should stop the reader")
+	}
+	if !errors.Is(r.Err(), ErrInvalidFormat) {
+		t.Errorf("expected ErrInvalidFormat, got %v", r.Err())
+	}
+}
+
+func TestOpenLogFileGzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log.1.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	gz.Write([]byte("[2024-01-02T03:04:05Z   INFO] one\n[2024-01-02T03:04:06Z   INFO] two\n"))
+	gz.Close()
+	f.Close()
+
+	r, err := OpenLogFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	records := readAll(t, r)
+	if len(records) != 2 || records[1].Message != "two" {
+		t.Errorf("unexpected records: %+v", records)
+	}
+}
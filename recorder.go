@@ -213,22 +213,13 @@ func findEqual(s string) int {
 	return -1
 }
 
-// parseLevelString converts a level string to LogLevel
+// parseLevelString converts a level string to LogLevel, defaulting to LevelInfo
 func parseLevelString(s string) LogLevel {
-	switch s {
-	case "DEBUG":
-		return LevelDebug
-	case "INFO":
-		return LevelInfo
-	case "WARN", "WARNING":
-		return LevelWarn
-	case "ERROR":
-		return LevelError
-	case "FATAL":
-		return LevelFatal
-	default:
+	level, err := ParseLevel(s)
+	if err != nil {
 		return LevelInfo
 	}
+	return level
 }
 
 // extractFieldsFromJSON extracts fields from a JSON log entry
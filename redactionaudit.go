@@ -0,0 +1,107 @@
+package dd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// Redaction categories reported in SecurityConfig.AuditRedactions events
+// besides the names of pattern packs.
+const (
+	// RedactionSensitiveKey marks a field redacted because of its key,
+	// such as "password" or "api_key".
+	RedactionSensitiveKey = "sensitive_key"
+	// RedactionCustomPattern marks a match of a pattern added with
+	// AddPattern rather than a pattern pack.
+	RedactionCustomPattern = "custom"
+)
+
+// auditRedaction reports a value changed by the sensitive data filter to
+// the AuditRedactions logger, if any. key is "" for the message. The event
+// carries the matched categories and a SHA-256 hash of the original value,
+// never the value itself.
+func (l *Logger) auditRedaction(filter *SensitiveDataFilter, key string, original any) {
+	sc := l.getSecurityConfig()
+	if sc == nil || sc.AuditRedactions == nil {
+		return
+	}
+
+	var packs []string
+	if key != "" && internal.IsSensitiveKey(key) {
+		packs = []string{RedactionSensitiveKey}
+	} else if s, ok := original.(string); ok {
+		packs = filter.matchingPacks(s)
+	}
+
+	text, ok := original.(string)
+	if !ok {
+		text = fmt.Sprint(original)
+	}
+	sum := sha256.Sum256([]byte(text))
+
+	message := "field redacted"
+	if key == "" {
+		message = "message redacted"
+	}
+	sc.AuditRedactions.Log(AuditEvent{
+		Type:    AuditEventSensitiveDataRedacted,
+		Message: message,
+		Field:   key,
+		Metadata: map[string]any{
+			"packs":        packs,
+			"value_sha256": hex.EncodeToString(sum[:]),
+		},
+		Severity:  AuditSeverityInfo,
+		Timestamp: l.clock.Now(),
+	})
+}
+
+// auditsRedactions reports whether redactions are audited.
+func (l *Logger) auditsRedactions() bool {
+	sc := l.getSecurityConfig()
+	return sc != nil && sc.AuditRedactions != nil
+}
+
+// matchingPacks returns the sorted pattern packs with an active pattern
+// matching input, using RedactionCustomPattern for custom patterns.
+func (f *SensitiveDataFilter) matchingPacks(input string) []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var packs []string
+	for _, p := range f.sources {
+		if p.pack != "" && f.disabledPacks[p.pack] {
+			continue
+		}
+		pack := p.pack
+		if pack == "" {
+			pack = RedactionCustomPattern
+		}
+		if slices.Contains(packs, pack) || !p.re.MatchString(input) {
+			continue
+		}
+		packs = append(packs, pack)
+	}
+	if _, n := f.entropy.Load().redactHighEntropy(input); n > 0 {
+		packs = append(packs, RedactionEntropy)
+	}
+	slices.Sort(packs)
+	return packs
+}
+
+// redacted reports whether filtering changed a field value. Only strings
+// and values under sensitive keys are compared.
+func redacted(key string, original, filtered any) bool {
+	if original == nil {
+		return false
+	}
+	if internal.IsSensitiveKey(key) {
+		return true
+	}
+	s, ok := original.(string)
+	return ok && filtered != s
+}
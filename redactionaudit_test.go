@@ -0,0 +1,102 @@
+package dd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditRedactions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	out, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+
+	auditor := NewAuditLogger(&AuditConfig{Enabled: true, Output: out, BufferSize: 16, JSONFormat: true})
+
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Security.AuditRedactions = auditor
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.InfoWith("retry with api_key=sk_live_abcdefghijklmnop",
+		String("password", "hunter2"),
+		String("note", "nothing secret"),
+	)
+	logger.Close()
+	auditor.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "hunter2") || strings.Contains(string(data), "sk_live") {
+		t.Fatalf("audit trail contains original values: %s", data)
+	}
+
+	// AuditSeverity encodes as a string but does not decode
+	type auditLine struct {
+		Message  string         `json:"message"`
+		Field    string         `json:"field"`
+		Metadata map[string]any `json:"metadata"`
+	}
+	var events []auditLine
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var event auditLine
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		events = append(events, event)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2 (message and password): %s", len(events), data)
+	}
+
+	byField := map[string]auditLine{}
+	for _, e := range events {
+		byField[e.Field] = e
+	}
+	msg, ok := byField[""]
+	if !ok || msg.Message != "message redacted" {
+		t.Errorf("message event = %+v", msg)
+	}
+	if packs, _ := msg.Metadata["packs"].([]any); len(packs) == 0 {
+		t.Errorf("message event has no packs: %+v", msg.Metadata)
+	}
+
+	pw, ok := byField["password"]
+	if !ok {
+		t.Fatalf("no event for password field: %s", data)
+	}
+	sum := sha256.Sum256([]byte("hunter2"))
+	if got := pw.Metadata["value_sha256"]; got != hex.EncodeToString(sum[:]) {
+		t.Errorf("value_sha256 = %v", got)
+	}
+	if packs, _ := pw.Metadata["packs"].([]any); len(packs) != 1 || packs[0] != RedactionSensitiveKey {
+		t.Errorf("password packs = %v", pw.Metadata["packs"])
+	}
+}
+
+func TestMatchingPacks(t *testing.T) {
+	filter := NewEmptySensitiveDataFilter()
+	if err := filter.AddPattern(`order-\d+`); err != nil {
+		t.Fatal(err)
+	}
+	if got := filter.matchingPacks("see order-42"); len(got) != 1 || got[0] != RedactionCustomPattern {
+		t.Errorf("matchingPacks = %v", got)
+	}
+	if got := filter.matchingPacks("nothing"); got != nil {
+		t.Errorf("matchingPacks = %v, want nil", got)
+	}
+}
@@ -0,0 +1,104 @@
+package dd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// RequestIDHeader is the HTTP header read and set by RequestIDMiddleware.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds request IDs accepted from incoming headers.
+const maxRequestIDLength = 128
+
+// NewRequestID returns a new random UUIDv7 (RFC 9562). UUIDv7 values start
+// with a millisecond timestamp, so they sort by creation time.
+func NewRequestID() string {
+	var u [16]byte
+	_, _ = rand.Read(u[:])
+
+	ms := uint64(time.Now().UnixMilli())
+	binary.BigEndian.PutUint16(u[4:6], uint16(ms))
+	binary.BigEndian.PutUint32(u[0:4], uint32(ms>>16))
+	u[6] = (u[6] & 0x0f) | 0x70 // version 7
+	u[8] = (u[8] & 0x3f) | 0x80 // RFC 9562 variant
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// EnsureRequestID returns ctx unchanged if it already carries a request ID
+// (see WithRequestID), or a child context with a new ID from NewRequestID.
+//
+// Example:
+//
+//	ctx = dd.EnsureRequestID(ctx)
+//	logger.InfoCtx(ctx, "job started") // includes request_id
+func EnsureRequestID(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if GetRequestID(ctx) != "" {
+		return ctx
+	}
+	return WithRequestID(ctx, NewRequestID())
+}
+
+// RequestIDMiddleware is HTTP middleware that gives every request a request
+// ID. It reuses a valid incoming RequestIDHeader (up to 128 characters of
+// letters, digits and "-_.:") or generates one, stores it in the request
+// context with WithRequestID and echoes it in the response header.
+//
+// Example:
+//
+//	http.ListenAndServe(":8080", dd.RequestIDMiddleware(mux))
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = NewRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), id)))
+	})
+}
+
+// validRequestID reports whether an incoming request ID is safe to log.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// hasRequestID reports whether fields contain a request ID field.
+func hasRequestID(fields []Field) bool {
+	for _, f := range fields {
+		if f.Key == string(ContextKeyRequestID) {
+			return true
+		}
+	}
+	return false
+}
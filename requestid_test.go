@@ -0,0 +1,100 @@
+package dd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var uuidV7Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestNewRequestID(t *testing.T) {
+	a, b := NewRequestID(), NewRequestID()
+	if !uuidV7Pattern.MatchString(a) {
+		t.Errorf("not a UUIDv7: %q", a)
+	}
+	if a == b {
+		t.Error("request IDs should be unique")
+	}
+}
+
+func TestEnsureRequestID(t *testing.T) {
+	ctx := EnsureRequestID(context.Background())
+	id := GetRequestID(ctx)
+	if id == "" {
+		t.Fatal("expected a generated request ID")
+	}
+	if again := EnsureRequestID(ctx); GetRequestID(again) != id {
+		t.Error("an existing request ID should be kept")
+	}
+
+	existing := WithRequestID(context.Background(), "req-1")
+	if EnsureRequestID(existing) != existing {
+		t.Error("contexts with a request ID should be returned unchanged")
+	}
+	if GetRequestID(EnsureRequestID(nil)) == "" {
+		t.Error("nil context should get a request ID")
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var seen string
+	handler := RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = GetRequestID(r.Context())
+	}))
+
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{"generated", "", false},
+		{"reused", "abc-123_x.y:z", true},
+		{"rejected", "bad id\nINJECTED", false},
+		{"too long", strings.Repeat("a", maxRequestIDLength+1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(RequestIDHeader, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if seen == "" || rec.Header().Get(RequestIDHeader) != seen {
+				t.Errorf("request ID %q not echoed: %q", seen, rec.Header().Get(RequestIDHeader))
+			}
+			if (seen == tt.incoming) != tt.keep {
+				t.Errorf("incoming %q, got %q", tt.incoming, seen)
+			}
+		})
+	}
+}
+
+func TestContextPolicyMarkMissingRequestID(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.ContextPolicy = &ContextPolicy{MarkMissingRequestID: true}
+	})
+
+	logger.InfoCtx(context.Background(), "no id")
+	logger.InfoCtx(WithRequestID(context.Background(), "req-1"), "has id")
+	logger.Info("no context")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], RequestIDMissingKey+"=true") || strings.Contains(lines[0], "request_id=") {
+		t.Errorf("missing ID should be marked, not generated: %q", lines[0])
+	}
+	if strings.Contains(lines[1], RequestIDMissingKey) || !strings.Contains(lines[1], "request_id=req-1") {
+		t.Errorf("existing ID should be kept unmarked: %q", lines[1])
+	}
+	if strings.Contains(lines[2], "request_id") {
+		t.Errorf("entries without a context are unchanged: %q", lines[2])
+	}
+}
@@ -0,0 +1,135 @@
+package dd
+
+import (
+	"io"
+	"sync"
+)
+
+// defaultRingBufferSize is used when NewRingBufferWriter is given a
+// non-positive capacity.
+const defaultRingBufferSize = 1000
+
+// RingBufferWriter keeps the most recent N log lines in memory.
+// It is intended for post-mortem debugging: attach it with a low MinLevel
+// and dump its contents on panic or from a debug endpoint, without paying
+// for verbose logging to disk.
+//
+// Example:
+//
+//	ring := dd.NewRingBufferWriter(500, dd.LevelDebug)
+//	cfg := dd.DefaultConfig() // INFO level
+//	cfg.Outputs = []io.Writer{ring}
+//	logger, _ := dd.New(cfg)
+//	logger.Debug("kept in memory only")
+//	defer func() {
+//	    if r := recover(); r != nil {
+//	        ring.Dump(os.Stderr)
+//	        panic(r)
+//	    }
+//	}()
+type RingBufferWriter struct {
+	mu       sync.Mutex
+	lines    []string
+	next     int  // index of the slot to write next
+	full     bool // whether the buffer has wrapped
+	bytes    int64
+	minLevel LogLevel
+}
+
+// NewRingBufferWriter creates a RingBufferWriter holding up to n lines.
+// The optional minLevel sets the writer's own level (default: LevelDebug),
+// which overrides the logger level for this writer. See MinLevelWriter.
+func NewRingBufferWriter(n int, minLevel ...LogLevel) *RingBufferWriter {
+	if n <= 0 {
+		n = defaultRingBufferSize
+	}
+	rb := &RingBufferWriter{lines: make([]string, n)}
+	if len(minLevel) > 0 && minLevel[0].IsValid() {
+		rb.minLevel = minLevel[0]
+	}
+	return rb
+}
+
+// MinLevel implements MinLevelWriter.
+func (rb *RingBufferWriter) MinLevel() LogLevel {
+	return rb.minLevel
+}
+
+// Write stores p as one line, evicting the oldest line when full.
+// A single trailing newline is stripped.
+func (rb *RingBufferWriter) Write(p []byte) (int, error) {
+	line := p
+	if len(line) > 0 && line[len(line)-1] == '\n' {
+		line = line[:len(line)-1]
+	}
+	s := string(line)
+
+	rb.mu.Lock()
+	rb.bytes += int64(len(s) - len(rb.lines[rb.next]))
+	rb.lines[rb.next] = s
+	rb.next++
+	if rb.next == len(rb.lines) {
+		rb.next = 0
+		rb.full = true
+	}
+	rb.mu.Unlock()
+
+	return len(p), nil
+}
+
+// Snapshot returns the buffered lines, oldest first.
+func (rb *RingBufferWriter) Snapshot() []string {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	if !rb.full {
+		out := make([]string, rb.next)
+		copy(out, rb.lines[:rb.next])
+		return out
+	}
+
+	out := make([]string, 0, len(rb.lines))
+	out = append(out, rb.lines[rb.next:]...)
+	out = append(out, rb.lines[:rb.next]...)
+	return out
+}
+
+// Dump writes the buffered lines to w, oldest first, one per line.
+func (rb *RingBufferWriter) Dump(w io.Writer) error {
+	if w == nil {
+		return ErrNilWriter
+	}
+	for _, line := range rb.Snapshot() {
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Len returns the number of buffered lines.
+func (rb *RingBufferWriter) Len() int {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	if rb.full {
+		return len(rb.lines)
+	}
+	return rb.next
+}
+
+// Reset discards all buffered lines.
+func (rb *RingBufferWriter) Reset() {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	clear(rb.lines)
+	rb.next = 0
+	rb.full = false
+	rb.bytes = 0
+}
+
+// MemoryUsage implements MemoryUser: the size of the buffered lines.
+func (rb *RingBufferWriter) MemoryUsage() int64 {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	return rb.bytes
+}
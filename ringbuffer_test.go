@@ -0,0 +1,117 @@
+package dd
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRingBufferWriter(t *testing.T) {
+	t.Run("keeps last N lines", func(t *testing.T) {
+		rb := NewRingBufferWriter(3)
+		for i := 1; i <= 5; i++ {
+			_, _ = rb.Write([]byte("line" + strconv.Itoa(i) + "\n"))
+		}
+
+		got := rb.Snapshot()
+		want := []string{"line3", "line4", "line5"}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("Snapshot() = %v, want %v", got, want)
+		}
+		if rb.Len() != 3 {
+			t.Errorf("Len() = %d, want 3", rb.Len())
+		}
+	})
+
+	t.Run("partial buffer", func(t *testing.T) {
+		rb := NewRingBufferWriter(10)
+		_, _ = rb.Write([]byte("a\n"))
+		_, _ = rb.Write([]byte("b\n"))
+
+		var buf bytes.Buffer
+		if err := rb.Dump(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != "a\nb\n" {
+			t.Errorf("Dump() = %q", buf.String())
+		}
+
+		rb.Reset()
+		if rb.Len() != 0 || len(rb.Snapshot()) != 0 {
+			t.Error("Reset() did not clear the buffer")
+		}
+	})
+
+	t.Run("default size", func(t *testing.T) {
+		rb := NewRingBufferWriter(0)
+		if len(rb.lines) != defaultRingBufferSize {
+			t.Errorf("capacity = %d, want %d", len(rb.lines), defaultRingBufferSize)
+		}
+	})
+}
+
+func TestRingBufferCapturesBelowLoggerLevel(t *testing.T) {
+	var main bytes.Buffer
+	ring := NewRingBufferWriter(10)
+
+	cfg := DefaultConfig()
+	cfg.Level = LevelInfo
+	cfg.Outputs = []io.Writer{&main, ring}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	if !logger.IsDebugEnabled() {
+		t.Error("IsDebugEnabled() should be true while a DEBUG MinLevelWriter is attached")
+	}
+
+	logger.Debug("debug history")
+	logger.Info("normal entry")
+
+	if strings.Contains(main.String(), "debug history") {
+		t.Error("main output should not receive DEBUG entries")
+	}
+	if !strings.Contains(main.String(), "normal entry") {
+		t.Error("main output missing INFO entry")
+	}
+
+	snap := ring.Snapshot()
+	if len(snap) != 2 || !strings.Contains(snap[0], "debug history") {
+		t.Errorf("ring buffer = %v", snap)
+	}
+}
+
+func TestLevelFilterWriter(t *testing.T) {
+	if _, err := NewLevelFilterWriter(nil, LevelError); err == nil {
+		t.Error("expected error for nil writer")
+	}
+
+	var errorsOnly, all bytes.Buffer
+	filtered, err := NewLevelFilterWriter(&errorsOnly, LevelError)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Outputs = []io.Writer{&all, filtered}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Debug("suppressed")
+	logger.Info("info entry")
+	logger.Error("error entry")
+
+	if strings.Contains(errorsOnly.String(), "info entry") || !strings.Contains(errorsOnly.String(), "error entry") {
+		t.Errorf("filtered output = %q", errorsOnly.String())
+	}
+	if !strings.Contains(all.String(), "info entry") || strings.Contains(all.String(), "suppressed") {
+		t.Errorf("main output = %q", all.String())
+	}
+}
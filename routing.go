@@ -0,0 +1,258 @@
+package dd
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultRouteName names the file for entries without the routing field.
+	DefaultRouteName = "default"
+
+	// defaultMaxOpenRoutes is the RoutingWriter open file cap used when
+	// MaxOpenFiles is not set.
+	defaultMaxOpenRoutes = 64
+
+	// maxRouteNameLength bounds route names derived from field values.
+	maxRouteNameLength = 64
+)
+
+// RoutingWriterConfig configures a RoutingWriter.
+type RoutingWriterConfig struct {
+	// Field is the field key whose value selects the file, e.g. "component"
+	// or TenantFieldKey. Required.
+	Field string
+
+	// Dir is the directory holding the routed files. Required.
+	Dir string
+
+	// Extension is appended to route names (default ".log").
+	Extension string
+
+	// DefaultRoute names the file for entries without Field and for plain
+	// Write calls (default DefaultRouteName).
+	DefaultRoute string
+
+	// File holds the rotation settings shared by every routed file.
+	File FileWriterConfig
+
+	// MaxOpenFiles caps the number of open files (default 64). When a new
+	// route would exceed it, the least recently used file is closed; it is
+	// reopened on its next entry.
+	MaxOpenFiles int
+}
+
+// RoutingWriter sends each entry to a file chosen by the value of a field,
+// e.g. logs/payments.log and logs/auth.log for component=payments and
+// component=auth. Files are created lazily and share one set of rotation
+// settings, so one logger can replace a logger per component or tenant.
+//
+// Field values are sanitized into file names: characters other than
+// letters, digits, "-", "_" and "." are replaced with "_", as is "..".
+//
+// Example:
+//
+//	router, _ := dd.NewRoutingWriter(dd.RoutingWriterConfig{
+//	    Field: "component",
+//	    Dir:   "logs",
+//	    File:  dd.FileWriterConfig{MaxSizeMB: 50, MaxBackups: 5},
+//	})
+//	cfg.Output = router
+//	logger.InfoWith("charged", dd.String("component", "payments")) // logs/payments.log
+type RoutingWriter struct {
+	field        string
+	dir          string
+	ext          string
+	defaultRoute string
+	fileConfig   FileWriterConfig
+	maxOpen      int
+
+	mu     sync.Mutex
+	routes map[string]*list.Element // route name -> element holding *routedFile
+	lru    *list.List               // most recently used at the front
+	closed bool
+}
+
+// routedFile is an open file of a RoutingWriter.
+type routedFile struct {
+	name   string
+	writer *FileWriter
+}
+
+// NewRoutingWriter creates a RoutingWriter. No files are opened until the
+// first entry for a route arrives.
+func NewRoutingWriter(config RoutingWriterConfig) (*RoutingWriter, error) {
+	if config.Field == "" {
+		return nil, fmt.Errorf("%w: RoutingWriter requires a Field", ErrConfigValidation)
+	}
+	if config.Dir == "" {
+		return nil, fmt.Errorf("%w: RoutingWriter requires a Dir", ErrConfigValidation)
+	}
+	if config.MaxOpenFiles < 0 {
+		return nil, fmt.Errorf("%w: MaxOpenFiles cannot be negative", ErrConfigValidation)
+	}
+	if err := validateFileWriterConfig(&config.File); err != nil {
+		return nil, err
+	}
+
+	rw := &RoutingWriter{
+		field:        config.Field,
+		dir:          config.Dir,
+		ext:          config.Extension,
+		defaultRoute: sanitizeRouteName(config.DefaultRoute),
+		fileConfig:   config.File,
+		maxOpen:      config.MaxOpenFiles,
+		routes:       make(map[string]*list.Element),
+		lru:          list.New(),
+	}
+	if rw.ext == "" {
+		rw.ext = ".log"
+	}
+	if rw.defaultRoute == "" {
+		rw.defaultRoute = DefaultRouteName
+	}
+	if rw.maxOpen == 0 {
+		rw.maxOpen = defaultMaxOpenRoutes
+	}
+	return rw, nil
+}
+
+// Write writes p to the default route.
+func (rw *RoutingWriter) Write(p []byte) (int, error) {
+	return rw.writeRoute(rw.defaultRoute, LevelInfo, false, p)
+}
+
+// WriteRecord implements RecordWriter.
+func (rw *RoutingWriter) WriteRecord(rec *Record, p []byte) (int, error) {
+	return rw.writeRoute(rw.routeFor(rec.Fields), rec.Level, true, p)
+}
+
+// routeFor returns the route name selected by fields.
+func (rw *RoutingWriter) routeFor(fields []Field) string {
+	for i := len(fields) - 1; i >= 0; i-- {
+		if fields[i].Key != rw.field {
+			continue
+		}
+		var value string
+		switch v := fields[i].Value.(type) {
+		case string:
+			value = v
+		case fmt.Stringer:
+			value = v.String()
+		default:
+			value = fmt.Sprint(v)
+		}
+		if name := sanitizeRouteName(value); name != "" {
+			return name
+		}
+		break
+	}
+	return rw.defaultRoute
+}
+
+// writeRoute writes p to the file for route, opening it if needed. The
+// lock is held for the write so an evicted file is never written after
+// it is closed.
+func (rw *RoutingWriter) writeRoute(route string, level LogLevel, leveled bool, p []byte) (int, error) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if rw.closed {
+		return 0, os.ErrClosed
+	}
+	fw, err := rw.fileLocked(route)
+	if err != nil {
+		return 0, err
+	}
+	if leveled {
+		return fw.WriteLevel(level, p)
+	}
+	return fw.Write(p)
+}
+
+// fileLocked returns the open file for route, opening it and closing the
+// least recently used file if the cap is reached. Caller holds mu.
+func (rw *RoutingWriter) fileLocked(route string) (*FileWriter, error) {
+	if elem, ok := rw.routes[route]; ok {
+		rw.lru.MoveToFront(elem)
+		return elem.Value.(*routedFile).writer, nil
+	}
+
+	for rw.lru.Len() >= rw.maxOpen {
+		oldest := rw.lru.Back()
+		rf := rw.lru.Remove(oldest).(*routedFile)
+		delete(rw.routes, rf.name)
+		if err := rf.writer.Close(); err != nil {
+			reportInternal(nil, ComponentWriter, err, "close routed file %s: %v", rf.name, err)
+		}
+	}
+
+	fw, err := NewFileWriter(filepath.Join(rw.dir, route+rw.ext), rw.fileConfig)
+	if err != nil {
+		return nil, err
+	}
+	rw.routes[route] = rw.lru.PushFront(&routedFile{name: route, writer: fw})
+	return fw, nil
+}
+
+// Routes returns the names of the routes with an open file, most recently
+// used first.
+func (rw *RoutingWriter) Routes() []string {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	names := make([]string, 0, rw.lru.Len())
+	for e := rw.lru.Front(); e != nil; e = e.Next() {
+		names = append(names, e.Value.(*routedFile).name)
+	}
+	return names
+}
+
+// Close closes every open file. Later writes return os.ErrClosed.
+func (rw *RoutingWriter) Close() error {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if rw.closed {
+		return nil
+	}
+	rw.closed = true
+
+	var errs []error
+	for e := rw.lru.Front(); e != nil; e = e.Next() {
+		if err := e.Value.(*routedFile).writer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	rw.lru.Init()
+	clear(rw.routes)
+	return errors.Join(errs...)
+}
+
+// sanitizeRouteName turns a field value into a safe file name. It returns
+// "" for values that leave nothing usable.
+func sanitizeRouteName(value string) string {
+	if len(value) > maxRouteNameLength {
+		value = value[:maxRouteNameLength]
+	}
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, value)
+	name = strings.ReplaceAll(name, "..", "__")
+	if strings.Trim(name, "._") == "" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,121 @@
+package dd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRoutingWriter(t *testing.T) {
+	dir := t.TempDir()
+	router, err := NewRoutingWriter(RoutingWriterConfig{Field: "component", Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := DefaultConfig()
+	cfg.Output = router
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.InfoWith("charged", String("component", "payments"))
+	logger.InfoWith("login", String("component", "auth"))
+	logger.WithFields(String("component", "payments")).Info("refunded")
+	logger.Info("no component")
+	logger.InfoWith("escape", String("component", "../../etc/passwd"))
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(name string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	if got := read("payments.log"); !strings.Contains(got, "charged") || !strings.Contains(got, "refunded") || strings.Contains(got, "login") {
+		t.Errorf("payments.log = %q", got)
+	}
+	if got := read("auth.log"); !strings.Contains(got, "login") {
+		t.Errorf("auth.log = %q", got)
+	}
+	if got := read(DefaultRouteName + ".log"); !strings.Contains(got, "no component") {
+		t.Errorf("default.log = %q", got)
+	}
+	if got := read("______etc_passwd.log"); !strings.Contains(got, "escape") {
+		t.Errorf("sanitized route = %q", got)
+	}
+	if _, err := router.Write([]byte("x\n")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("write after Close = %v, want os.ErrClosed", err)
+	}
+}
+
+func TestRoutingWriterMaxOpenFiles(t *testing.T) {
+	dir := t.TempDir()
+	router, err := NewRoutingWriter(RoutingWriterConfig{Field: "tenant", Dir: dir, MaxOpenFiles: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer router.Close()
+
+	write := func(tenant, line string) {
+		t.Helper()
+		rec := &Record{Level: LevelInfo, Fields: []Field{String("tenant", tenant)}}
+		if _, err := router.WriteRecord(rec, []byte(line+"\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a", "a1")
+	write("b", "b1")
+	write("c", "c1") // evicts a
+	if got := router.Routes(); !reflect.DeepEqual(got, []string{"c", "b"}) {
+		t.Errorf("Routes() = %v", got)
+	}
+	write("a", "a2") // reopens a, evicts b
+	if got := router.Routes(); !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Errorf("Routes() = %v", got)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "a.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "a1\na2\n" {
+		t.Errorf("reopened file should be appended to, got %q", data)
+	}
+}
+
+func TestRoutingWriterConfigValidation(t *testing.T) {
+	tests := []RoutingWriterConfig{
+		{Dir: t.TempDir()},
+		{Field: "component"},
+		{Field: "component", Dir: t.TempDir(), MaxOpenFiles: -1},
+	}
+	for _, config := range tests {
+		if _, err := NewRoutingWriter(config); !errors.Is(err, ErrConfigValidation) {
+			t.Errorf("NewRoutingWriter(%+v) error = %v, want ErrConfigValidation", config, err)
+		}
+	}
+}
+
+func TestSanitizeRouteName(t *testing.T) {
+	tests := map[string]string{
+		"payments":               "payments",
+		"Team A/B":               "Team_A_B",
+		"..":                     "",
+		"":                       "",
+		"café":                   "caf_",
+		strings.Repeat("x", 100): strings.Repeat("x", maxRouteNameLength),
+	}
+	for in, want := range tests {
+		if got := sanitizeRouteName(in); got != want {
+			t.Errorf("sanitizeRouteName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
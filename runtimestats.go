@@ -0,0 +1,128 @@
+package dd
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// RuntimeStatsKey is the field key of RuntimeStats.
+const RuntimeStatsKey = "runtime"
+
+// RuntimeStatsConfig attaches Go runtime metrics to entries so memory and
+// scheduler context is captured alongside errors.
+//
+// Example:
+//
+//	cfg.RuntimeStats = &dd.RuntimeStatsConfig{
+//	    Level:    dd.LevelWarn,
+//	    Interval: time.Minute,
+//	}
+type RuntimeStatsConfig struct {
+	// Level is the lowest level whose entries get a RuntimeStats field.
+	Level LogLevel
+	// Interval, if positive, makes the logger write an INFO entry with
+	// the runtime stats at this interval, whatever Level is.
+	Interval time.Duration
+}
+
+// Clone returns a copy of the config.
+func (c *RuntimeStatsConfig) Clone() *RuntimeStatsConfig {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	return &clone
+}
+
+// validate checks the level and interval.
+func (c *RuntimeStatsConfig) validate() error {
+	if !c.Level.IsValid() {
+		return fmt.Errorf("%w: RuntimeStats Level %d", ErrInvalidLevel, c.Level)
+	}
+	if c.Interval < 0 {
+		return fmt.Errorf("%w: RuntimeStats Interval must not be negative", ErrConfigValidation)
+	}
+	return nil
+}
+
+// RuntimeStats returns a field with a snapshot of Go runtime metrics:
+//
+//	goroutines     number of goroutines
+//	heap_inuse     bytes in in-use heap spans
+//	heap_objects   number of allocated heap objects
+//	num_gc         completed GC cycles
+//	gc_pause       duration of the most recent GC pause
+//	gc_pause_total total GC pause time since the program started
+//
+// The snapshot is taken when the entry is logged, not when RuntimeStats is
+// called. Reading memory statistics briefly stops the world, so prefer
+// RuntimeStatsConfig or occasional use over adding it to every entry.
+//
+// Example:
+//
+//	logger.ErrorWith("request failed", dd.Err(err), dd.RuntimeStats())
+func RuntimeStats() Field {
+	return Object(RuntimeStatsKey, runtimeStats{})
+}
+
+// runtimeStats reads the runtime metrics when marshaled.
+type runtimeStats struct{}
+
+// MarshalLogObject implements LogObjectMarshaler.
+func (runtimeStats) MarshalLogObject(enc FieldEncoder) error {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	var lastPause time.Duration
+	if ms.NumGC > 0 {
+		lastPause = time.Duration(ms.PauseNs[(ms.NumGC+255)%256])
+	}
+	enc.AddInt("goroutines", runtime.NumGoroutine())
+	enc.AddUint64("heap_inuse", ms.HeapInuse)
+	enc.AddUint64("heap_objects", ms.HeapObjects)
+	enc.AddUint64("num_gc", uint64(ms.NumGC))
+	enc.AddDuration("gc_pause", lastPause)
+	enc.AddDuration("gc_pause_total", time.Duration(ms.PauseTotalNs))
+	return nil
+}
+
+// addRuntimeStats appends a RuntimeStats field to entries at or above the
+// configured level that do not have one already. Entries passed on by a
+// Tee already carry it.
+func (l *Logger) addRuntimeStats(level LogLevel, entry *logEntry) {
+	if l.runtimeStats == nil || level < l.runtimeStats.Level || entry.tee != nil {
+		return
+	}
+	for _, field := range entry.fields {
+		if field.Key == RuntimeStatsKey {
+			return
+		}
+	}
+	entry.fields = append(entry.fields[:len(entry.fields):len(entry.fields)], resolvedRuntimeStats())
+}
+
+// resolvedRuntimeStats returns RuntimeStats already marshaled, for fields
+// added after field processing.
+func resolvedRuntimeStats() Field {
+	return Field{Key: RuntimeStatsKey, Value: marshalObject(runtimeStats{}, 0)}
+}
+
+// runRuntimeStats writes an INFO entry with the runtime stats every
+// interval until the logger closes.
+func (l *Logger) runRuntimeStats(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if l.closed.Load() || !l.IsLevelEnabled(LevelInfo) {
+			continue
+		}
+		l.logCore(LevelInfo, logEntry{msg: "runtime stats", fields: []Field{resolvedRuntimeStats()}})
+	}
+}
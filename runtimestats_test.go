@@ -0,0 +1,106 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRuntimeStatsField(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := JSONConfig()
+	cfg.Output = &buf
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.InfoWith("snapshot", RuntimeStats())
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	fields, _ := entry["fields"].(map[string]any)
+	stats, ok := fields[RuntimeStatsKey].(map[string]any)
+	if !ok {
+		t.Fatalf("missing runtime field: %s", buf.String())
+	}
+	for _, key := range []string{"goroutines", "heap_inuse", "heap_objects", "num_gc", "gc_pause", "gc_pause_total"} {
+		if _, ok := stats[key]; !ok {
+			t.Errorf("runtime stats missing %q: %v", key, stats)
+		}
+	}
+	if n, _ := stats["goroutines"].(float64); n < 1 {
+		t.Errorf("goroutines = %v", stats["goroutines"])
+	}
+}
+
+func TestRuntimeStatsConfigLevel(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.RuntimeStats = &RuntimeStatsConfig{Level: LevelWarn}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Info("fine")
+	logger.Warn("slow")
+	logger.ErrorWith("failed", RuntimeStats())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %q", buf.String())
+	}
+	if strings.Contains(lines[0], "runtime=") {
+		t.Errorf("INFO entry should not carry runtime stats: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], "runtime=") || !strings.Contains(lines[1], "goroutines") {
+		t.Errorf("WARN entry should carry runtime stats: %s", lines[1])
+	}
+	if strings.Count(lines[2], "runtime=") != 1 {
+		t.Errorf("an explicit RuntimeStats field should not be duplicated: %s", lines[2])
+	}
+}
+
+func TestRuntimeStatsInterval(t *testing.T) {
+	buf := &syncBuffer{}
+	cfg := DefaultConfig()
+	cfg.Output = buf
+	cfg.RuntimeStats = &RuntimeStatsConfig{Level: LevelFatal, Interval: 10 * time.Millisecond}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(buf.String(), "runtime stats") {
+		if time.Now().After(deadline) {
+			t.Fatalf("no periodic entry written, output: %q", buf.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !strings.Contains(buf.String(), "heap_inuse") {
+		t.Errorf("periodic entry should carry the stats: %q", buf.String())
+	}
+}
+
+func TestRuntimeStatsConfigValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RuntimeStats = &RuntimeStatsConfig{Level: LogLevel(42)}
+	if _, err := New(cfg); !errors.Is(err, ErrInvalidLevel) {
+		t.Errorf("invalid level: %v", err)
+	}
+	cfg.RuntimeStats = &RuntimeStatsConfig{Level: LevelWarn, Interval: -time.Second}
+	if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("negative interval: %v", err)
+	}
+}
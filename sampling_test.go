@@ -0,0 +1,111 @@
+package dd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSamplingPerLevel(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.Level = LevelDebug
+		cfg.Sampling = &SamplingConfig{
+			Enabled:    true,
+			Thereafter: 2,
+			Levels: map[LogLevel]LevelSampling{
+				LevelDebug: {Thereafter: 10},
+				LevelError: {KeepAll: true},
+			},
+		}
+	})
+
+	for range 20 {
+		logger.Debug("debug")
+		logger.Info("info")
+		logger.Error("error")
+	}
+
+	out := buf.String()
+	if n := strings.Count(out, "debug"); n != 2 {
+		t.Errorf("debug kept %d times, want 2", n)
+	}
+	if n := strings.Count(out, "info"); n != 10 {
+		t.Errorf("info kept %d times, want 10", n)
+	}
+	if n := strings.Count(out, "error"); n != 20 {
+		t.Errorf("error kept %d times, want 20", n)
+	}
+}
+
+func TestSamplingDeterministic(t *testing.T) {
+	sampling := &SamplingConfig{Enabled: true, Deterministic: true, Rate: 0.5, Seed: 7}
+	first, firstBuf := newTestLogger(t, func(cfg *Config) { cfg.Sampling = sampling })
+	second, secondBuf := newTestLogger(t, func(cfg *Config) { cfg.Sampling = sampling })
+
+	kept := 0
+	for i := range 200 {
+		ctx := WithTraceID(context.Background(), fmt.Sprintf("trace-%d", i))
+		first.InfoCtx(ctx, "a")
+		first.InfoCtx(ctx, "b")
+		second.InfoCtx(ctx, "c")
+
+		a := strings.Count(firstBuf.String(), "\n")
+		firstBuf.Reset()
+		c := strings.Count(secondBuf.String(), "\n")
+		secondBuf.Reset()
+
+		if a != 0 && a != 2 {
+			t.Fatalf("trace %d: entries of one trace must share a decision, kept %d of 2", i, a)
+		}
+		if (a == 2) != (c == 1) {
+			t.Fatalf("trace %d: loggers with the same seed disagree", i)
+		}
+		if c == 1 {
+			kept++
+		}
+	}
+	if kept < 60 || kept > 140 {
+		t.Errorf("kept %d of 200 traces at rate 0.5", kept)
+	}
+}
+
+func TestSamplingDeterministicSeedAndFallback(t *testing.T) {
+	if sampleHash(1, "abc") == sampleHash(2, "abc") {
+		t.Error("seed should change the hash")
+	}
+	if sampleHash(1, "abc") != sampleHash(1, "abc") {
+		t.Error("hash must be stable")
+	}
+
+	// Without a trace ID, entries fall back to the counter
+	logger, buf := newTestLogger(t, func(cfg *Config) { cfg.Sampling = &SamplingConfig{Enabled: true, Deterministic: true, Thereafter: 4} })
+	for range 8 {
+		logger.Info("untraced")
+	}
+	if n := strings.Count(buf.String(), "untraced"); n != 2 {
+		t.Errorf("kept %d untraced entries, want 2", n)
+	}
+}
+
+func TestSamplingConfigValidation(t *testing.T) {
+	for name, sampling := range map[string]*SamplingConfig{
+		"rate":       {Enabled: true, Rate: 1.5},
+		"level rate": {Enabled: true, Levels: map[LogLevel]LevelSampling{LevelInfo: {Rate: -1}}},
+		"level key":  {Enabled: true, Levels: map[LogLevel]LevelSampling{LogLevel(42): {}}},
+	} {
+		cfg := DefaultConfig()
+		cfg.Sampling = sampling
+		if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) && !errors.Is(err, ErrInvalidLevel) {
+			t.Errorf("%s: expected a validation error, got %v", name, err)
+		}
+	}
+
+	original := &SamplingConfig{Enabled: true, Levels: map[LogLevel]LevelSampling{LevelDebug: {Thereafter: 5}}}
+	clone := original.Clone()
+	clone.Levels[LevelDebug] = LevelSampling{KeepAll: true}
+	if original.Levels[LevelDebug].KeepAll {
+		t.Error("Clone should copy Levels")
+	}
+}
@@ -0,0 +1,100 @@
+package dd
+
+import "sync/atomic"
+
+// Keys of the fields SamplingConfig.Annotate adds to kept entries.
+const (
+	// SampledKey is true on entries kept by sampling.
+	SampledKey = "sampled"
+	// SampleDropRatioKey is the fraction of the entry's level dropped by
+	// sampling so far, in [0, 1]. Dividing a kept count by
+	// 1 - ratio estimates the count before sampling.
+	SampleDropRatioKey = "sample_drop_ratio"
+)
+
+// SamplingStats reports the entries kept and dropped by sampling since the
+// logger was created. Entries logged while sampling is disabled are not
+// counted.
+type SamplingStats struct {
+	Kept           int64              // Total entries kept
+	Dropped        int64              // Total entries dropped
+	KeptByLevel    map[LogLevel]int64 // Kept entries per level (levels with entries only)
+	DroppedByLevel map[LogLevel]int64 // Dropped entries per level (levels with drops only)
+}
+
+// DropRatio returns the fraction of sampled entries that were dropped,
+// or 0 if none were sampled.
+func (s SamplingStats) DropRatio() float64 {
+	return dropRatio(s.Kept, s.Dropped)
+}
+
+// samplingCounters counts sampling decisions per level.
+type samplingCounters struct {
+	kept    [LevelFatal + 1]atomic.Int64
+	dropped [LevelFatal + 1]atomic.Int64
+}
+
+// SamplingStats returns the number of entries kept and dropped by sampling
+// since the logger was created. Counts are kept across SetSampling calls.
+//
+// Example:
+//
+//	stats := logger.SamplingStats()
+//	metrics.Gauge("log.sample_drop_ratio", stats.DropRatio())
+func (l *Logger) SamplingStats() SamplingStats {
+	if l == nil {
+		return SamplingStats{KeptByLevel: make(map[LogLevel]int64), DroppedByLevel: make(map[LogLevel]int64)}
+	}
+	stats := SamplingStats{
+		KeptByLevel:    make(map[LogLevel]int64),
+		DroppedByLevel: make(map[LogLevel]int64),
+	}
+	for level := LevelDebug; level <= LevelFatal; level++ {
+		if n := l.samplingCounts.kept[level].Load(); n > 0 {
+			stats.KeptByLevel[level] = n
+			stats.Kept += n
+		}
+		if n := l.samplingCounts.dropped[level].Load(); n > 0 {
+			stats.DroppedByLevel[level] = n
+			stats.Dropped += n
+		}
+	}
+	return stats
+}
+
+// countSample records a sampling decision for level.
+func (l *Logger) countSample(level LogLevel, kept bool) {
+	if level < LevelDebug || level > LevelFatal {
+		return
+	}
+	if kept {
+		l.samplingCounts.kept[level].Add(1)
+	} else {
+		l.samplingCounts.dropped[level].Add(1)
+	}
+}
+
+// addSamplingFields adds SampledKey and SampleDropRatioKey to an entry
+// when sampling is enabled with Annotate.
+func (l *Logger) addSamplingFields(level LogLevel, entry *logEntry) {
+	v := l.sampling.Load()
+	if v == nil || level < LevelDebug || level > LevelFatal {
+		return
+	}
+	config := v.(*samplingState).config
+	if config == nil || !config.Enabled || !config.Annotate {
+		return
+	}
+	ratio := dropRatio(l.samplingCounts.kept[level].Load(), l.samplingCounts.dropped[level].Load())
+	entry.fields = append(entry.fields[:len(entry.fields):len(entry.fields)],
+		Field{Key: SampledKey, Value: true},
+		Field{Key: SampleDropRatioKey, Value: ratio},
+	)
+}
+
+func dropRatio(kept, dropped int64) float64 {
+	if total := kept + dropped; total > 0 {
+		return float64(dropped) / float64(total)
+	}
+	return 0
+}
@@ -0,0 +1,66 @@
+package dd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSamplingStats(t *testing.T) {
+	logger, _ := newTestLogger(t, func(cfg *Config) {
+		cfg.Sampling = &SamplingConfig{
+			Enabled:    true,
+			Thereafter: 4,
+			Levels:     map[LogLevel]LevelSampling{LevelError: {KeepAll: true}},
+		}
+	})
+
+	for range 8 {
+		logger.Info("info")
+		logger.Error("error")
+	}
+
+	stats := logger.SamplingStats()
+	if stats.KeptByLevel[LevelInfo] != 2 || stats.DroppedByLevel[LevelInfo] != 6 {
+		t.Errorf("INFO kept/dropped = %d/%d, want 2/6", stats.KeptByLevel[LevelInfo], stats.DroppedByLevel[LevelInfo])
+	}
+	if stats.KeptByLevel[LevelError] != 8 || stats.DroppedByLevel[LevelError] != 0 {
+		t.Errorf("ERROR kept/dropped = %d/%d, want 8/0", stats.KeptByLevel[LevelError], stats.DroppedByLevel[LevelError])
+	}
+	if stats.Kept != 10 || stats.Dropped != 6 {
+		t.Errorf("totals = %d/%d, want 10/6", stats.Kept, stats.Dropped)
+	}
+	if got := stats.DropRatio(); got != 6.0/16 {
+		t.Errorf("DropRatio = %v", got)
+	}
+}
+
+func TestSamplingStatsDisabled(t *testing.T) {
+	logger, _ := newTestLogger(t, nil)
+	logger.Info("info")
+	if stats := logger.SamplingStats(); stats.Kept != 0 || stats.Dropped != 0 {
+		t.Errorf("stats without sampling = %+v", stats)
+	}
+}
+
+func TestSamplingAnnotate(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.Sampling = &SamplingConfig{
+			Enabled:    true,
+			Thereafter: 2,
+			Annotate:   true,
+		}
+	})
+
+	for range 4 {
+		logger.Info("info")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+	last := lines[len(lines)-1]
+	if !strings.Contains(last, "sampled=true") || !strings.Contains(last, "sample_drop_ratio=0.5") {
+		t.Errorf("last entry = %q", last)
+	}
+}
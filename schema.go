@@ -0,0 +1,342 @@
+package dd
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// SchemaErrorKey holds the schema violations of an entry written with
+// SchemaEnforceError.
+const SchemaErrorKey = "schema_error"
+
+// SchemaType is the expected type of a field in a LogSchema.
+type SchemaType string
+
+const (
+	SchemaAny      SchemaType = "any"      // any value
+	SchemaString   SchemaType = "string"   // strings, errors and Stringer fields
+	SchemaInt      SchemaType = "integer"  // signed and unsigned integers
+	SchemaNumber   SchemaType = "number"   // integers and floats
+	SchemaBool     SchemaType = "boolean"  // booleans
+	SchemaTime     SchemaType = "time"     // time.Time values
+	SchemaDuration SchemaType = "duration" // time.Duration values
+	SchemaObject   SchemaType = "object"   // maps, structs and Object fields
+	SchemaArray    SchemaType = "array"    // slices and arrays
+)
+
+// isValid reports whether t is a known type.
+func (t SchemaType) isValid() bool {
+	switch t {
+	case SchemaAny, SchemaString, SchemaInt, SchemaNumber, SchemaBool,
+		SchemaTime, SchemaDuration, SchemaObject, SchemaArray:
+		return true
+	}
+	return false
+}
+
+// SchemaEnforcement selects what happens to an entry that violates its
+// LogSchema. Violations are always counted in SchemaStats and reported
+// through the internal diagnostics channel.
+type SchemaEnforcement int
+
+const (
+	// SchemaEnforceWarn writes the entry unchanged (default).
+	SchemaEnforceWarn SchemaEnforcement = iota
+	// SchemaEnforceDrop discards the entry. FATAL entries are written
+	// as with SchemaEnforceError.
+	SchemaEnforceDrop
+	// SchemaEnforceError writes the entry with a SchemaErrorKey field
+	// listing the violations, so consumers can set it aside.
+	SchemaEnforceError
+)
+
+// LogSchema describes the fields entries may carry, so field types do not
+// drift silently between releases. Fields not named in Fields are allowed.
+// The fields of an entry, including global fields, are checked after
+// BeforeLog hooks; fields dd adds afterwards (fingerprints, runtime
+// stats, truncation markers) are not.
+//
+// Example:
+//
+//	cfg.Schema = &dd.LogSchema{
+//	    Fields: map[string]dd.SchemaType{
+//	        "user_id":     dd.SchemaString,
+//	        "status":      dd.SchemaInt,
+//	        "duration_ms": dd.SchemaNumber,
+//	    },
+//	    Required:    map[dd.LogLevel][]string{dd.LevelError: {"error"}},
+//	    Enforcement: dd.SchemaEnforceError,
+//	}
+type LogSchema struct {
+	// Fields maps field keys to their expected type.
+	Fields map[string]SchemaType
+
+	// Required lists the fields entries at a level and above must carry.
+	Required map[LogLevel][]string
+
+	// Enforcement selects what happens to entries that violate the schema.
+	Enforcement SchemaEnforcement
+}
+
+// Clone returns a deep copy of the schema.
+func (s *LogSchema) Clone() *LogSchema {
+	if s == nil {
+		return nil
+	}
+	clone := *s
+	clone.Fields = maps.Clone(s.Fields)
+	if s.Required != nil {
+		clone.Required = make(map[LogLevel][]string, len(s.Required))
+		for level, keys := range s.Required {
+			clone.Required[level] = slices.Clone(keys)
+		}
+	}
+	return &clone
+}
+
+// validate checks the types, levels and enforcement of the schema.
+func (s *LogSchema) validate() error {
+	for key, t := range s.Fields {
+		if !t.isValid() {
+			return fmt.Errorf("%w: Schema field %q has unknown type %q", ErrConfigValidation, key, t)
+		}
+	}
+	for level := range s.Required {
+		if level < LevelDebug || level > LevelFatal {
+			return fmt.Errorf("%w: Schema.Required level %d", ErrInvalidLevel, level)
+		}
+	}
+	if s.Enforcement < SchemaEnforceWarn || s.Enforcement > SchemaEnforceError {
+		return fmt.Errorf("%w: invalid Schema.Enforcement %d", ErrConfigValidation, s.Enforcement)
+	}
+	return nil
+}
+
+// requiredAt returns the fields required at level, including those of
+// lower levels.
+func (s *LogSchema) requiredAt(level LogLevel) []string {
+	var keys []string
+	for l := LevelDebug; l <= level; l++ {
+		for _, key := range s.Required[l] {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// Check returns the schema violations of an entry at level with fields,
+// or nil if it conforms.
+func (s *LogSchema) Check(level LogLevel, fields []Field) []string {
+	if s == nil {
+		return nil
+	}
+	var violations []string
+	for _, key := range s.requiredAt(level) {
+		if !slices.ContainsFunc(fields, func(f Field) bool { return f.Key == key }) {
+			violations = append(violations, fmt.Sprintf("missing required field %q", key))
+		}
+	}
+	for _, field := range fields {
+		want, ok := s.Fields[field.Key]
+		if !ok || field.Value == nil || schemaTypeMatches(want, field.Value) {
+			continue
+		}
+		violations = append(violations, fmt.Sprintf("field %q is %T, want %s", field.Key, field.Value, want))
+	}
+	return violations
+}
+
+// schemaTypeMatches reports whether v is a value of type t.
+func schemaTypeMatches(t SchemaType, v any) bool {
+	switch v.(type) {
+	case time.Time:
+		return t == SchemaAny || t == SchemaTime
+	case time.Duration:
+		return t == SchemaAny || t == SchemaDuration
+	case ObjectValue, objectValue:
+		return t == SchemaAny || t == SchemaObject
+	case string, error, fmt.Stringer, internal.StringerValue:
+		return t == SchemaAny || t == SchemaString
+	}
+
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return t == SchemaAny || t == SchemaInt || t == SchemaNumber
+	case reflect.Float32, reflect.Float64:
+		return t == SchemaAny || t == SchemaNumber
+	case reflect.Bool:
+		return t == SchemaAny || t == SchemaBool
+	case reflect.String:
+		return t == SchemaAny || t == SchemaString
+	case reflect.Map, reflect.Struct:
+		return t == SchemaAny || t == SchemaObject
+	case reflect.Slice, reflect.Array:
+		return t == SchemaAny || t == SchemaArray
+	}
+	return t == SchemaAny
+}
+
+// SchemaStats reports the entries that violated the logger's LogSchema.
+type SchemaStats struct {
+	Violations int64 // Entries with at least one violation
+	Dropped    int64 // Entries discarded by SchemaEnforceDrop
+}
+
+// schemaCounters holds the counts behind SchemaStats.
+type schemaCounters struct {
+	violations atomic.Int64
+	dropped    atomic.Int64
+}
+
+// SchemaStats returns the schema violations since the logger was created.
+func (l *Logger) SchemaStats() SchemaStats {
+	if l == nil {
+		return SchemaStats{}
+	}
+	return SchemaStats{
+		Violations: l.schemaStats.violations.Load(),
+		Dropped:    l.schemaStats.dropped.Load(),
+	}
+}
+
+// enforceSchema checks an entry against Config.Schema. It returns false if
+// the entry must be dropped.
+func (l *Logger) enforceSchema(level LogLevel, entry *logEntry) bool {
+	if l.schema == nil || entry.event {
+		return true
+	}
+	fields := entry.fields
+	if len(l.globalFields) > 0 {
+		fields = append(slices.Clip(l.globalFields), fields...)
+	}
+	violations := l.schema.Check(level, fields)
+	if len(violations) == 0 {
+		return true
+	}
+	l.schemaStats.violations.Add(1)
+	summary := strings.Join(violations, "; ")
+	l.internalError(ComponentSchema, nil, "schema violation in %q: %s", entry.msg, summary)
+
+	switch l.schema.Enforcement {
+	case SchemaEnforceDrop:
+		if level < LevelFatal {
+			l.schemaStats.dropped.Add(1)
+			return false
+		}
+		fallthrough
+	case SchemaEnforceError:
+		entry.fields = append(slices.Clip(entry.fields), Field{Key: SchemaErrorKey, Value: summary})
+	}
+	return true
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) document describing the
+// JSON entries of a logger using this schema and the given JSON options
+// (nil for the defaults), for downstream consumers. Levels with required
+// fields become if/then clauses on the level key.
+func (s *LogSchema) JSONSchema(opts *JSONOptions) ([]byte, error) {
+	names := internal.MergeWithDefaults(nil)
+	if opts != nil {
+		names = internal.MergeWithDefaults(opts.FieldNames)
+	}
+	flatten := opts != nil && opts.FlattenFields
+
+	fieldProps := map[string]any{}
+	if s != nil {
+		for key, t := range s.Fields {
+			fieldProps[key] = jsonSchemaType(t)
+		}
+	}
+
+	levelNames := make([]string, 0, LevelFatal+1)
+	for level := LevelDebug; level <= LevelFatal; level++ {
+		levelNames = append(levelNames, jsonLevelName(opts, level))
+	}
+	props := map[string]any{
+		names.Timestamp: map[string]any{"type": []string{"string", "integer"}},
+		names.Level:     map[string]any{"type": "string", "enum": levelNames},
+		names.Caller:    map[string]any{"type": "string"},
+		names.Message:   map[string]any{"type": "string"},
+	}
+	if flatten {
+		for key, prop := range fieldProps {
+			if _, standard := props[key]; !standard {
+				props[key] = prop
+			}
+		}
+	} else {
+		props[names.Fields] = map[string]any{"type": "object", "properties": fieldProps}
+	}
+
+	doc := map[string]any{
+		"$schema":    "https://json-schema.org/draft/2020-12/schema",
+		"type":       "object",
+		"properties": props,
+		"required":   []string{names.Message},
+	}
+
+	var clauses []any
+	if s != nil {
+		for level := LevelDebug; level <= LevelFatal; level++ {
+			required := s.requiredAt(level)
+			if len(required) == 0 {
+				continue
+			}
+			sort.Strings(required)
+			then := map[string]any{"required": required}
+			if !flatten {
+				then = map[string]any{
+					"required":   []string{names.Fields},
+					"properties": map[string]any{names.Fields: then},
+				}
+			}
+			clauses = append(clauses, map[string]any{
+				"if": map[string]any{
+					"required":   []string{names.Level},
+					"properties": map[string]any{names.Level: map[string]any{"const": jsonLevelName(opts, level)}},
+				},
+				"then": then,
+			})
+		}
+	}
+	if len(clauses) > 0 {
+		doc["allOf"] = clauses
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// jsonSchemaType returns the JSON Schema of values of type t as dd writes
+// them.
+func jsonSchemaType(t SchemaType) map[string]any {
+	switch t {
+	case SchemaString, SchemaDuration:
+		return map[string]any{"type": "string"}
+	case SchemaTime:
+		return map[string]any{"type": []string{"string", "integer"}}
+	case SchemaInt, SchemaNumber, SchemaBool, SchemaObject, SchemaArray:
+		return map[string]any{"type": string(t)}
+	}
+	return map[string]any{}
+}
+
+// jsonLevelName returns the level value written by JSON output.
+func jsonLevelName(opts *JSONOptions, level LogLevel) string {
+	if opts != nil {
+		if name, ok := opts.LevelNames[level]; ok {
+			return name
+		}
+	}
+	return level.String()
+}
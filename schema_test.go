@@ -0,0 +1,183 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testSchema(enforcement SchemaEnforcement) *LogSchema {
+	return &LogSchema{
+		Fields: map[string]SchemaType{
+			"user":    SchemaString,
+			"status":  SchemaInt,
+			"latency": SchemaDuration,
+			"ratio":   SchemaNumber,
+		},
+		Required:    map[LogLevel][]string{LevelWarn: {"user"}},
+		Enforcement: enforcement,
+	}
+}
+
+func TestSchemaWarn(t *testing.T) {
+	var events []InternalEvent
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.Schema = testSchema(SchemaEnforceWarn)
+		cfg.InternalErrorHandler = func(ev InternalEvent) { events = append(events, ev) }
+	})
+
+	logger.InfoWith("ok", String("user", "a"), Int("status", 200), Duration("latency", time.Second), Float64("ratio", 0.5))
+	logger.InfoWith("info without user", Int("ratio", 1))
+	if n := logger.SchemaStats().Violations; n != 0 {
+		t.Fatalf("violations = %d, want 0", n)
+	}
+
+	logger.InfoWith("bad", String("status", "200"))
+	logger.Warn("missing user")
+
+	if got := logger.SchemaStats(); got.Violations != 2 || got.Dropped != 0 {
+		t.Errorf("stats = %+v", got)
+	}
+	if out := buf.String(); !strings.Contains(out, "bad") || !strings.Contains(out, "missing user") || strings.Contains(out, SchemaErrorKey) {
+		t.Errorf("output = %q", out)
+	}
+	if len(events) != 2 || events[0].Component != ComponentSchema || !strings.Contains(events[1].Message, `missing required field "user"`) {
+		t.Errorf("events = %+v", events)
+	}
+}
+
+func TestSchemaDropAndError(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.Schema = testSchema(SchemaEnforceDrop)
+		cfg.InternalErrorHandler = func(InternalEvent) {}
+	})
+	logger.ErrorWith("dropped", String("user", "a"), Bool("status", true))
+	if buf.Len() != 0 {
+		t.Errorf("output = %q", buf.String())
+	}
+	if got := logger.SchemaStats(); got.Violations != 1 || got.Dropped != 1 {
+		t.Errorf("stats = %+v", got)
+	}
+
+	logger, buf = newTestLogger(t, func(cfg *Config) {
+		cfg.Schema = testSchema(SchemaEnforceError)
+		cfg.InternalErrorHandler = func(InternalEvent) {}
+	})
+	logger.WarnWith("flagged", Int("status", 500))
+	if out := buf.String(); !strings.Contains(out, "flagged") || !strings.Contains(out, SchemaErrorKey) {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestSchemaGlobalFieldsSatisfyRequired(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Schema = testSchema(SchemaEnforceDrop)
+	cfg.GlobalFields = []Field{String("user", "service")}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Error("kept")
+	if !strings.Contains(buf.String(), "kept") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+func TestSchemaValidation(t *testing.T) {
+	for name, schema := range map[string]*LogSchema{
+		"type":        {Fields: map[string]SchemaType{"a": "uuid"}},
+		"level":       {Required: map[LogLevel][]string{LogLevel(9): {"a"}}},
+		"enforcement": {Enforcement: SchemaEnforcement(7)},
+	} {
+		cfg := DefaultConfig()
+		cfg.Schema = schema
+		if _, err := New(cfg); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.Schema = testSchema(SchemaEnforceWarn)
+	clone := cfg.Clone()
+	clone.Schema.Fields["user"] = SchemaBool
+	clone.Schema.Required[LevelWarn][0] = "other"
+	if cfg.Schema.Fields["user"] != SchemaString || cfg.Schema.Required[LevelWarn][0] != "user" {
+		t.Error("Clone shares the schema")
+	}
+}
+
+func TestSchemaTypeMatches(t *testing.T) {
+	tests := []struct {
+		t    SchemaType
+		v    any
+		want bool
+	}{
+		{SchemaString, "a", true},
+		{SchemaString, errors.New("e"), true},
+		{SchemaString, 1, false},
+		{SchemaInt, uint8(1), true},
+		{SchemaInt, 1.5, false},
+		{SchemaNumber, 1, true},
+		{SchemaNumber, float32(1), true},
+		{SchemaBool, true, true},
+		{SchemaTime, time.Now(), true},
+		{SchemaTime, "2026-01-01", false},
+		{SchemaDuration, time.Second, true},
+		{SchemaString, time.Second, false},
+		{SchemaObject, map[string]any{}, true},
+		{SchemaArray, []int{1}, true},
+		{SchemaArray, map[string]int{}, false},
+		{SchemaAny, struct{}{}, true},
+	}
+	for _, tt := range tests {
+		if got := schemaTypeMatches(tt.t, tt.v); got != tt.want {
+			t.Errorf("schemaTypeMatches(%s, %T) = %v, want %v", tt.t, tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestLogSchemaJSONSchema(t *testing.T) {
+	data, err := testSchema(SchemaEnforceWarn).JSONSchema(&JSONOptions{
+		FieldNames: &JSONFieldNames{Level: "severity", Fields: "attrs"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Properties map[string]struct {
+			Type       any            `json:"type"`
+			Enum       []string       `json:"enum"`
+			Properties map[string]any `json:"properties"`
+		} `json:"properties"`
+		AllOf []map[string]any `json:"allOf"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Properties["severity"].Enum) != 5 {
+		t.Errorf("severity = %+v", doc.Properties["severity"])
+	}
+	attrs := doc.Properties["attrs"].Properties
+	if status, _ := attrs["status"].(map[string]any); status["type"] != "integer" {
+		t.Errorf("attrs = %v", attrs)
+	}
+	// WARN, ERROR and FATAL require user.
+	if len(doc.AllOf) != 3 {
+		t.Errorf("allOf = %v", doc.AllOf)
+	}
+
+	flat, err := testSchema(SchemaEnforceWarn).JSONSchema(&JSONOptions{FlattenFields: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(flat), `"ratio"`) || strings.Contains(string(flat), `"fields"`) {
+		t.Errorf("flattened schema = %s", flat)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"hash/maphash"
+	"maps"
 	"reflect"
 	"regexp"
 	"strings"
@@ -29,8 +30,18 @@ type SensitiveDataFilter struct {
 	// patternsPtr stores an immutable slice of patterns using atomic pointer.
 	// This eliminates slice copying during filter operations (hot path).
 	// The slice is replaced atomically when patterns are added/removed.
-	patternsPtr    atomic.Pointer[[]*regexp.Regexp]
-	mu             sync.RWMutex // protects pattern modifications
+	patternsPtr atomic.Pointer[[]*regexp.Regexp]
+	mu          sync.RWMutex // protects pattern modifications
+	// sources records every pattern with the pack it came from ("" for
+	// custom patterns); patternsPtr holds those not in a disabled pack.
+	// Both are protected by mu.
+	sources       []packPattern
+	disabledPacks map[string]bool
+	// literalsPtr holds the required literals of each active pattern, used
+	// to skip patterns that cannot match (see patternLiterals).
+	literalsPtr atomic.Pointer[patternLiterals]
+	// entropy is the entropy detector (nil when disabled).
+	entropy        atomic.Pointer[entropyDetector]
 	maxInputLength int
 	timeout        time.Duration
 	enabled        atomic.Bool
@@ -46,6 +57,7 @@ type SensitiveDataFilter struct {
 	totalFiltered   atomic.Int64 // Total number of filter operations
 	totalRedactions atomic.Int64 // Total number of redactions performed
 	totalTimeouts   atomic.Int64 // Total number of timeout events
+	totalEntropy    atomic.Int64 // Total number of high-entropy tokens redacted
 	totalLatencyNs  atomic.Int64 // Total latency in nanoseconds (for average calculation)
 
 	// Filter result cache for repeated messages
@@ -56,6 +68,11 @@ type SensitiveDataFilter struct {
 	cacheMiss  atomic.Int64
 	maxCacheSz int
 
+	// cacheBytes estimates the memory held by the cache; cacheLimit, when
+	// positive, bounds it (see Config.MaxMemoryBytes).
+	cacheBytes atomic.Int64
+	cacheLimit atomic.Int64
+
 	// hashSeed is used for maphash-based hashing of cache keys.
 	// Initialized once during filter creation for better collision resistance.
 	hashSeed maphash.Seed
@@ -89,7 +106,7 @@ func (f *SensitiveDataFilter) hashString(s string) uint64 {
 
 // newSensitiveDataFilterWithPatterns is the internal constructor for SensitiveDataFilter.
 // It creates a filter with the specified patterns and timeout.
-func newSensitiveDataFilterWithPatterns(patterns []*regexp.Regexp, timeout time.Duration) *SensitiveDataFilter {
+func newSensitiveDataFilterWithPatterns(patterns []packPattern, timeout time.Duration) *SensitiveDataFilter {
 	filter := &SensitiveDataFilter{
 		maxInputLength: maxInputLength,
 		timeout:        timeout,
@@ -103,23 +120,14 @@ func newSensitiveDataFilterWithPatterns(patterns []*regexp.Regexp, timeout time.
 	filter.goroutineCond = *sync.NewCond(&sync.Mutex{})
 	filter.enabled.Store(true)
 
-	if patterns != nil {
-		copiedPatterns := make([]*regexp.Regexp, len(patterns))
-		copy(copiedPatterns, patterns)
-		filter.patternsPtr.Store(&copiedPatterns)
-		filter.patternCount.Store(int32(len(copiedPatterns)))
-	} else {
-		emptyPatterns := make([]*regexp.Regexp, 0)
-		filter.patternsPtr.Store(&emptyPatterns)
-		filter.patternCount.Store(0)
-	}
+	filter.sources = patterns
+	filter.publishPatterns()
 
 	return filter
 }
 
 func NewSensitiveDataFilter() *SensitiveDataFilter {
-	internal.InitPatterns()
-	return newSensitiveDataFilterWithPatterns(internal.CompiledFullPatterns, defaultFilterTimeout)
+	return newSensitiveDataFilterWithPatterns(builtinPatterns(false), defaultFilterTimeout)
 }
 
 func NewEmptySensitiveDataFilter() *SensitiveDataFilter {
@@ -138,7 +146,7 @@ func NewCustomSensitiveDataFilter(patterns ...string) (*SensitiveDataFilter, err
 	return filter, nil
 }
 
-func (f *SensitiveDataFilter) addPattern(pattern string) error {
+func (f *SensitiveDataFilter) addPattern(pattern, pack string) error {
 	if len(pattern) > maxPatternLength {
 		return fmt.Errorf("%w: %d exceeds maximum %d", ErrPatternTooLong, len(pattern), maxPatternLength)
 	}
@@ -155,12 +163,12 @@ func (f *SensitiveDataFilter) addPattern(pattern string) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	currentPatterns := f.patternsPtr.Load()
-	newPatterns := make([]*regexp.Regexp, len(*currentPatterns)+1)
-	copy(newPatterns, *currentPatterns)
-	newPatterns[len(*currentPatterns)] = re
-	f.patternsPtr.Store(&newPatterns)
-	f.patternCount.Store(int32(len(newPatterns)))
+	f.sources = append(f.sources[:len(f.sources):len(f.sources)], packPattern{
+		re:       re,
+		pack:     pack,
+		literals: internal.RequiredLiterals(re),
+	})
+	f.publishPatterns()
 
 	return nil
 }
@@ -172,7 +180,7 @@ func (f *SensitiveDataFilter) AddPattern(pattern string) error {
 	if pattern == "" {
 		return ErrEmptyPattern
 	}
-	return f.addPattern(pattern)
+	return f.addPattern(pattern, "")
 }
 
 func (f *SensitiveDataFilter) AddPatterns(patterns ...string) error {
@@ -183,7 +191,7 @@ func (f *SensitiveDataFilter) AddPatterns(patterns ...string) error {
 		if pattern == "" {
 			continue
 		}
-		if err := f.addPattern(pattern); err != nil {
+		if err := f.addPattern(pattern, ""); err != nil {
 			return fmt.Errorf("%w: %q", ErrPatternFailed, pattern)
 		}
 	}
@@ -193,9 +201,8 @@ func (f *SensitiveDataFilter) AddPatterns(patterns ...string) error {
 func (f *SensitiveDataFilter) ClearPatterns() {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	emptyPatterns := make([]*regexp.Regexp, 0)
-	f.patternsPtr.Store(&emptyPatterns)
-	f.patternCount.Store(0)
+	f.sources = nil
+	f.publishPatterns()
 }
 
 func (f *SensitiveDataFilter) PatternCount() int {
@@ -247,9 +254,11 @@ type FilterStats struct {
 	TotalFiltered     int64         // Total number of filter operations
 	TotalRedactions   int64         // Total number of redactions performed
 	TotalTimeouts     int64         // Total number of timeout events
+	EntropyRedactions int64         // Tokens redacted by the entropy detector
 	AverageLatency    time.Duration // Average latency per filter operation
 	CacheHits         int64         // Number of cache hits
 	CacheMiss         int64         // Number of cache misses
+	CacheBytes        int64         // Estimated memory held by the result cache
 }
 
 // GetFilterStats returns current filter statistics for monitoring.
@@ -287,9 +296,11 @@ func (f *SensitiveDataFilter) GetFilterStats() FilterStats {
 		TotalFiltered:     totalFiltered,
 		TotalRedactions:   f.totalRedactions.Load(),
 		TotalTimeouts:     f.totalTimeouts.Load(),
+		EntropyRedactions: f.totalEntropy.Load(),
 		AverageLatency:    avgLatency,
 		CacheHits:         f.cacheHits.Load(),
 		CacheMiss:         f.cacheMiss.Load(),
+		CacheBytes:        f.cacheBytes.Load(),
 	}
 }
 
@@ -395,7 +406,16 @@ func (f *SensitiveDataFilter) Clone() *SensitiveDataFilter {
 	// Share the patterns pointer directly (immutable after creation)
 	// This avoids allocation when cloning
 	clone.patternsPtr.Store(f.patternsPtr.Load())
+	clone.literalsPtr.Store(f.literalsPtr.Load())
+	clone.entropy.Store(f.entropy.Load())
 	clone.patternCount.Store(f.patternCount.Load())
+	clone.sources = f.sources[:len(f.sources):len(f.sources)]
+	for pack := range f.disabledPacks {
+		if clone.disabledPacks == nil {
+			clone.disabledPacks = make(map[string]bool)
+		}
+		clone.disabledPacks[pack] = true
+	}
 
 	return clone
 }
@@ -412,7 +432,8 @@ func (f *SensitiveDataFilter) Filter(input string) string {
 
 	// Fast path: atomic load of patterns pointer (lock-free read)
 	patternsPtr := f.patternsPtr.Load()
-	if patternsPtr == nil || len(*patternsPtr) == 0 {
+	entropy := f.entropy.Load()
+	if (patternsPtr == nil || len(*patternsPtr) == 0) && entropy == nil {
 		return input
 	}
 
@@ -458,7 +479,10 @@ func (f *SensitiveDataFilter) Filter(input string) string {
 
 	startTime := time.Now()
 
-	patterns := *patternsPtr
+	var patterns []*regexp.Regexp
+	if patternsPtr != nil {
+		patterns = *patternsPtr
+	}
 	timeout := f.timeout
 
 	// Handle truncation with boundary-aware sensitive data detection FIRST.
@@ -514,7 +538,9 @@ func (f *SensitiveDataFilter) Filter(input string) string {
 	// Quick rejection: check if input could possibly contain sensitive data
 	// This avoids running all regex patterns on obviously safe input
 	// Note: Truncation is already handled above
-	if !f.couldContainSensitiveData(input) {
+	if len(patterns) == 0 || !f.couldContainSensitiveData(input) {
+		result := f.redactHighEntropy(entropy, input)
+
 		// Still track metrics for monitoring
 		// Ensure at least 1ns to avoid zero average latency for very fast operations
 		latencyNs := time.Since(startTime).Nanoseconds()
@@ -526,19 +552,33 @@ func (f *SensitiveDataFilter) Filter(input string) string {
 
 		// Cache the result for small inputs (use pre-computed hash)
 		if useCache && f.cache != nil {
-			f.cacheResult(inputHash, input, input)
+			f.cacheResult(inputHash, input, result)
 		}
-		return input
+		return result
+	}
+
+	// Skip patterns whose required literals do not occur in the input.
+	// The folded copy is refreshed whenever a pattern changes the result.
+	literals := f.literalsFor(patternsPtr)
+	folded := ""
+	if literals != nil {
+		folded = internal.FoldString(input)
 	}
 
 	result := input
 	redactionCount := int64(0)
 	for i := range patterns {
+		if literals != nil && !internal.ContainsAnyLiteral(folded, literals[i]) {
+			continue
+		}
 		beforeFilter := result
 		result = f.filterWithTimeout(result, patterns[i], timeout)
 		// Track redactions (result changed by this pattern)
 		if result != beforeFilter {
 			redactionCount++
+			if literals != nil {
+				folded = internal.FoldString(result)
+			}
 		}
 		// Early exit if result becomes empty or redacted
 		// Note: redactionCount already incremented above when result changed
@@ -547,6 +587,14 @@ func (f *SensitiveDataFilter) Filter(input string) string {
 		}
 	}
 
+	if result != "" && result != "[REDACTED]" {
+		before := result
+		result = f.redactHighEntropy(entropy, result)
+		if result != before {
+			redactionCount++
+		}
+	}
+
 	// Update metrics
 	f.totalFiltered.Add(1)
 	if redactionCount > 0 {
@@ -591,31 +639,40 @@ func (f *SensitiveDataFilter) cacheResult(hash uint64, input, result string) {
 		return
 	}
 
-	// Check if this is a new entry or an update (handles hash collision case)
-	_, exists := f.cache[hash]
+	// An update (or hash collision) replaces the entry, so it is evicted
+	// first and re-added as a new entry.
+	if old, exists := f.cache[hash]; exists {
+		f.evictLocked(hash, old)
+	}
+
+	size := cacheEntrySize(input, result)
+	limit := f.cacheLimit.Load()
+	overLimit := func() bool {
+		return f.cacheSize >= f.maxCacheSz || (limit > 0 && f.cacheBytes.Load()+size > limit)
+	}
 
-	// Evict old entries if cache is full AND this is a new entry
-	if !exists && f.cacheSize >= f.maxCacheSz {
+	// Evict old entries if cache is full
+	if overLimit() {
 		// Simple eviction: clear expired entries first
 		for k, entry := range f.cache {
 			if time.Since(entry.created) >= cacheTTLSeconds*time.Second {
-				delete(f.cache, k)
-				f.cacheSize--
+				f.evictLocked(k, entry)
 			}
 		}
 
 		// If still full after removing expired, clear half the cache
-		if f.cacheSize >= f.maxCacheSz {
-			count := 0
-			toDelete := f.maxCacheSz / 2
-			for k := range f.cache {
-				delete(f.cache, k)
-				count++
-				if count >= toDelete {
+		if overLimit() {
+			toDelete := max(f.cacheSize/2, 1)
+			for k, entry := range f.cache {
+				f.evictLocked(k, entry)
+				toDelete--
+				if toDelete == 0 {
 					break
 				}
 			}
-			f.cacheSize -= count
+		}
+		if limit > 0 && f.cacheBytes.Load()+size > limit {
+			return
 		}
 	}
 
@@ -624,11 +681,51 @@ func (f *SensitiveDataFilter) cacheResult(hash uint64, input, result string) {
 		result:  result,
 		created: time.Now(),
 	}
+	f.cacheSize++
+	f.cacheBytes.Add(size)
+}
+
+// filterCacheEntryOverhead approximates the memory of a cache entry besides
+// its strings: the map slot, string headers and creation time.
+const filterCacheEntryOverhead = 80
+
+// cacheEntrySize estimates the memory held by a cache entry. The result
+// shares memory with the input when filtering changed nothing.
+func cacheEntrySize(input, result string) int64 {
+	size := int64(len(input)) + filterCacheEntryOverhead
+	if result != input {
+		size += int64(len(result))
+	}
+	return size
+}
+
+// evictLocked removes a cache entry. The caller holds cacheMu.
+func (f *SensitiveDataFilter) evictLocked(hash uint64, entry filterCacheEntry) {
+	delete(f.cache, hash)
+	f.cacheSize--
+	f.cacheBytes.Add(-cacheEntrySize(entry.input, entry.result))
+}
+
+// limitCache bounds the estimated memory of the result cache to limit
+// bytes (0: only the entry count is bounded). The bound applies as new
+// results are cached.
+func (f *SensitiveDataFilter) limitCache(limit int64) {
+	if f.cacheLimit.Load() != limit {
+		f.cacheLimit.Store(limit)
+	}
+}
 
-	// Only increment size counter for new entries
-	if !exists {
-		f.cacheSize++
+// shrinkCache empties the result cache and returns the estimated number of
+// bytes released.
+func (f *SensitiveDataFilter) shrinkCache() int64 {
+	f.cacheMu.Lock()
+	defer f.cacheMu.Unlock()
+	if f.cacheSize == 0 {
+		return 0
 	}
+	clear(f.cache)
+	f.cacheSize = 0
+	return f.cacheBytes.Swap(0)
 }
 
 // Pre-computed lowercase credential keywords for fast case-insensitive matching
@@ -647,6 +744,23 @@ var credentialKeywords = [][]byte{
 	[]byte("session"),
 }
 
+// patternLiterals holds the required literals (see internal.RequiredLiterals)
+// of each pattern in a published pattern slice.
+type patternLiterals struct {
+	patterns *[]*regexp.Regexp
+	literals [][]string
+}
+
+// literalsFor returns the required literals of each pattern in patterns, or
+// nil if they are not known for that pattern slice.
+func (f *SensitiveDataFilter) literalsFor(patterns *[]*regexp.Regexp) [][]string {
+	index := f.literalsPtr.Load()
+	if index == nil || index.patterns != patterns || len(index.literals) != len(*patterns) {
+		return nil
+	}
+	return index.literals
+}
+
 // couldContainSensitiveData performs fast pre-checks to determine if input
 // could possibly contain sensitive data. This avoids expensive regex matching
 // on obviously safe input, providing significant performance improvement.
@@ -833,6 +947,7 @@ func (f *SensitiveDataFilter) filterWithTimeout(input string, pattern *regexp.Re
 		// Check if context timed out
 		if ctx.Err() == context.DeadlineExceeded {
 			f.totalTimeouts.Add(1)
+			notifyInternal(nil, ComponentFilter, ctx.Err(), "sensitive data filter timed out after %v on %d bytes; rest of input not filtered", timeout, inputLen)
 		}
 		return result
 	}
@@ -844,6 +959,7 @@ func (f *SensitiveDataFilter) filterWithTimeout(input string, pattern *regexp.Re
 	case <-time.After(timeout / 2):
 		// Could not acquire semaphore within half the timeout, return [REDACTED] for safety
 		f.totalTimeouts.Add(1)
+		notifyInternal(nil, ComponentFilter, context.DeadlineExceeded, "sensitive data filter busy for %v; %d bytes redacted", timeout/2, inputLen)
 		return "[REDACTED]"
 	}
 
@@ -884,6 +1000,7 @@ func (f *SensitiveDataFilter) filterWithTimeout(input string, pattern *regexp.Re
 		return res.output
 	case <-ctx.Done():
 		f.totalTimeouts.Add(1)
+		notifyInternal(nil, ComponentFilter, ctx.Err(), "sensitive data filter timed out after %v; %d bytes redacted", timeout, inputLen)
 		return "[REDACTED]"
 	}
 }
@@ -1044,6 +1161,28 @@ func (f *SensitiveDataFilter) filterValueRecursiveInternal(key string, value any
 		return f.Filter(str)
 	}
 
+	// Fast paths for typed slices produced by the field constructors,
+	// preserving their type so formatters avoid reflection
+	switch v := value.(type) {
+	case []string:
+		filtered := make([]string, len(v))
+		for i, s := range v {
+			filtered[i] = f.Filter(s)
+		}
+		return filtered
+	case []int, []int64, []uint64, []float64, []bool, []time.Duration:
+		return value
+	case internal.ObjectValue:
+		filtered := make(internal.ObjectValue, len(v))
+		for i, field := range v {
+			filtered[i] = Field{
+				Key:   field.Key,
+				Value: f.filterValueRecursiveInternal(field.Key, field.Value, visited, depth+1),
+			}
+		}
+		return filtered
+	}
+
 	// Use reflection for complex types
 	val := reflect.ValueOf(value)
 	if !val.IsValid() {
@@ -1154,37 +1293,124 @@ type SecurityConfig struct {
 	MaxMessageSize  int
 	MaxWriters      int
 	SensitiveFilter *SensitiveDataFilter
+	// MaxFieldSizes caps string and []byte field values by key, in bytes.
+	// A capped value ends in "..." and the entry gets TruncatedKey and
+	// "<key>_original_size" fields.
+	MaxFieldSizes map[string]int
+	// MaxEntrySize caps the size in bytes of a formatted entry, fields
+	// included (0: no limit). An entry over the limit has its largest
+	// values shortened or replaced and gets EntryTruncatedKey and
+	// EntryOriginalSizeKey fields. It is applied after MaxMessageSize.
+	MaxEntrySize int
+	// MaxEntrySizeByLevel overrides MaxEntrySize for the listed levels; a
+	// value of 0 removes the limit for that level.
+	MaxEntrySizeByLevel map[LogLevel]int
+	// ControlChars selects how control characters, ANSI escape sequences
+	// and invisible Unicode formatting characters in messages are handled
+	// (default: ControlCharEscape).
+	ControlChars ControlCharPolicy
+	// KeepNewlines leaves newlines in messages written as JSON, where the
+	// encoder escapes them, so multi-line messages such as stack traces
+	// decode to the original text. Text and console output always escape
+	// newlines onto a single line.
+	KeepNewlines bool
+	// AuditRedactions, when set, receives an AuditEventSensitiveDataRedacted
+	// event for each message or string field the filter changes and each
+	// field redacted by its key. Events carry the field key, the matched
+	// pattern packs (see RedactionSensitiveKey and RedactionCustomPattern)
+	// and a "value_sha256" hash of the original value, never the value.
+	// The AuditLogger is shared by clones of the config.
+	AuditRedactions *AuditLogger
+	// FilterScope selects whether SensitiveFilter runs on the message and
+	// field values, on the encoded entry, or both (default:
+	// FilterScopeValues).
+	FilterScope FilterScope
+}
+
+// FilterScope selects what SecurityConfig.SensitiveFilter is applied to.
+type FilterScope int
+
+const (
+	// FilterScopeValues filters the message and field values before they
+	// are formatted. Values that are neither strings nor containers the
+	// filter can walk, such as types with a String or MarshalJSON method,
+	// are not filtered.
+	FilterScopeValues FilterScope = iota
+	// FilterScopeOutput filters the encoded entry instead, after every
+	// field has been rendered to JSON or text, so it also catches data in
+	// values serialized by reflection. Fields with sensitive keys such as
+	// "password" are still redacted by key. Patterns must match the
+	// encoded form: a JSON-escaped value is matched as escaped. Hooks and
+	// RecordWriters, which get the message and field values rather than
+	// the encoded entry, get them filtered as with FilterScopeValues.
+	FilterScopeOutput
+	// FilterScopeAll filters the values and then the encoded entry.
+	FilterScopeAll
+)
+
+// IsValid reports whether s is a known scope.
+func (s FilterScope) IsValid() bool {
+	return s >= FilterScopeValues && s <= FilterScopeAll
+}
+
+// filtersValues reports whether the message and field values are filtered.
+func (s FilterScope) filtersValues() bool {
+	return s != FilterScopeOutput
 }
 
+// filtersOutput reports whether the encoded entry is filtered.
+func (s FilterScope) filtersOutput() bool {
+	return s == FilterScopeOutput || s == FilterScopeAll
+}
+
+// ControlCharPolicy selects how SecurityConfig sanitizes control characters
+// in messages. Tabs are always kept; newlines are escaped as \n and \r
+// under every policy unless SecurityConfig.KeepNewlines applies.
+type ControlCharPolicy = internal.ControlCharPolicy
+
+// Control character policies.
+const (
+	// ControlCharEscape shows C0 control characters as \xNN and removes
+	// NUL, DEL, ANSI escape sequences and invisible Unicode characters.
+	ControlCharEscape = internal.ControlCharEscape
+	// ControlCharEscapeUnicode shows every control character, including
+	// ESC and invisible Unicode characters, as \uXXXX, and invalid UTF-8
+	// bytes as \ufffd.
+	ControlCharEscapeUnicode = internal.ControlCharEscapeUnicode
+	// ControlCharStrip removes control characters, ANSI escape sequences
+	// and invalid UTF-8 bytes.
+	ControlCharStrip = internal.ControlCharStrip
+	// ControlCharReplace replaces each control character, ANSI escape
+	// sequence and invalid UTF-8 byte with '?'.
+	ControlCharReplace = internal.ControlCharReplace
+)
+
 // SecurityLevel defines the security level for the logger.
 // Higher levels provide more protection but may impact performance.
+// Security levels do not limit log volume; use Config.RateLimit for that.
 type SecurityLevel int
 
 const (
 	// SecurityLevelDevelopment provides minimal security for development.
 	// - No sensitive data filtering
-	// - No rate limiting
 	// - No audit logging
 	// Use only in local development environments.
 	SecurityLevelDevelopment SecurityLevel = iota
 
 	// SecurityLevelBasic provides basic security for non-production environments.
 	// - Basic sensitive data filtering (passwords, API keys, credit cards)
-	// - No rate limiting
 	// - No audit logging
 	// Suitable for staging and testing environments.
 	SecurityLevelBasic
 
 	// SecurityLevelStandard provides standard security for production.
 	// - Full sensitive data filtering
-	// - Rate limiting enabled
 	// - Basic audit logging
 	// Recommended for most production deployments.
 	SecurityLevelStandard
 
 	// SecurityLevelStrict provides enhanced security for sensitive environments.
 	// - Full sensitive data filtering
-	// - Strict rate limiting
 	// - Full audit logging
 	// - Input sanitization
 	// Suitable for environments handling PII or financial data.
@@ -1192,7 +1418,6 @@ const (
 
 	// SecurityLevelParanoid provides maximum security for high-risk environments.
 	// - Full sensitive data filtering with all patterns
-	// - Very strict rate limiting
 	// - Complete audit logging
 	// - All input validation
 	// - Log integrity verification
@@ -1246,14 +1471,10 @@ func SecurityConfigForLevel(level SecurityLevel) *SecurityConfig {
 	case SecurityLevelStrict:
 		filter := NewSensitiveDataFilter()
 		// Add additional strict patterns
-		strictPatterns := []string{
-			// Additional context patterns for strict mode
-			`(?i)(?:confidential|classified|secret|private)[\s:=]+[^\s]{1,256}\b`,
-			`(?i)(?:internal[_-]?id|employee[_-]?id|user[_-]?id)[\s:=]+[A-Za-z0-9]{4,50}\b`,
-		}
-		for _, p := range strictPatterns {
-			filter.AddPattern(p)
-		}
+		filter.addPackPatterns(PackCredentials,
+			`(?i)(?:confidential|classified|secret|private)[\s:=]+[^\s]{1,256}\b`)
+		filter.addPackPatterns(PackIdentifiers,
+			`(?i)(?:internal[_-]?id|employee[_-]?id|user[_-]?id)[\s:=]+[A-Za-z0-9]{4,50}\b`)
 		return &SecurityConfig{
 			MaxMessageSize:  maxMessageSize,
 			MaxWriters:      maxWriterCount,
@@ -1263,19 +1484,17 @@ func SecurityConfigForLevel(level SecurityLevel) *SecurityConfig {
 	case SecurityLevelParanoid:
 		filter := NewSensitiveDataFilter()
 		// Add all additional patterns for paranoid mode
-		paranoidPatterns := []string{
-			// Confidential/classified data
-			`(?i)(?:confidential|classified|secret|private|restricted)[\s:=]+[^\s]{1,256}\b`,
-			// All IDs
-			`(?i)(?:internal[_-]?id|employee[_-]?id|user[_-]?id|session[_-]?id|transaction[_-]?id|reference[_-]?id|tracking[_-]?id)[\s:=]+[A-Za-z0-9]{4,50}\b`,
-			// Additional financial patterns
-			`(?i)(?:amount|balance|deposit|withdrawal|transfer|payment)[\s:=]+[0-9.,]{1,20}\b`,
-			// Any UUID-like identifier
-			`\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`,
-		}
-		for _, p := range paranoidPatterns {
-			filter.AddPattern(p)
-		}
+		// Confidential/classified data
+		filter.addPackPatterns(PackCredentials,
+			`(?i)(?:confidential|classified|secret|private|restricted)[\s:=]+[^\s]{1,256}\b`)
+		// All IDs
+		filter.addPackPatterns(PackIdentifiers,
+			`(?i)(?:internal[_-]?id|employee[_-]?id|user[_-]?id|session[_-]?id|transaction[_-]?id|reference[_-]?id|tracking[_-]?id)[\s:=]+[A-Za-z0-9]{4,50}\b`)
+		// Additional financial patterns
+		filter.addPackPatterns(PackPCI,
+			`(?i)(?:amount|balance|deposit|withdrawal|transfer|payment)[\s:=]+[0-9.,]{1,20}\b`)
+		// Any UUID-like identifier
+		_ = filter.EnablePack(PackUUID)
 		return &SecurityConfig{
 			MaxMessageSize:  maxMessageSize,
 			MaxWriters:      maxWriterCount,
@@ -1291,6 +1510,10 @@ func SecurityConfigForLevel(level SecurityLevel) *SecurityConfig {
 //
 // Deep copy:
 //   - SensitiveFilter (via SensitiveDataFilter.Clone())
+//   - MaxFieldSizes
+//   - MaxEntrySizeByLevel
+//
+// AuditRedactions is shared.
 //
 // Returns nil if the receiver is nil.
 func (sc *SecurityConfig) Clone() *SecurityConfig {
@@ -1301,16 +1524,30 @@ func (sc *SecurityConfig) Clone() *SecurityConfig {
 	clone := &SecurityConfig{
 		MaxMessageSize: sc.MaxMessageSize,
 		MaxWriters:     sc.MaxWriters,
+		MaxEntrySize:   sc.MaxEntrySize,
+		ControlChars:   sc.ControlChars,
+		KeepNewlines:   sc.KeepNewlines,
+		FilterScope:    sc.FilterScope,
+
+		AuditRedactions: sc.AuditRedactions,
+	}
+	if sc.MaxEntrySizeByLevel != nil {
+		clone.MaxEntrySizeByLevel = maps.Clone(sc.MaxEntrySizeByLevel)
 	}
 	if sc.SensitiveFilter != nil {
 		clone.SensitiveFilter = sc.SensitiveFilter.Clone()
 	}
+	if sc.MaxFieldSizes != nil {
+		clone.MaxFieldSizes = make(map[string]int, len(sc.MaxFieldSizes))
+		for key, size := range sc.MaxFieldSizes {
+			clone.MaxFieldSizes[key] = size
+		}
+	}
 	return clone
 }
 
 func NewBasicSensitiveDataFilter() *SensitiveDataFilter {
-	internal.InitPatterns()
-	return newSensitiveDataFilterWithPatterns(internal.CompiledBasicPatterns, defaultFilterTimeout)
+	return newSensitiveDataFilterWithPatterns(builtinPatterns(true), defaultFilterTimeout)
 }
 
 // DefaultSecurityConfig returns a security config with basic sensitive data filtering enabled.
@@ -1366,9 +1603,7 @@ func HealthcareConfig() *SecurityConfig {
 		`(?i)(?:patient[_-]?identifier|patient[_-]?code)[\s:=]+[A-Za-z0-9]{6,20}\b`,
 	}
 
-	for _, pattern := range healthcarePatterns {
-		filter.AddPattern(pattern)
-	}
+	filter.addPackPatterns(PackPHI, healthcarePatterns...)
 
 	return &SecurityConfig{
 		MaxMessageSize:  maxMessageSize,
@@ -1403,9 +1638,7 @@ func FinancialConfig() *SecurityConfig {
 		`(?i)(?:routing[_-]?number|aba|aba[_-]?rn|routing)[\s:=]+[0-9]{9}\b`,
 	}
 
-	for _, pattern := range financialPatterns {
-		filter.AddPattern(pattern)
-	}
+	filter.addPackPatterns(PackPCI, financialPatterns...)
 
 	return &SecurityConfig{
 		MaxMessageSize:  maxMessageSize,
@@ -1443,9 +1676,7 @@ func GovernmentConfig() *SecurityConfig {
 		`(?i)(?:case[_-]?number|file[_-]?number|docket)[\s:=]+[A-Za-z0-9]{5,20}\b`,
 	}
 
-	for _, pattern := range governmentPatterns {
-		filter.AddPattern(pattern)
-	}
+	filter.addPackPatterns(PackIdentifiers, governmentPatterns...)
 
 	return &SecurityConfig{
 		MaxMessageSize:  maxMessageSize,
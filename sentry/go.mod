@@ -0,0 +1,15 @@
+module github.com/cybergodev/dd/sentry
+
+go 1.25.0
+
+require (
+	github.com/cybergodev/dd v1.2.3-0.20261017005637-bbec0c6746a2
+	github.com/getsentry/sentry-go v0.43.0
+)
+
+require (
+	golang.org/x/sys v0.18.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+)
+
+replace github.com/cybergodev/dd => ../
@@ -0,0 +1,379 @@
+// Package sentry reports dd ERROR and FATAL entries to Sentry.
+//
+// Example:
+//
+//	_ = sentrygo.Init(sentrygo.ClientOptions{Dsn: dsn})
+//	hook := sentry.NewHook(sentry.Config{})
+//
+//	cfg := dd.DefaultConfig()
+//	cfg.Hooks = dd.NewHookRegistry()
+//	hook.Register(cfg.Hooks)
+//	logger, _ := dd.New(cfg)
+//
+//	logger.ErrorWith("charge failed", dd.ErrWithStack(err)) // also sent to Sentry
+package sentry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cybergodev/dd"
+	sentrygo "github.com/getsentry/sentry-go"
+)
+
+// Field keys with special meaning in Sentry events.
+const (
+	ErrorKey   = "error"
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+)
+
+const (
+	defaultRateLimit    = 10
+	defaultFlushTimeout = 2 * time.Second
+	loggerName          = "dd"
+)
+
+// Config configures the hook. The zero value is usable.
+type Config struct {
+	// Hub receives the events (default: sentrygo.CurrentHub()).
+	Hub *sentrygo.Hub
+
+	// MinLevel is the lowest level reported. The zero value
+	// (dd.LevelDebug) selects dd.LevelError.
+	MinLevel dd.LogLevel
+
+	// RateLimit is the number of events sent per second (default: 10).
+	// Entries above the limit are counted in Dropped. Negative disables
+	// the limit.
+	RateLimit float64
+
+	// Burst is the number of events that may be sent at once
+	// (default: RateLimit, at least 1).
+	Burst int
+
+	// Filter redacts event strings before sending, e.g. the logger's
+	// SecurityConfig.SensitiveFilter. Message and fields arrive already
+	// filtered by the logger; Filter also covers stack traces, tags and
+	// anything BeforeSend adds.
+	Filter *dd.SensitiveDataFilter
+
+	// BeforeSend may modify the event or return nil to drop it. It runs
+	// before Filter.
+	BeforeSend func(event *sentrygo.Event, hookCtx *dd.HookContext) *sentrygo.Event
+
+	// Tags lists field keys sent as Sentry tags instead of extra data.
+	Tags []string
+
+	// FlushTimeout bounds the flush on FATAL entries (default: 2s).
+	FlushTimeout time.Duration
+}
+
+// Hook converts log entries into Sentry events.
+type Hook struct {
+	config  Config
+	tags    map[string]bool
+	limiter *tokenBucket
+	sent    atomic.Int64
+	dropped atomic.Int64
+}
+
+// NewHook creates a hook from cfg.
+func NewHook(cfg Config) *Hook {
+	if cfg.MinLevel == dd.LevelDebug {
+		cfg.MinLevel = dd.LevelError
+	}
+	if cfg.RateLimit == 0 {
+		cfg.RateLimit = defaultRateLimit
+	}
+	if cfg.FlushTimeout <= 0 {
+		cfg.FlushTimeout = defaultFlushTimeout
+	}
+
+	h := &Hook{config: cfg, tags: make(map[string]bool, len(cfg.Tags))}
+	for _, key := range cfg.Tags {
+		h.tags[key] = true
+	}
+	if cfg.RateLimit > 0 {
+		burst := cfg.Burst
+		if burst <= 0 {
+			burst = max(1, int(cfg.RateLimit))
+		}
+		h.limiter = newTokenBucket(cfg.RateLimit, burst)
+	}
+	return h
+}
+
+// Register adds the hook to registry: AfterLog sends events, OnFatal
+// flushes them before the program exits.
+func (h *Hook) Register(registry *dd.HookRegistry) {
+	registry.Add(dd.HookAfterLog, h.AfterLog)
+	registry.Add(dd.HookOnFatal, h.OnFatal)
+}
+
+// AfterLog sends entries at or above MinLevel to Sentry.
+func (h *Hook) AfterLog(ctx context.Context, hookCtx *dd.HookContext) error {
+	if hookCtx.Level < h.config.MinLevel {
+		return nil
+	}
+	if h.limiter != nil && !h.limiter.allow(time.Now()) {
+		h.dropped.Add(1)
+		return nil
+	}
+
+	event := h.newEvent(ctx, hookCtx)
+	if h.config.BeforeSend != nil {
+		if event = h.config.BeforeSend(event, hookCtx); event == nil {
+			h.dropped.Add(1)
+			return nil
+		}
+	}
+	if h.config.Filter != nil {
+		redactEvent(event, h.config.Filter)
+	}
+
+	if h.hub().CaptureEvent(event) == nil {
+		h.dropped.Add(1)
+		return nil
+	}
+	h.sent.Add(1)
+	return nil
+}
+
+// OnFatal flushes pending events. The flush ends at FlushTimeout or when
+// ctx expires, whichever is first.
+func (h *Hook) OnFatal(ctx context.Context, _ *dd.HookContext) error {
+	ctx, cancel := context.WithTimeout(ctx, h.config.FlushTimeout)
+	defer cancel()
+	if !h.hub().FlushWithContext(ctx) {
+		return errors.New("sentry: flush timed out")
+	}
+	return nil
+}
+
+// Sent returns the number of events handed to Sentry.
+func (h *Hook) Sent() int64 {
+	return h.sent.Load()
+}
+
+// Dropped returns the number of entries not sent because of the rate
+// limit, BeforeSend or the Sentry client.
+func (h *Hook) Dropped() int64 {
+	return h.dropped.Load()
+}
+
+func (h *Hook) hub() *sentrygo.Hub {
+	if h.config.Hub != nil {
+		return h.config.Hub
+	}
+	return sentrygo.CurrentHub()
+}
+
+// newEvent builds the event of one entry.
+func (h *Hook) newEvent(ctx context.Context, hookCtx *dd.HookContext) *sentrygo.Event {
+	event := sentrygo.NewEvent()
+	event.Level = levelOf(hookCtx.Level)
+	event.Message = hookCtx.Message
+	event.Logger = loggerName
+	event.Timestamp = hookCtx.Timestamp
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	var errText, stack string
+	traceID, spanID := dd.GetTraceID(ctx), dd.GetSpanID(ctx)
+	for _, field := range hookCtx.Fields {
+		value := field.Value
+		switch {
+		case field.Key == ErrorKey:
+			errText, stack = splitStack(fmt.Sprint(value))
+			continue
+		case isStackKey(field.Key):
+			if s, ok := value.(string); ok && stack == "" {
+				stack = s
+				continue
+			}
+		case field.Key == TraceIDKey:
+			traceID = fmt.Sprint(value)
+		case field.Key == SpanIDKey:
+			spanID = fmt.Sprint(value)
+		}
+		if h.tags[field.Key] {
+			event.Tags[field.Key] = fmt.Sprint(value)
+		} else {
+			event.Extra[field.Key] = value
+		}
+	}
+
+	// Tags rather than the trace context, which the Sentry scope owns
+	if traceID != "" {
+		event.Tags[TraceIDKey] = traceID
+	}
+	if spanID != "" {
+		event.Tags[SpanIDKey] = spanID
+	}
+
+	if errText != "" || stack != "" {
+		exception := sentrygo.Exception{Type: hookCtx.Message, Value: errText}
+		if exception.Type == "" {
+			exception.Type, exception.Value = errText, ""
+		}
+		if frames := parseStack(stack); len(frames) > 0 {
+			exception.Stacktrace = &sentrygo.Stacktrace{Frames: frames}
+		}
+		event.Exception = []sentrygo.Exception{exception}
+	}
+	return event
+}
+
+// levelOf maps a dd level to a Sentry level.
+func levelOf(level dd.LogLevel) sentrygo.Level {
+	switch level {
+	case dd.LevelDebug:
+		return sentrygo.LevelDebug
+	case dd.LevelInfo:
+		return sentrygo.LevelInfo
+	case dd.LevelWarn:
+		return sentrygo.LevelWarning
+	case dd.LevelFatal:
+		return sentrygo.LevelFatal
+	default:
+		return sentrygo.LevelError
+	}
+}
+
+// isStackKey reports whether key conventionally holds a stack trace.
+func isStackKey(key string) bool {
+	switch key {
+	case "stack", "stacktrace", "stack_trace", "error.stack":
+		return true
+	}
+	return false
+}
+
+// splitStack separates an ErrWithStack value into the error text and the
+// stack frames.
+func splitStack(s string) (text, stack string) {
+	if idx := strings.Index(s, "\nStack:"); idx >= 0 {
+		return s[:idx], s[idx+len("\nStack:"):]
+	}
+	return s, ""
+}
+
+// parseStack converts ErrWithStack frames ("\tfile:line: function") or a
+// runtime/debug.Stack dump into Sentry frames, oldest call first.
+func parseStack(stack string) []sentrygo.Frame {
+	var frames []sentrygo.Frame
+	var function string
+	for _, line := range strings.Split(stack, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" || strings.HasPrefix(line, "goroutine ") {
+			continue
+		}
+		if line[0] != '\t' && line[0] != ' ' {
+			// debug.Stack: the function line precedes its location
+			function = line
+			if idx := strings.LastIndexByte(function, '('); idx > 0 {
+				function = function[:idx]
+			}
+			continue
+		}
+
+		location := strings.TrimSpace(line)
+		if idx := strings.Index(location, ": "); idx >= 0 {
+			function = location[idx+2:]
+			location = location[:idx]
+		}
+		if idx := strings.Index(location, " +0x"); idx >= 0 {
+			location = location[:idx]
+		}
+		file, lineNo := location, 0
+		if idx := strings.LastIndexByte(location, ':'); idx > 0 {
+			if n, err := strconv.Atoi(location[idx+1:]); err == nil {
+				file, lineNo = location[:idx], n
+			}
+		}
+		frames = append(frames, newFrame(function, file, lineNo))
+		function = ""
+	}
+
+	// Sentry expects the most recent call last
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+	return frames
+}
+
+// newFrame builds a frame from a qualified function name and location.
+func newFrame(function, file string, line int) sentrygo.Frame {
+	frame := sentrygo.Frame{
+		Function: function,
+		AbsPath:  file,
+		Filename: file,
+		Lineno:   line,
+		InApp:    true,
+	}
+	if idx := strings.LastIndexByte(file, '/'); idx >= 0 {
+		frame.Filename = file[idx+1:]
+	}
+	// "github.com/acme/app/pkg.(*T).Method" -> module "github.com/acme/app/pkg"
+	if function != "" {
+		slash := strings.LastIndexByte(function, '/')
+		if dot := strings.IndexByte(function[slash+1:], '.'); dot >= 0 {
+			frame.Module = function[:slash+1+dot]
+			frame.Function = function[slash+1+dot+1:]
+		}
+	}
+	if strings.HasPrefix(frame.Module, "runtime") || strings.HasPrefix(frame.Module, "testing") {
+		frame.InApp = false
+	}
+	return frame
+}
+
+// redactEvent applies filter to the event's strings.
+func redactEvent(event *sentrygo.Event, filter *dd.SensitiveDataFilter) {
+	event.Message = filter.Filter(event.Message)
+	for key, value := range event.Tags {
+		event.Tags[key] = filter.Filter(value)
+	}
+	for key, value := range event.Extra {
+		event.Extra[key] = filter.FilterFieldValue(key, value)
+	}
+	for i := range event.Exception {
+		event.Exception[i].Type = filter.Filter(event.Exception[i].Type)
+		event.Exception[i].Value = filter.Filter(event.Exception[i].Value)
+	}
+}
+
+// tokenBucket is a minimal rate limiter.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+func (b *tokenBucket) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.last.IsZero() {
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,175 @@
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cybergodev/dd"
+	sentrygo "github.com/getsentry/sentry-go"
+)
+
+func newTestHub(t *testing.T) (*sentrygo.Hub, *sentrygo.MockTransport) {
+	t.Helper()
+	transport := &sentrygo.MockTransport{}
+	client, err := sentrygo.NewClient(sentrygo.ClientOptions{Transport: transport})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sentrygo.NewHub(client, sentrygo.NewScope()), transport
+}
+
+func newTestLogger(t *testing.T, hook *Hook) *dd.Logger {
+	t.Helper()
+	cfg := dd.DefaultConfig()
+	cfg.Output = &bytes.Buffer{}
+	cfg.Hooks = dd.NewHookRegistry()
+	cfg.FatalHandler = func() {}
+	hook.Register(cfg.Hooks)
+	logger, err := dd.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { logger.Close() })
+	return logger
+}
+
+func TestHookSendsErrorEntries(t *testing.T) {
+	hub, transport := newTestHub(t)
+	hook := NewHook(Config{Hub: hub, Tags: []string{"tenant"}})
+	logger := newTestLogger(t, hook)
+
+	ctx := dd.WithTraceID(context.Background(), "trace-123")
+	logger.InfoCtx(ctx, "ignored")
+	logger.WarnCtx(ctx, "ignored too")
+	logger.ErrorCtx(ctx, "charge failed",
+		dd.ErrWithStack(errors.New("card declined")),
+		dd.String("tenant", "acme"),
+		dd.Int("amount", 42),
+	)
+
+	events := transport.Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	event := events[0]
+	if event.Level != sentrygo.LevelError || event.Message != "charge failed" || event.Logger != "dd" {
+		t.Errorf("unexpected event: level=%v message=%q logger=%q", event.Level, event.Message, event.Logger)
+	}
+	if event.Tags["tenant"] != "acme" || event.Tags[TraceIDKey] != "trace-123" {
+		t.Errorf("unexpected tags: %v", event.Tags)
+	}
+	if event.Extra["amount"] != 42 {
+		t.Errorf("unexpected extra: %v", event.Extra)
+	}
+
+	if len(event.Exception) != 1 {
+		t.Fatalf("expected 1 exception, got %d", len(event.Exception))
+	}
+	exception := event.Exception[0]
+	if exception.Type != "charge failed" || exception.Value != "card declined" {
+		t.Errorf("unexpected exception: %+v", exception)
+	}
+	if exception.Stacktrace == nil || len(exception.Stacktrace.Frames) == 0 {
+		t.Fatal("expected stack frames")
+	}
+	last := exception.Stacktrace.Frames[len(exception.Stacktrace.Frames)-1]
+	if last.Filename != "testing.go" || last.Module != "testing" || last.Lineno == 0 || last.InApp {
+		t.Errorf("most recent frame should be the test runner: %+v", last)
+	}
+	if hook.Sent() != 1 {
+		t.Errorf("Sent = %d, want 1", hook.Sent())
+	}
+}
+
+func TestHookFatalFlushes(t *testing.T) {
+	hub, transport := newTestHub(t)
+	logger := newTestLogger(t, NewHook(Config{Hub: hub}))
+
+	logger.Fatal("out of memory")
+
+	events := transport.Events()
+	if len(events) != 1 || events[0].Level != sentrygo.LevelFatal {
+		t.Fatalf("expected a fatal event, got %v", events)
+	}
+}
+
+func TestHookRateLimit(t *testing.T) {
+	hub, transport := newTestHub(t)
+	hook := NewHook(Config{Hub: hub, RateLimit: 1, Burst: 2})
+	logger := newTestLogger(t, hook)
+
+	for range 5 {
+		logger.Error("flood")
+	}
+
+	if n := len(transport.Events()); n != 2 {
+		t.Errorf("expected burst of 2 events, got %d", n)
+	}
+	if hook.Dropped() != 3 {
+		t.Errorf("Dropped = %d, want 3", hook.Dropped())
+	}
+}
+
+func TestHookBeforeSendAndFilter(t *testing.T) {
+	hub, transport := newTestHub(t)
+	hook := NewHook(Config{
+		Hub:    hub,
+		Filter: dd.NewBasicSensitiveDataFilter(),
+		BeforeSend: func(event *sentrygo.Event, hookCtx *dd.HookContext) *sentrygo.Event {
+			if hookCtx.Message == "skip" {
+				return nil
+			}
+			event.Tags["note"] = "password=hunter2"
+			return event
+		},
+	})
+	logger := newTestLogger(t, hook)
+
+	logger.Error("skip")
+	logger.Error("keep")
+
+	events := transport.Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if strings.Contains(events[0].Tags["note"], "hunter2") {
+		t.Errorf("tag should be redacted: %q", events[0].Tags["note"])
+	}
+	if hook.Dropped() != 1 {
+		t.Errorf("Dropped = %d, want 1", hook.Dropped())
+	}
+}
+
+func TestParseStackDebugFormat(t *testing.T) {
+	stack := "goroutine 1 [running]:\n" +
+		"main.handler(0x1)\n" +
+		"\t/app/main.go:20 +0x1d\n" +
+		"main.main()\n" +
+		"\t/app/main.go:10 +0x25\n"
+
+	frames := parseStack(stack)
+	if len(frames) != 2 {
+		t.Fatalf("expected 2 frames, got %d", len(frames))
+	}
+	if frames[0].Function != "main" || frames[0].Lineno != 10 {
+		t.Errorf("oldest frame should be main.main: %+v", frames[0])
+	}
+	if frames[1].Function != "handler" || frames[1].Module != "main" || frames[1].AbsPath != "/app/main.go" {
+		t.Errorf("unexpected frame: %+v", frames[1])
+	}
+}
+
+func TestTokenBucketRefills(t *testing.T) {
+	b := newTokenBucket(10, 1)
+	now := time.Unix(0, 0)
+	if !b.allow(now) || b.allow(now) {
+		t.Fatal("burst of 1 should allow exactly one event")
+	}
+	if !b.allow(now.Add(100 * time.Millisecond)) {
+		t.Error("bucket should refill at the configured rate")
+	}
+}
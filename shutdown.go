@@ -0,0 +1,153 @@
+package dd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// defaultShutdownHookTimeout bounds a shutdown hook without a Timeout.
+const defaultShutdownHookTimeout = 5 * time.Second
+
+// ShutdownHook is a step run when the logger is closed, such as flushing
+// an external sink or tracing exporter that must finish before the log
+// files are closed.
+type ShutdownHook struct {
+	// Name identifies the hook in ShutdownPlan and in errors.
+	Name string
+
+	// Run performs the step. Its context expires after Timeout or when
+	// the Shutdown context is done, whichever is first.
+	Run func(ctx context.Context) error
+
+	// Timeout bounds the hook. A hook still running at its deadline is
+	// abandoned and reported as ErrShutdownHookTimeout. Zero uses 5s.
+	Timeout time.Duration
+}
+
+// ShutdownStep is an entry of ShutdownPlan.
+type ShutdownStep struct {
+	Order   int           // position in the run order, from 1
+	Name    string        // ShutdownHook.Name
+	Timeout time.Duration // effective timeout
+}
+
+// OnShutdown registers a shutdown hook. Close and Shutdown run the hooks
+// in reverse registration order, one at a time, after the logger stops
+// accepting entries and before OnClose hooks run and writers are flushed
+// and closed, so a hook registered after the resource it depends on runs
+// before that resource is torn down. Hook errors, panics and timeouts are
+// returned by Close and Shutdown and do not stop the remaining hooks.
+// Returns ErrNilHook if Run is nil, or ErrLoggerClosed if the logger is
+// closed.
+//
+// Example:
+//
+//	logger.OnShutdown(dd.ShutdownHook{
+//	    Name:    "sentry",
+//	    Run:     func(ctx context.Context) error { sentry.Flush(2 * time.Second); return nil },
+//	    Timeout: 3 * time.Second,
+//	})
+func (l *Logger) OnShutdown(hook ShutdownHook) error {
+	if l == nil {
+		return ErrNilLogger
+	}
+	if hook.Run == nil {
+		return ErrNilHook
+	}
+	if hook.Timeout < 0 {
+		return fmt.Errorf("%w: shutdown hook %q has a negative timeout", ErrConfigValidation, hook.Name)
+	}
+
+	l.shutdownMu.Lock()
+	defer l.shutdownMu.Unlock()
+	if l.closed.Load() {
+		return ErrLoggerClosed
+	}
+	l.shutdownHooks = append(l.shutdownHooks, hook)
+	return nil
+}
+
+// ShutdownPlan returns the registered shutdown hooks in the order Close
+// and Shutdown will run them, without running them.
+func (l *Logger) ShutdownPlan() []ShutdownStep {
+	if l == nil {
+		return nil
+	}
+	l.shutdownMu.Lock()
+	hooks := slices.Clone(l.shutdownHooks)
+	l.shutdownMu.Unlock()
+
+	plan := make([]ShutdownStep, 0, len(hooks))
+	for i := len(hooks) - 1; i >= 0; i-- {
+		plan = append(plan, ShutdownStep{
+			Order:   len(plan) + 1,
+			Name:    hooks[i].Name,
+			Timeout: hooks[i].timeout(),
+		})
+	}
+	return plan
+}
+
+// timeout returns the effective timeout of the hook.
+func (h ShutdownHook) timeout() time.Duration {
+	if h.Timeout > 0 {
+		return h.Timeout
+	}
+	return defaultShutdownHookTimeout
+}
+
+// runShutdownHooks runs the shutdown hooks in reverse registration order
+// and returns their errors. The logger must already be marked closed.
+func (l *Logger) runShutdownHooks(ctx context.Context) error {
+	l.shutdownMu.Lock()
+	hooks := l.shutdownHooks
+	l.shutdownHooks = nil
+	l.shutdownMu.Unlock()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("shutdown hook %q not run: %w", hooks[i].Name, err))
+			continue
+		}
+		if err := hooks[i].run(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// run calls the hook with its timeout, recovering panics. A hook still
+// running at the deadline is abandoned.
+func (h ShutdownHook) run(parent context.Context) error {
+	timeout := h.timeout()
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("shutdown hook %q panicked: %v", h.Name, r)
+			}
+		}()
+		if err := h.Run(ctx); err != nil {
+			done <- fmt.Errorf("shutdown hook %q: %w", h.Name, err)
+			return
+		}
+		done <- nil
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if err := parent.Err(); err != nil {
+			return fmt.Errorf("shutdown hook %q: %w", h.Name, err)
+		}
+		return fmt.Errorf("shutdown hook %q: %w after %v", h.Name, ErrShutdownHookTimeout, timeout)
+	}
+}
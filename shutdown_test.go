@@ -0,0 +1,130 @@
+package dd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestShutdownHooksRunInReverseOrder(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) ShutdownHook {
+		return ShutdownHook{Name: name, Run: func(context.Context) error {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return nil
+		}}
+	}
+
+	cfg := DefaultConfig()
+	cfg.Output = io.Discard
+	cfg.ShutdownHooks = []ShutdownHook{record("metrics")}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.AddHook(HookOnClose, func(context.Context, *HookContext) error {
+		mu.Lock()
+		order = append(order, "OnClose")
+		mu.Unlock()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"tracing", "sentry"} {
+		if err := logger.OnShutdown(record(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	withTimeout := record("slow")
+	withTimeout.Timeout = time.Minute
+	if err := logger.OnShutdown(withTimeout); err != nil {
+		t.Fatal(err)
+	}
+
+	plan := logger.ShutdownPlan()
+	var names []string
+	for i, step := range plan {
+		if step.Order != i+1 {
+			t.Errorf("step %d has Order %d", i, step.Order)
+		}
+		names = append(names, step.Name)
+	}
+	if got := strings.Join(names, ","); got != "slow,sentry,tracing,metrics" {
+		t.Errorf("plan = %s", got)
+	}
+	if plan[0].Timeout != time.Minute || plan[1].Timeout != defaultShutdownHookTimeout {
+		t.Errorf("plan timeouts = %+v", plan)
+	}
+	if len(order) != 0 {
+		t.Fatalf("ShutdownPlan ran hooks: %v", order)
+	}
+
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(order, ","); got != "slow,sentry,tracing,metrics,OnClose" {
+		t.Errorf("order = %s", got)
+	}
+	if err := logger.OnShutdown(record("late")); !errors.Is(err, ErrLoggerClosed) {
+		t.Errorf("OnShutdown after Close = %v", err)
+	}
+}
+
+func TestShutdownHookErrorsAndTimeouts(t *testing.T) {
+	logger, err := New(&Config{Output: io.Discard, Format: FormatText, Level: LevelInfo})
+	if err != nil {
+		t.Fatal(err)
+	}
+	boom := errors.New("boom")
+	ran := false
+	hooks := []ShutdownHook{
+		{Name: "last", Run: func(context.Context) error { ran = true; return nil }},
+		{Name: "stuck", Timeout: 20 * time.Millisecond, Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		}},
+		{Name: "panics", Run: func(context.Context) error { panic("bad") }},
+		{Name: "fails", Run: func(context.Context) error { return boom }},
+	}
+	for _, h := range hooks {
+		if err := logger.OnShutdown(h); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = logger.Shutdown(context.Background())
+	if !errors.Is(err, boom) || !errors.Is(err, ErrShutdownHookTimeout) || !strings.Contains(err.Error(), `"panics" panicked`) {
+		t.Errorf("Shutdown = %v", err)
+	}
+	if !ran {
+		t.Error("hook after failures did not run")
+	}
+}
+
+func TestShutdownHookValidation(t *testing.T) {
+	logger, err := New(&Config{Output: io.Discard, Format: FormatText, Level: LevelInfo})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+	if err := logger.OnShutdown(ShutdownHook{Name: "nil"}); !errors.Is(err, ErrNilHook) {
+		t.Errorf("nil Run = %v", err)
+	}
+	if err := logger.OnShutdown(ShutdownHook{Run: func(context.Context) error { return nil }, Timeout: -1}); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("negative timeout = %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.ShutdownHooks = []ShutdownHook{{Name: "nil"}}
+	if _, err := New(cfg); !errors.Is(err, ErrNilHook) {
+		t.Errorf("New = %v", err)
+	}
+}
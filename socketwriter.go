@@ -0,0 +1,397 @@
+package dd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// Networks of a SocketWriter.
+const (
+	// SocketFIFO writes to a POSIX named pipe (not supported on Windows).
+	SocketFIFO = "fifo"
+	// SocketUnix writes to a Unix domain stream socket.
+	SocketUnix = "unix"
+	// SocketUnixgram sends each entry as one Unix domain datagram.
+	SocketUnixgram = "unixgram"
+)
+
+const (
+	defaultSocketBufferSize        = 1024
+	defaultSocketReconnectDelay    = 100 * time.Millisecond
+	defaultSocketMaxReconnectDelay = 5 * time.Second
+	defaultSocketWriteTimeout      = 5 * time.Second
+	defaultSocketCoalesceBytes     = 64 * 1024
+)
+
+// SocketConfig configures a SocketWriter.
+type SocketConfig struct {
+	// Network is SocketFIFO, SocketUnix or SocketUnixgram.
+	Network string
+
+	// Path is the named pipe or socket path.
+	Path string
+
+	// BufferSize bounds the entries held while the reader is missing or
+	// slow; further entries are dropped. Zero uses 1024.
+	BufferSize int
+
+	// ReconnectDelay is the wait after a failed connection attempt; it
+	// doubles on each further failure up to MaxReconnectDelay. Zero uses
+	// 100ms.
+	ReconnectDelay time.Duration
+
+	// MaxReconnectDelay caps the wait between connection attempts. Zero
+	// uses 5s.
+	MaxReconnectDelay time.Duration
+
+	// WriteTimeout bounds each write, so a reader that stops reading
+	// without closing cannot stall Flush and Close. Zero uses 5s.
+	WriteTimeout time.Duration
+
+	// CoalesceBytes bounds the entries written with one system call on
+	// streams (SocketFIFO, SocketUnix): a burst of buffered entries goes
+	// out in one vectored write (writev) up to this many bytes. An entry
+	// larger than the limit is written on its own. Zero uses 64KB; 1
+	// writes every entry separately. Datagrams are always one per entry.
+	CoalesceBytes int
+}
+
+// SocketWriter writes entries to a local collector (vector, fluent-bit)
+// through a named pipe or a Unix domain socket. It never blocks logging:
+// entries are buffered and written by a background goroutine, which opens
+// the pipe or socket when a reader is available and reconnects when the
+// reader goes away (EPIPE, ECONNREFUSED). A plain os.OpenFile on a FIFO,
+// by contrast, blocks until a reader attaches.
+//
+// Example:
+//
+//	sock, err := dd.NewSocketWriter(dd.SocketConfig{
+//	    Network: dd.SocketUnixgram,
+//	    Path:    "/var/run/vector.sock",
+//	})
+//	cfg := dd.JSONConfig()
+//	cfg.Outputs = []io.Writer{os.Stdout, sock}
+type SocketWriter struct {
+	network    string
+	path       string
+	bufferSize int
+	delay      time.Duration
+	maxDelay   time.Duration
+	timeout    time.Duration
+	coalesce   int
+
+	mu           sync.Mutex
+	pending      [][]byte
+	pendingBytes atomic.Int64 // size of the buffered entries, including those being written
+
+	sendMu sync.Mutex     // serializes writes so entries arrive in order
+	conn   io.WriteCloser // guarded by sendMu; nil while disconnected
+	failed bool           // guarded by sendMu; the last attempt failed
+
+	wake       chan struct{}
+	done       chan struct{}
+	wg         sync.WaitGroup
+	dropped    atomic.Int64
+	reconnects atomic.Int64
+	writes     atomic.Int64
+	closed     atomic.Bool
+}
+
+// NewSocketWriter creates a SocketWriter and starts its background
+// writer. The pipe or socket does not need to exist yet. Call Close to
+// write the remaining entries and stop it.
+func NewSocketWriter(cfg SocketConfig) (*SocketWriter, error) {
+	switch cfg.Network {
+	case SocketFIFO, SocketUnix, SocketUnixgram:
+	default:
+		return nil, fmt.Errorf("%w: unsupported socket network %q", ErrConfigValidation, cfg.Network)
+	}
+	if cfg.Path == "" {
+		return nil, ErrEmptyFilePath
+	}
+	if cfg.BufferSize < 0 || cfg.ReconnectDelay < 0 || cfg.MaxReconnectDelay < 0 || cfg.WriteTimeout < 0 || cfg.CoalesceBytes < 0 {
+		return nil, fmt.Errorf("%w: SocketConfig values must not be negative", ErrConfigValidation)
+	}
+
+	sw := &SocketWriter{
+		network:    cfg.Network,
+		path:       cfg.Path,
+		bufferSize: cfg.BufferSize,
+		delay:      cfg.ReconnectDelay,
+		maxDelay:   cfg.MaxReconnectDelay,
+		timeout:    cfg.WriteTimeout,
+		coalesce:   cfg.CoalesceBytes,
+		wake:       make(chan struct{}, 1),
+		done:       make(chan struct{}),
+	}
+	if sw.bufferSize == 0 {
+		sw.bufferSize = defaultSocketBufferSize
+	}
+	if sw.delay == 0 {
+		sw.delay = defaultSocketReconnectDelay
+	}
+	if sw.maxDelay == 0 {
+		sw.maxDelay = defaultSocketMaxReconnectDelay
+	}
+	sw.maxDelay = max(sw.maxDelay, sw.delay)
+	if sw.timeout == 0 {
+		sw.timeout = defaultSocketWriteTimeout
+	}
+	if sw.coalesce == 0 {
+		sw.coalesce = defaultSocketCoalesceBytes
+	}
+
+	sw.wg.Add(1)
+	go sw.run()
+	return sw, nil
+}
+
+// Write buffers a copy of p for the background writer. When the buffer is
+// full the entry is dropped and counted in Dropped.
+func (sw *SocketWriter) Write(p []byte) (int, error) {
+	if sw.closed.Load() {
+		return 0, ErrLoggerClosed
+	}
+
+	sw.mu.Lock()
+	if len(sw.pending) >= sw.bufferSize {
+		sw.mu.Unlock()
+		sw.dropped.Add(1)
+		return len(p), nil
+	}
+	sw.pending = append(sw.pending, bytes.Clone(p))
+	sw.pendingBytes.Add(int64(len(p)))
+	sw.mu.Unlock()
+
+	select {
+	case sw.wake <- struct{}{}:
+	default:
+	}
+	return len(p), nil
+}
+
+// run writes buffered entries as they arrive, retrying with backoff while
+// the reader is unavailable.
+func (sw *SocketWriter) run() {
+	defer sw.wg.Done()
+	delay := sw.delay
+	var retry <-chan time.Time
+
+	for {
+		select {
+		case <-sw.done:
+			return
+		case <-sw.wake:
+		case <-retry:
+		}
+		if err := sw.Flush(); err != nil {
+			retry = time.After(delay)
+			delay = min(delay*2, sw.maxDelay)
+			continue
+		}
+		retry, delay = nil, sw.delay
+	}
+}
+
+// Flush writes all buffered entries, connecting first if needed. It
+// returns the connection or write error that stopped it; the entries not
+// written stay buffered.
+func (sw *SocketWriter) Flush() error {
+	sw.sendMu.Lock()
+	defer sw.sendMu.Unlock()
+
+	for {
+		sw.mu.Lock()
+		batch := sw.pending
+		sw.pending = nil
+		sw.mu.Unlock()
+		if len(batch) == 0 {
+			return nil
+		}
+
+		for len(batch) > 0 {
+			n, err := sw.send(batch[:sw.chunkLen(batch)])
+			if err != nil {
+				sw.requeue(batch)
+				sw.reportFailure(err)
+				return err
+			}
+			sw.pendingBytes.Add(-entriesSize(batch[:n]))
+			batch = batch[n:]
+		}
+		sw.failed = false
+	}
+}
+
+// chunkLen returns the number of leading entries of batch to write with
+// one system call: one for datagrams, otherwise as many as fit in
+// CoalesceBytes, and at least one.
+func (sw *SocketWriter) chunkLen(batch [][]byte) int {
+	if sw.network == SocketUnixgram {
+		return 1
+	}
+	n, size := 1, len(batch[0])
+	for n < len(batch) && size+len(batch[n]) <= sw.coalesce {
+		size += len(batch[n])
+		n++
+	}
+	return n
+}
+
+// send writes a chunk of entries with one system call, reconnecting once
+// if the connection is broken, and returns the number of entries done.
+// An entry that was partly written to a stream is dropped rather than
+// repeated.
+func (sw *SocketWriter) send(chunk [][]byte) (int, error) {
+	for attempt := 0; ; attempt++ {
+		if sw.conn == nil {
+			conn, err := sw.dial()
+			if err != nil {
+				return 0, err
+			}
+			sw.conn = conn
+			sw.reconnects.Add(1)
+		}
+
+		if d, ok := sw.conn.(interface{ SetWriteDeadline(time.Time) error }); ok {
+			_ = d.SetWriteDeadline(time.Now().Add(sw.timeout))
+		}
+		n, err := sw.write(chunk)
+		if err == nil {
+			return len(chunk), nil
+		}
+		sw.conn.Close()
+		sw.conn = nil
+		if n > 0 {
+			done := 0
+			for done < len(chunk) && n >= int64(len(chunk[done])) {
+				n -= int64(len(chunk[done]))
+				done++
+			}
+			if n > 0 {
+				sw.dropped.Add(1)
+				done++
+			}
+			return done, nil
+		}
+		if attempt > 0 {
+			return 0, err
+		}
+	}
+}
+
+// write makes one system call for chunk: writev on sockets, a single
+// write of the joined entries on pipes.
+func (sw *SocketWriter) write(chunk [][]byte) (int64, error) {
+	sw.writes.Add(1)
+	if len(chunk) == 1 {
+		n, err := sw.conn.Write(chunk[0])
+		return int64(n), err
+	}
+	if _, ok := sw.conn.(net.Conn); ok {
+		buffers := net.Buffers(slices.Clone(chunk))
+		return buffers.WriteTo(sw.conn)
+	}
+	n, err := sw.conn.Write(bytes.Join(chunk, nil))
+	return int64(n), err
+}
+
+// dial opens the pipe or connects to the socket.
+func (sw *SocketWriter) dial() (io.WriteCloser, error) {
+	if sw.network == SocketFIFO {
+		return internal.OpenFIFO(sw.path)
+	}
+	return net.Dial(sw.network, sw.path)
+}
+
+// requeue puts unwritten entries back in front of those buffered since,
+// dropping the newest beyond BufferSize.
+func (sw *SocketWriter) requeue(rest [][]byte) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	pending := append(rest, sw.pending...)
+	if excess := len(pending) - sw.bufferSize; excess > 0 {
+		sw.pendingBytes.Add(-entriesSize(pending[sw.bufferSize:]))
+		pending = pending[:sw.bufferSize]
+		sw.dropped.Add(int64(excess))
+	}
+	sw.pending = pending
+}
+
+// reportFailure prints the first failure after a successful write, so a
+// missing reader is reported once rather than on every retry.
+func (sw *SocketWriter) reportFailure(err error) {
+	if sw.failed {
+		return
+	}
+	sw.failed = true
+	reportInternal(nil, ComponentWriter, err, "%s writer %s unavailable, buffering entries: %v", sw.network, sw.path, err)
+}
+
+// Dropped returns the number of entries discarded because the buffer was
+// full or they were cut off by a broken stream.
+func (sw *SocketWriter) Dropped() int64 {
+	return sw.dropped.Load()
+}
+
+// MemoryUsage implements MemoryUser: the size of the buffered entries.
+func (sw *SocketWriter) MemoryUsage() int64 {
+	return sw.pendingBytes.Load()
+}
+
+// entriesSize returns the total size of entries.
+func entriesSize(entries [][]byte) int64 {
+	var size int64
+	for _, entry := range entries {
+		size += int64(len(entry))
+	}
+	return size
+}
+
+// Reconnects returns the number of times the pipe or socket was opened.
+func (sw *SocketWriter) Reconnects() int64 {
+	return sw.reconnects.Load()
+}
+
+// Writes returns the number of write system calls made to the pipe or
+// socket. With bursts of entries it is lower than the number of entries
+// written; see SocketConfig.CoalesceBytes.
+func (sw *SocketWriter) Writes() int64 {
+	return sw.writes.Load()
+}
+
+// Close stops the background writer, makes a last attempt to write the
+// buffered entries and closes the pipe or socket. Entries still buffered
+// are dropped.
+func (sw *SocketWriter) Close() error {
+	if !sw.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	close(sw.done)
+	sw.wg.Wait()
+
+	err := sw.Flush()
+
+	sw.sendMu.Lock()
+	defer sw.sendMu.Unlock()
+	sw.mu.Lock()
+	sw.dropped.Add(int64(len(sw.pending)))
+	sw.pending = nil
+	sw.pendingBytes.Store(0)
+	sw.mu.Unlock()
+	if sw.conn != nil {
+		if cerr := sw.conn.Close(); err == nil {
+			err = cerr
+		}
+		sw.conn = nil
+	}
+	return err
+}
@@ -0,0 +1,215 @@
+//go:build !windows
+
+package dd
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestSocketWriterFIFO(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.fifo")
+	if err := syscall.Mkfifo(path, 0o600); err != nil {
+		t.Skipf("mkfifo: %v", err)
+	}
+
+	// No reader yet: writes must not block.
+	sw, err := NewSocketWriter(SocketConfig{Network: SocketFIFO, Path: path, ReconnectDelay: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sw.Close()
+	if _, err := sw.Write([]byte("first\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := sw.Flush(); err == nil {
+		t.Fatal("Flush without a reader succeeded")
+	}
+
+	readLine := func(r *os.File) string {
+		t.Helper()
+		_ = r.SetReadDeadline(time.Now().Add(5 * time.Second))
+		line, err := bufio.NewReader(r).ReadString('\n')
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		return line
+	}
+
+	reader, err := os.OpenFile(path, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := readLine(reader); got != "first\n" {
+		t.Errorf("got %q", got)
+	}
+
+	// The reader goes away and a new one attaches: the writer reopens.
+	reader.Close()
+	sw.Write([]byte("second\n"))
+	_ = sw.Flush()
+	reader, err = os.OpenFile(path, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	if err := sw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := readLine(reader); got != "second\n" {
+		t.Errorf("got %q", got)
+	}
+	if sw.Reconnects() != 2 || sw.Dropped() != 0 {
+		t.Errorf("reconnects = %d, dropped = %d", sw.Reconnects(), sw.Dropped())
+	}
+}
+
+func TestSocketWriterUnixgram(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "s.sock")
+	sw, err := NewSocketWriter(SocketConfig{Network: SocketUnixgram, Path: path, ReconnectDelay: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sw.Close()
+
+	cfg := DefaultConfig()
+	cfg.Output = sw
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("buffered until the collector starts")
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if err := sw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 4096)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(buf[:n]); !strings.Contains(got, "buffered until the collector starts") {
+		t.Errorf("datagram = %q", got)
+	}
+}
+
+func TestSocketWriterBufferLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.sock")
+	sw, err := NewSocketWriter(SocketConfig{Network: SocketUnix, Path: path, BufferSize: 2, ReconnectDelay: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := sw.Write([]byte("entry\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sw.Close(); err == nil {
+		t.Error("Close without a reader succeeded")
+	}
+	if sw.Dropped() != 3 {
+		t.Errorf("dropped = %d, want 3", sw.Dropped())
+	}
+	if _, err := sw.Write([]byte("late\n")); !errors.Is(err, ErrLoggerClosed) {
+		t.Errorf("write after close: err = %v", err)
+	}
+}
+
+func TestNewSocketWriterValidation(t *testing.T) {
+	if _, err := NewSocketWriter(SocketConfig{Network: "tcp", Path: "x"}); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("network: err = %v", err)
+	}
+	if _, err := NewSocketWriter(SocketConfig{Network: SocketUnix}); !errors.Is(err, ErrEmptyFilePath) {
+		t.Errorf("path: err = %v", err)
+	}
+}
+
+func TestSocketWriterCoalescesBursts(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		coalesce  int
+		wantWrite int64
+	}{
+		{"writev", 0, 1},
+		{"one per entry", 1, 50},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "s.sock")
+			sw, err := NewSocketWriter(SocketConfig{Network: SocketUnix, Path: path, ReconnectDelay: time.Hour, CoalesceBytes: tt.coalesce})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sw.Close()
+			for i := 0; i < 50; i++ {
+				sw.Write([]byte("entry\n"))
+			}
+
+			ln, err := net.Listen("unix", path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer ln.Close()
+			received := make(chan int, 1)
+			go func() {
+				conn, err := ln.Accept()
+				if err != nil {
+					received <- 0
+					return
+				}
+				defer conn.Close()
+				_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+				r := bufio.NewReader(conn)
+				n := 0
+				for n < 50 {
+					if _, err := r.ReadString('\n'); err != nil {
+						break
+					}
+					n++
+				}
+				received <- n
+			}()
+
+			if err := sw.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if n := <-received; n != 50 {
+				t.Errorf("received %d entries, want 50", n)
+			}
+			if got := sw.Writes(); got != tt.wantWrite {
+				t.Errorf("Writes = %d, want %d", got, tt.wantWrite)
+			}
+		})
+	}
+}
+
+func TestSocketWriterChunkLen(t *testing.T) {
+	sw := &SocketWriter{network: SocketUnix, coalesce: 10}
+	batch := [][]byte{make([]byte, 4), make([]byte, 4), make([]byte, 4), make([]byte, 20)}
+	if got := sw.chunkLen(batch); got != 2 {
+		t.Errorf("chunkLen = %d, want 2", got)
+	}
+	if got := sw.chunkLen(batch[3:]); got != 1 {
+		t.Errorf("oversized entry: chunkLen = %d, want 1", got)
+	}
+	sw.network = SocketUnixgram
+	if got := sw.chunkLen(batch); got != 1 {
+		t.Errorf("datagrams: chunkLen = %d, want 1", got)
+	}
+}
@@ -0,0 +1,42 @@
+package dd
+
+import (
+	"log"
+)
+
+// StdLogger returns a standard library *log.Logger that writes through the
+// logger at the given level (see WriterLevel). The returned logger has no
+// prefix or flags, since the entries get dd's own timestamp and caller.
+//
+// Example:
+//
+//	srv := &http.Server{ErrorLog: logger.StdLogger(dd.LevelWarn)}
+func (l *Logger) StdLogger(level LogLevel) *log.Logger {
+	return log.New(l.WriterLevel(level), "", 0)
+}
+
+// RedirectStdLog redirects the standard library's default logger (log.Printf
+// and friends) into logger at LevelInfo, so third-party code that uses the
+// log package gets the logger's rotation, filtering and format. It returns
+// a function that restores the previous output, prefix and flags.
+//
+// Example:
+//
+//	restore := dd.RedirectStdLog(logger)
+//	defer restore()
+func RedirectStdLog(logger *Logger) func() {
+	if logger == nil {
+		return func() {}
+	}
+
+	writer, prefix, flags := log.Writer(), log.Prefix(), log.Flags()
+	log.SetOutput(logger.WriterLevel(LevelInfo))
+	log.SetPrefix("")
+	log.SetFlags(0)
+
+	return func() {
+		log.SetOutput(writer)
+		log.SetPrefix(prefix)
+		log.SetFlags(flags)
+	}
+}
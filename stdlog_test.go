@@ -0,0 +1,54 @@
+package dd
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestStdLogger(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	std := logger.StdLogger(LevelError)
+	std.Printf("query failed: %s", "timeout")
+
+	out := buf.String()
+	if !strings.Contains(out, "ERROR") || !strings.Contains(out, "query failed: timeout") {
+		t.Errorf("unexpected output %q", out)
+	}
+	if std.Flags() != 0 || std.Prefix() != "" {
+		t.Error("StdLogger should not add its own timestamp or prefix")
+	}
+}
+
+func TestRedirectStdLog(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	var original bytes.Buffer
+	log.SetOutput(&original)
+	log.SetFlags(log.LstdFlags)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	}()
+
+	restore := RedirectStdLog(logger)
+	log.Printf("from a dependency password=hunter22")
+	restore()
+	log.Print("after restore")
+
+	out := buf.String()
+	if !strings.Contains(out, "INFO") || !strings.Contains(out, "from a dependency") {
+		t.Errorf("std log output should go through the logger: %q", out)
+	}
+	if strings.Contains(out, "hunter22") {
+		t.Errorf("redirected lines should be filtered: %q", out)
+	}
+	if !strings.Contains(original.String(), "after restore") || log.Flags() != log.LstdFlags {
+		t.Errorf("restore should bring back the previous output and flags")
+	}
+
+	RedirectStdLog(nil)()
+}
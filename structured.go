@@ -1,6 +1,8 @@
 package dd
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"runtime"
 	"strings"
@@ -93,11 +95,78 @@ func Duration(key string, value time.Duration) Field {
 	return Field{Key: key, Value: value}
 }
 
+// ByteSize creates a field with a byte count. Text and console output
+// render it with IEC units ("4.2 MiB"); JSON output keeps the number.
+func ByteSize(key string, n int64) Field {
+	return Field{Key: key, Value: internal.ByteSize(n)}
+}
+
 // Time creates a field with a time.Time value.
 func Time(key string, value time.Time) Field {
 	return Field{Key: key, Value: value}
 }
 
+// TimeLayout creates a field with a time.Time value formatted using layout
+// (e.g. time.RFC3339Nano or time.Kitchen) instead of the default RFC3339.
+func TimeLayout(key string, value time.Time, layout string) Field {
+	return Field{Key: key, Value: value.Format(layout)}
+}
+
+// Bytes creates a field with a byte slice encoded as standard base64.
+func Bytes(key string, value []byte) Field {
+	return Field{Key: key, Value: base64.StdEncoding.EncodeToString(value)}
+}
+
+// BytesHex creates a field with a byte slice encoded as lowercase hex.
+func BytesHex(key string, value []byte) Field {
+	return Field{Key: key, Value: hex.EncodeToString(value)}
+}
+
+// Stringer creates a field whose value is produced by value.String().
+// String is only called if the entry is actually logged.
+func Stringer(key string, value fmt.Stringer) Field {
+	if value == nil {
+		return Field{Key: key, Value: nil}
+	}
+	return Field{Key: key, Value: internal.StringerValue{Stringer: value}}
+}
+
+// Strings creates a field with a string slice value.
+func Strings(key string, value []string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Ints creates a field with an int slice value.
+func Ints(key string, value []int) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int64s creates a field with an int64 slice value.
+func Int64s(key string, value []int64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Uint64s creates a field with a uint64 slice value.
+func Uint64s(key string, value []uint64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Float64s creates a field with a float64 slice value.
+func Float64s(key string, value []float64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Bools creates a field with a bool slice value.
+func Bools(key string, value []bool) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Durations creates a field with a time.Duration slice value.
+// Each element is rendered like a Duration field (e.g. "1.5s").
+func Durations(key string, value []time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
 // Err creates a field from an error.
 // If the error is nil, the value will be nil.
 // Otherwise, the value will be the error's message string.
@@ -0,0 +1,150 @@
+package dd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// Tee returns a logger that passes every call to each of loggers in turn.
+// Each logger applies its own level, sampling, security filtering, hooks and
+// format, so one call can produce a JSON entry for a collector and a
+// console line for a developer.
+//
+// A writer shared by several loggers (compared with ==) receives each entry
+// only once, from the first logger that writes it, so a file both loggers
+// append to does not get duplicate lines.
+//
+// The returned logger's own level is LevelDebug; raising it filters entries
+// before any of the loggers see them. It has no writers of its own. Flush,
+// Close and Shutdown apply to every logger. A FATAL entry is written by all
+// loggers before the program ends once, following the FatalPolicy and
+// FatalHandler of the first logger. Nil loggers are ignored.
+//
+// Example:
+//
+//	app, _ := dd.New(jsonConfig)     // JSON to logs/app.log
+//	dev, _ := dd.New(dd.DevelopmentConfig())
+//	logger := dd.Tee(app, dev)
+//	defer logger.Close()
+//	logger.InfoWith("listening", dd.Int("port", 8080))
+func Tee(loggers ...*Logger) LogProvider {
+	members := make([]*Logger, 0, len(loggers))
+	for _, l := range loggers {
+		if l != nil {
+			members = append(members, l)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &Logger{
+		callerDepth: defaultCallerDepth,
+		clock:       systemClock{},
+		formatter: internal.NewMessageFormatter(&internal.FormatterConfig{
+			Format:     internal.LogFormatText,
+			TimeFormat: DefaultTimeFormat,
+		}),
+		tee:    &teeState{loggers: members},
+		events: newEventsState(nil),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	if len(members) > 0 {
+		first := members[0]
+		l.clock = first.clock
+		l.fatalHandler = first.fatalHandler
+		l.fatalPolicy = first.fatalPolicy
+		l.fatalTimeout = first.fatalTimeout
+	}
+	l.level.Store(int32(LevelDebug))
+	// Each logger filters with its own security config
+	l.securityConfig.Store(&SecurityConfig{})
+	writers := make([]io.Writer, 0)
+	l.writersPtr.Store(&writers)
+	return l
+}
+
+// teeState holds the loggers of a Tee.
+type teeState struct {
+	loggers []*Logger
+	dual    *dualWriteCounters // non-nil for DualWrite
+}
+
+// each calls fn for every logger and joins the errors.
+func (t *teeState) each(fn func(*Logger) error) error {
+	var errs []error
+	for _, l := range t.loggers {
+		if err := fn(l); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// teeWriters records the writers one Tee entry has been written to.
+type teeWriters struct {
+	written   []io.Writer
+	delivered int // loggers that reached their writers, for DualWrite
+}
+
+// claim reports whether w has not received the entry yet and marks it.
+// Writers of uncomparable types cannot be matched and always receive it.
+func (t *teeWriters) claim(w io.Writer) bool {
+	if !reflect.TypeOf(w).Comparable() {
+		return true
+	}
+	if slices.Contains(t.written, w) {
+		return false
+	}
+	t.written = append(t.written, w)
+	return true
+}
+
+// writeTee passes an entry that passed the Tee's own level and hooks to
+// each logger. callerDepth is the Tee's caller depth for the entry.
+func (l *Logger) writeTee(level LogLevel, entry *logEntry, callerDepth int) {
+	written := &teeWriters{}
+	ctx := entry.context()
+	dual := l.tee.dual
+	if dual != nil {
+		// One level, sampling and rate limit decision for both copies
+		if len(l.tee.loggers) == 0 || !l.tee.loggers[0].shouldLogCtx(ctx, level) {
+			return
+		}
+	}
+	var wrote [2]bool
+	for i, m := range l.tee.loggers {
+		if dual == nil && !m.shouldLogCtx(ctx, level) {
+			continue
+		}
+
+		originalFields := entry.originalFields
+		if originalFields == nil && m.hooks.Load() != nil && len(entry.fields) > 0 {
+			originalFields = slices.Clone(entry.fields)
+		}
+
+		before := written.delivered
+		// writeTee adds one frame between the caller and m.logCoreWithDepth
+		m.logCoreWithDepth(level, logEntry{
+			ctx:            entry.ctx,
+			msg:            m.applyMessageSecurity(entry.msg),
+			fields:         m.processFields(entry.fields),
+			originalFields: originalFields,
+			template:       entry.template,
+			fatalPanic:     entry.fatalPanic,
+			tee:            written,
+			time:           entry.time,
+		}, callerDepth+1-m.callerDepth)
+
+		if dual != nil && written.delivered > before {
+			wrote[i] = true
+		}
+	}
+	if dual != nil {
+		dual.record(wrote)
+	}
+}
@@ -0,0 +1,124 @@
+package dd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestTeeFormatsPerLogger(t *testing.T) {
+	textLogger, text := newTestLogger(t, nil)
+	jsonLogger, js := newTestLogger(t, func(cfg *Config) { cfg.Format = FormatJSON })
+	tee := Tee(textLogger, jsonLogger)
+
+	tee.InfoWith("order placed", Int("order", 42))
+
+	if !strings.Contains(text.String(), "INFO") || !strings.Contains(text.String(), "order placed order=42") {
+		t.Errorf("unexpected text output: %q", text.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(js.Bytes(), &entry); err != nil {
+		t.Fatalf("JSON output is invalid: %v: %q", err, js.String())
+	}
+	if entry["message"] != "order placed" {
+		t.Errorf("unexpected JSON entry: %v", entry)
+	}
+}
+
+func TestTeeSharedWriterWrittenOnce(t *testing.T) {
+	textLogger, shared := newTestLogger(t, nil)
+	jsonLogger, own := newTestLogger(t, func(cfg *Config) {
+		cfg.Format = FormatJSON
+		cfg.Outputs = []io.Writer{shared}
+	})
+	tee := Tee(textLogger, jsonLogger)
+
+	tee.Info("once")
+	tee.WithFields(String("k", "v")).Warn("twice")
+
+	if n := strings.Count(shared.String(), "\n"); n != 2 {
+		t.Errorf("shared writer should get one line per entry, got %d: %q", n, shared.String())
+	}
+	if strings.Contains(shared.String(), "{") {
+		t.Errorf("shared writer should get the first logger's text format: %q", shared.String())
+	}
+	if n := strings.Count(own.String(), "\n"); n != 2 {
+		t.Errorf("unshared writer should get every entry, got %d: %q", n, own.String())
+	}
+}
+
+func TestTeeLevelsAndSecurityPerLogger(t *testing.T) {
+	debugLogger, debug := newTestLogger(t, func(cfg *Config) {
+		cfg.Level = LevelDebug
+		cfg.Security = &SecurityConfig{}
+	})
+	warnLogger, warn := newTestLogger(t, func(cfg *Config) { cfg.Level = LevelWarn })
+	tee := Tee(debugLogger, warnLogger)
+
+	tee.DebugWith("login", String("password", "hunter2"))
+	tee.WarnWith("retry", String("password", "hunter2"))
+
+	if !strings.Contains(debug.String(), "login") || !strings.Contains(debug.String(), "hunter2") {
+		t.Errorf("unfiltered debug logger should get both entries in full: %q", debug.String())
+	}
+	if strings.Contains(warn.String(), "login") {
+		t.Errorf("warn logger should skip DEBUG entries: %q", warn.String())
+	}
+	if !strings.Contains(warn.String(), "retry") || strings.Contains(warn.String(), "hunter2") {
+		t.Errorf("warn logger should redact the password: %q", warn.String())
+	}
+}
+
+func TestTeeHooksPerLogger(t *testing.T) {
+	var seen []string
+	hooks := NewHookRegistry()
+	hooks.Add(HookAfterLog, func(_ context.Context, h *HookContext) error {
+		seen = append(seen, h.Message)
+		return nil
+	})
+	hooked, _ := newTestLogger(t, func(cfg *Config) { cfg.Hooks = hooks })
+	plain, _ := newTestLogger(t, nil)
+	tee := Tee(hooked, plain)
+
+	tee.Info("a")
+	tee.Info("b")
+
+	if strings.Join(seen, ",") != "a,b" {
+		t.Errorf("hooks should run once per entry, got %v", seen)
+	}
+}
+
+func TestTeeFatalExitsOnce(t *testing.T) {
+	exits := 0
+	a, first := newTestLogger(t, func(cfg *Config) { cfg.FatalHandler = func() { exits++ } })
+	b, second := newTestLogger(t, func(cfg *Config) {
+		cfg.FatalHandler = func() { t.Error("second FatalHandler should not run") }
+	})
+
+	Tee(a, b).Fatal("boom")
+
+	if exits != 1 {
+		t.Errorf("FatalHandler should run once, ran %d times", exits)
+	}
+	if !strings.Contains(first.String(), "boom") || !strings.Contains(second.String(), "boom") {
+		t.Errorf("both loggers should write the FATAL entry: %q %q", first.String(), second.String())
+	}
+	if !a.IsClosed() || !b.IsClosed() {
+		t.Error("loggers should be closed after FATAL")
+	}
+}
+
+func TestTeeCloseClosesLoggers(t *testing.T) {
+	a, _ := newTestLogger(t, nil)
+	b, _ := newTestLogger(t, nil)
+	tee := Tee(a, nil, b)
+
+	if err := tee.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !tee.IsClosed() || !a.IsClosed() || !b.IsClosed() {
+		t.Error("Close should close the tee and every logger")
+	}
+}
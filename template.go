@@ -0,0 +1,89 @@
+package dd
+
+// Message templates
+//
+// The T methods take a message template with {name} placeholders that are
+// replaced by the values of the fields with the same key. The fields are
+// still emitted as structured data, so one call gives readable text output
+// and machine-readable JSON:
+//
+//	logger.InfoT("user {user} bought {count} items", dd.String("user", "alice"), dd.Int("count", 3))
+//	// text: ... user alice bought 3 items user=alice count=3
+//	// json: {"message":"user alice bought 3 items","fields":{"user":"alice","count":3}}
+//
+// Placeholders are filled after sensitive data filtering, so redacted
+// values stay redacted in the message, and the rendered message is
+// sanitized like any other. Placeholders without a matching
+// field are kept as written; use {{ and }} for literal braces. On a
+// LoggerEntry, fields from WithFields can be referenced as well.
+// Hooks see the unrendered template as HookContext.Message.
+
+// LogT logs a message template at the specified level.
+func (l *Logger) LogT(level LogLevel, template string, fields ...Field) {
+	if !l.shouldLog(level) {
+		return
+	}
+
+	// Only copy original fields if hooks are registered (they may need them)
+	var originalFields []Field
+	if l.hooks.Load() != nil && len(fields) > 0 {
+		originalFields = make([]Field, len(fields))
+		copy(originalFields, fields)
+	}
+
+	l.logCore(level, logEntry{
+		msg:            l.applyMessageSecurity(template),
+		fields:         l.processFields(fields),
+		originalFields: originalFields,
+		template:       true,
+	})
+}
+
+func (l *Logger) DebugT(template string, fields ...Field) { l.LogT(LevelDebug, template, fields...) }
+func (l *Logger) InfoT(template string, fields ...Field)  { l.LogT(LevelInfo, template, fields...) }
+func (l *Logger) WarnT(template string, fields ...Field)  { l.LogT(LevelWarn, template, fields...) }
+func (l *Logger) ErrorT(template string, fields ...Field) { l.LogT(LevelError, template, fields...) }
+
+// FatalT logs a message template at FATAL level and terminates the program via os.Exit(1).
+// WARNING: defer statements will NOT execute. For graceful shutdown, use ErrorT() with custom logic.
+func (l *Logger) FatalT(template string, fields ...Field) { l.LogT(LevelFatal, template, fields...) }
+
+// LogT logs a message template with the entry's fields plus additional fields.
+func (e *LoggerEntry) LogT(level LogLevel, template string, fields ...Field) {
+	if e.logger.nopEntry != nil {
+		return
+	}
+	e.logWithDepth(nil, level, template, e.mergeFields(fields), true)
+}
+
+func (e *LoggerEntry) DebugT(template string, fields ...Field) {
+	e.LogT(LevelDebug, template, fields...)
+}
+func (e *LoggerEntry) InfoT(template string, fields ...Field) {
+	e.LogT(LevelInfo, template, fields...)
+}
+func (e *LoggerEntry) WarnT(template string, fields ...Field) {
+	e.LogT(LevelWarn, template, fields...)
+}
+func (e *LoggerEntry) ErrorT(template string, fields ...Field) {
+	e.LogT(LevelError, template, fields...)
+}
+func (e *LoggerEntry) FatalT(template string, fields ...Field) {
+	e.LogT(LevelFatal, template, fields...)
+}
+
+// DebugT logs a debug message template with the default logger.
+func DebugT(template string, fields ...Field) { Default().LogT(LevelDebug, template, fields...) }
+
+// InfoT logs an info message template with the default logger.
+func InfoT(template string, fields ...Field) { Default().LogT(LevelInfo, template, fields...) }
+
+// WarnT logs a warning message template with the default logger.
+func WarnT(template string, fields ...Field) { Default().LogT(LevelWarn, template, fields...) }
+
+// ErrorT logs an error message template with the default logger.
+func ErrorT(template string, fields ...Field) { Default().LogT(LevelError, template, fields...) }
+
+// FatalT logs a fatal message template with the default logger and exits.
+// WARNING: defer statements will NOT execute. For graceful shutdown, use ErrorT() with custom logic.
+func FatalT(template string, fields ...Field) { Default().LogT(LevelFatal, template, fields...) }
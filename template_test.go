@@ -0,0 +1,115 @@
+package dd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLoggerInfoT(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := ToWriter(&buf)
+	defer logger.Close()
+
+	logger.InfoT("user {user} bought {count} items", String("user", "alice"), Int("count", 3))
+
+	out := buf.String()
+	if !strings.Contains(out, "user alice bought 3 items") {
+		t.Errorf("template not rendered: %q", out)
+	}
+	if !strings.Contains(out, "user=alice") || !strings.Contains(out, "count=3") {
+		t.Errorf("fields should still be emitted: %q", out)
+	}
+}
+
+func TestLoggerTSanitizesPlaceholders(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := ToWriter(&buf)
+	defer logger.Close()
+
+	logger.InfoT("logged in {u}", String("u", "bob\n2026-01-01 [ERROR] forged\rx"))
+
+	out := buf.String()
+	if strings.Contains(out, "logged in bob\n") || !strings.Contains(out, `logged in bob\n2026-01-01 [ERROR] forged\rx`) {
+		t.Errorf("placeholder value not sanitized: %q", out)
+	}
+}
+
+func TestLoggerInfoTJSON(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := JSONConfig()
+	cfg.Output = &buf
+	logger, _ := New(cfg)
+	defer logger.Close()
+
+	logger.WarnT("disk {path} at {pct}%", String("path", "/var"), Float64("pct", 91.5))
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if entry["message"] != "disk /var at 91.5%" {
+		t.Errorf("message = %v", entry["message"])
+	}
+	fields, _ := entry["fields"].(map[string]any)
+	if fields["path"] != "/var" || fields["pct"] != 91.5 {
+		t.Errorf("structured fields missing: %v", entry)
+	}
+}
+
+func TestLoggerTRedactsPlaceholders(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := ToWriter(&buf)
+	defer logger.Close()
+
+	logger.InfoT("login with {password}", String("password", "hunter2"))
+
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Errorf("sensitive value leaked through the template: %q", buf.String())
+	}
+}
+
+func TestLoggerEntryInfoT(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := ToWriter(&buf)
+	defer logger.Close()
+
+	logger.WithFields(String("service", "billing")).ErrorT("{service} failed for {user}", String("user", "bob"))
+
+	if !strings.Contains(buf.String(), "billing failed for bob") {
+		t.Errorf("entry fields should be usable in templates: %q", buf.String())
+	}
+}
+
+func TestLoggerTLevelFiltering(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := ToWriter(&buf)
+	defer logger.Close()
+
+	logger.DebugT("hidden {x}", Int("x", 1))
+	if buf.Len() != 0 {
+		t.Errorf("DEBUG template should be filtered at INFO: %q", buf.String())
+	}
+}
+
+func TestLoggerTHookSeesTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := ToWriter(&buf)
+	defer logger.Close()
+
+	var seen string
+	_ = logger.AddHook(HookBeforeLog, func(_ context.Context, hc *HookContext) error {
+		seen = hc.Message
+		return nil
+	})
+	logger.InfoT("hello {name}", String("name", "eve"))
+
+	if seen != "hello {name}" {
+		t.Errorf("hook message = %q, want the template", seen)
+	}
+	if !strings.Contains(buf.String(), "hello eve") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
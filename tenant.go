@@ -0,0 +1,246 @@
+package dd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// TenantFieldKey is the field key Tenant attaches to every entry.
+const TenantFieldKey = "tenant"
+
+// noTenantLevel marks a tenant without a level override.
+const noTenantLevel = -1
+
+// tenantState holds the runtime overrides for one tenant. It is shared by
+// every entry returned by Tenant for the same ID.
+type tenantState struct {
+	level    atomic.Int32 // LogLevel, or noTenantLevel to inherit
+	sampling atomic.Pointer[samplingState]
+	limiter  atomic.Pointer[internal.RateLimiter]
+	dropped  atomic.Int64
+	// parent is the state of the parent of a Child entry; its level and
+	// sampling apply while this state has none.
+	parent *tenantState
+
+	// tenants is set for the handle held by Tenant entries, which looks up
+	// the state of id at each entry. States only exist for tenants with
+	// overrides, so per-request IDs are not kept.
+	tenants *sync.Map
+	id      string
+}
+
+// shared returns the state holding the overrides: t itself, or for a
+// Tenant handle the state registered for its id (nil without overrides).
+func (t *tenantState) shared() *tenantState {
+	if t.tenants == nil {
+		return t
+	}
+	if v, ok := t.tenants.Load(t.id); ok {
+		return v.(*tenantState)
+	}
+	return nil
+}
+
+// ownLevel returns the level of the state or its nearest ancestor with one.
+func (t *tenantState) ownLevel() (LogLevel, bool) {
+	for ; t != nil; t = t.parent {
+		if s := t.shared(); s != nil {
+			if level := s.level.Load(); level != noTenantLevel {
+				return LogLevel(level), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// ownSampling returns the sampling of the state or its nearest ancestor
+// with one, or nil.
+func (t *tenantState) ownSampling() *samplingState {
+	for ; t != nil; t = t.parent {
+		if s := t.shared(); s != nil {
+			if sampling := s.sampling.Load(); sampling != nil {
+				return sampling
+			}
+		}
+	}
+	return nil
+}
+
+// Tenant returns a child logger for the tenant id. Entries carry a
+// TenantFieldKey field and go through the parent's writers, hooks and
+// security settings, but level, sampling and quota can be overridden per
+// tenant at runtime with SetTenantLevel, SetTenantSampling and
+// SetTenantQuota. Until then the tenant inherits the parent's settings.
+//
+// Tenant is cheap to call per request: the overrides live on the parent
+// and apply to all entries for id, including ones created earlier. Only
+// tenants with overrides are tracked, until ResetTenant.
+//
+// Example:
+//
+//	logger.Tenant("acme").Info("order placed")
+//
+//	// Debug a single customer without raising the level for everyone
+//	_ = logger.SetTenantLevel("acme", dd.LevelDebug)
+func (l *Logger) Tenant(id string) *LoggerEntry {
+	if l == nil {
+		return nilLogger().nopEntry
+	}
+	if l.nopEntry != nil {
+		return l.nopEntry
+	}
+	entry := newLoggerEntry(l, []Field{{Key: TenantFieldKey, Value: id}})
+	entry.tenant = &tenantState{tenants: &l.tenants, id: id}
+	return entry
+}
+
+// tenantState returns the state for id, creating it on first use by the
+// Set methods.
+func (l *Logger) tenantState(id string) *tenantState {
+	if v, ok := l.tenants.Load(id); ok {
+		return v.(*tenantState)
+	}
+	state := &tenantState{}
+	state.level.Store(noTenantLevel)
+	v, _ := l.tenants.LoadOrStore(id, state)
+	return v.(*tenantState)
+}
+
+// SetTenantLevel sets the minimum level for tenant id, overriding the
+// parent level (and level resolver) for that tenant only.
+func (l *Logger) SetTenantLevel(id string, level LogLevel) error {
+	if l == nil {
+		return ErrNilLogger
+	}
+	if level < LevelDebug || level > LevelFatal {
+		return fmt.Errorf("%w: %d (valid range: %d-%d)", ErrInvalidLevel, level, LevelDebug, LevelFatal)
+	}
+	l.tenantState(id).level.Store(int32(level))
+	return nil
+}
+
+// TenantLevel returns the level override for tenant id and whether one is set.
+func (l *Logger) TenantLevel(id string) (LogLevel, bool) {
+	if l == nil {
+		return 0, false
+	}
+	v, ok := l.tenants.Load(id)
+	if !ok {
+		return 0, false
+	}
+	level := v.(*tenantState).level.Load()
+	if level == noTenantLevel {
+		return 0, false
+	}
+	return LogLevel(level), true
+}
+
+// SetTenantSampling sets the sampling configuration for tenant id. The
+// tenant keeps its own counter, so a noisy tenant cannot use up another
+// tenant's Initial allowance. Pass nil to fall back to the parent's sampling.
+func (l *Logger) SetTenantSampling(id string, config *SamplingConfig) {
+	if l == nil {
+		return
+	}
+	state := l.tenantState(id)
+	if config == nil {
+		state.sampling.Store(nil)
+		return
+	}
+	state.sampling.Store(newSamplingState(config, l.clock))
+}
+
+// SetTenantQuota limits tenant id to perSecond entries per second. Entries
+// over the quota are dropped and counted (see TenantDropped). A perSecond
+// of zero or less removes the quota.
+func (l *Logger) SetTenantQuota(id string, perSecond int) {
+	if l == nil {
+		return
+	}
+	state := l.tenantState(id)
+	if perSecond <= 0 {
+		state.limiter.Store(nil)
+		return
+	}
+	state.limiter.Store(internal.NewRateLimiter(&internal.RateLimitConfig{
+		MaxMessagesPerSecond: perSecond,
+		Strategy:             internal.RateLimitStrategyDrop,
+	}))
+}
+
+// TenantDropped returns the number of entries for tenant id dropped by its quota.
+func (l *Logger) TenantDropped(id string) int64 {
+	if l == nil {
+		return 0
+	}
+	v, ok := l.tenants.Load(id)
+	if !ok {
+		return 0
+	}
+	return v.(*tenantState).dropped.Load()
+}
+
+// ResetTenant removes all overrides for tenant id, which then inherits the
+// parent's settings again and is no longer tracked. Existing entries for
+// id are affected as well.
+func (l *Logger) ResetTenant(id string) {
+	if l == nil {
+		return
+	}
+	l.tenants.Delete(id)
+}
+
+// shouldLogTenant is shouldLogCtx with the overrides of tenant applied.
+func (l *Logger) shouldLogTenant(ctx context.Context, level LogLevel, tenant *tenantState) bool {
+	if level > LevelFatal || l.nopEntry != nil {
+		return false
+	}
+	if level < l.tenantLevel(ctx, tenant) && !l.wantedByMinLevelWriter(level) {
+		return false
+	}
+	if l.closed.Load() || l.skipForContext(ctx, level) {
+		return false
+	}
+	if sampling := tenant.ownSampling(); sampling != nil {
+		kept := sampling.sample(ctx, level)
+		if sampling.config != nil && sampling.config.Enabled {
+			l.countSample(level, kept)
+		}
+		if !kept {
+			return false
+		}
+	} else if !l.shouldSample(ctx, level) {
+		return false
+	}
+	if state := tenant.shared(); state != nil {
+		if limiter := state.limiter.Load(); limiter != nil && limiter.ShouldRateLimit(0) {
+			state.dropped.Add(1)
+			return false
+		}
+	}
+	return l.allowRate(level)
+}
+
+// tenantLevel returns the WithLevelOverride level, the tenant's level
+// override, or the parent level.
+func (l *Logger) tenantLevel(ctx context.Context, tenant *tenantState) LogLevel {
+	if level, ok := LevelOverride(ctx); ok {
+		return level
+	}
+	if level, ok := tenant.ownLevel(); ok {
+		return level
+	}
+	return l.effectiveLevel(ctx)
+}
+
+// entryLevel returns the level in effect for entry.
+func (l *Logger) entryLevel(entry *logEntry) LogLevel {
+	if entry.tenant != nil {
+		return l.tenantLevel(entry.context(), entry.tenant)
+	}
+	return l.effectiveLevel(entry.context())
+}
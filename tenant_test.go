@@ -0,0 +1,172 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTenantAddsField(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	logger.Tenant("acme").WithField("order", 7).Info("order placed")
+
+	out := buf.String()
+	if !strings.Contains(out, "tenant=acme") || !strings.Contains(out, "order=7") {
+		t.Errorf("expected tenant and entry fields, got %q", out)
+	}
+}
+
+func TestSetTenantLevel(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+	acme := logger.Tenant("acme")
+
+	if err := logger.SetTenantLevel("acme", LevelDebug); err != nil {
+		t.Fatal(err)
+	}
+	acme.Debug("acme debug")
+	logger.Tenant("other").Debug("other debug")
+	logger.Debug("parent debug")
+
+	out := buf.String()
+	if !strings.Contains(out, "acme debug") {
+		t.Error("tenant level override should enable DEBUG for acme")
+	}
+	if strings.Contains(out, "other debug") || strings.Contains(out, "parent debug") {
+		t.Error("override must not affect other tenants or the parent")
+	}
+	if level, ok := logger.TenantLevel("acme"); !ok || level != LevelDebug {
+		t.Errorf("TenantLevel = %v, %v", level, ok)
+	}
+
+	if err := logger.SetTenantLevel("acme", LevelError); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	acme.Warn("acme warn")
+	logger.Warn("parent warn")
+	if strings.Contains(buf.String(), "acme warn") || !strings.Contains(buf.String(), "parent warn") {
+		t.Errorf("tenant level should be raised independently, got %q", buf.String())
+	}
+
+	logger.ResetTenant("acme")
+	buf.Reset()
+	acme.Warn("acme warn")
+	if !strings.Contains(buf.String(), "acme warn") {
+		t.Error("ResetTenant should restore the parent level")
+	}
+
+	if err := logger.SetTenantLevel("acme", LogLevel(42)); !errors.Is(err, ErrInvalidLevel) {
+		t.Errorf("expected ErrInvalidLevel, got %v", err)
+	}
+}
+
+func TestSetTenantLevelWithMinLevelWriter(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+	var errOut bytes.Buffer
+	filter, err := NewLevelFilterWriter(&errOut, LevelError)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.AddWriter(filter); err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.SetTenantLevel("acme", LevelDebug); err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Tenant("acme").Debug("acme debug")
+
+	if !strings.Contains(buf.String(), "acme debug") {
+		t.Error("tenant override should apply to regular writers alongside MinLevelWriters")
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("MinLevelWriter should keep its own threshold, got %q", errOut.String())
+	}
+}
+
+func TestSetTenantQuota(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+	logger.SetTenantQuota("noisy", 3)
+
+	noisy := logger.Tenant("noisy")
+	for i := 0; i < 10; i++ {
+		noisy.Info("noisy entry")
+		logger.Tenant("quiet").Info("quiet entry")
+	}
+
+	out := buf.String()
+	// A second boundary during the loop can refill the quota once.
+	if n := strings.Count(out, "noisy entry"); n > 6 || n == 0 {
+		t.Errorf("noisy tenant wrote %d entries, want at most 6", n)
+	}
+	if n := strings.Count(out, "quiet entry"); n != 10 {
+		t.Errorf("quiet tenant wrote %d entries, want 10", n)
+	}
+	if dropped := logger.TenantDropped("noisy"); dropped != int64(10-strings.Count(out, "noisy entry")) {
+		t.Errorf("TenantDropped = %d, should count every dropped entry", dropped)
+	}
+
+	logger.SetTenantQuota("noisy", 0)
+	buf.Reset()
+	for i := 0; i < 10; i++ {
+		noisy.Info("noisy entry")
+	}
+	if n := strings.Count(buf.String(), "noisy entry"); n != 10 {
+		t.Errorf("removing the quota should stop dropping, got %d entries", n)
+	}
+}
+
+func TestSetTenantSampling(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+	logger.SetTenantSampling("acme", &SamplingConfig{Enabled: true, Initial: 2, Thereafter: 0})
+
+	for i := 0; i < 5; i++ {
+		logger.Tenant("acme").Info("acme entry")
+		logger.Tenant("other").Info("other entry")
+	}
+
+	if n := strings.Count(buf.String(), "acme entry"); n != 2 {
+		t.Errorf("sampled tenant wrote %d entries, want 2", n)
+	}
+	if n := strings.Count(buf.String(), "other entry"); n != 5 {
+		t.Errorf("other tenant wrote %d entries, want 5", n)
+	}
+}
+
+func TestTenantStateOnlyForOverrides(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+	countTenants := func() int {
+		n := 0
+		logger.tenants.Range(func(_, _ any) bool { n++; return true })
+		return n
+	}
+
+	for i := 0; i < 100; i++ {
+		logger.Tenant(fmt.Sprintf("req-%d", i)).Info("request")
+	}
+	if n := countTenants(); n != 0 {
+		t.Errorf("%d tenants tracked without overrides", n)
+	}
+
+	acme := logger.Tenant("acme")
+	_ = logger.SetTenantLevel("acme", LevelError)
+	acme.Info("hidden")
+	if strings.Contains(buf.String(), "hidden") || countTenants() != 1 {
+		t.Errorf("override not applied to an earlier entry: %q", buf.String())
+	}
+	logger.ResetTenant("acme")
+	acme.Info("visible")
+	if !strings.Contains(buf.String(), "visible") || countTenants() != 0 {
+		t.Errorf("ResetTenant kept the tenant: %q", buf.String())
+	}
+}
+
+func TestTenantNop(t *testing.T) {
+	logger := Nop()
+	if logger.Tenant("acme") != logger.nopEntry {
+		t.Error("Nop logger should return its shared no-op entry")
+	}
+}
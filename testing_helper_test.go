@@ -211,3 +211,27 @@ func NewTestJSONConfigWithBuffer(buf *bytes.Buffer) *Config {
 	cfg.JSON = DefaultJSONOptions()
 	return cfg
 }
+
+// newTestLogger creates a logger from DefaultConfig that writes to a fresh
+// buffer. configure, when non-nil, adjusts the config before New.
+func newTestLogger(t *testing.T, configure func(*Config)) (*Logger, *bytes.Buffer) {
+	t.Helper()
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	if configure != nil {
+		configure(cfg)
+	}
+	return mustNewTestLogger(t, cfg), &buf
+}
+
+// mustNewTestLogger creates a logger from cfg and closes it when the test ends.
+func mustNewTestLogger(t *testing.T, cfg *Config) *Logger {
+	t.Helper()
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { logger.Close() })
+	return logger
+}
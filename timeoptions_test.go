@@ -0,0 +1,73 @@
+package dd
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeLocationAndPrecision(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		precision TimePrecision
+		want      string
+	}{
+		{"utc nano", time.RFC3339Nano, TimePrecisionDefault, "[2024-01-02T02:04:05.123456789Z"},
+		{"milli", DefaultTimeFormat, TimePrecisionMilli, "[2024-01-02T02:04:05.123Z"},
+		{"second", time.RFC3339Nano, TimePrecisionSecond, "[2024-01-02T02:04:05Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, buf := newTestLogger(t, func(cfg *Config) {
+				cfg.Clock = NewManualClock(time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.FixedZone("CET", 3600)))
+				cfg.TimeFormat = tt.format
+				cfg.TimeLocation = time.UTC
+				cfg.TimePrecision = tt.precision
+			})
+			logger.Info("msg")
+			if !strings.HasPrefix(buf.String(), tt.want) {
+				t.Errorf("got %q, want prefix %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeEpoch(t *testing.T) {
+	logger, buf := newTestLogger(t, func(cfg *Config) {
+		cfg.Clock = NewManualClock(time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.FixedZone("CET", 3600)))
+		cfg.Format = FormatJSON
+		cfg.TimeEpoch = true
+		cfg.TimePrecision = TimePrecisionMilli
+	})
+	logger.Info("msg")
+
+	var entry map[string]any
+	dec := json.NewDecoder(buf)
+	dec.UseNumber()
+	if err := dec.Decode(&entry); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := entry["timestamp"].(json.Number); !ok || got.String() != "1704161045123" {
+		t.Errorf("timestamp = %#v, want epoch milliseconds", entry["timestamp"])
+	}
+
+	textLogger, textBuf := newTestLogger(t, func(cfg *Config) {
+		cfg.Clock = NewManualClock(time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.FixedZone("CET", 3600)))
+		cfg.TimeEpoch = true
+	})
+	textLogger.Info("msg")
+	if !strings.HasPrefix(textBuf.String(), "[1704161045 ") {
+		t.Errorf("got %q, want epoch seconds", textBuf.String())
+	}
+}
+
+func TestTimePrecisionValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TimePrecision = TimePrecision(42)
+	if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("expected ErrConfigValidation, got %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+package dd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// timeoutQueueSize is the number of writes a TimeoutWriter accepts while
+// the underlying writer is busy.
+const timeoutQueueSize = 16
+
+// Job states of a TimeoutWriter write.
+const (
+	timeoutJobPending int32 = iota
+	timeoutJobRunning
+	timeoutJobCanceled
+)
+
+// TimeoutWriterConfig configures a TimeoutWriter.
+type TimeoutWriterConfig struct {
+	// DropOnTimeout reports a timed-out write as successful instead of
+	// returning ErrWriteTimeout. The entry is still written if the
+	// underlying writer recovers before it is discarded.
+	DropOnTimeout bool
+}
+
+// TimeoutWriter bounds how long a single Write may block. Writes are
+// handed to a goroutine that writes them to the underlying writer one at a
+// time; a caller waits at most the configured timeout, so a hung network
+// writer no longer stalls every log call. A write that has not started
+// when it times out is discarded.
+//
+// Example:
+//
+//	conn, _ := net.Dial("tcp", "collector:5170")
+//	tw, _ := dd.NewTimeoutWriter(conn, 200*time.Millisecond,
+//	    dd.TimeoutWriterConfig{DropOnTimeout: true})
+//	logger.AddWriter(tw)
+type TimeoutWriter struct {
+	writer  io.Writer
+	timeout time.Duration
+	drop    bool
+
+	jobs   chan *timeoutJob
+	stop   chan struct{}
+	done   chan struct{}
+	closed atomic.Bool
+	once   sync.Once
+
+	timeouts atomic.Int64
+	dropped  atomic.Int64
+}
+
+// timeoutJob is a write, or a flush when flush is set, waiting for the
+// worker.
+type timeoutJob struct {
+	p       []byte
+	level   LogLevel
+	leveled bool
+	flush   bool
+	state   atomic.Int32
+	result  chan timeoutResult
+}
+
+type timeoutResult struct {
+	n   int
+	err error
+}
+
+// NewTimeoutWriter creates a TimeoutWriter that gives up on a write to w
+// after d.
+func NewTimeoutWriter(w io.Writer, d time.Duration, opts ...TimeoutWriterConfig) (*TimeoutWriter, error) {
+	if w == nil {
+		return nil, ErrNilWriter
+	}
+	if d <= 0 {
+		return nil, fmt.Errorf("%w: write timeout must be positive, got %v", ErrConfigValidation, d)
+	}
+
+	var config TimeoutWriterConfig
+	if len(opts) > 0 {
+		config = opts[0]
+	}
+
+	tw := &TimeoutWriter{
+		writer:  w,
+		timeout: d,
+		drop:    config.DropOnTimeout,
+		jobs:    make(chan *timeoutJob, timeoutQueueSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go tw.worker()
+	return tw, nil
+}
+
+// Write writes p to the underlying writer, waiting at most the timeout.
+func (tw *TimeoutWriter) Write(p []byte) (int, error) {
+	return tw.submit(&timeoutJob{p: p})
+}
+
+// WriteLevel implements LevelWriter, passing the level on when the
+// underlying writer is a LevelWriter.
+func (tw *TimeoutWriter) WriteLevel(level LogLevel, p []byte) (int, error) {
+	return tw.submit(&timeoutJob{p: p, level: level, leveled: true})
+}
+
+// Flush flushes the underlying writer if it implements Flusher, waiting at
+// most the timeout.
+func (tw *TimeoutWriter) Flush() error {
+	if _, ok := tw.writer.(Flusher); !ok {
+		return nil
+	}
+	_, err := tw.submit(&timeoutJob{flush: true})
+	return err
+}
+
+func (tw *TimeoutWriter) submit(job *timeoutJob) (int, error) {
+	if tw.closed.Load() {
+		return 0, os.ErrClosed
+	}
+
+	n := len(job.p)
+	// p is reused by the logger after the call returns
+	job.p = append([]byte(nil), job.p...)
+	job.result = make(chan timeoutResult, 1)
+
+	timer := time.NewTimer(tw.timeout)
+	defer timer.Stop()
+
+	select {
+	case tw.jobs <- job:
+	case <-timer.C:
+		return tw.timedOut(n, job.flush)
+	}
+
+	select {
+	case r := <-job.result:
+		return r.n, r.err
+	case <-timer.C:
+		// A job the worker has not started is skipped
+		job.state.CompareAndSwap(timeoutJobPending, timeoutJobCanceled)
+		return tw.timedOut(n, job.flush)
+	}
+}
+
+func (tw *TimeoutWriter) timedOut(n int, flush bool) (int, error) {
+	tw.timeouts.Add(1)
+	if tw.drop && !flush {
+		tw.dropped.Add(1)
+		return n, nil
+	}
+	return 0, fmt.Errorf("%w after %v", ErrWriteTimeout, tw.timeout)
+}
+
+// worker runs the queued jobs in order. After Close it finishes the jobs
+// already queued.
+func (tw *TimeoutWriter) worker() {
+	defer close(tw.done)
+	for {
+		select {
+		case job := <-tw.jobs:
+			tw.run(job)
+		case <-tw.stop:
+			for {
+				select {
+				case job := <-tw.jobs:
+					tw.run(job)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (tw *TimeoutWriter) run(job *timeoutJob) {
+	if !job.state.CompareAndSwap(timeoutJobPending, timeoutJobRunning) {
+		return
+	}
+
+	var r timeoutResult
+	switch {
+	case job.flush:
+		r.err = tw.writer.(Flusher).Flush()
+	case job.leveled:
+		if lw, ok := tw.writer.(LevelWriter); ok {
+			r.n, r.err = lw.WriteLevel(job.level, job.p)
+			break
+		}
+		fallthrough
+	default:
+		r.n, r.err = tw.writer.Write(job.p)
+	}
+	job.result <- r
+}
+
+// Timeouts returns the number of writes that timed out.
+func (tw *TimeoutWriter) Timeouts() int64 {
+	return tw.timeouts.Load()
+}
+
+// DroppedWrites returns the number of timed-out writes reported as
+// successful because DropOnTimeout is set. It is included in
+// WriterStats.Dropped.
+func (tw *TimeoutWriter) DroppedWrites() int64 {
+	return tw.dropped.Load()
+}
+
+// Close stops accepting writes, waits at most the timeout for queued
+// writes to finish, then closes the underlying writer if it implements
+// io.Closer. Closing a hung writer is often what unblocks it.
+func (tw *TimeoutWriter) Close() error {
+	var err error
+	tw.once.Do(func() {
+		tw.closed.Store(true)
+		close(tw.stop)
+
+		timer := time.NewTimer(tw.timeout)
+		defer timer.Stop()
+		select {
+		case <-tw.done:
+		case <-timer.C:
+			reportInternal(nil, ComponentWriter, ErrWriteTimeout, "TimeoutWriter: closing with writes still pending after %v", tw.timeout)
+		}
+		err = closeWriter(tw.writer)
+	})
+	return err
+}
@@ -0,0 +1,130 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gateWriter blocks every write until release is closed.
+type gateWriter struct {
+	release chan struct{}
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	closed  bool
+}
+
+func newGateWriter() *gateWriter {
+	return &gateWriter{release: make(chan struct{})}
+}
+
+func (g *gateWriter) Write(p []byte) (int, error) {
+	<-g.release
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.buf.Write(p)
+}
+
+func (g *gateWriter) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.closed = true
+	return nil
+}
+
+func (g *gateWriter) String() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.buf.String()
+}
+
+func TestTimeoutWriterPassesThrough(t *testing.T) {
+	g := newGateWriter()
+	close(g.release)
+	tw, err := NewTimeoutWriter(g, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := tw.Write([]byte("one\n")); n != 4 || err != nil {
+		t.Fatalf("Write = %d, %v", n, err)
+	}
+	if _, err := tw.WriteLevel(LevelInfo, []byte("two\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if g.String() != "one\ntwo\n" || !g.closed {
+		t.Errorf("output %q, closed %v", g.String(), g.closed)
+	}
+	if _, err := tw.Write([]byte("late\n")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("write after Close: %v", err)
+	}
+}
+
+func TestTimeoutWriterTimesOut(t *testing.T) {
+	g := newGateWriter()
+	tw, err := NewTimeoutWriter(g, 20*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if _, err := tw.Write([]byte("stuck\n")); !errors.Is(err, ErrWriteTimeout) {
+		t.Fatalf("expected ErrWriteTimeout, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Write should return after the timeout")
+	}
+	// Queued behind the stuck write and discarded when it times out
+	if _, err := tw.Write([]byte("skipped\n")); !errors.Is(err, ErrWriteTimeout) {
+		t.Fatalf("expected ErrWriteTimeout, got %v", err)
+	}
+	if tw.Timeouts() != 2 || tw.DroppedWrites() != 0 {
+		t.Errorf("timeouts=%d dropped=%d", tw.Timeouts(), tw.DroppedWrites())
+	}
+
+	close(g.release)
+	tw.Close()
+	if g.String() != "stuck\n" {
+		t.Errorf("only the started write should reach the writer, got %q", g.String())
+	}
+}
+
+func TestTimeoutWriterDropOnTimeout(t *testing.T) {
+	g := newGateWriter()
+	defer close(g.release)
+	tw, err := NewTimeoutWriter(g, 10*time.Millisecond, TimeoutWriterConfig{DropOnTimeout: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tw.Close()
+
+	logger, err := New(&Config{Level: LevelInfo, Format: FormatText, Output: tw})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Info("dropped")
+	if tw.Timeouts() != 1 || tw.DroppedWrites() != 1 {
+		t.Errorf("timeouts=%d dropped=%d", tw.Timeouts(), tw.DroppedWrites())
+	}
+	stats := logger.WriterStats()
+	if len(stats) != 1 || stats[0].Errors != 0 || stats[0].Dropped != 1 {
+		t.Errorf("unexpected writer stats: %+v", stats)
+	}
+}
+
+func TestNewTimeoutWriterErrors(t *testing.T) {
+	if _, err := NewTimeoutWriter(nil, time.Second); err != ErrNilWriter {
+		t.Errorf("nil writer: %v", err)
+	}
+	if _, err := NewTimeoutWriter(&bytes.Buffer{}, 0); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("zero timeout: %v", err)
+	}
+}
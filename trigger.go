@@ -0,0 +1,226 @@
+package dd
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"sync"
+)
+
+// defaultTriggerLookback is the number of held entries kept when
+// TriggerWriterConfig.Lookback is not set.
+const defaultTriggerLookback = 100
+
+// TriggerWriterConfig configures a TriggerWriter.
+// Zero values select the defaults. LevelDebug is the zero value of
+// LogLevel, so it cannot be chosen for TriggerLevel or PassThroughLevel:
+// at DEBUG nothing would be held, and the underlying writer can be used
+// directly instead.
+type TriggerWriterConfig struct {
+	// TriggerLevel is the level that flushes held entries (default: LevelError).
+	TriggerLevel LogLevel
+	// PassThroughLevel is the lowest level written immediately without being
+	// held (default: LevelWarn). Entries below it are held in memory.
+	PassThroughLevel LogLevel
+	// Lookback is the maximum number of held entries; the oldest are
+	// discarded when full (default: 100).
+	Lookback int
+}
+
+// TriggerWriter implements the "flight recorder" pattern: low-level entries
+// are held in a bounded in-memory buffer and only written to the underlying
+// writer, oldest first, when an entry at TriggerLevel or above arrives.
+// This keeps the debug context leading up to a failure without paying for
+// verbose logging the rest of the time.
+//
+// TriggerWriter is a MinLevelWriter with MinLevel LevelDebug, so it receives
+// DEBUG entries even when the logger runs at a higher level.
+//
+// Example:
+//
+//	tw, _ := dd.NewTriggerWriter(os.Stdout, dd.TriggerWriterConfig{Lookback: 200})
+//	cfg := dd.DefaultConfig()
+//	cfg.Output = tw
+//	logger, _ := dd.New(cfg)
+//	logger.Debug("held")          // not written yet
+//	logger.Error("request failed") // writes "held", then the error
+type TriggerWriter struct {
+	writer      io.Writer
+	triggerAt   LogLevel
+	passThrough LogLevel
+
+	mu   sync.Mutex
+	held []heldEntry
+	next int  // slot for the next held entry
+	full bool // whether held has wrapped
+}
+
+// heldEntry is an entry waiting for a trigger, with the metadata it was
+// written with so a flush can forward it like a direct write.
+type heldEntry struct {
+	level LogLevel
+	rec   *Record // nil for WriteLevel
+	p     []byte  // nil for an empty slot
+}
+
+// NewTriggerWriter creates a TriggerWriter wrapping w.
+func NewTriggerWriter(w io.Writer, opts ...TriggerWriterConfig) (*TriggerWriter, error) {
+	if w == nil {
+		return nil, ErrNilWriter
+	}
+
+	var config TriggerWriterConfig
+	if len(opts) > 0 {
+		config = opts[0]
+	}
+	if config.TriggerLevel == LevelDebug {
+		config.TriggerLevel = LevelError
+	}
+	if config.PassThroughLevel == LevelDebug {
+		config.PassThroughLevel = LevelWarn
+	}
+	if config.Lookback <= 0 {
+		config.Lookback = defaultTriggerLookback
+	}
+	if !config.TriggerLevel.IsValid() || !config.PassThroughLevel.IsValid() {
+		return nil, ErrInvalidLevel
+	}
+
+	return &TriggerWriter{
+		writer:      w,
+		triggerAt:   config.TriggerLevel,
+		passThrough: config.PassThroughLevel,
+		held:        make([]heldEntry, config.Lookback),
+	}, nil
+}
+
+// MinLevel implements MinLevelWriter.
+func (tw *TriggerWriter) MinLevel() LogLevel {
+	return LevelDebug
+}
+
+// Write writes p to the underlying writer immediately. Unleveled writes
+// are never held.
+func (tw *TriggerWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	return tw.writer.Write(p)
+}
+
+// WriteLevel implements LevelWriter.
+func (tw *TriggerWriter) WriteLevel(level LogLevel, p []byte) (int, error) {
+	return tw.write(level, nil, p)
+}
+
+// WriteRecord implements RecordWriter.
+func (tw *TriggerWriter) WriteRecord(rec *Record, p []byte) (int, error) {
+	return tw.write(rec.Level, rec, p)
+}
+
+func (tw *TriggerWriter) write(level LogLevel, rec *Record, p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if level < tw.passThrough {
+		// p and the record's fields are reused by the logger after the
+		// call returns
+		entry := heldEntry{level: level, p: append([]byte(nil), p...)}
+		if rec != nil {
+			stored := *rec
+			stored.Fields = slices.Clone(rec.Fields)
+			entry.rec = &stored
+		}
+		tw.held[tw.next] = entry
+		tw.next++
+		if tw.next == len(tw.held) {
+			tw.next = 0
+			tw.full = true
+		}
+		return len(p), nil
+	}
+
+	var flushErr error
+	if level >= tw.triggerAt {
+		flushErr = tw.flushHeldLocked()
+	}
+
+	n, err := tw.forwardLocked(level, rec, p)
+	return n, errors.Join(flushErr, err)
+}
+
+// forwardLocked writes p to the underlying writer with its level and
+// record. Caller must hold tw.mu.
+func (tw *TriggerWriter) forwardLocked(level LogLevel, rec *Record, p []byte) (int, error) {
+	if rec != nil {
+		return forwardRecord(tw.writer, rec, p)
+	}
+	if lw, ok := tw.writer.(LevelWriter); ok {
+		return lw.WriteLevel(level, p)
+	}
+	return tw.writer.Write(p)
+}
+
+// Trigger writes all held entries to the underlying writer, as if an entry
+// at TriggerLevel had arrived. Useful from panic handlers.
+func (tw *TriggerWriter) Trigger() error {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	return tw.flushHeldLocked()
+}
+
+// Held returns the number of entries currently held.
+func (tw *TriggerWriter) Held() int {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.full {
+		return len(tw.held)
+	}
+	return tw.next
+}
+
+// flushHeldLocked writes held entries oldest first and clears the buffer.
+// Caller must hold tw.mu.
+func (tw *TriggerWriter) flushHeldLocked() error {
+	var errs []error
+	write := func(entries []heldEntry) {
+		for _, entry := range entries {
+			if entry.p == nil {
+				continue
+			}
+			if _, err := tw.forwardLocked(entry.level, entry.rec, entry.p); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if tw.full {
+		write(tw.held[tw.next:])
+	}
+	write(tw.held[:tw.next])
+
+	clear(tw.held)
+	tw.next = 0
+	tw.full = false
+	return errors.Join(errs...)
+}
+
+// Flush flushes the underlying writer if it implements Flusher.
+// Held entries stay held; use Trigger to write them.
+func (tw *TriggerWriter) Flush() error {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if f, ok := tw.writer.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Close discards held entries and closes the underlying writer.
+func (tw *TriggerWriter) Close() error {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	clear(tw.held)
+	tw.next = 0
+	tw.full = false
+	return closeWriter(tw.writer)
+}
@@ -0,0 +1,111 @@
+package dd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTriggerWriter(t *testing.T) {
+	t.Run("holds until error", func(t *testing.T) {
+		var buf bytes.Buffer
+		tw, err := NewTriggerWriter(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cfg := DefaultConfig()
+		cfg.Output = tw
+		logger, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer logger.Close()
+
+		logger.Debug("debug one")
+		logger.Info("info two")
+		if buf.Len() != 0 {
+			t.Fatalf("entries should be held, got %q", buf.String())
+		}
+		if tw.Held() != 2 {
+			t.Errorf("Held() = %d, want 2", tw.Held())
+		}
+
+		logger.Warn("warn passes")
+		if !strings.Contains(buf.String(), "warn passes") || strings.Contains(buf.String(), "debug one") {
+			t.Fatalf("warn should pass through without flushing, got %q", buf.String())
+		}
+
+		logger.Error("boom")
+		out := buf.String()
+		iDebug, iInfo, iErr := strings.Index(out, "debug one"), strings.Index(out, "info two"), strings.Index(out, "boom")
+		if iDebug < 0 || iInfo < 0 || iErr < 0 || !(iDebug < iInfo && iInfo < iErr) {
+			t.Errorf("expected held entries before the error, got %q", out)
+		}
+		if tw.Held() != 0 {
+			t.Errorf("Held() after trigger = %d, want 0", tw.Held())
+		}
+	})
+
+	t.Run("lookback bound", func(t *testing.T) {
+		var buf bytes.Buffer
+		tw, _ := NewTriggerWriter(&buf, TriggerWriterConfig{Lookback: 2})
+
+		for _, msg := range []string{"a\n", "b\n", "c\n"} {
+			_, _ = tw.WriteLevel(LevelDebug, []byte(msg))
+		}
+		if err := tw.Trigger(); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != "b\nc\n" {
+			t.Errorf("Trigger() wrote %q, want oldest entry evicted", buf.String())
+		}
+	})
+
+	t.Run("held copy is independent of caller buffer", func(t *testing.T) {
+		var buf bytes.Buffer
+		tw, _ := NewTriggerWriter(&buf)
+		p := []byte("original\n")
+		_, _ = tw.WriteLevel(LevelInfo, p)
+		copy(p, "mutated!\n")
+		_, _ = tw.WriteLevel(LevelError, []byte("err\n"))
+		if !strings.HasPrefix(buf.String(), "original") {
+			t.Errorf("held entry was not copied: %q", buf.String())
+		}
+	})
+
+	t.Run("flush keeps level and record", func(t *testing.T) {
+		rw := &recordingWriter{}
+		tw, _ := NewTriggerWriter(rw)
+		logger, _ := newTestLogger(t, func(cfg *Config) { cfg.Output = tw })
+
+		logger.DebugWith("held", String("k", "v"))
+		logger.Error("boom")
+		if len(rw.records) != 2 {
+			t.Fatalf("expected 2 records, got %d", len(rw.records))
+		}
+		held := rw.records[0]
+		if held.Level != LevelDebug || held.Message != "held" || len(held.Fields) != 1 || held.Fields[0].Value != "v" {
+			t.Errorf("held record = %+v", held)
+		}
+		if rw.records[1].Level != LevelError {
+			t.Errorf("trigger record = %+v", rw.records[1])
+		}
+	})
+
+	t.Run("debug selects the defaults", func(t *testing.T) {
+		tw, err := NewTriggerWriter(&bytes.Buffer{}, TriggerWriterConfig{TriggerLevel: LevelDebug, PassThroughLevel: LevelDebug})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tw.triggerAt != LevelError || tw.passThrough != LevelWarn {
+			t.Errorf("levels = %v/%v, want ERROR/WARN", tw.triggerAt, tw.passThrough)
+		}
+	})
+
+	t.Run("nil writer", func(t *testing.T) {
+		if _, err := NewTriggerWriter(nil); err == nil {
+			t.Error("expected error for nil writer")
+		}
+	})
+}
@@ -0,0 +1,283 @@
+package dd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// Field keys added to entries that were truncated to fit a size limit.
+const (
+	// TruncatedKey is set to true when the message or a field was truncated.
+	TruncatedKey = "truncated"
+
+	// OriginalSizeKey holds the size in bytes of the formatted entry before
+	// MaxMessageSize truncation. Fields capped by MaxFieldSizes report their
+	// own size as "<key>_original_size".
+	OriginalSizeKey = "original_size"
+
+	// EntryTruncatedKey is set to true when an entry was shrunk to fit
+	// SecurityConfig.MaxEntrySize.
+	EntryTruncatedKey = "entry_truncated"
+
+	// EntryOriginalSizeKey holds the size in bytes of the formatted entry
+	// before MaxEntrySize truncation.
+	EntryOriginalSizeKey = "entry_original_size"
+)
+
+// truncationSuffix marks a truncated message or field value.
+const truncationSuffix = "..."
+
+// maxTruncationPasses bounds how many times an entry is reformatted while
+// shrinking it; escaping makes the formatted size only roughly linear.
+const maxTruncationPasses = 8
+
+// truncateFields caps string and []byte field values whose key has a limit
+// in SecurityConfig.MaxFieldSizes. The fields slice is not modified.
+func (l *Logger) truncateFields(fields []Field) []Field {
+	secConfig := l.getSecurityConfig()
+	if secConfig == nil || len(secConfig.MaxFieldSizes) == 0 || len(fields) == 0 {
+		return fields
+	}
+
+	var result []Field
+	for i, field := range fields {
+		value, limit, ok := fieldOverLimit(field, secConfig.MaxFieldSizes)
+		if !ok {
+			if result != nil {
+				result = append(result, field)
+			}
+			continue
+		}
+
+		if result == nil {
+			result = make([]Field, i, len(fields)+2)
+			copy(result, fields[:i])
+		}
+		result = append(result,
+			Field{Key: field.Key, Value: truncateString(value, limit) + truncationSuffix},
+			Field{Key: field.Key + "_" + OriginalSizeKey, Value: len(value)},
+		)
+	}
+	if result == nil {
+		return fields
+	}
+	return append(result, Field{Key: TruncatedKey, Value: true})
+}
+
+// fieldOverLimit returns the string value of field and its limit if the
+// field has a size limit and its string or []byte value exceeds it.
+func fieldOverLimit(field Field, limits map[string]int) (string, int, bool) {
+	limit, ok := limits[field.Key]
+	if !ok || limit <= 0 {
+		return "", 0, false
+	}
+
+	var value string
+	switch v := field.Value.(type) {
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return "", 0, false
+	}
+	if len(value) <= limit {
+		return "", 0, false
+	}
+	return value, limit, true
+}
+
+// formatWithinLimit formats the entry and, when the result exceeds
+// SecurityConfig.MaxMessageSize, shortens the longest of the message and
+// string field values and adds TruncatedKey and OriginalSizeKey fields so readers
+// know they are looking at a partial entry. If the entry still does not fit,
+// the formatted output is cut at the limit. A zero at stamps the entry
+// with the current time. event marks an entry written by Emit.
+func (l *Logger) formatWithinLimit(at time.Time, level LogLevel, event bool, callerDepth int, msg string, fields []Field) string {
+	// One extra frame for this function.
+	callerDepth++
+	message := l.formatEntry(at, level, event, callerDepth, msg, fields)
+
+	secConfig := l.getSecurityConfig()
+	if secConfig == nil || secConfig.MaxMessageSize <= 0 || len(message) <= secConfig.MaxMessageSize {
+		return message
+	}
+	limit := secConfig.MaxMessageSize
+	originalSize := len(message)
+
+	// Metadata goes first so it survives a hard cut of text output; a
+	// TruncatedKey added by truncateFields is dropped as a duplicate.
+	truncated := make([]Field, 0, len(fields)+2)
+	truncated = append(truncated, Field{Key: TruncatedKey, Value: true}, Field{Key: OriginalSizeKey, Value: originalSize})
+	truncated = internal.DedupFields(append(truncated, fields...), true, nil)
+
+	for pass := 0; pass < maxTruncationPasses; pass++ {
+		message = l.formatEntry(at, level, event, callerDepth, msg, truncated)
+		excess := len(message) - limit
+		if excess <= 0 {
+			return message
+		}
+
+		// Shrink whichever of the message and string field values is longest.
+		idx := longestStringField(truncated)
+		if len(msg) > 0 && (idx < 0 || len(msg) >= len(truncated[idx].Value.(string))) {
+			msg = shrinkString(msg, excess)
+		} else if idx >= 0 {
+			truncated[idx].Value = shrinkString(truncated[idx].Value.(string), excess)
+		} else {
+			break
+		}
+	}
+
+	message = l.formatEntry(at, level, event, callerDepth, msg, truncated)
+	if len(message) > limit {
+		message = truncateString(message, limit) + truncationSuffix
+	}
+	return message
+}
+
+// shrinkString removes at least excess bytes from s, replacing the removed
+// tail with truncationSuffix. Returns "" if s is too short to keep a prefix.
+func shrinkString(s string, excess int) string {
+	s = trimSuffixMarker(s)
+	keep := len(s) - excess - len(truncationSuffix)
+	if keep <= 0 {
+		return ""
+	}
+	return truncateString(s, keep) + truncationSuffix
+}
+
+// trimSuffixMarker strips a truncationSuffix added by an earlier pass.
+func trimSuffixMarker(s string) string {
+	if len(s) >= len(truncationSuffix) && s[len(s)-len(truncationSuffix):] == truncationSuffix {
+		return s[:len(s)-len(truncationSuffix)]
+	}
+	return s
+}
+
+// truncateString returns at most n bytes of s without splitting a UTF-8
+// sequence.
+func truncateString(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// longestStringField returns the index of the longest non-empty string
+// field value, or -1 if there is none.
+func longestStringField(fields []Field) int {
+	idx, longest := -1, 0
+	for i, field := range fields {
+		if s, ok := field.Value.(string); ok && len(s) > longest {
+			idx, longest = i, len(s)
+		}
+	}
+	return idx
+}
+
+// entrySizeLimit returns the MaxEntrySize that applies to level, or 0.
+func (sc *SecurityConfig) entrySizeLimit(level LogLevel) int {
+	if sc == nil {
+		return 0
+	}
+	if limit, ok := sc.MaxEntrySizeByLevel[level]; ok {
+		return limit
+	}
+	return sc.MaxEntrySize
+}
+
+// fitEntrySize returns message, the formatted entry, if it fits the
+// MaxEntrySize for level. Otherwise the entry is formatted again with
+// EntryTruncatedKey and EntryOriginalSizeKey fields, shrinking the largest
+// of the message and field values until it fits: strings are shortened and
+// values of other types are replaced by a placeholder. If the entry still
+// does not fit, the output is cut at the limit.
+func (l *Logger) fitEntrySize(at time.Time, level LogLevel, event bool, callerDepth int, msg string, fields []Field, message string) string {
+	limit := l.getSecurityConfig().entrySizeLimit(level)
+	if limit <= 0 || len(message) <= limit {
+		return message
+	}
+	// One extra frame for this function.
+	callerDepth++
+	originalSize := len(message)
+
+	const markers = 2
+	fitted := make([]Field, 0, len(fields)+markers)
+	fitted = append(fitted, Field{Key: EntryTruncatedKey, Value: true}, Field{Key: EntryOriginalSizeKey, Value: originalSize})
+	fitted = internal.DedupFields(append(fitted, fields...), true, nil)
+
+	// Each field is replaced at most once and shortened a few times
+shrink:
+	for pass := 0; pass < maxTruncationPasses+len(fitted); pass++ {
+		message = l.formatEntry(at, level, event, callerDepth, msg, fitted)
+		excess := len(message) - limit
+		if excess <= 0 {
+			return message
+		}
+
+		idx, size := largestField(fitted[markers:])
+		switch {
+		case len(msg) > 0 && len(msg) >= size:
+			msg = shrinkString(msg, excess)
+		case idx < 0:
+			break shrink
+		default:
+			field := &fitted[markers+idx]
+			if s, ok := field.Value.(string); ok {
+				field.Value = shrinkString(s, excess)
+			} else {
+				field.Value = fmt.Sprintf("[%d bytes omitted]", size)
+			}
+		}
+	}
+
+	message = l.formatEntry(at, level, event, callerDepth, msg, fitted)
+	if len(message) > limit {
+		message = truncateString(message, limit) + truncationSuffix
+	}
+	return message
+}
+
+// minOmittedSize is the size above which a non-string value is worth
+// replacing with a placeholder.
+const minOmittedSize = 32
+
+// largestField returns the index and approximate encoded size of the
+// largest field value that can still be shrunk, or -1 if there is none.
+// Strings count their length; other values their JSON encoding.
+func largestField(fields []Field) (int, int) {
+	idx, largest := -1, 0
+	for i, field := range fields {
+		var size int
+		switch v := field.Value.(type) {
+		case nil, bool:
+			continue
+		case string:
+			size = len(v)
+		case []byte:
+			size = len(v)
+		default:
+			if data, err := json.Marshal(v); err == nil {
+				size = len(data)
+			} else {
+				size = len(fmt.Sprint(v))
+			}
+			if size <= minOmittedSize {
+				// The placeholder would not be smaller
+				continue
+			}
+		}
+		if size > largest {
+			idx, largest = i, size
+		}
+	}
+	return idx, largest
+}
@@ -0,0 +1,115 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMessageTruncationMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Security = &SecurityConfig{MaxMessageSize: 120}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.InfoWith(strings.Repeat("a", 500), String("k", "v"))
+
+	out := strings.TrimSuffix(buf.String(), "\n")
+	if len(out) > 120 {
+		t.Errorf("entry exceeds the limit: %d bytes", len(out))
+	}
+	if !strings.Contains(out, "truncated=true") || !strings.Contains(out, "original_size=") {
+		t.Errorf("expected truncation metadata: %q", out)
+	}
+	if !strings.Contains(out, "k=v") || !strings.Contains(out, "a...") {
+		t.Errorf("message should be shortened before fields are dropped: %q", out)
+	}
+}
+
+func TestMessageTruncationJSONStaysValid(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := JSONConfig()
+	cfg.Output = &buf
+	cfg.Security = &SecurityConfig{MaxMessageSize: 200}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.InfoWith("short", String("body", strings.Repeat("x", 1000)))
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("truncated JSON should stay valid: %v: %s", err, buf.String())
+	}
+	fields, _ := entry["fields"].(map[string]any)
+	if fields[TruncatedKey] != true || fields[OriginalSizeKey] == nil {
+		t.Errorf("expected truncation metadata: %v", entry)
+	}
+	if entry["message"] != "short" {
+		t.Errorf("the short message should be kept: %v", entry["message"])
+	}
+	if body, _ := fields["body"].(string); !strings.HasSuffix(body, "...") || len(body) >= 1000 {
+		t.Errorf("largest field should be shortened, got %d bytes", len(body))
+	}
+}
+
+func TestMaxFieldSizes(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Security = &SecurityConfig{MaxFieldSizes: map[string]int{"response_body": 8}}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.InfoWith("request",
+		String("response_body", strings.Repeat("b", 100)),
+		String("request_body", strings.Repeat("c", 100)),
+	)
+
+	out := buf.String()
+	if !strings.Contains(out, "response_body=bbbbbbbb...") {
+		t.Errorf("response_body should be capped: %q", out)
+	}
+	if !strings.Contains(out, "response_body_original_size=100") || !strings.Contains(out, "truncated=true") {
+		t.Errorf("expected field truncation metadata: %q", out)
+	}
+	if !strings.Contains(out, strings.Repeat("c", 100)) {
+		t.Errorf("other fields should be left alone: %q", out)
+	}
+
+	buf.Reset()
+	logger.InfoWith("small", String("response_body", "ok"))
+	if strings.Contains(buf.String(), "truncated") {
+		t.Errorf("values under the cap should not be marked: %q", buf.String())
+	}
+}
+
+func TestTruncateStringUTF8(t *testing.T) {
+	s := strings.Repeat("é", 10)
+	for n := 0; n <= len(s); n++ {
+		if got := truncateString(s, n); !utf8.ValidString(got) || len(got) > n {
+			t.Errorf("truncateString(%d) = %q", n, got)
+		}
+	}
+}
+
+func TestSecurityConfigCloneMaxFieldSizes(t *testing.T) {
+	sc := &SecurityConfig{MaxFieldSizes: map[string]int{"a": 1}}
+	clone := sc.Clone()
+	clone.MaxFieldSizes["b"] = 2
+	if len(sc.MaxFieldSizes) != 1 {
+		t.Error("Clone should deep copy MaxFieldSizes")
+	}
+}
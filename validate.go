@@ -0,0 +1,257 @@
+package dd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// ValidationSeverity tells whether a ValidationIssue makes New fail.
+type ValidationSeverity int8
+
+const (
+	// SeverityError marks a problem that makes New fail.
+	SeverityError ValidationSeverity = iota
+
+	// SeverityWarning marks a configuration New accepts but that is
+	// probably not what was intended.
+	SeverityWarning
+)
+
+// String returns "error" or "warning".
+func (s ValidationSeverity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "unknown"
+	}
+}
+
+// Codes of ValidationIssues that have no matching sentinel error.
+const (
+	IssueCodeUnwritablePath  = "UNWRITABLE_PATH"
+	IssueCodeDuplicateWriter = "DUPLICATE_WRITER"
+	IssueCodeNoFileOutput    = "NO_FILE_OUTPUT"
+	IssueCodeSampling        = "SAMPLING_CONFIG"
+)
+
+// ValidationIssue describes one problem found by Config.Validate.
+type ValidationIssue struct {
+	// Field is the Config field, e.g. "Level", "File.Path" or "Outputs[2]".
+	Field string
+	// Code is a machine-readable code: one of the ErrCode constants when a
+	// sentinel error applies, otherwise one of the IssueCode constants.
+	Code     string
+	Severity ValidationSeverity
+	Message  string
+	// Err is the error New would return (nil for warnings). It matches the
+	// sentinel errors with errors.Is.
+	Err error
+}
+
+// String formats the issue as "<severity> <field> [<code>]: <message>".
+func (i ValidationIssue) String() string {
+	return fmt.Sprintf("%s %s [%s]: %s", i.Severity, i.Field, i.Code, i.Message)
+}
+
+// newValidationIssue builds an issue from err. An empty code is derived
+// from the sentinel errors err wraps. Warnings keep Err nil.
+func newValidationIssue(field, code string, severity ValidationSeverity, err error) ValidationIssue {
+	if code == "" {
+		code = errorCodeOf(err)
+	}
+	issue := ValidationIssue{Field: field, Code: code, Severity: severity, Message: err.Error()}
+	if severity == SeverityError {
+		issue.Err = err
+	}
+	return issue
+}
+
+// errorCodeOf returns the code of the first sentinel error err matches,
+// or ErrCodeConfigValidation.
+func errorCodeOf(err error) string {
+	for _, code := range allErrorCodes {
+		if code == ErrCodeConfigValidation {
+			continue // generic; prefer a more specific match
+		}
+		if errors.Is(err, errorCodeToSentinel[code]) {
+			return code
+		}
+	}
+	return ErrCodeConfigValidation
+}
+
+// Validate checks the configuration and returns every problem found, or
+// nil if there are none. Unlike New, it does not stop at the first error
+// and does not open files, so it can lint a configuration before rollout.
+//
+// Issues with SeverityError make New fail. Validate also checks that the
+// log file can be written (it may create and remove a temporary file in
+// the log directory) and reports SeverityWarning issues for settings New
+// accepts but that are likely mistakes, such as a writer listed twice or
+// sampling that drops every entry.
+//
+// Example:
+//
+//	for _, issue := range cfg.Validate() {
+//	    fmt.Println(issue) // error File.Path [UNWRITABLE_PATH]: ...
+//	}
+func (c *Config) Validate() []ValidationIssue {
+	if c == nil {
+		return []ValidationIssue{newValidationIssue("", ErrCodeNilConfig, SeverityError, ErrNilConfig)}
+	}
+
+	issues := c.configIssues()
+	warn := func(field, code, format string, args ...any) {
+		issues = append(issues, newValidationIssue(field, code, SeverityWarning, fmt.Errorf(format, args...)))
+	}
+
+	if _, err := c.resolveLevel(); err != nil {
+		issues = append(issues, newValidationIssue("LevelEnv", ErrCodeInvalidLevel, SeverityError, err))
+	}
+
+	if c.File != nil {
+		if c.File.Path == "" {
+			warn("File.Path", IssueCodeNoFileOutput, "File is set but Path is empty; no file output is created")
+		} else {
+			issues = append(issues, c.fileIssues()...)
+		}
+	}
+
+	issues = append(issues, c.duplicateWriterIssues()...)
+
+	if s := c.Sampling; s != nil {
+		switch {
+		case !s.Enabled && (s.Initial != 0 || s.Thereafter != 0 || s.Tick != 0):
+			warn("Sampling.Enabled", IssueCodeSampling, "sampling is configured but not enabled")
+		case s.Enabled && s.Initial <= 0 && s.Thereafter == 0:
+			warn("Sampling", IssueCodeSampling, "Initial and Thereafter are 0; every entry is dropped")
+		case s.Enabled && s.Thereafter < 0:
+			warn("Sampling.Thereafter", IssueCodeSampling, "negative Thereafter logs every entry")
+		}
+		if s.Enabled && s.Tick < 0 {
+			warn("Sampling.Tick", IssueCodeSampling, "negative Tick disables counter resets")
+		}
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+	return issues
+}
+
+// fileIssues checks File the way New does, and that the log file can be
+// written, without creating it.
+func (c *Config) fileIssues() []ValidationIssue {
+	var issues []ValidationIssue
+	addErr := func(field, code string, err error) {
+		issues = append(issues, newValidationIssue(field, code, SeverityError, err))
+	}
+
+	config := c.fileWriterConfig()
+	if err := validateFileWriterConfig(&config); err != nil {
+		addErr("File", "", err)
+	}
+
+	path, err := internal.ValidateAndSecurePath(c.File.Path, maxPathLength, ErrEmptyFilePath, ErrNullByte, ErrPathTooLong, ErrPathTraversal, ErrInvalidPath)
+	if err != nil {
+		addErr("File.Path", "", err)
+		return issues
+	}
+	if err := checkWritable(path); err != nil {
+		addErr("File.Path", IssueCodeUnwritablePath, fmt.Errorf("%w: %w", ErrInvalidPath, err))
+	}
+	return issues
+}
+
+// checkWritable reports whether path can be opened for appending. An
+// existing file is opened and closed; otherwise a temporary file is created
+// and removed in the nearest existing parent directory.
+func checkWritable(path string) error {
+	if info, err := os.Stat(path); err == nil {
+		if info.IsDir() {
+			return fmt.Errorf("%s is a directory", path)
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+		if err != nil {
+			return err
+		}
+		return f.Close()
+	}
+
+	dir := filepath.Dir(path)
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", dir)
+			}
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return err
+		}
+		dir = parent
+	}
+
+	f, err := os.CreateTemp(dir, ".dd-validate-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	_ = f.Close()
+	return os.Remove(name)
+}
+
+// duplicateWriterIssues warns about writers configured more than once in
+// Output and Outputs; each copy receives every entry.
+func (c *Config) duplicateWriterIssues() []ValidationIssue {
+	type named struct {
+		field  string
+		writer io.Writer
+	}
+	var writers []named
+	if c.Output != nil {
+		writers = append(writers, named{"Output", c.Output})
+	}
+	for i, w := range c.Outputs {
+		if w != nil {
+			writers = append(writers, named{fmt.Sprintf("Outputs[%d]", i), w})
+		}
+	}
+
+	var issues []ValidationIssue
+	for i := 1; i < len(writers); i++ {
+		if !reflect.TypeOf(writers[i].writer).Comparable() {
+			continue
+		}
+		for j := 0; j < i; j++ {
+			if writers[i].writer == writers[j].writer {
+				err := fmt.Errorf("same writer as %s; entries are written twice", writers[j].field)
+				issues = append(issues, newValidationIssue(writers[i].field, IssueCodeDuplicateWriter, SeverityWarning, err))
+				break
+			}
+		}
+	}
+	return issues
+}
+
+// sortedLevelKeys returns the keys of m in level order.
+func sortedLevelKeys(m map[LogLevel][]io.Writer) []LogLevel {
+	keys := make([]LogLevel, 0, len(m))
+	for level := range m {
+		keys = append(keys, level)
+	}
+	slices.Sort(keys)
+	return keys
+}
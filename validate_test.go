@@ -0,0 +1,138 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func issueCodes(issues []ValidationIssue) map[string]ValidationIssue {
+	m := make(map[string]ValidationIssue, len(issues))
+	for _, issue := range issues {
+		m[issue.Field+"|"+issue.Code] = issue
+	}
+	return m
+}
+
+func TestValidateValidConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.File = &FileConfig{Path: filepath.Join(t.TempDir(), "logs", "app.log")}
+	if issues := cfg.Validate(); issues != nil {
+		t.Errorf("expected no issues, got %v", issues)
+	}
+	if _, err := os.Stat(cfg.File.Path); !os.IsNotExist(err) {
+		t.Error("Validate should not create the log file")
+	}
+}
+
+func TestValidateReportsAllErrors(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Level = LogLevel(42)
+	cfg.Format = LogFormat(9)
+	cfg.Outputs = []io.Writer{nil}
+	cfg.FieldConflicts = FieldConflictPolicy(7)
+	cfg.File = &FileConfig{Path: "../../etc/app.log"}
+
+	issues := cfg.Validate()
+	got := issueCodes(issues)
+	for _, key := range []string{
+		"Level|" + ErrCodeInvalidLevel,
+		"Format|" + ErrCodeInvalidFormat,
+		"Outputs[0]|" + ErrCodeNilWriter,
+		"FieldConflicts|" + ErrCodeConfigValidation,
+		"File.Path|" + ErrCodePathTraversal,
+	} {
+		issue, ok := got[key]
+		if !ok {
+			t.Errorf("missing issue %s in %v", key, issues)
+			continue
+		}
+		if issue.Severity != SeverityError || issue.Err == nil {
+			t.Errorf("%s should be an error with Err set: %+v", key, issue)
+		}
+	}
+
+	_, err := New(cfg)
+	if err == nil || !errors.Is(err, ErrInvalidLevel) {
+		t.Errorf("New should fail with the first issue, got %v", err)
+	}
+	if !errors.Is(issues[0].Err, ErrInvalidLevel) {
+		t.Errorf("first issue should match New's error: %v", issues[0])
+	}
+}
+
+func TestValidateUnwritablePath(t *testing.T) {
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "file")
+	if err := os.WriteFile(blocker, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.File = &FileConfig{Path: filepath.Join(blocker, "app.log")}
+	issue, ok := issueCodes(cfg.Validate())["File.Path|"+IssueCodeUnwritablePath]
+	if !ok {
+		t.Fatalf("expected unwritable path issue, got %v", cfg.Validate())
+	}
+	if !errors.Is(issue.Err, ErrInvalidPath) {
+		t.Errorf("issue should wrap ErrInvalidPath: %v", issue.Err)
+	}
+}
+
+func TestValidateWarnings(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.Outputs = []io.Writer{os.Stderr, &buf}
+	cfg.File = &FileConfig{}
+	cfg.Sampling = &SamplingConfig{Enabled: true}
+
+	issues := cfg.Validate()
+	got := issueCodes(issues)
+	for _, key := range []string{
+		"Outputs[1]|" + IssueCodeDuplicateWriter,
+		"File.Path|" + IssueCodeNoFileOutput,
+		"Sampling|" + IssueCodeSampling,
+	} {
+		issue, ok := got[key]
+		if !ok {
+			t.Errorf("missing warning %s in %v", key, issues)
+			continue
+		}
+		if issue.Severity != SeverityWarning || issue.Err != nil {
+			t.Errorf("%s should be a warning without Err: %+v", key, issue)
+		}
+	}
+
+	if _, err := New(cfg); err != nil {
+		t.Errorf("warnings should not make New fail: %v", err)
+	}
+}
+
+func TestValidateLevelEnvAndNil(t *testing.T) {
+	t.Setenv("DD_TEST_VALIDATE_LEVEL", "loud")
+	cfg := DefaultConfig()
+	cfg.LevelEnv = "DD_TEST_VALIDATE_LEVEL"
+	if _, ok := issueCodes(cfg.Validate())["LevelEnv|"+ErrCodeInvalidLevel]; !ok {
+		t.Errorf("expected LevelEnv issue, got %v", cfg.Validate())
+	}
+
+	var nilCfg *Config
+	issues := nilCfg.Validate()
+	if len(issues) != 1 || issues[0].Code != ErrCodeNilConfig {
+		t.Errorf("unexpected issues for nil config: %v", issues)
+	}
+}
+
+func TestValidationIssueString(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Level = LogLevel(42)
+	s := cfg.Validate()[0].String()
+	if !strings.HasPrefix(s, "error Level [INVALID_LEVEL]: ") {
+		t.Errorf("unexpected String() %q", s)
+	}
+}
@@ -0,0 +1,105 @@
+package dd
+
+import (
+	"time"
+
+	"github.com/cybergodev/dd/internal"
+)
+
+// ValueEncoder renders field values of types the formatter has no native
+// support for, such as decimal.Decimal, uuid.UUID or net.IP, which would
+// otherwise go through reflection and fmt. EncodeValue returns the value
+// to log in place of v, typically a string or number, and whether it
+// handled v.
+//
+// Encoders are set with Config.ValueEncoders and consulted in order for
+// each top-level field value that is not a string, number, bool, []byte,
+// time.Time, time.Duration or nil. They run before sensitive data
+// filtering, so their output is filtered like any other value.
+type ValueEncoder interface {
+	EncodeValue(v any) (any, bool)
+}
+
+// ValueEncoderFunc adapts a function to ValueEncoder.
+type ValueEncoderFunc func(v any) (any, bool)
+
+// EncodeValue implements ValueEncoder.
+func (f ValueEncoderFunc) EncodeValue(v any) (any, bool) {
+	return f(v)
+}
+
+// EncodeType returns a ValueEncoder for values of type T.
+//
+// Example:
+//
+//	cfg.ValueEncoders = []dd.ValueEncoder{
+//	    dd.EncodeType(func(d decimal.Decimal) any { return d.String() }),
+//	    dd.EncodeType(func(ip net.IP) any { return ip.String() }),
+//	}
+func EncodeType[T any](fn func(T) any) ValueEncoder {
+	return ValueEncoderFunc(func(v any) (any, bool) {
+		t, ok := v.(T)
+		if !ok {
+			return nil, false
+		}
+		return fn(t), true
+	})
+}
+
+// isNativeValue reports whether the formatter renders v without help.
+func isNativeValue(v any) bool {
+	switch v.(type) {
+	case nil, string, bool, []byte,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64,
+		time.Time, time.Duration,
+		internal.ByteSize, internal.ObjectValue:
+		return true
+	}
+	return false
+}
+
+// encodeValues replaces field values handled by the logger's value
+// encoders. The fields slice is not modified.
+func (l *Logger) encodeValues(fields []Field) []Field {
+	if len(l.valueEncoders) == 0 {
+		return fields
+	}
+
+	var result []Field
+	for i, field := range fields {
+		if isNativeValue(field.Value) {
+			continue
+		}
+		value, ok := l.encodeValue(field)
+		if !ok {
+			continue
+		}
+		if result == nil {
+			result = make([]Field, len(fields))
+			copy(result, fields)
+		}
+		result[i].Value = value
+	}
+	if result == nil {
+		return fields
+	}
+	return result
+}
+
+// encodeValue returns the value of the first encoder that handles field.
+func (l *Logger) encodeValue(field Field) (value any, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			l.internalError(ComponentEncoder, nil, "value encoder panic for field %q: %v", field.Key, r)
+			value, ok = nil, false
+		}
+	}()
+	for _, enc := range l.valueEncoders {
+		if value, ok := enc.EncodeValue(field.Value); ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
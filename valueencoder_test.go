@@ -0,0 +1,70 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+)
+
+type testMoney struct{ cents int64 }
+
+func TestValueEncoders(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := JSONConfig()
+	cfg.Output = &buf
+	cfg.ValueEncoders = []ValueEncoder{
+		EncodeType(func(m testMoney) any { return fmt.Sprintf("%d.%02d", m.cents/100, m.cents%100) }),
+		EncodeType(func(ip net.IP) any { return ip.String() }),
+		ValueEncoderFunc(func(v any) (any, bool) {
+			if _, ok := v.(string); ok {
+				t.Error("encoders should not see native values")
+			}
+			return nil, false
+		}),
+	}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.InfoWith("paid",
+		Any("amount", testMoney{cents: 1250}),
+		Any("client", net.ParseIP("10.1.2.3")),
+		String("user", "bob"),
+		Any("other", struct{ N int }{7}),
+	)
+	out := buf.String()
+	for _, want := range []string{`"amount":"12.50"`, `"client":"10.1.2.3"`, `"user":"bob"`, `"other":{"N":7}`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s: %s", want, out)
+		}
+	}
+}
+
+func TestValueEncoderPanic(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Output = &buf
+	cfg.ValueEncoders = []ValueEncoder{
+		EncodeType(func(m testMoney) any { panic("bad encoder") }),
+	}
+	logger, _ := New(cfg)
+	defer logger.Close()
+
+	logger.InfoWith("paid", Any("amount", testMoney{cents: 5}))
+	if !strings.Contains(buf.String(), "paid") {
+		t.Errorf("entry should still be written: %q", buf.String())
+	}
+}
+
+func TestValueEncodersValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ValueEncoders = []ValueEncoder{nil}
+	if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("expected ErrConfigValidation, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package dd
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// maxWriterLineSize bounds how much of an unterminated line WriterLevel
+// buffers; longer lines are logged in pieces of this size.
+const maxWriterLineSize = 64 * 1024
+
+// WriterLevel returns an io.Writer that logs everything written to it at the
+// given level, one entry per line. Use it to plug the logger into APIs that
+// only accept an io.Writer, such as http.Server.ErrorLog or database
+// drivers. Each line goes through the usual level checks, sensitive data
+// filtering and formatting; trailing "\r" and empty lines are dropped.
+//
+// Bytes after the last newline are kept until the next write completes the
+// line. The returned writer also implements io.Closer; Close logs any
+// pending partial line. The writer is safe for concurrent use.
+//
+// Example:
+//
+//	srv := &http.Server{ErrorLog: log.New(logger.WriterLevel(dd.LevelWarn), "", 0)}
+func (l *Logger) WriterLevel(level LogLevel) io.Writer {
+	if l == nil {
+		l = nilLogger()
+	}
+	return &lineWriter{logger: l, level: level}
+}
+
+// lineWriter splits writes into lines and logs each one.
+type lineWriter struct {
+	logger *Logger
+	level  LogLevel
+
+	mu  sync.Mutex
+	buf []byte // pending partial line
+}
+
+// Write logs every complete line in p and buffers the rest. It always
+// reports len(p) bytes written so callers never treat logging as failed.
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			w.buf = append(w.buf, p...)
+			for len(w.buf) >= maxWriterLineSize {
+				w.logLine(w.buf[:maxWriterLineSize])
+				w.buf = append(w.buf[:0], w.buf[maxWriterLineSize:]...)
+			}
+			break
+		}
+		if len(w.buf) > 0 {
+			w.buf = append(w.buf, p[:i]...)
+			w.logLine(w.buf)
+			w.buf = w.buf[:0]
+		} else {
+			w.logLine(p[:i])
+		}
+		p = p[i+1:]
+	}
+	return n, nil
+}
+
+// Close logs the pending partial line, if any.
+func (w *lineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.logLine(w.buf)
+		w.buf = nil
+	}
+	return nil
+}
+
+func (w *lineWriter) logLine(line []byte) {
+	line = bytes.TrimSuffix(line, []byte{'\r'})
+	if len(line) == 0 {
+		return
+	}
+	w.logger.LogWith(w.level, string(line))
+}
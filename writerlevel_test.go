@@ -0,0 +1,71 @@
+package dd
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestWriterLevelSplitsLines(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+	w := logger.WriterLevel(LevelWarn)
+
+	io.WriteString(w, "first line\r\nsecond ")
+	io.WriteString(w, "line\n\nthird")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 entries, got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], "WARN") || !strings.HasSuffix(lines[0], "first line") {
+		t.Errorf("unexpected first entry %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "second line") {
+		t.Errorf("partial writes should be joined: %q", lines[1])
+	}
+
+	w.(io.Closer).Close()
+	if !strings.Contains(buf.String(), "third") {
+		t.Errorf("Close should log the pending line: %q", buf.String())
+	}
+}
+
+func TestWriterLevelFiltersAndLevels(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+
+	io.WriteString(logger.WriterLevel(LevelDebug), "debug line\n")
+	if buf.Len() != 0 {
+		t.Errorf("lines below the logger level should be dropped: %q", buf.String())
+	}
+
+	io.WriteString(logger.WriterLevel(LevelError), "db error password=hunter22\n")
+	if strings.Contains(buf.String(), "hunter22") {
+		t.Errorf("lines should be filtered: %q", buf.String())
+	}
+}
+
+func TestWriterLevelWithStdLog(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+	std := log.New(logger.WriterLevel(LevelInfo), "http: ", 0)
+
+	std.Printf("TLS handshake error from %s", "1.2.3.4:5678")
+
+	if !strings.Contains(buf.String(), "http: TLS handshake error") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestWriterLevelLongLine(t *testing.T) {
+	logger, buf := newTestLogger(t, nil)
+	w := logger.WriterLevel(LevelInfo)
+
+	n, err := w.Write(bytes.Repeat([]byte("x"), maxWriterLineSize+10))
+	if err != nil || n != maxWriterLineSize+10 {
+		t.Fatalf("Write = %d, %v", n, err)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("a full buffer should be logged without waiting for a newline")
+	}
+}
@@ -0,0 +1,135 @@
+package dd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// WriterMiddleware wraps a writer with a byte-level transform, such as a
+// line prefix, encryption or a byte counter. Config.WriterMiddleware
+// applies it to every writer of the logger, including writers added later
+// with AddWriter, so the transform does not have to be wired up per writer.
+//
+// The returned writer receives each formatted entry, newline included, and
+// should write the result to next. It must not close next: the logger
+// closes the original writer itself. If the returned writer implements
+// Flusher or io.Closer, it is flushed or closed before the writer it wraps.
+//
+// Example:
+//
+//	prefix := func(next io.Writer) io.Writer {
+//	    return dd.WriterFunc(func(p []byte) (int, error) {
+//	        if _, err := next.Write(append([]byte("[api] "), p...)); err != nil {
+//	            return 0, err
+//	        }
+//	        return len(p), nil
+//	    })
+//	}
+//	cfg.WriterMiddleware = []dd.WriterMiddleware{prefix}
+type WriterMiddleware func(next io.Writer) io.Writer
+
+// WriterFunc adapts a function to io.Writer, which is convenient for
+// WriterMiddleware.
+type WriterFunc func(p []byte) (int, error)
+
+// Write calls f(p).
+func (f WriterFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+// errNilMiddlewareWriter is returned when a middleware returns nil.
+var errNilMiddlewareWriter = errors.New("writer middleware returned nil")
+
+// applyWriterMiddleware wraps w with chain so that chain[0] sees the
+// entry first. It returns w unchanged when chain is empty.
+func applyWriterMiddleware(w io.Writer, chain []WriterMiddleware) (io.Writer, error) {
+	if len(chain) == 0 {
+		return w, nil
+	}
+	out := w
+	for i := len(chain) - 1; i >= 0; i-- {
+		out = chain[i](out)
+		if out == nil {
+			return nil, fmt.Errorf("%w: WriterMiddleware[%d]", errNilMiddlewareWriter, i)
+		}
+	}
+	mw := &middlewareWriter{writer: out, base: w}
+	if mlw, ok := w.(MinLevelWriter); ok {
+		return &minLevelMiddlewareWriter{middlewareWriter: mw, minLevel: mlw.MinLevel}, nil
+	}
+	return mw, nil
+}
+
+// middlewareWriter is a writer wrapped by Config.WriterMiddleware. It keeps
+// the original writer for RemoveWriter, Flush and Close.
+type middlewareWriter struct {
+	writer io.Writer // output of the middleware chain
+	base   io.Writer // writer the chain was applied to
+}
+
+// Write writes p through the middleware chain.
+func (mw *middlewareWriter) Write(p []byte) (int, error) {
+	return mw.writer.Write(p)
+}
+
+// Flush flushes the middleware chain, then the original writer.
+func (mw *middlewareWriter) Flush() error {
+	var errs []error
+	if f, ok := mw.writer.(Flusher); ok && mw.writer != mw.base {
+		errs = append(errs, f.Flush())
+	}
+	if f, ok := mw.base.(Flusher); ok {
+		errs = append(errs, f.Flush())
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes the middleware chain, then the original writer.
+func (mw *middlewareWriter) Close() error {
+	var errs []error
+	if c, ok := mw.writer.(io.Closer); ok && mw.writer != mw.base {
+		errs = append(errs, c.Close())
+	}
+	errs = append(errs, closeWriter(mw.base))
+	return errors.Join(errs...)
+}
+
+// minLevelMiddlewareWriter keeps the MinLevel of a wrapped MinLevelWriter
+// so the logger still applies the writer's own level.
+type minLevelMiddlewareWriter struct {
+	*middlewareWriter
+	minLevel func() LogLevel
+}
+
+// MinLevel implements MinLevelWriter.
+func (mw *minLevelMiddlewareWriter) MinLevel() LogLevel {
+	return mw.minLevel()
+}
+
+// unwrapMiddleware returns the writer a middleware chain was applied to,
+// or w itself.
+func unwrapMiddleware(w io.Writer) io.Writer {
+	switch mw := w.(type) {
+	case *middlewareWriter:
+		return mw.base
+	case *minLevelMiddlewareWriter:
+		return mw.base
+	}
+	return w
+}
+
+// applyConfigMiddleware is applyWriterMiddleware for the writers built
+// from Config. Level routes stay outside the chain so they still see the
+// level of each entry.
+func applyConfigMiddleware(w io.Writer, chain []WriterMiddleware) (io.Writer, error) {
+	rw, ok := w.(*levelRouteWriter)
+	if !ok {
+		return applyWriterMiddleware(w, chain)
+	}
+	inner, err := applyWriterMiddleware(rw.writer, chain)
+	if err != nil {
+		return nil, err
+	}
+	return &levelRouteWriter{writer: inner, levels: rw.levels}, nil
+}
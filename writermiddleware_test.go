@@ -0,0 +1,148 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func prefixMiddleware(prefix string) WriterMiddleware {
+	return func(next io.Writer) io.Writer {
+		return WriterFunc(func(p []byte) (int, error) {
+			if _, err := next.Write(append([]byte(prefix), p...)); err != nil {
+				return 0, err
+			}
+			return len(p), nil
+		})
+	}
+}
+
+func newMiddlewareTestConfig(outputs ...io.Writer) *Config {
+	cfg := DefaultConfig()
+	cfg.IncludeTime = false
+	cfg.DynamicCaller = false
+	cfg.Outputs = outputs
+	cfg.WriterMiddleware = []WriterMiddleware{prefixMiddleware("A:"), prefixMiddleware("B:")}
+	return cfg
+}
+
+func TestWriterMiddlewareAppliesToAllWriters(t *testing.T) {
+	var first, second, added bytes.Buffer
+	logger, err := New(newMiddlewareTestConfig(&first, &second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	if err := logger.AddWriter(&added); err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("hello")
+
+	for name, buf := range map[string]*bytes.Buffer{"first": &first, "second": &second, "added": &added} {
+		// A runs first, so B's prefix ends up outermost
+		if got := buf.String(); !strings.HasPrefix(got, "B:A:") || !strings.Contains(got, "hello") {
+			t.Errorf("%s = %q", name, got)
+		}
+	}
+}
+
+func TestWriterMiddlewareRemoveWriter(t *testing.T) {
+	var buf, added bytes.Buffer
+	logger, err := New(newMiddlewareTestConfig(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	if err := logger.AddWriter(&added); err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.RemoveWriter(&added); err != nil {
+		t.Fatalf("RemoveWriter: %v", err)
+	}
+	if n := logger.WriterCount(); n != 1 {
+		t.Fatalf("WriterCount = %d, want 1", n)
+	}
+}
+
+func TestWriterMiddlewareKeepsLevelRoutes(t *testing.T) {
+	var regular, errs bytes.Buffer
+	cfg := newMiddlewareTestConfig(&regular)
+	cfg.RouteLevel(LevelError, &errs)
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Info("info entry")
+	logger.Error("error entry")
+
+	if got := regular.String(); !strings.Contains(got, "B:A:") || strings.Contains(got, "error entry") {
+		t.Errorf("regular = %q", got)
+	}
+	if got := errs.String(); !strings.Contains(got, "B:A:") || strings.Contains(got, "info entry") {
+		t.Errorf("errs = %q", got)
+	}
+}
+
+func TestWriterMiddlewareKeepsMinLevel(t *testing.T) {
+	var buf bytes.Buffer
+	lw, _ := NewLevelFilterWriter(&buf, LevelDebug)
+	cfg := newMiddlewareTestConfig(lw)
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	logger.Debug("debug entry")
+	if got := buf.String(); !strings.Contains(got, "debug entry") {
+		t.Errorf("MinLevelWriter lost its level: %q", got)
+	}
+}
+
+type flushCloseRecorder struct {
+	bytes.Buffer
+	flushed, closed int
+}
+
+func (r *flushCloseRecorder) Flush() error { r.flushed++; return nil }
+func (r *flushCloseRecorder) Close() error { r.closed++; return nil }
+
+func TestWriterMiddlewareFlushAndClose(t *testing.T) {
+	base := &flushCloseRecorder{}
+	chain := &flushCloseRecorder{}
+	cfg := newMiddlewareTestConfig(base)
+	cfg.WriterMiddleware = []WriterMiddleware{func(io.Writer) io.Writer { return chain }}
+	logger, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := logger.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if chain.flushed != 1 || base.flushed != 1 || chain.closed != 1 || base.closed != 1 {
+		t.Errorf("chain flushed/closed %d/%d, base %d/%d", chain.flushed, chain.closed, base.flushed, base.closed)
+	}
+}
+
+func TestWriterMiddlewareErrors(t *testing.T) {
+	cfg := newMiddlewareTestConfig(io.Discard)
+	cfg.WriterMiddleware = []WriterMiddleware{nil}
+	if _, err := New(cfg); !errors.Is(err, ErrConfigValidation) {
+		t.Errorf("nil middleware: err = %v", err)
+	}
+
+	cfg.WriterMiddleware = []WriterMiddleware{func(io.Writer) io.Writer { return nil }}
+	if _, err := New(cfg); !errors.Is(err, errNilMiddlewareWriter) {
+		t.Errorf("nil result: err = %v", err)
+	}
+}
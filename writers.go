@@ -32,12 +32,145 @@ func closeWriter(w io.Writer) error {
 	return nil
 }
 
+// LevelWriter is implemented by writers that want to know the level of each
+// log entry. When a writer implements LevelWriter, the Logger calls WriteLevel
+// instead of Write, allowing level-based decisions at the sink.
+type LevelWriter interface {
+	io.Writer
+	WriteLevel(level LogLevel, p []byte) (int, error)
+}
+
+// Record describes a log entry for writers implementing RecordWriter.
+// Writers must not retain the Record or its Fields after WriteRecord returns.
+type Record struct {
+	Time           time.Time
+	Level          LogLevel
+	Message        string
+	Fields         []Field
+	Classification ConfidentialityLevel
+
+	// Caller is the source location parsed by Reader. It is empty in
+	// records passed to RecordWriters.
+	Caller string
+}
+
+// RecordWriter is implemented by writers that make decisions based on entry
+// metadata (level, fields, classification). The Logger prefers WriteRecord
+// over WriteLevel and Write. p is the fully formatted entry.
+type RecordWriter interface {
+	io.Writer
+	WriteRecord(rec *Record, p []byte) (int, error)
+}
+
+// forwardRecord writes p to w using the richest interface w implements.
+// Wrapping writers use it to pass entry metadata through.
+func forwardRecord(w io.Writer, rec *Record, p []byte) (int, error) {
+	switch tw := w.(type) {
+	case RecordWriter:
+		return tw.WriteRecord(rec, p)
+	case LevelWriter:
+		return tw.WriteLevel(rec.Level, p)
+	default:
+		return w.Write(p)
+	}
+}
+
+// MinLevelWriter is implemented by writers that have their own minimum level.
+// The writer's MinLevel replaces the logger level for that writer, so it can
+// receive entries the logger would otherwise discard (e.g. DEBUG history in a
+// RingBufferWriter while the logger runs at INFO) or be restricted to fewer.
+type MinLevelWriter interface {
+	io.Writer
+	MinLevel() LogLevel
+}
+
+// LevelFilterWriter gives any writer its own minimum level.
+// See MinLevelWriter.
+type LevelFilterWriter struct {
+	writer   io.Writer
+	minLevel LogLevel
+}
+
+// NewLevelFilterWriter wraps w so it only receives entries at or above minLevel,
+// independent of the logger level.
+func NewLevelFilterWriter(w io.Writer, minLevel LogLevel) (*LevelFilterWriter, error) {
+	if w == nil {
+		return nil, ErrNilWriter
+	}
+	if !minLevel.IsValid() {
+		return nil, ErrInvalidLevel
+	}
+	return &LevelFilterWriter{writer: w, minLevel: minLevel}, nil
+}
+
+// MinLevel implements MinLevelWriter.
+func (lw *LevelFilterWriter) MinLevel() LogLevel {
+	return lw.minLevel
+}
+
+// Write writes p to the wrapped writer unconditionally.
+func (lw *LevelFilterWriter) Write(p []byte) (int, error) {
+	return lw.writer.Write(p)
+}
+
+// WriteLevel implements LevelWriter.
+func (lw *LevelFilterWriter) WriteLevel(level LogLevel, p []byte) (int, error) {
+	if level < lw.minLevel {
+		return len(p), nil
+	}
+	if w, ok := lw.writer.(LevelWriter); ok {
+		return w.WriteLevel(level, p)
+	}
+	return lw.writer.Write(p)
+}
+
+// WriteRecord implements RecordWriter.
+func (lw *LevelFilterWriter) WriteRecord(rec *Record, p []byte) (int, error) {
+	if rec.Level < lw.minLevel {
+		return len(p), nil
+	}
+	return forwardRecord(lw.writer, rec, p)
+}
+
+// Flush flushes the wrapped writer if it implements Flusher.
+func (lw *LevelFilterWriter) Flush() error {
+	if f, ok := lw.writer.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Close closes the wrapped writer.
+func (lw *LevelFilterWriter) Close() error {
+	return closeWriter(lw.writer)
+}
+
 type FileWriter struct {
 	path       string
 	maxSize    int64
 	maxAge     time.Duration
 	maxBackups int
-	compress   bool
+
+	// compression is nil when backups stay uncompressed
+	compression *compression
+
+	// Backup naming (nil backupName uses the default app_log_N.log scheme)
+	backupName func(BackupNameInfo) string
+	backupGlob string // matches custom backups for retention; empty disables it
+	backupSeq  int    // last sequence number handed out, guarded by mu
+	onRotate   func(backupPath string)
+	clock      Clock
+
+	// Disk space guard
+	minFreeBytes   int64
+	minFreePercent float64
+	maxTotalSize   int64
+	pruneOnLowDisk bool
+	onDiskPressure func(DiskPressureEvent)
+	degraded       atomic.Bool
+	minLevel       atomic.Int32 // Lowest LogLevel written while degraded
+	lastDiskCheck  atomic.Int64 // Unix nanoseconds of the last free space check
+	droppedWrites  atomic.Int64
 
 	mu          sync.Mutex
 	file        *os.File
@@ -52,7 +185,75 @@ type FileWriterConfig struct {
 	MaxSizeMB  int
 	MaxAge     time.Duration
 	MaxBackups int
-	Compress   bool
+	Compress   bool // gzip rotated backups; see CompressionAlgorithm
+
+	// CompressionAlgorithm compresses rotated backups with gzip, zstd (see
+	// CompressionZstd) or another registered algorithm; CompressionNone
+	// disables compression even if Compress is set. Empty uses gzip when
+	// Compress is set. Backups are compressed one at a time by a
+	// background worker; see FileWriter.CompressionStats.
+	CompressionAlgorithm CompressionAlgorithm
+
+	// CompressionLevel is passed to the algorithm (0 uses its default).
+	// gzip accepts compress/gzip levels (-2 to 9), zstd 1 to 22.
+	CompressionLevel int
+
+	// OnCompressError is called on a background goroutine when a backup
+	// stays uncompressed: it could not be compressed, or too many backups
+	// were waiting (ErrCompressionBacklog). Without it the error is
+	// written to stderr.
+	OnCompressError func(path string, err error)
+
+	// MinFreeBytes enables the disk space guard. When free space on the
+	// target filesystem drops below this threshold, the writer enters
+	// degraded mode and drops entries below WARN until space recovers.
+	// Zero disables the guard.
+	MinFreeBytes int64
+
+	// MinFreeDiskPercent is like MinFreeBytes but expressed as a percentage
+	// (0-100) of the filesystem size, and crossing it enters emergency mode,
+	// which drops entries below ERROR. Zero disables the check.
+	MinFreeDiskPercent float64
+
+	// MaxTotalSizeMB caps the combined size of the active file and its
+	// backups. The oldest backups are deleted when it is exceeded; if the
+	// active file alone is too large, the writer enters emergency mode, like
+	// MinFreeDiskPercent, until the next rotation. Zero disables the cap.
+	MaxTotalSizeMB int
+
+	// PruneBackupsOnLowDisk deletes the oldest backups, regardless of the
+	// MaxBackups/MaxAge retention policy, while free space is below
+	// MinFreeBytes or MinFreeDiskPercent.
+	PruneBackupsOnLowDisk bool
+
+	// OnDiskPressure is called asynchronously when the writer enters or
+	// leaves degraded mode, or deletes backups to relieve disk pressure.
+	OnDiskPressure func(event DiskPressureEvent)
+
+	// BackupNameTemplate names rotated files using text/template syntax with
+	// BackupNameInfo as data, e.g. "{{.Base}}-{{.Time}}-{{.Seq}}{{.Ext}}".
+	// Retention (MaxBackups, MaxAge, MaxTotalSizeMB) applies to files
+	// matching the template with {{.Base}} and {{.Ext}} filled in, so the
+	// template must include the log file name, via {{.Base}} or literally.
+	BackupNameTemplate string
+
+	// BackupNameFunc names rotated files; it takes precedence over
+	// BackupNameTemplate. It returns a file name in the log directory.
+	BackupNameFunc func(info BackupNameInfo) string
+
+	// BackupGlob is a filepath.Match pattern (file name only) identifying
+	// backups produced by BackupNameFunc, for retention. Without it,
+	// retention is disabled for BackupNameFunc.
+	BackupGlob string
+
+	// OnRotate is called with the final backup path (after compression,
+	// if enabled) once a rotation completes. It runs on a background
+	// goroutine; Close waits for pending calls.
+	OnRotate func(backupPath string)
+
+	// Clock supplies the time used for backup names and disk space checks
+	// (nil uses the system clock).
+	Clock Clock
 }
 
 // DefaultFileWriterConfig returns FileWriterConfig with sensible defaults.
@@ -92,9 +293,26 @@ func NewFileWriter(path string, opts ...FileWriterConfig) (*FileWriter, error) {
 		maxSize:    int64(effectiveConfig.MaxSizeMB) * 1024 * 1024,
 		maxAge:     effectiveConfig.MaxAge,
 		maxBackups: effectiveConfig.MaxBackups,
-		compress:   effectiveConfig.Compress,
 		ctx:        ctx,
 		cancel:     cancel,
+
+		minFreeBytes:   effectiveConfig.MinFreeBytes,
+		minFreePercent: effectiveConfig.MinFreeDiskPercent,
+		maxTotalSize:   int64(effectiveConfig.MaxTotalSizeMB) * 1024 * 1024,
+		pruneOnLowDisk: effectiveConfig.PruneBackupsOnLowDisk,
+		onDiskPressure: effectiveConfig.OnDiskPressure,
+		onRotate:       effectiveConfig.OnRotate,
+		clock:          clockOrSystem(effectiveConfig.Clock),
+	}
+
+	if err := fw.initBackupNaming(effectiveConfig); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if fw.compression, err = newCompression(effectiveConfig); err != nil {
+		cancel()
+		return nil, err
 	}
 
 	dir := filepath.Dir(securePath)
@@ -111,11 +329,20 @@ func NewFileWriter(path string, opts ...FileWriterConfig) (*FileWriter, error) {
 	fw.file = file
 	fw.currentSize.Store(size)
 
+	if fw.diskGuardEnabled() {
+		fw.checkDiskSpace(fw.clock.Now())
+	}
+
 	if fw.maxAge > 0 && fw.maxBackups > 0 {
 		fw.wg.Add(1)
 		go fw.cleanupRoutine()
 	}
 
+	if fw.compression != nil {
+		fw.wg.Add(1)
+		go fw.compressionWorker()
+	}
+
 	return fw, nil
 }
 
@@ -129,6 +356,15 @@ func validateFileWriterConfig(config *FileWriterConfig) error {
 	if config.MaxBackups > maxBackupCount {
 		return fmt.Errorf("%w: maximum %d", ErrMaxBackupsExceeded, maxBackupCount)
 	}
+	if config.MinFreeBytes < 0 {
+		return fmt.Errorf("%w: MinFreeBytes cannot be negative", ErrConfigValidation)
+	}
+	if config.MinFreeDiskPercent < 0 || config.MinFreeDiskPercent >= 100 {
+		return fmt.Errorf("%w: MinFreeDiskPercent must be in [0, 100)", ErrConfigValidation)
+	}
+	if config.MaxTotalSizeMB < 0 {
+		return fmt.Errorf("%w: MaxTotalSizeMB cannot be negative", ErrConfigValidation)
+	}
 
 	return nil
 }
@@ -158,6 +394,9 @@ func applyFileWriterDefaults(config FileWriterConfig) FileWriterConfig {
 	return config
 }
 
+// Write writes p to the log file, rotating first if needed.
+// Writes made through Write carry no level and are never dropped by the
+// disk space guard; the Logger uses WriteLevel instead.
 func (fw *FileWriter) Write(p []byte) (int, error) {
 	pLen := len(p)
 	if pLen == 0 {
@@ -167,6 +406,42 @@ func (fw *FileWriter) Write(p []byte) (int, error) {
 	fw.mu.Lock()
 	defer fw.mu.Unlock()
 
+	if fw.diskGuardEnabled() {
+		fw.checkDiskSpace(fw.clock.Now())
+	}
+
+	return fw.writeLocked(p)
+}
+
+// WriteLevel implements LevelWriter. In degraded mode (see
+// FileWriterConfig.MinFreeBytes), entries below LevelWarn are dropped, and
+// in emergency mode (MinFreeDiskPercent, MaxTotalSizeMB) entries below
+// LevelError. Dropped entries are reported as fully written so callers do
+// not treat them as errors.
+func (fw *FileWriter) WriteLevel(level LogLevel, p []byte) (int, error) {
+	pLen := len(p)
+	if pLen == 0 {
+		return 0, nil
+	}
+
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	if fw.diskGuardEnabled() {
+		fw.checkDiskSpace(fw.clock.Now())
+		if fw.degraded.Load() && level < LogLevel(fw.minLevel.Load()) {
+			fw.droppedWrites.Add(1)
+			return pLen, nil
+		}
+	}
+
+	return fw.writeLocked(p)
+}
+
+// writeLocked performs the write. Caller must hold fw.mu.
+func (fw *FileWriter) writeLocked(p []byte) (int, error) {
+	pLen := len(p)
+
 	if internal.NeedsRotation(fw.currentSize.Load(), int64(pLen), fw.maxSize) {
 		if err := fw.rotate(); err != nil {
 			return 0, fmt.Errorf("rotation failed: %w", err)
@@ -182,6 +457,16 @@ func (fw *FileWriter) Write(p []byte) (int, error) {
 	return n, nil
 }
 
+// IsDegraded reports whether the writer is in low-disk degraded mode.
+func (fw *FileWriter) IsDegraded() bool {
+	return fw.degraded.Load()
+}
+
+// DroppedWrites returns the number of entries dropped by the disk space guard.
+func (fw *FileWriter) DroppedWrites() int64 {
+	return fw.droppedWrites.Load()
+}
+
 func (fw *FileWriter) Close() error {
 	fw.cancel()
 	fw.wg.Wait()
@@ -205,8 +490,7 @@ func (fw *FileWriter) rotate() error {
 		fw.file = nil
 	}
 
-	nextIndex := internal.FindNextBackupIndex(fw.path, fw.compress)
-	backupPath := internal.GetBackupPath(fw.path, nextIndex, false)
+	backupPath := fw.nextBackupPath(fw.clock.Now())
 
 	if err := os.Rename(fw.path, backupPath); err != nil {
 		// Rename failed, try to reopen the original file
@@ -227,7 +511,7 @@ func (fw *FileWriter) rotate() error {
 		if renameBackErr := os.Rename(backupPath, fw.path); renameBackErr != nil {
 			// Recovery failed - this is a critical error
 			// Log to stderr as we cannot return this error without losing the rotation error
-			fmt.Fprintf(os.Stderr, "dd: CRITICAL - failed to open new log file and failed to recover backup: open=%v, recover=%v\n", err, renameBackErr)
+			reportInternal(nil, ComponentWriter, err, "CRITICAL - failed to open new log file and failed to recover backup: open=%v, recover=%v", err, renameBackErr)
 			return fmt.Errorf("open new file failed and recovery failed: open=%w, recovery=%w", err, renameBackErr)
 		}
 		// Recovery succeeded, try to reopen the original file
@@ -243,21 +527,34 @@ func (fw *FileWriter) rotate() error {
 	fw.currentSize.Store(size)
 
 	// Only perform cleanup and compression after successful file open
-	internal.RotateBackups(fw.path, fw.maxBackups, fw.compress)
+	fw.applyRetention()
+	if fw.diskGuardEnabled() {
+		// Re-evaluate disk pressure on the next write
+		fw.lastDiskCheck.Store(0)
+	}
 
-	if fw.compress {
+	if fw.compression != nil {
+		fw.queueCompression(backupPath)
+	} else if fw.onRotate != nil {
 		fw.wg.Add(1)
-		go fw.compressBackup(backupPath)
+		go func() {
+			defer fw.wg.Done()
+			fw.callOnRotate(backupPath)
+		}()
 	}
 
 	return nil
 }
 
-func (fw *FileWriter) compressBackup(path string) {
-	defer fw.wg.Done()
-	if err := internal.CompressFile(path); err != nil {
-		fmt.Fprintf(os.Stderr, "dd: compress backup %s: %v\n", path, err)
-	}
+// callOnRotate runs the OnRotate callback, recovering from panics so a
+// faulty callback cannot crash the application.
+func (fw *FileWriter) callOnRotate(path string) {
+	defer func() {
+		if r := recover(); r != nil {
+			reportInternal(nil, ComponentWriter, nil, "OnRotate callback panic for %s: %v", path, r)
+		}
+	}()
+	fw.onRotate(path)
 }
 
 func (fw *FileWriter) cleanupRoutine() {
@@ -271,9 +568,9 @@ func (fw *FileWriter) cleanupRoutine() {
 		case <-fw.ctx.Done():
 			return
 		case <-ticker.C:
-			if err := internal.CleanupOldFiles(fw.path, fw.maxAge); err != nil {
+			if err := fw.cleanupOldBackups(); err != nil {
 				// Log to stderr as fallback - cleanup errors should not be silent
-				fmt.Fprintf(os.Stderr, "dd: cleanup old files %s: %v\n", fw.path, err)
+				reportInternal(nil, ComponentWriter, err, "cleanup old files %s: %v", fw.path, err)
 			}
 		}
 	}
@@ -296,6 +593,11 @@ type BufferedWriter struct {
 	lastFlush time.Time
 	wg        sync.WaitGroup
 	closed    atomic.Bool
+
+	// spill mirrors the unflushed buffer (see BufferedWriterConfig.SpillPath);
+	// flushed counts the bytes the buffer has passed to writer.
+	spill   *os.File
+	flushed int64
 }
 
 // NewBufferedWriter creates a new BufferedWriter with the specified buffer size.
@@ -303,31 +605,59 @@ type BufferedWriter struct {
 // Remember to call Close() to ensure all buffered data is written to the underlying writer.
 // If bufferSize is not specified or is 0, 1KB is used.
 func NewBufferedWriter(w io.Writer, bufferSizes ...int) (*BufferedWriter, error) {
+	var cfg BufferedWriterConfig
+	if len(bufferSizes) > 0 {
+		cfg.BufferSize = bufferSizes[0]
+	}
+	return NewBufferedWriterWithConfig(w, cfg)
+}
+
+// NewBufferedWriterWithConfig is like NewBufferedWriter but takes a
+// BufferedWriterConfig. With a SpillPath, entries left in the spill file
+// by a crashed process are written to w first.
+func NewBufferedWriterWithConfig(w io.Writer, cfg BufferedWriterConfig) (*BufferedWriter, error) {
 	if w == nil {
 		return nil, ErrNilWriter
 	}
 
-	bufferSize := 0
-	if len(bufferSizes) > 0 {
-		bufferSize = bufferSizes[0]
-	}
+	bufferSize := cfg.BufferSize
 	if bufferSize < defaultBufferSizeKB*1024 {
 		bufferSize = defaultBufferSizeKB * 1024
 	}
 	if bufferSize > maxBufferSizeKB*1024 {
 		return nil, fmt.Errorf("%w: maximum %dMB", ErrBufferSizeTooLarge, maxBufferSizeKB/1024)
 	}
+	if cfg.FlushInterval < 0 {
+		return nil, fmt.Errorf("%w: FlushInterval must not be negative", ErrConfigValidation)
+	}
+	flushTime := cfg.FlushInterval
+	if flushTime == 0 {
+		flushTime = autoFlushInterval
+	}
+
+	var spill *os.File
+	if cfg.SpillPath != "" {
+		var err error
+		if spill, err = openSpill(cfg.SpillPath, w); err != nil {
+			return nil, err
+		}
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
 	bw := &BufferedWriter{
 		writer:    w,
-		buffer:    bufio.NewWriterSize(w, bufferSize),
 		flushSize: bufferSize / autoFlushThreshold,
-		flushTime: autoFlushInterval,
+		flushTime: flushTime,
 		ctx:       ctx,
 		cancel:    cancel,
 		lastFlush: time.Now(),
+		spill:     spill,
+	}
+	if spill != nil {
+		bw.buffer = bufio.NewWriterSize(&countingWriter{w: w, n: &bw.flushed}, bufferSize)
+	} else {
+		bw.buffer = bufio.NewWriterSize(w, bufferSize)
 	}
 
 	bw.wg.Add(1)
@@ -345,6 +675,9 @@ func (bw *BufferedWriter) Write(p []byte) (int, error) {
 	bw.mu.Lock()
 	defer bw.mu.Unlock()
 
+	flushed := bw.flushed
+	bw.appendSpill(p)
+
 	n, err := bw.buffer.Write(p)
 	if err != nil {
 		return n, err
@@ -357,6 +690,7 @@ func (bw *BufferedWriter) Write(p []byte) (int, error) {
 		bw.lastFlush = time.Now()
 	}
 
+	bw.syncSpill(p, flushed)
 	return n, nil
 }
 
@@ -366,9 +700,18 @@ func (bw *BufferedWriter) Flush() error {
 
 	err := bw.buffer.Flush()
 	bw.lastFlush = time.Now()
+	if err == nil {
+		bw.syncSpill(nil, bw.flushed)
+	}
 	return err
 }
 
+// MemoryUsage implements MemoryUser: the size of the buffer, which is
+// allocated up front.
+func (bw *BufferedWriter) MemoryUsage() int64 {
+	return int64(bw.buffer.Size())
+}
+
 func (bw *BufferedWriter) Close() error {
 	if bw == nil {
 		return nil
@@ -387,6 +730,12 @@ func (bw *BufferedWriter) Close() error {
 			errs = append(errs, fmt.Errorf("flush: %w", err))
 		}
 	}
+	if bw.spill != nil {
+		// Keep the spill file if entries could not be flushed
+		if err := bw.closeSpill(len(errs) == 0); err != nil {
+			errs = append(errs, fmt.Errorf("spill file: %w", err))
+		}
+	}
 	bw.mu.Unlock()
 
 	// Now stop the background goroutine
@@ -429,7 +778,9 @@ func (bw *BufferedWriter) autoFlushRoutine() {
 			}
 			if bw.buffer.Buffered() > 0 && time.Since(bw.lastFlush) >= bw.flushTime {
 				if err := bw.buffer.Flush(); err != nil {
-					fmt.Fprintf(os.Stderr, "dd: autoflush error: %v\n", err)
+					reportInternal(nil, ComponentWriter, err, "autoflush error: %v", err)
+				} else {
+					bw.syncSpill(nil, bw.flushed)
 				}
 				bw.lastFlush = time.Now()
 			}
@@ -459,7 +810,27 @@ func NewMultiWriter(writers ...io.Writer) *MultiWriter {
 	return mw
 }
 
+// Write writes p to every writer. Writers added with AddWriterLevel
+// receive it regardless of their level, since p carries no level.
 func (mw *MultiWriter) Write(p []byte) (int, error) {
+	return mw.fanOut(p, func(w io.Writer) (int, error) {
+		return w.Write(p)
+	})
+}
+
+// WriteLevel implements LevelWriter. Writers added with AddWriterLevel
+// skip entries below their level; LevelWriters receive the level.
+func (mw *MultiWriter) WriteLevel(level LogLevel, p []byte) (int, error) {
+	return mw.fanOut(p, func(w io.Writer) (int, error) {
+		if lw, ok := w.(LevelWriter); ok {
+			return lw.WriteLevel(level, p)
+		}
+		return w.Write(p)
+	})
+}
+
+// fanOut calls write for every writer and collects the errors.
+func (mw *MultiWriter) fanOut(p []byte, write func(w io.Writer) (int, error)) (int, error) {
 	pLen := len(p)
 	if pLen == 0 {
 		return 0, nil
@@ -476,7 +847,7 @@ func (mw *MultiWriter) Write(p []byte) (int, error) {
 
 	// Fast path: single writer optimization
 	if writerCount == 1 {
-		return writers[0].Write(p)
+		return write(writers[0])
 	}
 
 	// Iterate directly over the immutable slice - no copy needed
@@ -484,13 +855,13 @@ func (mw *MultiWriter) Write(p []byte) (int, error) {
 	successCount := 0
 
 	for i := 0; i < writerCount; i++ {
-		n, err := writers[i].Write(p)
+		n, err := write(writers[i])
 		if err != nil {
-			allErrors.AddError(i, writers[i], err)
+			allErrors.AddError(i, unwrapMultiLevel(writers[i]), err)
 			continue
 		}
 		if n != pLen {
-			allErrors.AddError(i, writers[i], fmt.Errorf("short write (%d/%d bytes)", n, pLen))
+			allErrors.AddError(i, unwrapMultiLevel(writers[i]), fmt.Errorf("short write (%d/%d bytes)", n, pLen))
 			continue
 		}
 		successCount++
@@ -516,6 +887,36 @@ func (mw *MultiWriter) AddWriter(w io.Writer) error {
 	if w == nil {
 		return ErrNilWriter
 	}
+	return mw.add(w)
+}
+
+// AddWriterLevel adds w so that it only receives entries at or above
+// minLevel, letting one MultiWriter fan out e.g. errors to an alerting
+// sink and everything to a file. Levels reach the MultiWriter through
+// WriteLevel when the logger writes to it; the logger level still applies
+// first, so minLevel can only narrow what w receives. Adding a writer
+// that is already present leaves it unchanged.
+//
+// Example:
+//
+//	mw := dd.NewMultiWriter(fileWriter)
+//	mw.AddWriterLevel(alertWriter, dd.LevelError)
+//	cfg.Output = mw
+func (mw *MultiWriter) AddWriterLevel(w io.Writer, minLevel LogLevel) error {
+	if mw == nil {
+		return ErrNilMultiWriter
+	}
+	if w == nil {
+		return ErrNilWriter
+	}
+	if !minLevel.IsValid() {
+		return ErrInvalidLevel
+	}
+	return mw.add(&multiLevelWriter{LevelFilterWriter{writer: w, minLevel: minLevel}})
+}
+
+// add appends w unless it is already present.
+func (mw *MultiWriter) add(w io.Writer) error {
 
 	mw.mu.Lock()
 	defer mw.mu.Unlock()
@@ -528,7 +929,7 @@ func (mw *MultiWriter) AddWriter(w io.Writer) error {
 
 	// Check for duplicates
 	for _, existing := range *currentWriters {
-		if existing == w {
+		if unwrapMultiLevel(existing) == unwrapMultiLevel(w) {
 			return nil // Already exists, not an error
 		}
 	}
@@ -563,7 +964,7 @@ func (mw *MultiWriter) RemoveWriter(w io.Writer) error {
 
 	writerCount := len(*currentWriters)
 	for i := 0; i < writerCount; i++ {
-		if (*currentWriters)[i] == w {
+		if unwrapMultiLevel((*currentWriters)[i]) == w {
 			// Create new slice without the removed writer
 			newWriters := make([]io.Writer, writerCount-1)
 			copy(newWriters, (*currentWriters)[:i])
@@ -595,3 +996,16 @@ func (mw *MultiWriter) Close() error {
 
 	return errors.Join(errs...)
 }
+
+// multiLevelWriter is a writer added with MultiWriter.AddWriterLevel.
+type multiLevelWriter struct {
+	LevelFilterWriter
+}
+
+// unwrapMultiLevel returns the writer passed to AddWriterLevel, or w.
+func unwrapMultiLevel(w io.Writer) io.Writer {
+	if lw, ok := w.(*multiLevelWriter); ok {
+		return lw.writer
+	}
+	return w
+}
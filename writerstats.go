@@ -0,0 +1,281 @@
+package dd
+
+import (
+	"io"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// writerLatencyBounds are the upper bounds of the write latency histogram
+// buckets; a final bucket counts slower writes.
+var writerLatencyBounds = [...]time.Duration{
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+}
+
+// LatencyBucket counts writes that took at most UpperBound and longer than
+// the previous bucket's bound. The last bucket has UpperBound
+// math.MaxInt64 and counts every slower write.
+type LatencyBucket struct {
+	UpperBound time.Duration
+	Count      int64
+}
+
+// WriterStats reports the activity of one writer since the logger was
+// created or ResetWriterStats was called. Use it to alert on a slow or
+// failing log pipeline.
+type WriterStats struct {
+	Index  int       // Position in the logger's writer list
+	Writer io.Writer // The writer
+
+	Writes       int64 // Entries passed to the writer
+	BytesWritten int64 // Bytes the writer reported as written
+	Errors       int64 // Writes that returned an error
+
+	// ConsecutiveErrors is the number of failed writes since the last
+	// successful one; a growing value means the writer is down.
+	ConsecutiveErrors int64
+
+	// Dropped counts entries that were not fully written: failed or short
+	// writes, plus drops reported by the writer itself (FileWriter in
+	// degraded mode, LokiWriter queue overflow).
+	Dropped int64
+
+	LastError     error     // Most recent write error, nil if none
+	LastErrorTime time.Time // Time of LastError
+
+	MaxLatency     time.Duration   // Slowest single write
+	LatencyBuckets []LatencyBucket // Write latency histogram
+
+	// Quarantined reports whether the writer is quarantined after repeated
+	// failures (see QuarantineConfig), since QuarantinedSince.
+	Quarantined      bool
+	QuarantinedSince time.Time
+
+	// SkippedWrites and SkippedBytes count the entries the writer did not
+	// receive while quarantined. They are included in Dropped.
+	SkippedWrites int64
+	SkippedBytes  int64
+}
+
+// writerStats holds the counters of one writer.
+type writerStats struct {
+	writer io.Writer
+
+	writes            atomic.Int64
+	bytes             atomic.Int64
+	errors            atomic.Int64
+	consecutiveErrors atomic.Int64
+	dropped           atomic.Int64
+	maxLatency        atomic.Int64
+	latencyTotal      atomic.Int64 // sum of write latencies, for AdaptiveConfig
+	buckets           [len(writerLatencyBounds) + 1]atomic.Int64
+
+	// writerDropsBase is the writer-reported drop count at the last reset.
+	writerDropsBase atomic.Int64
+
+	errMu     sync.Mutex
+	lastErr   error
+	lastErrAt time.Time
+
+	quarantine quarantineState
+}
+
+// writerStatsSet is the stats for one writers slice. writers identifies
+// the slice, so a stale set is never applied to a newer slice.
+type writerStatsSet struct {
+	writers *[]io.Writer
+	stats   []*writerStats
+}
+
+// droppingWriter is implemented by writers that count their own drops.
+type droppingWriter interface {
+	DroppedWrites() int64
+}
+
+// lokiDropper matches LokiWriter.Dropped.
+type lokiDropper interface {
+	Dropped() int64
+}
+
+// writerReportedDrops returns the drops counted by w itself.
+func writerReportedDrops(w io.Writer) int64 {
+	switch dw := w.(type) {
+	case droppingWriter:
+		return dw.DroppedWrites()
+	case lokiDropper:
+		return dw.Dropped()
+	}
+	return 0
+}
+
+// record updates the counters after one write of size bytes.
+func (s *writerStats) record(n, size int, err error, latency time.Duration, now func() time.Time) {
+	s.writes.Add(1)
+	if n > 0 {
+		s.bytes.Add(int64(n))
+	}
+	if err != nil {
+		s.errors.Add(1)
+		s.consecutiveErrors.Add(1)
+		s.errMu.Lock()
+		s.lastErr = err
+		s.lastErrAt = now()
+		s.errMu.Unlock()
+	} else if s.consecutiveErrors.Load() != 0 {
+		s.consecutiveErrors.Store(0)
+	}
+	if err != nil || n < size {
+		s.dropped.Add(1)
+	}
+
+	bucket := len(writerLatencyBounds)
+	for i, bound := range writerLatencyBounds {
+		if latency <= bound {
+			bucket = i
+			break
+		}
+	}
+	s.buckets[bucket].Add(1)
+	s.latencyTotal.Add(int64(latency))
+	for {
+		current := s.maxLatency.Load()
+		if int64(latency) <= current || s.maxLatency.CompareAndSwap(current, int64(latency)) {
+			break
+		}
+	}
+}
+
+// snapshot returns the public form of s.
+func (s *writerStats) snapshot(index int) WriterStats {
+	stats := WriterStats{
+		Index:             index,
+		Writer:            s.writer,
+		Writes:            s.writes.Load(),
+		BytesWritten:      s.bytes.Load(),
+		Errors:            s.errors.Load(),
+		ConsecutiveErrors: s.consecutiveErrors.Load(),
+		Dropped:           s.dropped.Load() + s.quarantine.skipped.Load() + writerReportedDrops(s.writer) - s.writerDropsBase.Load(),
+		SkippedWrites:     s.quarantine.skipped.Load(),
+		SkippedBytes:      s.quarantine.skippedBytes.Load(),
+		MaxLatency:        time.Duration(s.maxLatency.Load()),
+		LatencyBuckets:    make([]LatencyBucket, len(s.buckets)),
+	}
+	for i := range s.buckets {
+		bound := time.Duration(math.MaxInt64)
+		if i < len(writerLatencyBounds) {
+			bound = writerLatencyBounds[i]
+		}
+		stats.LatencyBuckets[i] = LatencyBucket{UpperBound: bound, Count: s.buckets[i].Load()}
+	}
+	s.errMu.Lock()
+	stats.LastError = s.lastErr
+	stats.LastErrorTime = s.lastErrAt
+	s.errMu.Unlock()
+	if s.quarantine.active.Load() {
+		s.quarantine.mu.Lock()
+		stats.Quarantined = s.quarantine.active.Load()
+		stats.QuarantinedSince = s.quarantine.since
+		s.quarantine.mu.Unlock()
+	}
+	return stats
+}
+
+// reset clears the counters.
+func (s *writerStats) reset() {
+	s.writes.Store(0)
+	s.bytes.Store(0)
+	s.errors.Store(0)
+	s.consecutiveErrors.Store(0)
+	s.dropped.Store(0)
+	s.maxLatency.Store(0)
+	s.latencyTotal.Store(0)
+	for i := range s.buckets {
+		s.buckets[i].Store(0)
+	}
+	s.writerDropsBase.Store(writerReportedDrops(s.writer))
+	s.quarantine.mu.Lock()
+	s.quarantine.skippedBase -= s.quarantine.skipped.Swap(0)
+	s.quarantine.bytesBase -= s.quarantine.skippedBytes.Swap(0)
+	s.quarantine.mu.Unlock()
+	s.errMu.Lock()
+	s.lastErr = nil
+	s.lastErrAt = time.Time{}
+	s.errMu.Unlock()
+}
+
+// storeWriterStats builds the stats set for writers, keeping the counters
+// of writers that were already registered. Caller holds writersMu.
+func (l *Logger) storeWriterStats(writers *[]io.Writer) {
+	var previous []*writerStats
+	if old := l.writerStats.Load(); old != nil {
+		previous = old.stats
+	}
+
+	stats := make([]*writerStats, len(*writers))
+	for i, w := range *writers {
+		for _, s := range previous {
+			if s.writer == w {
+				stats[i] = s
+				break
+			}
+		}
+		if stats[i] == nil {
+			stats[i] = &writerStats{writer: w}
+			stats[i].writerDropsBase.Store(writerReportedDrops(w))
+		}
+	}
+	l.writerStats.Store(&writerStatsSet{writers: writers, stats: stats})
+}
+
+// statsFor returns the stats matching writers, or nil while a writer
+// change is being published.
+func (l *Logger) statsFor(writers *[]io.Writer) []*writerStats {
+	if set := l.writerStats.Load(); set != nil && set.writers == writers {
+		return set.stats
+	}
+	return nil
+}
+
+// WriterStats returns per-writer statistics in writer order: writes,
+// bytes, errors, drops and a write latency histogram.
+//
+// Example:
+//
+//	for _, s := range logger.WriterStats() {
+//	    if s.ConsecutiveErrors > 10 {
+//	        alert("log writer %d failing: %v", s.Index, s.LastError)
+//	    }
+//	}
+func (l *Logger) WriterStats() []WriterStats {
+	if l == nil {
+		return nil
+	}
+	set := l.writerStats.Load()
+	if set == nil {
+		return nil
+	}
+	result := make([]WriterStats, len(set.stats))
+	for i, s := range set.stats {
+		result[i] = s.snapshot(i)
+	}
+	return result
+}
+
+// ResetWriterStats clears the statistics of all writers.
+func (l *Logger) ResetWriterStats() {
+	if l == nil {
+		return
+	}
+	if set := l.writerStats.Load(); set != nil {
+		for _, s := range set.stats {
+			s.reset()
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package dd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+)
+
+// toggleWriter fails while fail is set.
+type toggleWriter struct {
+	buf  bytes.Buffer
+	fail atomic.Bool
+}
+
+func (w *toggleWriter) Write(p []byte) (int, error) {
+	if w.fail.Load() {
+		return 0, errors.New("disk gone")
+	}
+	return w.buf.Write(p)
+}
+
+func TestWriterStatsCounts(t *testing.T) {
+	var good bytes.Buffer
+	bad := &toggleWriter{}
+	cfg := DefaultConfig()
+	cfg.Outputs = []io.Writer{&good, bad}
+	cfg.WriteErrorHandler = func(io.Writer, error) {}
+	logger := mustNewTestLogger(t, cfg)
+
+	logger.Info("one")
+	bad.fail.Store(true)
+	logger.Info("two")
+	logger.Info("three")
+
+	stats := logger.WriterStats()
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 writer stats, got %d", len(stats))
+	}
+
+	g := stats[0]
+	if g.Index != 0 || g.Writer != &good {
+		t.Errorf("first stats should describe the buffer: %+v", g)
+	}
+	if g.Writes != 3 || g.BytesWritten != int64(good.Len()) || g.Errors != 0 || g.Dropped != 0 {
+		t.Errorf("unexpected healthy writer stats: %+v", g)
+	}
+
+	b := stats[1]
+	if b.Writes != 3 || b.Errors != 2 || b.ConsecutiveErrors != 2 || b.Dropped != 2 {
+		t.Errorf("unexpected failing writer stats: %+v", b)
+	}
+	if b.LastError == nil || b.LastError.Error() != "disk gone" || b.LastErrorTime.IsZero() {
+		t.Errorf("last error not recorded: %v at %v", b.LastError, b.LastErrorTime)
+	}
+
+	bad.fail.Store(false)
+	logger.Info("four")
+	if b := logger.WriterStats()[1]; b.ConsecutiveErrors != 0 || b.Errors != 2 {
+		t.Errorf("success should reset only ConsecutiveErrors: %+v", b)
+	}
+}
+
+func TestWriterStatsLatencyBuckets(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Outputs = []io.Writer{&buf}
+	cfg.WriteErrorHandler = func(io.Writer, error) {}
+	logger := mustNewTestLogger(t, cfg)
+
+	for range 5 {
+		logger.Info("entry")
+	}
+
+	s := logger.WriterStats()[0]
+	if len(s.LatencyBuckets) != len(writerLatencyBounds)+1 {
+		t.Fatalf("unexpected bucket count: %d", len(s.LatencyBuckets))
+	}
+	var total int64
+	for i, b := range s.LatencyBuckets {
+		total += b.Count
+		if i > 0 && b.UpperBound <= s.LatencyBuckets[i-1].UpperBound {
+			t.Errorf("bucket bounds not increasing at %d", i)
+		}
+	}
+	if total != s.Writes {
+		t.Errorf("buckets should sum to writes: %d != %d", total, s.Writes)
+	}
+	if s.MaxLatency <= 0 {
+		t.Errorf("max latency not recorded: %v", s.MaxLatency)
+	}
+}
+
+func TestWriterStatsShortWriteIsDropped(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Outputs = []io.Writer{&partialWriter{}}
+	cfg.WriteErrorHandler = func(io.Writer, error) {}
+	logger := mustNewTestLogger(t, cfg)
+	logger.Info("a message long enough to be cut in half")
+
+	s := logger.WriterStats()[0]
+	if s.Dropped != 1 || s.Errors != 0 {
+		t.Errorf("short write should count as a drop, not an error: %+v", s)
+	}
+}
+
+func TestResetWriterStats(t *testing.T) {
+	bad := &toggleWriter{}
+	bad.fail.Store(true)
+	cfg := DefaultConfig()
+	cfg.Outputs = []io.Writer{bad}
+	cfg.WriteErrorHandler = func(io.Writer, error) {}
+	logger := mustNewTestLogger(t, cfg)
+	logger.Info("x")
+
+	logger.ResetWriterStats()
+	s := logger.WriterStats()[0]
+	if s.Writes != 0 || s.Errors != 0 || s.Dropped != 0 || s.LastError != nil || s.MaxLatency != 0 {
+		t.Errorf("stats not reset: %+v", s)
+	}
+	for _, b := range s.LatencyBuckets {
+		if b.Count != 0 {
+			t.Errorf("bucket not reset: %+v", b)
+		}
+	}
+}
+
+func TestWriterStatsSurviveWriterChanges(t *testing.T) {
+	var first, second bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Outputs = []io.Writer{&first}
+	cfg.WriteErrorHandler = func(io.Writer, error) {}
+	logger := mustNewTestLogger(t, cfg)
+	logger.Info("before")
+
+	if err := logger.AddWriter(&second); err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("after")
+
+	stats := logger.WriterStats()
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 writer stats, got %d", len(stats))
+	}
+	if stats[0].Writes != 2 || stats[1].Writes != 1 {
+		t.Errorf("counts should carry over for retained writers: %d, %d", stats[0].Writes, stats[1].Writes)
+	}
+
+	if err := logger.RemoveWriter(&first); err != nil {
+		t.Fatal(err)
+	}
+	stats = logger.WriterStats()
+	if len(stats) != 1 || stats[0].Writer != &second || stats[0].Writes != 1 {
+		t.Errorf("unexpected stats after removal: %+v", stats)
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/cybergodev/dd/zstd
+
+go 1.25.0
+
+require (
+	github.com/cybergodev/dd v1.2.3-0.20261017005637-bbec0c6746a2
+	github.com/klauspost/compress v1.18.0
+)
+
+replace github.com/cybergodev/dd => ../
@@ -0,0 +1,61 @@
+// Package zstd registers zstd compression for dd's rotated log files.
+//
+// Importing it makes dd.CompressionZstd available to
+// FileWriterConfig.CompressionAlgorithm and lets dd.OpenLogFile read ".zst"
+// backups.
+//
+// Example:
+//
+//	import _ "github.com/cybergodev/dd/zstd"
+//
+//	fw, _ := dd.NewFileWriter("logs/app.log", dd.FileWriterConfig{
+//	    CompressionAlgorithm: dd.CompressionZstd,
+//	    CompressionLevel:     3,
+//	})
+package zstd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cybergodev/dd"
+	kzstd "github.com/klauspost/compress/zstd"
+)
+
+// Extension is appended to zstd-compressed backups.
+const Extension = ".zst"
+
+// Compressor is the dd.Compressor registered for dd.CompressionZstd.
+// Levels follow the zstd command line (1 fastest to 22 smallest); 0 uses
+// the default, level 3.
+var Compressor = dd.Compressor{
+	Extension: Extension,
+	NewWriter: newWriter,
+	NewReader: newReader,
+}
+
+func init() {
+	if err := dd.RegisterCompressor(dd.CompressionZstd, Compressor); err != nil {
+		panic(err)
+	}
+}
+
+func newWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	if level == 0 {
+		level = 3
+	}
+	if level < 1 || level > 22 {
+		return nil, fmt.Errorf("zstd level %d out of range [1, 22]", level)
+	}
+	return kzstd.NewWriter(w,
+		kzstd.WithEncoderLevel(kzstd.EncoderLevelFromZstd(level)),
+		kzstd.WithEncoderConcurrency(1))
+}
+
+func newReader(r io.Reader) (io.ReadCloser, error) {
+	d, err := kzstd.NewReader(r, kzstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return d.IOReadCloser(), nil
+}
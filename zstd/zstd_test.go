@@ -0,0 +1,73 @@
+package zstd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cybergodev/dd"
+)
+
+func TestFileWriterZstd(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	fw, err := dd.NewFileWriter(path, dd.FileWriterConfig{
+		MaxSizeMB:            1,
+		CompressionAlgorithm: dd.CompressionZstd,
+		CompressionLevel:     19,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	line := []byte("[2024-01-02T03:04:05Z   INFO] request served\n")
+	chunk := bytes.Repeat(line, 600*1024/len(line))
+	for range 2 {
+		if _, err := fw.Write(chunk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := fw.CompressionStats()
+	if stats.Algorithm != dd.CompressionZstd || stats.Completed != 1 || stats.BytesOut >= stats.BytesIn {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	backup := filepath.Join(dir, "app_log_1.log"+Extension)
+	if _, err := os.Stat(backup); err != nil {
+		t.Fatalf("zstd backup missing: %v", err)
+	}
+	r, err := dd.OpenLogFile(backup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	n := 0
+	for r.Next() {
+		if r.Record().Message != "request served" {
+			t.Fatalf("unexpected record: %+v", r.Record())
+		}
+		n++
+	}
+	if r.Err() != nil || n != len(chunk)/len(line) {
+		t.Errorf("read %d records, err %v", n, r.Err())
+	}
+}
+
+func TestZstdInvalidLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	for _, level := range []int{-1, 23} {
+		_, err := dd.NewFileWriter(path, dd.FileWriterConfig{
+			CompressionAlgorithm: dd.CompressionZstd,
+			CompressionLevel:     level,
+		})
+		if !errors.Is(err, dd.ErrConfigValidation) {
+			t.Errorf("level %d: expected ErrConfigValidation, got %v", level, err)
+		}
+	}
+}